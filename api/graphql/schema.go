@@ -0,0 +1,8 @@
+// Package graphql embeds the schema of the GraphQL endpoint, so the server
+// doesn't depend on the working directory.
+package graphql
+
+import _ "embed"
+
+//go:embed schema.graphqls
+var Schema string
@@ -0,0 +1,640 @@
+// Protobuf contract of the gRPC server, served on GRPC_PORT next to the HTTP
+// API. It mirrors domain.Airport and the sync operations of
+// service.ServiceInterface. Regenerate the Go code in aviationweatherv1 with:
+//
+//	protoc --go_out=. --go_opt=module=aviation-weather \
+//	  --go-grpc_out=. --go-grpc_opt=module=aviation-weather \
+//	  api/proto/aviation_weather.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: api/proto/aviation_weather.proto
+
+package aviationweatherv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Airport struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SiteNumber   string                 `protobuf:"bytes,1,opt,name=site_number,json=siteNumber,proto3" json:"site_number,omitempty"`
+	FacilityName string                 `protobuf:"bytes,2,opt,name=facility_name,json=facilityName,proto3" json:"facility_name,omitempty"`
+	FaaIdent     string                 `protobuf:"bytes,3,opt,name=faa_ident,json=faaIdent,proto3" json:"faa_ident,omitempty"`
+	IcaoIdent    string                 `protobuf:"bytes,4,opt,name=icao_ident,json=icaoIdent,proto3" json:"icao_ident,omitempty"`
+	State        string                 `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	StateFull    string                 `protobuf:"bytes,6,opt,name=state_full,json=stateFull,proto3" json:"state_full,omitempty"`
+	County       string                 `protobuf:"bytes,7,opt,name=county,proto3" json:"county,omitempty"`
+	City         string                 `protobuf:"bytes,8,opt,name=city,proto3" json:"city,omitempty"`
+	Ownership    string                 `protobuf:"bytes,9,opt,name=ownership,proto3" json:"ownership,omitempty"`
+	Use          string                 `protobuf:"bytes,10,opt,name=use,proto3" json:"use,omitempty"`
+	Manager      string                 `protobuf:"bytes,11,opt,name=manager,proto3" json:"manager,omitempty"`
+	ManagerPhone string                 `protobuf:"bytes,12,opt,name=manager_phone,json=managerPhone,proto3" json:"manager_phone,omitempty"`
+	// Position in decimal degrees, unset when unknown
+	Latitude      *float64 `protobuf:"fixed64,13,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude     *float64 `protobuf:"fixed64,14,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	Status        string   `protobuf:"bytes,15,opt,name=status,proto3" json:"status,omitempty"`
+	Weather       string   `protobuf:"bytes,16,opt,name=weather,proto3" json:"weather,omitempty"`
+	ElevationFt   *float64 `protobuf:"fixed64,17,opt,name=elevation_ft,json=elevationFt,proto3,oneof" json:"elevation_ft,omitempty"`
+	Timezone      string   `protobuf:"bytes,18,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	FuelTypes     []string `protobuf:"bytes,19,rep,name=fuel_types,json=fuelTypes,proto3" json:"fuel_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Airport) Reset() {
+	*x = Airport{}
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Airport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Airport) ProtoMessage() {}
+
+func (x *Airport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Airport.ProtoReflect.Descriptor instead.
+func (*Airport) Descriptor() ([]byte, []int) {
+	return file_api_proto_aviation_weather_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Airport) GetSiteNumber() string {
+	if x != nil {
+		return x.SiteNumber
+	}
+	return ""
+}
+
+func (x *Airport) GetFacilityName() string {
+	if x != nil {
+		return x.FacilityName
+	}
+	return ""
+}
+
+func (x *Airport) GetFaaIdent() string {
+	if x != nil {
+		return x.FaaIdent
+	}
+	return ""
+}
+
+func (x *Airport) GetIcaoIdent() string {
+	if x != nil {
+		return x.IcaoIdent
+	}
+	return ""
+}
+
+func (x *Airport) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Airport) GetStateFull() string {
+	if x != nil {
+		return x.StateFull
+	}
+	return ""
+}
+
+func (x *Airport) GetCounty() string {
+	if x != nil {
+		return x.County
+	}
+	return ""
+}
+
+func (x *Airport) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Airport) GetOwnership() string {
+	if x != nil {
+		return x.Ownership
+	}
+	return ""
+}
+
+func (x *Airport) GetUse() string {
+	if x != nil {
+		return x.Use
+	}
+	return ""
+}
+
+func (x *Airport) GetManager() string {
+	if x != nil {
+		return x.Manager
+	}
+	return ""
+}
+
+func (x *Airport) GetManagerPhone() string {
+	if x != nil {
+		return x.ManagerPhone
+	}
+	return ""
+}
+
+func (x *Airport) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *Airport) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *Airport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Airport) GetWeather() string {
+	if x != nil {
+		return x.Weather
+	}
+	return ""
+}
+
+func (x *Airport) GetElevationFt() float64 {
+	if x != nil && x.ElevationFt != nil {
+		return *x.ElevationFt
+	}
+	return 0
+}
+
+func (x *Airport) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Airport) GetFuelTypes() []string {
+	if x != nil {
+		return x.FuelTypes
+	}
+	return nil
+}
+
+type GetAirportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FaaIdent      string                 `protobuf:"bytes,1,opt,name=faa_ident,json=faaIdent,proto3" json:"faa_ident,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAirportRequest) Reset() {
+	*x = GetAirportRequest{}
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAirportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAirportRequest) ProtoMessage() {}
+
+func (x *GetAirportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAirportRequest.ProtoReflect.Descriptor instead.
+func (*GetAirportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_aviation_weather_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetAirportRequest) GetFaaIdent() string {
+	if x != nil {
+		return x.FaaIdent
+	}
+	return ""
+}
+
+type ListAirportsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAirportsRequest) Reset() {
+	*x = ListAirportsRequest{}
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAirportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAirportsRequest) ProtoMessage() {}
+
+func (x *ListAirportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAirportsRequest.ProtoReflect.Descriptor instead.
+func (*ListAirportsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_aviation_weather_proto_rawDescGZIP(), []int{2}
+}
+
+type ListAirportsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Airports      []*Airport             `protobuf:"bytes,1,rep,name=airports,proto3" json:"airports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAirportsResponse) Reset() {
+	*x = ListAirportsResponse{}
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAirportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAirportsResponse) ProtoMessage() {}
+
+func (x *ListAirportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAirportsResponse.ProtoReflect.Descriptor instead.
+func (*ListAirportsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_aviation_weather_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListAirportsResponse) GetAirports() []*Airport {
+	if x != nil {
+		return x.Airports
+	}
+	return nil
+}
+
+type SyncAirportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FaaIdent      string                 `protobuf:"bytes,1,opt,name=faa_ident,json=faaIdent,proto3" json:"faa_ident,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncAirportRequest) Reset() {
+	*x = SyncAirportRequest{}
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncAirportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncAirportRequest) ProtoMessage() {}
+
+func (x *SyncAirportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncAirportRequest.ProtoReflect.Descriptor instead.
+func (*SyncAirportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_aviation_weather_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SyncAirportRequest) GetFaaIdent() string {
+	if x != nil {
+		return x.FaaIdent
+	}
+	return ""
+}
+
+type SyncAirportResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Airport *Airport               `protobuf:"bytes,1,opt,name=airport,proto3" json:"airport,omitempty"`
+	// JSON names of the fields the sync changed
+	ChangedFields []string `protobuf:"bytes,2,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncAirportResponse) Reset() {
+	*x = SyncAirportResponse{}
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncAirportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncAirportResponse) ProtoMessage() {}
+
+func (x *SyncAirportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncAirportResponse.ProtoReflect.Descriptor instead.
+func (*SyncAirportResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_aviation_weather_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SyncAirportResponse) GetAirport() *Airport {
+	if x != nil {
+		return x.Airport
+	}
+	return nil
+}
+
+func (x *SyncAirportResponse) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+type SyncAllAirportsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncAllAirportsRequest) Reset() {
+	*x = SyncAllAirportsRequest{}
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncAllAirportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncAllAirportsRequest) ProtoMessage() {}
+
+func (x *SyncAllAirportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncAllAirportsRequest.ProtoReflect.Descriptor instead.
+func (*SyncAllAirportsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_aviation_weather_proto_rawDescGZIP(), []int{6}
+}
+
+type SyncAllAirportsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       int32                  `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncAllAirportsResponse) Reset() {
+	*x = SyncAllAirportsResponse{}
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncAllAirportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncAllAirportsResponse) ProtoMessage() {}
+
+func (x *SyncAllAirportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_aviation_weather_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncAllAirportsResponse.ProtoReflect.Descriptor instead.
+func (*SyncAllAirportsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_aviation_weather_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SyncAllAirportsResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+var File_api_proto_aviation_weather_proto protoreflect.FileDescriptor
+
+const file_api_proto_aviation_weather_proto_rawDesc = "" +
+	"\n" +
+	" api/proto/aviation_weather.proto\x12\x12aviationweather.v1\"\xe0\x04\n" +
+	"\aAirport\x12\x1f\n" +
+	"\vsite_number\x18\x01 \x01(\tR\n" +
+	"siteNumber\x12#\n" +
+	"\rfacility_name\x18\x02 \x01(\tR\ffacilityName\x12\x1b\n" +
+	"\tfaa_ident\x18\x03 \x01(\tR\bfaaIdent\x12\x1d\n" +
+	"\n" +
+	"icao_ident\x18\x04 \x01(\tR\ticaoIdent\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\x12\x1d\n" +
+	"\n" +
+	"state_full\x18\x06 \x01(\tR\tstateFull\x12\x16\n" +
+	"\x06county\x18\a \x01(\tR\x06county\x12\x12\n" +
+	"\x04city\x18\b \x01(\tR\x04city\x12\x1c\n" +
+	"\townership\x18\t \x01(\tR\townership\x12\x10\n" +
+	"\x03use\x18\n" +
+	" \x01(\tR\x03use\x12\x18\n" +
+	"\amanager\x18\v \x01(\tR\amanager\x12#\n" +
+	"\rmanager_phone\x18\f \x01(\tR\fmanagerPhone\x12\x1f\n" +
+	"\blatitude\x18\r \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x0e \x01(\x01H\x01R\tlongitude\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x0f \x01(\tR\x06status\x12\x18\n" +
+	"\aweather\x18\x10 \x01(\tR\aweather\x12&\n" +
+	"\felevation_ft\x18\x11 \x01(\x01H\x02R\velevationFt\x88\x01\x01\x12\x1a\n" +
+	"\btimezone\x18\x12 \x01(\tR\btimezone\x12\x1d\n" +
+	"\n" +
+	"fuel_types\x18\x13 \x03(\tR\tfuelTypesB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitudeB\x0f\n" +
+	"\r_elevation_ft\"0\n" +
+	"\x11GetAirportRequest\x12\x1b\n" +
+	"\tfaa_ident\x18\x01 \x01(\tR\bfaaIdent\"\x15\n" +
+	"\x13ListAirportsRequest\"O\n" +
+	"\x14ListAirportsResponse\x127\n" +
+	"\bairports\x18\x01 \x03(\v2\x1b.aviationweather.v1.AirportR\bairports\"1\n" +
+	"\x12SyncAirportRequest\x12\x1b\n" +
+	"\tfaa_ident\x18\x01 \x01(\tR\bfaaIdent\"s\n" +
+	"\x13SyncAirportResponse\x125\n" +
+	"\aairport\x18\x01 \x01(\v2\x1b.aviationweather.v1.AirportR\aairport\x12%\n" +
+	"\x0echanged_fields\x18\x02 \x03(\tR\rchangedFields\"\x18\n" +
+	"\x16SyncAllAirportsRequest\"3\n" +
+	"\x17SyncAllAirportsResponse\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x05R\aupdated2\x91\x03\n" +
+	"\x0eAirportService\x12P\n" +
+	"\n" +
+	"GetAirport\x12%.aviationweather.v1.GetAirportRequest\x1a\x1b.aviationweather.v1.Airport\x12a\n" +
+	"\fListAirports\x12'.aviationweather.v1.ListAirportsRequest\x1a(.aviationweather.v1.ListAirportsResponse\x12^\n" +
+	"\vSyncAirport\x12&.aviationweather.v1.SyncAirportRequest\x1a'.aviationweather.v1.SyncAirportResponse\x12j\n" +
+	"\x0fSyncAllAirports\x12*.aviationweather.v1.SyncAllAirportsRequest\x1a+.aviationweather.v1.SyncAllAirportsResponseB.Z,aviation-weather/api/proto/aviationweatherv1b\x06proto3"
+
+var (
+	file_api_proto_aviation_weather_proto_rawDescOnce sync.Once
+	file_api_proto_aviation_weather_proto_rawDescData []byte
+)
+
+func file_api_proto_aviation_weather_proto_rawDescGZIP() []byte {
+	file_api_proto_aviation_weather_proto_rawDescOnce.Do(func() {
+		file_api_proto_aviation_weather_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_aviation_weather_proto_rawDesc), len(file_api_proto_aviation_weather_proto_rawDesc)))
+	})
+	return file_api_proto_aviation_weather_proto_rawDescData
+}
+
+var file_api_proto_aviation_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_proto_aviation_weather_proto_goTypes = []any{
+	(*Airport)(nil),                 // 0: aviationweather.v1.Airport
+	(*GetAirportRequest)(nil),       // 1: aviationweather.v1.GetAirportRequest
+	(*ListAirportsRequest)(nil),     // 2: aviationweather.v1.ListAirportsRequest
+	(*ListAirportsResponse)(nil),    // 3: aviationweather.v1.ListAirportsResponse
+	(*SyncAirportRequest)(nil),      // 4: aviationweather.v1.SyncAirportRequest
+	(*SyncAirportResponse)(nil),     // 5: aviationweather.v1.SyncAirportResponse
+	(*SyncAllAirportsRequest)(nil),  // 6: aviationweather.v1.SyncAllAirportsRequest
+	(*SyncAllAirportsResponse)(nil), // 7: aviationweather.v1.SyncAllAirportsResponse
+}
+var file_api_proto_aviation_weather_proto_depIdxs = []int32{
+	0, // 0: aviationweather.v1.ListAirportsResponse.airports:type_name -> aviationweather.v1.Airport
+	0, // 1: aviationweather.v1.SyncAirportResponse.airport:type_name -> aviationweather.v1.Airport
+	1, // 2: aviationweather.v1.AirportService.GetAirport:input_type -> aviationweather.v1.GetAirportRequest
+	2, // 3: aviationweather.v1.AirportService.ListAirports:input_type -> aviationweather.v1.ListAirportsRequest
+	4, // 4: aviationweather.v1.AirportService.SyncAirport:input_type -> aviationweather.v1.SyncAirportRequest
+	6, // 5: aviationweather.v1.AirportService.SyncAllAirports:input_type -> aviationweather.v1.SyncAllAirportsRequest
+	0, // 6: aviationweather.v1.AirportService.GetAirport:output_type -> aviationweather.v1.Airport
+	3, // 7: aviationweather.v1.AirportService.ListAirports:output_type -> aviationweather.v1.ListAirportsResponse
+	5, // 8: aviationweather.v1.AirportService.SyncAirport:output_type -> aviationweather.v1.SyncAirportResponse
+	7, // 9: aviationweather.v1.AirportService.SyncAllAirports:output_type -> aviationweather.v1.SyncAllAirportsResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_aviation_weather_proto_init() }
+func file_api_proto_aviation_weather_proto_init() {
+	if File_api_proto_aviation_weather_proto != nil {
+		return
+	}
+	file_api_proto_aviation_weather_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_aviation_weather_proto_rawDesc), len(file_api_proto_aviation_weather_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_aviation_weather_proto_goTypes,
+		DependencyIndexes: file_api_proto_aviation_weather_proto_depIdxs,
+		MessageInfos:      file_api_proto_aviation_weather_proto_msgTypes,
+	}.Build()
+	File_api_proto_aviation_weather_proto = out.File
+	file_api_proto_aviation_weather_proto_goTypes = nil
+	file_api_proto_aviation_weather_proto_depIdxs = nil
+}
@@ -0,0 +1,243 @@
+// Protobuf contract of the gRPC server, served on GRPC_PORT next to the HTTP
+// API. It mirrors domain.Airport and the sync operations of
+// service.ServiceInterface. Regenerate the Go code in aviationweatherv1 with:
+//
+//	protoc --go_out=. --go_opt=module=aviation-weather \
+//	  --go-grpc_out=. --go-grpc_opt=module=aviation-weather \
+//	  api/proto/aviation_weather.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: api/proto/aviation_weather.proto
+
+package aviationweatherv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AirportService_GetAirport_FullMethodName      = "/aviationweather.v1.AirportService/GetAirport"
+	AirportService_ListAirports_FullMethodName    = "/aviationweather.v1.AirportService/ListAirports"
+	AirportService_SyncAirport_FullMethodName     = "/aviationweather.v1.AirportService/SyncAirport"
+	AirportService_SyncAllAirports_FullMethodName = "/aviationweather.v1.AirportService/SyncAllAirports"
+)
+
+// AirportServiceClient is the client API for AirportService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AirportServiceClient interface {
+	GetAirport(ctx context.Context, in *GetAirportRequest, opts ...grpc.CallOption) (*Airport, error)
+	ListAirports(ctx context.Context, in *ListAirportsRequest, opts ...grpc.CallOption) (*ListAirportsResponse, error)
+	SyncAirport(ctx context.Context, in *SyncAirportRequest, opts ...grpc.CallOption) (*SyncAirportResponse, error)
+	SyncAllAirports(ctx context.Context, in *SyncAllAirportsRequest, opts ...grpc.CallOption) (*SyncAllAirportsResponse, error)
+}
+
+type airportServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAirportServiceClient(cc grpc.ClientConnInterface) AirportServiceClient {
+	return &airportServiceClient{cc}
+}
+
+func (c *airportServiceClient) GetAirport(ctx context.Context, in *GetAirportRequest, opts ...grpc.CallOption) (*Airport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Airport)
+	err := c.cc.Invoke(ctx, AirportService_GetAirport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *airportServiceClient) ListAirports(ctx context.Context, in *ListAirportsRequest, opts ...grpc.CallOption) (*ListAirportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAirportsResponse)
+	err := c.cc.Invoke(ctx, AirportService_ListAirports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *airportServiceClient) SyncAirport(ctx context.Context, in *SyncAirportRequest, opts ...grpc.CallOption) (*SyncAirportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncAirportResponse)
+	err := c.cc.Invoke(ctx, AirportService_SyncAirport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *airportServiceClient) SyncAllAirports(ctx context.Context, in *SyncAllAirportsRequest, opts ...grpc.CallOption) (*SyncAllAirportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncAllAirportsResponse)
+	err := c.cc.Invoke(ctx, AirportService_SyncAllAirports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AirportServiceServer is the server API for AirportService service.
+// All implementations must embed UnimplementedAirportServiceServer
+// for forward compatibility.
+type AirportServiceServer interface {
+	GetAirport(context.Context, *GetAirportRequest) (*Airport, error)
+	ListAirports(context.Context, *ListAirportsRequest) (*ListAirportsResponse, error)
+	SyncAirport(context.Context, *SyncAirportRequest) (*SyncAirportResponse, error)
+	SyncAllAirports(context.Context, *SyncAllAirportsRequest) (*SyncAllAirportsResponse, error)
+	mustEmbedUnimplementedAirportServiceServer()
+}
+
+// UnimplementedAirportServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAirportServiceServer struct{}
+
+func (UnimplementedAirportServiceServer) GetAirport(context.Context, *GetAirportRequest) (*Airport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAirport not implemented")
+}
+func (UnimplementedAirportServiceServer) ListAirports(context.Context, *ListAirportsRequest) (*ListAirportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAirports not implemented")
+}
+func (UnimplementedAirportServiceServer) SyncAirport(context.Context, *SyncAirportRequest) (*SyncAirportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncAirport not implemented")
+}
+func (UnimplementedAirportServiceServer) SyncAllAirports(context.Context, *SyncAllAirportsRequest) (*SyncAllAirportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncAllAirports not implemented")
+}
+func (UnimplementedAirportServiceServer) mustEmbedUnimplementedAirportServiceServer() {}
+func (UnimplementedAirportServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeAirportServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AirportServiceServer will
+// result in compilation errors.
+type UnsafeAirportServiceServer interface {
+	mustEmbedUnimplementedAirportServiceServer()
+}
+
+func RegisterAirportServiceServer(s grpc.ServiceRegistrar, srv AirportServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAirportServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AirportService_ServiceDesc, srv)
+}
+
+func _AirportService_GetAirport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAirportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AirportServiceServer).GetAirport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AirportService_GetAirport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AirportServiceServer).GetAirport(ctx, req.(*GetAirportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AirportService_ListAirports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAirportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AirportServiceServer).ListAirports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AirportService_ListAirports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AirportServiceServer).ListAirports(ctx, req.(*ListAirportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AirportService_SyncAirport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncAirportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AirportServiceServer).SyncAirport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AirportService_SyncAirport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AirportServiceServer).SyncAirport(ctx, req.(*SyncAirportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AirportService_SyncAllAirports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncAllAirportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AirportServiceServer).SyncAllAirports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AirportService_SyncAllAirports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AirportServiceServer).SyncAllAirports(ctx, req.(*SyncAllAirportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AirportService_ServiceDesc is the grpc.ServiceDesc for AirportService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AirportService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "aviationweather.v1.AirportService",
+	HandlerType: (*AirportServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAirport",
+			Handler:    _AirportService_GetAirport_Handler,
+		},
+		{
+			MethodName: "ListAirports",
+			Handler:    _AirportService_ListAirports_Handler,
+		},
+		{
+			MethodName: "SyncAirport",
+			Handler:    _AirportService_SyncAirport_Handler,
+		},
+		{
+			MethodName: "SyncAllAirports",
+			Handler:    _AirportService_SyncAllAirports_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/aviation_weather.proto",
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"aviation-weather/pkg/client"
+)
+
+// Terminal escapes: cursor home and clear screen, then the colors of the
+// flight categories as on aviation charts.
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	resetColor  = "\x1b[0m"
+)
+
+var categoryColors = map[string]string{
+	"VFR":  "\x1b[32m", // green
+	"MVFR": "\x1b[34m", // blue
+	"IFR":  "\x1b[31m", // red
+	"LIFR": "\x1b[35m", // magenta
+}
+
+// row is a watched airport as last fetched.
+type row struct {
+	name    string
+	weather *client.AirportWeather
+	err     error
+	deleted bool
+}
+
+// board is the state on screen.
+type board struct {
+	url     string
+	color   bool
+	faas    []string
+	rows    map[string]*row
+	status  string
+	updated time.Time
+}
+
+func newBoard(url string, faas []string, color bool) *board {
+	b := &board{url: url, color: color, faas: faas, rows: make(map[string]*row, len(faas)), status: "connecting"}
+	for _, faa := range faas {
+		b.rows[faa] = &row{}
+	}
+	return b
+}
+
+// render draws the whole board in one write, so it doesn't flicker.
+func (b *board) render(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString(clearScreen)
+	fmt.Fprintf(&buf, "Aviation Weather  %s  stream: %s  updated %s\n\n", b.url, b.status, formatClock(b.updated))
+	fmt.Fprintf(&buf, "%-5s %-30s %-4s %-20s %-9s %-6s %-6s %s\n", "FAA", "NAME", "CAT", "WEATHER", "WIND KT", "VIS SM", "TEMP C", "OBSERVED")
+	for _, faa := range b.faas {
+		r := b.rows[faa]
+		switch {
+		case r.deleted:
+			fmt.Fprintf(&buf, "%-5s %-30.30s deleted\n", faa, r.name)
+			continue
+		case r.err != nil:
+			fmt.Fprintf(&buf, "%-5s %-30.30s error: %v\n", faa, r.name, r.err)
+			continue
+		case r.weather == nil:
+			fmt.Fprintf(&buf, "%-5s %-30.30s loading\n", faa, r.name)
+			continue
+		}
+
+		weather := r.weather
+		wind, visibility, temperature, observed := "-", "-", "-", "-"
+		if obs := weather.Observation; obs != nil {
+			wind = fmt.Sprintf("%03d@%g", obs.WindDirDeg, obs.WindSpeedKt)
+			if obs.WindGustKt > 0 {
+				wind += fmt.Sprintf("G%g", obs.WindGustKt)
+			}
+			visibility = strconv.FormatFloat(obs.VisibilitySM, 'f', -1, 64)
+			temperature = strconv.FormatFloat(obs.TemperatureC, 'f', 1, 64)
+			if !obs.ObservedAt.IsZero() {
+				observed = formatClock(obs.ObservedAt)
+			}
+		}
+		fmt.Fprintf(&buf, "%-5s %-30.30s %s %-20.20s %-9s %-6s %-6s %s\n",
+			faa, r.name, b.category(weather.FlightCategory), weather.Condition, wind, visibility, temperature, observed)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// category pads a flight category to its column, in its color.
+func (b *board) category(category string) string {
+	padded := fmt.Sprintf("%-4s", category)
+	if category == "" {
+		padded = "-   "
+	}
+	color, ok := categoryColors[category]
+	if !b.color || !ok {
+		return padded
+	}
+	return color + padded + resetColor
+}
+
+func formatClock(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("15:04:05")
+}
@@ -0,0 +1,194 @@
+// Command awboard is a terminal weather board of watched airports for ops
+// rooms and dispatch desks:
+//
+//	awboard DEN JFK LAX ORD
+//
+// Each airport shows its flight category in color, updated as soon as the API
+// streams a change of it, and every -refresh in case a change was missed.
+// Ctrl+C quits.
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"aviation-weather/pkg/client"
+)
+
+// reconnectDelay is how long awboard waits before opening a lost change
+// stream again.
+const reconnectDelay = 5 * time.Second
+
+// streamEvent is news from the change stream: a change of one airport, or a
+// new state of the stream itself.
+type streamEvent struct {
+	change *client.Change
+	status string
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("awboard", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: awboard [flags] FAA...")
+		flags.PrintDefaults()
+	}
+	baseURL := flags.String("url", cmp.Or(os.Getenv("AWCTL_URL"), "http://localhost:8080"), "Base URL of the API (AWCTL_URL)")
+	apiKey := flags.String("api-key", os.Getenv("AWCTL_API_KEY"), "API key sent as X-API-Key (AWCTL_API_KEY)")
+	refresh := flags.Duration("refresh", 5*time.Minute, "Fetch every airport again this often, 0 never")
+	noColor := flags.Bool("no-color", os.Getenv("NO_COLOR") != "", "Don't color the flight categories (NO_COLOR)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	var faas []string
+	seen := map[string]bool{}
+	for _, arg := range flags.Args() {
+		for _, faa := range strings.Split(arg, ",") {
+			faa = strings.ToUpper(strings.TrimSpace(faa))
+			if faa != "" && !seen[faa] {
+				seen[faa] = true
+				faas = append(faas, faa)
+			}
+		}
+	}
+
+	c := client.New(*baseURL, client.WithAPIKey(*apiKey))
+	b := newBoard(*baseURL, faas, !*noColor)
+	events := make(chan streamEvent)
+	go watch(ctx, c, events)
+
+	var tick <-chan time.Time
+	if *refresh > 0 {
+		ticker := time.NewTicker(*refresh)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	// The stream going live fetches every airport first
+	for {
+		if err := b.render(stdout); err != nil {
+			fmt.Fprintf(stderr, "awboard: %v\n", err)
+			return 1
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(stdout, resetColor)
+			return 0
+		case <-tick:
+			fetchAll(ctx, c, b)
+		case event := <-events:
+			if event.status != "" {
+				b.status = event.status
+				// Changes may have been missed while the stream was down
+				if event.status == "live" {
+					fetchAll(ctx, c, b)
+				}
+				continue
+			}
+			if r, ok := b.rows[event.change.Faa]; ok {
+				if event.change.Op == client.ChangeDelete {
+					r.deleted = true
+				} else {
+					fetchAirport(ctx, c, b, event.change.Faa)
+				}
+				b.updated = time.Now()
+			}
+		}
+	}
+}
+
+// watch follows the change stream until ctx is done, opening it again
+// whenever it is lost.
+func watch(ctx context.Context, c *client.Client, events chan<- streamEvent) {
+	send := func(event streamEvent) error {
+		select {
+		case events <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for {
+		if send(streamEvent{status: "live"}) != nil {
+			return
+		}
+		err := c.WatchChanges(ctx, func(change client.Change) error {
+			return send(streamEvent{change: &change})
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		status := "lost, reconnecting"
+		if err != nil {
+			status = fmt.Sprintf("lost (%v), reconnecting", err)
+		}
+		if send(streamEvent{status: status}) != nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// fetchAll fetches the names and weather of every watched airport.
+func fetchAll(ctx context.Context, c *client.Client, b *board) {
+	airports, err := c.GetAirports(ctx, b.faas)
+	found := make(map[string]bool, len(airports))
+	for _, airport := range airports {
+		found[airport.Faa] = true
+		if r, ok := b.rows[airport.Faa]; ok {
+			r.name = airport.FacilityName
+		}
+	}
+	for _, faa := range b.faas {
+		if err == nil && !found[faa] {
+			b.rows[faa].err = errors.New("airport not found")
+			continue
+		}
+		fetchAirport(ctx, c, b, faa)
+	}
+	b.updated = time.Now()
+}
+
+// fetchAirport fetches the weather of one airport as of its last sync.
+func fetchAirport(ctx context.Context, c *client.Client, b *board, faa string) {
+	r := b.rows[faa]
+	weather, err := c.AirportWeather(ctx, faa, false)
+	switch {
+	case client.IsNotFound(err):
+		r.deleted = true
+	case err != nil:
+		if ctx.Err() == nil {
+			r.err = err
+		}
+	default:
+		r.weather, r.err, r.deleted = weather, nil, false
+	}
+}
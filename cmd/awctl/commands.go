@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"aviation-weather/pkg/client"
+)
+
+var airportHeader = []string{"FAA", "ICAO", "NAME", "CITY", "STATE", "STATUS", "WEATHER", "LAST SYNCED"}
+
+func airportRow(airport client.Airport) []string {
+	return []string{
+		airport.Faa, airport.Icao, airport.FacilityName, airport.City, airport.StateCode,
+		airport.AirportStatus, airport.Weather, formatTime(airport.LastSyncedAt),
+	}
+}
+
+func airportRows(airports []client.Airport) [][]string {
+	rows := make([][]string, len(airports))
+	for i, airport := range airports {
+		rows[i] = airportRow(airport)
+	}
+	return rows
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func upper(faas []string) []string {
+	out := make([]string, len(faas))
+	for i, faa := range faas {
+		out[i] = strings.ToUpper(faa)
+	}
+	return out
+}
+
+// airportGet shows one airport, or several in one batched request.
+func (a *app) airportGet(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("airport get", flag.ContinueOnError)
+	if err := parse(flags, args, 1, -1); err != nil {
+		return err
+	}
+	faas := upper(flags.Args())
+
+	if len(faas) == 1 {
+		airport, err := a.client.GetAirport(ctx, faas[0])
+		if err != nil {
+			return err
+		}
+		return a.print(airport, airportHeader, [][]string{airportRow(*airport)})
+	}
+	airports, err := a.client.GetAirports(ctx, faas)
+	if err != nil {
+		return err
+	}
+	return a.print(airports, airportHeader, airportRows(airports))
+}
+
+// airportWeather shows the weather of an airport.
+func (a *app) airportWeather(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("airport weather", flag.ContinueOnError)
+	refresh := flags.Bool("refresh", false, "Fetch it live from the providers")
+	if err := parse(flags, args, 1, 1); err != nil {
+		return err
+	}
+
+	weather, err := a.client.AirportWeather(ctx, strings.ToUpper(flags.Arg(0)), *refresh)
+	if err != nil {
+		return err
+	}
+	row := []string{weather.Faa, weather.Condition, weather.FlightCategory, "-", "-", "-", formatTime(weather.LastSyncedAt)}
+	if obs := weather.Observation; obs != nil {
+		row[3] = strconv.FormatFloat(obs.TemperatureC, 'f', 1, 64)
+		row[4] = fmt.Sprintf("%03d@%g", obs.WindDirDeg, obs.WindSpeedKt)
+		row[5] = strconv.FormatFloat(obs.VisibilitySM, 'f', -1, 64)
+	}
+	header := []string{"FAA", "WEATHER", "CATEGORY", "TEMP C", "WIND KT", "VIS SM", "LAST SYNCED"}
+	return a.print(weather, header, [][]string{row})
+}
+
+// airportDelete deletes an airport.
+func (a *app) airportDelete(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("airport delete", flag.ContinueOnError)
+	if err := parse(flags, args, 1, 1); err != nil {
+		return err
+	}
+
+	faa := strings.ToUpper(flags.Arg(0))
+	if err := a.client.DeleteAirport(ctx, faa); err != nil {
+		return err
+	}
+	return a.message("deleted", faa, "Deleted "+faa)
+}
+
+// airportsList lists every airport, or those selling a fuel type.
+func (a *app) airportsList(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("airports list", flag.ContinueOnError)
+	sort := flags.String("sort", "", `Sort order such as "state,-facility_name"`)
+	fuel := flags.String("fuel", "", `Fuel type the airports sell, such as "100LL" or "JetA"`)
+	if err := parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	airports, err := a.client.ListAirports(ctx, client.ListOptions{Sort: *sort, Fuel: *fuel})
+	if err != nil {
+		return err
+	}
+	return a.print(airports, airportHeader, airportRows(airports))
+}
+
+// airportsExport writes every airport to stdout. CSV, KML and Excel files are
+// streamed by the API as is; JSON is the full list, whatever -o says.
+func (a *app) airportsExport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("airports export", flag.ContinueOnError)
+	format := flags.String("format", "csv", "csv, kml, xlsx or json")
+	if err := parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	switch *format {
+	case client.FormatCSV, client.FormatKML, client.FormatXLSX:
+		return a.client.ExportAirports(ctx, *format, a.stdout)
+	case "json":
+		airports, err := a.client.ListAirports(ctx, client.ListOptions{})
+		if err != nil {
+			return err
+		}
+		a.json = true
+		return a.print(airports, nil, nil)
+	default:
+		return usageError(fmt.Sprintf("airports export: unknown format %q, want csv, kml, xlsx or json", *format))
+	}
+}
+
+// sync syncs the airports listed, those of a state, or every airport.
+func (a *app) sync(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("sync", flag.ContinueOnError)
+	state := flags.String("state", "", "Sync the airports of this state")
+	if err := parse(flags, args, 0, -1); err != nil {
+		return err
+	}
+
+	faas := upper(flags.Args())
+	if len(faas) == 1 && *state == "" {
+		result, err := a.client.SyncAirport(ctx, faas[0])
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, len(result.Changes))
+		for i, change := range result.Changes {
+			rows[i] = []string{change.Field, change.Old, change.New}
+		}
+		if len(rows) == 0 && !a.json {
+			return a.message("", nil, faas[0]+" is up to date")
+		}
+		return a.print(result, []string{"FIELD", "OLD", "NEW"}, rows)
+	}
+
+	var updated int
+	var err error
+	switch {
+	case len(faas) > 0 && *state != "":
+		return usageError("sync: give either FAA codes or -state, not both")
+	case len(faas) > 0:
+		updated, err = a.client.SyncAirports(ctx, faas)
+	case *state != "":
+		updated, err = a.client.SyncState(ctx, strings.ToUpper(*state))
+	default:
+		updated, err = a.client.SyncAll(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	return a.message("synced", updated, fmt.Sprintf("%d airports synced", updated))
+}
+
+// syncRetryFailed syncs again the airports whose last sync failed.
+func (a *app) syncRetryFailed(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("sync retry-failed", flag.ContinueOnError)
+	if err := parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	updated, err := a.client.RetryFailed(ctx)
+	if err != nil {
+		return err
+	}
+	return a.message("synced", updated, fmt.Sprintf("%d airports synced", updated))
+}
+
+// stats counts the airports, by state first.
+func (a *app) stats(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	if err := parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	stats, err := a.client.Stats(ctx)
+	if err != nil {
+		return err
+	}
+	rows := [][]string{{"total", "", strconv.Itoa(stats.TotalAirports)}}
+	if stats.StaleAfter != "" {
+		rows = append(rows, []string{"stale", "older than " + stats.StaleAfter, strconv.Itoa(stats.StaleWeather)})
+	}
+	for _, group := range []struct {
+		name   string
+		counts map[string]int
+	}{
+		{"state", stats.ByState},
+		{"ownership", stats.ByOwnership},
+		{"flight category", stats.ByFlightCategory},
+	} {
+		for _, key := range slices.Sorted(maps.Keys(group.counts)) {
+			rows = append(rows, []string{group.name, key, strconv.Itoa(group.counts[key])})
+		}
+	}
+	return a.print(stats, []string{"BY", "VALUE", "AIRPORTS"}, rows)
+}
@@ -0,0 +1,201 @@
+// Command awctl calls the Aviation Weather API from a shell, through the Go
+// client of pkg/client:
+//
+//	awctl airport get JFK
+//	awctl sync --state TX
+//	awctl airports export --format csv > airports.csv
+//
+// Results print as a table, or as JSON with -o json for scripts.
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"aviation-weather/pkg/client"
+)
+
+const usage = `Usage: awctl [flags] <command> [args]
+
+Commands:
+  airport get FAA...                 Show airports
+  airport weather [-refresh] FAA     Show the weather of an airport
+  airport delete FAA                 Delete an airport (admin)
+  airports list [-sort FIELDS] [-fuel TYPE]
+                                     List every airport, or those selling a fuel type
+  airports export [-format F]        Write every airport to stdout as csv, kml, xlsx or json
+  sync [-state XX] [FAA...]          Sync the given airports, a state or all (admin)
+  sync retry-failed                  Sync again the airports whose sync failed (admin)
+  stats                              Count the airports
+
+Flags:
+`
+
+// app is one run of awctl.
+type app struct {
+	client *client.Client
+	json   bool
+	stdout io.Writer
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run parses the global flags, then runs the command, returning the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("awctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	baseURL := flags.String("url", cmp.Or(os.Getenv("AWCTL_URL"), "http://localhost:8080"), "Base URL of the API (AWCTL_URL)")
+	apiKey := flags.String("api-key", os.Getenv("AWCTL_API_KEY"), "API key sent as X-API-Key (AWCTL_API_KEY)")
+	adminToken := flags.String("admin-token", os.Getenv("AWCTL_ADMIN_TOKEN"), "Bearer token of the admin endpoints (AWCTL_ADMIN_TOKEN)")
+	output := flags.String("o", "table", "Output format: table or json")
+	timeout := flags.Duration("timeout", 5*time.Minute, "Give up after this long, 0 waits forever")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "awctl: unknown output format %q, want table or json\n", *output)
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	// No client timeout, the context deadline bounds every call, full syncs
+	// included
+	a := &app{
+		client: client.New(*baseURL,
+			client.WithAPIKey(*apiKey),
+			client.WithAdminToken(*adminToken),
+			client.WithHTTPClient(&http.Client{}),
+		),
+		json:   *output == "json",
+		stdout: stdout,
+	}
+
+	err := a.dispatch(ctx, flags.Arg(0), flags.Args()[1:])
+	var usageErr usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "awctl: %v\n\n", err)
+		flags.Usage()
+		return 2
+	default:
+		fmt.Fprintf(stderr, "awctl: %v\n", err)
+		return 1
+	}
+}
+
+// usageError is a command line awctl doesn't understand.
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+func (a *app) dispatch(ctx context.Context, command string, args []string) error {
+	sub := func() (string, []string) {
+		if len(args) == 0 {
+			return "", nil
+		}
+		return args[0], args[1:]
+	}
+
+	switch command {
+	case "airport":
+		switch name, rest := sub(); name {
+		case "get":
+			return a.airportGet(ctx, rest)
+		case "weather":
+			return a.airportWeather(ctx, rest)
+		case "delete":
+			return a.airportDelete(ctx, rest)
+		default:
+			return usageError(fmt.Sprintf("unknown airport command %q", name))
+		}
+	case "airports":
+		switch name, rest := sub(); name {
+		case "list":
+			return a.airportsList(ctx, rest)
+		case "export":
+			return a.airportsExport(ctx, rest)
+		default:
+			return usageError(fmt.Sprintf("unknown airports command %q", name))
+		}
+	case "sync":
+		if name, rest := sub(); name == "retry-failed" {
+			return a.syncRetryFailed(ctx, rest)
+		}
+		return a.sync(ctx, args)
+	case "stats":
+		return a.stats(ctx, args)
+	default:
+		return usageError(fmt.Sprintf("unknown command %q", command))
+	}
+}
+
+// parse parses the flags of a command, and checks its count of arguments
+// falls between minArgs and maxArgs, -1 for no limit.
+func parse(flags *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		return usageError(fmt.Sprintf("%s: %v", flags.Name(), err))
+	}
+	if n := flags.NArg(); n < minArgs || (maxArgs >= 0 && n > maxArgs) {
+		return usageError(fmt.Sprintf("%s: wrong number of arguments", flags.Name()))
+	}
+	return nil
+}
+
+// print writes v as indented JSON, or as a table with the given header and
+// rows.
+func (a *app) print(v any, header []string, rows [][]string) error {
+	if a.json {
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// message writes a line for humans, or {"key": value} with -o json.
+func (a *app) message(key string, value any, text string) error {
+	if a.json {
+		return a.print(map[string]any{key: value}, nil, nil)
+	}
+	_, err := fmt.Fprintln(a.stdout, text)
+	return err
+}
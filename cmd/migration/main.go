@@ -1,33 +1,44 @@
 package main
 
 import (
-	"database/sql"
+	"context"
 	"flag"
 	"fmt"
+	"io/fs"
 	"log"
-	"os"
 
 	"aviation-weather/config"
-
-	_ "github.com/lib/pq"
+	"aviation-weather/internal/database"
+	"aviation-weather/internal/migrate"
+	"aviation-weather/migrations"
 )
 
 func main() {
 	// Parse flags
-	up := flag.Bool("up", false, "Run migration up (create)")                                  // docker-compose exec app go run cmd/migration/main.go --up
-	down := flag.Bool("down", false, "Run migration down (drop)")                              // docker-compose exec app go run cmd/migration/main.go --down
-	fill := flag.Bool("fill", false, "Fill table with top US airports via SQL (implies --up)") // docker-compose exec app go run cmd/migration/main.go --fill
+	up := flag.Bool("up", false, "Apply every pending migration")                                                          // docker-compose exec app go run cmd/migration/main.go --up
+	down := flag.Bool("down", false, "Revert every migration (drop)")                                                      // docker-compose exec app go run cmd/migration/main.go --down
+	to := flag.Int("to", -1, "Migrate up or down to version N, 0 reverts everything")                                      // docker-compose exec app go run cmd/migration/main.go --to 5
+	status := flag.Bool("status", false, "List migrations and whether they are applied")                                   // docker-compose exec app go run cmd/migration/main.go --status
+	fill := flag.Bool("fill", false, "Fill table with top US airports via SQL (implies --up)")                             // docker-compose exec app go run cmd/migration/main.go --fill
+	fillNASRPath := flag.String("fill-nasr", "", "Load every US airport from a FAA NASR CSV extract (implies --up)")       // docker-compose exec app go run cmd/migration/main.go --fill-nasr path/to/APT_CSV.zip
+	fillNavaidsPath := flag.String("fill-navaids", "", "Replace the navaids with a FAA NAVAID CSV extract (implies --up)") // docker-compose exec app go run cmd/migration/main.go --fill-navaids path/to/NAV_CSV.zip
 	flag.Parse()
 
 	// VERIFY TABLE: docker-compose exec postgres psql -U postgres -d aviation_weather -c "\d airport"
 
 	// Default flag behavior
+	seed := *fill && *fillNASRPath == ""
+	if *fillNASRPath != "" || *fillNavaidsPath != "" {
+		*fill = true
+	}
 	switch {
-	case *fill && *down:
-		log.Fatal("error: cannot use --fill with --down")
-	case *up && *down:
-		log.Fatal("error: cannot specify both --up and --down")
-	case !*up && !*down && !*fill:
+	case *fill && (*down || *to >= 0):
+		log.Fatal("error: --fill needs every migration, it cannot be used with --down or --to")
+	case *status && (*up || *down || *to >= 0 || *fill):
+		log.Fatal("error: --status cannot be combined with other flags")
+	case *up && *down, *to >= 0 && (*up || *down):
+		log.Fatal("error: specify only one of --up, --down and --to")
+	case !*up && !*down && !*fill && !*status && *to < 0:
 		*up = true
 		log.Println("No flags provided; defaulting to --up")
 	}
@@ -37,44 +48,76 @@ func main() {
 		log.Println("--fill requested: Will run --up then seed data")
 	}
 
-	// Load config and connect
-	cfg := config.Load()
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=UTC",
-		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName,
-	)
+	loaded, err := migrate.Load(migrations.FS)
+	if err != nil {
+		log.Fatalf("error loading migrations: %v", err)
+	}
 
-	db, err := sql.Open("postgres", dsn)
+	// Load config and connect
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	db, closeDB, err := database.Open(context.Background(), cfg)
 	if err != nil {
 		log.Fatalf("db connection error: %v", err)
 	}
-	defer db.Close()
+	defer closeDB()
+	log.Println("Connected to PostgreSQL")
 
-	if err := db.Ping(); err != nil {
-		log.Fatalf("db ping error: %v", err)
+	migrator := migrate.NewMigrator(db, loaded)
+	ctx := context.Background()
+
+	switch {
+	case *status:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("error reading migration status: %v", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			}
+			if s.Modified {
+				state += " (modified since)"
+			}
+			fmt.Printf("%04d %-30s %s\n", s.Version, s.Name, state)
+		}
+		return
+	case *down:
+		*to = 0
+	case *up:
+		*to = migrator.Latest()
 	}
-	log.Println("Connected to PostgreSQL")
 
-	// Run migration
-	runMigration := func(filename, action string) {
-		sqlBytes, err := os.ReadFile(filename)
+	if err := migrator.To(ctx, *to); err != nil {
+		log.Fatalf("migration error: %v", err)
+	}
+	log.Printf("Schema is at version %d", *to)
+
+	if *fillNASRPath != "" {
+		if err := fillNASR(db, cfg, *fillNASRPath); err != nil {
+			log.Fatalf("error loading NASR data: %v", err)
+		}
+		log.Println("Fill (NASR) completed")
+	} else if seed {
+		sqlBytes, err := fs.ReadFile(migrations.FS, "fill_airport.sql")
 		if err != nil {
-			log.Fatalf("error reading %s: %v", filename, err)
+			log.Fatalf("error reading fill_airport.sql: %v", err)
 		}
-		if _, err := db.Exec(string(sqlBytes)); err != nil {
-			log.Fatalf("error executing %s: %v", filename, err)
+		ctx, cancel := database.QueryContext(ctx, cfg)
+		defer cancel()
+		if _, err := db.ExecContext(ctx, string(sqlBytes)); err != nil {
+			log.Fatalf("error executing fill_airport.sql: %v", err)
 		}
-		log.Printf("%s completed: %s", action, filename)
+		log.Println("Fill (seed data) completed")
 	}
 
-	switch {
-	case *down:
-		runMigration("migrations/drop_airport.sql", "Migration down")
-		return // Early exit after down—no fill possible
-	case *up:
-		runMigration("migrations/create_airport.sql", "Migration up")
-		if *fill {
-			runMigration("migrations/fill_airport.sql", "Fill (seed data)")
+	if *fillNavaidsPath != "" {
+		if err := fillNavaids(db, cfg, *fillNavaidsPath); err != nil {
+			log.Fatalf("error loading NAVAID data: %v", err)
 		}
+		log.Println("Fill (NAVAID) completed")
 	}
 }
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"archive/zip"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/nasr"
+	"aviation-weather/internal/repository"
+)
+
+// nasrChunkSize is how many airports each upsert transaction saves.
+const nasrChunkSize = 500
+
+// openNASR opens the NASR CSV extract at path: its directory, one of its
+// files such as APT_BASE.csv, or the zip as downloaded. The NAVAID extract
+// opens the same way.
+func openNASR(path string) (fs.FS, func() error, error) {
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		r, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, r.Close, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.IsDir() {
+		path = filepath.Dir(path)
+	}
+	return os.DirFS(path), func() error { return nil }, nil
+}
+
+// fillNASR loads every airport of the NASR extract at path with its runways
+// and frequencies. Airports already stored are updated, keeping their weather
+// and the fields edited by hand.
+func fillNASR(db *sql.DB, cfg *config.Config, path string) error {
+	fsys, closeFS, err := openNASR(path)
+	if err != nil {
+		return fmt.Errorf("failed to open NASR data: %w", err)
+	}
+	defer closeFS()
+
+	ds, err := nasr.Read(fsys)
+	if err != nil {
+		return err
+	}
+	log.Printf("Read %d airports from NASR", len(ds.Airports))
+
+	repo := repository.NewRepository(db, cfg.DBQueryTimeout)
+	for start := 0; start < len(ds.Airports); start += nasrChunkSize {
+		chunk := ds.Airports[start:min(start+nasrChunkSize, len(ds.Airports))]
+		if err := repo.UpsertAirports(chunk); err != nil {
+			return err
+		}
+		log.Printf("Saved %d/%d airports", start+len(chunk), len(ds.Airports))
+	}
+
+	for faa, runways := range ds.Runways {
+		if err := repo.ReplaceRunways(faa, runways); err != nil {
+			return err
+		}
+	}
+	for faa, frequencies := range ds.Frequencies {
+		if err := repo.ReplaceFrequencies(faa, frequencies); err != nil {
+			return err
+		}
+	}
+	log.Printf("Saved runways of %d and frequencies of %d airports", len(ds.Runways), len(ds.Frequencies))
+	return nil
+}
+
+// fillNavaids replaces the stored navaids with those of the NAVAID extract at
+// path.
+func fillNavaids(db *sql.DB, cfg *config.Config, path string) error {
+	fsys, closeFS, err := openNASR(path)
+	if err != nil {
+		return fmt.Errorf("failed to open NAVAID data: %w", err)
+	}
+	defer closeFS()
+
+	navaids, err := nasr.ReadNavaids(fsys)
+	if err != nil {
+		return err
+	}
+
+	repo := repository.NewRepository(db, cfg.DBQueryTimeout)
+	if err := repo.ReplaceNavaids(navaids); err != nil {
+		return err
+	}
+	log.Printf("Saved %d navaids", len(navaids))
+	return nil
+}
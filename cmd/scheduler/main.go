@@ -2,63 +2,80 @@ package main
 
 import (
 	"aviation-weather/config"
+	"aviation-weather/internal/database"
 	"aviation-weather/internal/repository"
+	"aviation-weather/internal/scheduler"
 	"aviation-weather/internal/service"
-	"database/sql"
-	"fmt"
+	"aviation-weather/internal/utils"
+	"context"
 	"log"
-
-	_ "github.com/lib/pq"
-	"github.com/robfig/cron/v3"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 )
 
 func main() {
-	// Load configuration
-	cfg := config.Load()
+	// Cancelled on shutdown so a running sync stops early
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Connect to PostgreSQL
-	db, err := sql.Open(
-		"postgres",
-		fmt.Sprintf(
-			"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=UTC",
-			cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName,
-		),
-	)
+	// Load configuration
+	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("failed to open DB: %v", err)
+		log.Fatal(err)
 	}
-	defer db.Close()
+	utils.SetLogLevel(cfg.LogLevel)
 
-	if err := db.Ping(); err != nil {
-		log.Fatalf("failed to ping DB: %v", err)
+	// Connect to PostgreSQL
+	db, closeDB, err := database.Open(ctx, cfg)
+	if err != nil {
+		log.Fatal(err)
 	}
+	defer closeDB()
 	log.Println("Connected to PostgreSQL")
 
 	// Initialize app layers
-	repo := repository.NewRepository(db)
+	repo := repository.NewRepository(db, cfg.DBQueryTimeout)
 	svc := service.NewService(repo, cfg)
 
-	// Initialize cron scheduler
-	cronScheduler := cron.New()
+	// Workers running in the background stop with ctx, and are waited for
+	// before the database is closed
+	var workers sync.WaitGroup
+	background := func(work func(ctx context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			work(ctx)
+		}()
+	}
 
-	// Schedule SyncAllAirports to run every 12 hours
-	_, err = cronScheduler.AddFunc("0 0,12 * * *", func() {
-		log.Println("Starting SyncAllAirports...")
-		updated, err := svc.SyncAllAirports()
-		if err != nil {
-			log.Printf("Error in SyncAllAirports: %v", err)
-			return
-		}
-		log.Printf("SyncAllAirports completed, updated %d airports", updated)
-	})
-	if err != nil {
-		log.Fatalf("Failed to schedule SyncAllAirports: %v", err)
+	// Pick up rotated secrets
+	if store := cfg.Secrets(); store != nil && cfg.SecretsRefreshInterval > 0 {
+		background(func(ctx context.Context) { store.Watch(ctx, cfg.SecretsRefreshInterval) })
 	}
 
-	// Start the cron scheduler
-	cronScheduler.Start()
-	log.Println("Scheduler started, running SyncAllAirports every 12 hours")
+	// Deliver the events saved with airport updates
+	background(svc.DispatchOutbox)
+
+	// Run the queued syncs
+	background(svc.RunJobWorkers)
+
+	// Run the jobs of the config, overridden by the scheduler_jobs table
+	runner := scheduler.NewRunner(service.Scheduled(ctx), cfg, db, svc)
+
+	// Apply edits to .env that are safe to make while running, moving jobs
+	// to their new schedules without waiting for a restart
+	config.Watch(cfg, func(updated *config.Config) {
+		utils.SetLogLevel(updated.LogLevel)
+		svc.ApplyConfig(updated)
+		runner.ApplyConfig(updated)
+	})
 
-	// Keep the application running
-	select {}
+	// Keep the application running until shutdown, then wait for a running
+	// job and the workers
+	<-ctx.Done()
+	log.Println("Shutting down scheduler...")
+	runner.Stop()
+	workers.Wait()
 }
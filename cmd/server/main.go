@@ -1,48 +1,195 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"aviation-weather/config"
+	"aviation-weather/internal/database"
+	"aviation-weather/internal/grpcserver"
 	"aviation-weather/internal/handler"
+	"aviation-weather/internal/migrate"
 	"aviation-weather/internal/repository"
+	"aviation-weather/internal/scheduler"
 	"aviation-weather/internal/service"
+	"aviation-weather/internal/utils"
+	"aviation-weather/migrations"
 
-	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	enableScheduler := flag.Bool("enable-scheduler", false, "Run the scheduler's jobs in the server too, like SCHEDULER_ENABLED=true") // go run cmd/server/main.go --enable-scheduler
+	flag.Parse()
+
+	if err := run(*enableScheduler); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run serves until SIGINT or SIGTERM, then drains the servers and stops the
+// in-process scheduler and background workers before returning, so the
+// database is closed cleanly.
+func run(enableScheduler bool) error {
+	// Cancelled on shutdown to stop serving
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Load configuration
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	utils.SetLogLevel(cfg.LogLevel)
+	if enableScheduler {
+		cfg.SchedulerEnabled = true
+	}
 
 	// Connect to PostgreSQL
-	db, err := sql.Open(
-		"postgres",
-		fmt.Sprintf(
-			"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=UTC",
-			cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName,
-		),
-	)
+	db, closeDB, err := database.Open(ctx, cfg)
 	if err != nil {
-		log.Fatalf("failed to open DB: %v", err)
+		return err
 	}
-	defer db.Close()
+	defer closeDB()
+	log.Println("Connected to PostgreSQL")
 
-	if err := db.Ping(); err != nil {
-		log.Fatalf("failed to ping DB: %v", err)
+	// Bring the schema up to date before serving
+	if cfg.DBAutoMigrate {
+		loaded, err := migrate.Load(migrations.FS)
+		if err != nil {
+			return fmt.Errorf("failed to load migrations: %w", err)
+		}
+		migrator := migrate.NewMigrator(db, loaded)
+		if err := migrator.To(ctx, migrator.Latest()); err != nil {
+			return fmt.Errorf("failed to migrate: %w", err)
+		}
+		log.Printf("Schema is at version %d", migrator.Latest())
+	}
+
+	// Airport reads go to the replica when one is configured
+	var replica *sql.DB
+	if cfg.DBReplicaDSN != "" {
+		var closeReplica func()
+		replica, closeReplica, err = database.OpenReplica(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		defer closeReplica()
+		log.Println("Reading airports from the PostgreSQL replica")
 	}
-	log.Println("Connected to PostgreSQL")
 
 	// Initialize app layers
-	repo := repository.NewRepository(db)
+	repo := repository.NewReplicatedRepository(db, replica, cfg.DBQueryTimeout)
 	svc := service.NewService(repo, cfg)
-	h := handler.NewHandler(svc)
+	h := handler.NewHandler(svc, cfg)
+
+	// Workers running in the background stop with ctx, and are waited for
+	// before the database is closed
+	var workers sync.WaitGroup
+	background := func(work func(ctx context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			work(ctx)
+		}()
+	}
+	defer func() {
+		stop()
+		workers.Wait()
+	}()
+
+	// Pick up rotated secrets
+	if store := cfg.Secrets(); store != nil && cfg.SecretsRefreshInterval > 0 {
+		background(func(ctx context.Context) { store.Watch(ctx, cfg.SecretsRefreshInterval) })
+	}
+
+	// Run the scheduler's jobs in-process for small deployments. On shutdown
+	// a running job is cancelled and waited for before returning.
+	var runner *scheduler.Runner
+	if cfg.SchedulerEnabled {
+		runner = scheduler.NewRunner(service.Scheduled(ctx), cfg, db, svc)
+		defer func() {
+			log.Println("Shutting down scheduler...")
+			runner.Stop()
+		}()
+	}
+
+	// Apply edits to .env that are safe to make while serving
+	config.Watch(cfg, func(updated *config.Config) {
+		utils.SetLogLevel(updated.LogLevel)
+		svc.ApplyConfig(updated)
+		h.ApplyConfig(updated)
+		if runner != nil {
+			runner.ApplyConfig(updated)
+		}
+	})
+
+	// Relay airport changes by any writer to live subscribers
+	changes, err := repository.ListenAirportChanges(ctx, func() string { return database.DSN(cfg) })
+	if err != nil {
+		log.Printf("WARN: Live airport changes disabled: %v", err)
+	} else {
+		background(func(ctx context.Context) { svc.ConsumeAirportChanges(ctx, changes) })
+	}
+
+	// Deliver the events saved with airport updates
+	background(svc.DispatchOutbox)
+
+	// Run the queued syncs
+	background(svc.RunJobWorkers)
+
+	// Serve gRPC for internal consumers next to HTTP
+	var grpcSrv *grpc.Server
+	if cfg.GRPCPort != "" {
+		lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			return fmt.Errorf("failed to listen for gRPC: %w", err)
+		}
+		grpcSrv = grpcserver.NewServer(svc).Register()
+		go func() {
+			log.Printf("gRPC server starting on port %s", cfg.GRPCPort)
+			if err := grpcSrv.Serve(lis); err != nil {
+				log.Printf("WARN: gRPC server stopped: %v", err)
+			}
+		}()
+	}
 
 	// Start HTTP server
-	port := ":" + cfg.AppPort
-	log.Printf("Server starting on port %s", port)
-	log.Fatal(http.ListenAndServe(port, h.Router()))
+	srv := newServer(cfg, h.Router())
+	served := make(chan error, 1)
+	go func() { served <- serve(cfg, srv) }()
+
+	// Keep serving until shutdown, then let in-flight requests finish
+	select {
+	case err := <-served:
+		stop()
+		if grpcSrv != nil {
+			grpcSrv.Stop()
+		}
+		return err
+	case <-ctx.Done():
+	}
+	log.Println("Shutting down server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if grpcSrv != nil {
+		stopGRPC(shutdownCtx, grpcSrv)
+	}
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"net/http"
+	"time"
+
+	"aviation-weather/config"
+
+	"golang.org/x/crypto/acme/autocert"
+	"google.golang.org/grpc"
+)
+
+// shutdownTimeout bounds how long shutdown waits for in-flight requests.
+const shutdownTimeout = 30 * time.Second
+
+// newServer wraps handler in an http.Server with the configured timeouts.
+func newServer(cfg *config.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + cfg.AppPort,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+		TLSConfig:         &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+}
+
+// serve runs srv over TLS when a cert pair or autocert domains are configured,
+// else over plain HTTP. HTTP/2 is negotiated over TLS.
+func serve(cfg *config.Config, srv *http.Server) error {
+	switch {
+	case cfg.TLSCertFile != "":
+		log.Printf("Server starting on port %s with TLS", srv.Addr)
+		return srv.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+
+	case len(cfg.TLSAutocertDomains) > 0:
+		m := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.TLSAutocertDomains...),
+			Cache:      autocert.DirCache(cfg.TLSAutocertCacheDir),
+			Email:      cfg.TLSAutocertEmail,
+		}
+		srv.TLSConfig = m.TLSConfig()
+		srv.TLSConfig.MinVersion = tls.VersionTLS12
+
+		if cfg.TLSAutocertHTTPPort != "" {
+			challenges := &http.Server{
+				Addr:              ":" + cfg.TLSAutocertHTTPPort,
+				Handler:           m.HTTPHandler(nil),
+				ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+				IdleTimeout:       cfg.HTTPIdleTimeout,
+			}
+			go func() {
+				if err := challenges.ListenAndServe(); err != nil {
+					log.Printf("WARN: ACME HTTP challenges on port %s: %v", cfg.TLSAutocertHTTPPort, err)
+				}
+			}()
+		}
+
+		log.Printf("Server starting on port %s with Let's Encrypt certificates for %v", srv.Addr, cfg.TLSAutocertDomains)
+		return srv.ListenAndServeTLS("", "")
+
+	default:
+		log.Printf("Server starting on port %s", srv.Addr)
+		return srv.ListenAndServe()
+	}
+}
+
+// stopGRPC lets in-flight RPCs of srv finish, or cancels them once ctx is
+// done.
+func stopGRPC(ctx context.Context, srv *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
@@ -1,8 +1,23 @@
 package config
 
 import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"aviation-weather/internal/secrets"
+
+	"github.com/robfig/cron/v3"
 	"github.com/spf13/viper"
 )
 
@@ -14,18 +29,352 @@ type Config struct {
 	DBPassword    string
 	AppPort       string
 	WeatherAPIKey string
+
+	// Port of the gRPC server next to the HTTP one, empty disables it
+	GRPCPort string
+
+	// Connection pool, statements running longer than DBStatementTimeout are
+	// cancelled by the server, 0 leaves them unbounded
+	DBMaxConns         int
+	DBMaxIdleConns     int
+	DBMaxConnIdleTime  time.Duration
+	DBConnMaxLifetime  time.Duration
+	DBStatementTimeout time.Duration
+
+	// Apply pending migrations when the server starts
+	DBAutoMigrate bool
+
+	// Read-only replica serving airport list and lookup reads, disabled when
+	// empty. Reads fall back to the primary while it fails.
+	DBReplicaDSN string
+
+	// Client side bound of each query, so a hung server fails requests instead
+	// of exhausting the pool, 0 disables
+	DBQueryTimeout time.Duration
+
+	// Weather providers in fallback order: weatherapi, openweathermap, noaa
+	WeatherProviders     []string
+	OpenWeatherMapAPIKey string
+
+	// Per-client rate limiting, disabled when RateLimitRPS is 0
+	RateLimitRPS   float64
+	RateLimitBurst int
+
+	// Proxies, as IPs or CIDRs, whose X-Forwarded-For names the client; other
+	// peers are identified by their own address
+	TrustedProxies []string
+	// Header the trusted proxies set to the client address instead, X-Real-IP
+	// or True-Client-IP; empty reads X-Forwarded-For
+	ClientIPHeader string
+
+	// CORS, disabled when no origins are allowed
+	CORSAllowedOrigins []string
+	CORSAllowedMethods []string
+	CORSAllowedHeaders []string
+
+	// Skip airports synced within this window, 0 syncs every airport
+	SyncStaleAfter time.Duration
+
+	// Sync batching, chunks are handed to a pool of SyncMaxConcurrency workers
+	SyncChunkSize      int
+	SyncMaxConcurrency int
+
+	// Spread the chunks of a scheduled sync evenly over this window instead
+	// of handing them out at once, 0 disables
+	SyncSpread time.Duration
+
+	// Include closed and decommissioned airports in batch syncs, which skip
+	// them by default
+	SyncClosedAirports bool
+
+	// Syncs requested over the API are queued in the job_queue table and run
+	// by JobWorkers workers per process, 0 leaving them to other processes.
+	// Idle workers poll every JobPollInterval; a job failing on a provider is
+	// retried until it has run JobMaxAttempts times.
+	JobWorkers      int
+	JobPollInterval time.Duration
+	JobMaxAttempts  int
+
+	// Outbound request budget per provider, unlimited when RPS is 0. The
+	// weather budget applies to each weather provider separately.
+	AviationAPIRPS   float64
+	AviationAPIBurst int
+	WeatherAPIRPS    float64
+	WeatherAPIBurst  int
+
+	// "live" calls the external providers, "mock" answers them from the
+	// fixtures embedded in the binary and needs no API key. "record" calls
+	// them and writes each response to ProviderRecordingsDir, where "replay"
+	// answers them from, again without keys.
+	ProviderMode          string
+	ProviderRecordingsDir string
+
+	// Outbound HTTP client. Requests go through HTTPProxyURL, or the proxy
+	// of the HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment when it is empty,
+	// and trust the PEM certificates of HTTPCAFile on top of the system
+	// ones. A provider timeout of 0 takes HTTPTimeout, itself 10s when 0.
+	HTTPProxyURL            string
+	HTTPCAFile              string
+	HTTPTimeout             time.Duration
+	AviationAPITimeout      time.Duration
+	WeatherAPITimeout       time.Duration
+	HTTPMaxIdleConns        int
+	HTTPMaxIdleConnsPerHost int
+
+	// Provider circuit breakers, disabled when the threshold is 0
+	BreakerFailureThreshold int
+	BreakerCooldown         time.Duration
+
+	// How long fetched weather is reused per city, 0 disables the cache
+	WeatherCacheTTL time.Duration
+
+	// How long airport reads are cached, 0 disables the cache
+	AirportCacheTTL time.Duration
+
+	// Cache backend, "memory" or "redis" to share it across replicas
+	CacheBackend  string
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+
+	// Webhook deliveries, disabled when WebhookMaxAttempts is 0. Retries wait
+	// WebhookBackoff, doubling after each attempt.
+	WebhookMaxAttempts int
+	WebhookBackoff     time.Duration
+
+	// SMTP relay of alert emails, disabled when SMTPHost is empty. Slack
+	// notifications need no setup beyond the webhook URL of each rule.
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	// Message broker of airport and sync events, disabled when BrokerURL is
+	// empty. Events go to "<BrokerSubjectPrefix>.<event>".
+	BrokerURL           string
+	BrokerSubjectPrefix string
+
+	// How often the outbox of airport events is polled for delivery. Events
+	// of airport updates are saved with them and delivered from there when
+	// webhooks or the broker are enabled; 0 sends them directly instead.
+	OutboxPollInterval time.Duration
+
+	// Cron spec of the scheduled sync of every airport
+	SyncSchedule string
+
+	// Sync every airport as soon as the scheduler starts instead of waiting
+	// for SyncSchedule
+	SyncOnStart bool
+
+	// Run the scheduler's jobs inside the server as well, for deployments
+	// without a separate scheduler
+	SchedulerEnabled bool
+
+	// Delay each scheduled job run by a random duration up to this, so
+	// environments sharing provider keys don't all start at once
+	SchedulerJitter time.Duration
+
+	// Elect one of the scheduler replicas sharing the database to run jobs,
+	// checking or trying its lock every SchedulerLeaderInterval
+	SchedulerLeaderElection bool
+	SchedulerLeaderInterval time.Duration
+
+	// Cron spec of the weather digests sent to subscribers; empty disables
+	// them
+	DigestSchedule string
+
+	// Cron spec of the sync of airports not synced within SyncStaleAfter (12h
+	// when unset) only; empty disables it
+	StaleSyncSchedule string
+
+	// Cron spec of the deletion of alerts, webhook deliveries and change feed
+	// entries older than LogRetention; empty disables it
+	PruneSchedule string
+	LogRetention  time.Duration
+
+	// Cron spec of the retry of the airports whose last sync failed; empty
+	// disables it. A failed airport waits SyncRetryBackoff before its first
+	// retry, doubling with every failure in a row.
+	RetryFailedSchedule string
+	SyncRetryBackoff    time.Duration
+
+	// Cron spec of the maintenance of the monthly weather_history partitions,
+	// creating the upcoming ones and dropping those older than
+	// WeatherHistoryRetention (0 keeps every month); empty disables it
+	HistoryPartitionSchedule string
+	WeatherHistoryRetention  time.Duration
+
+	// Cron spec of the sync of the SIGMETs and AIRMETs in effect; empty
+	// disables it
+	AdvisorySchedule string
+
+	// Cron spec of the sync of airport charts, which fetches only the
+	// airports not yet synced for the current AIRAC cycle; empty disables it
+	ChartSchedule string
+
+	// Cron spec of the refresh of runways, frequencies and charts; empty
+	// leaves it to AIRACRefresh
+	StaticRefreshSchedule string
+
+	// Refresh static data as soon as each AIRAC cycle becomes effective
+	AIRACRefresh bool
+
+	// Cron spec of the refresh of the NOTAMs of the airports on watchlists
+	// and subscriptions from the FAA NOTAM API, which needs the client ID and
+	// secret of a registered application; empty disables it
+	NOTAMSchedule     string
+	NOTAMClientID     string
+	NOTAMClientSecret string
+
+	// How often the scheduler re-reads the scheduler_jobs table, which
+	// overrides the schedules above by job name; 0 reads it at start only
+	SchedulerRefreshInterval time.Duration
+
+	// Lowest level logged: debug, info or warn. Lines without a level prefix
+	// count as info.
+	LogLevel string
+
+	// WeatherAPIKey and DBPassword read from files (Docker or Kubernetes
+	// secrets) or a Vault key/value secret instead, files taking precedence.
+	// They are re-read every SecretsRefreshInterval, 0 reads them once.
+	WeatherAPIKeyFile      string
+	DBPasswordFile         string
+	VaultAddr              string
+	VaultToken             string
+	VaultSecretPath        string
+	SecretsRefreshInterval time.Duration
+
+	// TLS termination, plain HTTP when neither a cert pair nor autocert
+	// domains are set. Autocert gets Let's Encrypt certificates for its
+	// domains, caches them in TLSAutocertCacheDir and answers HTTP challenges,
+	// redirecting other requests to HTTPS, on TLSAutocertHTTPPort.
+	TLSCertFile         string
+	TLSKeyFile          string
+	TLSAutocertDomains  []string
+	TLSAutocertEmail    string
+	TLSAutocertCacheDir string
+	TLSAutocertHTTPPort string
+
+	// HTTP server timeouts, 0 disables. Event streams, exports and syncs of
+	// every airport are exempt from the write timeout.
+	HTTPReadHeaderTimeout time.Duration
+	HTTPReadTimeout       time.Duration
+	HTTPWriteTimeout      time.Duration
+	HTTPIdleTimeout       time.Duration
+
+	// Bearer token of the admin and /debug/pprof routes, which are hidden
+	// while it is empty
+	AdminToken string
+
+	// Per-request deadline, passed to handlers through the request context,
+	// and largest body accepted by POST and PUT /airport; 0 disables either.
+	// Event streams, exports and syncs of every airport have no deadline.
+	RequestTimeout time.Duration
+	MaxBodyBytes   int64
+
+	// gzip and brotli level of JSON, XML, CSV and HTML responses, 1 (fastest)
+	// to 9 (smallest); 0 disables compression
+	CompressionLevel int
+
+	// How long browsers and CDNs may reuse the airport list before
+	// revalidating it with If-Modified-Since; 0 makes them revalidate each time
+	HTTPCacheMaxAge time.Duration
+
+	secrets *secrets.Store
 }
 
-func Load() *Config {
+// Load reads the config from .env in the working directory, when there is
+// one, with environment variables taking precedence, and validates it.
+func Load() (*Config, error) {
 	viper.SetConfigName(".env")
 	viper.SetConfigType("env")
 	viper.AddConfigPath(".")
+	viper.AutomaticEnv()
+
+	viper.SetDefault("DB_PORT", "5432")
+	viper.SetDefault("APP_PORT", "8080")
+
+	viper.SetDefault("DB_MAX_CONNS", 10)
+	viper.SetDefault("DB_MAX_IDLE_CONNS", 5)
+	viper.SetDefault("DB_MAX_CONN_IDLE_TIME", "5m")
+	viper.SetDefault("DB_CONN_MAX_LIFETIME", "1h")
+	viper.SetDefault("DB_STATEMENT_TIMEOUT", "30s")
+	viper.SetDefault("DB_QUERY_TIMEOUT", "35s")
+	viper.SetDefault("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
+	viper.SetDefault("CORS_ALLOWED_HEADERS", "Content-Type,X-API-Key")
+	viper.SetDefault("WEATHER_PROVIDERS", "weatherapi")
+	viper.SetDefault("SYNC_CHUNK_SIZE", 20)
+	viper.SetDefault("SYNC_MAX_CONCURRENCY", 4)
+	viper.SetDefault("JOB_WORKERS", 2)
+	viper.SetDefault("JOB_POLL_INTERVAL", "1s")
+	viper.SetDefault("JOB_MAX_ATTEMPTS", 3)
+	viper.SetDefault("AVIATION_API_RPS", 5)
+	viper.SetDefault("AVIATION_API_BURST", 5)
+	viper.SetDefault("WEATHER_API_RPS", 5)
+	viper.SetDefault("WEATHER_API_BURST", 5)
+	viper.SetDefault("PROVIDER_MODE", "live")
+	viper.SetDefault("PROVIDER_RECORDINGS_DIR", "recordings")
+	viper.SetDefault("HTTP_TIMEOUT", "10s")
+	viper.SetDefault("HTTP_MAX_IDLE_CONNS", 100)
+	viper.SetDefault("HTTP_MAX_IDLE_CONNS_PER_HOST", 10)
+	viper.SetDefault("BREAKER_FAILURE_THRESHOLD", 5)
+	viper.SetDefault("BREAKER_COOLDOWN", "30s")
+	viper.SetDefault("WEATHER_CACHE_TTL", "10m")
+	viper.SetDefault("CACHE_BACKEND", "memory")
+	viper.SetDefault("REDIS_ADDR", "localhost:6379")
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 5)
+	viper.SetDefault("WEBHOOK_BACKOFF", "2s")
+	viper.SetDefault("SMTP_PORT", 587)
+	viper.SetDefault("SMTP_FROM", "aviation-weather@localhost")
+	viper.SetDefault("BROKER_SUBJECT_PREFIX", "aviation-weather")
+	viper.SetDefault("OUTBOX_POLL_INTERVAL", "1s")
+	viper.SetDefault("SYNC_SCHEDULE", "0 0,12 * * *")
+	viper.SetDefault("DIGEST_SCHEDULE", "0 6 * * *")
+	viper.SetDefault("LOG_RETENTION", "720h")
+	viper.SetDefault("SCHEDULER_REFRESH_INTERVAL", "1m")
+	viper.SetDefault("RETRY_FAILED_SCHEDULE", "*/15 * * * *")
+	viper.SetDefault("SYNC_RETRY_BACKOFF", "15m")
+	viper.SetDefault("HISTORY_PARTITION_SCHEDULE", "0 1 * * *")
+	viper.SetDefault("ADVISORY_SCHEDULE", "*/10 * * * *")
+	viper.SetDefault("CHART_SCHEDULE", "0 6 * * *")
+	viper.SetDefault("AIRAC_REFRESH", true)
+	viper.SetDefault("SCHEDULER_LEADER_ELECTION", true)
+	viper.SetDefault("SCHEDULER_LEADER_INTERVAL", "15s")
+	viper.SetDefault("LOG_LEVEL", "info")
+	viper.SetDefault("SECRETS_REFRESH_INTERVAL", "1m")
+	viper.SetDefault("TLS_AUTOCERT_CACHE_DIR", "autocert")
+	viper.SetDefault("TLS_AUTOCERT_HTTP_PORT", "80")
+	viper.SetDefault("HTTP_READ_HEADER_TIMEOUT", "5s")
+	viper.SetDefault("HTTP_READ_TIMEOUT", "1m")
+	viper.SetDefault("HTTP_WRITE_TIMEOUT", "1m")
+	viper.SetDefault("HTTP_IDLE_TIMEOUT", "2m")
+	viper.SetDefault("REQUEST_TIMEOUT", "30s")
+	viper.SetDefault("MAX_BODY_BYTES", 1<<20)
+	viper.SetDefault("COMPRESSION_LEVEL", 5)
+	viper.SetDefault("HTTP_CACHE_MAX_AGE", "1m")
 
 	if err := viper.ReadInConfig(); err != nil {
-		log.Fatalf("Error reading .env file: %v", err)
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("error reading .env file: %w", err)
+		}
+		log.Println("No .env file, reading config from the environment")
 	}
 
-	return &Config{
+	cfg, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.loadSecrets(context.Background()); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// build maps the settings viper holds onto a validated Config.
+func build() (*Config, error) {
+	cfg := &Config{
 		DBHost:        viper.GetString("DB_HOST"),
 		DBPort:        viper.GetString("DB_PORT"),
 		DBName:        viper.GetString("DB_NAME"),
@@ -33,5 +382,425 @@ func Load() *Config {
 		DBPassword:    viper.GetString("DB_PASSWORD"),
 		AppPort:       viper.GetString("APP_PORT"),
 		WeatherAPIKey: viper.GetString("WEATHER_API_KEY"),
+
+		GRPCPort: viper.GetString("GRPC_PORT"),
+
+		DBMaxConns:         viper.GetInt("DB_MAX_CONNS"),
+		DBMaxIdleConns:     viper.GetInt("DB_MAX_IDLE_CONNS"),
+		DBMaxConnIdleTime:  viper.GetDuration("DB_MAX_CONN_IDLE_TIME"),
+		DBConnMaxLifetime:  viper.GetDuration("DB_CONN_MAX_LIFETIME"),
+		DBStatementTimeout: viper.GetDuration("DB_STATEMENT_TIMEOUT"),
+
+		DBAutoMigrate: viper.GetBool("DB_AUTO_MIGRATE"),
+
+		DBReplicaDSN: viper.GetString("DB_REPLICA_DSN"),
+
+		DBQueryTimeout: viper.GetDuration("DB_QUERY_TIMEOUT"),
+
+		WeatherProviders:     splitList(viper.GetString("WEATHER_PROVIDERS")),
+		OpenWeatherMapAPIKey: viper.GetString("OPENWEATHERMAP_API_KEY"),
+
+		RateLimitRPS:   viper.GetFloat64("RATE_LIMIT_RPS"),
+		RateLimitBurst: viper.GetInt("RATE_LIMIT_BURST"),
+		TrustedProxies: splitList(viper.GetString("TRUSTED_PROXIES")),
+		ClientIPHeader: viper.GetString("CLIENT_IP_HEADER"),
+
+		CORSAllowedOrigins: splitList(viper.GetString("CORS_ALLOWED_ORIGINS")),
+		CORSAllowedMethods: splitList(viper.GetString("CORS_ALLOWED_METHODS")),
+		CORSAllowedHeaders: splitList(viper.GetString("CORS_ALLOWED_HEADERS")),
+
+		SyncStaleAfter: viper.GetDuration("SYNC_STALE_AFTER"),
+
+		SyncChunkSize:      viper.GetInt("SYNC_CHUNK_SIZE"),
+		SyncMaxConcurrency: viper.GetInt("SYNC_MAX_CONCURRENCY"),
+		SyncSpread:         viper.GetDuration("SYNC_SPREAD"),
+		SyncClosedAirports: viper.GetBool("SYNC_CLOSED_AIRPORTS"),
+
+		JobWorkers:      viper.GetInt("JOB_WORKERS"),
+		JobPollInterval: viper.GetDuration("JOB_POLL_INTERVAL"),
+		JobMaxAttempts:  viper.GetInt("JOB_MAX_ATTEMPTS"),
+
+		AviationAPIRPS:   viper.GetFloat64("AVIATION_API_RPS"),
+		AviationAPIBurst: viper.GetInt("AVIATION_API_BURST"),
+		WeatherAPIRPS:    viper.GetFloat64("WEATHER_API_RPS"),
+		WeatherAPIBurst:  viper.GetInt("WEATHER_API_BURST"),
+
+		ProviderMode:          strings.ToLower(viper.GetString("PROVIDER_MODE")),
+		ProviderRecordingsDir: viper.GetString("PROVIDER_RECORDINGS_DIR"),
+
+		HTTPProxyURL:            viper.GetString("HTTP_PROXY_URL"),
+		HTTPCAFile:              viper.GetString("HTTP_CA_FILE"),
+		HTTPTimeout:             viper.GetDuration("HTTP_TIMEOUT"),
+		AviationAPITimeout:      viper.GetDuration("AVIATION_API_TIMEOUT"),
+		WeatherAPITimeout:       viper.GetDuration("WEATHER_API_TIMEOUT"),
+		HTTPMaxIdleConns:        viper.GetInt("HTTP_MAX_IDLE_CONNS"),
+		HTTPMaxIdleConnsPerHost: viper.GetInt("HTTP_MAX_IDLE_CONNS_PER_HOST"),
+
+		BreakerFailureThreshold: viper.GetInt("BREAKER_FAILURE_THRESHOLD"),
+		BreakerCooldown:         viper.GetDuration("BREAKER_COOLDOWN"),
+
+		WeatherCacheTTL: viper.GetDuration("WEATHER_CACHE_TTL"),
+		AirportCacheTTL: viper.GetDuration("AIRPORT_CACHE_TTL"),
+
+		CacheBackend:  viper.GetString("CACHE_BACKEND"),
+		RedisAddr:     viper.GetString("REDIS_ADDR"),
+		RedisPassword: viper.GetString("REDIS_PASSWORD"),
+		RedisDB:       viper.GetInt("REDIS_DB"),
+
+		WebhookMaxAttempts: viper.GetInt("WEBHOOK_MAX_ATTEMPTS"),
+		WebhookBackoff:     viper.GetDuration("WEBHOOK_BACKOFF"),
+
+		SMTPHost:     viper.GetString("SMTP_HOST"),
+		SMTPPort:     viper.GetInt("SMTP_PORT"),
+		SMTPUsername: viper.GetString("SMTP_USERNAME"),
+		SMTPPassword: viper.GetString("SMTP_PASSWORD"),
+		SMTPFrom:     viper.GetString("SMTP_FROM"),
+
+		BrokerURL:           viper.GetString("BROKER_URL"),
+		BrokerSubjectPrefix: viper.GetString("BROKER_SUBJECT_PREFIX"),
+
+		OutboxPollInterval: viper.GetDuration("OUTBOX_POLL_INTERVAL"),
+
+		SyncSchedule:             viper.GetString("SYNC_SCHEDULE"),
+		SyncOnStart:              viper.GetBool("SYNC_ON_START"),
+		SchedulerEnabled:         viper.GetBool("SCHEDULER_ENABLED"),
+		SchedulerJitter:          viper.GetDuration("SCHEDULER_JITTER"),
+		SchedulerLeaderElection:  viper.GetBool("SCHEDULER_LEADER_ELECTION"),
+		SchedulerLeaderInterval:  viper.GetDuration("SCHEDULER_LEADER_INTERVAL"),
+		DigestSchedule:           viper.GetString("DIGEST_SCHEDULE"),
+		StaleSyncSchedule:        viper.GetString("STALE_SYNC_SCHEDULE"),
+		PruneSchedule:            viper.GetString("PRUNE_SCHEDULE"),
+		RetryFailedSchedule:      viper.GetString("RETRY_FAILED_SCHEDULE"),
+		SyncRetryBackoff:         viper.GetDuration("SYNC_RETRY_BACKOFF"),
+		HistoryPartitionSchedule: viper.GetString("HISTORY_PARTITION_SCHEDULE"),
+		WeatherHistoryRetention:  viper.GetDuration("WEATHER_HISTORY_RETENTION"),
+		AdvisorySchedule:         viper.GetString("ADVISORY_SCHEDULE"),
+		ChartSchedule:            viper.GetString("CHART_SCHEDULE"),
+		StaticRefreshSchedule:    viper.GetString("STATIC_REFRESH_SCHEDULE"),
+		AIRACRefresh:             viper.GetBool("AIRAC_REFRESH"),
+		NOTAMSchedule:            viper.GetString("NOTAM_SCHEDULE"),
+		NOTAMClientID:            viper.GetString("NOTAM_CLIENT_ID"),
+		NOTAMClientSecret:        viper.GetString("NOTAM_CLIENT_SECRET"),
+		LogRetention:             viper.GetDuration("LOG_RETENTION"),
+		SchedulerRefreshInterval: viper.GetDuration("SCHEDULER_REFRESH_INTERVAL"),
+		LogLevel:                 strings.ToLower(viper.GetString("LOG_LEVEL")),
+
+		WeatherAPIKeyFile:      viper.GetString("WEATHER_API_KEY_FILE"),
+		DBPasswordFile:         viper.GetString("DB_PASSWORD_FILE"),
+		VaultAddr:              viper.GetString("VAULT_ADDR"),
+		VaultToken:             viper.GetString("VAULT_TOKEN"),
+		VaultSecretPath:        viper.GetString("VAULT_SECRET_PATH"),
+		SecretsRefreshInterval: viper.GetDuration("SECRETS_REFRESH_INTERVAL"),
+
+		TLSCertFile:         viper.GetString("TLS_CERT_FILE"),
+		TLSKeyFile:          viper.GetString("TLS_KEY_FILE"),
+		TLSAutocertDomains:  splitList(viper.GetString("TLS_AUTOCERT_DOMAINS")),
+		TLSAutocertEmail:    viper.GetString("TLS_AUTOCERT_EMAIL"),
+		TLSAutocertCacheDir: viper.GetString("TLS_AUTOCERT_CACHE_DIR"),
+		TLSAutocertHTTPPort: viper.GetString("TLS_AUTOCERT_HTTP_PORT"),
+
+		HTTPReadHeaderTimeout: viper.GetDuration("HTTP_READ_HEADER_TIMEOUT"),
+		HTTPReadTimeout:       viper.GetDuration("HTTP_READ_TIMEOUT"),
+		HTTPWriteTimeout:      viper.GetDuration("HTTP_WRITE_TIMEOUT"),
+		HTTPIdleTimeout:       viper.GetDuration("HTTP_IDLE_TIMEOUT"),
+
+		AdminToken: viper.GetString("ADMIN_TOKEN"),
+
+		RequestTimeout: viper.GetDuration("REQUEST_TIMEOUT"),
+		MaxBodyBytes:   viper.GetInt64("MAX_BODY_BYTES"),
+
+		CompressionLevel: viper.GetInt("COMPRESSION_LEVEL"),
+		HTTPCacheMaxAge:  viper.GetDuration("HTTP_CACHE_MAX_AGE"),
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// weatherProviders are the names WEATHER_PROVIDERS accepts.
+var weatherProviders = map[string]bool{"weatherapi": true, "openweathermap": true, "noaa": true}
+
+// proxySchemes are the URL schemes HTTP_PROXY_URL accepts.
+var proxySchemes = map[string]bool{"http": true, "https": true, "socks5": true}
+
+// clientIPHeaders are the headers CLIENT_IP_HEADER accepts, canonicalized.
+// Empty reads X-Forwarded-For.
+var clientIPHeaders = map[string]bool{"": true, "X-Real-Ip": true, "True-Client-Ip": true}
+
+// logLevels are the names LOG_LEVEL accepts.
+var logLevels = map[string]bool{"debug": true, "info": true, "warn": true}
+
+// Validate reports every missing or invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	require := func(name, value string) {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", name))
+		}
+	}
+	port := func(name, value string) {
+		if n, err := strconv.Atoi(value); err != nil || n < 1 || n > 65535 {
+			errs = append(errs, fmt.Errorf("%s must be a port number, got %q", name, value))
+		}
+	}
+	// Secrets may also come from a file or Vault
+	requireSecret := func(name, value, file string) {
+		if file == "" && c.VaultSecretPath == "" {
+			require(name, value)
+		}
+	}
+	notNegative := func(name string, value float64) {
+		if value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", name))
+		}
+	}
+
+	require("DB_HOST", c.DBHost)
+	require("DB_NAME", c.DBName)
+	require("DB_USER", c.DBUser)
+	port("DB_PORT", c.DBPort)
+	port("APP_PORT", c.AppPort)
+	if c.GRPCPort != "" {
+		port("GRPC_PORT", c.GRPCPort)
+		if c.GRPCPort == c.AppPort {
+			errs = append(errs, errors.New("GRPC_PORT must differ from APP_PORT"))
+		}
+	}
+
+	if len(c.WeatherProviders) == 0 {
+		errs = append(errs, errors.New("WEATHER_PROVIDERS is required"))
+	}
+	switch c.ProviderMode {
+	case "live", "mock":
+	case "record", "replay":
+		require("PROVIDER_RECORDINGS_DIR", c.ProviderRecordingsDir)
+	default:
+		errs = append(errs, fmt.Errorf("PROVIDER_MODE must be live, mock, record or replay, got %q", c.ProviderMode))
+	}
+	for _, name := range c.WeatherProviders {
+		switch {
+		case !weatherProviders[name]:
+			errs = append(errs, fmt.Errorf("WEATHER_PROVIDERS has unknown provider %q", name))
+		case c.ProvidersOffline():
+			// Fixtures and recordings need no key
+		case name == "weatherapi":
+			requireSecret("WEATHER_API_KEY", c.WeatherAPIKey, c.WeatherAPIKeyFile)
+		case name == "openweathermap":
+			require("OPENWEATHERMAP_API_KEY", c.OpenWeatherMapAPIKey)
+		}
+	}
+
+	if c.CacheBackend != "memory" && c.CacheBackend != "redis" {
+		errs = append(errs, fmt.Errorf("CACHE_BACKEND must be memory or redis, got %q", c.CacheBackend))
+	}
+	if c.SyncChunkSize < 1 {
+		errs = append(errs, errors.New("SYNC_CHUNK_SIZE must be at least 1"))
+	}
+	if c.SyncMaxConcurrency < 1 {
+		errs = append(errs, errors.New("SYNC_MAX_CONCURRENCY must be at least 1"))
+	}
+	notNegative("RATE_LIMIT_RPS", c.RateLimitRPS)
+	for _, proxy := range c.TrustedProxies {
+		if _, err := ParseProxy(proxy); err != nil {
+			errs = append(errs, fmt.Errorf("TRUSTED_PROXIES must list IPs or CIDRs, got %q", proxy))
+		}
+	}
+	if !clientIPHeaders[http.CanonicalHeaderKey(c.ClientIPHeader)] {
+		errs = append(errs, fmt.Errorf("CLIENT_IP_HEADER must be X-Real-IP or True-Client-IP, got %q", c.ClientIPHeader))
+	}
+	notNegative("AVIATION_API_RPS", c.AviationAPIRPS)
+	notNegative("WEATHER_API_RPS", c.WeatherAPIRPS)
+	if c.HTTPProxyURL != "" {
+		if u, err := url.Parse(c.HTTPProxyURL); err != nil || !proxySchemes[u.Scheme] || u.Host == "" {
+			errs = append(errs, fmt.Errorf("HTTP_PROXY_URL must be an http, https or socks5 URL, got %q", c.HTTPProxyURL))
+		}
+	}
+	if c.HTTPCAFile != "" {
+		if pem, err := os.ReadFile(c.HTTPCAFile); err != nil {
+			errs = append(errs, fmt.Errorf("HTTP_CA_FILE is not readable: %w", err))
+		} else if !x509.NewCertPool().AppendCertsFromPEM(pem) {
+			errs = append(errs, errors.New("HTTP_CA_FILE holds no PEM certificate"))
+		}
+	}
+	notNegative("HTTP_TIMEOUT", float64(c.HTTPTimeout))
+	notNegative("AVIATION_API_TIMEOUT", float64(c.AviationAPITimeout))
+	notNegative("WEATHER_API_TIMEOUT", float64(c.WeatherAPITimeout))
+	notNegative("HTTP_MAX_IDLE_CONNS", float64(c.HTTPMaxIdleConns))
+	notNegative("HTTP_MAX_IDLE_CONNS_PER_HOST", float64(c.HTTPMaxIdleConnsPerHost))
+	notNegative("DB_MAX_CONNS", float64(c.DBMaxConns))
+	notNegative("DB_QUERY_TIMEOUT", float64(c.DBQueryTimeout))
+	notNegative("DB_STATEMENT_TIMEOUT", float64(c.DBStatementTimeout))
+	notNegative("SYNC_STALE_AFTER", float64(c.SyncStaleAfter))
+	notNegative("SYNC_SPREAD", float64(c.SyncSpread))
+	notNegative("SYNC_RETRY_BACKOFF", float64(c.SyncRetryBackoff))
+	notNegative("JOB_WORKERS", float64(c.JobWorkers))
+	notNegative("JOB_POLL_INTERVAL", float64(c.JobPollInterval))
+	notNegative("WEATHER_HISTORY_RETENTION", float64(c.WeatherHistoryRetention))
+	notNegative("SCHEDULER_JITTER", float64(c.SchedulerJitter))
+	if c.SchedulerLeaderElection && c.SchedulerLeaderInterval <= 0 {
+		errs = append(errs, errors.New("SCHEDULER_LEADER_INTERVAL must be positive for leader election"))
+	}
+
+	if _, err := cron.ParseStandard(c.SyncSchedule); err != nil {
+		errs = append(errs, fmt.Errorf("SYNC_SCHEDULE is not a cron spec: %w", err))
+	}
+	optionalSchedules := []struct{ name, spec string }{
+		{"DIGEST_SCHEDULE", c.DigestSchedule},
+		{"STALE_SYNC_SCHEDULE", c.StaleSyncSchedule},
+		{"PRUNE_SCHEDULE", c.PruneSchedule},
+		{"RETRY_FAILED_SCHEDULE", c.RetryFailedSchedule},
+		{"HISTORY_PARTITION_SCHEDULE", c.HistoryPartitionSchedule},
+		{"ADVISORY_SCHEDULE", c.AdvisorySchedule},
+		{"CHART_SCHEDULE", c.ChartSchedule},
+		{"STATIC_REFRESH_SCHEDULE", c.StaticRefreshSchedule},
+		{"NOTAM_SCHEDULE", c.NOTAMSchedule},
+	}
+	for _, schedule := range optionalSchedules {
+		if schedule.spec == "" {
+			continue
+		}
+		if _, err := cron.ParseStandard(schedule.spec); err != nil {
+			errs = append(errs, fmt.Errorf("%s is not a cron spec: %w", schedule.name, err))
+		}
+	}
+	if c.PruneSchedule != "" && c.LogRetention <= 0 {
+		errs = append(errs, errors.New("LOG_RETENTION must be positive to prune logs"))
+	}
+	if c.NOTAMSchedule != "" {
+		require("NOTAM_CLIENT_ID", c.NOTAMClientID)
+		require("NOTAM_CLIENT_SECRET", c.NOTAMClientSecret)
+	}
+	notNegative("SCHEDULER_REFRESH_INTERVAL", float64(c.SchedulerRefreshInterval))
+	if !logLevels[c.LogLevel] {
+		errs = append(errs, fmt.Errorf("LOG_LEVEL must be debug, info or warn, got %q", c.LogLevel))
+	}
+	if c.VaultSecretPath != "" {
+		require("VAULT_ADDR", c.VaultAddr)
+		require("VAULT_TOKEN", c.VaultToken)
+	}
+	notNegative("SECRETS_REFRESH_INTERVAL", float64(c.SecretsRefreshInterval))
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		errs = append(errs, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together"))
+	}
+	if len(c.TLSAutocertDomains) > 0 {
+		if c.TLSCertFile != "" {
+			errs = append(errs, errors.New("TLS_AUTOCERT_DOMAINS and TLS_CERT_FILE are exclusive"))
+		}
+		require("TLS_AUTOCERT_CACHE_DIR", c.TLSAutocertCacheDir)
+		if c.TLSAutocertHTTPPort != "" {
+			port("TLS_AUTOCERT_HTTP_PORT", c.TLSAutocertHTTPPort)
+		}
+	}
+	notNegative("HTTP_READ_HEADER_TIMEOUT", float64(c.HTTPReadHeaderTimeout))
+	notNegative("HTTP_READ_TIMEOUT", float64(c.HTTPReadTimeout))
+	notNegative("HTTP_WRITE_TIMEOUT", float64(c.HTTPWriteTimeout))
+	notNegative("HTTP_IDLE_TIMEOUT", float64(c.HTTPIdleTimeout))
+	notNegative("REQUEST_TIMEOUT", float64(c.RequestTimeout))
+	notNegative("MAX_BODY_BYTES", float64(c.MaxBodyBytes))
+	notNegative("HTTP_CACHE_MAX_AGE", float64(c.HTTPCacheMaxAge))
+	if c.CompressionLevel < 0 || c.CompressionLevel > 9 {
+		errs = append(errs, fmt.Errorf("COMPRESSION_LEVEL must be between 0 and 9, got %d", c.CompressionLevel))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// ProvidersOffline reports whether provider requests are answered without
+// reaching the providers, from fixtures or recordings.
+func (c *Config) ProvidersOffline() bool {
+	return c.ProviderMode == "mock" || c.ProviderMode == "replay"
+}
+
+// Names of the secrets that may come from files or Vault, which are also their
+// keys in the Vault secret.
+const (
+	SecretWeatherAPIKey = "WEATHER_API_KEY"
+	SecretDBPassword    = "DB_PASSWORD"
+)
+
+// Secrets holds the secrets read from files or Vault, nil when neither is
+// configured.
+func (c *Config) Secrets() *secrets.Store {
+	return c.secrets
+}
+
+// loadSecrets reads the secrets configured to come from files or Vault into
+// WeatherAPIKey and DBPassword.
+func (c *Config) loadSecrets(ctx context.Context) error {
+	var sources []secrets.Source
+	if c.VaultSecretPath != "" {
+		sources = append(sources, &secrets.Vault{
+			Addr:   c.VaultAddr,
+			Token:  c.VaultToken,
+			Path:   c.VaultSecretPath,
+			Client: &http.Client{Timeout: 10 * time.Second},
+		})
+	}
+	files := secrets.Files{}
+	if c.WeatherAPIKeyFile != "" {
+		files[SecretWeatherAPIKey] = c.WeatherAPIKeyFile
+	}
+	if c.DBPasswordFile != "" {
+		files[SecretDBPassword] = c.DBPasswordFile
+	}
+	if len(files) > 0 {
+		sources = append(sources, files)
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+
+	store := secrets.NewStore(sources...)
+	if err := store.Refresh(ctx); err != nil {
+		return fmt.Errorf("failed to load secrets: %w", err)
+	}
+	c.useSecrets(store)
+
+	if slices.Contains(c.WeatherProviders, "weatherapi") && c.WeatherAPIKey == "" {
+		return fmt.Errorf("invalid config: %s is empty in its file and Vault secret", SecretWeatherAPIKey)
+	}
+	return nil
+}
+
+// useSecrets takes WeatherAPIKey and DBPassword from store where it has them.
+func (c *Config) useSecrets(store *secrets.Store) {
+	c.secrets = store
+	if store == nil {
+		return
+	}
+	if value := store.Get(SecretWeatherAPIKey); value != "" {
+		c.WeatherAPIKey = value
+	}
+	if value := store.Get(SecretDBPassword); value != "" {
+		c.DBPassword = value
+	}
+}
+
+// ParseProxy parses an entry of TRUSTED_PROXIES, an IP standing for itself
+// alone or a CIDR.
+func ParseProxy(proxy string) (netip.Prefix, error) {
+	if addr, err := netip.ParseAddr(proxy); err == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(proxy)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return prefix.Masked(), nil
+}
+
+// splitList parses a comma separated value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFromEnvironment(t *testing.T) {
+	// No .env in this directory, so everything comes from the environment
+	t.Setenv("DB_HOST", "postgres")
+	t.Setenv("DB_NAME", "aviation_weather")
+	t.Setenv("DB_USER", "postgres")
+	t.Setenv("WEATHER_PROVIDERS", "noaa")
+	t.Setenv("SYNC_STALE_AFTER", "6h")
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "postgres", cfg.DBHost)
+	assert.Equal(t, "5432", cfg.DBPort, "Defaults apply")
+	assert.Equal(t, "8080", cfg.AppPort)
+	assert.Equal(t, []string{"noaa"}, cfg.WeatherProviders)
+	assert.Equal(t, "6h0m0s", cfg.SyncStaleAfter.String())
+}
+
+func TestLoadSecretsFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "weather_api_key"), []byte("key\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "db_password"), []byte("postgres\n"), 0o600))
+	t.Setenv("DB_HOST", "postgres")
+	t.Setenv("DB_NAME", "aviation_weather")
+	t.Setenv("DB_USER", "postgres")
+	t.Setenv("WEATHER_PROVIDERS", "weatherapi")
+	t.Setenv("WEATHER_API_KEY_FILE", filepath.Join(dir, "weather_api_key"))
+	t.Setenv("DB_PASSWORD_FILE", filepath.Join(dir, "db_password"))
+
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "key", cfg.WeatherAPIKey)
+	assert.Equal(t, "postgres", cfg.DBPassword)
+	if assert.NotNil(t, cfg.Secrets()) {
+		assert.Equal(t, "key", cfg.Secrets().Get(SecretWeatherAPIKey))
+	}
+
+	t.Setenv("DB_PASSWORD_FILE", filepath.Join(dir, "missing"))
+	_, err = Load()
+	assert.ErrorContains(t, err, "failed to load secrets: failed to read DB_PASSWORD")
+}
+
+func TestValidate(t *testing.T) {
+	valid := Config{
+		DBHost: "localhost", DBPort: "5432", DBName: "aviation_weather", DBUser: "postgres", AppPort: "8080",
+		WeatherProviders: []string{"weatherapi", "noaa"}, WeatherAPIKey: "key",
+		CacheBackend: "memory", SyncChunkSize: 20, SyncMaxConcurrency: 4,
+		SyncSchedule: "0 0,12 * * *", LogLevel: "info", ProviderMode: "live",
+	}
+	assert.NoError(t, valid.Validate())
+
+	grpc := valid
+	grpc.GRPCPort = "9090"
+	assert.NoError(t, grpc.Validate())
+	grpc.GRPCPort = "8080"
+	assert.EqualError(t, grpc.Validate(), "invalid config: GRPC_PORT must differ from APP_PORT")
+
+	proxies := valid
+	proxies.TrustedProxies = []string{"10.0.0.0/8", "192.168.1.10", "fd00::/8"}
+	assert.NoError(t, proxies.Validate())
+	proxies.TrustedProxies = []string{"proxy.example.com"}
+	assert.EqualError(t, proxies.Validate(), `invalid config: TRUSTED_PROXIES must list IPs or CIDRs, got "proxy.example.com"`)
+	proxies.TrustedProxies = nil
+	proxies.ClientIPHeader = "x-real-ip"
+	assert.NoError(t, proxies.Validate())
+	proxies.ClientIPHeader = "X-Forwarded-For"
+	assert.EqualError(t, proxies.Validate(), `invalid config: CLIENT_IP_HEADER must be X-Real-IP or True-Client-IP, got "X-Forwarded-For"`)
+
+	offline := valid
+	offline.ProviderMode = "mock"
+	offline.WeatherAPIKey = ""
+	offline.WeatherProviders = []string{"weatherapi", "openweathermap"}
+	assert.NoError(t, offline.Validate(), "Fixtures need no key")
+	offline.ProviderMode = "replay"
+	assert.EqualError(t, offline.Validate(), "invalid config: PROVIDER_RECORDINGS_DIR is required")
+	offline.ProviderMode = "fake"
+	assert.ErrorContains(t, offline.Validate(), `PROVIDER_MODE must be live, mock, record or replay, got "fake"`)
+
+	invalid := valid
+	invalid.DBHost = ""
+	invalid.DBPort = "postgres"
+	invalid.WeatherAPIKey = ""
+	invalid.WeatherProviders = []string{"weatherapi", "darksky"}
+	invalid.CacheBackend = "memcached"
+	invalid.RateLimitRPS = -1
+	invalid.LogLevel = "trace"
+	assert.EqualError(t, invalid.Validate(), `invalid config: DB_HOST is required
+DB_PORT must be a port number, got "postgres"
+WEATHER_API_KEY is required
+WEATHER_PROVIDERS has unknown provider "darksky"
+CACHE_BACKEND must be memory or redis, got "memcached"
+RATE_LIMIT_RPS must not be negative
+LOG_LEVEL must be debug, info or warn, got "trace"`)
+
+	invalid = valid
+	invalid.SyncSchedule = "twice a day"
+	assert.ErrorContains(t, invalid.Validate(), "SYNC_SCHEDULE is not a cron spec")
+
+	invalid = valid
+	invalid.DigestSchedule = "daily"
+	assert.ErrorContains(t, invalid.Validate(), "DIGEST_SCHEDULE is not a cron spec")
+
+	invalid = valid
+	invalid.StaleSyncSchedule = "hourly"
+	invalid.PruneSchedule = "0 3 * * *"
+	invalid.NOTAMSchedule = "@hourly"
+	invalid.NOTAMClientID = "app"
+	assert.EqualError(t, invalid.Validate(), `invalid config: STALE_SYNC_SCHEDULE is not a cron spec: expected exactly 5 fields, found 1: [hourly]
+LOG_RETENTION must be positive to prune logs
+NOTAM_CLIENT_SECRET is required`)
+
+	tls := valid
+	tls.TLSCertFile = "server.crt"
+	tls.TLSAutocertDomains = []string{"weather.example.com"}
+	tls.HTTPWriteTimeout = -time.Second
+	assert.EqualError(t, tls.Validate(), `invalid config: TLS_CERT_FILE and TLS_KEY_FILE must be set together
+TLS_AUTOCERT_DOMAINS and TLS_CERT_FILE are exclusive
+TLS_AUTOCERT_CACHE_DIR is required
+HTTP_WRITE_TIMEOUT must not be negative`)
+
+	outbound := valid
+	outbound.HTTPProxyURL = "proxy.corp:3128"
+	outbound.HTTPCAFile = "testdata/missing.pem"
+	outbound.WeatherAPITimeout = -time.Second
+	assert.EqualError(t, outbound.Validate(), `invalid config: HTTP_PROXY_URL must be an http, https or socks5 URL, got "proxy.corp:3128"
+HTTP_CA_FILE is not readable: open testdata/missing.pem: no such file or directory
+WEATHER_API_TIMEOUT must not be negative`)
+
+	outbound = valid
+	outbound.HTTPProxyURL = "http://proxy.corp:3128"
+	outbound.HTTPCAFile = "config_test.go"
+	assert.EqualError(t, outbound.Validate(), "invalid config: HTTP_CA_FILE holds no PEM certificate")
+
+	fromVault := valid
+	fromVault.WeatherAPIKey = ""
+	fromVault.VaultSecretPath = "secret/data/aviation-weather"
+	assert.EqualError(t, fromVault.Validate(), `invalid config: VAULT_ADDR is required
+VAULT_TOKEN is required`, "The key may come from Vault once it is reachable")
+}
+
+func TestWithRuntime(t *testing.T) {
+	current := &Config{DBHost: "localhost", SyncSchedule: "0 0,12 * * *", LogLevel: "info", RateLimitRPS: 5}
+	next := &Config{DBHost: "elsewhere", SyncSchedule: "0 */6 * * *", LogLevel: "warn", RateLimitRPS: 1, SyncStaleAfter: time.Hour}
+
+	updated := current.WithRuntime(next)
+	assert.Equal(t, "localhost", updated.DBHost, "Connection settings need a restart")
+	assert.Equal(t, "0 */6 * * *", updated.SyncSchedule)
+	assert.Equal(t, "warn", updated.LogLevel)
+	assert.Equal(t, 1.0, updated.RateLimitRPS)
+	assert.Equal(t, time.Hour, updated.SyncStaleAfter)
+	assert.Equal(t, "0 0,12 * * *", current.SyncSchedule, "The current config is left alone")
+}
+
+func TestSettings(t *testing.T) {
+	settings := (&Config{
+		DBHost:           "localhost",
+		DBPassword:       "postgres",
+		WeatherProviders: []string{"weatherapi", "noaa"},
+		SyncStaleAfter:   6 * time.Hour,
+		RateLimitBurst:   10,
+	}).Settings()
+	assert.Equal(t, "localhost", settings["DBHost"])
+	assert.Equal(t, "[redacted]", settings["DBPassword"])
+	assert.Equal(t, "", settings["RedisPassword"], "Unset secrets show as unset")
+	assert.Equal(t, "weatherapi,noaa", settings["WeatherProviders"])
+	assert.Equal(t, "6h0m0s", settings["SyncStaleAfter"])
+	assert.Equal(t, "10", settings["RateLimitBurst"])
+}
+
+func TestSecretFieldsAreRedacted(t *testing.T) {
+	typ := reflect.TypeFor[Config]()
+	for i := 0; i < typ.NumField(); i++ {
+		name := typ.Field(i).Name
+		for _, suffix := range []string{"Secret", "Password", "Token", "APIKey"} {
+			if strings.HasSuffix(name, suffix) {
+				assert.True(t, secretFields[name], "%s must be listed in secretFields", name)
+			}
+		}
+	}
+}
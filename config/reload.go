@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// WithRuntime returns a copy of c taking the settings that are safe to change
+// while running from next: job schedules, sync staleness, rate limits and the
+// log level. Everything else keeps its value until a restart.
+func (c *Config) WithRuntime(next *Config) *Config {
+	updated := *c
+	updated.SyncSchedule = next.SyncSchedule
+	updated.DigestSchedule = next.DigestSchedule
+	updated.StaleSyncSchedule = next.StaleSyncSchedule
+	updated.PruneSchedule = next.PruneSchedule
+	updated.RetryFailedSchedule = next.RetryFailedSchedule
+	updated.HistoryPartitionSchedule = next.HistoryPartitionSchedule
+	updated.AdvisorySchedule = next.AdvisorySchedule
+	updated.ChartSchedule = next.ChartSchedule
+	updated.StaticRefreshSchedule = next.StaticRefreshSchedule
+	updated.AIRACRefresh = next.AIRACRefresh
+	updated.NOTAMSchedule = next.NOTAMSchedule
+	updated.SchedulerJitter = next.SchedulerJitter
+	updated.SyncStaleAfter = next.SyncStaleAfter
+	updated.RateLimitRPS = next.RateLimitRPS
+	updated.RateLimitBurst = next.RateLimitBurst
+	updated.AviationAPIRPS = next.AviationAPIRPS
+	updated.AviationAPIBurst = next.AviationAPIBurst
+	updated.WeatherAPIRPS = next.WeatherAPIRPS
+	updated.WeatherAPIBurst = next.WeatherAPIBurst
+	updated.LogLevel = next.LogLevel
+	return &updated
+}
+
+// Watch reloads .env whenever it changes and passes the config with its
+// runtime settings applied to apply. Edits that fail validation are logged and
+// ignored. Environment variables still take precedence over the file, so
+// settings set there cannot be changed this way.
+func Watch(current *Config, apply func(*Config)) {
+	if viper.ConfigFileUsed() == "" {
+		log.Println("No .env file to watch, config changes need a restart")
+		return
+	}
+
+	var mu sync.Mutex
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		next, err := build()
+		if err != nil {
+			log.Printf("WARN: Ignoring change to %s: %v", e.Name, err)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		// Rotated secrets are picked up by their store, not the file
+		next.useSecrets(current.secrets)
+		updated := current.WithRuntime(next)
+		updated.useSecrets(current.secrets)
+		if !reflect.DeepEqual(updated, next) {
+			log.Printf("WARN: %s changed settings that only apply after a restart", e.Name)
+		}
+		if reflect.DeepEqual(updated, current) {
+			return
+		}
+		current = updated
+		log.Printf("Config reloaded from %s", e.Name)
+		apply(current)
+	})
+	viper.WatchConfig()
+}
+
+// secretFields are left out of Settings. The DSN and broker URL may carry
+// credentials too.
+var secretFields = map[string]bool{
+	"DBPassword":           true,
+	"DBReplicaDSN":         true,
+	"WeatherAPIKey":        true,
+	"OpenWeatherMapAPIKey": true,
+	"RedisPassword":        true,
+	"SMTPPassword":         true,
+	"BrokerURL":            true,
+	"HTTPProxyURL":         true,
+	"VaultToken":           true,
+	"AdminToken":           true,
+	"NOTAMClientSecret":    true,
+}
+
+// Settings lists every setting by field name for display, with durations and
+// lists in their .env form and secrets redacted when set.
+func (c *Config) Settings() map[string]string {
+	settings := map[string]string{}
+	v := reflect.ValueOf(*c)
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		name, field := v.Type().Field(i).Name, v.Field(i)
+		switch value := field.Interface().(type) {
+		case string:
+			if secretFields[name] && value != "" {
+				value = "[redacted]"
+			}
+			settings[name] = value
+		case []string:
+			settings[name] = strings.Join(value, ",")
+		case time.Duration:
+			settings[name] = value.String()
+		default:
+			settings[name] = fmt.Sprint(value)
+		}
+	}
+	return settings
+}
@@ -0,0 +1,84 @@
+// Package alert evaluates user-defined alert rules against synced weather.
+package alert
+
+import (
+	"fmt"
+	"strconv"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// Evaluate returns an alert for every rule the observation of faa matches.
+// Rules are assumed to apply to the airport already.
+func Evaluate(rules []domain.AlertRule, faa string, obs domain.Observation) []domain.Alert {
+	var alerts []domain.Alert
+	for _, rule := range rules {
+		value, ok := matches(rule, obs)
+		if !ok {
+			continue
+		}
+		alerts = append(alerts, domain.Alert{
+			RuleID:     rule.ID,
+			Faa:        faa,
+			Metric:     rule.Metric,
+			Value:      value,
+			Message:    message(rule, faa, value),
+			ObservedAt: obs.ObservedAt,
+		})
+	}
+	return alerts
+}
+
+// matches reports whether obs triggers rule, with the observed value as text.
+func matches(rule domain.AlertRule, obs domain.Observation) (string, bool) {
+	if rule.Metric == domain.MetricFlightCategory {
+		category := aviation.ObservationCategory(obs)
+		return category, category != "" && !aviation.CategoryAtLeast(category, rule.Category)
+	}
+
+	var value float64
+	switch rule.Metric {
+	case domain.MetricWindSpeed:
+		value = obs.WindSpeedKt
+	case domain.MetricWindGust:
+		value = obs.WindGustKt
+	case domain.MetricVisibility:
+		value = obs.VisibilitySM
+	case domain.MetricTemperature:
+		value = obs.TemperatureC
+	default:
+		return "", false
+	}
+
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+	switch rule.Operator {
+	case domain.OpGreaterThan:
+		return text, value > rule.Threshold
+	case domain.OpGreaterOrEq:
+		return text, value >= rule.Threshold
+	case domain.OpLessThan:
+		return text, value < rule.Threshold
+	case domain.OpLessOrEq:
+		return text, value <= rule.Threshold
+	}
+	return text, false
+}
+
+// operatorText renders rule operators in alert messages.
+var operatorText = map[string]string{
+	domain.OpGreaterThan: ">",
+	domain.OpGreaterOrEq: ">=",
+	domain.OpLessThan:    "<",
+	domain.OpLessOrEq:    "<=",
+	domain.OpWorseThan:   "worse than",
+}
+
+// message describes a triggered rule, e.g. "DFW: wind_speed_kt 30 > 25 (Strong wind)".
+func message(rule domain.AlertRule, faa, value string) string {
+	limit := rule.Category
+	if rule.Metric != domain.MetricFlightCategory {
+		limit = strconv.FormatFloat(rule.Threshold, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%s: %s %s %s %s (%s)", faa, rule.Metric, value, operatorText[rule.Operator], limit, rule.Name)
+}
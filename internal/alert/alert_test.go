@@ -0,0 +1,40 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	observedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	obs := domain.Observation{
+		WindSpeedKt:  30,
+		WindGustKt:   38,
+		VisibilitySM: 2.5,
+		TemperatureC: 21,
+		RawMETAR:     "KDFW 011200Z 18030G38KT 2 1/2SM BR BKN012 21/19 A2992",
+		ObservedAt:   observedAt,
+	}
+	rules := []domain.AlertRule{
+		{ID: 1, Name: "Strong wind", Metric: domain.MetricWindSpeed, Operator: domain.OpGreaterThan, Threshold: 25},
+		{ID: 2, Name: "Low visibility", Metric: domain.MetricVisibility, Operator: domain.OpLessThan, Threshold: 3},
+		{ID: 3, Name: "Below MVFR", Metric: domain.MetricFlightCategory, Operator: domain.OpWorseThan, Category: "MVFR"},
+		{ID: 4, Name: "Below IFR", Metric: domain.MetricFlightCategory, Operator: domain.OpWorseThan, Category: "IFR"},
+		{ID: 5, Name: "Heat", Metric: domain.MetricTemperature, Operator: domain.OpGreaterOrEq, Threshold: 35},
+		{ID: 6, Name: "Gusts", Metric: domain.MetricWindGust, Operator: domain.OpGreaterOrEq, Threshold: 38},
+	}
+
+	alerts := Evaluate(rules, "DFW", obs)
+	assert.Equal(t, []domain.Alert{
+		{RuleID: 1, Faa: "DFW", Metric: "wind_speed_kt", Value: "30", Message: "DFW: wind_speed_kt 30 > 25 (Strong wind)", ObservedAt: observedAt},
+		{RuleID: 2, Faa: "DFW", Metric: "visibility_sm", Value: "2.5", Message: "DFW: visibility_sm 2.5 < 3 (Low visibility)", ObservedAt: observedAt},
+		{RuleID: 3, Faa: "DFW", Metric: "flight_category", Value: "IFR", Message: "DFW: flight_category IFR worse than MVFR (Below MVFR)", ObservedAt: observedAt},
+		{RuleID: 6, Faa: "DFW", Metric: "wind_gust_kt", Value: "38", Message: "DFW: wind_gust_kt 38 >= 38 (Gusts)", ObservedAt: observedAt},
+	}, alerts)
+
+	assert.Empty(t, Evaluate(rules[2:3], "TST", domain.Observation{Condition: "Sunny"}), "unclassifiable weather never matches a category rule")
+}
@@ -0,0 +1,46 @@
+package aviation
+
+import (
+	"fmt"
+	"time"
+)
+
+// AIRAC cycles last 28 days from the effective date of cycle 2001, 0000 UTC.
+const airacPeriod = 28 * 24 * time.Hour
+
+var airacEpoch = time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+
+// AIRACCycle is one cycle of aeronautical data, such as "2510", the tenth
+// cycle effective in 2025.
+type AIRACCycle struct {
+	Ident     string
+	Effective time.Time
+}
+
+// AIRACCycleAt returns the cycle in effect at t.
+func AIRACCycleAt(t time.Time) AIRACCycle {
+	n := cyclesSinceEpoch(t)
+	effective := airacEpoch.Add(time.Duration(n) * airacPeriod)
+	// Number the cycle from the first one effective in its year
+	first := cyclesSinceEpoch(time.Date(effective.Year(), 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)) + 1
+	return AIRACCycle{
+		Ident:     fmt.Sprintf("%02d%02d", effective.Year()%100, n-first+1),
+		Effective: effective,
+	}
+}
+
+// Next returns the cycle following c.
+func (c AIRACCycle) Next() AIRACCycle {
+	return AIRACCycleAt(c.Effective.Add(airacPeriod))
+}
+
+// cyclesSinceEpoch counts the cycle boundaries between the epoch and t,
+// negative before it.
+func cyclesSinceEpoch(t time.Time) int64 {
+	d := t.Sub(airacEpoch)
+	n := int64(d / airacPeriod)
+	if d < 0 && d%airacPeriod != 0 {
+		n--
+	}
+	return n
+}
@@ -0,0 +1,41 @@
+package aviation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAIRACCycleAt(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		at        time.Time
+		ident     string
+		effective time.Time
+	}{
+		{day(2020, 1, 2), "2001", day(2020, 1, 2)},
+		{day(2020, 1, 1), "1913", day(2019, 12, 5)},
+		{day(2020, 12, 31), "2014", day(2020, 12, 31)}, // 2020 has 14 cycles
+		{day(2025, 1, 23), "2501", day(2025, 1, 23)},
+		{day(2025, 1, 22), "2413", day(2024, 12, 26)},
+		{time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC), "2610", day(2026, 10, 1)},
+		{day(2018, 3, 1), "1803", day(2018, 3, 1)},
+	}
+	for _, tt := range tests {
+		cycle := AIRACCycleAt(tt.at)
+		assert.Equal(t, tt.ident, cycle.Ident, tt.at)
+		assert.Equal(t, tt.effective, cycle.Effective, tt.at)
+	}
+}
+
+func TestAIRACCycleNext(t *testing.T) {
+	last := AIRACCycleAt(time.Date(2020, 12, 31, 12, 0, 0, 0, time.UTC))
+	next := last.Next()
+	assert.Equal(t, "2101", next.Ident, "Numbering restarts with the year")
+	assert.Equal(t, time.Date(2021, 1, 28, 0, 0, 0, 0, time.UTC), next.Effective)
+	assert.Equal(t, "2102", next.Next().Ident)
+}
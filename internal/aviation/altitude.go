@@ -0,0 +1,34 @@
+package aviation
+
+import "math"
+
+const (
+	hpaToInHg        = 0.02953
+	standardInHg     = 29.92
+	isaSeaLevelC     = 15.0
+	isaLapseCPer1000 = 1.98
+)
+
+// AltimeterInHg converts a sea-level pressure in hPa to an altimeter setting.
+func AltimeterInHg(pressureHpa float64) float64 {
+	return math.Round(pressureHpa*hpaToInHg*100) / 100
+}
+
+// PressureAltitude is the field elevation corrected for non-standard pressure,
+// 1000 ft per inHg away from 29.92.
+func PressureAltitude(elevationFt, pressureHpa float64) int {
+	return int(math.Round(elevationFt + (standardInHg-AltimeterInHg(pressureHpa))*1000))
+}
+
+// ISADeviation is how much warmer than the standard atmosphere temperatureC is
+// at pressureAltitudeFt.
+func ISADeviation(pressureAltitudeFt int, temperatureC float64) float64 {
+	isa := isaSeaLevelC - isaLapseCPer1000*float64(pressureAltitudeFt)/1000
+	return math.Round((temperatureC-isa)*10) / 10
+}
+
+// DensityAltitude corrects pressure altitude for non-standard temperature,
+// about 120 ft per °C above ISA.
+func DensityAltitude(pressureAltitudeFt int, temperatureC float64) int {
+	return int(math.Round(float64(pressureAltitudeFt) + 118.8*ISADeviation(pressureAltitudeFt, temperatureC)))
+}
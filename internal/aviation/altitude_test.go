@@ -0,0 +1,32 @@
+package aviation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAltitudes(t *testing.T) {
+	tests := []struct {
+		name             string
+		elevation        float64
+		pressureHpa      float64
+		temperatureC     float64
+		altimeter        float64
+		pressureAltitude int
+		densityAltitude  int
+	}{
+		{name: "standard day at sea level", elevation: 0, pressureHpa: 1013.25, temperatureC: 15, altimeter: 29.92, pressureAltitude: 0, densityAltitude: 0},
+		{name: "hot day in Denver", elevation: 5434, pressureHpa: 1016, temperatureC: 32, altimeter: 30.0, pressureAltitude: 5354, densityAltitude: 8633},
+		{name: "cold low pressure", elevation: 1000, pressureHpa: 990, temperatureC: -10, altimeter: 29.23, pressureAltitude: 1690, densityAltitude: -888},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.altimeter, AltimeterInHg(tt.pressureHpa))
+			pa := PressureAltitude(tt.elevation, tt.pressureHpa)
+			assert.Equal(t, tt.pressureAltitude, pa)
+			assert.Equal(t, tt.densityAltitude, DensityAltitude(pa, tt.temperatureC))
+		})
+	}
+}
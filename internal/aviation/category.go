@@ -0,0 +1,68 @@
+package aviation
+
+import (
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// Flight categories, from best to worst.
+const (
+	CategoryVFR  = "VFR"
+	CategoryMVFR = "MVFR"
+	CategoryIFR  = "IFR"
+	CategoryLIFR = "LIFR"
+)
+
+// categoryRank orders the flight categories, best first.
+var categoryRank = map[string]int{CategoryVFR: 0, CategoryMVFR: 1, CategoryIFR: 2, CategoryLIFR: 3}
+
+// IsCategory reports whether category is one of the four flight categories.
+func IsCategory(category string) bool {
+	_, ok := categoryRank[category]
+	return ok
+}
+
+// CategoryAtLeast reports whether category is minimum or better; e.g. VFR is
+// at least MVFR. An unknown category never qualifies.
+func CategoryAtLeast(category, minimum string) bool {
+	rank, ok := categoryRank[category]
+	return ok && rank <= categoryRank[minimum]
+}
+
+// FlightCategory classifies a ceiling and visibility the way aviation weather
+// charts do; the worse of the two decides. hasCeiling false means no
+// broken, overcast or obscured layer.
+func FlightCategory(ceilingFt int, hasCeiling bool, visibilitySM float64) string {
+	switch {
+	case (hasCeiling && ceilingFt < 500) || visibilitySM < 1:
+		return CategoryLIFR
+	case (hasCeiling && ceilingFt < 1000) || visibilitySM < 3:
+		return CategoryIFR
+	case (hasCeiling && ceilingFt <= 3000) || visibilitySM <= 5:
+		return CategoryMVFR
+	default:
+		return CategoryVFR
+	}
+}
+
+// CeilingFromMETAR returns the lowest broken, overcast or vertical visibility
+// layer of a raw METAR in feet above ground, e.g. 2500 for "BKN025". Layers
+// mentioned in the remarks don't count.
+func CeilingFromMETAR(raw string) (ceilingFt int, ok bool) {
+	metar, err := domain.ParseMETAR(raw, time.Time{})
+	if err != nil || metar.CeilingFt == nil {
+		return 0, false
+	}
+	return *metar.CeilingFt, true
+}
+
+// ObservationCategory classifies an observation, or returns "" when it
+// carries neither a visibility nor a METAR to judge by.
+func ObservationCategory(obs domain.Observation) string {
+	if obs.VisibilitySM <= 0 && obs.RawMETAR == "" {
+		return ""
+	}
+	ceiling, ok := CeilingFromMETAR(obs.RawMETAR)
+	return FlightCategory(ceiling, ok, obs.VisibilitySM)
+}
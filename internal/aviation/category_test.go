@@ -0,0 +1,58 @@
+package aviation
+
+import (
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlightCategory(t *testing.T) {
+	tests := []struct {
+		name       string
+		ceilingFt  int
+		hasCeiling bool
+		visSM      float64
+		want       string
+	}{
+		{"clear and 10 miles", 0, false, 10, CategoryVFR},
+		{"ceiling 3500", 3500, true, 10, CategoryVFR},
+		{"ceiling 3000", 3000, true, 10, CategoryMVFR},
+		{"visibility 5", 0, false, 5, CategoryMVFR},
+		{"ceiling 800", 800, true, 10, CategoryIFR},
+		{"visibility 2", 5000, true, 2, CategoryIFR},
+		{"ceiling 400", 400, true, 10, CategoryLIFR},
+		{"visibility half mile", 0, false, 0.5, CategoryLIFR},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FlightCategory(tt.ceilingFt, tt.hasCeiling, tt.visSM))
+		})
+	}
+}
+
+func TestCeilingFromMETAR(t *testing.T) {
+	ceiling, ok := CeilingFromMETAR("KJFK 011251Z 18012KT 10SM FEW015 BKN025 OVC040 21/10 A2992")
+	assert.True(t, ok)
+	assert.Equal(t, 2500, ceiling)
+
+	ceiling, ok = CeilingFromMETAR("KSFO 011256Z 28008KT 1/4SM FG VV002 12/12 A3001")
+	assert.True(t, ok)
+	assert.Equal(t, 200, ceiling)
+
+	_, ok = CeilingFromMETAR("KDFW 011253Z VRB03KT 10SM FEW250 20/10 A3001")
+	assert.False(t, ok)
+
+	assert.Equal(t, CategoryMVFR, ObservationCategory(domain.Observation{VisibilitySM: 10, RawMETAR: "KJFK 011251Z 10SM BKN025"}))
+	assert.Equal(t, "", ObservationCategory(domain.Observation{Condition: "Sunny"}))
+}
+
+func TestCategoryAtLeast(t *testing.T) {
+	assert.True(t, CategoryAtLeast(CategoryVFR, CategoryMVFR))
+	assert.True(t, CategoryAtLeast(CategoryMVFR, CategoryMVFR))
+	assert.False(t, CategoryAtLeast(CategoryIFR, CategoryMVFR))
+	assert.False(t, CategoryAtLeast("", CategoryLIFR))
+	assert.True(t, IsCategory(CategoryLIFR))
+	assert.False(t, IsCategory("vfr"))
+}
@@ -0,0 +1,115 @@
+package aviation
+
+import "math"
+
+// EarthRadiusNM is the mean earth radius used by the great-circle calculations.
+const EarthRadiusNM = 3440.065
+
+// Point is a position in decimal degrees.
+type Point struct {
+	Lat, Lon float64
+}
+
+// Box is a latitude/longitude bounding box in decimal degrees.
+type Box struct {
+	MinLat, MaxLat, MinLon, MaxLon float64
+}
+
+// Contains reports whether p lies inside the box, edges included.
+func (b Box) Contains(p Point) bool {
+	return p.Lat >= b.MinLat && p.Lat <= b.MaxLat && p.Lon >= b.MinLon && p.Lon <= b.MaxLon
+}
+
+// union grows the box to also cover other.
+func (b Box) union(other Box) Box {
+	return Box{
+		MinLat: math.Min(b.MinLat, other.MinLat),
+		MaxLat: math.Max(b.MaxLat, other.MaxLat),
+		MinLon: math.Min(b.MinLon, other.MinLon),
+		MaxLon: math.Max(b.MaxLon, other.MaxLon),
+	}
+}
+
+// DistanceNM is the great-circle distance between two points (haversine).
+func DistanceNM(a, b Point) float64 {
+	return EarthRadiusNM * angularDistance(a, b)
+}
+
+// angularDistance is the central angle between two points in radians.
+func angularDistance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat, dLon := lat2-lat1, radians(b.Lon-a.Lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// InitialBearing is the true course in degrees (0-360) leaving a towards b.
+func InitialBearing(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLon := radians(b.Lon - a.Lon)
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+// Intermediate is the point a fraction f (0 at a, 1 at b) along the great circle from a to b.
+func Intermediate(a, b Point, f float64) Point {
+	d := angularDistance(a, b)
+	if d == 0 {
+		return a
+	}
+	lat1, lon1 := radians(a.Lat), radians(a.Lon)
+	lat2, lon2 := radians(b.Lat), radians(b.Lon)
+	wa, wb := math.Sin((1-f)*d)/math.Sin(d), math.Sin(f*d)/math.Sin(d)
+	x := wa*math.Cos(lat1)*math.Cos(lon1) + wb*math.Cos(lat2)*math.Cos(lon2)
+	y := wa*math.Cos(lat1)*math.Sin(lon1) + wb*math.Cos(lat2)*math.Sin(lon2)
+	z := wa*math.Sin(lat1) + wb*math.Sin(lat2)
+	return Point{Lat: degrees(math.Atan2(z, math.Hypot(x, y))), Lon: degrees(math.Atan2(y, x))}
+}
+
+// CrossTrack locates p relative to the great-circle route from a to b: how far
+// it lies off the route (positive right of course) and how far along the route
+// its abeam point is, both in nautical miles.
+func CrossTrack(a, b, p Point) (crossNM, alongNM float64) {
+	d13 := angularDistance(a, p)
+	theta13 := radians(InitialBearing(a, p))
+	theta12 := radians(InitialBearing(a, b))
+	cross := math.Asin(math.Sin(d13) * math.Sin(theta13-theta12))
+	along := math.Acos(math.Max(-1, math.Min(1, math.Cos(d13)/math.Cos(cross))))
+	if math.Cos(theta13-theta12) < 0 {
+		along = -along
+	}
+	return EarthRadiusNM * cross, EarthRadiusNM * along
+}
+
+// BoxAround is the bounding box of every point within radiusNM of center.
+// Near the poles the longitude span covers the whole globe.
+func BoxAround(center Point, radiusNM float64) Box {
+	dLat := degrees(radiusNM / EarthRadiusNM)
+	box := Box{MinLat: center.Lat - dLat, MaxLat: center.Lat + dLat, MinLon: -180, MaxLon: 180}
+	if cosLat := math.Cos(radians(center.Lat)); box.MaxLat < 90 && box.MinLat > -90 && cosLat > 0 {
+		dLon := degrees(radiusNM / (EarthRadiusNM * cosLat))
+		if dLon < 180 {
+			box.MinLon, box.MaxLon = center.Lon-dLon, center.Lon+dLon
+		}
+	}
+	return box
+}
+
+// routeSamples is how many legs the route is split into when boxing it; the
+// great circle bulges away from the straight line between the endpoints.
+const routeSamples = 32
+
+// RouteBox is a bounding box covering every point within corridorNM of the
+// great-circle route from a to b. It is a coarse prefilter for CrossTrack.
+func RouteBox(a, b Point, corridorNM float64) Box {
+	box := BoxAround(a, corridorNM)
+	for i := 1; i <= routeSamples; i++ {
+		box = box.union(BoxAround(Intermediate(a, b, float64(i)/routeSamples), corridorNM))
+	}
+	return box
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
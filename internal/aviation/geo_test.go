@@ -0,0 +1,52 @@
+package aviation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	jfk = Point{Lat: 40.6398, Lon: -73.7789}
+	lax = Point{Lat: 33.9425, Lon: -118.4081}
+)
+
+func TestGreatCircle(t *testing.T) {
+	assert.InDelta(t, 2145, DistanceNM(jfk, lax), 5)
+	assert.InDelta(t, 274, InitialBearing(jfk, lax), 1)
+	assert.Equal(t, 0.0, DistanceNM(jfk, jfk))
+
+	mid := Intermediate(jfk, lax, 0.5)
+	assert.InDelta(t, DistanceNM(jfk, lax)/2, DistanceNM(jfk, mid), 0.5)
+	assert.Greater(t, mid.Lat, (jfk.Lat+lax.Lat)/2+1, "the great circle bulges north of the rhumb line")
+
+	cross, along := CrossTrack(jfk, lax, mid)
+	assert.InDelta(t, 0, cross, 0.5)
+	assert.InDelta(t, DistanceNM(jfk, lax)/2, along, 0.5)
+
+	// Denver lies north of the JFK-LAX great circle, right of the westbound course
+	cross, along = CrossTrack(jfk, lax, Point{Lat: 39.8561, Lon: -104.6737})
+	assert.InDelta(t, 106, cross, 2)
+	assert.Greater(t, along, 1300.0)
+
+	// Boston is behind the departure point
+	_, along = CrossTrack(jfk, lax, Point{Lat: 42.3656, Lon: -71.0096})
+	assert.Less(t, along, 0.0)
+}
+
+func TestBoxes(t *testing.T) {
+	box := BoxAround(jfk, 60)
+	assert.InDelta(t, 1, box.MaxLat-jfk.Lat, 0.01)
+	assert.Greater(t, jfk.Lon-box.MinLon, 1.0, "longitude degrees shrink away from the equator")
+	assert.True(t, box.Contains(jfk))
+	assert.False(t, box.Contains(lax))
+
+	polar := BoxAround(Point{Lat: 89.5, Lon: 0}, 60)
+	assert.Equal(t, -180.0, polar.MinLon)
+	assert.Equal(t, 180.0, polar.MaxLon)
+
+	route := RouteBox(jfk, lax, 50)
+	assert.True(t, route.Contains(Intermediate(jfk, lax, 0.5)))
+	assert.True(t, route.Contains(lax))
+	assert.Greater(t, route.MaxLat, jfk.Lat+0.83, "box covers the northern bulge and the corridor")
+}
@@ -0,0 +1,28 @@
+package aviation
+
+import "math"
+
+// BoundingBox is the smallest box covering every point of polygon.
+func BoundingBox(polygon []Point) Box {
+	box := Box{MinLat: math.Inf(1), MaxLat: math.Inf(-1), MinLon: math.Inf(1), MaxLon: math.Inf(-1)}
+	for _, p := range polygon {
+		box = box.union(Box{MinLat: p.Lat, MaxLat: p.Lat, MinLon: p.Lon, MaxLon: p.Lon})
+	}
+	return box
+}
+
+// PolygonContains reports whether p lies inside polygon, by counting the
+// crossings of a ray from p (even-odd rule). Vertices are treated as plane
+// coordinates, which is close enough for advisory areas away from the
+// antimeridian. The polygon may or may not repeat its first vertex.
+func PolygonContains(polygon []Point, p Point) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lon < (b.Lon-a.Lon)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,19 @@
+package aviation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolygonContains(t *testing.T) {
+	// A square around Kansas with a notch cut into its east side
+	polygon := []Point{{37, -102}, {40, -102}, {40, -94}, {38.5, -97}, {37, -94}, {37, -102}}
+
+	assert.True(t, PolygonContains(polygon, Point{Lat: 38.5, Lon: -100}))
+	assert.False(t, PolygonContains(polygon, Point{Lat: 38.5, Lon: -95}), "The notch is outside")
+	assert.False(t, PolygonContains(polygon, Point{Lat: 41, Lon: -100}))
+	assert.False(t, PolygonContains(nil, Point{Lat: 38.5, Lon: -100}))
+
+	assert.Equal(t, Box{MinLat: 37, MaxLat: 40, MinLon: -102, MaxLon: -94}, BoundingBox(polygon))
+}
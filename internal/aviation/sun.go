@@ -0,0 +1,59 @@
+package aviation
+
+import (
+	"math"
+	"time"
+)
+
+// Sun altitudes, in degrees, that define the daylight events.
+const (
+	SunriseAltitude  = -0.833 // Upper limb on the horizon, with refraction
+	CivilAltitude    = -6.0
+	julianUnixEpoch  = 2440587.5
+	julianJ2000      = 2451545.0
+	earthObliquity   = 23.4397
+	degreesPerRadian = 180 / math.Pi
+)
+
+// SunPosition tells whether the sun crosses an altitude on a given day.
+type SunPosition int
+
+const (
+	SunCrosses     SunPosition = iota
+	SunAlwaysAbove             // e.g. polar day
+	SunAlwaysBelow             // e.g. polar night
+)
+
+// SunEvent returns when the sun rises above and sets below altitudeDeg on the
+// calendar day of date, at lat/lon (east positive), using the NOAA sunrise
+// equation. rise and set are zero unless the position is SunCrosses.
+func SunEvent(date time.Time, lat, lon, altitudeDeg float64) (rise, set time.Time, pos SunPosition) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	jd := float64(day.Unix())/86400 + julianUnixEpoch
+	n := math.Ceil(jd - julianJ2000 + 0.0008)
+
+	meanNoon := n - lon/360
+	anomaly := math.Mod(357.5291+0.98560028*meanNoon, 360) / degreesPerRadian
+	center := 1.9148*math.Sin(anomaly) + 0.02*math.Sin(2*anomaly) + 0.0003*math.Sin(3*anomaly)
+	longitude := math.Mod(anomaly*degreesPerRadian+center+180+102.9372, 360) / degreesPerRadian
+	transit := julianJ2000 + meanNoon + 0.0053*math.Sin(anomaly) - 0.0069*math.Sin(2*longitude)
+
+	sinDecl := math.Sin(longitude) * math.Sin(earthObliquity/degreesPerRadian)
+	cosDecl := math.Cos(math.Asin(sinDecl))
+	phi := lat / degreesPerRadian
+	cosHour := (math.Sin(altitudeDeg/degreesPerRadian) - math.Sin(phi)*sinDecl) / (math.Cos(phi) * cosDecl)
+	switch {
+	case cosHour < -1:
+		return time.Time{}, time.Time{}, SunAlwaysAbove
+	case cosHour > 1:
+		return time.Time{}, time.Time{}, SunAlwaysBelow
+	}
+
+	hourAngle := math.Acos(cosHour) * degreesPerRadian / 360
+	return julianToTime(transit - hourAngle), julianToTime(transit + hourAngle), SunCrosses
+}
+
+func julianToTime(jd float64) time.Time {
+	seconds := (jd - julianUnixEpoch) * 86400
+	return time.Unix(int64(math.Round(seconds)), 0).UTC()
+}
@@ -0,0 +1,54 @@
+package aviation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSunEvent(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	assert.NoError(t, err)
+
+	// DFW on the June solstice: sunrise about 06:20 and sunset about 20:38 CDT
+	date := time.Date(2024, 6, 21, 0, 0, 0, 0, chicago)
+	rise, set, pos := SunEvent(date, 32.8998, -97.0403, SunriseAltitude)
+	assert.Equal(t, SunCrosses, pos)
+	assert.WithinDuration(t, time.Date(2024, 6, 21, 6, 20, 0, 0, chicago), rise, 2*time.Minute)
+	assert.WithinDuration(t, time.Date(2024, 6, 21, 20, 38, 0, 0, chicago), set, 2*time.Minute)
+
+	dawn, dusk, _ := SunEvent(date, 32.8998, -97.0403, CivilAltitude)
+	assert.True(t, dawn.Before(rise) && dusk.After(set), "civil twilight should bracket the day")
+
+	// Utqiagvik, Alaska has polar day in June and polar night in December
+	_, _, pos = SunEvent(date, 71.29, -156.79, SunriseAltitude)
+	assert.Equal(t, SunAlwaysAbove, pos)
+	_, _, pos = SunEvent(time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC), 71.29, -156.79, SunriseAltitude)
+	assert.Equal(t, SunAlwaysBelow, pos)
+}
+
+func TestTimezone(t *testing.T) {
+	tests := []struct {
+		state    string
+		lat, lon float64
+		expected string
+	}{
+		{"TX", 32.8998, -97.0403, "America/Chicago"},
+		{"TX", 31.8072, -106.3776, "America/Denver"}, // El Paso
+		{"FL", 30.4733, -87.1866, "America/Chicago"}, // Pensacola
+		{"fl", 28.4294, -81.3089, "America/New_York"},
+		{"AK", 51.878, -176.646, "America/Adak"},
+		{"", 0, 2.35, "Etc/GMT"},
+		{"", 51.47, -0.45, "Etc/GMT"},
+		{"", -6.12, 106.65, "Etc/GMT-7"},
+		{"", 40.0, -75.0, "Etc/GMT+5"},
+	}
+
+	for _, tt := range tests {
+		zone := Timezone(tt.state, tt.lat, tt.lon)
+		assert.Equal(t, tt.expected, zone, "%s %.2f,%.2f", tt.state, tt.lat, tt.lon)
+		_, err := time.LoadLocation(zone)
+		assert.NoError(t, err, zone)
+	}
+}
@@ -0,0 +1,73 @@
+package aviation
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	_ "time/tzdata" // Airport zones must resolve even on hosts without zoneinfo
+)
+
+// stateZones is the IANA zone covering most of each state or territory.
+var stateZones = map[string]string{
+	"AL": "America/Chicago", "AK": "America/Anchorage", "AZ": "America/Phoenix", "AR": "America/Chicago",
+	"CA": "America/Los_Angeles", "CO": "America/Denver", "CT": "America/New_York", "DE": "America/New_York",
+	"FL": "America/New_York", "GA": "America/New_York", "HI": "Pacific/Honolulu", "ID": "America/Boise",
+	"IL": "America/Chicago", "IN": "America/Indiana/Indianapolis", "IA": "America/Chicago", "KS": "America/Chicago",
+	"KY": "America/New_York", "LA": "America/Chicago", "ME": "America/New_York", "MD": "America/New_York",
+	"MA": "America/New_York", "MI": "America/Detroit", "MN": "America/Chicago", "MS": "America/Chicago",
+	"MO": "America/Chicago", "MT": "America/Denver", "NE": "America/Chicago", "NV": "America/Los_Angeles",
+	"NH": "America/New_York", "NJ": "America/New_York", "NM": "America/Denver", "NY": "America/New_York",
+	"NC": "America/New_York", "ND": "America/Chicago", "OH": "America/New_York", "OK": "America/Chicago",
+	"OR": "America/Los_Angeles", "PA": "America/New_York", "RI": "America/New_York", "SC": "America/New_York",
+	"SD": "America/Chicago", "TN": "America/Chicago", "TX": "America/Chicago", "UT": "America/Denver",
+	"VT": "America/New_York", "VA": "America/New_York", "WA": "America/Los_Angeles", "WV": "America/New_York",
+	"WI": "America/Chicago", "WY": "America/Denver", "DC": "America/New_York", "PR": "America/Puerto_Rico",
+	"VI": "America/St_Thomas", "GU": "Pacific/Guam", "AS": "Pacific/Pago_Pago", "MP": "Pacific/Saipan",
+}
+
+// Timezone derives the IANA zone of an airport from its state and position.
+// States split between zones are divided along approximate boundary
+// longitudes, so airports right at a zone line may land on the wrong side.
+// Outside the known states it falls back to the nautical zone of the longitude.
+func Timezone(stateCode string, lat, lon float64) string {
+	state := strings.ToUpper(stateCode)
+	switch {
+	case state == "TX" && lon < -105:
+		return "America/Denver"
+	case state == "FL" && lon < -85:
+		return "America/Chicago"
+	case state == "TN" && lon > -85:
+		return "America/New_York"
+	case state == "KY" && lon < -86:
+		return "America/Chicago"
+	case state == "IN" && lon < -87:
+		return "America/Chicago"
+	case state == "MI" && lon < -87.6 && lat > 45:
+		return "America/Menominee"
+	case state == "ND" && lon < -101 && lat < 47.5:
+		return "America/Denver"
+	case state == "SD" && lon < -100.5:
+		return "America/Denver"
+	case state == "NE" && lon < -101:
+		return "America/Denver"
+	case state == "KS" && lon < -101.5:
+		return "America/Denver"
+	case state == "OR" && lon > -118 && lat < 44.5:
+		return "America/Boise"
+	case state == "ID" && lat > 45.5:
+		return "America/Los_Angeles"
+	case state == "AK" && lon < -169:
+		return "America/Adak"
+	}
+	if zone, ok := stateZones[state]; ok {
+		return zone
+	}
+
+	// Etc zones count west as positive, so UTC-5 is Etc/GMT+5
+	offset := int(math.Round(lon / 15))
+	if offset == 0 {
+		return "Etc/GMT"
+	}
+	return fmt.Sprintf("Etc/GMT%+d", -offset)
+}
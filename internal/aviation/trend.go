@@ -0,0 +1,96 @@
+package aviation
+
+import (
+	"math"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// Sample is the value of one weather element at an observation time.
+type Sample struct {
+	At    time.Time
+	Value float64
+}
+
+// SlopePerHour fits a least-squares line through the samples and returns its
+// slope in units per hour. ok is false with fewer than two samples or when
+// they were all observed at once.
+func SlopePerHour(samples []Sample) (slope float64, ok bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+	// Hours since the first sample keep the sums small
+	origin := samples[0].At
+	var sumX, sumY float64
+	for _, s := range samples {
+		sumX += s.At.Sub(origin).Hours()
+		sumY += s.Value
+	}
+	n := float64(len(samples))
+	meanX, meanY := sumX/n, sumY/n
+
+	var num, den float64
+	for _, s := range samples {
+		dx := s.At.Sub(origin).Hours() - meanX
+		num += dx * (s.Value - meanY)
+		den += dx * dx
+	}
+	if den == 0 {
+		return 0, false
+	}
+	return num / den, true
+}
+
+// Trend summarizes samples ordered by time for an element that is better for
+// flying the higher it gets, as pressure, visibility and ceiling are. Slopes
+// within steady per hour either way count as steady.
+func Trend(samples []Sample, steady float64) domain.TrendMetric {
+	metric := domain.TrendMetric{Samples: len(samples), Trend: domain.TrendUnknown}
+	if len(samples) > 0 {
+		first, last := samples[0].Value, samples[len(samples)-1].Value
+		metric.First, metric.Last = &first, &last
+	}
+
+	slope, ok := SlopePerHour(samples)
+	if !ok {
+		return metric
+	}
+	metric.SlopePerHour = round2(slope)
+	switch {
+	case slope > steady:
+		metric.Trend = domain.TrendImproving
+	case slope < -steady:
+		metric.Trend = domain.TrendDeteriorating
+	default:
+		metric.Trend = domain.TrendSteady
+	}
+	return metric
+}
+
+// OverallTrend combines the trends of several elements: deteriorating if any
+// is, else improving if any is, else steady if any is known.
+func OverallTrend(metrics ...domain.TrendMetric) string {
+	overall := domain.TrendUnknown
+	for _, m := range metrics {
+		switch m.Trend {
+		case domain.TrendDeteriorating:
+			return domain.TrendDeteriorating
+		case domain.TrendImproving:
+			overall = domain.TrendImproving
+		case domain.TrendSteady:
+			if overall == domain.TrendUnknown {
+				overall = domain.TrendSteady
+			}
+		}
+	}
+	return overall
+}
+
+func round2(v float64) float64 {
+	v = math.Round(v*100) / 100
+	if v == 0 {
+		return 0 // Avoid reporting -0
+	}
+	return v
+}
@@ -0,0 +1,70 @@
+package aviation
+
+import (
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlopePerHour(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	slope, ok := SlopePerHour([]Sample{{at(0), 1015}, {at(60), 1013}, {at(120), 1011}})
+	assert.True(t, ok)
+	assert.InDelta(t, -2, slope, 1e-9)
+
+	// Uneven spacing and noise still give the fitted slope
+	slope, ok = SlopePerHour([]Sample{{at(0), 10}, {at(30), 9}, {at(45), 8}, {at(120), 6}})
+	assert.True(t, ok)
+	assert.InDelta(t, -1.99, slope, 0.01)
+
+	_, ok = SlopePerHour([]Sample{{at(0), 10}})
+	assert.False(t, ok, "One sample has no slope")
+	_, ok = SlopePerHour([]Sample{{at(0), 10}, {at(0), 5}})
+	assert.False(t, ok, "Samples at one time have no slope")
+}
+
+func TestTrend(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	samples := func(values ...float64) []Sample {
+		out := make([]Sample, len(values))
+		for i, v := range values {
+			out[i] = Sample{start.Add(time.Duration(i) * time.Hour), v}
+		}
+		return out
+	}
+
+	falling := Trend(samples(1016, 1014.5, 1013), 0.33)
+	assert.Equal(t, domain.TrendDeteriorating, falling.Trend)
+	assert.Equal(t, -1.5, falling.SlopePerHour)
+	assert.Equal(t, 3, falling.Samples)
+	assert.Equal(t, 1016.0, *falling.First)
+	assert.Equal(t, 1013.0, *falling.Last)
+
+	assert.Equal(t, domain.TrendImproving, Trend(samples(3, 5, 7), 0.25).Trend)
+	assert.Equal(t, domain.TrendSteady, Trend(samples(1013, 1013.2, 1013.1), 0.33).Trend)
+
+	single := Trend(samples(10), 0.25)
+	assert.Equal(t, domain.TrendUnknown, single.Trend)
+	assert.Equal(t, 10.0, *single.Last)
+
+	none := Trend(nil, 0.25)
+	assert.Equal(t, domain.TrendUnknown, none.Trend)
+	assert.Nil(t, none.First)
+}
+
+func TestOverallTrend(t *testing.T) {
+	improving := domain.TrendMetric{Trend: domain.TrendImproving}
+	steady := domain.TrendMetric{Trend: domain.TrendSteady}
+	deteriorating := domain.TrendMetric{Trend: domain.TrendDeteriorating}
+	unknown := domain.TrendMetric{Trend: domain.TrendUnknown}
+
+	assert.Equal(t, domain.TrendDeteriorating, OverallTrend(improving, deteriorating, steady))
+	assert.Equal(t, domain.TrendImproving, OverallTrend(steady, improving, unknown))
+	assert.Equal(t, domain.TrendSteady, OverallTrend(unknown, steady))
+	assert.Equal(t, domain.TrendUnknown, OverallTrend(unknown, unknown))
+}
@@ -0,0 +1,62 @@
+// Package aviation holds the flight planning calculations built on stored
+// airport and weather data.
+package aviation
+
+import (
+	"math"
+
+	"aviation-weather/internal/domain"
+)
+
+// WindComponents splits a wind into its headwind and crosswind along a runway
+// heading. Wind direction is where the wind blows from. A negative headwind is
+// a tailwind and a negative crosswind comes from the left.
+func WindComponents(runwayHeadingDeg float64, windDirDeg int, windSpeedKt float64) (headwind, crosswind float64) {
+	angle := (float64(windDirDeg) - runwayHeadingDeg) * math.Pi / 180
+	return round1(windSpeedKt * math.Cos(angle)), round1(windSpeedKt * math.Sin(angle))
+}
+
+// RunwayWinds computes the wind components of every runway end for the
+// observation's wind. Gust crosswind is only set when the observation reports gusts.
+func RunwayWinds(runways []domain.Runway, obs domain.Observation) []domain.RunwayWind {
+	winds := make([]domain.RunwayWind, 0, len(runways))
+	for _, rwy := range runways {
+		headwind, crosswind := WindComponents(rwy.HeadingDeg, obs.WindDirDeg, obs.WindSpeedKt)
+		wind := domain.RunwayWind{
+			Runway:      rwy.Ident,
+			HeadingDeg:  rwy.HeadingDeg,
+			HeadwindKt:  headwind,
+			CrosswindKt: crosswind,
+		}
+		if obs.WindGustKt > obs.WindSpeedKt {
+			_, wind.GustCrosswindKt = WindComponents(rwy.HeadingDeg, obs.WindDirDeg, obs.WindGustKt)
+		}
+		winds = append(winds, wind)
+	}
+	return winds
+}
+
+// RecommendRunway picks the runway end with the most headwind, preferring the
+// longer runway on a tie such as calm wind. winds must be in runways order.
+func RecommendRunway(runways []domain.Runway, winds []domain.RunwayWind) string {
+	best := -1
+	for i := range winds {
+		if best < 0 ||
+			winds[i].HeadwindKt > winds[best].HeadwindKt ||
+			winds[i].HeadwindKt == winds[best].HeadwindKt && runways[i].LengthFt > runways[best].LengthFt {
+			best = i
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	return winds[best].Runway
+}
+
+func round1(v float64) float64 {
+	v = math.Round(v*10) / 10
+	if v == 0 {
+		return 0 // Avoid reporting -0
+	}
+	return v
+}
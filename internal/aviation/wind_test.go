@@ -0,0 +1,54 @@
+package aviation
+
+import (
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindComponents(t *testing.T) {
+	tests := []struct {
+		name            string
+		heading         float64
+		windDir         int
+		speed           float64
+		headwind, xwind float64
+	}{
+		{name: "straight down the runway", heading: 170, windDir: 170, speed: 10, headwind: 10, xwind: 0},
+		{name: "direct tailwind", heading: 350, windDir: 170, speed: 10, headwind: -10, xwind: 0},
+		{name: "direct crosswind from the right", heading: 90, windDir: 180, speed: 15, headwind: 0, xwind: 15},
+		{name: "crosswind from the left", heading: 90, windDir: 0, speed: 15, headwind: 0, xwind: -15},
+		{name: "30 degrees off", heading: 170, windDir: 200, speed: 20, headwind: 17.3, xwind: 10},
+		{name: "wraps through north", heading: 10, windDir: 340, speed: 20, headwind: 17.3, xwind: -10},
+		{name: "calm", heading: 170, windDir: 0, speed: 0, headwind: 0, xwind: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headwind, xwind := WindComponents(tt.heading, tt.windDir, tt.speed)
+			assert.Equal(t, tt.headwind, headwind)
+			assert.Equal(t, tt.xwind, xwind)
+		})
+	}
+}
+
+func TestRecommendRunway(t *testing.T) {
+	runways := []domain.Runway{
+		{Ident: "13", HeadingDeg: 130, LengthFt: 5000},
+		{Ident: "31", HeadingDeg: 310, LengthFt: 5000},
+		{Ident: "17", HeadingDeg: 170, LengthFt: 9000},
+		{Ident: "35", HeadingDeg: 350, LengthFt: 9000},
+	}
+
+	winds := RunwayWinds(runways, domain.Observation{WindDirDeg: 300, WindSpeedKt: 12, WindGustKt: 20})
+	assert.Equal(t, "31", RecommendRunway(runways, winds))
+	assert.Equal(t, domain.RunwayWind{Runway: "31", HeadingDeg: 310, HeadwindKt: 11.8, CrosswindKt: -2.1, GustCrosswindKt: -3.5}, winds[1])
+
+	calm := RunwayWinds(runways, domain.Observation{})
+	assert.Equal(t, "17", RecommendRunway(runways, calm), "calm wind should prefer the longest runway")
+	assert.Zero(t, calm[0].GustCrosswindKt)
+
+	assert.Equal(t, "", RecommendRunway(nil, nil))
+}
@@ -0,0 +1,138 @@
+// Package awc reads the hazard advisories and TAFs of the Aviation Weather
+// Center data API, which needs no key.
+package awc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// Client fetches advisories and TAFs from BaseURL.
+type Client struct {
+	BaseURL string
+	client  *http.Client
+}
+
+func NewClient(client *http.Client) *Client {
+	return &Client{BaseURL: "https://aviationweather.gov", client: client}
+}
+
+type airSigmet struct {
+	Type          string   `json:"airSigmetType"`
+	Hazard        string   `json:"hazard"`
+	Severity      int      `json:"severity"`
+	ValidTimeFrom int64    `json:"validTimeFrom"`
+	ValidTimeTo   int64    `json:"validTimeTo"`
+	AltitudeLow   *float64 `json:"altitudeLow1"`
+	AltitudeHigh  *float64 `json:"altitudeHi1"`
+	Raw           string   `json:"rawAirSigmet"`
+	Coords        []struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"coords"`
+}
+
+// Advisories returns the SIGMETs and AIRMETs in effect over the US. Those
+// without an area of at least three points can't be placed and are skipped.
+func (c *Client) Advisories(ctx context.Context) ([]domain.Advisory, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/api/data/airsigmet?format=json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for advisories: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed for advisories: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("AWC returned %s for advisories", resp.Status)
+	}
+
+	var raw []airSigmet
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal advisories: %w", err)
+	}
+
+	advisories := make([]domain.Advisory, 0, len(raw))
+	for _, a := range raw {
+		if len(a.Coords) < 3 {
+			continue
+		}
+		advisory := domain.Advisory{
+			Type:           strings.ToUpper(strings.TrimSpace(a.Type)),
+			Hazard:         strings.ToUpper(strings.TrimSpace(a.Hazard)),
+			Severity:       a.Severity,
+			AltitudeLowFt:  feet(a.AltitudeLow),
+			AltitudeHighFt: feet(a.AltitudeHigh),
+			ValidFrom:      unixTime(a.ValidTimeFrom),
+			ValidTo:        unixTime(a.ValidTimeTo),
+			RawText:        strings.TrimSpace(a.Raw),
+			Area:           make([]domain.AdvisoryPoint, 0, len(a.Coords)),
+		}
+		for _, p := range a.Coords {
+			advisory.Area = append(advisory.Area, domain.AdvisoryPoint{Lat: p.Lat, Lon: p.Lon})
+		}
+		advisories = append(advisories, advisory)
+	}
+	return advisories, nil
+}
+
+type taf struct {
+	Raw string `json:"rawTAF"`
+}
+
+// TAF returns the latest raw TAF of a station by ICAO code, or "" when it
+// issues none.
+func (c *Client) TAF(ctx context.Context, icao string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/api/data/taf?format=json&ids="+url.QueryEscape(icao), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request for TAF of %s: %w", icao, err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("HTTP request failed for TAF of %s: %w", icao, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("AWC returned %s for TAF of %s", resp.Status, icao)
+	}
+
+	var tafs []taf
+	if err := json.NewDecoder(resp.Body).Decode(&tafs); err != nil {
+		return "", fmt.Errorf("failed to unmarshal TAF of %s: %w", icao, err)
+	}
+	if len(tafs) == 0 {
+		return "", nil
+	}
+	return strings.TrimSpace(tafs[0].Raw), nil
+}
+
+func feet(altitude *float64) *int {
+	if altitude == nil {
+		return nil
+	}
+	ft := int(*altitude)
+	return &ft
+}
+
+func unixTime(seconds int64) *time.Time {
+	if seconds <= 0 {
+		return nil
+	}
+	t := time.Unix(seconds, 0).UTC()
+	return &t
+}
@@ -0,0 +1,89 @@
+package awc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const airSigmetJSON = `[
+	{"airSigmetType":"SIGMET","hazard":"CONVECTIVE","severity":1,"validTimeFrom":1718000000,"validTimeTo":1718007200,
+	 "altitudeLow1":null,"altitudeHi1":45000,"rawAirSigmet":" CONVECTIVE SIGMET 12C ",
+	 "coords":[{"lat":37,"lon":-102},{"lat":40,"lon":-102},{"lat":40,"lon":-94},{"lat":37,"lon":-94}]},
+	{"airSigmetType":"AIRMET","hazard":"ice","validTimeFrom":1718000000,"validTimeTo":0,"rawAirSigmet":"AIRMET ZULU","coords":[{"lat":37,"lon":-102}]}
+]`
+
+func TestAdvisories(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/data/airsigmet", r.URL.Path)
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+		w.Write([]byte(airSigmetJSON))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL = srv.URL
+
+	advisories, err := c.Advisories(context.Background())
+	assert.NoError(t, err)
+	from, to := time.Unix(1718000000, 0).UTC(), time.Unix(1718007200, 0).UTC()
+	high := 45000
+	assert.Equal(t, []domain.Advisory{{
+		Type:           domain.AdvisorySIGMET,
+		Hazard:         "CONVECTIVE",
+		Severity:       1,
+		AltitudeHighFt: &high,
+		ValidFrom:      &from,
+		ValidTo:        &to,
+		RawText:        "CONVECTIVE SIGMET 12C",
+		Area:           []domain.AdvisoryPoint{{Lat: 37, Lon: -102}, {Lat: 40, Lon: -102}, {Lat: 40, Lon: -94}, {Lat: 37, Lon: -94}},
+	}}, advisories, "An advisory without an area is skipped")
+}
+
+func TestAdvisoriesErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL = srv.URL
+
+	_, err := c.Advisories(context.Background())
+	assert.EqualError(t, err, "AWC returned 503 Service Unavailable for advisories")
+}
+
+func TestTAF(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/data/taf", r.URL.Path)
+		switch r.URL.Query().Get("ids") {
+		case "KDEN":
+			w.Write([]byte(`[{"icaoId":"KDEN","rawTAF":"TAF KDEN 011720Z 0118/0224 27015KT P6SM SKC "}]`))
+		case "KXXX":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL = srv.URL
+
+	raw, err := c.TAF(context.Background(), "KDEN")
+	assert.NoError(t, err)
+	assert.Equal(t, "TAF KDEN 011720Z 0118/0224 27015KT P6SM SKC", raw)
+
+	raw, err = c.TAF(context.Background(), "KXXX")
+	assert.NoError(t, err)
+	assert.Empty(t, raw, "A station without a TAF is not an error")
+
+	_, err = c.TAF(context.Background(), "KERR")
+	assert.EqualError(t, err, "AWC returned 502 Bad Gateway for TAF of KERR")
+}
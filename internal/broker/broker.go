@@ -0,0 +1,35 @@
+// Package broker publishes service events to a message broker, so downstream
+// consumers can follow airport changes without polling the API.
+package broker
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SchemaVersion is the version of the Event envelope. Bump it on any
+// incompatible change to the envelope or to the data of an event.
+const SchemaVersion = 1
+
+// EventPublisher sends a payload to a subject of a message broker.
+type EventPublisher interface {
+	Publish(subject string, payload []byte) error
+}
+
+// Event is the envelope of every published message.
+type Event struct {
+	SchemaVersion int       `json:"schema_version"`
+	Event         string    `json:"event"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	Data          any       `json:"data"`
+}
+
+// Encode wraps data in an Event envelope stamped with the current time.
+func Encode(event string, data any) ([]byte, error) {
+	return json.Marshal(Event{
+		SchemaVersion: SchemaVersion,
+		Event:         event,
+		OccurredAt:    time.Now().UTC(),
+		Data:          data,
+	})
+}
@@ -0,0 +1,26 @@
+package broker
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	payload, err := Encode("sync.completed", map[string]int{"updated": 3})
+	assert.NoError(t, err)
+
+	var event struct {
+		SchemaVersion int            `json:"schema_version"`
+		Event         string         `json:"event"`
+		OccurredAt    time.Time      `json:"occurred_at"`
+		Data          map[string]int `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(payload, &event))
+	assert.Equal(t, SchemaVersion, event.SchemaVersion)
+	assert.Equal(t, "sync.completed", event.Event)
+	assert.WithinDuration(t, time.Now(), event.OccurredAt, time.Minute)
+	assert.Equal(t, 3, event.Data["updated"])
+}
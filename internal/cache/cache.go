@@ -0,0 +1,21 @@
+package cache
+
+import "time"
+
+// Cache stores byte values under string keys for a limited time. Backends
+// fail open: an unreachable store behaves like an empty cache.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(keys ...string)
+	// Clear removes every key starting with prefix
+	Clear(prefix string)
+	Stats() Stats
+}
+
+// Stats summarizes cache usage.
+type Stats struct {
+	Hits    int64
+	Misses  int64
+	Entries int
+}
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// Memory is an in-process cache whose entries expire after their TTL.
+type Memory struct {
+	mu        sync.Mutex
+	entries   map[string]entry
+	lastSweep time.Time
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+func NewMemory() *Memory {
+	return &Memory{entries: make(map[string]entry), lastSweep: time.Now()}
+}
+
+// Get returns the value for key if present and not expired.
+func (m *Memory) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	e, ok := m.entries[key]
+	if ok && time.Now().After(e.expiresAt) {
+		delete(m.entries, key)
+		ok = false
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		m.misses.Add(1)
+		return nil, false
+	}
+	m.hits.Add(1)
+	return e.value, true
+}
+
+// Set stores value under key for ttl.
+func (m *Memory) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	// Drop expired entries once a minute so unused keys don't pile up
+	if now.Sub(m.lastSweep) > time.Minute {
+		for k, e := range m.entries {
+			if now.After(e.expiresAt) {
+				delete(m.entries, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	m.entries[key] = entry{value: value, expiresAt: now.Add(ttl)}
+}
+
+// Delete removes keys.
+func (m *Memory) Delete(keys ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+}
+
+// Clear removes every key starting with prefix.
+func (m *Memory) Clear(prefix string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.entries, key)
+		}
+	}
+}
+
+// Count returns the number of unexpired entries whose key starts with prefix.
+func (m *Memory) Count(prefix string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now, n := time.Now(), 0
+	for key, e := range m.entries {
+		if strings.HasPrefix(key, prefix) && !now.After(e.expiresAt) {
+			n++
+		}
+	}
+	return n
+}
+
+// Stats returns hit and miss counters and the number of stored entries.
+func (m *Memory) Stats() Stats {
+	m.mu.Lock()
+	entries := len(m.entries)
+	m.mu.Unlock()
+
+	return Stats{Hits: m.hits.Load(), Misses: m.misses.Load(), Entries: entries}
+}
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory(t *testing.T) {
+	m := NewMemory()
+
+	_, ok := m.Get("weather:dallas")
+	assert.False(t, ok, "Empty cache should miss")
+
+	m.Set("weather:dallas", []byte("Sunny"), time.Minute)
+	value, ok := m.Get("weather:dallas")
+	assert.True(t, ok, "Stored key should hit")
+	assert.Equal(t, "Sunny", string(value))
+
+	m.Set("weather:austin", []byte("Rain"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	_, ok = m.Get("weather:austin")
+	assert.False(t, ok, "Expired key should miss")
+
+	assert.Equal(t, Stats{Hits: 1, Misses: 2, Entries: 1}, m.Stats())
+
+	m.Set("airports:all", []byte("[]"), time.Minute)
+	assert.Equal(t, 1, m.Count("weather:"))
+	m.Clear("weather:")
+	assert.Equal(t, 0, m.Count("weather:"))
+	assert.Equal(t, 1, m.Count("airports:"), "Other prefixes are kept")
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Namespace is the part of a shared cache under one key prefix, counting its
+// own hits and misses so each use of the cache can be inspected and cleared
+// apart from the others.
+type Namespace struct {
+	c      Cache
+	prefix string
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// NewNamespace returns the keys of c starting with prefix.
+func NewNamespace(c Cache, prefix string) *Namespace {
+	return &Namespace{c: c, prefix: prefix}
+}
+
+func (n *Namespace) Get(key string) ([]byte, bool) {
+	value, ok := n.c.Get(n.prefix + key)
+	if ok {
+		n.hits.Add(1)
+	} else {
+		n.misses.Add(1)
+	}
+	return value, ok
+}
+
+func (n *Namespace) Set(key string, value []byte, ttl time.Duration) {
+	n.c.Set(n.prefix+key, value, ttl)
+}
+
+func (n *Namespace) Delete(keys ...string) {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = n.prefix + key
+	}
+	n.c.Delete(prefixed...)
+}
+
+func (n *Namespace) Clear(prefix string) {
+	n.c.Clear(n.prefix + prefix)
+}
+
+// Stats returns the hits and misses of the namespace, and its entries when
+// the backend can count them.
+func (n *Namespace) Stats() Stats {
+	stats := Stats{Hits: n.hits.Load(), Misses: n.misses.Load()}
+	if counter, ok := n.c.(interface{ Count(prefix string) int }); ok {
+		stats.Entries = counter.Count(n.prefix)
+	}
+	return stats
+}
@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespace(t *testing.T) {
+	m := NewMemory()
+	weather, airports := NewNamespace(m, "weather:"), NewNamespace(m, "airports:")
+
+	weather.Set("dallas", []byte("Sunny"), time.Minute)
+	airports.Set("all", []byte("[]"), time.Minute)
+	_, ok := m.Get("weather:dallas")
+	assert.True(t, ok, "Keys are stored under the prefix")
+
+	_, ok = weather.Get("dallas")
+	assert.True(t, ok)
+	_, ok = weather.Get("austin")
+	assert.False(t, ok)
+	assert.Equal(t, Stats{Hits: 1, Misses: 1, Entries: 1}, weather.Stats())
+	assert.Equal(t, Stats{Entries: 1}, airports.Stats(), "Counts are kept per namespace")
+
+	weather.Clear("")
+	_, ok = weather.Get("dallas")
+	assert.False(t, ok)
+	_, ok = airports.Get("all")
+	assert.True(t, ok, "Clearing a namespace keeps the others")
+
+	airports.Delete("all")
+	assert.Equal(t, 0, airports.Stats().Entries)
+}
@@ -0,0 +1,271 @@
+package cache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// redisPoolSize bounds the idle connections kept for reuse.
+const redisPoolSize = 8
+
+// Redis is a cache shared by every replica, speaking the RESP protocol directly.
+type Redis struct {
+	addr     string
+	password string
+	db       int
+	prefix   string
+	timeout  time.Duration
+
+	pool chan *redisConn
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// errNil is the reply to GET for a missing key.
+var errNil = errors.New("redis: nil")
+
+// NewRedis returns a Redis cache. Connections are opened lazily, and every key
+// is stored under prefix so one Redis database can be shared with other apps.
+func NewRedis(addr, password string, db int, prefix string) *Redis {
+	return &Redis{
+		addr:     addr,
+		password: password,
+		db:       db,
+		prefix:   prefix,
+		timeout:  2 * time.Second,
+		pool:     make(chan *redisConn, redisPoolSize),
+	}
+}
+
+func (c *Redis) Get(key string) ([]byte, bool) {
+	reply, err := c.do("GET", c.prefix+key)
+	if err != nil {
+		if !errors.Is(err, errNil) {
+			log.Printf("redis: GET %s failed: %v", key, err)
+		}
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.hits.Add(1)
+	return reply.([]byte), true
+}
+
+func (c *Redis) Set(key string, value []byte, ttl time.Duration) {
+	ms := strconv.FormatInt(max(ttl.Milliseconds(), 1), 10)
+	if _, err := c.do("SET", c.prefix+key, string(value), "PX", ms); err != nil {
+		log.Printf("redis: SET %s failed: %v", key, err)
+	}
+}
+
+func (c *Redis) Delete(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	args := []string{"DEL"}
+	for _, key := range keys {
+		args = append(args, c.prefix+key)
+	}
+	if _, err := c.do(args...); err != nil {
+		log.Printf("redis: DEL failed: %v", err)
+	}
+}
+
+// redisGlob escapes the pattern characters of a key for SCAN MATCH.
+var redisGlob = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+// Clear removes every key starting with prefix, walking the keyspace with
+// SCAN so Redis isn't blocked the way KEYS would.
+func (c *Redis) Clear(prefix string) {
+	pattern := redisGlob.Replace(c.prefix+prefix) + "*"
+	cursor := "0"
+	for {
+		reply, err := c.do("SCAN", cursor, "MATCH", pattern, "COUNT", "100")
+		if err != nil {
+			log.Printf("redis: SCAN %s failed: %v", pattern, err)
+			return
+		}
+		page, ok := reply.([]any)
+		if !ok || len(page) != 2 {
+			log.Printf("redis: malformed SCAN reply %v", reply)
+			return
+		}
+		next, _ := page[0].([]byte)
+		keys, _ := page[1].([]any)
+
+		if len(keys) > 0 {
+			args := []string{"DEL"}
+			for _, key := range keys {
+				if key, ok := key.([]byte); ok {
+					args = append(args, string(key))
+				}
+			}
+			if _, err := c.do(args...); err != nil {
+				log.Printf("redis: DEL failed: %v", err)
+				return
+			}
+		}
+
+		if cursor = string(next); cursor == "0" || cursor == "" {
+			return
+		}
+	}
+}
+
+// Stats returns the hits and misses seen by this instance. Entries is not
+// tracked because the keyspace is shared with other replicas.
+func (c *Redis) Stats() Stats {
+	return Stats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// do runs one command on a pooled connection.
+func (c *Redis) do(args ...string) (any, error) {
+	rc, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := rc.command(c.timeout, args...)
+	if err != nil && !errors.Is(err, errNil) && !isRedisError(err) {
+		// Broken connection, don't reuse it
+		rc.conn.Close()
+		return nil, err
+	}
+
+	c.put(rc)
+	return reply, err
+}
+
+func (c *Redis) get() (*redisConn, error) {
+	select {
+	case rc := <-c.pool:
+		return rc, nil
+	default:
+	}
+
+	conn, err := net.DialTimeout("tcp", c.addr, c.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	rc := &redisConn{conn: conn, r: bufio.NewReader(conn)}
+
+	if c.password != "" {
+		if _, err := rc.command(c.timeout, "AUTH", c.password); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("redis AUTH failed: %w", err)
+		}
+	}
+	if c.db != 0 {
+		if _, err := rc.command(c.timeout, "SELECT", strconv.Itoa(c.db)); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("redis SELECT failed: %w", err)
+		}
+	}
+
+	return rc, nil
+}
+
+func (c *Redis) put(rc *redisConn) {
+	select {
+	case c.pool <- rc:
+	default:
+		rc.conn.Close()
+	}
+}
+
+// redisError is an error reply sent by the server.
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+func isRedisError(err error) bool {
+	var re redisError
+	return errors.As(err, &re)
+}
+
+// command writes args as a RESP array and reads one reply.
+func (rc *redisConn) command(timeout time.Duration, args ...string) (any, error) {
+	rc.conn.SetDeadline(time.Now().Add(timeout))
+
+	buf := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		buf = append(buf, "$"+strconv.Itoa(len(arg))+"\r\n"...)
+		buf = append(buf, arg...)
+		buf = append(buf, "\r\n"...)
+	}
+	if _, err := rc.conn.Write(buf); err != nil {
+		return nil, err
+	}
+
+	return readReply(rc.r)
+}
+
+// readReply parses one RESP reply. Bulk strings are returned as []byte,
+// integers as int64, simple strings as string and arrays as []any, with nil
+// for their null elements.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, redisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk length %q", body)
+		}
+		if n < 0 {
+			return nil, errNil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed array length %q", body)
+		}
+		if n < 0 {
+			return nil, errNil
+		}
+		items := make([]any, n)
+		for i := range items {
+			item, err := readReply(r)
+			if err != nil && !errors.Is(err, errNil) {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply type %q", kind)
+	}
+}
@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRedis answers GET, SET, DEL and a single page of SCAN from a map, enough
+// to exercise the client.
+func fakeRedis(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	var mu sync.Mutex
+	store := map[string]string{}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					args, err := readCommand(r)
+					if err != nil {
+						return
+					}
+					mu.Lock()
+					switch strings.ToUpper(args[0]) {
+					case "GET":
+						if v, ok := store[args[1]]; ok {
+							conn.Write([]byte("$" + strconv.Itoa(len(v)) + "\r\n" + v + "\r\n"))
+						} else {
+							conn.Write([]byte("$-1\r\n"))
+						}
+					case "SET":
+						store[args[1]] = args[2]
+						conn.Write([]byte("+OK\r\n"))
+					case "DEL":
+						for _, k := range args[1:] {
+							delete(store, k)
+						}
+						conn.Write([]byte(":1\r\n"))
+					case "SCAN":
+						prefix := strings.ReplaceAll(strings.TrimSuffix(args[3], "*"), `\`, "")
+						var keys []string
+						for k := range store {
+							if strings.HasPrefix(k, prefix) {
+								keys = append(keys, "$"+strconv.Itoa(len(k))+"\r\n"+k+"\r\n")
+							}
+						}
+						conn.Write([]byte("*2\r\n$1\r\n0\r\n*" + strconv.Itoa(len(keys)) + "\r\n" + strings.Join(keys, "")))
+					default:
+						conn.Write([]byte("-ERR unknown command\r\n"))
+					}
+					mu.Unlock()
+				}
+			}(conn)
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+// readCommand parses one RESP array of bulk strings.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		value, err := readReply(r)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, string(value.([]byte)))
+	}
+	return args, nil
+}
+
+func TestRedis(t *testing.T) {
+	addr := fakeRedis(t)
+	c := NewRedis(addr, "", 0, "aw:")
+	c.timeout = time.Second
+
+	_, ok := c.Get("weather:dallas")
+	assert.False(t, ok)
+
+	c.Set("weather:dallas", []byte("Sunny"), time.Minute)
+	value, ok := c.Get("weather:dallas")
+	assert.True(t, ok)
+	assert.Equal(t, "Sunny", string(value))
+
+	c.Delete("weather:dallas")
+	_, ok = c.Get("weather:dallas")
+	assert.False(t, ok)
+
+	assert.Equal(t, Stats{Hits: 1, Misses: 2}, c.Stats())
+
+	c.Set("weather:dallas", []byte("Sunny"), time.Minute)
+	c.Set("weather:austin", []byte("Rain"), time.Minute)
+	c.Set("airports:all", []byte("[]"), time.Minute)
+	c.Clear("weather:")
+	_, ok = c.Get("weather:austin")
+	assert.False(t, ok, "Cleared keys are gone")
+	_, ok = c.Get("airports:all")
+	assert.True(t, ok, "Other prefixes are kept")
+}
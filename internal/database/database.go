@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+
+	"aviation-weather/config"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// DSN builds the PostgreSQL connection string from the config, with the
+// latest password when it comes from a file or Vault.
+func DSN(cfg *config.Config) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=UTC",
+		cfg.DBHost, cfg.DBPort, cfg.DBUser, password(cfg), cfg.DBName,
+	)
+}
+
+func password(cfg *config.Config) string {
+	if store := cfg.Secrets(); store != nil {
+		if value := store.Get(config.SecretDBPassword); value != "" {
+			return value
+		}
+	}
+	return cfg.DBPassword
+}
+
+// PoolConfig parses dsn and applies the pool settings of the config.
+func PoolConfig(dsn string, cfg *config.Config) (*pgxpool.Config, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database config: %w", err)
+	}
+	if cfg.DBMaxConns > 0 {
+		poolCfg.MaxConns = int32(cfg.DBMaxConns)
+	}
+	if cfg.DBMaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.DBMaxConnIdleTime
+	}
+	if cfg.DBConnMaxLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.DBConnMaxLifetime
+	}
+	if cfg.DBStatementTimeout > 0 {
+		poolCfg.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.DBStatementTimeout.Milliseconds(), 10)
+	}
+	return poolCfg, nil
+}
+
+// configureDB applies the pool settings to the database/sql side. Its idle
+// connections stay checked out of the pgx pool, which database/sql keeps
+// within the open limit.
+func configureDB(db *sql.DB, cfg *config.Config) {
+	if cfg.DBMaxConns > 0 {
+		db.SetMaxOpenConns(cfg.DBMaxConns)
+	}
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxIdleTime(cfg.DBMaxConnIdleTime)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+}
+
+// QueryContext bounds a query by the configured timeout, or only by parent
+// when DBQueryTimeout is 0.
+func QueryContext(parent context.Context, cfg *config.Config) (context.Context, context.CancelFunc) {
+	if cfg.DBQueryTimeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, cfg.DBQueryTimeout)
+}
+
+// Open connects a pgx pool and checks it answers. The pool is returned as a
+// *sql.DB so the repository keeps working on database/sql. Closing the
+// *sql.DB leaves the pool open, call the returned close func instead.
+func Open(ctx context.Context, cfg *config.Config) (*sql.DB, func(), error) {
+	poolCfg, err := PoolConfig(DSN(cfg), cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	// New connections log in with the rotated password, older ones are
+	// replaced as they reach DBConnMaxLifetime
+	if cfg.Secrets() != nil {
+		poolCfg.BeforeConnect = func(ctx context.Context, connCfg *pgx.ConnConfig) error {
+			connCfg.Password = password(cfg)
+			return nil
+		}
+	}
+	return open(ctx, poolCfg, cfg, true)
+}
+
+// OpenReplica connects the read replica at DBReplicaDSN like Open, in
+// read-only sessions. A replica that is down only logs a warning, reads fall
+// back to the primary until it answers again.
+func OpenReplica(ctx context.Context, cfg *config.Config) (*sql.DB, func(), error) {
+	poolCfg, err := PoolConfig(cfg.DBReplicaDSN, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("replica: %w", err)
+	}
+	poolCfg.ConnConfig.RuntimeParams["default_transaction_read_only"] = "on"
+	if _, ok := poolCfg.ConnConfig.RuntimeParams["TimeZone"]; !ok {
+		// Read timestamps in UTC as on the primary
+		poolCfg.ConnConfig.RuntimeParams["TimeZone"] = "UTC"
+	}
+	return open(ctx, poolCfg, cfg, false)
+}
+
+// open creates the pool, failing if it doesn't answer unless mustPing is false.
+func open(ctx context.Context, poolCfg *pgxpool.Config, cfg *config.Config, mustPing bool) (*sql.DB, func(), error) {
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open DB: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		if mustPing {
+			pool.Close()
+			return nil, nil, fmt.Errorf("failed to ping DB: %w", err)
+		}
+		log.Printf("WARN: DB %s is not answering yet: %v", poolCfg.ConnConfig.Host, err)
+	}
+
+	db := stdlib.OpenDBFromPool(pool)
+	configureDB(db, cfg)
+	return db, func() {
+		db.Close()
+		pool.Close()
+	}, nil
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolConfig(t *testing.T) {
+	cfg := &config.Config{
+		DBHost: "db", DBPort: "5432", DBUser: "postgres", DBPassword: "secret", DBName: "aviation_weather",
+		DBMaxConns: 25, DBMaxConnIdleTime: 2 * time.Minute, DBConnMaxLifetime: time.Hour, DBStatementTimeout: 15 * time.Second,
+	}
+
+	poolCfg, err := PoolConfig(DSN(cfg), cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(25), poolCfg.MaxConns)
+	assert.Equal(t, 2*time.Minute, poolCfg.MaxConnIdleTime)
+	assert.Equal(t, time.Hour, poolCfg.MaxConnLifetime)
+	assert.Equal(t, "15000", poolCfg.ConnConfig.RuntimeParams["statement_timeout"])
+	assert.Equal(t, "aviation_weather", poolCfg.ConnConfig.Database)
+
+	// Unset limits keep the pgx defaults
+	cfg.DBMaxConns, cfg.DBMaxConnIdleTime, cfg.DBConnMaxLifetime, cfg.DBStatementTimeout = 0, 0, 0, 0
+	poolCfg, err = PoolConfig(DSN(cfg), cfg)
+	assert.NoError(t, err)
+	assert.Positive(t, poolCfg.MaxConns)
+	assert.NotContains(t, poolCfg.ConnConfig.RuntimeParams, "statement_timeout")
+}
+
+func TestConfigureDB(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	configureDB(db, &config.Config{DBMaxConns: 8, DBMaxIdleConns: 3})
+	assert.Equal(t, 8, db.Stats().MaxOpenConnections)
+}
+
+func TestQueryContext(t *testing.T) {
+	ctx, cancel := QueryContext(context.Background(), &config.Config{DBQueryTimeout: time.Minute})
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+	ctx, cancel = QueryContext(context.Background(), &config.Config{})
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok, "0 leaves queries unbounded")
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// AIRACCycle is one 28-day cycle of aeronautical data, in effect from
+// Effective until Expires.
+type AIRACCycle struct {
+	Ident     string    `json:"ident"`
+	Effective time.Time `json:"effective"`
+	Expires   time.Time `json:"expires"`
+}
+
+// AIRACStatus is the payload of the AIRAC endpoint.
+type AIRACStatus struct {
+	Current       AIRACCycle `json:"current"`
+	Next          AIRACCycle `json:"next"`
+	DaysUntilNext int        `json:"days_until_next"`
+}
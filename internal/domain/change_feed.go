@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangeCursor is a position in the change feed: after the change ID of
+// transaction TxID. The zero cursor is before every change.
+type ChangeCursor struct {
+	TxID int64
+	ID   int64
+}
+
+// String formats the cursor as clients pass it back, "<txid>-<id>".
+func (c ChangeCursor) String() string {
+	return strconv.FormatInt(c.TxID, 10) + "-" + strconv.FormatInt(c.ID, 10)
+}
+
+// MarshalText encodes the cursor as its String.
+func (c ChangeCursor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// ParseChangeCursor reads a cursor formatted by String; empty is the zero
+// cursor.
+func ParseChangeCursor(s string) (ChangeCursor, error) {
+	if s == "" {
+		return ChangeCursor{}, nil
+	}
+	txID, id, ok := strings.Cut(s, "-")
+	if !ok {
+		return ChangeCursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	var c ChangeCursor
+	var err1, err2 error
+	c.TxID, err1 = strconv.ParseInt(txID, 10, 64)
+	c.ID, err2 = strconv.ParseInt(id, 10, 64)
+	if err1 != nil || err2 != nil || c.TxID < 0 || c.ID < 0 {
+		return ChangeCursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	return c, nil
+}
+
+// ChangeLogEntry is one change of the change feed. Cursor resumes the feed
+// right after it.
+type ChangeLogEntry struct {
+	Cursor    ChangeCursor `json:"cursor"`
+	Op        string       `json:"op"`
+	Faa       string       `json:"faa_ident"`
+	ChangedAt time.Time    `json:"changed_at"`
+}
+
+// ChangeFeed is a page of the change feed, in order. Cursor is that of its
+// last change, or the one asked for when there is none; HasMore tells a
+// next page is ready.
+type ChangeFeed struct {
+	Changes []ChangeLogEntry `json:"changes"`
+	Cursor  ChangeCursor     `json:"cursor"`
+	HasMore bool             `json:"has_more"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChangeCursor(t *testing.T) {
+	cursor, err := ParseChangeCursor("")
+	assert.NoError(t, err)
+	assert.Equal(t, ChangeCursor{}, cursor)
+
+	cursor, err = ParseChangeCursor("7521-42")
+	assert.NoError(t, err)
+	assert.Equal(t, ChangeCursor{TxID: 7521, ID: 42}, cursor)
+	assert.Equal(t, "7521-42", cursor.String())
+
+	for _, invalid := range []string{"42", "a-1", "1-", "-1-2", "1--2"} {
+		_, err := ParseChangeCursor(invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	data, err := json.Marshal(ChangeFeed{Cursor: cursor})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"changes":null,"cursor":"7521-42","has_more":false}`, string(data))
+}
@@ -0,0 +1,16 @@
+package domain
+
+// Chart codes of the d-TPP kept as airport charts.
+const (
+	ChartAirportDiagram = "APD"
+	ChartApproach       = "IAP"
+)
+
+// Chart is an airport diagram or approach plate of the FAA d-TPP, as a link
+// to its PDF. Cycle is the AIRAC cycle it was published for, such as "2510".
+type Chart struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	PDFURL string `json:"pdf_url"`
+	Cycle  string `json:"cycle"`
+}
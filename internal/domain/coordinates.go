@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// FormatDMS writes decimal degrees in the FAA degrees-minutes-seconds form
+// that ParseCoordinate reads, "33-38-12.1186N" or "084-25-40.3104W".
+func FormatDMS(deg float64, longitude bool) string {
+	positive, negative, width := "N", "S", 2
+	if longitude {
+		positive, negative, width = "E", "W", 3
+	}
+	hemisphere := positive
+	if deg < 0 {
+		hemisphere = negative
+	}
+
+	// Round once in ten-thousandths of a second so 59.99999 carries over
+	total := math.Round(math.Abs(deg) * 3600 * 1e4)
+	degrees := math.Floor(total / (3600 * 1e4))
+	total -= degrees * 3600 * 1e4
+	minutes := math.Floor(total / (60 * 1e4))
+	seconds := (total - minutes*60*1e4) / 1e4
+	return fmt.Sprintf("%0*d-%02d-%07.4f%s", width, int(degrees), int(minutes), seconds, hemisphere)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldChange is one airport field whose value changed.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// SyncResult is a synced airport together with what the sync changed.
+type SyncResult struct {
+	Airport       *Airport      `json:"airport"`
+	ChangedFields []string      `json:"changed_fields"`
+	Changes       []FieldChange `json:"changes"`
+}
+
+// DiffAirports compares the string fields of two airports by their JSON names.
+// Timestamps are left out since every write changes them.
+func DiffAirports(before, after *Airport) []FieldChange {
+	changes := []FieldChange{}
+
+	bv, av := reflect.ValueOf(before).Elem(), reflect.ValueOf(after).Elem()
+	t := bv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Type.Kind() != reflect.String {
+			continue
+		}
+		old, new := bv.Field(i).String(), av.Field(i).String()
+		if old == new {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		changes = append(changes, FieldChange{Field: name, Old: old, New: new})
+	}
+
+	return changes
+}
+
+// NewSyncResult builds the result of syncing before into after.
+func NewSyncResult(before, after *Airport) *SyncResult {
+	changes := DiffAirports(before, after)
+	fields := make([]string, len(changes))
+	for i, c := range changes {
+		fields[i] = c.Field
+	}
+	return &SyncResult{Airport: after, ChangedFields: fields, Changes: changes}
+}
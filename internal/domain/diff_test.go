@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSyncResult(t *testing.T) {
+	before := &Airport{Faa: "TST", City: "Old City", Weather: "Clear"}
+	now := time.Now()
+	after := &Airport{Faa: "TST", City: "Jakarta", Weather: "Sunny", LastSyncedAt: &now}
+
+	result := NewSyncResult(before, after)
+
+	assert.Same(t, after, result.Airport)
+	assert.Equal(t, []string{"city", "weather"}, result.ChangedFields, "Timestamps should not count as changes")
+	assert.Equal(t, []FieldChange{
+		{Field: "city", Old: "Old City", New: "Jakarta"},
+		{Field: "weather", Old: "Clear", New: "Sunny"},
+	}, result.Changes)
+
+	unchanged := NewSyncResult(after, after)
+	assert.Empty(t, unchanged.ChangedFields)
+	assert.NotNil(t, unchanged.ChangedFields, "Should encode as [] rather than null")
+}
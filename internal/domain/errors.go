@@ -0,0 +1,54 @@
+package domain
+
+import "errors"
+
+// Sentinel errors shared by the repository and service layers. Wrap them with
+// fmt.Errorf("%w: ...") and test with errors.Is.
+var (
+	ErrNotFound    = errors.New("airport not found")
+	ErrDuplicate   = errors.New("airport already exists")
+	ErrExternalAPI = errors.New("external API error")
+	// ErrNoData means the airport exists but the requested data (runways,
+	// weather...) has not been synced yet.
+	ErrNoData = errors.New("data not available")
+	// ErrRuleNotFound means no alert rule has the given id.
+	ErrRuleNotFound = errors.New("alert rule not found")
+	// ErrWebhookNotFound means no webhook has the given id.
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrWatchlistNotFound means the caller has no watchlist with the given id.
+	ErrWatchlistNotFound = errors.New("watchlist not found")
+	// ErrSubscriptionNotFound means the caller has no digest subscription
+	// with the given id.
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	// ErrJobNotFound means no scheduler job has the given name.
+	ErrJobNotFound = errors.New("scheduler job not found")
+	// ErrCacheNotFound means no cache has the given name.
+	ErrCacheNotFound = errors.New("cache not found")
+	// ErrTenantNotFound means no tenant has the given id.
+	ErrTenantNotFound = errors.New("tenant not found")
+	// ErrTenantExists means another tenant already has the given name.
+	ErrTenantExists = errors.New("tenant already exists")
+	// ErrAPIKeyNotFound means no API key has the given id, or no registered
+	// key matches the one presented.
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	// ErrChannelDisabled means a notification channel isn't configured, e.g.
+	// email without an SMTP host.
+	ErrChannelDisabled = errors.New("notification channel not configured")
+	// ErrNoteNotFound means the airport has no note with the given id.
+	ErrNoteNotFound = errors.New("note not found")
+	// ErrConflictNotFound means no airport conflict has the given id.
+	ErrConflictNotFound = errors.New("conflict not found")
+	// ErrConflictResolved means an airport conflict was already accepted or
+	// rejected.
+	ErrConflictResolved = errors.New("conflict already resolved")
+	// ErrInvalidTransition means an airport cannot move from its lifecycle
+	// status to the one asked for.
+	ErrInvalidTransition = errors.New("invalid status transition")
+	// ErrInvalidSort means a list was asked to be sorted by a field that is
+	// not in SortableAirportFields.
+	ErrInvalidSort = errors.New("field is not sortable")
+	// ErrInvalidMETAR means a report could not be decoded as a METAR.
+	ErrInvalidMETAR = errors.New("invalid METAR")
+	// ErrInvalidTAF means a forecast could not be decoded as a TAF.
+	ErrInvalidTAF = errors.New("invalid TAF")
+)
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// Fuel types an airport may sell, as stored and filtered on.
+const (
+	Fuel100LL = "100LL"
+	Fuel100   = "100"
+	FuelUL94  = "UL94"
+	FuelMogas = "MOGAS"
+	FuelJetA  = "JetA"
+	FuelJetA1 = "JetA1"
+	FuelJetB  = "JetB"
+)
+
+// fuelCodes maps the spellings of providers and clients, upper-cased without
+// spaces or dashes, to fuel types. NASR codes Jet A as "A", with "A+" and
+// "A++" for its blends with additives.
+var fuelCodes = map[string]string{
+	"100LL": Fuel100LL,
+	"100":   Fuel100,
+	"UL94":  FuelUL94, "94UL": FuelUL94,
+	"MOGAS": FuelMogas, "MOGA": FuelMogas,
+	"JETA": FuelJetA, "A": FuelJetA, "A+": FuelJetA, "A++": FuelJetA,
+	"JETA1": FuelJetA1, "A1": FuelJetA1, "A1+": FuelJetA1,
+	"JETB": FuelJetB, "B": FuelJetB, "B+": FuelJetB,
+}
+
+// ParseFuelType returns the fuel type spelled code, such as "Jet A", "JET-A"
+// or "A", or ok false when unknown.
+func ParseFuelType(code string) (fuel string, ok bool) {
+	key := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(code))
+	fuel, ok = fuelCodes[key]
+	return fuel, ok
+}
+
+// ParseFuelTypes reads a comma separated list such as NASR's "100LL,A",
+// leaving out unknown and repeated codes. It returns nil when none is known.
+func ParseFuelTypes(list string) []string {
+	var fuels []string
+	for _, code := range strings.Split(list, ",") {
+		if fuel, ok := ParseFuelType(code); ok && !slices.Contains(fuels, fuel) {
+			fuels = append(fuels, fuel)
+		}
+	}
+	return fuels
+}
+
+// AirportFuel is the fuel and FBO data of an airport, as edited by hand.
+type AirportFuel struct {
+	FuelTypes []string `json:"fuel_types"`
+	FboName   string   `json:"fbo_name"`
+	FboPhone  string   `json:"fbo_phone"`
+}
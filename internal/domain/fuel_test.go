@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFuelType(t *testing.T) {
+	for code, want := range map[string]string{
+		"100LL": Fuel100LL, "100ll": Fuel100LL, "A": FuelJetA, "Jet A": FuelJetA, "JET-A": FuelJetA,
+		"JetA": FuelJetA, "A++": FuelJetA, "A1": FuelJetA1, "Jet A-1": FuelJetA1, "MOGAS": FuelMogas, "94UL": FuelUL94,
+	} {
+		fuel, ok := ParseFuelType(code)
+		assert.True(t, ok, code)
+		assert.Equal(t, want, fuel, code)
+	}
+
+	_, ok := ParseFuelType("diesel")
+	assert.False(t, ok)
+}
+
+func TestParseFuelTypes(t *testing.T) {
+	assert.Equal(t, []string{Fuel100LL, FuelJetA}, ParseFuelTypes("100LL,A,A+, ,XYZ"))
+	assert.Nil(t, ParseFuelTypes(""))
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Lifecycle statuses of an airport. Airports start active; a decommissioned
+// airport stays so.
+const (
+	LifecycleActive         = "active"
+	LifecycleClosed         = "closed"
+	LifecycleSeasonal       = "seasonal"
+	LifecycleDecommissioned = "decommissioned"
+)
+
+// lifecycleTransitions lists the statuses each lifecycle status may move to.
+var lifecycleTransitions = map[string][]string{
+	LifecycleActive:         {LifecycleClosed, LifecycleSeasonal, LifecycleDecommissioned},
+	LifecycleSeasonal:       {LifecycleActive, LifecycleClosed, LifecycleDecommissioned},
+	LifecycleClosed:         {LifecycleActive, LifecycleSeasonal, LifecycleDecommissioned},
+	LifecycleDecommissioned: nil,
+}
+
+// IsLifecycleStatus reports whether status is one of the Lifecycle statuses.
+func IsLifecycleStatus(status string) bool {
+	_, ok := lifecycleTransitions[status]
+	return ok
+}
+
+// CheckLifecycleTransition returns ErrInvalidTransition unless an airport
+// may move from one lifecycle status to another. Staying put is no
+// transition either.
+func CheckLifecycleTransition(from, to string) error {
+	if !slices.Contains(lifecycleTransitions[from], to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+// IsOutOfService reports whether an airport is closed or decommissioned,
+// which leaves it out of batch syncs unless configured otherwise.
+func (a *Airport) IsOutOfService() bool {
+	return a.LifecycleStatus == LifecycleClosed || a.LifecycleStatus == LifecycleDecommissioned
+}
+
+// AirportStatusChange is one change of the lifecycle status of an airport.
+type AirportStatusChange struct {
+	ID         int64      `json:"id"`
+	Faa        string     `json:"faa_ident"`
+	FromStatus string     `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	Reason     string     `json:"reason,omitempty"`
+	ChangedAt  *time.Time `json:"changed_at,omitempty"`
+}
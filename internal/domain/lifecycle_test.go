@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLifecycleTransition(t *testing.T) {
+	assert.NoError(t, CheckLifecycleTransition(LifecycleActive, LifecycleClosed))
+	assert.NoError(t, CheckLifecycleTransition(LifecycleClosed, LifecycleActive))
+	assert.NoError(t, CheckLifecycleTransition(LifecycleSeasonal, LifecycleDecommissioned))
+
+	assert.ErrorIs(t, CheckLifecycleTransition(LifecycleActive, LifecycleActive), ErrInvalidTransition, "Staying put is no transition")
+	assert.ErrorIs(t, CheckLifecycleTransition(LifecycleDecommissioned, LifecycleActive), ErrInvalidTransition, "Decommissioned is final")
+	assert.EqualError(t, CheckLifecycleTransition(LifecycleActive, "demolished"), "invalid status transition: active to demolished")
+}
+
+func TestIsOutOfService(t *testing.T) {
+	for status, out := range map[string]bool{
+		LifecycleActive: false, LifecycleSeasonal: false, LifecycleClosed: true, LifecycleDecommissioned: true, "": false,
+	} {
+		a := Airport{LifecycleStatus: status}
+		assert.Equal(t, out, a.IsOutOfService(), status)
+	}
+	assert.True(t, IsLifecycleStatus(LifecycleSeasonal))
+	assert.False(t, IsLifecycleStatus("open"))
+}
@@ -0,0 +1,298 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// METAR is a decoded METAR or SPECI report. Groups the parser doesn't know are
+// kept in Unparsed rather than failing the report.
+type METAR struct {
+	Raw        string     `json:"raw"`
+	Type       string     `json:"type"`
+	Station    string     `json:"station"`
+	ObservedAt *time.Time `json:"observed_at,omitempty"`
+	Auto       bool       `json:"auto,omitempty"`
+	Corrected  bool       `json:"corrected,omitempty"`
+	Conditions
+	RunwayVisualRange []string `json:"runway_visual_range,omitempty"`
+	TemperatureC      *float64 `json:"temperature_c,omitempty"`
+	DewpointC         *float64 `json:"dewpoint_c,omitempty"`
+	AltimeterInHg     *float64 `json:"altimeter_inhg,omitempty"`
+	AltimeterHpa      *float64 `json:"altimeter_hpa,omitempty"`
+	Remarks           string   `json:"remarks,omitempty"`
+	Unparsed          []string `json:"unparsed,omitempty"`
+}
+
+// Conditions are the wind, visibility, weather and sky groups that METARs and
+// TAF forecast periods share.
+type Conditions struct {
+	Wind *METARWind `json:"wind,omitempty"`
+	// VisibilityAbove means more than VisibilitySM, as in P6SM, 9999 or CAVOK
+	VisibilitySM    *float64     `json:"visibility_sm,omitempty"`
+	VisibilityAbove bool         `json:"visibility_above,omitempty"`
+	Weather         []string     `json:"weather,omitempty"`
+	Clouds          []CloudLayer `json:"clouds,omitempty"`
+	CeilingFt       *int         `json:"ceiling_ft,omitempty"`
+}
+
+// METARWind is the wind group of a METAR in knots. DirectionDeg is nil for
+// variable wind (VRB); VariableFromDeg and VariableToDeg give the range of a
+// varying direction such as 250V310.
+type METARWind struct {
+	DirectionDeg    *int `json:"direction_deg,omitempty"`
+	Variable        bool `json:"variable,omitempty"`
+	SpeedKt         int  `json:"speed_kt"`
+	GustKt          int  `json:"gust_kt,omitempty"`
+	VariableFromDeg *int `json:"variable_from_deg,omitempty"`
+	VariableToDeg   *int `json:"variable_to_deg,omitempty"`
+}
+
+// CloudLayer is a sky condition group: a cover (FEW, SCT, BKN, OVC, or VV for
+// an obscured sky; CLR, SKC, NSC or NCD without a base) with its base in feet
+// above ground, and CB or TCU for convective clouds.
+type CloudLayer struct {
+	Cover  string `json:"cover"`
+	BaseFt *int   `json:"base_ft,omitempty"`
+	Type   string `json:"type,omitempty"`
+}
+
+// ParseMETARRequest is the payload of POST /parse/metar.
+type ParseMETARRequest struct {
+	Raw string `json:"raw"`
+}
+
+// Validate checks that there is a report to parse.
+func (r *ParseMETARRequest) Validate() ValidationErrors {
+	if strings.TrimSpace(r.Raw) == "" {
+		return ValidationErrors{{Field: "raw", Error: "is required"}}
+	}
+	return nil
+}
+
+var (
+	metarStationPattern    = regexp.MustCompile(`^[A-Z][A-Z0-9]{3}$`)
+	metarTimePattern       = regexp.MustCompile(`^(\d{2})(\d{2})(\d{2})Z$`)
+	metarWindPattern       = regexp.MustCompile(`^(\d{3}|VRB)(\d{2,3})(?:G(\d{2,3}))?(KT|MPS|KMH)$`)
+	metarWindVarPattern    = regexp.MustCompile(`^(\d{3})V(\d{3})$`)
+	metarVisibilityPattern = regexp.MustCompile(`^([MP])?(?:(\d+)|(\d)/(\d{1,2}))SM$`)
+	metarMetricVisPattern  = regexp.MustCompile(`^\d{4}$`)
+	metarRVRPattern        = regexp.MustCompile(`^R\d{2}[LCR]?/`)
+	metarCloudPattern      = regexp.MustCompile(`^(FEW|SCT|BKN|OVC|VV)(\d{3}|///)(CB|TCU)?$`)
+	metarTempPattern       = regexp.MustCompile(`^(M)?(\d{2})/(?:(M)?(\d{2}))?$`)
+	metarAltimeterPattern  = regexp.MustCompile(`^([AQ])(\d{4})$`)
+	metarWeatherPattern    = regexp.MustCompile(`^(?:\+|-|VC)?(?:MI|PR|BC|DR|BL|SH|TS|FZ)?(?:DZ|RA|SN|SG|IC|PL|GR|GS|UP|BR|FG|FU|VA|DU|SA|HZ|PY|PO|SQ|FC|SS|DS)*$`)
+)
+
+// hpaPerInHg converts altimeter settings.
+const hpaPerInHg = 33.8639
+
+// metresPerSM converts metric visibility.
+const metresPerSM = 1609.344
+
+// ParseMETAR decodes a raw METAR or SPECI. The day of month of its time group
+// is resolved against ref, the latest it can have been issued around; a zero
+// ref leaves ObservedAt out. Only a report without a station is an error.
+func ParseMETAR(raw string, ref time.Time) (*METAR, error) {
+	tokens := strings.Fields(strings.TrimSuffix(strings.TrimSpace(raw), "="))
+	m := &METAR{Raw: strings.Join(tokens, " "), Type: "METAR"}
+
+	if len(tokens) > 0 && (tokens[0] == "METAR" || tokens[0] == "SPECI") {
+		m.Type, tokens = tokens[0], tokens[1:]
+	}
+	if len(tokens) > 0 && tokens[0] == "COR" {
+		m.Corrected, tokens = true, tokens[1:]
+	}
+	if len(tokens) == 0 || !metarStationPattern.MatchString(tokens[0]) {
+		return nil, fmt.Errorf("%w: no station in %q", ErrInvalidMETAR, raw)
+	}
+	m.Station, tokens = tokens[0], tokens[1:]
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token == "RMK" {
+			m.Remarks = strings.Join(tokens[i+1:], " ")
+			break
+		}
+
+		if next, ok := m.decode(tokens, i); ok {
+			i = next
+			continue
+		}
+
+		switch {
+		case token == "AUTO":
+			m.Auto = true
+		case token == "COR":
+			m.Corrected = true
+		case metarTimePattern.MatchString(token):
+			if !ref.IsZero() {
+				m.ObservedAt = metarTime(token, ref)
+			}
+		case metarTempPattern.MatchString(token):
+			g := metarTempPattern.FindStringSubmatch(token)
+			m.TemperatureC = metarDegrees(g[1], g[2])
+			m.DewpointC = metarDegrees(g[3], g[4])
+		case metarAltimeterPattern.MatchString(token):
+			g := metarAltimeterPattern.FindStringSubmatch(token)
+			value, _ := strconv.ParseFloat(g[2], 64)
+			inHg, hpa := value/100, value
+			if g[1] == "A" {
+				hpa = math.Round(inHg*hpaPerInHg*10) / 10
+			} else {
+				inHg = math.Round(hpa/hpaPerInHg*100) / 100
+			}
+			m.AltimeterInHg, m.AltimeterHpa = &inHg, &hpa
+		case metarRVRPattern.MatchString(token):
+			m.RunwayVisualRange = append(m.RunwayVisualRange, token)
+		default:
+			m.Unparsed = append(m.Unparsed, token)
+		}
+	}
+
+	return m, nil
+}
+
+// decode reads the condition group at tokens[i], and the one after it when
+// the two belong together, returning the index of the last token it used.
+func (c *Conditions) decode(tokens []string, i int) (last int, ok bool) {
+	token := tokens[i]
+	switch {
+	case metarWindPattern.MatchString(token):
+		c.Wind = metarWind(token)
+	case c.Wind != nil && metarWindVarPattern.MatchString(token):
+		g := metarWindVarPattern.FindStringSubmatch(token)
+		from, _ := strconv.Atoi(g[1])
+		to, _ := strconv.Atoi(g[2])
+		c.Wind.VariableFromDeg, c.Wind.VariableToDeg = &from, &to
+	case token == "CAVOK":
+		visibility := 10000 / metresPerSM
+		c.VisibilitySM, c.VisibilityAbove = &visibility, true
+	case isWholeMiles(token) && i+1 < len(tokens) && metarVisibilityPattern.MatchString(tokens[i+1]):
+		// "1 1/2SM" splits whole miles from the fraction
+		fraction, _, ok := metarVisibility(tokens[i+1])
+		if !ok {
+			return i, false
+		}
+		whole, _ := strconv.ParseFloat(token, 64)
+		visibility := whole + fraction
+		c.VisibilitySM = &visibility
+		return i + 1, true
+	case metarVisibilityPattern.MatchString(token):
+		visibility, above, ok := metarVisibility(token)
+		if !ok {
+			return i, false
+		}
+		c.VisibilitySM, c.VisibilityAbove = &visibility, above
+	case c.VisibilitySM == nil && metarMetricVisPattern.MatchString(token):
+		metres, _ := strconv.Atoi(token)
+		visibility := math.Round(float64(metres)/metresPerSM*100) / 100
+		c.VisibilitySM, c.VisibilityAbove = &visibility, metres == 9999
+	case token == "CLR" || token == "SKC" || token == "NSC" || token == "NCD":
+		c.Clouds = append(c.Clouds, CloudLayer{Cover: token})
+	case metarCloudPattern.MatchString(token):
+		g := metarCloudPattern.FindStringSubmatch(token)
+		layer := CloudLayer{Cover: g[1], Type: g[3]}
+		if hundreds, err := strconv.Atoi(g[2]); err == nil {
+			base := hundreds * 100
+			layer.BaseFt = &base
+			if (g[1] == "BKN" || g[1] == "OVC" || g[1] == "VV") && (c.CeilingFt == nil || base < *c.CeilingFt) {
+				c.CeilingFt = &base
+			}
+		}
+		c.Clouds = append(c.Clouds, layer)
+	case len(token) >= 2 && metarWeatherPattern.MatchString(token):
+		c.Weather = append(c.Weather, token)
+	default:
+		return i, false
+	}
+	return i, true
+}
+
+// metarTime resolves a DDHHMMZ group to the latest such time no more than a
+// day after ref, within ref's month or the one before.
+func metarTime(token string, ref time.Time) *time.Time {
+	g := metarTimePattern.FindStringSubmatch(token)
+	day, _ := strconv.Atoi(g[1])
+	hour, _ := strconv.Atoi(g[2])
+	minute, _ := strconv.Atoi(g[3])
+	if day < 1 || hour > 23 || minute > 59 {
+		return nil
+	}
+
+	ref = ref.UTC()
+	for back := 0; back < 2; back++ {
+		first := time.Date(ref.Year(), ref.Month()-time.Month(back), 1, 0, 0, 0, 0, time.UTC)
+		t := time.Date(first.Year(), first.Month(), day, hour, minute, 0, 0, time.UTC)
+		if t.Month() != first.Month() {
+			continue // No such day in that month
+		}
+		if !t.After(ref.Add(24 * time.Hour)) {
+			return &t
+		}
+	}
+	return nil
+}
+
+func metarWind(token string) *METARWind {
+	g := metarWindPattern.FindStringSubmatch(token)
+	toKnots := func(s string) int {
+		v, _ := strconv.ParseFloat(s, 64)
+		switch g[4] {
+		case "MPS":
+			v *= 1.943844
+		case "KMH":
+			v *= 0.539957
+		}
+		return int(math.Round(v))
+	}
+
+	wind := &METARWind{SpeedKt: toKnots(g[2])}
+	if g[1] == "VRB" {
+		wind.Variable = true
+	} else {
+		dir, _ := strconv.Atoi(g[1])
+		wind.DirectionDeg = &dir
+	}
+	if g[3] != "" {
+		wind.GustKt = toKnots(g[3])
+	}
+	return wind
+}
+
+// metarVisibility reads a statute mile group such as 10SM, 1/4SM, M1/4SM or
+// P6SM; above means more than the value.
+func metarVisibility(token string) (visibility float64, above, ok bool) {
+	g := metarVisibilityPattern.FindStringSubmatch(token)
+	if g[2] != "" {
+		visibility, _ = strconv.ParseFloat(g[2], 64)
+	} else {
+		num, _ := strconv.ParseFloat(g[3], 64)
+		den, _ := strconv.ParseFloat(g[4], 64)
+		if den == 0 {
+			return 0, false, false
+		}
+		visibility = num / den
+	}
+	return visibility, g[1] == "P", true
+}
+
+func isWholeMiles(token string) bool {
+	return len(token) == 1 && token[0] >= '1' && token[0] <= '9'
+}
+
+// metarDegrees reads a temperature group, M marking below zero.
+func metarDegrees(minus, digits string) *float64 {
+	if digits == "" {
+		return nil
+	}
+	v, _ := strconv.ParseFloat(digits, 64)
+	if minus == "M" {
+		v = -v
+	}
+	return &v
+}
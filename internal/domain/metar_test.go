@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMETAR(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	ft := func(v int) *int { return &v }
+	ref := time.Date(2024, 6, 1, 13, 0, 0, 0, time.UTC)
+
+	m, err := ParseMETAR("METAR KJFK 011251Z 18012G20KT 150V210 10SM -RA BR FEW015 BKN025CB OVC040 21/M01 A2992 RMK AO2 SLP132=", ref)
+	assert.NoError(t, err)
+	observedAt := time.Date(2024, 6, 1, 12, 51, 0, 0, time.UTC)
+	assert.Equal(t, &METAR{
+		Raw:        "METAR KJFK 011251Z 18012G20KT 150V210 10SM -RA BR FEW015 BKN025CB OVC040 21/M01 A2992 RMK AO2 SLP132",
+		Type:       "METAR",
+		Station:    "KJFK",
+		ObservedAt: &observedAt,
+		Conditions: Conditions{
+			Wind:         &METARWind{DirectionDeg: ft(180), SpeedKt: 12, GustKt: 20, VariableFromDeg: ft(150), VariableToDeg: ft(210)},
+			VisibilitySM: ptr(10),
+			Weather:      []string{"-RA", "BR"},
+			Clouds: []CloudLayer{
+				{Cover: "FEW", BaseFt: ft(1500)},
+				{Cover: "BKN", BaseFt: ft(2500), Type: "CB"},
+				{Cover: "OVC", BaseFt: ft(4000)},
+			},
+			CeilingFt: ft(2500),
+		},
+		TemperatureC:  ptr(21),
+		DewpointC:     ptr(-1),
+		AltimeterInHg: ptr(29.92),
+		AltimeterHpa:  ptr(1013.2),
+		Remarks:       "AO2 SLP132",
+	}, m)
+
+	m, err = ParseMETAR("SPECI KSFO 302356Z AUTO VRB03KT 1 1/2SM FG VV002 12/12 A3001", ref)
+	assert.NoError(t, err)
+	assert.Equal(t, "SPECI", m.Type)
+	assert.True(t, m.Auto)
+	assert.Equal(t, time.Date(2024, 5, 30, 23, 56, 0, 0, time.UTC), *m.ObservedAt, "A day ahead of ref is last month's")
+	assert.Equal(t, &METARWind{Variable: true, SpeedKt: 3}, m.Wind)
+	assert.Equal(t, 1.5, *m.VisibilitySM)
+	assert.Equal(t, 200, *m.CeilingFt)
+
+	m, err = ParseMETAR("EGLL 011250Z 24008MPS 9999 SCT030 15/08 Q1013 NOSIG", time.Time{})
+	assert.NoError(t, err)
+	assert.Nil(t, m.ObservedAt, "No ref leaves the time out")
+	assert.Equal(t, 16, m.Wind.SpeedKt)
+	assert.Equal(t, 6.21, *m.VisibilitySM)
+	assert.True(t, m.VisibilityAbove)
+	assert.Nil(t, m.CeilingFt)
+	assert.Equal(t, 29.91, *m.AltimeterInHg)
+	assert.Equal(t, []string{"NOSIG"}, m.Unparsed)
+
+	m, err = ParseMETAR("KDEN 011253Z 00000KT P6SM CLR M05/ A3001", ref)
+	assert.NoError(t, err)
+	assert.True(t, m.VisibilityAbove)
+	assert.Equal(t, []CloudLayer{{Cover: "CLR"}}, m.Clouds)
+	assert.Equal(t, -5.0, *m.TemperatureC)
+	assert.Nil(t, m.DewpointC)
+
+	_, err = ParseMETAR("  ", ref)
+	assert.ErrorIs(t, err, ErrInvalidMETAR)
+	_, err = ParseMETAR("METAR 18012KT 10SM", ref)
+	assert.ErrorIs(t, err, ErrInvalidMETAR)
+}
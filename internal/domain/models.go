@@ -1,22 +1,80 @@
 package domain
 
+import (
+	"encoding/json"
+	"encoding/xml"
+	"time"
+)
+
 type Airport struct {
-	SiteNumber    string `json:"site_number"`
-	FacilityName  string `json:"facility_name"`
-	Faa           string `json:"faa_ident"`
-	Icao          string `json:"icao_ident"`
-	StateCode     string `json:"state"`
-	StateFull     string `json:"state_full"`
-	County        string `json:"county"`
-	City          string `json:"city"`
-	OwnershipType string `json:"ownership"`
-	UseType       string `json:"use"`
-	Manager       string `json:"manager"`
-	ManagerPhone  string `json:"manager_phone"`
-	Latitude      string `json:"latitude"`
-	Longitude     string `json:"longitude"`
-	AirportStatus string `json:"status"`
-	Weather       string `json:"weather"`
+	XMLName xml.Name `json:"-" xml:"airport"`
+
+	SiteNumber    string `json:"site_number" xml:"site_number"`
+	FacilityName  string `json:"facility_name" xml:"facility_name"`
+	Faa           string `json:"faa_ident" xml:"faa_ident"`
+	Icao          string `json:"icao_ident" xml:"icao_ident"`
+	StateCode     string `json:"state" xml:"state"`
+	StateFull     string `json:"state_full" xml:"state_full"`
+	County        string `json:"county" xml:"county"`
+	City          string `json:"city" xml:"city"`
+	OwnershipType string `json:"ownership" xml:"ownership"`
+	UseType       string `json:"use" xml:"use"`
+	Manager       string `json:"manager" xml:"manager"`
+	ManagerPhone  string `json:"manager_phone" xml:"manager_phone"`
+	AirportStatus string `json:"status" xml:"status"`
+	Weather       string `json:"weather" xml:"weather"`
+
+	// Position in decimal degrees, nil when unknown
+	Latitude  *float64 `json:"latitude,omitempty" xml:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty" xml:"longitude,omitempty"`
+
+	// Field elevation, and the altitudes computed from it at the last sync
+	ElevationFt        *float64 `json:"elevation_ft,omitempty" xml:"elevation_ft,omitempty"`
+	PressureAltitudeFt *int     `json:"pressure_altitude_ft,omitempty" xml:"pressure_altitude_ft,omitempty"`
+	DensityAltitudeFt  *int     `json:"density_altitude_ft,omitempty" xml:"density_altitude_ft,omitempty"`
+
+	// Magnetic variation in degrees, east positive
+	MagneticVariation *float64 `json:"magnetic_variation,omitempty" xml:"magnetic_variation,omitempty"`
+
+	// IANA zone derived from the position, e.g. "America/Chicago"
+	Timezone string `json:"timezone,omitempty" xml:"timezone,omitempty"`
+
+	// Fuel types sold, among the Fuel constants, and the FBO to call about
+	// them, when the provider has them
+	FuelTypes []string `json:"fuel_types,omitempty" xml:"fuel_type,omitempty"`
+	FboName   string   `json:"fbo_name,omitempty" xml:"fbo_name,omitempty"`
+	FboPhone  string   `json:"fbo_phone,omitempty" xml:"fbo_phone,omitempty"`
+
+	// Fields edited by hand, by JSON name, which syncs leave alone
+	ManualOverrides []string `json:"manual_overrides,omitempty" xml:"manual_override,omitempty"`
+
+	// One of the Lifecycle statuses, changed only by an AirportStatusChange;
+	// unlike AirportStatus it is not synced from the Aviation API
+	LifecycleStatus string `json:"lifecycle_status,omitempty" xml:"lifecycle_status,omitempty"`
+
+	// Maintained by the repository; LastSyncedAt tells how fresh Weather is
+	CreatedAt    *time.Time `json:"created_at,omitempty" xml:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty" xml:"last_synced_at,omitempty"`
+}
+
+// RowError describes why a single imported row was rejected.
+type RowError struct {
+	Row   int    `json:"row"`
+	Faa   string `json:"faa_ident"`
+	Error string `json:"error"`
+}
+
+type ImportReport struct {
+	Imported int        `json:"imported"`
+	Failed   int        `json:"failed"`
+	Errors   []RowError `json:"errors"`
+}
+
+type BulkCreateReport struct {
+	Created []string   `json:"created"`
+	Skipped []string   `json:"skipped"`
+	Failed  []RowError `json:"failed"`
 }
 
 type WeatherResponse struct {
@@ -27,8 +85,661 @@ type WeatherResponse struct {
 	} `json:"current"`
 }
 
+// Observation is a current weather report normalized across providers.
+type Observation struct {
+	Provider     string    `json:"provider"`
+	Condition    string    `json:"condition"`
+	TemperatureC float64   `json:"temperature_c"`
+	DewpointC    float64   `json:"dewpoint_c"`
+	HumidityPct  float64   `json:"humidity_pct"`
+	WindDirDeg   int       `json:"wind_dir_deg"`
+	WindSpeedKt  float64   `json:"wind_speed_kt"`
+	WindGustKt   float64   `json:"wind_gust_kt"`
+	VisibilitySM float64   `json:"visibility_sm"`
+	PressureHpa  float64   `json:"pressure_hpa"`
+	RawMETAR     string    `json:"raw_metar,omitempty"`
+	ObservedAt   time.Time `json:"observed_at"`
+}
+
 type ApiResponse struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
-	Data    any    `json:"data"`
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Data      any    `json:"data"`
+}
+
+// ProviderStatus reports the circuit breaker of one external provider.
+type ProviderStatus struct {
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Failures int    `json:"failures"`
+	Trips    int64  `json:"trips"`
+}
+
+// HealthStatus is the payload of the health endpoint.
+type HealthStatus struct {
+	Providers []ProviderStatus `json:"providers"`
+}
+
+// Dependency states in the readiness report.
+const (
+	DependencyUp       = "up"
+	DependencyDegraded = "degraded"
+	DependencyDown     = "down"
+)
+
+// DependencyStatus is the state of one dependency of the API.
+type DependencyStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Readiness is the payload of the readiness endpoint. The API is ready while
+// no dependency is down.
+type Readiness struct {
+	Ready        bool               `json:"ready"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+	Providers    []ProviderStatus   `json:"providers"`
+	LastSyncAt   *time.Time         `json:"last_sync_at"`
+}
+
+// RuntimeStats is a snapshot of the process, to diagnose goroutine leaks and
+// sync backlogs.
+type RuntimeStats struct {
+	Goroutines     int    `json:"goroutines"`
+	HeapAllocBytes uint64 `json:"heap_alloc_bytes"`
+	HeapObjects    uint64 `json:"heap_objects"`
+	NumGC          uint32 `json:"num_gc"`
+	// Single airport and full syncs queued by this process and waited for
+	SyncQueueDepth    int `json:"sync_queue_depth"`
+	SyncAllQueueDepth int `json:"sync_all_queue_depth"`
+	// Airports being synced through the queue, and chunk workers of running
+	// bulk syncs
+	SyncsInFlight     int   `json:"syncs_in_flight"`
+	SyncWorkers       int64 `json:"sync_workers"`
+	ChangeSubscribers int   `json:"change_subscribers"`
+}
+
+// CategoryUnknown counts airports whose weather can't be classified, such as
+// those never synced.
+const CategoryUnknown = "unknown"
+
+// AirportStats summarizes the stored airports. StaleWeather counts airports
+// not synced within StaleAfter.
+type AirportStats struct {
+	TotalAirports    int            `json:"total_airports"`
+	ByState          map[string]int `json:"by_state"`
+	ByOwnership      map[string]int `json:"by_ownership"`
+	ByFlightCategory map[string]int `json:"by_flight_category"`
+	StaleWeather     int            `json:"stale_weather"`
+	StaleAfter       string         `json:"stale_after"`
+}
+
+// Dashboard summarizes the current conditions of the stored airports as of
+// the last refresh of the current_conditions view, after the last sync.
+type Dashboard struct {
+	TotalAirports    int              `json:"total_airports"`
+	ByFlightCategory map[string]int   `json:"by_flight_category"`
+	States           []DashboardState `json:"states"`
+	LastSyncedAt     *time.Time       `json:"last_synced_at,omitempty"`
+	RefreshedAt      *time.Time       `json:"refreshed_at,omitempty"`
+}
+
+// DashboardState counts the airports of one state by flight category.
+type DashboardState struct {
+	State            string         `json:"state"`
+	Airports         int            `json:"airports"`
+	ByFlightCategory map[string]int `json:"by_flight_category"`
+}
+
+// CacheStats reports usage of the weather and airport cache.
+type CacheStats struct {
+	Name     string  `json:"name,omitempty"`
+	Enabled  bool    `json:"enabled"`
+	Backend  string  `json:"backend,omitempty"`
+	Hits     int64   `json:"hits"`
+	Misses   int64   `json:"misses"`
+	Entries  int     `json:"entries"`
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+// Frequency is one published COM frequency of an airport, such as tower or ATIS.
+type Frequency struct {
+	Type         string  `json:"type"`
+	Description  string  `json:"description"`
+	FrequencyMHz float64 `json:"frequency_mhz"`
+	Cycle        string  `json:"cycle,omitempty"`
+}
+
+// Runway is one runway end, e.g. "17R", with its true heading.
+type Runway struct {
+	Ident      string  `json:"ident"`
+	HeadingDeg float64 `json:"heading_deg"`
+	LengthFt   int     `json:"length_ft"`
+	WidthFt    int     `json:"width_ft"`
+	Surface    string  `json:"surface"`
+	Cycle      string  `json:"cycle,omitempty"`
+}
+
+// RunwayWind is the wind component along and across one runway end. A negative
+// headwind is a tailwind; a negative crosswind comes from the left.
+type RunwayWind struct {
+	Runway          string  `json:"runway"`
+	HeadingDeg      float64 `json:"heading_deg"`
+	HeadwindKt      float64 `json:"headwind_kt"`
+	CrosswindKt     float64 `json:"crosswind_kt"`
+	GustCrosswindKt float64 `json:"gust_crosswind_kt,omitempty"`
+}
+
+// WindComponents is the payload of the wind components endpoint.
+type WindComponents struct {
+	Faa         string       `json:"faa_ident"`
+	WindDirDeg  int          `json:"wind_dir_deg"`
+	WindSpeedKt float64      `json:"wind_speed_kt"`
+	WindGustKt  float64      `json:"wind_gust_kt,omitempty"`
+	ObservedAt  time.Time    `json:"observed_at"`
+	Recommended string       `json:"recommended_runway"`
+	Runways     []RunwayWind `json:"runways"`
+}
+
+// AirportWeather is the weather of one airport without the rest of its
+// record. Observation is nil until a sync stored one; FlightCategory is empty
+// when it can't be classified.
+type AirportWeather struct {
+	Faa            string       `json:"faa_ident"`
+	Condition      string       `json:"weather"`
+	FlightCategory string       `json:"flight_category,omitempty"`
+	Observation    *Observation `json:"observation"`
+	LastSyncedAt   *time.Time   `json:"last_synced_at,omitempty"`
+}
+
+// Performance is the payload of the performance endpoint: the altitudes that
+// drive takeoff and climb performance under the last synced weather.
+type Performance struct {
+	Faa                string    `json:"faa_ident"`
+	ElevationFt        float64   `json:"elevation_ft"`
+	TemperatureC       float64   `json:"temperature_c"`
+	AltimeterInHg      float64   `json:"altimeter_inhg"`
+	ISADeviationC      float64   `json:"isa_deviation_c"`
+	PressureAltitudeFt int       `json:"pressure_altitude_ft"`
+	DensityAltitudeFt  int       `json:"density_altitude_ft"`
+	ObservedAt         time.Time `json:"observed_at"`
+}
+
+// Daylight is the payload of the daylight endpoint. Times are in the airport's
+// local zone; events the sun doesn't reach that day (polar day or night) are omitted.
+type Daylight struct {
+	Faa             string     `json:"faa_ident"`
+	Timezone        string     `json:"timezone"`
+	Date            string     `json:"date"`
+	CivilDawn       *time.Time `json:"civil_dawn,omitempty"`
+	Sunrise         *time.Time `json:"sunrise,omitempty"`
+	Sunset          *time.Time `json:"sunset,omitempty"`
+	CivilDusk       *time.Time `json:"civil_dusk,omitempty"`
+	DaylightMinutes int        `json:"daylight_minutes"`
+}
+
+// NearbyAirport is an airport found by a position search, with its distance.
+type NearbyAirport struct {
+	Airport
+	DistanceNM float64 `json:"distance_nm"`
+}
+
+// RouteAirport is one airport within the corridor of a route, with its latest
+// stored weather. FlightCategory is empty when the weather can't be classified.
+type RouteAirport struct {
+	Faa            string     `json:"faa_ident"`
+	Icao           string     `json:"icao_ident"`
+	FacilityName   string     `json:"facility_name"`
+	AlongTrackNM   float64    `json:"along_track_nm"`
+	OffsetNM       float64    `json:"offset_nm"`
+	Condition      string     `json:"condition"`
+	FlightCategory string     `json:"flight_category,omitempty"`
+	ObservedAt     *time.Time `json:"observed_at,omitempty"`
+}
+
+// RouteWeather is the payload of the route weather endpoint. Airports are
+// ordered by distance along the route; OffsetNM is positive right of course.
+type RouteWeather struct {
+	From       string         `json:"from"`
+	To         string         `json:"to"`
+	DistanceNM float64        `json:"distance_nm"`
+	CourseDeg  float64        `json:"course_deg"`
+	CorridorNM float64        `json:"corridor_nm"`
+	Airports   []RouteAirport `json:"airports"`
+}
+
+// Alternate is a nearby airport reporting good enough weather to divert to.
+type Alternate struct {
+	Faa            string    `json:"faa_ident"`
+	Icao           string    `json:"icao_ident"`
+	FacilityName   string    `json:"facility_name"`
+	DistanceNM     float64   `json:"distance_nm"`
+	BearingDeg     float64   `json:"bearing_deg"`
+	Condition      string    `json:"condition"`
+	FlightCategory string    `json:"flight_category"`
+	ObservedAt     time.Time `json:"observed_at"`
+}
+
+// Alert rule metrics, read from the latest observation.
+const (
+	MetricWindSpeed      = "wind_speed_kt"
+	MetricWindGust       = "wind_gust_kt"
+	MetricVisibility     = "visibility_sm"
+	MetricTemperature    = "temperature_c"
+	MetricFlightCategory = "flight_category"
+)
+
+// Alert rule operators. Numeric metrics compare against Threshold;
+// flight_category only supports worse_than, against Category.
+const (
+	OpGreaterThan = "gt"
+	OpGreaterOrEq = "gte"
+	OpLessThan    = "lt"
+	OpLessOrEq    = "lte"
+	OpWorseThan   = "worse_than"
+)
+
+// AlertRule raises an alert when a synced observation matches it, e.g. wind_speed_kt
+// gt 25. A rule applies to one airport (Faa), one state, or every airport when
+// both are empty.
+type AlertRule struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Faa       string  `json:"faa_ident,omitempty"`
+	StateCode string  `json:"state,omitempty"`
+	Metric    string  `json:"metric"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold,omitempty"`
+	Category  string  `json:"category,omitempty"`
+	// Notify lists where the rule's alerts are sent besides the alert.fired webhooks.
+	Notify    []NotifyTarget `json:"notify,omitempty"`
+	TenantID  int64          `json:"-"`
+	CreatedAt *time.Time     `json:"created_at,omitempty"`
+}
+
+// Notification channels of alert rules.
+const (
+	ChannelEmail = "email"
+	ChannelSlack = "slack"
+	// ChannelWebhook posts weather digests as JSON. Alert rules don't use it.
+	ChannelWebhook = "webhook"
+)
+
+// NotifyTarget is one destination of alert notifications: an email address
+// for ChannelEmail or an incoming webhook URL for ChannelSlack.
+type NotifyTarget struct {
+	Channel string `json:"channel"`
+	Target  string `json:"target"`
+}
+
+// Alert records one rule matching one airport's observation. It belongs to the
+// tenant of its rule.
+type Alert struct {
+	ID         int64      `json:"id"`
+	RuleID     int64      `json:"rule_id"`
+	Faa        string     `json:"faa_ident"`
+	Metric     string     `json:"metric"`
+	Value      string     `json:"value"`
+	Message    string     `json:"message"`
+	ObservedAt time.Time  `json:"observed_at"`
+	TenantID   int64      `json:"-"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+}
+
+// Webhook events.
+const (
+	EventWeatherChanged = "weather.changed"
+	EventSyncCompleted  = "sync.completed"
+	EventAlertFired     = "alert.fired"
+	// EventWeatherDigest is posted to digest subscriptions, not to webhooks.
+	EventWeatherDigest = "weather.digest"
+)
+
+// Operations of an AirportChange.
+const (
+	ChangeInsert = "insert"
+	ChangeUpdate = "update"
+	ChangeDelete = "delete"
+)
+
+// AirportChange is one write to the airport table, by this service or any
+// other writer, as announced by the database.
+type AirportChange struct {
+	Op  string `json:"op"`
+	Faa string `json:"faa_ident"`
+}
+
+// EventAirportUpdated is published to the message broker whenever an airport
+// is saved, by a sync or an edit. Webhooks don't receive it.
+const EventAirportUpdated = "airport.updated"
+
+// Webhook is a subscriber notified by POST of the events it lists. When Secret
+// is set, each body is signed with HMAC-SHA256 in the X-Webhook-Signature header.
+type Webhook struct {
+	ID        int64      `json:"id"`
+	URL       string     `json:"url"`
+	Secret    string     `json:"secret,omitempty"`
+	Events    []string   `json:"events"`
+	TenantID  int64      `json:"-"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// WebhookEvent is the JSON body posted to webhooks.
+type WebhookEvent struct {
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// WebhookDelivery logs one event sent to one webhook, after all its attempts.
+type WebhookDelivery struct {
+	ID         int64           `json:"id"`
+	WebhookID  int64           `json:"webhook_id"`
+	Event      string          `json:"event"`
+	Payload    json.RawMessage `json:"payload"`
+	Attempts   int             `json:"attempts"`
+	StatusCode int             `json:"status_code,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Succeeded  bool            `json:"succeeded"`
+	CreatedAt  *time.Time      `json:"created_at,omitempty"`
+}
+
+// WeatherChange is the data of a weather.changed event.
+type WeatherChange struct {
+	Faa        string    `json:"faa_ident"`
+	Previous   string    `json:"previous"`
+	Current    string    `json:"current"`
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+// SyncCompleted is the data of a sync.completed event. Faa is set when a
+// single airport was synced.
+type SyncCompleted struct {
+	Faa     string `json:"faa_ident,omitempty"`
+	Updated int    `json:"updated"`
+	Failed  int    `json:"failed"`
+}
+
+// MaxWatchlistAirports caps the airports of one watchlist.
+const MaxWatchlistAirports = 100
+
+// Watchlist is a named list of airports kept by one API key. Owner is the
+// SHA-256 of that key, so keys are never stored.
+type Watchlist struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Faas      []string   `json:"faa_codes"`
+	Owner     string     `json:"-"`
+	TenantID  int64      `json:"-"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// MaxNoteLength caps the body of an AirportNote, in characters.
+const MaxNoteLength = 1000
+
+// AirportNote is an operational remark recorded on an airport by a
+// dispatcher, e.g. "Fuel unavailable this week", shown with the airport until
+// it expires or is deleted. Notes without ExpiresAt stay until deleted.
+type AirportNote struct {
+	ID        int64      `json:"id" xml:"id"`
+	Faa       string     `json:"faa_ident" xml:"faa_ident"`
+	Body      string     `json:"body" xml:"body"`
+	Author    string     `json:"author,omitempty" xml:"author,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" xml:"expires_at,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty" xml:"created_at,omitempty"`
+}
+
+// WatchlistBoard is the payload of the watchlist weather endpoint: the
+// current conditions of each airport, in watchlist order.
+type WatchlistBoard struct {
+	ID       int64        `json:"id"`
+	Name     string       `json:"name"`
+	Airports []BoardEntry `json:"airports"`
+}
+
+// BoardEntry is one line of a WatchlistBoard. Only Faa is set for an airport
+// that is not stored, and the observed fields are nil until a sync.
+type BoardEntry struct {
+	Faa            string     `json:"faa_ident"`
+	FacilityName   string     `json:"facility_name,omitempty"`
+	Weather        string     `json:"weather,omitempty"`
+	FlightCategory string     `json:"flight_category,omitempty"`
+	TemperatureC   *float64   `json:"temperature_c,omitempty"`
+	WindDirDeg     *int       `json:"wind_dir_deg,omitempty"`
+	WindSpeedKt    *float64   `json:"wind_speed_kt,omitempty"`
+	WindGustKt     *float64   `json:"wind_gust_kt,omitempty"`
+	VisibilitySM   *float64   `json:"visibility_sm,omitempty"`
+	ObservedAt     *time.Time `json:"observed_at,omitempty"`
+	LastSyncedAt   *time.Time `json:"last_synced_at,omitempty"`
+}
+
+// MaxSubscriptionAirports caps the airports of one digest subscription.
+const MaxSubscriptionAirports = 100
+
+// Subscription sends a digest of the current conditions at its airports on the
+// digest schedule, by email or as a JSON POST to a webhook URL. Like a
+// Webhook, a set Secret signs each POST. Owner is the SHA-256 of the API key
+// that subscribed.
+type Subscription struct {
+	ID         int64      `json:"id"`
+	Faas       []string   `json:"faa_codes"`
+	Channel    string     `json:"channel"`
+	Target     string     `json:"target"`
+	Secret     string     `json:"secret,omitempty"`
+	Owner      string     `json:"-"`
+	TenantID   int64      `json:"-"`
+	LastSentAt *time.Time `json:"last_sent_at,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+}
+
+// Digest is one scheduled report of a Subscription, the body posted to
+// webhook subscribers. Forecasts leave out airports without a current TAF.
+type Digest struct {
+	Event          string            `json:"event"`
+	SubscriptionID int64             `json:"subscription_id"`
+	GeneratedAt    time.Time         `json:"generated_at"`
+	Airports       []BoardEntry      `json:"airports"`
+	Forecasts      []ForecastSummary `json:"forecasts,omitempty"`
+}
+
+// ForecastSummary sums up the TAF of one digest airport: the conditions it
+// forecasts when the digest is generated and at LaterAt, each led by its
+// flight category, e.g. "IFR, wind 300 at 10KT, 3SM BR, ceiling 800ft", and
+// the TEMPO, PROB and BECMG groups under way, e.g. "TEMPO 2SM TSRA".
+type ForecastSummary struct {
+	Faa       string     `json:"faa_ident"`
+	Station   string     `json:"station"`
+	Now       string     `json:"now"`
+	LaterAt   *time.Time `json:"later_at,omitempty"`
+	Later     string     `json:"later,omitempty"`
+	Temporary []string   `json:"temporary,omitempty"`
+}
+
+// DefaultTenantID is the tenant of data created before tenants existed and of
+// requests without a registered API key.
+const DefaultTenantID int64 = 1
+
+// AllTenants stands for every tenant where a tenant id filters, e.g. for the
+// webhooks of events that aren't tenant data.
+const AllTenants int64 = 0
+
+// Tenant is one customer of the API. Alert rules, alerts, webhooks,
+// watchlists and subscriptions belong to a tenant and are only visible to it.
+type Tenant struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// APIKey maps an X-API-Key onto its tenant. Only the SHA-256 of the key is
+// stored, so Key is only set in the response issuing it.
+type APIKey struct {
+	ID        int64      `json:"id"`
+	TenantID  int64      `json:"tenant_id"`
+	Name      string     `json:"name,omitempty"`
+	Key       string     `json:"key,omitempty"`
+	KeyHash   string     `json:"-"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// Jobs of the scheduler's registry.
+const (
+	JobSyncAll          = "sync_all"
+	JobSyncStale        = "sync_stale"
+	JobSendDigests      = "send_digests"
+	JobPruneLogs        = "prune_logs"
+	JobRetryFailed      = "retry_failed"
+	JobPartitionHistory = "partition_history"
+	JobSyncAdvisories   = "sync_advisories"
+	JobSyncCharts       = "sync_charts"
+	JobRefreshStatic    = "refresh_static"
+	JobRefreshNOTAMs    = "refresh_notams"
+)
+
+// SchedulerJobNames lists the jobs of the registry.
+var SchedulerJobNames = []string{JobSyncAll, JobSyncStale, JobSendDigests, JobPruneLogs, JobRetryFailed, JobPartitionHistory, JobSyncAdvisories, JobSyncCharts, JobRefreshStatic, JobRefreshNOTAMs}
+
+// SchedulerJob runs one job of the registry on a cron spec. Definitions come
+// from config, and rows of the scheduler_jobs table override them by name; a
+// row without a schedule keeps that of the config.
+type SchedulerJob struct {
+	Name           string     `json:"name"`
+	Schedule       string     `json:"schedule"`
+	Enabled        bool       `json:"enabled"`
+	RunRequestedAt *time.Time `json:"run_requested_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+// Types of a QueuedJob.
+const (
+	QueuedSyncAirport = "sync_airport"
+	QueuedSyncAll     = "sync_all"
+)
+
+// Statuses of a QueuedJob.
+const (
+	JobStatusQueued  = "queued"
+	JobStatusRunning = "running"
+	JobStatusDone    = "done"
+	JobStatusFailed  = "failed"
+)
+
+// QueuedJob is a job of the job_queue table, run by the first worker to claim
+// it once RunAt has passed. A running job's RunAt is the end of its worker's
+// lease, after which another worker takes it over. Failed attempts are
+// retried until MaxAttempts; Result holds the outcome of a done job.
+type QueuedJob struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	LastError   string          `json:"last_error,omitempty"`
+	Result      json.RawMessage `json:"result,omitempty"`
+	CreatedAt   *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time      `json:"updated_at,omitempty"`
+}
+
+// ProviderResponse is a provider response kept in provider_cache, revalidated
+// with its ETag or Last-Modified on the next request for the same URL instead
+// of being sent again.
+type ProviderResponse struct {
+	URL          string
+	ETag         string
+	LastModified string
+	Body         []byte
+	FetchedAt    time.Time
+}
+
+// OutboxEvent is an event saved in event_outbox in the same transaction as
+// the airport update it announces, kept until it has been delivered. Attempts
+// counts the deliveries that failed.
+type OutboxEvent struct {
+	ID        int64
+	TenantID  int64
+	Event     string
+	Payload   json.RawMessage
+	Attempts  int
+	CreatedAt time.Time
+}
+
+// SyncFailure is an airport whose last sync failed, retried by the
+// retry_failed job once NextRetryAt has passed. Attempts counts the failures
+// in a row.
+type SyncFailure struct {
+	Faa          string     `json:"faa"`
+	Error        string     `json:"error"`
+	Attempts     int        `json:"attempts"`
+	LastFailedAt *time.Time `json:"last_failed_at,omitempty"`
+	NextRetryAt  *time.Time `json:"next_retry_at,omitempty"`
+}
+
+// Statuses of an AirportConflict.
+const (
+	ConflictPending  = "pending"
+	ConflictAccepted = "accepted"
+	ConflictRejected = "rejected"
+)
+
+// AirportConflict is a value fetched from the Aviation API that disagrees with
+// a field overridden by hand. Syncs keep the local value; an admin accepts the
+// provider's, which ends the override, or rejects it, which keeps the local
+// value and silences the same provider value from then on. Values are in
+// their text form, numbers as decimals.
+type AirportConflict struct {
+	ID            int64      `json:"id"`
+	Faa           string     `json:"faa_ident"`
+	Field         string     `json:"field"`
+	LocalValue    string     `json:"local_value"`
+	ProviderValue string     `json:"provider_value"`
+	Status        string     `json:"status"`
+	DetectedAt    *time.Time `json:"detected_at,omitempty"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+}
+
+// Advisory types.
+const (
+	AdvisorySIGMET = "SIGMET"
+	AdvisoryAIRMET = "AIRMET"
+)
+
+// Advisory is a SIGMET or AIRMET forecasting Hazard (such as "TURB", "ICE" or
+// "CONVECTIVE") over the polygon Area, between its altitudes, from ValidFrom
+// to ValidTo.
+type Advisory struct {
+	ID             int64           `json:"id"`
+	Type           string          `json:"type"`
+	Hazard         string          `json:"hazard"`
+	Severity       int             `json:"severity,omitempty"`
+	AltitudeLowFt  *int            `json:"altitude_low_ft,omitempty"`
+	AltitudeHighFt *int            `json:"altitude_high_ft,omitempty"`
+	ValidFrom      *time.Time      `json:"valid_from,omitempty"`
+	ValidTo        *time.Time      `json:"valid_to,omitempty"`
+	RawText        string          `json:"raw_text"`
+	Area           []AdvisoryPoint `json:"area"`
+}
+
+// AdvisoryPoint is a vertex of the area of an Advisory, in decimal degrees.
+type AdvisoryPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// NOTAM is a notice to air missions issued for an airport. Type is N for a
+// new NOTAM, R for one replacing and C for one cancelling an earlier NOTAM,
+// and EffectiveEnd is nil for a permanent one.
+type NOTAM struct {
+	ID             string     `json:"id"`
+	Faa            string     `json:"faa_ident"`
+	Number         string     `json:"number"`
+	Type           string     `json:"type"`
+	Classification string     `json:"classification,omitempty"`
+	IssuedAt       *time.Time `json:"issued_at,omitempty"`
+	EffectiveStart *time.Time `json:"effective_start,omitempty"`
+	EffectiveEnd   *time.Time `json:"effective_end,omitempty"`
+	Text           string     `json:"text"`
 }
@@ -8,6 +8,7 @@ import (
 )
 
 func TestAirportJSONMarshalUnmarshal(t *testing.T) {
+	lat, lon := 34.0522, -118.2437
 	// Sample Airport data
 	expectedAirport := Airport{
 		SiteNumber:    "12345",
@@ -22,8 +23,8 @@ func TestAirportJSONMarshalUnmarshal(t *testing.T) {
 		UseType:       "Public Use",
 		Manager:       "Test Manager",
 		ManagerPhone:  "123-456-7890",
-		Latitude:      "34.0522",
-		Longitude:     "-118.2437",
+		Latitude:      &lat,
+		Longitude:     &lon,
 		AirportStatus: "Open",
 		Weather:       "Clear",
 	}
@@ -32,7 +33,7 @@ func TestAirportJSONMarshalUnmarshal(t *testing.T) {
 	jsonBytes, err := json.Marshal(expectedAirport)
 	assert.NoError(t, err, "Should marshal Airport without error")
 
-	expectedJSON := `{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":"34.0522","longitude":"-118.2437","status":"Open","weather":"Clear"}`
+	expectedJSON := `{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":34.0522,"longitude":-118.2437,"status":"Open","weather":"Clear"}`
 	assert.JSONEq(t, expectedJSON, string(jsonBytes), "Marshaled JSON should match expected")
 
 	// Test Unmarshal (decoding, data format -> go)
@@ -43,6 +44,12 @@ func TestAirportJSONMarshalUnmarshal(t *testing.T) {
 	assert.Equal(t, expectedAirport, actualAirport, "Unmarshaled Airport should match original")
 }
 
+func TestFormatDMS(t *testing.T) {
+	assert.Equal(t, "33-38-12.1186N", FormatDMS(33.636699611, false))
+	assert.Equal(t, "084-25-40.3104W", FormatDMS(-84.427864, true))
+	assert.Equal(t, "01-00-00.0000S", FormatDMS(-0.99999999999, false), "Seconds round up into the next minute and degree")
+}
+
 func TestWeatherResponseJSONMarshalUnmarshal(t *testing.T) {
 	// Sample WeatherResponse data
 	expectedWeather := WeatherResponse{
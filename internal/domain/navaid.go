@@ -0,0 +1,45 @@
+package domain
+
+// Navaid types kept from the FAA NAVAID file; fan markers, VOTs and marine
+// beacons are left out.
+const (
+	NavaidVOR    = "VOR"
+	NavaidVORTAC = "VORTAC"
+	NavaidVORDME = "VOR/DME"
+	NavaidNDB    = "NDB"
+	NavaidNDBDME = "NDB/DME"
+	NavaidDME    = "DME"
+	NavaidTACAN  = "TACAN"
+)
+
+// IsNavaidType reports whether t is one of the navaid types stored.
+func IsNavaidType(t string) bool {
+	switch t {
+	case NavaidVOR, NavaidVORTAC, NavaidVORDME, NavaidNDB, NavaidNDBDME, NavaidDME, NavaidTACAN:
+		return true
+	}
+	return false
+}
+
+// Navaid is a radio navigation aid. Frequency is in kHz for NDBs and MHz for
+// the others, and left out for DMEs and TACANs tuned by Channel.
+type Navaid struct {
+	Ident       string   `json:"ident"`
+	Type        string   `json:"type"`
+	Name        string   `json:"name"`
+	City        string   `json:"city"`
+	StateCode   string   `json:"state"`
+	Frequency   *float64 `json:"frequency,omitempty"`
+	Channel     string   `json:"channel,omitempty"`
+	Latitude    float64  `json:"latitude"`
+	Longitude   float64  `json:"longitude"`
+	ElevationFt *float64 `json:"elevation_ft,omitempty"`
+	Status      string   `json:"status"`
+	Cycle       string   `json:"cycle,omitempty"`
+}
+
+// NearbyNavaid is a navaid found by a position search, with its distance.
+type NearbyNavaid struct {
+	Navaid
+	DistanceNM float64 `json:"distance_nm"`
+}
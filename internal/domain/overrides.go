@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// OverridableFields are the airport fields, by JSON name, that syncs fill in
+// from the Aviation API. One edited by hand is listed in ManualOverrides and
+// keeps its value through later syncs.
+var OverridableFields = []string{
+	"site_number", "facility_name", "icao_ident", "state", "state_full", "county", "city",
+	"ownership", "use", "manager", "manager_phone", "status",
+	"latitude", "longitude", "elevation_ft", "magnetic_variation",
+	"fuel_types", "fbo_name", "fbo_phone",
+}
+
+// airportFields indexes the fields of Airport by JSON name.
+var airportFields = func() map[string]int {
+	t := reflect.TypeFor[Airport]()
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		fields[name] = i
+	}
+	return fields
+}()
+
+func (a *Airport) field(name string) reflect.Value {
+	return reflect.ValueOf(a).Elem().Field(airportFields[name])
+}
+
+// IsOverridden reports whether field was edited by hand.
+func (a *Airport) IsOverridden(field string) bool {
+	return slices.Contains(a.ManualOverrides, field)
+}
+
+// IsSet reports whether field, one of OverridableFields, has a value: a
+// non-empty string, a non-nil number or a non-nil list.
+func (a *Airport) IsSet(field string) bool {
+	return !a.field(field).IsZero()
+}
+
+// EditedFields lists the OverridableFields whose values in a differ from
+// those in before.
+func (a *Airport) EditedFields(before *Airport) []string {
+	var edited []string
+	for _, field := range OverridableFields {
+		if !reflect.DeepEqual(a.field(field).Interface(), before.field(field).Interface()) {
+			edited = append(edited, field)
+		}
+	}
+	return edited
+}
+
+// MergeFetched takes the values of airport data fetched from the Aviation
+// API, field by field. Overridden fields keep theirs, as do fields the
+// provider left unset; other fields, such as the weather, are left to the
+// caller. It returns the conflicts: the overridden fields the provider set
+// to another value.
+func (a *Airport) MergeFetched(fetched *Airport) []AirportConflict {
+	var conflicts []AirportConflict
+	for _, field := range OverridableFields {
+		if !fetched.IsSet(field) {
+			continue
+		}
+		if a.IsOverridden(field) {
+			if local, provider := a.FieldText(field), fetched.FieldText(field); local != provider {
+				conflicts = append(conflicts, AirportConflict{
+					Faa: a.Faa, Field: field, LocalValue: local, ProviderValue: provider, Status: ConflictPending,
+				})
+			}
+			continue
+		}
+		a.field(field).Set(fetched.field(field))
+	}
+	return conflicts
+}
+
+// FieldText renders field, one of OverridableFields, as text: strings as
+// they are, numbers as decimals, lists joined by commas and unset numbers
+// as "".
+func (a *Airport) FieldText(field string) string {
+	switch v := a.field(field).Interface().(type) {
+	case string:
+		return v
+	case *float64:
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// SetFieldText sets field, one of OverridableFields, from its FieldText form.
+func (a *Airport) SetFieldText(field, text string) error {
+	v := a.field(field)
+	switch v.Interface().(type) {
+	case string:
+		v.SetString(text)
+	case *float64:
+		if text == "" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", field, text, err)
+		}
+		v.Set(reflect.ValueOf(&f))
+	case []string:
+		list := []string{}
+		if text != "" {
+			list = strings.Split(text, ",")
+		}
+		v.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("cannot set %s from text", field)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditedFields(t *testing.T) {
+	lat, otherLat := 34.05, 34.05
+	before := Airport{Faa: "TST", Manager: "Old", City: "Same", Latitude: &lat, Weather: "Clear"}
+	after := Airport{Faa: "TST", Manager: "New", City: "Same", Latitude: &otherLat, Weather: "Rain"}
+
+	assert.Equal(t, []string{"manager"}, after.EditedFields(&before), "Only overridable fields count, by value")
+
+	elevation := 100.0
+	after.ElevationFt = &elevation
+	assert.Equal(t, []string{"manager", "elevation_ft"}, after.EditedFields(&before))
+}
+
+func TestMergeFetched(t *testing.T) {
+	storedLat, fetchedLat := 1.0, 2.0
+	a := Airport{
+		Faa:             "TST",
+		Manager:         "Fixed By Hand",
+		ManagerPhone:    "555-0100",
+		City:            "Old City",
+		County:          "Kept County",
+		Latitude:        &storedLat,
+		Weather:         "Clear",
+		ManualOverrides: []string{"manager", "manager_phone"},
+	}
+	fetched := Airport{
+		Faa:          "TST",
+		Manager:      "From Provider",
+		ManagerPhone: "555-0199",
+		City:         "New City",
+		Latitude:     &fetchedLat,
+		Weather:      "Rain",
+	}
+
+	conflicts := a.MergeFetched(&fetched)
+	assert.Equal(t, []AirportConflict{
+		{Faa: "TST", Field: "manager", LocalValue: "Fixed By Hand", ProviderValue: "From Provider", Status: ConflictPending},
+		{Faa: "TST", Field: "manager_phone", LocalValue: "555-0100", ProviderValue: "555-0199", Status: ConflictPending},
+	}, conflicts)
+	assert.Equal(t, "Fixed By Hand", a.Manager)
+	assert.Equal(t, "555-0100", a.ManagerPhone)
+	assert.Equal(t, "New City", a.City)
+	assert.Equal(t, "Kept County", a.County, "Unset fetched fields keep the stored value")
+	assert.Equal(t, 2.0, *a.Latitude)
+	assert.Equal(t, "Clear", a.Weather, "Only overridable fields are merged")
+}
+
+func TestMergeFetchedConflicts(t *testing.T) {
+	stored, same := 5280.0, 5280.0
+	a := Airport{Faa: "TST", Manager: "Local", ElevationFt: &stored, ManualOverrides: []string{"manager", "elevation_ft", "city"}}
+
+	assert.Empty(t, a.MergeFetched(&Airport{Faa: "TST", Manager: "Local", ElevationFt: &same}), "Agreeing values are no conflict")
+	assert.Empty(t, a.MergeFetched(&Airport{Faa: "TST"}), "Nor are values the provider left unset")
+
+	fetched := 5431.5
+	conflicts := a.MergeFetched(&Airport{Faa: "TST", ElevationFt: &fetched, City: "Denver"})
+	assert.Equal(t, []AirportConflict{
+		{Faa: "TST", Field: "city", LocalValue: "", ProviderValue: "Denver", Status: ConflictPending},
+		{Faa: "TST", Field: "elevation_ft", LocalValue: "5280", ProviderValue: "5431.5", Status: ConflictPending},
+	}, conflicts)
+	assert.Equal(t, 5280.0, *a.ElevationFt)
+}
+
+func TestSetFieldText(t *testing.T) {
+	var a Airport
+	assert.NoError(t, a.SetFieldText("city", "Denver"))
+	assert.NoError(t, a.SetFieldText("latitude", "39.86"))
+	assert.Equal(t, "Denver", a.City)
+	assert.Equal(t, 39.86, *a.Latitude)
+	assert.Equal(t, "39.86", a.FieldText("latitude"))
+
+	assert.NoError(t, a.SetFieldText("latitude", ""))
+	assert.Nil(t, a.Latitude)
+	assert.Error(t, a.SetFieldText("longitude", "east"))
+
+	assert.NoError(t, a.SetFieldText("fuel_types", "100LL,JetA"))
+	assert.Equal(t, []string{"100LL", "JetA"}, a.FuelTypes)
+	assert.Equal(t, "100LL,JetA", a.FieldText("fuel_types"))
+	assert.NoError(t, a.SetFieldText("fuel_types", ""))
+	assert.Equal(t, []string{}, a.FuelTypes, "An empty list rather than one unset")
+}
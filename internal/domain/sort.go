@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SortableAirportFields are the airport fields, by JSON name, that lists may
+// be sorted by.
+var SortableAirportFields = []string{
+	"faa_ident", "icao_ident", "facility_name", "state", "county", "city",
+	"status", "elevation_ft", "created_at", "updated_at", "last_synced_at",
+}
+
+// SortField orders a list by one field, by JSON name.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort reads a comma separated sort such as "facility_name,-state",
+// where a leading minus sorts that field in descending order.
+func ParseSort(value string) ([]SortField, error) {
+	var sort []SortField
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		field := SortField{Field: strings.TrimPrefix(name, "-"), Desc: strings.HasPrefix(name, "-")}
+		if !slices.Contains(SortableAirportFields, field.Field) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSort, field.Field)
+		}
+		sort = append(sort, field)
+	}
+	return sort, nil
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	sort, err := ParseSort("facility_name, -state,")
+	assert.NoError(t, err)
+	assert.Equal(t, []SortField{{Field: "facility_name"}, {Field: "state", Desc: true}}, sort)
+
+	sort, err = ParseSort("")
+	assert.NoError(t, err)
+	assert.Nil(t, sort)
+
+	_, err = ParseSort("city,-manager_phone")
+	assert.ErrorIs(t, err, ErrInvalidSort)
+	assert.EqualError(t, err, "field is not sortable: manager_phone")
+}
@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TAF change groups. The base forecast and FM groups replace the prevailing
+// conditions, BECMG changes them gradually over its period, and TEMPO and PROB
+// groups forecast temporary or possible conditions on top of them.
+const (
+	TAFBase        = "BASE"
+	TAFFrom        = "FM"
+	TAFBecoming    = "BECMG"
+	TAFTemporary   = "TEMPO"
+	TAFProbability = "PROB"
+)
+
+// TAF is a decoded terminal aerodrome forecast. Periods start with the base
+// forecast, followed by its change groups in the order they were issued.
+type TAF struct {
+	Raw       string      `json:"raw"`
+	Station   string      `json:"station"`
+	Amended   bool        `json:"amended,omitempty"`
+	Corrected bool        `json:"corrected,omitempty"`
+	IssuedAt  *time.Time  `json:"issued_at,omitempty"`
+	ValidFrom *time.Time  `json:"valid_from,omitempty"`
+	ValidTo   *time.Time  `json:"valid_to,omitempty"`
+	Periods   []TAFPeriod `json:"periods"`
+}
+
+// TAFPeriod is one group of a TAF. The base and FM periods run until the next
+// FM group or the end of the TAF. A BECMG period lists only what changes, and
+// a TEMPO group with a probability, as in PROB30 TEMPO, keeps TEMPO as its
+// Change.
+type TAFPeriod struct {
+	Change      string     `json:"change"`
+	Probability int        `json:"probability,omitempty"`
+	From        *time.Time `json:"from,omitempty"`
+	To          *time.Time `json:"to,omitempty"`
+	Conditions
+	Unparsed []string `json:"unparsed,omitempty"`
+}
+
+// TAFForecast is what a TAF forecasts at one time: the prevailing conditions
+// with any completed BECMG changes applied, and the TEMPO, PROB and
+// unfinished BECMG periods that cover it.
+type TAFForecast struct {
+	Station    string      `json:"station"`
+	IssuedAt   *time.Time  `json:"issued_at,omitempty"`
+	At         time.Time   `json:"at"`
+	Prevailing TAFPeriod   `json:"prevailing"`
+	Temporary  []TAFPeriod `json:"temporary"`
+	Raw        string      `json:"raw"`
+}
+
+var (
+	tafValidityPattern    = regexp.MustCompile(`^(\d{2})(\d{2})/(\d{2})(\d{2})$`)
+	tafFromPattern        = regexp.MustCompile(`^FM(\d{2})(\d{2})(\d{2})$`)
+	tafProbabilityPattern = regexp.MustCompile(`^PROB(\d{2})$`)
+)
+
+// ParseTAF decodes a raw TAF. Its issue time is resolved against ref as a
+// METAR's is, and its periods against the issue time, or ref when it has
+// none; a zero ref leaves all times out. Only a TAF without a station is an
+// error.
+func ParseTAF(raw string, ref time.Time) (*TAF, error) {
+	tokens := strings.Fields(strings.TrimSuffix(strings.TrimSpace(raw), "="))
+	t := &TAF{Raw: strings.Join(tokens, " ")}
+
+	if len(tokens) > 0 && tokens[0] == "TAF" {
+		tokens = tokens[1:]
+	}
+	for len(tokens) > 0 && (tokens[0] == "AMD" || tokens[0] == "COR") {
+		if tokens[0] == "AMD" {
+			t.Amended = true
+		} else {
+			t.Corrected = true
+		}
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 || !metarStationPattern.MatchString(tokens[0]) {
+		return nil, fmt.Errorf("%w: no station in %q", ErrInvalidTAF, raw)
+	}
+	t.Station, tokens = tokens[0], tokens[1:]
+
+	if len(tokens) > 0 && metarTimePattern.MatchString(tokens[0]) {
+		if !ref.IsZero() {
+			t.IssuedAt = metarTime(tokens[0], ref)
+		}
+		tokens = tokens[1:]
+	}
+	base := ref
+	if t.IssuedAt != nil {
+		base = *t.IssuedAt
+	}
+	if len(tokens) > 0 && tafValidityPattern.MatchString(tokens[0]) {
+		if !base.IsZero() {
+			t.ValidFrom, t.ValidTo = tafValidity(tokens[0], base)
+		}
+		tokens = tokens[1:]
+	}
+
+	t.Periods = []TAFPeriod{{Change: TAFBase, From: t.ValidFrom}}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		p := &t.Periods[len(t.Periods)-1]
+
+		switch {
+		case tafFromPattern.MatchString(token):
+			period := TAFPeriod{Change: TAFFrom}
+			if !base.IsZero() {
+				g := tafFromPattern.FindStringSubmatch(token)
+				period.From = tafTime(g[1], g[2], g[3], base)
+			}
+			t.Periods = append(t.Periods, period)
+		case token == TAFBecoming || token == TAFTemporary || tafProbabilityPattern.MatchString(token):
+			period := TAFPeriod{Change: token}
+			if g := tafProbabilityPattern.FindStringSubmatch(token); g != nil {
+				period.Change = TAFProbability
+				period.Probability, _ = strconv.Atoi(g[1])
+				if i+1 < len(tokens) && tokens[i+1] == TAFTemporary {
+					period.Change = TAFTemporary
+					i++
+				}
+			}
+			if i+1 < len(tokens) && tafValidityPattern.MatchString(tokens[i+1]) {
+				if !base.IsZero() {
+					period.From, period.To = tafValidity(tokens[i+1], base)
+				}
+				i++
+			}
+			t.Periods = append(t.Periods, period)
+		case token == "NSW":
+			// No significant weather, ending what an earlier group forecast
+			p.Weather = append(p.Weather, token)
+		default:
+			if last, ok := p.decode(tokens, i); ok {
+				i = last
+				continue
+			}
+			p.Unparsed = append(p.Unparsed, token)
+		}
+	}
+
+	// The base forecast and each FM group last until the next FM group
+	var prevailing *TAFPeriod
+	for i := range t.Periods {
+		p := &t.Periods[i]
+		if p.Change != TAFBase && p.Change != TAFFrom {
+			continue
+		}
+		if prevailing != nil {
+			prevailing.To = p.From
+		}
+		prevailing = p
+	}
+	prevailing.To = t.ValidTo
+
+	return t, nil
+}
+
+// ForecastAt returns what the TAF forecasts at a time, or false when the time
+// is outside its validity or the TAF has none.
+func (t *TAF) ForecastAt(at time.Time) (*TAFForecast, bool) {
+	if t.ValidFrom == nil || t.ValidTo == nil || at.Before(*t.ValidFrom) || !at.Before(*t.ValidTo) {
+		return nil, false
+	}
+
+	forecast := &TAFForecast{Station: t.Station, IssuedAt: t.IssuedAt, At: at, Temporary: []TAFPeriod{}, Raw: t.Raw}
+	for _, p := range t.Periods {
+		switch p.Change {
+		case TAFBase, TAFFrom:
+			if p.From != nil && !at.Before(*p.From) {
+				forecast.Prevailing = p
+			}
+		case TAFBecoming:
+			if p.From == nil || p.To == nil || at.Before(*p.From) {
+				continue
+			}
+			if at.Before(*p.To) {
+				forecast.Temporary = append(forecast.Temporary, p)
+			} else if forecast.Prevailing.From != nil && !p.From.Before(*forecast.Prevailing.From) {
+				forecast.Prevailing.Conditions = forecast.Prevailing.becoming(p.Conditions)
+			}
+		default:
+			if p.From != nil && p.To != nil && !at.Before(*p.From) && at.Before(*p.To) {
+				forecast.Temporary = append(forecast.Temporary, p)
+			}
+		}
+	}
+	return forecast, true
+}
+
+// becoming returns the conditions once a BECMG group's changes are complete.
+// Groups it doesn't mention carry on unchanged.
+func (c Conditions) becoming(change Conditions) Conditions {
+	if change.Wind != nil {
+		c.Wind = change.Wind
+	}
+	if change.VisibilitySM != nil {
+		c.VisibilitySM, c.VisibilityAbove = change.VisibilitySM, change.VisibilityAbove
+	}
+	if change.Weather != nil {
+		c.Weather = change.Weather
+	}
+	if change.Clouds != nil {
+		c.Clouds, c.CeilingFt = change.Clouds, change.CeilingFt
+	}
+	return c
+}
+
+// tafValidity resolves a DDHH/DDHH group against base.
+func tafValidity(token string, base time.Time) (from, to *time.Time) {
+	g := tafValidityPattern.FindStringSubmatch(token)
+	return tafTime(g[1], g[2], "00", base), tafTime(g[3], g[4], "00", base)
+}
+
+// tafTime resolves a day, hour and minute to the nearest such time to base,
+// in base's month or the ones either side. Hour 24 is midnight at the end of
+// the day, as TAFs write it.
+func tafTime(dd, hh, mm string, base time.Time) *time.Time {
+	day, _ := strconv.Atoi(dd)
+	hour, _ := strconv.Atoi(hh)
+	minute, _ := strconv.Atoi(mm)
+	if day < 1 || hour > 24 || minute > 59 {
+		return nil
+	}
+
+	base = base.UTC()
+	var nearest *time.Time
+	for month := -1; month <= 1; month++ {
+		first := time.Date(base.Year(), base.Month()+time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		midnight := time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+		if midnight.Month() != first.Month() {
+			continue // No such day in that month
+		}
+		t := midnight.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+		if nearest == nil || t.Sub(base).Abs() < nearest.Sub(base).Abs() {
+			nearest = &t
+		}
+	}
+	return nearest
+}
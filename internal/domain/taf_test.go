@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTAF = "TAF AMD KDEN 011720Z 0118/0224 27015G25KT P6SM SCT080 BKN200 " +
+	"FM012100 30012KT P6SM BKN100 " +
+	"TEMPO 0122/0202 VRB20G35KT 3SM TSRA BKN050CB " +
+	"BECMG 0206/0208 33008KT 5SM BR " +
+	"PROB30 0210/0214 1SM FG OVC004 " +
+	"FM021800 VRB05KT P6SM SKC="
+
+func TestParseTAF(t *testing.T) {
+	at := func(day, hour int) *time.Time {
+		t := time.Date(2024, 6, day, hour, 0, 0, 0, time.UTC)
+		return &t
+	}
+	ref := time.Date(2024, 6, 1, 17, 30, 0, 0, time.UTC)
+
+	taf, err := ParseTAF(testTAF, ref)
+	assert.NoError(t, err)
+	assert.Equal(t, "KDEN", taf.Station)
+	assert.True(t, taf.Amended)
+	assert.Equal(t, time.Date(2024, 6, 1, 17, 20, 0, 0, time.UTC), *taf.IssuedAt)
+	assert.Equal(t, at(1, 18), taf.ValidFrom)
+	assert.Equal(t, at(3, 0), taf.ValidTo, "Hour 24 is midnight")
+
+	changes := []string{}
+	for _, p := range taf.Periods {
+		changes = append(changes, p.Change)
+	}
+	assert.Equal(t, []string{TAFBase, TAFFrom, TAFTemporary, TAFBecoming, TAFProbability, TAFFrom}, changes)
+
+	base := taf.Periods[0]
+	assert.Equal(t, at(1, 18), base.From)
+	assert.Equal(t, at(1, 21), base.To, "The base forecast lasts until the first FM group")
+	assert.Equal(t, 25, base.Wind.GustKt)
+	assert.Equal(t, 20000, *base.CeilingFt)
+	assert.Equal(t, at(2, 18), taf.Periods[1].To)
+	assert.Equal(t, at(3, 0), taf.Periods[5].To, "The last FM group lasts until the end of the TAF")
+
+	tempo := taf.Periods[2]
+	assert.Equal(t, at(1, 22), tempo.From)
+	assert.Equal(t, at(2, 2), tempo.To)
+	assert.Equal(t, []string{"TSRA"}, tempo.Weather)
+	assert.Equal(t, 30, taf.Periods[4].Probability)
+
+	taf, err = ParseTAF("TAF KJFK 302330Z 3100/0106 18010KT PROB40 TEMPO 3102/3104 2SM", time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 1, 6, 0, 0, 0, time.UTC), *taf.ValidTo, "Validity runs into the next month")
+	assert.Equal(t, TAFTemporary, taf.Periods[1].Change)
+	assert.Equal(t, 40, taf.Periods[1].Probability)
+
+	taf, err = ParseTAF("KDEN 011720Z 0118/0224 27015KT P6SM SKC", time.Time{})
+	assert.NoError(t, err)
+	assert.Nil(t, taf.ValidFrom, "No ref leaves the times out")
+
+	_, err = ParseTAF("TAF 011720Z 0118/0224", ref)
+	assert.ErrorIs(t, err, ErrInvalidTAF)
+}
+
+func TestTAFForecastAt(t *testing.T) {
+	taf, err := ParseTAF(testTAF, time.Date(2024, 6, 1, 17, 30, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	at := func(day, hour int) time.Time { return time.Date(2024, 6, day, hour, 0, 0, 0, time.UTC) }
+
+	forecast, ok := taf.ForecastAt(at(1, 19))
+	assert.True(t, ok)
+	assert.Equal(t, TAFBase, forecast.Prevailing.Change)
+	assert.Empty(t, forecast.Temporary)
+
+	forecast, ok = taf.ForecastAt(at(1, 23))
+	assert.True(t, ok)
+	assert.Equal(t, TAFFrom, forecast.Prevailing.Change)
+	assert.Equal(t, 10000, *forecast.Prevailing.CeilingFt)
+	assert.Len(t, forecast.Temporary, 1)
+	assert.Equal(t, TAFTemporary, forecast.Temporary[0].Change)
+
+	forecast, ok = taf.ForecastAt(at(2, 7))
+	assert.True(t, ok)
+	assert.Equal(t, 12, forecast.Prevailing.Wind.SpeedKt, "BECMG under way leaves the prevailing wind")
+	assert.Equal(t, TAFBecoming, forecast.Temporary[0].Change)
+
+	forecast, ok = taf.ForecastAt(at(2, 11))
+	assert.True(t, ok)
+	assert.Equal(t, 8, forecast.Prevailing.Wind.SpeedKt, "A finished BECMG becomes prevailing")
+	assert.Equal(t, 5.0, *forecast.Prevailing.VisibilitySM)
+	assert.Equal(t, 10000, *forecast.Prevailing.CeilingFt, "Groups the BECMG doesn't mention carry on")
+	assert.Len(t, forecast.Temporary, 1)
+	assert.Equal(t, TAFProbability, forecast.Temporary[0].Change)
+
+	forecast, ok = taf.ForecastAt(at(2, 20))
+	assert.True(t, ok)
+	assert.Equal(t, []CloudLayer{{Cover: "SKC"}}, forecast.Prevailing.Clouds)
+	assert.True(t, forecast.Prevailing.VisibilityAbove)
+	assert.Empty(t, forecast.Temporary)
+
+	_, ok = taf.ForecastAt(at(3, 0))
+	assert.False(t, ok, "The end of the validity is outside it")
+	_, ok = taf.ForecastAt(at(1, 17))
+	assert.False(t, ok)
+}
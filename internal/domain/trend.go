@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Directions of a weather element over a trend window, judged by what they
+// mean for flying.
+const (
+	TrendImproving     = "improving"
+	TrendDeteriorating = "deteriorating"
+	TrendSteady        = "steady"
+	TrendUnknown       = "unknown"
+)
+
+// TrendMetric is how one weather element moved over a trend window: its first
+// and last values, the least-squares slope through its samples per hour, and
+// the direction that slope stands for.
+type TrendMetric struct {
+	Samples      int      `json:"samples"`
+	First        *float64 `json:"first,omitempty"`
+	Last         *float64 `json:"last,omitempty"`
+	SlopePerHour float64  `json:"slope_per_hour"`
+	Trend        string   `json:"trend"`
+}
+
+// WeatherTrend is the payload of the trend endpoint. Overall is deteriorating
+// as soon as one element is, so it errs on the side of no-go.
+type WeatherTrend struct {
+	Faa          string      `json:"faa"`
+	Hours        int         `json:"hours"`
+	Since        time.Time   `json:"since"`
+	Observations int         `json:"observations"`
+	PressureHpa  TrendMetric `json:"pressure_hpa"`
+	VisibilitySM TrendMetric `json:"visibility_sm"`
+	CeilingFt    TrendMetric `json:"ceiling_ft"`
+	Overall      string      `json:"overall"`
+}
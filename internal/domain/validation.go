@@ -0,0 +1,421 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FieldError is a validation failure of a single JSON field.
+type FieldError struct {
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+// ValidationErrors collects every invalid field of a payload.
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	parts := make([]string, len(v))
+	for i, fe := range v {
+		parts[i] = fe.Field + ": " + fe.Error
+	}
+	return strings.Join(parts, "; ")
+}
+
+var (
+	faaPattern    = regexp.MustCompile(`^[A-Za-z0-9]{3,4}$`)
+	icaoPattern   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]{3}$`)
+	phonePattern  = regexp.MustCompile(`^\+?[0-9 ().-]+$`)
+	dmsPattern    = regexp.MustCompile(`^(\d{1,3})-(\d{1,2})-(\d{1,2}(?:\.\d+)?)([NSEW])$`)
+	magVarPattern = regexp.MustCompile(`^(\d{1,3}(?:\.\d+)?)([EW])$`)
+)
+
+// StateCodes lists the US states, DC, and territories with FAA airports.
+var StateCodes = map[string]bool{
+	"AL": true, "AK": true, "AZ": true, "AR": true, "CA": true, "CO": true, "CT": true, "DE": true,
+	"FL": true, "GA": true, "HI": true, "ID": true, "IL": true, "IN": true, "IA": true, "KS": true,
+	"KY": true, "LA": true, "ME": true, "MD": true, "MA": true, "MI": true, "MN": true, "MS": true,
+	"MO": true, "MT": true, "NE": true, "NV": true, "NH": true, "NJ": true, "NM": true, "NY": true,
+	"NC": true, "ND": true, "OH": true, "OK": true, "OR": true, "PA": true, "RI": true, "SC": true,
+	"SD": true, "TN": true, "TX": true, "UT": true, "VT": true, "VA": true, "WA": true, "WV": true,
+	"WI": true, "WY": true, "DC": true, "PR": true, "VI": true, "GU": true, "AS": true, "MP": true,
+}
+
+// Validate checks the airport payload. Only the FAA code is required; other
+// fields are checked when present.
+func (a *Airport) Validate() ValidationErrors {
+	var errs ValidationErrors
+	add := func(field, format string, args ...any) {
+		errs = append(errs, FieldError{Field: field, Error: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case a.Faa == "":
+		add("faa_ident", "is required")
+	case !faaPattern.MatchString(a.Faa):
+		add("faa_ident", "must be 3-4 letters or digits")
+	}
+
+	if a.Icao != "" && !icaoPattern.MatchString(a.Icao) {
+		add("icao_ident", "must be 4 characters starting with a letter")
+	}
+
+	if a.StateCode != "" && !StateCodes[strings.ToUpper(a.StateCode)] {
+		add("state", "unknown state code %q", a.StateCode)
+	}
+
+	for _, c := range []struct {
+		field string
+		value *float64
+		limit float64
+	}{{"latitude", a.Latitude, 90}, {"longitude", a.Longitude, 180}} {
+		if c.value != nil && (*c.value < -c.limit || *c.value > c.limit) {
+			add(c.field, "must be between -%g and %g", c.limit, c.limit)
+		}
+	}
+
+	// From the Dead Sea shore to well above the highest airfield
+	if a.ElevationFt != nil && (*a.ElevationFt < -1500 || *a.ElevationFt > 20000) {
+		add("elevation_ft", "must be between -1500 and 20000")
+	}
+
+	if a.MagneticVariation != nil && (*a.MagneticVariation < -180 || *a.MagneticVariation > 180) {
+		add("magnetic_variation", "must be between -180 and 180")
+	}
+
+	if a.ManagerPhone != "" && !isPhone(a.ManagerPhone) {
+		add("manager_phone", "must be a phone number with 7-15 digits")
+	}
+
+	fuel := AirportFuel{FuelTypes: a.FuelTypes, FboName: a.FboName, FboPhone: a.FboPhone}
+	errs = append(errs, fuel.Validate()...)
+
+	for _, field := range a.ManualOverrides {
+		if !slices.Contains(OverridableFields, field) {
+			add("manual_overrides", "%q is not a field syncs fill in", field)
+		}
+	}
+
+	return errs
+}
+
+// Validate checks the fuel types are among the Fuel constants and the FBO
+// reads as a name and phone number.
+func (f *AirportFuel) Validate() ValidationErrors {
+	var errs ValidationErrors
+	for _, fuel := range f.FuelTypes {
+		if canonical, ok := ParseFuelType(fuel); !ok || canonical != fuel {
+			errs = append(errs, FieldError{Field: "fuel_types", Error: fmt.Sprintf("unknown fuel type %q", fuel)})
+		}
+	}
+	if utf8.RuneCountInString(f.FboName) > 255 {
+		errs = append(errs, FieldError{Field: "fbo_name", Error: "must be at most 255 characters"})
+	}
+	if f.FboPhone != "" && !isPhone(f.FboPhone) {
+		errs = append(errs, FieldError{Field: "fbo_phone", Error: "must be a phone number with 7-15 digits"})
+	}
+	return errs
+}
+
+// isPhone reports whether s reads as a phone number of 7 to 15 digits.
+func isPhone(s string) bool {
+	digits := 0
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			digits++
+		}
+	}
+	return phonePattern.MatchString(s) && digits >= 7 && digits <= 15
+}
+
+// Coordinates returns the airport position in decimal degrees, or ok false
+// when either coordinate is missing or out of range.
+func (a *Airport) Coordinates() (lat, lon float64, ok bool) {
+	if a.Latitude == nil || a.Longitude == nil ||
+		math.Abs(*a.Latitude) > 90 || math.Abs(*a.Longitude) > 180 {
+		return 0, 0, false
+	}
+	return *a.Latitude, *a.Longitude, true
+}
+
+// ParseCoordinate parses decimal degrees ("34.0522") or the FAA
+// degrees-minutes-seconds form ("33-38-12.1186N") and checks it against limit.
+func ParseCoordinate(value string, limit float64) (float64, error) {
+	deg, err := ParseDegrees(value)
+	if err != nil {
+		return 0, err
+	}
+	if deg < -limit || deg > limit {
+		return 0, fmt.Errorf("must be between -%g and %g", limit, limit)
+	}
+	return deg, nil
+}
+
+// ParseDegrees parses a coordinate like ParseCoordinate, without a range.
+func ParseDegrees(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+
+	var deg float64
+	if m := dmsPattern.FindStringSubmatch(value); m != nil {
+		d, _ := strconv.ParseFloat(m[1], 64)
+		mins, _ := strconv.ParseFloat(m[2], 64)
+		sec, _ := strconv.ParseFloat(m[3], 64)
+		if mins >= 60 || sec >= 60 {
+			return 0, fmt.Errorf("invalid minutes or seconds in %q", value)
+		}
+		deg = d + mins/60 + sec/3600
+		if m[4] == "S" || m[4] == "W" {
+			deg = -deg
+		}
+	} else {
+		var err error
+		deg, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("must be decimal degrees or DD-MM-SS.sH, got %q", value)
+		}
+	}
+	return deg, nil
+}
+
+// ParseMagneticVariation parses the FAA form ("04E", "12W") or signed degrees
+// ("-12") into degrees, east positive.
+func ParseMagneticVariation(value string) (float64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+
+	if m := magVarPattern.FindStringSubmatch(value); m != nil {
+		deg, _ := strconv.ParseFloat(m[1], 64)
+		if m[2] == "W" {
+			deg = -deg
+		}
+		return deg, nil
+	}
+
+	deg, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("must be degrees with E or W, got %q", value)
+	}
+	return deg, nil
+}
+
+// flightCategories are the categories a flight_category rule may compare against.
+var flightCategories = map[string]bool{"VFR": true, "MVFR": true, "IFR": true, "LIFR": true}
+
+// numericOperators are the operators valid for every metric but flight_category.
+var numericOperators = map[string]bool{OpGreaterThan: true, OpGreaterOrEq: true, OpLessThan: true, OpLessOrEq: true}
+
+// Validate checks an alert rule payload.
+func (r *AlertRule) Validate() ValidationErrors {
+	var errs ValidationErrors
+	add := func(field, format string, args ...any) {
+		errs = append(errs, FieldError{Field: field, Error: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case strings.TrimSpace(r.Name) == "":
+		add("name", "is required")
+	case strings.IndexFunc(r.Name, unicode.IsControl) >= 0:
+		add("name", "must not contain control characters")
+	}
+
+	switch {
+	case r.Faa != "" && r.StateCode != "":
+		add("state", "must be empty when faa_ident is set")
+	case r.Faa != "" && !faaPattern.MatchString(r.Faa):
+		add("faa_ident", "must be 3-4 letters or digits")
+	case r.StateCode != "" && !StateCodes[strings.ToUpper(r.StateCode)]:
+		add("state", "unknown state code %q", r.StateCode)
+	}
+
+	switch r.Metric {
+	case MetricFlightCategory:
+		if r.Operator != OpWorseThan {
+			add("operator", "must be %s for %s", OpWorseThan, MetricFlightCategory)
+		}
+		if !flightCategories[r.Category] {
+			add("category", "must be VFR, MVFR, IFR or LIFR")
+		}
+	case MetricWindSpeed, MetricWindGust, MetricVisibility, MetricTemperature:
+		if !numericOperators[r.Operator] {
+			add("operator", "must be gt, gte, lt or lte")
+		}
+	case "":
+		add("metric", "is required")
+	default:
+		add("metric", "unknown metric %q", r.Metric)
+	}
+
+	for i, target := range r.Notify {
+		for _, fe := range target.Validate() {
+			add(fmt.Sprintf("notify[%d].%s", i, fe.Field), "%s", fe.Error)
+		}
+	}
+
+	return errs
+}
+
+// Validate checks a notification target.
+func (t *NotifyTarget) Validate() ValidationErrors {
+	var errs ValidationErrors
+	add := func(field, format string, args ...any) {
+		errs = append(errs, FieldError{Field: field, Error: fmt.Sprintf(format, args...)})
+	}
+
+	switch t.Channel {
+	case ChannelEmail:
+		if addr, err := mail.ParseAddress(t.Target); err != nil || addr.Address != t.Target {
+			add("target", "must be an email address")
+		}
+	case ChannelSlack:
+		if u, err := url.Parse(t.Target); err != nil || u.Scheme != "https" || u.Host == "" {
+			add("target", "must be an https Slack webhook URL")
+		}
+	case "":
+		add("channel", "is required")
+	default:
+		add("channel", "must be email or slack")
+	}
+
+	return errs
+}
+
+// webhookEvents are the events a webhook may subscribe to.
+var webhookEvents = map[string]bool{EventWeatherChanged: true, EventSyncCompleted: true, EventAlertFired: true}
+
+// IsWebhookEvent reports whether webhooks may subscribe to event.
+func IsWebhookEvent(event string) bool {
+	return webhookEvents[event]
+}
+
+// Validate checks a webhook payload.
+func (wh *Webhook) Validate() ValidationErrors {
+	var errs ValidationErrors
+	add := func(field, format string, args ...any) {
+		errs = append(errs, FieldError{Field: field, Error: fmt.Sprintf(format, args...)})
+	}
+
+	if u, err := url.Parse(wh.URL); wh.URL == "" {
+		add("url", "is required")
+	} else if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		add("url", "must be an absolute http or https URL")
+	}
+
+	if len(wh.Events) == 0 {
+		add("events", "is required")
+	}
+	for _, event := range wh.Events {
+		if !webhookEvents[event] {
+			add("events", "unknown event %q", event)
+		}
+	}
+
+	return errs
+}
+
+// Validate checks a watchlist payload.
+func (wl *Watchlist) Validate() ValidationErrors {
+	var errs ValidationErrors
+	add := func(field, format string, args ...any) {
+		errs = append(errs, FieldError{Field: field, Error: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case strings.TrimSpace(wl.Name) == "":
+		add("name", "is required")
+	case len(wl.Name) > 100:
+		add("name", "must be at most 100 characters")
+	}
+
+	validateFAAs(add, wl.Faas, MaxWatchlistAirports)
+
+	return errs
+}
+
+// validateFAAs checks the faa_codes of a watchlist or subscription.
+func validateFAAs(add func(field, format string, args ...any), faas []string, limit int) {
+	switch {
+	case len(faas) == 0:
+		add("faa_codes", "is required")
+	case len(faas) > limit:
+		add("faa_codes", "must list at most %d airports", limit)
+	}
+	for _, faa := range faas {
+		if !faaPattern.MatchString(faa) {
+			add("faa_codes", "%q must be 3-4 letters or digits", faa)
+		}
+	}
+}
+
+// Validate checks a digest subscription payload.
+func (sub *Subscription) Validate() ValidationErrors {
+	var errs ValidationErrors
+	add := func(field, format string, args ...any) {
+		errs = append(errs, FieldError{Field: field, Error: fmt.Sprintf(format, args...)})
+	}
+
+	validateFAAs(add, sub.Faas, MaxSubscriptionAirports)
+
+	switch sub.Channel {
+	case ChannelEmail:
+		if addr, err := mail.ParseAddress(sub.Target); err != nil || addr.Address != sub.Target {
+			add("target", "must be an email address")
+		}
+	case ChannelWebhook:
+		if u, err := url.Parse(sub.Target); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("target", "must be an absolute http or https URL")
+		}
+	case "":
+		add("channel", "is required")
+	default:
+		add("channel", "must be email or webhook")
+	}
+
+	return errs
+}
+
+// Validate checks a tenant payload.
+func (t *Tenant) Validate() ValidationErrors {
+	var errs ValidationErrors
+	switch {
+	case strings.TrimSpace(t.Name) == "":
+		errs = append(errs, FieldError{Field: "name", Error: "is required"})
+	case len(t.Name) > 100:
+		errs = append(errs, FieldError{Field: "name", Error: "must be at most 100 characters"})
+	}
+	return errs
+}
+
+// Validate checks an API key payload; the key itself is generated.
+func (k *APIKey) Validate() ValidationErrors {
+	var errs ValidationErrors
+	if len(k.Name) > 100 {
+		errs = append(errs, FieldError{Field: "name", Error: "must be at most 100 characters"})
+	}
+	return errs
+}
+
+// Validate checks an airport note payload; its airport comes from the path.
+func (n *AirportNote) Validate() ValidationErrors {
+	var errs ValidationErrors
+	switch {
+	case strings.TrimSpace(n.Body) == "":
+		errs = append(errs, FieldError{Field: "body", Error: "is required"})
+	case utf8.RuneCountInString(n.Body) > MaxNoteLength:
+		errs = append(errs, FieldError{Field: "body", Error: fmt.Sprintf("must be at most %d characters", MaxNoteLength)})
+	}
+	if len(n.Author) > 100 {
+		errs = append(errs, FieldError{Field: "author", Error: "must be at most 100 characters"})
+	}
+	if n.ExpiresAt != nil && !n.ExpiresAt.After(time.Now()) {
+		errs = append(errs, FieldError{Field: "expires_at", Error: "must be in the future"})
+	}
+	return errs
+}
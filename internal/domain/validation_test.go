@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAirportValidate(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	valid := Airport{
+		Faa:          "TST",
+		Icao:         "KTST",
+		StateCode:    "CA",
+		Latitude:     ptr(34.0522),
+		Longitude:    ptr(-118.2437),
+		ManagerPhone: "123-456-7890",
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(a *Airport)
+		expected ValidationErrors
+	}{
+		{
+			name:     "valid",
+			modify:   func(a *Airport) {},
+			expected: nil,
+		},
+		{
+			name:     "only faa",
+			modify:   func(a *Airport) { *a = Airport{Faa: "X12"} },
+			expected: nil,
+		},
+		{
+			name:     "edge coordinates",
+			modify:   func(a *Airport) { a.Latitude, a.Longitude = ptr(-90), ptr(180) },
+			expected: nil,
+		},
+		{
+			name:     "missing faa",
+			modify:   func(a *Airport) { a.Faa = "" },
+			expected: ValidationErrors{{Field: "faa_ident", Error: "is required"}},
+		},
+		{
+			name: "every field invalid",
+			modify: func(a *Airport) {
+				a.Faa = "TOOLONG"
+				a.Icao = "1ABC"
+				a.StateCode = "ZZ"
+				a.Latitude = ptr(91)
+				a.Longitude = ptr(-180.5)
+				a.ElevationFt = new(float64)
+				*a.ElevationFt = 30000
+				a.MagneticVariation = new(float64)
+				*a.MagneticVariation = 200
+				a.ManagerPhone = "call me"
+				a.FuelTypes = []string{"JetA", "Jet A"}
+				a.FboName = strings.Repeat("x", 256)
+				a.FboPhone = "12"
+				a.ManualOverrides = []string{"manager", "weather"}
+			},
+			expected: ValidationErrors{
+				{Field: "faa_ident", Error: "must be 3-4 letters or digits"},
+				{Field: "icao_ident", Error: "must be 4 characters starting with a letter"},
+				{Field: "state", Error: `unknown state code "ZZ"`},
+				{Field: "latitude", Error: "must be between -90 and 90"},
+				{Field: "longitude", Error: "must be between -180 and 180"},
+				{Field: "elevation_ft", Error: "must be between -1500 and 20000"},
+				{Field: "magnetic_variation", Error: "must be between -180 and 180"},
+				{Field: "manager_phone", Error: "must be a phone number with 7-15 digits"},
+				{Field: "fuel_types", Error: `unknown fuel type "Jet A"`},
+				{Field: "fbo_name", Error: "must be at most 255 characters"},
+				{Field: "fbo_phone", Error: "must be a phone number with 7-15 digits"},
+				{Field: "manual_overrides", Error: `"weather" is not a field syncs fill in`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := valid
+			tt.modify(&a)
+			assert.Equal(t, tt.expected, a.Validate())
+		})
+	}
+}
+
+func TestParseCoordinate(t *testing.T) {
+	deg, err := ParseCoordinate("33-38-12.1186N", 90)
+	assert.NoError(t, err)
+	assert.InDelta(t, 33.6367, deg, 0.0001)
+
+	deg, err = ParseCoordinate("084-25-40.3104W", 180)
+	assert.NoError(t, err)
+	assert.InDelta(t, -84.4279, deg, 0.0001)
+
+	_, err = ParseCoordinate("33-61-00N", 90)
+	assert.Error(t, err, "Minutes over 59 should be rejected")
+}
+
+func TestParseMagneticVariation(t *testing.T) {
+	tests := map[string]float64{"04E": 4, "12W": -12, " 3.5e ": 3.5, "-7": -7}
+	for input, expected := range tests {
+		deg, err := ParseMagneticVariation(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, deg, input)
+	}
+
+	_, err := ParseMagneticVariation("")
+	assert.Error(t, err, "Empty variation should be rejected")
+}
+
+func TestValidationErrorsError(t *testing.T) {
+	errs := ValidationErrors{{Field: "faa_ident", Error: "is required"}, {Field: "state", Error: "unknown"}}
+	assert.EqualError(t, errs, "faa_ident: is required; state: unknown")
+}
+
+func TestAlertRuleValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     AlertRule
+		expected ValidationErrors
+	}{
+		{"numeric rule", AlertRule{Name: "Wind", StateCode: "TX", Metric: MetricWindSpeed, Operator: OpGreaterThan, Threshold: 25}, nil},
+		{"category rule", AlertRule{Name: "Below MVFR", Faa: "DFW", Metric: MetricFlightCategory, Operator: OpWorseThan, Category: "MVFR"}, nil},
+		{"faa and state", AlertRule{Name: "Wind", Faa: "DFW", StateCode: "TX", Metric: MetricWindGust, Operator: OpGreaterOrEq, Threshold: 35},
+			ValidationErrors{{Field: "state", Error: "must be empty when faa_ident is set"}}},
+		{"line break in name", AlertRule{Name: "Wind\r\nBcc: all@example.com", Metric: MetricWindSpeed, Operator: OpGreaterThan, Threshold: 25},
+			ValidationErrors{{Field: "name", Error: "must not contain control characters"}}},
+		{"everything missing", AlertRule{},
+			ValidationErrors{{Field: "name", Error: "is required"}, {Field: "metric", Error: "is required"}}},
+		{"wrong operator and category", AlertRule{Name: "Fog", Metric: MetricFlightCategory, Operator: OpLessThan, Category: "fog"},
+			ValidationErrors{{Field: "operator", Error: "must be worse_than for flight_category"}, {Field: "category", Error: "must be VFR, MVFR, IFR or LIFR"}}},
+		{"unknown metric", AlertRule{Name: "Rain", Metric: "rain_mm", Operator: OpGreaterThan},
+			ValidationErrors{{Field: "metric", Error: `unknown metric "rain_mm"`}}},
+		{"category operator on a number", AlertRule{Name: "Cold", Metric: MetricTemperature, Operator: OpWorseThan},
+			ValidationErrors{{Field: "operator", Error: "must be gt, gte, lt or lte"}}},
+		{"notify targets", AlertRule{Name: "Wind", Metric: MetricWindSpeed, Operator: OpGreaterThan, Threshold: 25, Notify: []NotifyTarget{
+			{Channel: ChannelEmail, Target: "ops@example.com"},
+			{Channel: ChannelSlack, Target: "http://hooks.slack.com/services/x"},
+			{Channel: "sms", Target: "+15551234567"},
+		}}, ValidationErrors{
+			{Field: "notify[1].target", Error: "must be an https Slack webhook URL"},
+			{Field: "notify[2].channel", Error: "must be email or slack"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule.Validate())
+		})
+	}
+}
+
+func TestWebhookValidate(t *testing.T) {
+	valid := Webhook{URL: "https://example.com/hook", Events: []string{EventWeatherChanged, EventAlertFired}}
+	assert.Empty(t, valid.Validate())
+
+	invalid := Webhook{URL: "ftp://example.com", Events: []string{"airport.deleted"}}
+	assert.Equal(t, ValidationErrors{
+		{Field: "url", Error: "must be an absolute http or https URL"},
+		{Field: "events", Error: `unknown event "airport.deleted"`},
+	}, invalid.Validate())
+
+	assert.Equal(t, ValidationErrors{
+		{Field: "url", Error: "is required"},
+		{Field: "events", Error: "is required"},
+	}, (&Webhook{}).Validate())
+}
+
+func TestWatchlistValidate(t *testing.T) {
+	valid := Watchlist{Name: "Home base", Faas: []string{"DEN", "KAPA"}}
+	assert.Empty(t, valid.Validate())
+
+	invalid := Watchlist{Name: " ", Faas: []string{"DEN", "D-EN"}}
+	assert.Equal(t, ValidationErrors{
+		{Field: "name", Error: "is required"},
+		{Field: "faa_codes", Error: `"D-EN" must be 3-4 letters or digits`},
+	}, invalid.Validate())
+
+	tooMany := Watchlist{Name: "Everything", Faas: make([]string, MaxWatchlistAirports+1)}
+	for i := range tooMany.Faas {
+		tooMany.Faas[i] = fmt.Sprintf("A%02d", i%100)
+	}
+	assert.Equal(t, ValidationErrors{
+		{Field: "faa_codes", Error: "must list at most 100 airports"},
+	}, tooMany.Validate())
+}
+
+func TestSubscriptionValidate(t *testing.T) {
+	assert.Empty(t, (&Subscription{Faas: []string{"DEN"}, Channel: ChannelEmail, Target: "pilot@example.com"}).Validate())
+	assert.Empty(t, (&Subscription{Faas: []string{"DEN"}, Channel: ChannelWebhook, Target: "https://example.com/digest"}).Validate())
+
+	assert.Equal(t, ValidationErrors{
+		{Field: "faa_codes", Error: "is required"},
+		{Field: "target", Error: "must be an absolute http or https URL"},
+	}, (&Subscription{Channel: ChannelWebhook, Target: "example.com"}).Validate())
+
+	assert.Equal(t, ValidationErrors{
+		{Field: "channel", Error: "must be email or webhook"},
+	}, (&Subscription{Faas: []string{"DEN"}, Channel: ChannelSlack, Target: "https://hooks.slack.com/x"}).Validate())
+}
+
+func TestTenantValidate(t *testing.T) {
+	assert.Empty(t, (&Tenant{Name: "Acme Aviation"}).Validate())
+	assert.Equal(t, ValidationErrors{{Field: "name", Error: "is required"}}, (&Tenant{Name: "  "}).Validate())
+	assert.Equal(t, ValidationErrors{{Field: "name", Error: "must be at most 100 characters"}}, (&Tenant{Name: strings.Repeat("a", 101)}).Validate())
+
+	assert.Empty(t, (&APIKey{}).Validate())
+	assert.Equal(t, ValidationErrors{{Field: "name", Error: "must be at most 100 characters"}}, (&APIKey{Name: strings.Repeat("k", 101)}).Validate())
+}
+
+func TestAirportNoteValidate(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour)
+	assert.Empty(t, (&AirportNote{Body: "Fuel unavailable this week", Author: "Dispatch", ExpiresAt: &tomorrow}).Validate())
+	assert.Empty(t, (&AirportNote{Body: strings.Repeat("é", MaxNoteLength)}).Validate(), "Characters count, not bytes")
+
+	yesterday := time.Now().Add(-24 * time.Hour)
+	assert.Equal(t, ValidationErrors{
+		{Field: "body", Error: "is required"},
+		{Field: "expires_at", Error: "must be in the future"},
+	}, (&AirportNote{Body: "  ", ExpiresAt: &yesterday}).Validate())
+	assert.Equal(t, ValidationErrors{
+		{Field: "body", Error: "must be at most 1000 characters"},
+		{Field: "author", Error: "must be at most 100 characters"},
+	}, (&AirportNote{Body: strings.Repeat("x", MaxNoteLength+1), Author: strings.Repeat("x", 101)}).Validate())
+}
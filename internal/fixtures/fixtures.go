@@ -0,0 +1,220 @@
+// Package fixtures answers the requests of the external providers without
+// reaching them: from canned responses embedded in the binary, which know six
+// airports (DEN, JFK, LAX, ORD, SEA and AVL), or from responses recorded from
+// the providers themselves. Either way the service runs without API keys or
+// network access.
+package fixtures
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//go:embed data
+var data embed.FS
+
+// Fixtures are templates stamped with the time of the request, so
+// observations are always current.
+var fixtures = template.Must(template.ParseFS(data, "data/*"))
+
+// providerHosts are the hosts of the external providers.
+var providerHosts = map[string]bool{
+	"api.aviationapi.com":      true,
+	"api.weatherapi.com":       true,
+	"api.openweathermap.org":   true,
+	"aviationweather.gov":      true,
+	"davidmegginson.github.io": true,
+}
+
+// Transport serves the providers' hosts from the fixtures and hands any other
+// request, such as webhooks, to Next.
+type Transport struct {
+	Next http.RoundTripper
+}
+
+func NewTransport(next http.RoundTripper) *Transport {
+	return &Transport{Next: next}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !providerHosts[req.URL.Host] {
+		return t.Next.RoundTrip(req)
+	}
+	query := req.URL.Query()
+	switch req.URL.Host {
+	case "api.aviationapi.com":
+		if req.URL.Path == "/v1/charts" {
+			return serveKeyed(req, "aviationapi-charts.json", strings.Split(query.Get("apt"), ","))
+		}
+		return serveKeyed(req, "aviationapi.json", strings.Split(query.Get("apt"), ","))
+	case "api.weatherapi.com":
+		return serveCity(req, query.Get("q"))
+	case "api.openweathermap.org":
+		return serve(req, "openweathermap.json")
+	case "aviationweather.gov":
+		switch req.URL.Path {
+		case "/api/data/metar":
+			return serveStations(req, "metar.json", query.Get("ids"))
+		case "/api/data/taf":
+			return serveStations(req, "taf.json", query.Get("ids"))
+		case "/api/data/airsigmet":
+			return serve(req, "airsigmet.json")
+		}
+		return respond(req, http.StatusNotFound, nil), nil
+	case "davidmegginson.github.io":
+		return serve(req, path.Base(req.URL.Path))
+	}
+	return respond(req, http.StatusNotFound, nil), nil
+}
+
+// stamp is the time the fixtures are rendered with.
+type stamp struct {
+	now time.Time
+}
+
+func (s stamp) Now() int64          { return s.now.Unix() }
+func (s stamp) ValidTo() int64      { return s.now.Add(4 * time.Hour).Unix() }
+func (s stamp) ValidToTime() string { return s.now.Add(4 * time.Hour).Format("021504") }
+func (s stamp) METARTime() string   { return s.now.Format("021504") }
+func (s stamp) TAFValid() string {
+	return s.now.Format("0215") + "/" + s.now.Add(24*time.Hour).Format("0215")
+}
+func (s stamp) TAFChange() string { return s.now.Add(6 * time.Hour).Format("021504") }
+
+// render executes a fixture, or returns nil when there is none by that name.
+func render(name string) ([]byte, error) {
+	fixture := fixtures.Lookup(name)
+	if fixture == nil {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	if err := fixture.Execute(&buf, stamp{now: time.Now().UTC().Truncate(time.Hour)}); err != nil {
+		return nil, fmt.Errorf("failed to render fixture %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func serve(req *http.Request, name string) (*http.Response, error) {
+	body, err := render(name)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return respond(req, http.StatusNotFound, nil), nil
+	}
+	return respond(req, http.StatusOK, body), nil
+}
+
+// serveKeyed answers with the entries of an object fixture under keys, as
+// lists, and an empty list for the keys it doesn't have.
+func serveKeyed(req *http.Request, name string, keys []string) (*http.Response, error) {
+	var all map[string]json.RawMessage
+	if err := decode(name, &all); err != nil {
+		return nil, err
+	}
+	result := make(map[string]json.RawMessage, len(keys))
+	for _, key := range keys {
+		key = strings.ToUpper(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		result[key] = json.RawMessage(`[]`)
+		if entry, ok := all[key]; ok {
+			result[key] = entry
+		}
+	}
+	return respondJSON(req, result)
+}
+
+// serveCity answers with the weather of a city, or the default one for other
+// cities and coordinates.
+func serveCity(req *http.Request, city string) (*http.Response, error) {
+	var all map[string]json.RawMessage
+	if err := decode("weatherapi.json", &all); err != nil {
+		return nil, err
+	}
+	current, ok := all[strings.ToLower(strings.TrimSpace(city))]
+	if !ok {
+		current = all["default"]
+	}
+	return respond(req, http.StatusOK, current), nil
+}
+
+// serveStations answers with the reports of a list fixture for the
+// comma-separated ICAO codes of ids, and 204 when there is none, like the
+// Aviation Weather Center does.
+func serveStations(req *http.Request, name, ids string) (*http.Response, error) {
+	var all []json.RawMessage
+	if err := decode(name, &all); err != nil {
+		return nil, err
+	}
+	wanted := map[string]bool{}
+	for _, id := range strings.Split(ids, ",") {
+		wanted[strings.ToUpper(strings.TrimSpace(id))] = true
+	}
+
+	reports := []json.RawMessage{}
+	for _, report := range all {
+		var station struct {
+			IcaoID string `json:"icaoId"`
+		}
+		if err := json.Unmarshal(report, &station); err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", name, err)
+		}
+		if wanted[station.IcaoID] {
+			reports = append(reports, report)
+		}
+	}
+	if len(reports) == 0 {
+		return respond(req, http.StatusNoContent, nil), nil
+	}
+	return respondJSON(req, reports)
+}
+
+func decode(name string, dst any) error {
+	body, err := render(name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, dst); err != nil {
+		return fmt.Errorf("invalid fixture %s: %w", name, err)
+	}
+	return nil
+}
+
+func respondJSON(req *http.Request, v any) (*http.Response, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return respond(req, http.StatusOK, body), nil
+}
+
+func respond(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {contentType(req.URL.Path)}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func contentType(urlPath string) string {
+	if strings.HasSuffix(urlPath, ".csv") {
+		return "text/csv"
+	}
+	return "application/json"
+}
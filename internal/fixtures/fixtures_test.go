@@ -0,0 +1,123 @@
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/awc"
+	"aviation-weather/internal/ourairports"
+	"aviation-weather/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient() *http.Client {
+	return &http.Client{Transport: NewTransport(http.DefaultTransport)}
+}
+
+func TestAviationAPI(t *testing.T) {
+	resp, err := newClient().Get("https://api.aviationapi.com/v1/airports?apt=DEN,xyz")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+
+	var airports map[string][]struct {
+		Faa  string `json:"faa_ident"`
+		City string `json:"city"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&airports))
+	if assert.Len(t, airports["DEN"], 1) {
+		assert.Equal(t, "DENVER", airports["DEN"][0].City)
+	}
+	assert.Empty(t, airports["XYZ"], "Unknown airports are listed empty")
+}
+
+func TestAviationAPICharts(t *testing.T) {
+	resp, err := newClient().Get("https://api.aviationapi.com/v1/charts?apt=DEN")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+
+	var charts map[string][]struct {
+		Code string `json:"chart_code"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&charts))
+	if assert.Len(t, charts["DEN"], 4) {
+		assert.Equal(t, "APD", charts["DEN"][0].Code)
+	}
+}
+
+func TestWeatherProviders(t *testing.T) {
+	ctx := context.Background()
+	loc := weather.Location{City: "DENVER", Icao: "KDEN"}
+
+	obs, err := weather.NewWeatherAPI(newClient(), "mock").Fetch(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, "Partly cloudy", obs.Condition)
+	assert.WithinDuration(t, time.Now(), obs.ObservedAt, time.Hour, "Observations are current")
+
+	obs, err = weather.NewWeatherAPI(newClient(), "mock").Fetch(ctx, weather.Location{City: "Springfield"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Clear", obs.Condition, "Other cities get the default")
+
+	obs, err = weather.NewOpenWeatherMap(newClient(), "mock").Fetch(ctx, loc)
+	assert.NoError(t, err)
+	assert.Equal(t, "Scattered clouds", obs.Condition)
+
+	obs, err = weather.NewNOAA(newClient()).Fetch(ctx, weather.Location{Icao: "KJFK"})
+	assert.NoError(t, err)
+	assert.Equal(t, "-RA BR", obs.Condition)
+	assert.Contains(t, obs.RawMETAR, "KJFK ")
+
+	_, err = weather.NewNOAA(newClient()).Fetch(ctx, weather.Location{Icao: "EGLL"})
+	assert.Error(t, err, "Stations without a fixture have no METAR")
+}
+
+func TestAWC(t *testing.T) {
+	client := awc.NewClient(newClient())
+
+	advisories, err := client.Advisories(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, advisories, 2)
+
+	taf, err := client.TAF(context.Background(), "KSEA")
+	assert.NoError(t, err)
+	assert.Contains(t, taf, "TAF KSEA")
+
+	taf, err = client.TAF(context.Background(), "KAVL")
+	assert.NoError(t, err)
+	assert.Empty(t, taf)
+}
+
+func TestOurAirports(t *testing.T) {
+	client := ourairports.NewClient(newClient())
+
+	frequencies, err := client.Frequencies(context.Background(), "KLAX", "LAX")
+	assert.NoError(t, err)
+	assert.Len(t, frequencies, 2)
+
+	runways, err := client.Runways(context.Background(), "KJFK")
+	assert.NoError(t, err)
+	assert.Len(t, runways, 4)
+}
+
+func TestOtherHostsPassThrough(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "webhook received")
+	}))
+	defer server.Close()
+
+	resp, err := newClient().Post(server.URL, "application/json", nil)
+	if assert.NoError(t, err) {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "webhook received", string(body))
+	}
+}
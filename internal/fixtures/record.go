@@ -0,0 +1,186 @@
+package fixtures
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// secretParams are the query parameters carrying API keys, left out of
+// recordings and of their file names.
+var secretParams = []string{"key", "appid"}
+
+// Recording is a provider response as written to a recordings directory. A
+// JSON body is kept as is, so it can be read and edited; any other body, such
+// as a CSV dataset, as text.
+type Recording struct {
+	Method      string          `json:"method"`
+	URL         string          `json:"url"`
+	Status      int             `json:"status"`
+	ContentType string          `json:"content_type,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+	Text        string          `json:"text,omitempty"`
+	RecordedAt  time.Time       `json:"recorded_at"`
+}
+
+// Recorder sends the providers' requests through Next and writes each response
+// to Dir, replacing an earlier recording of the same request. Other requests
+// are only handed to Next.
+type Recorder struct {
+	Dir  string
+	Next http.RoundTripper
+}
+
+func NewRecorder(dir string, next http.RoundTripper) *Recorder {
+	return &Recorder{Dir: dir, Next: next}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !providerHosts[req.URL.Host] {
+		return r.Next.RoundTrip(req)
+	}
+
+	// A 304 answer would leave nothing to replay
+	req = req.Clone(req.Context())
+	req.Header.Del("If-None-Match")
+	req.Header.Del("If-Modified-Since")
+
+	resp, err := r.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response to record: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec := Recording{
+		Method:      req.Method,
+		URL:         redactedURL(req.URL),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		RecordedAt:  time.Now().UTC(),
+	}
+	if json.Valid(body) {
+		rec.Body = body
+	} else {
+		rec.Text = string(body)
+	}
+	if err := r.save(fileName(req), rec); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes a recording through a temporary file, so a replay never reads
+// it half written.
+func (r *Recorder) save(name string, rec Recording) error {
+	content, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording of %s: %w", rec.URL, err)
+	}
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create recordings dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(r.Dir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to record %s: %w", rec.URL, err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+	if _, err := tmp.Write(append(content, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to record %s: %w", rec.URL, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to record %s: %w", rec.URL, err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(r.Dir, name)); err != nil {
+		return fmt.Errorf("failed to record %s: %w", rec.URL, err)
+	}
+	return nil
+}
+
+// Replayer answers the providers' requests from the recordings in Dir and
+// hands other requests to Next. A request that was never recorded fails, so
+// a test relying on it can't reach the network by accident.
+type Replayer struct {
+	Dir  string
+	Next http.RoundTripper
+}
+
+func NewReplayer(dir string, next http.RoundTripper) *Replayer {
+	return &Replayer{Dir: dir, Next: next}
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !providerHosts[req.URL.Host] {
+		return r.Next.RoundTrip(req)
+	}
+
+	content, err := os.ReadFile(filepath.Join(r.Dir, fileName(req)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no recording of %s %s in %s", req.Method, redactedURL(req.URL), r.Dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	var rec Recording
+	if err := json.Unmarshal(content, &rec); err != nil {
+		return nil, fmt.Errorf("invalid recording of %s: %w", redactedURL(req.URL), err)
+	}
+
+	body := []byte(rec.Text)
+	if len(rec.Body) > 0 {
+		body = rec.Body
+	}
+	resp := respond(req, rec.Status, body)
+	if rec.ContentType != "" {
+		resp.Header.Set("Content-Type", rec.ContentType)
+	}
+	return resp, nil
+}
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// fileName names the recording of a request after its method and redacted
+// URL, readable up to 100 characters and made unique by a hash of the whole.
+func fileName(req *http.Request) string {
+	key := req.Method + " " + redactedURL(req.URL)
+	sum := sha256.Sum256([]byte(key))
+
+	u := *req.URL
+	u.RawQuery = redactedQuery(req.URL)
+	readable := unsafeChars.ReplaceAllString(u.Host+u.Path+"_"+u.RawQuery, "_")
+	if len(readable) > 100 {
+		readable = readable[:100]
+	}
+	return fmt.Sprintf("%s-%s.json", readable, hex.EncodeToString(sum[:4]))
+}
+
+// redactedURL is u without its API keys.
+func redactedURL(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = redactedQuery(u)
+	return redacted.String()
+}
+
+func redactedQuery(u *url.URL) string {
+	query := u.Query()
+	for _, param := range secretParams {
+		query.Del(param)
+	}
+	return query.Encode()
+}
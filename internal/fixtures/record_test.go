@@ -0,0 +1,61 @@
+package fixtures
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aviation-weather/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	recorder := NewRecorder(dir, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Empty(t, req.Header.Get("If-None-Match"), "Recordings hold full responses")
+		return NewTransport(nil).RoundTrip(req)
+	}))
+
+	recorded, err := weather.NewWeatherAPI(&http.Client{Transport: recorder}, "secret-key").Fetch(context.Background(), weather.Location{City: "SEATTLE"})
+	assert.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://davidmegginson.github.io/ourairports-data/runways.csv", nil)
+	req.Header.Set("If-None-Match", `"v1"`)
+	resp, err := recorder.RoundTrip(req)
+	if assert.NoError(t, err) {
+		csv, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(csv), "KDEN", "The caller still gets the body")
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if assert.Len(t, files, 2) {
+		for _, file := range files {
+			content, _ := os.ReadFile(file)
+			assert.NotContains(t, string(content), "secret-key")
+			assert.NotContains(t, file, "secret-key")
+		}
+	}
+
+	// Replayed under another key, from the recordings alone
+	replayer := &http.Client{Transport: NewReplayer(dir, nil)}
+	replayed, err := weather.NewWeatherAPI(replayer, "other-key").Fetch(context.Background(), weather.Location{City: "SEATTLE"})
+	assert.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+
+	resp, err = replayer.Get("https://davidmegginson.github.io/ourairports-data/runways.csv")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+		resp.Body.Close()
+	}
+
+	_, err = weather.NewWeatherAPI(replayer, "other-key").Fetch(context.Background(), weather.Location{City: "DENVER"})
+	assert.ErrorContains(t, err, "no recording of GET https://api.weatherapi.com/v1/current.json?q=DENVER")
+}
@@ -0,0 +1,125 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	pb "aviation-weather/api/proto/aviationweatherv1"
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server serves the AirportService of api/proto over the same
+// ServiceInterface as the HTTP handlers, for internal consumers that would
+// rather skip JSON.
+type Server struct {
+	pb.UnimplementedAirportServiceServer
+	svc service.ServiceInterface
+}
+
+func NewServer(svc service.ServiceInterface) *Server {
+	return &Server{svc: svc}
+}
+
+// Register returns a grpc.Server serving s, ready to Serve a listener.
+func (s *Server) Register(opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(opts...)
+	pb.RegisterAirportServiceServer(srv, s)
+	return srv
+}
+
+func (s *Server) GetAirport(ctx context.Context, req *pb.GetAirportRequest) (*pb.Airport, error) {
+	if req.GetFaaIdent() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing FAA ident")
+	}
+	airport, err := s.svc.GetAirportByFAA(req.GetFaaIdent())
+	if err != nil {
+		log.Printf("grpc GetAirport: service error for %s: %v", req.GetFaaIdent(), err)
+		return nil, statusError(err)
+	}
+	return toProto(airport), nil
+}
+
+func (s *Server) ListAirports(ctx context.Context, req *pb.ListAirportsRequest) (*pb.ListAirportsResponse, error) {
+	airports, err := s.svc.GetAllAirports(nil)
+	if err != nil {
+		log.Printf("grpc ListAirports: service error: %v", err)
+		return nil, statusError(err)
+	}
+	resp := &pb.ListAirportsResponse{Airports: make([]*pb.Airport, 0, len(airports))}
+	for i := range airports {
+		resp.Airports = append(resp.Airports, toProto(&airports[i]))
+	}
+	return resp, nil
+}
+
+// SyncAirport queues the sync like POST /v1/sync/{faa} and waits for it.
+func (s *Server) SyncAirport(ctx context.Context, req *pb.SyncAirportRequest) (*pb.SyncAirportResponse, error) {
+	if req.GetFaaIdent() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing FAA ident")
+	}
+	result, err := s.svc.SyncAirportQueued(req.GetFaaIdent())
+	if err != nil {
+		log.Printf("grpc SyncAirport: service error for %s: %v", req.GetFaaIdent(), err)
+		return nil, statusError(err)
+	}
+	return &pb.SyncAirportResponse{Airport: toProto(result.Airport), ChangedFields: result.ChangedFields}, nil
+}
+
+// SyncAllAirports queues the sync like POST /v1/sync and waits for it.
+func (s *Server) SyncAllAirports(ctx context.Context, req *pb.SyncAllAirportsRequest) (*pb.SyncAllAirportsResponse, error) {
+	updated, err := s.svc.SyncAllAirportsQueued(ctx)
+	if err != nil {
+		log.Printf("grpc SyncAllAirports: service error: %v", err)
+		return nil, statusError(err)
+	}
+	return &pb.SyncAllAirportsResponse{Updated: int32(updated)}, nil
+}
+
+// statusError maps the service's sentinel errors to gRPC codes, as
+// respondServiceError does to HTTP statuses.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, "airport not found")
+	case errors.Is(err, domain.ErrNoData):
+		return status.Error(codes.NotFound, "data not available")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "request timeout")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+	case errors.Is(err, domain.ErrExternalAPI):
+		return status.Error(codes.Unavailable, "external API error")
+	default:
+		return status.Error(codes.Internal, "service error")
+	}
+}
+
+func toProto(a *domain.Airport) *pb.Airport {
+	return &pb.Airport{
+		SiteNumber:   a.SiteNumber,
+		FacilityName: a.FacilityName,
+		FaaIdent:     a.Faa,
+		IcaoIdent:    a.Icao,
+		State:        a.StateCode,
+		StateFull:    a.StateFull,
+		County:       a.County,
+		City:         a.City,
+		Ownership:    a.OwnershipType,
+		Use:          a.UseType,
+		Manager:      a.Manager,
+		ManagerPhone: a.ManagerPhone,
+		Latitude:     a.Latitude,
+		Longitude:    a.Longitude,
+		Status:       a.AirportStatus,
+		Weather:      a.Weather,
+		ElevationFt:  a.ElevationFt,
+		Timezone:     a.Timezone,
+		FuelTypes:    a.FuelTypes,
+	}
+}
@@ -0,0 +1,172 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	pb "aviation-weather/api/proto/aviationweatherv1"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock" // No conflict with testify
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var sampleLatitude, sampleLongitude = 34.0522, -118.2437
+
+var sampleAirport = domain.Airport{
+	SiteNumber:    "12345",
+	FacilityName:  "Test Airport",
+	Faa:           "TST",
+	Icao:          "KTST",
+	StateCode:     "CA",
+	City:          "Los Angeles",
+	AirportStatus: "O",
+	Weather:       "Sunny",
+	Latitude:      &sampleLatitude,
+	Longitude:     &sampleLongitude,
+}
+
+// dial serves svc over an in-memory listener and returns a client of it.
+func dial(t *testing.T, svc *mocks.ServiceMock) pb.AirportServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	srv := NewServer(svc).Register()
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewAirportServiceClient(conn)
+}
+
+func TestGetAirport(t *testing.T) {
+	tests := []struct {
+		name      string
+		faa       string
+		setupMock func(*mocks.ServiceMock)
+		code      codes.Code
+	}{
+		{
+			name: "found",
+			faa:  "TST",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+			},
+			code: codes.OK,
+		},
+		{
+			name: "not found",
+			faa:  "XXX",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportByFAA", "XXX").Return((*domain.Airport)(nil), fmt.Errorf("%w: XXX", domain.ErrNotFound))
+			},
+			code: codes.NotFound,
+		},
+		{
+			name:      "missing ident",
+			setupMock: func(m *mocks.ServiceMock) {},
+			code:      codes.InvalidArgument,
+		},
+		{
+			name: "service error",
+			faa:  "TST",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportByFAA", "TST").Return((*domain.Airport)(nil), assert.AnError)
+			},
+			code: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			client := dial(t, mockSvc)
+
+			airport, err := client.GetAirport(context.Background(), &pb.GetAirportRequest{FaaIdent: tt.faa})
+			assert.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.OK {
+				assert.Equal(t, "KTST", airport.GetIcaoIdent())
+				assert.Equal(t, "Sunny", airport.GetWeather())
+				assert.Equal(t, sampleLatitude, airport.GetLatitude())
+				assert.Equal(t, sampleLongitude, airport.GetLongitude())
+				assert.Nil(t, airport.ElevationFt, "Unknown values stay unset")
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAirports(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetAllAirports", []domain.SortField(nil)).Return([]domain.Airport{sampleAirport, {Faa: "DEN"}}, nil)
+	client := dial(t, mockSvc)
+
+	resp, err := client.ListAirports(context.Background(), &pb.ListAirportsRequest{})
+	assert.NoError(t, err)
+	if assert.Len(t, resp.GetAirports(), 2) {
+		assert.Equal(t, "TST", resp.GetAirports()[0].GetFaaIdent())
+		assert.Equal(t, "DEN", resp.GetAirports()[1].GetFaaIdent())
+	}
+	mockSvc.AssertExpectations(t)
+}
+
+func TestSyncAirport(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.ServiceMock)
+		code      codes.Code
+	}{
+		{
+			name: "synced",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("SyncAirportQueued", "TST").Return(&domain.SyncResult{Airport: &sampleAirport, ChangedFields: []string{"weather"}}, nil)
+			},
+			code: codes.OK,
+		},
+		{
+			name: "provider down",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("SyncAirportQueued", "TST").Return((*domain.SyncResult)(nil), fmt.Errorf("%w: timeout", domain.ErrExternalAPI))
+			},
+			code: codes.Unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			client := dial(t, mockSvc)
+
+			resp, err := client.SyncAirport(context.Background(), &pb.SyncAirportRequest{FaaIdent: "TST"})
+			assert.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.OK {
+				assert.Equal(t, "TST", resp.GetAirport().GetFaaIdent())
+				assert.Equal(t, []string{"weather"}, resp.GetChangedFields())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSyncAllAirports(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("SyncAllAirportsQueued", mock.Anything).Return(12, nil)
+	client := dial(t, mockSvc)
+
+	resp, err := client.SyncAllAirports(context.Background(), &pb.SyncAllAirportsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(12), resp.GetUpdated())
+	mockSvc.AssertExpectations(t)
+}
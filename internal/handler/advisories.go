@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// getAdvisories: Lists the SIGMETs and AIRMETs in effect.
+func (h *Handler) getAdvisories(w http.ResponseWriter, r *http.Request) {
+	advisories, err := h.svc.GetAdvisories()
+	if err != nil {
+		log.Printf("getAdvisories: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Advisories are Fetched", advisories)
+}
+
+// getAirportAdvisories: Lists the SIGMETs and AIRMETs in effect whose area contains the airport.
+func (h *Handler) getAirportAdvisories(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	advisories, err := h.svc.GetAirportAdvisories(faa)
+	if err != nil {
+		log.Printf("getAirportAdvisories: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Advisories are Fetched", advisories)
+}
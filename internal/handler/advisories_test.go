@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdvisoryEndpoints(t *testing.T) {
+	sigmet := domain.Advisory{
+		ID:      7,
+		Type:    domain.AdvisorySIGMET,
+		Hazard:  "CONVECTIVE",
+		RawText: "CONVECTIVE SIGMET 12C",
+		Area:    []domain.AdvisoryPoint{{Lat: 37, Lon: -102}, {Lat: 40, Lon: -102}, {Lat: 40, Lon: -94}},
+	}
+	sigmetJSON := `{"id":7,"type":"SIGMET","hazard":"CONVECTIVE","raw_text":"CONVECTIVE SIGMET 12C","area":[{"lat":37,"lon":-102},{"lat":40,"lon":-102},{"lat":40,"lon":-94}]}`
+
+	tests := []struct {
+		name         string
+		url          string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name: "list advisories",
+			url:  "/v1/advisories",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAdvisories").Return([]domain.Advisory{sigmet}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Advisories are Fetched","data":[` + sigmetJSON + `]}`,
+		},
+		{
+			name: "airport advisories",
+			url:  "/v1/airport/ICT/advisories",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportAdvisories", "ICT").Return([]domain.Advisory{sigmet}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Advisories are Fetched","data":[` + sigmetJSON + `]}`,
+		},
+		{
+			name: "airport without coordinates",
+			url:  "/v1/airport/NC/advisories",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportAdvisories", "NC").Return([]domain.Advisory(nil), fmt.Errorf("%w: no coordinates for NC", domain.ErrNoData))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Data Not Available","error_code":"no_data","data":null}`,
+		},
+		{
+			name: "airport not found",
+			url:  "/v1/airport/ZZZ/advisories",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportAdvisories", "ZZZ").Return([]domain.Advisory(nil), domain.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			r := NewHandler(mockSvc, &config.Config{}).Router()
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package handler
+
+import (
+	"net/http"
+
+	"aviation-weather/internal/utils"
+)
+
+// getAIRAC: Reports the AIRAC cycle in effect and the next one.
+func (h *Handler) getAIRAC(w http.ResponseWriter, r *http.Request) {
+	utils.EncodeResponseToUser(w, "OK", "AIRAC Cycle is Fetched", h.svc.GetAIRAC())
+}
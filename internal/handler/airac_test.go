@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAIRAC(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetAIRAC").Return(domain.AIRACStatus{
+		Current: domain.AIRACCycle{
+			Ident:     "2501",
+			Effective: time.Date(2025, 1, 23, 0, 0, 0, 0, time.UTC),
+			Expires:   time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC),
+		},
+		Next: domain.AIRACCycle{
+			Ident:     "2502",
+			Effective: time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC),
+			Expires:   time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC),
+		},
+		DaysUntilNext: 12,
+	})
+	h := NewHandler(mockSvc, &config.Config{})
+
+	req := httptest.NewRequest("GET", "/v1/airac", nil)
+	rec := httptest.NewRecorder()
+	h.Router().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"OK","message":"AIRAC Cycle is Fetched","data":{
+		"current":{"ident":"2501","effective":"2025-01-23T00:00:00Z","expires":"2025-02-20T00:00:00Z"},
+		"next":{"ident":"2502","effective":"2025-02-20T00:00:00Z","expires":"2025-03-20T00:00:00Z"},
+		"days_until_next":12}}`, rec.Body.String())
+	mockSvc.AssertExpectations(t)
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/xml"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// AirportRequest is the body of the airport create, update, bulk and import
+// endpoints. It holds only what a client may set; the altitudes, timezone and
+// timestamps are maintained by syncs and the repository.
+type AirportRequest struct {
+	SiteNumber        string          `json:"site_number"`
+	FacilityName      string          `json:"facility_name"`
+	Faa               string          `json:"faa_ident"`
+	Icao              string          `json:"icao_ident"`
+	StateCode         string          `json:"state"`
+	StateFull         string          `json:"state_full"`
+	County            string          `json:"county"`
+	City              string          `json:"city"`
+	OwnershipType     string          `json:"ownership"`
+	UseType           string          `json:"use"`
+	Manager           string          `json:"manager"`
+	ManagerPhone      string          `json:"manager_phone"`
+	AirportStatus     string          `json:"status"`
+	Weather           string          `json:"weather"`
+	Latitude          coordinateInput `json:"latitude"`
+	Longitude         coordinateInput `json:"longitude"`
+	ElevationFt       *float64        `json:"elevation_ft"`
+	MagneticVariation *float64        `json:"magnetic_variation"`
+	// Fields syncs must leave alone; when left out, those the update changes
+	// are added to the stored ones
+	ManualOverrides []string `json:"manual_overrides"`
+}
+
+// toAirport maps the request onto the stored model.
+func (req *AirportRequest) toAirport() domain.Airport {
+	return domain.Airport{
+		SiteNumber:        req.SiteNumber,
+		FacilityName:      req.FacilityName,
+		Faa:               req.Faa,
+		Icao:              req.Icao,
+		StateCode:         req.StateCode,
+		StateFull:         req.StateFull,
+		County:            req.County,
+		City:              req.City,
+		OwnershipType:     req.OwnershipType,
+		UseType:           req.UseType,
+		Manager:           req.Manager,
+		ManagerPhone:      req.ManagerPhone,
+		AirportStatus:     req.AirportStatus,
+		Weather:           req.Weather,
+		Latitude:          req.Latitude.degrees,
+		Longitude:         req.Longitude.degrees,
+		ElevationFt:       req.ElevationFt,
+		MagneticVariation: req.MagneticVariation,
+		ManualOverrides:   req.ManualOverrides,
+	}
+}
+
+// Validate checks the airport the request maps to, and reports coordinates
+// that could not be read at all.
+func (req *AirportRequest) Validate() domain.ValidationErrors {
+	airport := req.toAirport()
+	errs := airport.Validate()
+	for _, c := range []struct {
+		field string
+		input coordinateInput
+	}{{"latitude", req.Latitude}, {"longitude", req.Longitude}} {
+		if c.input.invalid != "" {
+			errs = append(errs, domain.FieldError{Field: c.field, Error: c.input.invalid})
+		}
+	}
+	return errs
+}
+
+// AirportResponse is an airport as the API returns it.
+type AirportResponse struct {
+	XMLName xml.Name `json:"-" xml:"airport"`
+
+	SiteNumber    string `json:"site_number" xml:"site_number"`
+	FacilityName  string `json:"facility_name" xml:"facility_name"`
+	Faa           string `json:"faa_ident" xml:"faa_ident"`
+	Icao          string `json:"icao_ident" xml:"icao_ident"`
+	StateCode     string `json:"state" xml:"state"`
+	StateFull     string `json:"state_full" xml:"state_full"`
+	County        string `json:"county" xml:"county"`
+	City          string `json:"city" xml:"city"`
+	OwnershipType string `json:"ownership" xml:"ownership"`
+	UseType       string `json:"use" xml:"use"`
+	Manager       string `json:"manager" xml:"manager"`
+	ManagerPhone  string `json:"manager_phone" xml:"manager_phone"`
+	AirportStatus string `json:"status" xml:"status"`
+	Weather       string `json:"weather" xml:"weather"`
+
+	Latitude  *float64 `json:"latitude,omitempty" xml:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty" xml:"longitude,omitempty"`
+
+	// The position in DD-MM-SS.sH, only with ?coordinates=dms
+	LatitudeDMS  string `json:"latitude_dms,omitempty" xml:"latitude_dms,omitempty"`
+	LongitudeDMS string `json:"longitude_dms,omitempty" xml:"longitude_dms,omitempty"`
+
+	ElevationFt        *float64 `json:"elevation_ft,omitempty" xml:"elevation_ft,omitempty"`
+	PressureAltitudeFt *int     `json:"pressure_altitude_ft,omitempty" xml:"pressure_altitude_ft,omitempty"`
+	DensityAltitudeFt  *int     `json:"density_altitude_ft,omitempty" xml:"density_altitude_ft,omitempty"`
+	MagneticVariation  *float64 `json:"magnetic_variation,omitempty" xml:"magnetic_variation,omitempty"`
+	Timezone           string   `json:"timezone,omitempty" xml:"timezone,omitempty"`
+
+	FuelTypes []string `json:"fuel_types,omitempty" xml:"fuel_type,omitempty"`
+	FboName   string   `json:"fbo_name,omitempty" xml:"fbo_name,omitempty"`
+	FboPhone  string   `json:"fbo_phone,omitempty" xml:"fbo_phone,omitempty"`
+
+	ManualOverrides []string `json:"manual_overrides,omitempty" xml:"manual_override,omitempty"`
+	LifecycleStatus string   `json:"lifecycle_status,omitempty" xml:"lifecycle_status,omitempty"`
+
+	// Operational notes that have not expired, only on a single airport
+	Notes []domain.AirportNote `json:"notes,omitempty" xml:"note,omitempty"`
+
+	CreatedAt    *time.Time `json:"created_at,omitempty" xml:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty" xml:"last_synced_at,omitempty"`
+}
+
+// newAirportResponse maps a stored airport onto its response, with its
+// coordinates in DD-MM-SS.sH too when dms is set.
+func newAirportResponse(a *domain.Airport, dms bool) AirportResponse {
+	resp := AirportResponse{
+		SiteNumber:         a.SiteNumber,
+		FacilityName:       a.FacilityName,
+		Faa:                a.Faa,
+		Icao:               a.Icao,
+		StateCode:          a.StateCode,
+		StateFull:          a.StateFull,
+		County:             a.County,
+		City:               a.City,
+		OwnershipType:      a.OwnershipType,
+		UseType:            a.UseType,
+		Manager:            a.Manager,
+		ManagerPhone:       a.ManagerPhone,
+		AirportStatus:      a.AirportStatus,
+		Weather:            a.Weather,
+		Latitude:           a.Latitude,
+		Longitude:          a.Longitude,
+		ElevationFt:        a.ElevationFt,
+		PressureAltitudeFt: a.PressureAltitudeFt,
+		DensityAltitudeFt:  a.DensityAltitudeFt,
+		MagneticVariation:  a.MagneticVariation,
+		Timezone:           a.Timezone,
+		FuelTypes:          a.FuelTypes,
+		FboName:            a.FboName,
+		FboPhone:           a.FboPhone,
+		ManualOverrides:    a.ManualOverrides,
+		LifecycleStatus:    a.LifecycleStatus,
+		CreatedAt:          a.CreatedAt,
+		UpdatedAt:          a.UpdatedAt,
+		LastSyncedAt:       a.LastSyncedAt,
+	}
+	if dms && a.Latitude != nil {
+		resp.LatitudeDMS = domain.FormatDMS(*a.Latitude, false)
+	}
+	if dms && a.Longitude != nil {
+		resp.LongitudeDMS = domain.FormatDMS(*a.Longitude, true)
+	}
+	return resp
+}
+
+// newAirportResponses maps stored airports onto their responses.
+func newAirportResponses(airports []domain.Airport, dms bool) []AirportResponse {
+	if airports == nil {
+		return nil
+	}
+	resp := make([]AirportResponse, len(airports))
+	for i := range airports {
+		resp[i] = newAirportResponse(&airports[i], dms)
+	}
+	return resp
+}
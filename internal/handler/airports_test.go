@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAirportRequestCoordinates(t *testing.T) {
+	var req AirportRequest
+	err := json.Unmarshal([]byte(`{"faa_ident":"ATL","latitude":"33-38-12.1186N","longitude":"-84.4279"}`), &req)
+	assert.NoError(t, err)
+	airport := req.toAirport()
+	assert.InDelta(t, 33.63670, *airport.Latitude, 1e-5, "DMS strings are still accepted")
+	assert.Equal(t, -84.4279, *airport.Longitude)
+
+	err = json.Unmarshal([]byte(`{"faa_ident":"ATL","latitude":"","longitude":null}`), &req)
+	assert.NoError(t, err)
+	airport = req.toAirport()
+	assert.Nil(t, airport.Latitude)
+	assert.Nil(t, airport.Longitude)
+
+	err = json.Unmarshal([]byte(`{"faa_ident":"ATL","state":"ZZ","latitude":"north","longitude":true}`), &req)
+	assert.NoError(t, err, "Unparsable coordinates are left to Validate")
+	assert.Equal(t, domain.ValidationErrors{
+		{Field: "state", Error: `unknown state code "ZZ"`},
+		{Field: "latitude", Error: `must be decimal degrees or DD-MM-SS.sH, got "north"`},
+		{Field: "longitude", Error: "must be decimal degrees or DD-MM-SS.sH, got true"},
+	}, req.Validate())
+}
+
+func TestAirportRequestLeavesOutMaintainedFields(t *testing.T) {
+	var req AirportRequest
+	err := json.Unmarshal([]byte(`{"faa_ident":"DFW","timezone":"Europe/Paris","density_altitude_ft":9000,"last_synced_at":"2025-01-02T12:00:00Z"}`), &req)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Airport{Faa: "DFW"}, req.toAirport(), "Only syncs and the repository set these")
+}
+
+func TestNewAirportResponse(t *testing.T) {
+	lat, lon := 40.6398, -73.7789
+	synced := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	airport := domain.Airport{Faa: "JFK", Latitude: &lat, Longitude: &lon, Timezone: "America/New_York", LastSyncedAt: &synced}
+
+	resp := newAirportResponse(&airport, false)
+	assert.Equal(t, AirportResponse{Faa: "JFK", Latitude: &lat, Longitude: &lon, Timezone: "America/New_York", LastSyncedAt: &synced}, resp)
+
+	resp = newAirportResponse(&airport, true)
+	assert.Equal(t, "40-38-23.2800N", resp.LatitudeDMS)
+	assert.Equal(t, "073-46-44.0400W", resp.LongitudeDMS)
+
+	assert.Nil(t, newAirportResponses(nil, false))
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Paging of the alerts endpoint.
+const (
+	defaultAlertsWindow = 24 * time.Hour
+	defaultAlertsLimit  = 100
+	maxAlertsLimit      = 1000
+)
+
+// getAlerts: Lists alerts raised since ?since= (RFC 3339, default the last 24
+// hours), newest first, optionally for one ?faa= and capped at ?limit=.
+func (h *Handler) getAlerts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	since := time.Now().Add(-defaultAlertsWindow)
+	if value := query.Get("since"); value != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, value); err != nil {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Since", nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	limit := defaultAlertsLimit
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 || limit > maxAlertsLimit {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Limit", nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	alerts, err := h.svc.GetAlerts(tenantID(r), query.Get("faa"), since, limit)
+	if err != nil {
+		log.Printf("getAlerts: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Alerts are Fetched", len(alerts)), alerts)
+}
+
+func (h *Handler) getAlertRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.svc.GetAlertRules(tenantID(r))
+	if err != nil {
+		log.Printf("getAlertRules: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Alert Rules are Fetched", rules)
+}
+
+func (h *Handler) createAlertRule(w http.ResponseWriter, r *http.Request) {
+	var rule domain.AlertRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		log.Printf("createAlertRule: invalid JSON: %v", err)
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid JSON", nil, http.StatusBadRequest)
+		return
+	}
+
+	if errs := rule.Validate(); len(errs) > 0 {
+		log.Printf("createAlertRule: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	rule.TenantID = tenantID(r)
+	if err := h.svc.CreateAlertRule(&rule); err != nil {
+		log.Printf("createAlertRule: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Alert Rule is Created", rule)
+}
+
+func (h *Handler) deleteAlertRule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Rule ID", nil, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.svc.DeleteAlertRule(tenantID(r), id); err != nil {
+		log.Printf("deleteAlertRule: service error for %d: %v", id, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Alert Rule is Deleted", id)
+}
+
+// testNotification sends a sample alert to the target in the body. It is an
+// admin route, as it mails or posts to whatever target it is given.
+func (h *Handler) testNotification(w http.ResponseWriter, r *http.Request) {
+	var target domain.NotifyTarget
+	if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+		log.Printf("testNotification: invalid JSON: %v", err)
+		respondInvalidJSON(w, err)
+		return
+	}
+
+	if errs := target.Validate(); len(errs) > 0 {
+		log.Printf("testNotification: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	if err := h.svc.SendTestNotification(r.Context(), target); err != nil {
+		log.Printf("testNotification: service error for %s %s: %v", target.Channel, target.Target, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Test Notification is Sent", target)
+}
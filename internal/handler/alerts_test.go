@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAlertRuleEndpoints(t *testing.T) {
+	createdAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		token        string
+		body         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "create",
+			method: "POST",
+			url:    "/v1/alerts/rules",
+			body:   `{"name":"Strong wind","state":"TX","metric":"wind_speed_kt","operator":"gt","threshold":25}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateAlertRule", mock.AnythingOfType("*domain.AlertRule")).Run(func(args mock.Arguments) {
+					rule := args.Get(0).(*domain.AlertRule)
+					rule.ID, rule.CreatedAt = 7, &createdAt
+				}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Alert Rule is Created","data":{"id":7,"name":"Strong wind","state":"TX","metric":"wind_speed_kt","operator":"gt","threshold":25,"created_at":"2024-06-01T12:00:00Z"}}`,
+		},
+		{
+			name:         "create invalid",
+			method:       "POST",
+			url:          "/v1/alerts/rules",
+			body:         `{"name":"Rain","metric":"rain_mm","operator":"gt"}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"metric","error":"unknown metric \"rain_mm\""}]}`,
+		},
+		{
+			name:   "list",
+			method: "GET",
+			url:    "/v1/alerts/rules",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAlertRules", domain.DefaultTenantID).Return([]domain.AlertRule{{ID: 7, Name: "Below MVFR", Faa: "DFW", Metric: "flight_category", Operator: "worse_than", Category: "MVFR"}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Alert Rules are Fetched","data":[{"id":7,"name":"Below MVFR","faa_ident":"DFW","metric":"flight_category","operator":"worse_than","category":"MVFR"}]}`,
+		},
+		{
+			name:   "delete missing",
+			method: "DELETE",
+			url:    "/v1/alerts/rules/9",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("DeleteAlertRule", domain.DefaultTenantID, int64(9)).Return(domain.ErrRuleNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Alert Rule Not Found","error_code":"not_found","data":null}`,
+		},
+		{
+			name:         "delete bad id",
+			method:       "DELETE",
+			url:          "/v1/alerts/rules/abc",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid Rule ID","data":null}`,
+		},
+		{
+			name:   "test notification",
+			method: "POST",
+			url:    "/v1/alerts/notifications/test",
+			token:  "admin-token",
+			body:   `{"channel":"slack","target":"https://hooks.slack.com/services/T0/B0/x"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("SendTestNotification", mock.Anything, domain.NotifyTarget{Channel: "slack", Target: "https://hooks.slack.com/services/T0/B0/x"}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Test Notification is Sent","data":{"channel":"slack","target":"https://hooks.slack.com/services/T0/B0/x"}}`,
+		},
+		{
+			name:   "test notification channel disabled",
+			method: "POST",
+			url:    "/v1/alerts/notifications/test",
+			token:  "admin-token",
+			body:   `{"channel":"email","target":"ops@example.com"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("SendTestNotification", mock.Anything, domain.NotifyTarget{Channel: "email", Target: "ops@example.com"}).Return(domain.ErrChannelDisabled)
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedJSON: `{"status":"Error","message":"Notification Channel Not Configured","error_code":"channel_disabled","data":null}`,
+		},
+		{
+			name:         "test notification invalid target",
+			method:       "POST",
+			url:          "/v1/alerts/notifications/test",
+			token:        "admin-token",
+			body:         `{"channel":"email","target":"ops"}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"target","error":"must be an email address"}]}`,
+		},
+		{
+			name:         "test notification invalid JSON",
+			method:       "POST",
+			url:          "/v1/alerts/notifications/test",
+			token:        "admin-token",
+			body:         `{"channel":`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid JSON","data":null}`,
+		},
+		{
+			name:         "test notification without admin token",
+			method:       "POST",
+			url:          "/v1/alerts/notifications/test",
+			body:         `{"channel":"email","target":"ops@example.com"}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnauthorized,
+			expectedJSON: `{"status":"Error","message":"Unauthorized","error_code":"unauthorized","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{AdminToken: "admin-token"})
+
+			req := httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAlerts(t *testing.T) {
+	observedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetAlerts", domain.DefaultTenantID, "DFW", since, 20).Return([]domain.Alert{
+		{ID: 3, RuleID: 7, Faa: "DFW", Metric: "wind_speed_kt", Value: "30", Message: "DFW: wind_speed_kt 30 > 25 (Strong wind)", ObservedAt: observedAt},
+	}, nil)
+	mockSvc.On("GetAlerts", domain.DefaultTenantID, "", mock.AnythingOfType("time.Time"), defaultAlertsLimit).Return([]domain.Alert{}, nil)
+	h := NewHandler(mockSvc, &config.Config{})
+	r := h.Router()
+
+	tests := []struct {
+		url      string
+		code     int
+		expected string
+	}{
+		{"/v1/alerts?faa=DFW&since=2024-06-01T00:00:00Z&limit=20", http.StatusOK, `{"status":"OK","message":"1 Alerts are Fetched","data":[{"id":3,"rule_id":7,"faa_ident":"DFW","metric":"wind_speed_kt","value":"30","message":"DFW: wind_speed_kt 30 > 25 (Strong wind)","observed_at":"2024-06-01T12:00:00Z"}]}`},
+		{"/v1/alerts", http.StatusOK, `{"status":"OK","message":"0 Alerts are Fetched","data":[]}`},
+		{"/v1/alerts?since=yesterday", http.StatusBadRequest, `{"status":"Bad Request","message":"Invalid Since","data":null}`},
+		{"/v1/alerts?limit=5000", http.StatusBadRequest, `{"status":"Bad Request","message":"Invalid Limit","data":null}`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.url, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, tt.code, rec.Code, tt.url)
+		assert.JSONEq(t, tt.expected, rec.Body.String(), tt.url)
+	}
+	mockSvc.AssertExpectations(t)
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// getCaches: Lists each cache with its hits, misses and entries.
+func (h *Handler) getCaches(w http.ResponseWriter, r *http.Request) {
+	caches, err := h.svc.GetCaches()
+	if err != nil {
+		log.Printf("getCaches: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Caches are Fetched", caches)
+}
+
+// clearCache: Drops every entry of a cache, e.g. after a bad upstream response poisoned it.
+func (h *Handler) clearCache(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if err := h.svc.ClearCache(name); err != nil {
+		log.Printf("clearCache: service error for %s: %v", name, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Cache is Cleared", name)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheAdminEndpoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		token        string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "list caches",
+			method: "GET",
+			url:    "/v1/admin/cache",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetCaches").Return([]domain.CacheStats{{Name: "weather", Enabled: true, Backend: "memory", Hits: 3, Misses: 1, Entries: 2, HitRatio: 0.75}, {Name: "airports"}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Caches are Fetched","data":[{"name":"weather","enabled":true,"backend":"memory","hits":3,"misses":1,"entries":2,"hit_ratio":0.75},{"name":"airports","enabled":false,"hits":0,"misses":0,"entries":0,"hit_ratio":0}]}`,
+		},
+		{
+			name:   "clear cache",
+			method: "DELETE",
+			url:    "/v1/admin/cache/weather",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ClearCache", "weather").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Cache is Cleared","data":"weather"}`,
+		},
+		{
+			name:   "clear unknown cache",
+			method: "DELETE",
+			url:    "/v1/admin/cache/tiles",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ClearCache", "tiles").Return(domain.ErrCacheNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Cache Not Found","error_code":"not_found","data":null}`,
+		},
+		{
+			name:         "without admin token",
+			method:       "DELETE",
+			url:          "/v1/admin/cache/weather",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{AdminToken: "admin-token"})
+
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedJSON != "" {
+				assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
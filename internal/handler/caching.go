@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// notModified marks a response that last changed at modified as cacheable for
+// HTTPCacheMaxAge, and answers 304 when the client's copy is as recent. It
+// reports whether the response is complete.
+func (h *Handler) notModified(w http.ResponseWriter, r *http.Request, modified time.Time) bool {
+	cacheControl := "public, no-cache"
+	if maxAge := int(h.cfg.HTTPCacheMaxAge.Seconds()); maxAge > 0 {
+		cacheControl = fmt.Sprintf("public, max-age=%d", maxAge)
+	}
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+
+	// Last-Modified only has whole seconds
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || modified.Truncate(time.Second).After(since) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+)
+
+// sseKeepalive is how often an idle event stream sends a comment, so proxies
+// don't close it.
+const sseKeepalive = 30 * time.Second
+
+// Paging of the change feed.
+const (
+	defaultChangesLimit = 100
+	maxChangesLimit     = 1000
+)
+
+// streamAirportChanges streams airport changes as server-sent events until
+// the client disconnects.
+func (h *Handler) streamAirportChanges(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.EncodeErrorToUser(w, "Streaming Unsupported", codeInternal, nil, http.StatusInternalServerError)
+		return
+	}
+
+	keepWriting(w)
+	changes, unsubscribe := h.svc.SubscribeAirportChanges()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepalive := time.NewTicker(sseKeepalive)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+			flusher.Flush()
+		case change, ok := <-changes:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(change)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: airport.%s\ndata: %s\n\n", change.Op, data)
+			flusher.Flush()
+		}
+	}
+}
+
+// getChangeFeed: Lists the airport inserts, updates and deletes after the
+// ?since= cursor (from the oldest kept when empty), in order and capped at
+// ?limit=, with the cursor to resume from.
+func (h *Handler) getChangeFeed(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	since, err := domain.ParseChangeCursor(query.Get("since"))
+	if err != nil {
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Cursor", nil, http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultChangesLimit
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 || limit > maxChangesLimit {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Limit", nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	feed, err := h.svc.GetChangeFeed(since, limit)
+	if err != nil {
+		log.Printf("getChangeFeed: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Changes are Fetched", len(feed.Changes)), feed)
+}
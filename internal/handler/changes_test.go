@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamAirportChanges(t *testing.T) {
+	changes := make(chan domain.AirportChange, 2)
+	changes <- domain.AirportChange{Op: domain.ChangeUpdate, Faa: "DFW"}
+	changes <- domain.AirportChange{Op: domain.ChangeDelete, Faa: "TST"}
+	close(changes)
+
+	unsubscribed := false
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("SubscribeAirportChanges").Return((<-chan domain.AirportChange)(changes), func() { unsubscribed = true })
+	h := NewHandler(mockSvc, &config.Config{})
+
+	req := httptest.NewRequest("GET", "/v1/airports/changes", nil)
+	rec := httptest.NewRecorder()
+	h.Router().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "event: airport.update\ndata: {\"op\":\"update\",\"faa_ident\":\"DFW\"}\n\n"+
+		"event: airport.delete\ndata: {\"op\":\"delete\",\"faa_ident\":\"TST\"}\n\n", rec.Body.String())
+	assert.True(t, unsubscribed)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetChangeFeed(t *testing.T) {
+	changedAt := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	feed := &domain.ChangeFeed{
+		Changes: []domain.ChangeLogEntry{{Cursor: domain.ChangeCursor{TxID: 7521, ID: 42}, Op: domain.ChangeUpdate, Faa: "DFW", ChangedAt: changedAt}},
+		Cursor:  domain.ChangeCursor{TxID: 7521, ID: 42},
+		HasMore: true,
+	}
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetChangeFeed", domain.ChangeCursor{TxID: 7500, ID: 40}, 1).Return(feed, nil)
+	mockSvc.On("GetChangeFeed", domain.ChangeCursor{}, defaultChangesLimit).Return(&domain.ChangeFeed{Changes: []domain.ChangeLogEntry{}}, errors.New("db down"))
+	router := NewHandler(mockSvc, &config.Config{}).Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/changes?since=7500-40&limit=1", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"OK","message":"1 Changes are Fetched","data":{
+		"changes":[{"cursor":"7521-42","op":"update","faa_ident":"DFW","changed_at":"2024-06-01T18:00:00Z"}],
+		"cursor":"7521-42","has_more":true}}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/changes", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	for _, query := range []string{"since=latest", "limit=0", "limit=1001"} {
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/changes?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+	mockSvc.AssertExpectations(t)
+}
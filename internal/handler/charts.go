@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// getCharts: Lists the airport diagram and approach plate links of an airport.
+func (h *Handler) getCharts(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	charts, err := h.svc.GetCharts(faa)
+	if err != nil {
+		log.Printf("getCharts: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Charts are Fetched", len(charts)), charts)
+}
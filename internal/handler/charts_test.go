@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCharts(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetCharts", "ATL").Return([]domain.Chart{
+		{Code: "APD", Name: "AIRPORT DIAGRAM", PDFURL: "https://aeronav.faa.gov/d-tpp/2510/00026AD.PDF", Cycle: "2510"},
+	}, nil)
+	mockSvc.On("GetCharts", "ZZZ").Return([]domain.Chart(nil), fmt.Errorf("%w: ZZZ", domain.ErrNotFound))
+	h := NewHandler(mockSvc, &config.Config{})
+	r := h.Router()
+
+	tests := []struct {
+		url      string
+		code     int
+		expected string
+	}{
+		{"/v1/airport/ATL/charts", http.StatusOK, `{"status":"OK","message":"1 Charts are Fetched","data":[{"code":"APD","name":"AIRPORT DIAGRAM","pdf_url":"https://aeronav.faa.gov/d-tpp/2510/00026AD.PDF","cycle":"2510"}]}`},
+		{"/v1/airport/ZZZ/charts", http.StatusNotFound, `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.url, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, tt.code, rec.Code, tt.url)
+		assert.JSONEq(t, tt.expected, rec.Body.String(), tt.url)
+	}
+	mockSvc.AssertExpectations(t)
+}
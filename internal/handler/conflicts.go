@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+)
+
+// getConflicts: Lists the conflicts between provider values and fields
+// overridden by hand, the pending ones unless ?status= is accepted, rejected
+// or all.
+func (h *Handler) getConflicts(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = domain.ConflictPending
+	case "all":
+		status = ""
+	case domain.ConflictPending, domain.ConflictAccepted, domain.ConflictRejected:
+	default:
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Status", nil, http.StatusBadRequest)
+		return
+	}
+
+	conflicts, err := h.svc.GetConflicts(status)
+	if err != nil {
+		log.Printf("getConflicts: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Conflicts are Fetched", len(conflicts)), conflicts)
+}
+
+// acceptConflict: Takes the provider's value of a conflicting field, which
+// syncs then keep up to date again.
+func (h *Handler) acceptConflict(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "Invalid Conflict ID")
+	if !ok {
+		return
+	}
+
+	conflict, err := h.svc.AcceptConflict(id)
+	if err != nil {
+		log.Printf("acceptConflict: service error for %d: %v", id, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Conflict is Accepted", conflict)
+}
+
+// rejectConflict: Keeps the local value of a conflicting field.
+func (h *Handler) rejectConflict(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "Invalid Conflict ID")
+	if !ok {
+		return
+	}
+
+	conflict, err := h.svc.RejectConflict(id)
+	if err != nil {
+		log.Printf("rejectConflict: service error for %d: %v", id, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Conflict is Rejected", conflict)
+}
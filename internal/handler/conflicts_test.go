@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflictAdminEndpoints(t *testing.T) {
+	conflict := domain.AirportConflict{ID: 3, Faa: "DEN", Field: "manager", LocalValue: "Local", ProviderValue: "Provider", Status: domain.ConflictPending}
+	accepted := conflict
+	accepted.Status = domain.ConflictAccepted
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		token        string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "list pending conflicts",
+			method: "GET",
+			url:    "/v1/admin/conflicts",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetConflicts", "pending").Return([]domain.AirportConflict{conflict}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Conflicts are Fetched","data":[{"id":3,"faa_ident":"DEN","field":"manager","local_value":"Local","provider_value":"Provider","status":"pending"}]}`,
+		},
+		{
+			name:   "list all conflicts",
+			method: "GET",
+			url:    "/v1/admin/conflicts?status=all",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetConflicts", "").Return([]domain.AirportConflict{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"0 Conflicts are Fetched","data":[]}`,
+		},
+		{
+			name:         "list by unknown status",
+			method:       "GET",
+			url:          "/v1/admin/conflicts?status=open",
+			token:        "admin-token",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid Status","data":null}`,
+		},
+		{
+			name:   "list error",
+			method: "GET",
+			url:    "/v1/admin/conflicts",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetConflicts", "pending").Return([]domain.AirportConflict(nil), errors.New("db down"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "accept conflict",
+			method: "POST",
+			url:    "/v1/admin/conflicts/3/accept",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("AcceptConflict", int64(3)).Return(&accepted, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Conflict is Accepted","data":{"id":3,"faa_ident":"DEN","field":"manager","local_value":"Local","provider_value":"Provider","status":"accepted"}}`,
+		},
+		{
+			name:   "reject resolved conflict",
+			method: "POST",
+			url:    "/v1/admin/conflicts/3/reject",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("RejectConflict", int64(3)).Return((*domain.AirportConflict)(nil), domain.ErrConflictResolved)
+			},
+			expectedCode: http.StatusConflict,
+			expectedJSON: `{"status":"Error","message":"Conflict Already Resolved","error_code":"already_resolved","data":null}`,
+		},
+		{
+			name:   "reject unknown conflict",
+			method: "POST",
+			url:    "/v1/admin/conflicts/9/reject",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("RejectConflict", int64(9)).Return((*domain.AirportConflict)(nil), domain.ErrConflictNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Conflict Not Found","error_code":"not_found","data":null}`,
+		},
+		{
+			name:         "invalid id",
+			method:       "POST",
+			url:          "/v1/admin/conflicts/abc/accept",
+			token:        "admin-token",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid Conflict ID","data":null}`,
+		},
+		{
+			name:         "without admin token",
+			method:       "GET",
+			url:          "/v1/admin/conflicts",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{AdminToken: "admin-token"})
+
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedJSON != "" {
+				assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+)
+
+// parseCoordinates reads ?coordinates=decimal|dms, answering 400 and false
+// for anything else. dms is true when latitude_dms and longitude_dms are
+// wanted alongside the decimal degrees.
+func parseCoordinates(w http.ResponseWriter, r *http.Request) (dms, ok bool) {
+	switch r.URL.Query().Get("coordinates") {
+	case "", "decimal":
+		return false, true
+	case "dms":
+		return true, true
+	}
+	utils.EncodeResponseToUser(w, "Bad Request", "Invalid Coordinates", nil, http.StatusBadRequest)
+	return false, false
+}
+
+// coordinateInput is a coordinate of an AirportRequest, as a JSON number or,
+// as airports were written before they were stored as numbers, a string in
+// decimal degrees or DD-MM-SS.sH. Null or an empty string leave it unknown,
+// and input that doesn't parse is kept for Validate rather than failing the
+// whole body.
+type coordinateInput struct {
+	degrees *float64
+	invalid string
+}
+
+func (c *coordinateInput) UnmarshalJSON(raw []byte) error {
+	*c = coordinateInput{}
+	if bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+
+	var deg float64
+	if raw[0] == '"' {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		if value == "" {
+			return nil
+		}
+		var err error
+		if deg, err = domain.ParseDegrees(value); err != nil {
+			c.invalid = err.Error()
+			return nil
+		}
+	} else if err := json.Unmarshal(raw, &deg); err != nil {
+		c.invalid = fmt.Sprintf("must be decimal degrees or DD-MM-SS.sH, got %s", raw)
+		return nil
+	}
+	c.degrees = &deg
+	return nil
+}
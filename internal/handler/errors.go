@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+)
+
+// Machine-readable codes returned in ApiResponse.ErrorCode.
+const (
+	codeNotFound     = "not_found"
+	codeNoData       = "no_data"
+	codeDuplicate    = "duplicate"
+	codeResolved     = "already_resolved"
+	codeTransition   = "invalid_transition"
+	codeExternalAPI  = "external_api_error"
+	codeInternal     = "internal_error"
+	codeValidation   = "validation_failed"
+	codeDisabled     = "channel_disabled"
+	codeUnauthorized = "unauthorized"
+	codeTooLarge     = "body_too_large"
+	codeTimeout      = "timeout"
+)
+
+// respondServiceError maps service errors onto HTTP statuses and error codes.
+func respondServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		utils.EncodeErrorToUser(w, "Airport Not Found", codeNotFound, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrRuleNotFound):
+		utils.EncodeErrorToUser(w, "Alert Rule Not Found", codeNotFound, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		utils.EncodeErrorToUser(w, "Webhook Not Found", codeNotFound, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrWatchlistNotFound):
+		utils.EncodeErrorToUser(w, "Watchlist Not Found", codeNotFound, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrSubscriptionNotFound):
+		utils.EncodeErrorToUser(w, "Subscription Not Found", codeNotFound, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrJobNotFound):
+		utils.EncodeErrorToUser(w, "Job Not Found", codeNotFound, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrCacheNotFound):
+		utils.EncodeErrorToUser(w, "Cache Not Found", codeNotFound, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrTenantNotFound):
+		utils.EncodeErrorToUser(w, "Tenant Not Found", codeNotFound, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrAPIKeyNotFound):
+		utils.EncodeErrorToUser(w, "API Key Not Found", codeNotFound, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrNoteNotFound):
+		utils.EncodeErrorToUser(w, "Note Not Found", codeNotFound, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrConflictNotFound):
+		utils.EncodeErrorToUser(w, "Conflict Not Found", codeNotFound, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrNoData):
+		utils.EncodeErrorToUser(w, "Data Not Available", codeNoData, nil, http.StatusNotFound)
+	case errors.Is(err, domain.ErrDuplicate):
+		utils.EncodeErrorToUser(w, "Duplicate Airport", codeDuplicate, nil, http.StatusConflict)
+	case errors.Is(err, domain.ErrTenantExists):
+		utils.EncodeErrorToUser(w, "Duplicate Tenant", codeDuplicate, nil, http.StatusConflict)
+	case errors.Is(err, domain.ErrConflictResolved):
+		utils.EncodeErrorToUser(w, "Conflict Already Resolved", codeResolved, nil, http.StatusConflict)
+	case errors.Is(err, domain.ErrInvalidTransition):
+		utils.EncodeErrorToUser(w, "Invalid Status Transition", codeTransition, nil, http.StatusConflict)
+	case errors.Is(err, domain.ErrChannelDisabled):
+		utils.EncodeErrorToUser(w, "Notification Channel Not Configured", codeDisabled, nil, http.StatusServiceUnavailable)
+	case errors.Is(err, context.DeadlineExceeded):
+		utils.EncodeErrorToUser(w, "Request Timeout", codeTimeout, nil, http.StatusRequestTimeout)
+	case errors.Is(err, domain.ErrExternalAPI):
+		utils.EncodeErrorToUser(w, "External API Error", codeExternalAPI, nil, http.StatusBadGateway)
+	default:
+		utils.EncodeErrorToUser(w, "Service Error", codeInternal, nil, http.StatusInternalServerError)
+	}
+}
+
+// respondValidationErrors rejects an invalid payload with its per-field errors.
+func respondValidationErrors(w http.ResponseWriter, errs domain.ValidationErrors) {
+	utils.EncodeErrorToUser(w, "Validation Failed", codeValidation, errs, http.StatusUnprocessableEntity)
+}
+
+// respondInvalidJSON rejects a body that failed to decode, telling a body cut
+// off by maxBodyBytes apart from malformed JSON.
+func respondInvalidJSON(w http.ResponseWriter, err error) {
+	if bodyTooLarge(err) {
+		utils.EncodeErrorToUser(w, "Request Body Too Large", codeTooLarge, nil, http.StatusRequestEntityTooLarge)
+		return
+	}
+	utils.EncodeResponseToUser(w, "Bad Request", "Invalid JSON", nil, http.StatusBadRequest)
+}
+
+// bodyTooLarge reports whether reading a body failed because it was cut off
+// by maxBodyBytes.
+func bodyTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+	"aviation-weather/internal/xlsx"
+)
+
+// exportColumns are the CSV columns of an export, named after the JSON fields
+// like the import expects, so an export can be imported back as is. The
+// formats with typed cells use cell when set, value otherwise.
+var exportColumns = []struct {
+	name  string
+	value func(a *domain.Airport) string
+	cell  func(a *domain.Airport) any
+}{
+	{"site_number", func(a *domain.Airport) string { return a.SiteNumber }, nil},
+	{"facility_name", func(a *domain.Airport) string { return a.FacilityName }, nil},
+	{"faa_ident", func(a *domain.Airport) string { return a.Faa }, nil},
+	{"icao_ident", func(a *domain.Airport) string { return a.Icao }, nil},
+	{"state", func(a *domain.Airport) string { return a.StateCode }, nil},
+	{"state_full", func(a *domain.Airport) string { return a.StateFull }, nil},
+	{"county", func(a *domain.Airport) string { return a.County }, nil},
+	{"city", func(a *domain.Airport) string { return a.City }, nil},
+	{"ownership", func(a *domain.Airport) string { return a.OwnershipType }, nil},
+	{"use", func(a *domain.Airport) string { return a.UseType }, nil},
+	{"manager", func(a *domain.Airport) string { return a.Manager }, nil},
+	{"manager_phone", func(a *domain.Airport) string { return a.ManagerPhone }, nil},
+	{"latitude", func(a *domain.Airport) string { return exportFloat(a.Latitude) }, func(a *domain.Airport) any { return a.Latitude }},
+	{"longitude", func(a *domain.Airport) string { return exportFloat(a.Longitude) }, func(a *domain.Airport) any { return a.Longitude }},
+	{"status", func(a *domain.Airport) string { return a.AirportStatus }, nil},
+	{"weather", func(a *domain.Airport) string { return a.Weather }, nil},
+}
+
+// exportFloat writes an optional number, empty when unset.
+func exportFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+// airportWriter writes an export, one airport at a time.
+type airportWriter interface {
+	// begin writes what comes before the first airport
+	begin() error
+	write(a *domain.Airport) error
+	// end writes what follows the last airport, then flushes
+	end() error
+	// flush writes out the airports buffered so far, when an export is cut
+	// short
+	flush() error
+}
+
+// exportFormat is a file format of the export, by its ?format= name.
+type exportFormat struct {
+	contentType string
+	extension   string
+	newWriter   func(w io.Writer) airportWriter
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv":  {"text/csv; charset=utf-8", "csv", newCSVWriter},
+	"kml":  {"application/vnd.google-earth.kml+xml", "kml", newKMLWriter},
+	"xlsx": {xlsx.ContentType, "xlsx", newXLSXWriter},
+}
+
+// ndjsonFormat is newline-delimited JSON, an airport object per line as the
+// API returns it elsewhere.
+var ndjsonFormat = exportFormat{"application/x-ndjson", "ndjson", newNDJSONWriter}
+
+// csvWriter writes the import's CSV layout, a header row then one row per
+// airport.
+type csvWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func newCSVWriter(w io.Writer) airportWriter {
+	return &csvWriter{writer: csv.NewWriter(w), record: make([]string, len(exportColumns))}
+}
+
+func (c *csvWriter) begin() error {
+	for i, col := range exportColumns {
+		c.record[i] = col.name
+	}
+	return c.writer.Write(c.record)
+}
+
+func (c *csvWriter) write(a *domain.Airport) error {
+	for i, col := range exportColumns {
+		c.record[i] = col.value(a)
+	}
+	return c.writer.Write(c.record)
+}
+
+func (c *csvWriter) end() error { return c.flush() }
+
+func (c *csvWriter) flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// ndjsonWriter writes an airport per line, with nothing around them.
+type ndjsonWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) airportWriter {
+	buf := bufio.NewWriter(w)
+	return &ndjsonWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+func (n *ndjsonWriter) begin() error { return nil }
+
+func (n *ndjsonWriter) write(a *domain.Airport) error { return n.enc.Encode(a) }
+
+func (n *ndjsonWriter) end() error { return n.flush() }
+
+func (n *ndjsonWriter) flush() error { return n.buf.Flush() }
+
+// exportAirports: Streams every airport row by row from the database, as CSV
+// or, with ?format=kml, as KML placemarks, or with ?format=xlsx, as an Excel
+// workbook.
+func (h *Handler) exportAirports(w http.ResponseWriter, r *http.Request) {
+	name := cmp.Or(r.URL.Query().Get("format"), "csv")
+	format, ok := exportFormats[name]
+	if !ok {
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Format", nil, http.StatusBadRequest)
+		return
+	}
+	h.writeAirports(w, r, "exportAirports", format, true)
+}
+
+// streamAirports: Streams every airport as newline-delimited JSON, one object
+// per line as read from the database, for consumers that process the whole
+// table without holding it.
+func (h *Handler) streamAirports(w http.ResponseWriter, r *http.Request) {
+	h.writeAirports(w, r, "streamAirports", ndjsonFormat, false)
+}
+
+// writeAirports writes every airport in format as it is read, as a file to
+// save when download is set.
+func (h *Handler) writeAirports(w http.ResponseWriter, r *http.Request, op string, format exportFormat, download bool) {
+	keepWriting(w)
+	writer := format.newWriter(w)
+
+	// The header goes out with the first row, so a failing query can still
+	// get a JSON error response
+	started := false
+	start := func() error {
+		started = true
+		w.Header().Set("Content-Type", format.contentType)
+		if download {
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="airports.%s"`, format.extension))
+		}
+		return writer.begin()
+	}
+
+	err := h.svc.ForEachAirport(r.Context(), func(a domain.Airport) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return writer.write(&a)
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err != nil && !started {
+		log.Printf("%s: service error: %v", op, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	if err == nil {
+		err = writer.end()
+	} else {
+		writer.flush()
+	}
+	if err != nil {
+		log.Printf("%s: export cut short: %v", op, err)
+	}
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+const (
+	kmlHeader = xml.Header + `<kml xmlns="http://www.opengis.net/kml/2.2">` + "\n<Document>\n<name>Airports</name>\n"
+	kmlFooter = "</Document>\n</kml>\n"
+)
+
+// kmlPlacemark is an airport on the map, labeled with its name. Its snippet,
+// shown under the name in lists, carries the codes and the weather.
+type kmlPlacemark struct {
+	XMLName      xml.Name  `xml:"Placemark"`
+	Name         string    `xml:"name"`
+	Snippet      string    `xml:"Snippet"`
+	Description  string    `xml:"description"`
+	ExtendedData []kmlData `xml:"ExtendedData>Data"`
+	Point        struct {
+		Coordinates string `xml:"coordinates"`
+	} `xml:"Point"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// kmlWriter writes a KML document of one placemark per airport, for Google
+// Earth and the map tools that read it. Airports without a position have no
+// place on a map, so they are left out.
+type kmlWriter struct {
+	buf *bufio.Writer
+	enc *xml.Encoder
+}
+
+func newKMLWriter(w io.Writer) airportWriter {
+	buf := bufio.NewWriter(w)
+	return &kmlWriter{buf: buf, enc: xml.NewEncoder(buf)}
+}
+
+func (k *kmlWriter) begin() error {
+	_, err := k.buf.WriteString(kmlHeader)
+	return err
+}
+
+func (k *kmlWriter) write(a *domain.Airport) error {
+	if a.Latitude == nil || a.Longitude == nil {
+		return nil
+	}
+	weather := cmp.Or(a.Weather, "No weather")
+	codes := a.Faa
+	if a.Icao != "" {
+		codes += "/" + a.Icao
+	}
+
+	p := kmlPlacemark{
+		Name:    cmp.Or(a.FacilityName, a.Faa),
+		Snippet: codes + " - " + weather,
+		ExtendedData: []kmlData{
+			{"faa_ident", a.Faa},
+			{"icao_ident", a.Icao},
+			{"weather", a.Weather},
+		},
+	}
+	// Description is HTML; the encoder escapes it once more, as KML expects
+	lines := []string{"FAA: " + a.Faa}
+	if a.Icao != "" {
+		lines = append(lines, "ICAO: "+a.Icao)
+	}
+	if place := strings.Trim(a.City+", "+a.StateCode, ", "); place != "" {
+		lines = append(lines, place)
+	}
+	lines = append(lines, "Weather: "+weather)
+	if a.LastSyncedAt != nil {
+		lines = append(lines, "Synced: "+a.LastSyncedAt.UTC().Format(time.RFC3339))
+		p.ExtendedData = append(p.ExtendedData, kmlData{"last_synced_at", a.LastSyncedAt.UTC().Format(time.RFC3339)})
+	}
+	for i, line := range lines {
+		lines[i] = html.EscapeString(line)
+	}
+	p.Description = strings.Join(lines, "<br>")
+	p.Point.Coordinates = strconv.FormatFloat(*a.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(*a.Latitude, 'f', -1, 64)
+
+	if err := k.enc.Encode(p); err != nil {
+		return fmt.Errorf("failed to encode placemark: %w", err)
+	}
+	_, err := k.buf.WriteString("\n")
+	return err
+}
+
+func (k *kmlWriter) end() error {
+	if _, err := k.buf.WriteString(kmlFooter); err != nil {
+		return err
+	}
+	return k.flush()
+}
+
+func (k *kmlWriter) flush() error {
+	if err := k.enc.Flush(); err != nil {
+		return err
+	}
+	return k.buf.Flush()
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportAirports(t *testing.T) {
+	other := sampleAirport
+	other.Faa, other.FacilityName = "OTH", `Other "Field", Inc`
+
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ForEachAirport", mock.Anything).Return([]domain.Airport{sampleAirport, other}, nil)
+	h := NewHandler(mockSvc, &config.Config{})
+
+	req := httptest.NewRequest("GET", "/v1/airports/export", nil)
+	rec := httptest.NewRecorder()
+	h.Router().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "site_number,facility_name,faa_ident,icao_ident,state,state_full,county,city,ownership,use,manager,manager_phone,latitude,longitude,status,weather", lines[0])
+
+	// The export reads back through the import parser unchanged
+	rows, err := parseCSV(strings.NewReader(rec.Body.String()))
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 2) {
+		assert.Equal(t, sampleAirport, rows[0].airport.toAirport())
+		assert.Equal(t, other, rows[1].airport.toAirport())
+	}
+	mockSvc.AssertExpectations(t)
+}
+
+func TestExportAirportsErrors(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ForEachAirport", mock.Anything).Return([]domain.Airport{}, assert.AnError)
+	h := NewHandler(mockSvc, &config.Config{})
+
+	req := httptest.NewRequest("GET", "/v1/airports/export", nil)
+	rec := httptest.NewRecorder()
+	h.Router().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`, rec.Body.String())
+
+	// Once rows are out, a failure can only cut the file short
+	mockSvc = &mocks.ServiceMock{}
+	mockSvc.On("ForEachAirport", mock.Anything).Return([]domain.Airport{sampleAirport}, assert.AnError)
+	h = NewHandler(mockSvc, &config.Config{})
+	rec = httptest.NewRecorder()
+	h.Router().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, 2, strings.Count(rec.Body.String(), "\n"), "Header and the rows read so far")
+}
+
+func TestExportAirportsKML(t *testing.T) {
+	other := sampleAirport
+	other.Faa, other.FacilityName, other.Weather = "OTH", "Smith & Sons <Field>", ""
+	unplaced := sampleAirport
+	unplaced.Faa, unplaced.Latitude = "NOP", nil
+
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ForEachAirport", mock.Anything).Return([]domain.Airport{sampleAirport, other, unplaced}, nil)
+	h := NewHandler(mockSvc, &config.Config{})
+
+	req := httptest.NewRequest("GET", "/v1/airports/export?format=kml", nil)
+	rec := httptest.NewRecorder()
+	h.Router().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/vnd.google-earth.kml+xml", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="airports.kml"`, rec.Header().Get("Content-Disposition"))
+
+	var doc struct {
+		Placemarks []kmlPlacemark `xml:"Document>Placemark"`
+	}
+	assert.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &doc))
+	if assert.Len(t, doc.Placemarks, 2, "Airports without a position are left out") {
+		p := doc.Placemarks[0]
+		assert.Equal(t, "Test Airport", p.Name)
+		assert.Equal(t, "TST/KTST - Clear", p.Snippet)
+		assert.Equal(t, "FAA: TST<br>ICAO: KTST<br>Test City, CA<br>Weather: Clear", p.Description)
+		assert.Equal(t, "-118.2437,34.0522", p.Point.Coordinates)
+		assert.Contains(t, p.ExtendedData, kmlData{"icao_ident", "KTST"})
+
+		assert.Equal(t, "Smith & Sons <Field>", doc.Placemarks[1].Name)
+		assert.Equal(t, "OTH/KTST - No weather", doc.Placemarks[1].Snippet)
+	}
+
+	req = httptest.NewRequest("GET", "/v1/airports/export?format=pdf", nil)
+	rec = httptest.NewRecorder()
+	h.Router().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Invalid Format")
+}
+
+func TestExportAirportsXLSX(t *testing.T) {
+	synced := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	airport := sampleAirport
+	airport.LastSyncedAt = &synced
+
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ForEachAirport", mock.Anything).Return([]domain.Airport{airport}, nil)
+	h := NewHandler(mockSvc, &config.Config{})
+
+	req := httptest.NewRequest("GET", "/v1/airports/export?format=xlsx", nil)
+	rec := httptest.NewRecorder()
+	h.Router().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="airports.xlsx"`, rec.Header().Get("Content-Disposition"))
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(body)
+	}
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Airports" sheetId="1" r:id="rId1"/><sheet name="Weather" sheetId="2" r:id="rId2"/>`)
+	// Positions are numbers, in the columns of the CSV export
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="M2"><v>34.0522</v></c>`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="C2" t="inlineStr"><is><t xml:space="preserve">TST</t></is></c>`)
+	assert.Contains(t, files["xl/worksheets/sheet2.xml"], `<c r="D2" t="inlineStr"><is><t xml:space="preserve">Clear</t></is></c>`)
+	assert.Contains(t, files["xl/worksheets/sheet2.xml"], `<c r="H2" s="2"><v>45444.75</v></c>`)
+}
+
+func TestStreamAirports(t *testing.T) {
+	other := sampleAirport
+	other.Faa = "OTH"
+
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ForEachAirport", mock.Anything).Return([]domain.Airport{sampleAirport, other}, nil)
+	h := NewHandler(mockSvc, &config.Config{})
+
+	req := httptest.NewRequest("GET", "/v1/airports/stream", nil)
+	rec := httptest.NewRecorder()
+	h.Router().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Header().Get("Content-Disposition"))
+	lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.JSONEq(t, sampleAirportJSON, lines[0])
+		assert.Contains(t, lines[1], `"faa_ident":"OTH"`)
+	}
+
+	// An empty table is an empty body, not an error
+	mockSvc = &mocks.ServiceMock{}
+	mockSvc.On("ForEachAirport", mock.Anything).Return([]domain.Airport{}, nil)
+	rec = httptest.NewRecorder()
+	NewHandler(mockSvc, &config.Config{}).Router().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"io"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/xlsx"
+)
+
+// weatherColumns are the columns of the Weather sheet of an Excel export, the
+// weather of each airport as of its last sync.
+var weatherColumns = []struct {
+	column xlsx.Column
+	cell   func(a *domain.Airport) any
+}{
+	{xlsx.Column{Name: "faa_ident"}, func(a *domain.Airport) any { return a.Faa }},
+	{xlsx.Column{Name: "icao_ident"}, func(a *domain.Airport) any { return a.Icao }},
+	{xlsx.Column{Name: "facility_name", Width: 36}, func(a *domain.Airport) any { return a.FacilityName }},
+	{xlsx.Column{Name: "weather", Width: 24}, func(a *domain.Airport) any { return a.Weather }},
+	{xlsx.Column{Name: "elevation_ft"}, func(a *domain.Airport) any { return a.ElevationFt }},
+	{xlsx.Column{Name: "pressure_altitude_ft"}, func(a *domain.Airport) any { return a.PressureAltitudeFt }},
+	{xlsx.Column{Name: "density_altitude_ft"}, func(a *domain.Airport) any { return a.DensityAltitudeFt }},
+	{xlsx.Column{Name: "last_synced_at", Width: 18}, func(a *domain.Airport) any { return a.LastSyncedAt }},
+}
+
+// xlsxWriter writes an Excel workbook of two sheets: Airports, in the columns
+// of the CSV export with numbers as numbers, and Weather. The Airports sheet
+// is streamed; the smaller Weather sheet is held until the end.
+type xlsxWriter struct {
+	workbook *xlsx.Writer
+	airports *xlsx.Sheet
+	weather  *xlsx.Sheet
+	row      []any
+}
+
+func newXLSXWriter(w io.Writer) airportWriter {
+	return &xlsxWriter{workbook: xlsx.NewWriter(w)}
+}
+
+func (x *xlsxWriter) begin() error {
+	columns := make([]xlsx.Column, len(exportColumns))
+	for i, col := range exportColumns {
+		columns[i] = xlsx.Column{Name: col.name}
+	}
+	var err error
+	if x.airports, err = x.workbook.NewSheet("Airports", columns); err != nil {
+		return err
+	}
+
+	columns = make([]xlsx.Column, len(weatherColumns))
+	for i, col := range weatherColumns {
+		columns[i] = col.column
+	}
+	x.weather, err = x.workbook.NewSheet("Weather", columns)
+	return err
+}
+
+func (x *xlsxWriter) write(a *domain.Airport) error {
+	x.row = x.row[:0]
+	for _, col := range exportColumns {
+		if col.cell != nil {
+			x.row = append(x.row, col.cell(a))
+		} else {
+			x.row = append(x.row, col.value(a))
+		}
+	}
+	if err := x.airports.WriteRow(x.row...); err != nil {
+		return err
+	}
+
+	x.row = x.row[:0]
+	for _, col := range weatherColumns {
+		x.row = append(x.row, col.cell(a))
+	}
+	return x.weather.WriteRow(x.row...)
+}
+
+func (x *xlsxWriter) end() error { return x.workbook.Close() }
+
+func (x *xlsxWriter) flush() error { return x.workbook.Flush() }
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// airportField is a field of AirportResponse by index, and whether it is
+// left out when empty.
+type airportField struct {
+	index     int
+	omitEmpty bool
+}
+
+// airportFields maps the JSON name of each airport field to its index.
+var airportFields = func() map[string]airportField {
+	fields := map[string]airportField{}
+	t := reflect.TypeOf(AirportResponse{})
+	for i := 0; i < t.NumField(); i++ {
+		name, options, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = airportField{index: i, omitEmpty: options == "omitempty"}
+		}
+	}
+	return fields
+}()
+
+// parseFields reads the comma separated ?fields= of r, returning nil when it
+// is absent and the names it does not know as unknown.
+func parseFields(r *http.Request) (fields, unknown []string) {
+	query := r.URL.Query().Get("fields")
+	if query == "" {
+		return nil, nil
+	}
+	for _, name := range strings.Split(query, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := airportFields[name]; !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		fields = append(fields, name)
+	}
+	return fields, unknown
+}
+
+// projectedAirport encodes only the listed fields of an airport, in their
+// order, as JSON or XML. Unset optional fields are left out as usual.
+type projectedAirport struct {
+	airport *AirportResponse
+	fields  []string
+}
+
+// projectAirports narrows airports down to fields.
+func projectAirports(airports []AirportResponse, fields []string) []projectedAirport {
+	projected := make([]projectedAirport, len(airports))
+	for i := range airports {
+		projected[i] = projectedAirport{airport: &airports[i], fields: fields}
+	}
+	return projected
+}
+
+// values calls fn with the name and value of each listed field that is set.
+func (p projectedAirport) values(fn func(name string, value any) error) error {
+	v := reflect.ValueOf(p.airport).Elem()
+	for _, name := range p.fields {
+		f := airportFields[name]
+		field := v.Field(f.index)
+		if f.omitEmpty && field.IsZero() {
+			continue
+		}
+		if err := fn(name, field.Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p projectedAirport) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	err := p.values(func(name string, value any) error {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`"` + name + `":`)
+		buf.Write(encoded)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (p projectedAirport) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	start := xml.StartElement{Name: xml.Name{Local: "airport"}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	err := p.values(func(name string, value any) error {
+		return e.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: name}})
+	})
+	if err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectedAirport(t *testing.T) {
+	elevation := 607.0
+	airport := AirportResponse{Faa: "DFW", City: "Dallas", ElevationFt: &elevation}
+	projected := projectAirports([]AirportResponse{airport}, []string{"city", "faa_ident", "elevation_ft", "pressure_altitude_ft", "timezone"})
+
+	encoded, err := json.Marshal(projected)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"city":"Dallas","faa_ident":"DFW","elevation_ft":607}]`, string(encoded), "Fields keep the requested order")
+
+	encoded, err = xml.Marshal(projected[0])
+	assert.NoError(t, err)
+	assert.Equal(t, `<airport><city>Dallas</city><faa_ident>DFW</faa_ident><elevation_ft>607</elevation_ft></airport>`, string(encoded))
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// forecastTimeLayouts are accepted for ?at=, RFC 3339 with or without seconds.
+var forecastTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04Z07:00"}
+
+// getAirportForecast: Reports what the airport's latest TAF forecasts at ?at= (default now).
+func (h *Handler) getAirportForecast(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	var at time.Time
+	if value := r.URL.Query().Get("at"); value != "" {
+		var err error
+		for _, layout := range forecastTimeLayouts {
+			if at, err = time.Parse(layout, value); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid At", nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	forecast, err := h.svc.GetAirportForecast(r.Context(), faa, at)
+	if err != nil {
+		log.Printf("getAirportForecast: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Forecast is Fetched", inUnits(r, forecast))
+}
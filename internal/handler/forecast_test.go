@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAirportForecast(t *testing.T) {
+	at := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	from := time.Date(2024, 6, 1, 17, 0, 0, 0, time.UTC)
+	forecast := &domain.TAFForecast{
+		Station:    "KDEN",
+		At:         at,
+		Prevailing: domain.TAFPeriod{Change: domain.TAFFrom, From: &from},
+		Temporary:  []domain.TAFPeriod{},
+		Raw:        "TAF KDEN 011520Z 0116/0218 FM011700 27010KT P6SM SKC",
+	}
+
+	tests := []struct {
+		name         string
+		url          string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name: "forecast at a time without seconds",
+			url:  "/v1/airport/DEN/forecast?at=2024-06-01T18:00Z",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportForecast", mock.Anything, "DEN", at).Return(forecast, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Forecast is Fetched","data":{"station":"KDEN","at":"2024-06-01T18:00:00Z","prevailing":{"change":"FM","from":"2024-06-01T17:00:00Z"},"temporary":[],"raw":"TAF KDEN 011520Z 0116/0218 FM011700 27010KT P6SM SKC"}}`,
+		},
+		{
+			name: "forecast now",
+			url:  "/v1/airport/DEN/forecast",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportForecast", mock.Anything, "DEN", time.Time{}).Return(forecast, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Forecast is Fetched","data":{"station":"KDEN","at":"2024-06-01T18:00:00Z","prevailing":{"change":"FM","from":"2024-06-01T17:00:00Z"},"temporary":[],"raw":"TAF KDEN 011520Z 0116/0218 FM011700 27010KT P6SM SKC"}}`,
+		},
+		{
+			name:         "invalid time",
+			url:          "/v1/airport/DEN/forecast?at=tomorrow",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid At","data":null}`,
+		},
+		{
+			name: "outside the TAF",
+			url:  "/v1/airport/DEN/forecast?at=2024-06-05T18:00:00Z",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportForecast", mock.Anything, "DEN", mock.Anything).Return((*domain.TAFForecast)(nil), fmt.Errorf("%w: TAF for DEN does not cover it", domain.ErrNoData))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Data Not Available","error_code":"no_data","data":null}`,
+		},
+		{
+			name: "airport not found",
+			url:  "/v1/airport/ZZZ/forecast",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportForecast", mock.Anything, "ZZZ", time.Time{}).Return((*domain.TAFForecast)(nil), domain.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			r := NewHandler(mockSvc, &config.Config{}).Router()
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// FuelRequest is the body of PUT /airport/{faa}/fuel. Fuel types may be
+// spelled as providers do, such as "Jet A" or NASR's "A".
+type FuelRequest struct {
+	FuelTypes []string `json:"fuel_types"`
+	FboName   string   `json:"fbo_name"`
+	FboPhone  string   `json:"fbo_phone"`
+}
+
+// toFuel maps the request onto the stored fuel types, leaving out unknown
+// and repeated ones.
+func (req *FuelRequest) toFuel() domain.AirportFuel {
+	return domain.AirportFuel{
+		FuelTypes: domain.ParseFuelTypes(strings.Join(req.FuelTypes, ",")),
+		FboName:   req.FboName,
+		FboPhone:  req.FboPhone,
+	}
+}
+
+// Validate checks every fuel type is known and the FBO reads as a name and
+// phone number.
+func (req *FuelRequest) Validate() domain.ValidationErrors {
+	var errs domain.ValidationErrors
+	for _, code := range req.FuelTypes {
+		if _, ok := domain.ParseFuelType(code); !ok {
+			errs = append(errs, domain.FieldError{Field: "fuel_types", Error: fmt.Sprintf("unknown fuel type %q", code)})
+		}
+	}
+	fuel := req.toFuel()
+	return append(errs, fuel.Validate()...)
+}
+
+// updateAirportFuel: Sets the fuel types and FBO of an airport by hand; syncs keep the
+// fields it changes.
+func (h *Handler) updateAirportFuel(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	var req FuelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("updateAirportFuel: invalid JSON: %v", err)
+		respondInvalidJSON(w, err)
+		return
+	}
+	if errs := req.Validate(); len(errs) > 0 {
+		log.Printf("updateAirportFuel: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	airport, err := h.svc.UpdateAirportFuel(faa, req.toFuel())
+	if err != nil {
+		log.Printf("updateAirportFuel: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Airport Fuel is Updated", newAirportResponse(airport, false))
+}
+
+// parseFuel reads ?fuel= as one of the domain Fuel types, answering 400 and
+// returning ok false when unknown. It returns "" when absent.
+func parseFuel(w http.ResponseWriter, r *http.Request) (fuel string, ok bool) {
+	code := r.URL.Query().Get("fuel")
+	if code == "" {
+		return "", true
+	}
+	if fuel, ok = domain.ParseFuelType(code); !ok {
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Fuel Type", nil, http.StatusBadRequest)
+	}
+	return fuel, ok
+}
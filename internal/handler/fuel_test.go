@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateAirportFuel(t *testing.T) {
+	updated := domain.Airport{
+		Faa: "TST", FuelTypes: []string{domain.Fuel100LL, domain.FuelJetA}, FboName: "Signature", FboPhone: "303-342-0100",
+		ManualOverrides: []string{"fuel_types", "fbo_name", "fbo_phone"},
+	}
+
+	tests := []struct {
+		name         string
+		url          string
+		body         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name: "success",
+			url:  "/v1/airport/TST/fuel",
+			body: `{"fuel_types":["100LL","Jet A","A"],"fbo_name":"Signature","fbo_phone":"303-342-0100"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("UpdateAirportFuel", "TST", domain.AirportFuel{
+					FuelTypes: []string{domain.Fuel100LL, domain.FuelJetA}, FboName: "Signature", FboPhone: "303-342-0100",
+				}).Return(&updated, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Airport Fuel is Updated","data":{"site_number":"","facility_name":"","faa_ident":"TST","icao_ident":"","state":"","state_full":"","county":"","city":"","ownership":"","use":"","manager":"","manager_phone":"","status":"","weather":"","fuel_types":["100LL","JetA"],"fbo_name":"Signature","fbo_phone":"303-342-0100","manual_overrides":["fuel_types","fbo_name","fbo_phone"]}}`,
+		},
+		{
+			name: "no fuel",
+			url:  "/v1/airport/TST/fuel",
+			body: `{"fuel_types":[]}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("UpdateAirportFuel", "TST", domain.AirportFuel{}).Return(&domain.Airport{Faa: "TST"}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid fuel and phone",
+			url:          "/v1/airport/TST/fuel",
+			body:         `{"fuel_types":["diesel"],"fbo_phone":"call us"}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"fuel_types","error":"unknown fuel type \"diesel\""},{"field":"fbo_phone","error":"must be a phone number with 7-15 digits"}]}`,
+		},
+		{
+			name:         "invalid JSON",
+			url:          "/v1/airport/TST/fuel",
+			body:         `{`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "unknown airport",
+			url:  "/v1/airport/NOPE/fuel",
+			body: `{"fuel_types":["100LL"]}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("UpdateAirportFuel", "NOPE", domain.AirportFuel{FuelTypes: []string{domain.Fuel100LL}}).Return((*domain.Airport)(nil), domain.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+
+			req := httptest.NewRequest("PUT", tt.url, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedJSON != "" {
+				assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
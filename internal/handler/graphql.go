@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	gqlschema "aviation-weather/api/graphql"
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/service"
+	"aviation-weather/internal/utils"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// graphqlRequest is the body of a POST to the GraphQL endpoint.
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Limits of a GraphQL query. The schema nests only airports and their weather
+// history, so nothing deeper is needed; clients read the schema from
+// api/graphql rather than by a full introspection query.
+const (
+	graphqlMaxDepth       = 4
+	graphqlMaxParallelism = 10
+	graphqlMaxQueryLength = 4096
+)
+
+// newGraphQLSchema parses the schema of api/graphql against the resolvers of
+// this file. It panics when they disagree, so a mismatch fails on startup.
+//
+// The resolvers use graph-gophers/graphql-go rather than gqlgen: it checks
+// them against the schema file at startup, with no generated code to keep in
+// sync with it.
+func newGraphQLSchema(svc service.ServiceInterface) *graphql.Schema {
+	return graphql.MustParseSchema(gqlschema.Schema, &queryResolver{svc: svc},
+		graphql.MaxDepth(graphqlMaxDepth),
+		graphql.MaxParallelism(graphqlMaxParallelism),
+		graphql.MaxQueryLength(graphqlMaxQueryLength))
+}
+
+// graphQL: Answers a GraphQL query over the airports. The response is the
+// GraphQL {"data","errors"} document rather than the JSON envelope.
+func (h *Handler) graphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("graphQL: invalid JSON: %v", err)
+		respondInvalidJSON(w, err)
+		return
+	}
+	if req.Query == "" {
+		utils.EncodeResponseToUser(w, "Bad Request", "Missing Query", nil, http.StatusBadRequest)
+		return
+	}
+
+	resp := h.graphql.Exec(r.Context(), req.Query, req.OperationName, req.Variables)
+	for _, err := range resp.Errors {
+		log.Printf("graphQL: %v", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("graphQL: failed to encode response: %v", err)
+	}
+}
+
+// queryResolver resolves the Query type.
+type queryResolver struct {
+	svc service.ServiceInterface
+}
+
+func (q *queryResolver) Airport(args struct{ Faa graphql.ID }) (*airportResolver, error) {
+	airport, err := q.svc.GetAirportByFAA(strings.ToUpper(string(args.Faa)))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+	return newAirportResolvers(q.svc, []domain.Airport{*airport})[0], nil
+}
+
+func (q *queryResolver) Airports(args struct {
+	State    *string
+	City     *string
+	Category *string
+}) ([]*airportResolver, error) {
+	if args.Category != nil && !aviation.IsCategory(*args.Category) {
+		return nil, fmt.Errorf("category must be VFR, MVFR, IFR or LIFR, got %q", *args.Category)
+	}
+
+	var state, city string
+	if args.State != nil {
+		state = *args.State
+	}
+	if args.City != nil {
+		city = *args.City
+	}
+	airports, err := q.svc.GetAirportsByPlace(state, city)
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+
+	resolvers := newAirportResolvers(q.svc, airports)
+	if args.Category == nil {
+		return resolvers, nil
+	}
+	filtered := []*airportResolver{}
+	for _, a := range resolvers {
+		category, err := a.FlightCategory()
+		if err != nil {
+			return nil, err
+		}
+		if category != nil && *category == *args.Category {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered, nil
+}
+
+// flightCategories loads the flight categories of a set of airports with one
+// query, the first time one of them is asked for.
+type flightCategories struct {
+	svc  service.ServiceInterface
+	faas []string
+
+	once       sync.Once
+	categories map[string]string
+	err        error
+}
+
+func (c *flightCategories) get(faa string) (string, error) {
+	c.once.Do(func() { c.categories, c.err = c.svc.GetFlightCategories(c.faas) })
+	return c.categories[faa], c.err
+}
+
+// weatherHistories loads the weather history of a set of airports with one
+// query per window, the first time one of them asks for that window.
+type weatherHistories struct {
+	svc  service.ServiceInterface
+	faas []string
+
+	mu      sync.Mutex
+	windows map[int]*historyWindow
+}
+
+type historyWindow struct {
+	once      sync.Once
+	histories map[string][]domain.Observation
+	err       error
+}
+
+func (h *weatherHistories) get(faa string, hours int) ([]domain.Observation, error) {
+	h.mu.Lock()
+	w, ok := h.windows[hours]
+	if !ok {
+		w = &historyWindow{}
+		h.windows[hours] = w
+	}
+	h.mu.Unlock()
+
+	w.once.Do(func() { w.histories, w.err = h.svc.GetWeatherHistories(h.faas, hours) })
+	return w.histories[faa], w.err
+}
+
+// airportResolver resolves the Airport type.
+type airportResolver struct {
+	a          domain.Airport
+	categories *flightCategories
+	histories  *weatherHistories
+}
+
+// newAirportResolvers resolves airports sharing one flightCategories and
+// weatherHistories, so listing the category or history of each costs a
+// single query.
+func newAirportResolvers(svc service.ServiceInterface, airports []domain.Airport) []*airportResolver {
+	faas := make([]string, 0, len(airports))
+	for _, a := range airports {
+		faas = append(faas, a.Faa)
+	}
+	categories := &flightCategories{svc: svc, faas: faas}
+	histories := &weatherHistories{svc: svc, faas: faas, windows: map[int]*historyWindow{}}
+
+	resolvers := make([]*airportResolver, 0, len(airports))
+	for _, a := range airports {
+		resolvers = append(resolvers, &airportResolver{a: a, categories: categories, histories: histories})
+	}
+	return resolvers
+}
+
+func (r *airportResolver) SiteNumber() string    { return r.a.SiteNumber }
+func (r *airportResolver) FacilityName() string  { return r.a.FacilityName }
+func (r *airportResolver) FaaIdent() graphql.ID  { return graphql.ID(r.a.Faa) }
+func (r *airportResolver) IcaoIdent() string     { return r.a.Icao }
+func (r *airportResolver) State() string         { return r.a.StateCode }
+func (r *airportResolver) StateFull() string     { return r.a.StateFull }
+func (r *airportResolver) County() string        { return r.a.County }
+func (r *airportResolver) City() string          { return r.a.City }
+func (r *airportResolver) Ownership() string     { return r.a.OwnershipType }
+func (r *airportResolver) Use() string           { return r.a.UseType }
+func (r *airportResolver) Manager() string       { return r.a.Manager }
+func (r *airportResolver) ManagerPhone() string  { return r.a.ManagerPhone }
+func (r *airportResolver) Latitude() *float64    { return r.a.Latitude }
+func (r *airportResolver) Longitude() *float64   { return r.a.Longitude }
+func (r *airportResolver) ElevationFt() *float64 { return r.a.ElevationFt }
+func (r *airportResolver) Status() string        { return r.a.AirportStatus }
+func (r *airportResolver) Weather() string       { return r.a.Weather }
+func (r *airportResolver) Timezone() *string     { return optionalString(r.a.Timezone) }
+func (r *airportResolver) LastSyncedAt() *graphql.Time {
+	if r.a.LastSyncedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.a.LastSyncedAt}
+}
+
+func (r *airportResolver) FlightCategory() (*string, error) {
+	category, err := r.categories.get(r.a.Faa)
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+	return optionalString(category), nil
+}
+
+func (r *airportResolver) WeatherHistory(args struct{ Hours *int32 }) ([]*observationResolver, error) {
+	hours := service.DefaultHistoryHours
+	if args.Hours != nil {
+		hours = int(*args.Hours)
+	}
+	if hours <= 0 || hours > service.MaxHistoryHours {
+		return nil, fmt.Errorf("hours must be between 1 and %d, got %d", service.MaxHistoryHours, hours)
+	}
+	history, err := r.histories.get(r.a.Faa, hours)
+	if err != nil {
+		return nil, graphqlError(err)
+	}
+	resolvers := make([]*observationResolver, 0, len(history))
+	for _, obs := range history {
+		resolvers = append(resolvers, &observationResolver{obs})
+	}
+	return resolvers, nil
+}
+
+// observationResolver resolves the Observation type.
+type observationResolver struct {
+	obs domain.Observation
+}
+
+func (r *observationResolver) Provider() string         { return r.obs.Provider }
+func (r *observationResolver) Condition() string        { return r.obs.Condition }
+func (r *observationResolver) TemperatureC() float64    { return r.obs.TemperatureC }
+func (r *observationResolver) DewpointC() float64       { return r.obs.DewpointC }
+func (r *observationResolver) HumidityPct() float64     { return r.obs.HumidityPct }
+func (r *observationResolver) WindDirDeg() int32        { return int32(r.obs.WindDirDeg) }
+func (r *observationResolver) WindSpeedKt() float64     { return r.obs.WindSpeedKt }
+func (r *observationResolver) WindGustKt() float64      { return r.obs.WindGustKt }
+func (r *observationResolver) VisibilitySm() float64    { return r.obs.VisibilitySM }
+func (r *observationResolver) PressureHpa() float64     { return r.obs.PressureHpa }
+func (r *observationResolver) RawMetar() *string        { return optionalString(r.obs.RawMETAR) }
+func (r *observationResolver) ObservedAt() graphql.Time { return graphql.Time{Time: r.obs.ObservedAt} }
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// graphqlError hides the cause of an unexpected service error from the
+// client, as respondServiceError does.
+func graphqlError(err error) error {
+	log.Printf("graphQL: service error: %v", err)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return errors.New("airport not found")
+	case errors.Is(err, domain.ErrNoData):
+		return errors.New("data not available")
+	default:
+		return errors.New("service error")
+	}
+}
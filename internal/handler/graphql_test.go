@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock" // No conflict with testify
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQL(t *testing.T) {
+	denver := domain.Airport{Faa: "DEN", FacilityName: "Denver Intl", StateCode: "CO", City: "Denver"}
+	aspen := domain.Airport{Faa: "ASE", FacilityName: "Aspen-Pitkin County", StateCode: "CO", City: "Aspen"}
+	kennedy := domain.Airport{Faa: "JFK", FacilityName: "John F Kennedy Intl", StateCode: "NY", City: "New York"}
+	observedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		body         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name: "airport with selected fields",
+			body: `{"query":"{ airport(faa: \"tst\") { faa_ident icao_ident latitude } }"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"data":{"airport":{"faa_ident":"TST","icao_ident":"KTST","latitude":34.0522}}}`,
+		},
+		{
+			name: "unknown airport",
+			body: `{"query":"{ airport(faa: \"XXX\") { faa_ident } }"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportByFAA", "XXX").Return((*domain.Airport)(nil), domain.ErrNotFound)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"data":{"airport":null}}`,
+		},
+		{
+			name: "filtered by state and category",
+			body: `{"query":"query($state: String) { airports(state: $state, category: \"IFR\") { faa_ident flight_category } }","variables":{"state":"co"}}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportsByPlace", "co", "").Return([]domain.Airport{denver, aspen}, nil)
+				m.On("GetFlightCategories", []string{"DEN", "ASE"}).Return(map[string]string{"DEN": "VFR", "ASE": "IFR"}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"data":{"airports":[{"faa_ident":"ASE","flight_category":"IFR"}]}}`,
+		},
+		{
+			name: "filtered by city",
+			body: `{"query":"{ airports(city: \"NEW YORK\") { faa_ident } }"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportsByPlace", "", "NEW YORK").Return([]domain.Airport{kennedy}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"data":{"airports":[{"faa_ident":"JFK"}]}}`,
+		},
+		{
+			name:         "unknown category",
+			body:         `{"query":"{ airports(category: \"SUNNY\") { faa_ident } }"}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"errors":[{"message":"category must be VFR, MVFR, IFR or LIFR, got \"SUNNY\"","path":["airports"]}],"data":null}`,
+		},
+		{
+			name: "nested weather history",
+			body: `{"query":"{ airport(faa: \"DEN\") { faa_ident weather_history(hours: 6) { condition visibility_sm raw_metar observed_at } } }"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportByFAA", "DEN").Return(&denver, nil)
+				m.On("GetWeatherHistories", []string{"DEN"}, 6).Return(map[string][]domain.Observation{"DEN": {{Condition: "Mist", VisibilitySM: 4, ObservedAt: observedAt}}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"data":{"airport":{"faa_ident":"DEN","weather_history":[{"condition":"Mist","visibility_sm":4,"raw_metar":null,"observed_at":"2026-10-16T12:00:00Z"}]}}}`,
+		},
+		{
+			name: "weather history of a list in one query",
+			body: `{"query":"{ airports(state: \"CO\") { faa_ident weather_history { condition } } }"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportsByPlace", "CO", "").Return([]domain.Airport{denver, aspen}, nil)
+				m.On("GetWeatherHistories", []string{"DEN", "ASE"}, 24).Return(map[string][]domain.Observation{"DEN": {{Condition: "Mist", ObservedAt: observedAt}}}, nil).Once()
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"data":{"airports":[{"faa_ident":"DEN","weather_history":[{"condition":"Mist"}]},{"faa_ident":"ASE","weather_history":[]}]}}`,
+		},
+		{
+			name: "weather history too long",
+			body: `{"query":"{ airport(faa: \"DEN\") { weather_history(hours: 1000000) { condition } } }"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportByFAA", "DEN").Return(&denver, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"errors":[{"message":"hours must be between 1 and 72, got 1000000","path":["airport","weather_history"]}],"data":{"airport":null}}`,
+		},
+		{
+			name:         "query too deep",
+			body:         `{"query":"{ airport(faa: \"DEN\") { weather_history { condition { a { b } } } } }"}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"errors":[{"message":"Field \"b\" has depth 5 that exceeds max depth 4","locations":[{"line":1,"column":59}]}]}`,
+		},
+		{
+			name: "service error hidden",
+			body: `{"query":"{ airports { faa_ident } }"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportsByPlace", "", "").Return([]domain.Airport(nil), assert.AnError)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"errors":[{"message":"service error","path":["airports"]}],"data":null}`,
+		},
+		{
+			name:         "invalid JSON",
+			body:         `{"query":`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid JSON","data":null}`,
+		},
+		{
+			name:         "missing query",
+			body:         `{}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Missing Query","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+
+			req := httptest.NewRequest("POST", "/v1/graphql", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGraphQLBodyLimit(t *testing.T) {
+	h := NewHandler(&mocks.ServiceMock{}, &config.Config{MaxBodyBytes: 16})
+
+	req := httptest.NewRequest("POST", "/v1/graphql", strings.NewReader(`{"query":"{ airports { faa_ident } }"}`))
+	rec := httptest.NewRecorder()
+	h.Router().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
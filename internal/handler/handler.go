@@ -2,93 +2,329 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
-
+	"net/http/pprof"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/aviation"
 	"aviation-weather/internal/domain"
 	"aviation-weather/internal/service"
 	"aviation-weather/internal/utils"
+	"aviation-weather/internal/weather"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/graph-gophers/graphql-go"
 )
 
 type Handler struct {
-	svc service.ServiceInterface
+	svc     service.ServiceInterface
+	cfg     *config.Config
+	limiter *clientRateLimiter
+	graphql *graphql.Schema
+
+	// Config as of the last ApplyConfig, shown by the admin endpoint
+	effective atomic.Pointer[config.Config]
 }
 
-func NewHandler(svc service.ServiceInterface) *Handler {
-	return &Handler{svc: svc}
+func NewHandler(svc service.ServiceInterface, cfg *config.Config) *Handler {
+	h := &Handler{svc: svc, cfg: cfg, graphql: newGraphQLSchema(svc)}
+	h.limiter = newClientRateLimiter(cfg.RateLimitRPS, cfg.RateLimitBurst, h.registeredKey)
+	h.effective.Store(cfg)
+	return h
+}
+
+// registeredKey reports whether key resolves to a tenant. Keys that can't be
+// checked count as unregistered.
+func (h *Handler) registeredKey(key string) bool {
+	_, err := h.svc.ResolveTenant(key)
+	return err == nil
+}
+
+// ApplyConfig takes on the client rate limit of a reloaded config.
+func (h *Handler) ApplyConfig(cfg *config.Config) {
+	h.limiter.setLimit(cfg.RateLimitRPS, cfg.RateLimitBurst)
+	h.effective.Store(cfg)
+}
+
+// trustedProxies parses TRUSTED_PROXIES, checked when the config was loaded.
+func (h *Handler) trustedProxies() []netip.Prefix {
+	var proxies []netip.Prefix
+	for _, proxy := range h.cfg.TrustedProxies {
+		if p, err := config.ParseProxy(proxy); err == nil {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
 }
 
 func (h *Handler) Router() *chi.Mux {
 	r := chi.NewRouter()
 
+	// Middlewares
+	r.Use(trustedRealIP(h.trustedProxies(), h.cfg.ClientIPHeader))
+	if len(h.cfg.CORSAllowedOrigins) > 0 {
+		r.Use(cors(h.cfg.CORSAllowedOrigins, h.cfg.CORSAllowedMethods, h.cfg.CORSAllowedHeaders))
+	}
+	// Always installed since reloads may enable it
+	r.Use(h.limiter.rateLimit)
+	r.Use(compress(h.cfg.CompressionLevel))
+
 	// Routes
 	r.Get("/health", h.healthCheck)
-	r.Get("/airports", h.getAllAirports)
+	r.Get("/health/live", h.liveness)
+	r.Get("/health/ready", h.readiness)
+	r.Get("/metrics", h.metrics)
+	r.Route("/debug/pprof", func(r chi.Router) {
+		r.Use(adminOnly(h.cfg.AdminToken))
+		r.Get("/cmdline", pprof.Cmdline)
+		r.Get("/profile", pprof.Profile)
+		r.Get("/symbol", pprof.Symbol)
+		r.Post("/symbol", pprof.Symbol)
+		r.Get("/trace", pprof.Trace)
+		r.Get("/*", pprof.Index)
+	})
+	r.Get("/openapi.json", h.openAPI)
+	r.Get("/docs", h.docs)
+	r.Route("/v1", func(r chi.Router) {
+		r.Get("/health", h.healthCheck)
+		r.Get("/health/live", h.liveness)
+		r.Get("/health/ready", h.readiness)
+		h.routes(r)
+	})
+
+	// Legacy unversioned paths, kept as deprecated aliases of /v1
+	r.Group(func(r chi.Router) {
+		r.Use(deprecated("/v1"))
+		h.routes(r)
+	})
+
+	return r
+}
+
+// routes registers the airport and sync endpoints on r.
+func (h *Handler) routes(r chi.Router) {
+	// Streams and whole-table jobs run as long as they need, like keepWriting
+	r.Get("/airports/export", h.exportAirports)
+	r.Get("/airports/stream", h.streamAirports)
+	r.Get("/airports/changes", h.streamAirportChanges)
+	r.Post("/sync", h.syncAllAirports)
+	r.Post("/sync/retry-failed", h.retryFailedAirports)
+
+	r.Group(func(r chi.Router) {
+		r.Use(requestTimeout(h.cfg.RequestTimeout))
+		h.boundedRoutes(r)
+	})
+}
+
+// boundedRoutes registers the endpoints answering within the request timeout.
+func (h *Handler) boundedRoutes(r chi.Router) {
+	r.With(negotiateXML).Get("/airports", h.getAllAirports)
+	r.With(maxBodyBytes(h.cfg.MaxBodyBytes)).Post("/airports", h.createAirports)
+	r.With(maxBodyBytes(maxImportSize)).Post("/airports/import", h.importAirports)
+	r.Get("/airports/states", h.getStates)
+	r.Get("/airports/cities", h.getCities)
+	r.Get("/changes", h.getChangeFeed)
+	r.With(maxBodyBytes(h.cfg.MaxBodyBytes)).Post("/graphql", h.graphQL)
 	r.Get("/airport/", func(w http.ResponseWriter, r *http.Request) {
 		utils.EncodeResponseToUser(w, "Bad Request", "Missing FAA Parameter", nil, http.StatusBadRequest)
 	})
-	r.Get("/airport/{faa}", h.getAirport)
-	r.Post("/airport", h.createAirport)
-	r.Put("/airport", h.updateAirport)
-	r.Post("/sync", h.syncAllAirports)
+	r.With(negotiateXML).Get("/airport/{faa}", h.getAirport)
+	r.Get("/airport/{faa}/frequencies", h.getFrequencies)
+	r.Get("/airport/{faa}/runways", h.getRunways)
+	r.Get("/airport/{faa}/charts", h.getCharts)
+	r.With(unitsParam).Get("/airport/{faa}/wind-components", h.getWindComponents)
+	r.With(unitsParam).Get("/airport/{faa}/performance", h.getPerformance)
+	r.With(unitsParam).Get("/airport/{faa}/weather", h.getAirportWeather)
+	r.Get("/airport/{faa}/daylight", h.getDaylight)
+	r.Get("/airport/{faa}/alternates", h.getAlternates)
+	r.Get("/airport/{faa}/trend", h.getWeatherTrend)
+	r.Get("/airport/{faa}/navaids", h.getAirportNavaids)
+	r.Get("/airport/{faa}/advisories", h.getAirportAdvisories)
+	r.Get("/airport/{faa}/notams", h.getAirportNOTAMs)
+	r.With(unitsParam).Get("/airport/{faa}/forecast", h.getAirportForecast)
+	r.Get("/airport/{faa}/status/history", h.getAirportStatusHistory)
+	r.Get("/airport/{faa}/notes", h.getAirportNotes)
+	r.Get("/advisories", h.getAdvisories)
+	r.Get("/navaids/nearest", h.getNearestNavaids)
+	r.Get("/airac", h.getAIRAC)
+	r.With(unitsParam).Get("/route/weather", h.getRouteWeather)
+	r.With(unitsParam).Get("/weather", h.getLiveWeather)
+	r.With(maxBodyBytes(h.cfg.MaxBodyBytes), unitsParam).Post("/parse/metar", h.parseMETAR)
+	r.Group(func(r chi.Router) {
+		r.Use(h.resolveTenant)
+		r.Get("/alerts", h.getAlerts)
+		r.Get("/alerts/rules", h.getAlertRules)
+		r.Post("/alerts/rules", h.createAlertRule)
+		r.Delete("/alerts/rules/{id}", h.deleteAlertRule)
+		r.Get("/webhooks", h.getWebhooks)
+		r.Post("/webhooks", h.createWebhook)
+		r.Delete("/webhooks/{id}", h.deleteWebhook)
+		r.Get("/webhooks/{id}/deliveries", h.getWebhookDeliveries)
+		r.Get("/watchlists", h.getWatchlists)
+		r.Post("/watchlists", h.createWatchlist)
+		r.Delete("/watchlists/{id}", h.deleteWatchlist)
+		r.With(unitsParam).Get("/watchlists/{id}/weather", h.getWatchlistWeather)
+		r.Get("/subscriptions", h.getSubscriptions)
+		r.Post("/subscriptions", h.createSubscription)
+		r.Delete("/subscriptions/{id}", h.deleteSubscription)
+	})
+	r.With(maxBodyBytes(h.cfg.MaxBodyBytes)).Post("/airport", h.createAirport)
+	r.With(maxBodyBytes(h.cfg.MaxBodyBytes)).Put("/airport", h.updateAirport)
+	r.With(maxBodyBytes(h.cfg.MaxBodyBytes)).Patch("/airport/{faa}/status", h.changeAirportStatus)
+	r.With(maxBodyBytes(h.cfg.MaxBodyBytes)).Put("/airport/{faa}/fuel", h.updateAirportFuel)
+	r.With(maxBodyBytes(h.cfg.MaxBodyBytes)).Post("/airport/{faa}/notes", h.createAirportNote)
 	r.Post("/sync/", func(w http.ResponseWriter, r *http.Request) {
 		utils.EncodeResponseToUser(w, "Bad Request", "Missing FAA Parameter", nil, http.StatusBadRequest)
 	})
 	r.Post("/sync/{faa}", h.syncAirportByFAA)
+	r.Post("/sync/{faa}/frequencies", h.syncFrequencies)
+	r.Post("/sync/{faa}/runways", h.syncRunways)
 	r.Delete("/airport/", func(w http.ResponseWriter, r *http.Request) {
 		utils.EncodeResponseToUser(w, "Bad Request", "Missing FAA Parameter", nil, http.StatusBadRequest)
 	})
 	r.Delete("/airport/{faa}", h.deleteAirportByFAA)
-
-	return r
+	r.Delete("/airport/{faa}/notes/{id}", h.deleteAirportNote)
+	r.Get("/stats", h.airportStats)
+	r.Get("/dashboard", h.dashboard)
+	r.Get("/cache/stats", h.cacheStats)
+	r.Group(func(r chi.Router) {
+		r.Use(adminOnly(h.cfg.AdminToken))
+		r.Get("/admin/config", h.effectiveConfig)
+		r.Get("/admin/runtime", h.runtimeStats)
+		r.With(maxBodyBytes(h.cfg.MaxBodyBytes)).Post("/alerts/notifications/test", h.testNotification)
+		r.Get("/admin/tenants", h.getTenants)
+		r.Post("/admin/tenants", h.createTenant)
+		r.Get("/admin/tenants/{id}/keys", h.getAPIKeys)
+		r.Post("/admin/tenants/{id}/keys", h.createAPIKey)
+		r.Delete("/admin/keys/{id}", h.deleteAPIKey)
+		r.Get("/admin/jobs", h.getJobs)
+		r.Get("/admin/jobs/queue", h.getQueuedJobs)
+		r.Post("/admin/jobs/{name}/run", h.runJob)
+		r.Post("/admin/jobs/{name}/pause", h.pauseJob)
+		r.Post("/admin/jobs/{name}/resume", h.resumeJob)
+		r.Get("/admin/cache", h.getCaches)
+		r.Delete("/admin/cache/{name}", h.clearCache)
+		r.Get("/admin/conflicts", h.getConflicts)
+		r.Post("/admin/conflicts/{id}/accept", h.acceptConflict)
+		r.Post("/admin/conflicts/{id}/reject", h.rejectConflict)
+	})
 }
 
-// healthCheck: Simple health endpoint.
+// healthCheck: Simple health endpoint, reporting the state of external providers.
 func (h *Handler) healthCheck(w http.ResponseWriter, r *http.Request) {
-	utils.EncodeResponseToUser(w, "OK", "Aviation Weather API is Running", nil)
+	health := domain.HealthStatus{Providers: h.svc.ProviderStatuses()}
+	utils.EncodeResponseToUser(w, "OK", "Aviation Weather API is Running", health)
+}
+
+// liveness: Answers while the process serves requests, whatever the state of
+// its dependencies.
+func (h *Handler) liveness(w http.ResponseWriter, r *http.Request) {
+	utils.EncodeResponseToUser(w, "OK", "Aviation Weather API is Live", nil)
+}
+
+// readiness: Probes the dependencies, answering 503 with their detail while one
+// is down.
+func (h *Handler) readiness(w http.ResponseWriter, r *http.Request) {
+	readiness := h.svc.Readiness(r.Context())
+	if !readiness.Ready {
+		utils.EncodeResponseToUser(w, "Error", "Aviation Weather API is Not Ready", readiness, http.StatusServiceUnavailable)
+		return
+	}
+	utils.EncodeResponseToUser(w, "OK", "Aviation Weather API is Ready", readiness)
 }
 
 func (h *Handler) createAirport(w http.ResponseWriter, r *http.Request) {
-	var airport domain.Airport
-	if err := json.NewDecoder(r.Body).Decode(&airport); err != nil {
+	var req AirportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("createAirport: invalid JSON: %v", err)
-		utils.EncodeResponseToUser(w, "Bad Request", "Invalid JSON", nil, http.StatusBadRequest)
+		respondInvalidJSON(w, err)
 		return
 	}
 
-	if airport.Faa == "" {
+	if req.Faa == "" {
 		log.Printf("createAirport: faa_ident is empty")
 		utils.EncodeResponseToUser(w, "Bad Request", "Missing FAA Value", nil, http.StatusBadRequest)
 		return
 	}
 
+	if errs := req.Validate(); len(errs) > 0 {
+		log.Printf("createAirport: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	airport := req.toAirport()
 	if err := h.svc.CreateAirport(&airport); err != nil {
 		log.Printf("createAirport: service error: %v", err)
-		utils.EncodeResponseToUser(w, "Error", "Duplicate Airport", nil, http.StatusInternalServerError)
+		respondServiceError(w, err)
 		return
 	}
 
-	utils.EncodeResponseToUser(w, "OK", "Airport is Created", airport)
+	utils.EncodeResponseToUser(w, "OK", "Airport is Created", newAirportResponse(&airport, false))
+}
+
+// createAirports: Creates a JSON array of airports in one transaction, skipping existing FAA codes.
+func (h *Handler) createAirports(w http.ResponseWriter, r *http.Request) {
+	var entries []AirportRequest
+	if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+		log.Printf("createAirports: invalid JSON: %v", err)
+		respondInvalidJSON(w, err)
+		return
+	}
+
+	report := domain.BulkCreateReport{Failed: []domain.RowError{}}
+	var airports []domain.Airport
+	for i, a := range entries {
+		if err := validateAirportRow(&a); err != nil {
+			report.Failed = append(report.Failed, domain.RowError{Row: i + 1, Faa: a.Faa, Error: err.Error()})
+			continue
+		}
+		airports = append(airports, a.toAirport())
+	}
+
+	created, skipped, err := h.svc.CreateAirports(airports)
+	if err != nil {
+		log.Printf("createAirports: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+	report.Created, report.Skipped = created, skipped
+
+	message := fmt.Sprintf("%d Airports are Created, %d Skipped, %d Failed", len(created), len(skipped), len(report.Failed))
+	utils.EncodeResponseToUser(w, "OK", message, report)
 }
 
 func (h *Handler) updateAirport(w http.ResponseWriter, r *http.Request) {
-	var airport domain.Airport
-	if err := json.NewDecoder(r.Body).Decode(&airport); err != nil {
+	var req AirportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("updateAirport: invalid JSON: %v", err)
-		utils.EncodeResponseToUser(w, "Bad Request", "Invalid JSON", nil, http.StatusBadRequest)
+		respondInvalidJSON(w, err)
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		log.Printf("updateAirport: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
 		return
 	}
 
+	airport := req.toAirport()
 	if err := h.svc.UpdateAirport(&airport); err != nil {
 		log.Printf("updateAirport: service error: %v", err)
-		utils.EncodeResponseToUser(w, "Error", "Airport Not Found", nil, http.StatusNotFound)
+		respondServiceError(w, err)
 		return
 	}
 
-	utils.EncodeResponseToUser(w, "OK", "Airport is Updated", airport)
+	utils.EncodeResponseToUser(w, "OK", "Airport is Updated", newAirportResponse(&airport, false))
 }
 
 func (h *Handler) deleteAirportByFAA(w http.ResponseWriter, r *http.Request) {
@@ -96,7 +332,8 @@ func (h *Handler) deleteAirportByFAA(w http.ResponseWriter, r *http.Request) {
 
 	err := h.svc.DeleteAirportByFAA(faa)
 	if err != nil {
-		utils.EncodeResponseToUser(w, "Error", "Airport Not Found", nil, http.StatusNotFound)
+		log.Printf("deleteAirportByFAA: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
 		return
 	}
 
@@ -105,58 +342,427 @@ func (h *Handler) deleteAirportByFAA(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) getAirport(w http.ResponseWriter, r *http.Request) {
 	faa := chi.URLParam(r, "faa")
-
-	airport, err := h.svc.GetAirportByFAA(faa)
-	if airport == nil {
-		utils.EncodeResponseToUser(w, "Error", "Airport Not Found", nil, http.StatusNotFound)
+	dms, ok := parseCoordinates(w, r)
+	if !ok {
 		return
 	}
 
+	airport, err := h.svc.GetAirportByFAA(faa)
 	if err != nil {
 		log.Printf("getAirport: service error for %s: %v", faa, err)
-		utils.EncodeResponseToUser(w, "Error", "Service Error", nil, http.StatusInternalServerError)
+		respondServiceError(w, err)
 		return
 	}
 
-	utils.EncodeResponseToUser(w, "OK", "Airport is Fetched", airport)
+	resp := newAirportResponse(airport, dms)
+	// The notes are extras: the airport is still served without them
+	if resp.Notes, err = h.svc.GetAirportNotes(faa); err != nil {
+		log.Printf("WARN: getAirport: notes of %s left out: %v", faa, err)
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Airport is Fetched", resp)
 }
 
+// maxBatchFAAs caps the FAA codes of one ?faa= batch.
+const maxBatchFAAs = 100
+
+// getAllAirports: Lists every airport in the order of ?sort=, those selling ?fuel= (such as
+// 100LL or JetA), or the airports of ?faa= in the order listed, with only the fields named in
+// ?fields= when given and DMS coordinates too with ?coordinates=dms.
 func (h *Handler) getAllAirports(w http.ResponseWriter, r *http.Request) {
-	airports, err := h.svc.GetAllAirports()
+	fields, unknown := parseFields(r)
+	if len(unknown) > 0 {
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Fields", unknown, http.StatusBadRequest)
+		return
+	}
+	sort, err := domain.ParseSort(r.URL.Query().Get("sort"))
+	if err != nil {
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Sort", nil, http.StatusBadRequest)
+		return
+	}
+	dms, ok := parseCoordinates(w, r)
+	if !ok {
+		return
+	}
+	fuel, ok := parseFuel(w, r)
+	if !ok {
+		return
+	}
+
+	if r.URL.Query().Has("faa") {
+		if fuel != "" {
+			utils.EncodeResponseToUser(w, "Bad Request", "Fuel Not Supported With FAA", nil, http.StatusBadRequest)
+			return
+		}
+		h.getAirportsByFAAs(w, r, fields, sort, dms)
+		return
+	}
+
+	if modified, err := h.svc.AirportsLastModified(r.Context()); err != nil {
+		log.Printf("WARN: getAllAirports: answering without Last-Modified: %v", err)
+	} else if h.notModified(w, r, modified) {
+		return
+	}
+
+	var airports []domain.Airport
+	if fuel != "" {
+		airports, err = h.svc.GetAirportsByFuel(fuel, sort)
+	} else {
+		airports, err = h.svc.GetAllAirports(sort)
+	}
 	if err != nil {
 		log.Printf("getAllAirports: service error: %v", err)
-		utils.EncodeResponseToUser(w, "Error", "Service Error", nil, http.StatusInternalServerError)
+		respondServiceError(w, err)
 		return
 	}
 
-	utils.EncodeResponseToUser(w, "OK", "Airports are Fetched", airports)
+	resp := newAirportResponses(airports, dms)
+	if fields != nil {
+		utils.EncodeResponseToUser(w, "OK", "Airports are Fetched", projectAirports(resp, fields))
+		return
+	}
+	utils.EncodeResponseToUser(w, "OK", "Airports are Fetched", resp)
 }
 
-// syncAirportByFAA: Syncs a single airport by FAA (fetches APIs, updates DB).
-func (h *Handler) syncAirportByFAA(w http.ResponseWriter, r *http.Request) {
+// getAirportsByFAAs: Fetches the airports of ?faa=JFK,LAX in one query, replacing a
+// /airport/{faa} call per airport. Unknown codes are left out.
+func (h *Handler) getAirportsByFAAs(w http.ResponseWriter, r *http.Request, fields []string, sort []domain.SortField, dms bool) {
+	var faas []string
+	seen := map[string]bool{}
+	for _, faa := range strings.Split(r.URL.Query().Get("faa"), ",") {
+		if faa = strings.TrimSpace(faa); faa != "" && !seen[faa] {
+			seen[faa] = true
+			faas = append(faas, faa)
+		}
+	}
+	switch {
+	case len(faas) == 0:
+		utils.EncodeResponseToUser(w, "Bad Request", "Missing FAA Value", nil, http.StatusBadRequest)
+		return
+	case len(faas) > maxBatchFAAs:
+		utils.EncodeResponseToUser(w, "Bad Request", fmt.Sprintf("At Most %d FAA Codes", maxBatchFAAs), nil, http.StatusBadRequest)
+		return
+	case len(sort) > 0:
+		// The airports come back in the order listed
+		utils.EncodeResponseToUser(w, "Bad Request", "Sort Not Supported With FAA", nil, http.StatusBadRequest)
+		return
+	}
+
+	airports, err := h.svc.GetAirportsByFAAs(faas)
+	if err != nil {
+		log.Printf("getAirportsByFAAs: service error for %v: %v", faas, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	resp := newAirportResponses(airports, dms)
+	if fields != nil {
+		utils.EncodeResponseToUser(w, "OK", "Airports are Fetched", projectAirports(resp, fields))
+		return
+	}
+	utils.EncodeResponseToUser(w, "OK", "Airports are Fetched", resp)
+}
+
+// getStates: Lists the distinct state codes of the stored airports.
+func (h *Handler) getStates(w http.ResponseWriter, r *http.Request) {
+	states, err := h.svc.GetStates()
+	if err != nil {
+		log.Printf("getStates: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "States are Fetched", states)
+}
+
+// getCities: Lists the distinct cities of the stored airports, of one state with ?state=.
+func (h *Handler) getCities(w http.ResponseWriter, r *http.Request) {
+	state := r.URL.Query().Get("state")
+
+	cities, err := h.svc.GetCities(state)
+	if err != nil {
+		log.Printf("getCities: service error for state %q: %v", state, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Cities are Fetched", cities)
+}
+
+// getFrequencies: Lists the stored tower, ATIS, ground and other COM frequencies of an airport.
+func (h *Handler) getFrequencies(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	frequencies, err := h.svc.GetFrequencies(faa)
+	if err != nil {
+		log.Printf("getFrequencies: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Frequencies are Fetched", frequencies)
+}
+
+// syncFrequencies: Replaces the stored frequencies of an airport with the OurAirports data.
+func (h *Handler) syncFrequencies(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	frequencies, err := h.svc.SyncFrequencies(r.Context(), faa)
+	if err != nil {
+		log.Printf("syncFrequencies: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Frequencies are Synced", len(frequencies)), frequencies)
+}
+
+// getRunways: Lists the stored runway ends of an airport with their true headings.
+func (h *Handler) getRunways(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	runways, err := h.svc.GetRunways(faa)
+	if err != nil {
+		log.Printf("getRunways: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Runways are Fetched", runways)
+}
+
+// syncRunways: Replaces the stored runways of an airport with the OurAirports data.
+func (h *Handler) syncRunways(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	runways, err := h.svc.SyncRunways(r.Context(), faa)
+	if err != nil {
+		log.Printf("syncRunways: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Runways are Synced", len(runways)), runways)
+}
+
+// getWindComponents: Reports headwind and crosswind per runway for the last synced wind.
+func (h *Handler) getWindComponents(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	wind, err := h.svc.GetWindComponents(faa)
+	if err != nil {
+		log.Printf("getWindComponents: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Wind Components are Fetched", inUnits(r, wind))
+}
+
+// getPerformance: Reports pressure and density altitude for the last synced weather.
+func (h *Handler) getPerformance(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	performance, err := h.svc.GetPerformance(faa)
+	if err != nil {
+		log.Printf("getPerformance: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Performance is Fetched", inUnits(r, performance))
+}
+
+// getAirportWeather: Reports only the weather of an airport, fetched live from the providers
+// with ?refresh=true.
+func (h *Handler) getAirportWeather(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+	refresh := false
+	if value := r.URL.Query().Get("refresh"); value != "" {
+		var err error
+		if refresh, err = strconv.ParseBool(value); err != nil {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Refresh", nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	weather, err := h.svc.GetAirportWeather(r.Context(), faa, refresh)
+	if err != nil {
+		log.Printf("getAirportWeather: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Weather is Fetched", inUnits(r, weather))
+}
+
+// getDaylight: Reports civil twilight, sunrise and sunset in local time, for ?date=YYYY-MM-DD or today.
+func (h *Handler) getDaylight(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	var date time.Time
+	if value := r.URL.Query().Get("date"); value != "" {
+		var err error
+		if date, err = time.Parse(time.DateOnly, value); err != nil {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Date", nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	daylight, err := h.svc.GetDaylight(faa, date)
+	if err != nil {
+		log.Printf("getDaylight: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Daylight is Fetched", daylight)
+}
+
+// maxCorridorNM caps the route weather corridor so one request can't scan the country.
+const maxCorridorNM = 200
+
+// getRouteWeather: Lists airports along the great-circle route ?from=JFK&to=LAX,
+// within ?corridor_nm= of it, with their weather and flight category.
+func (h *Handler) getRouteWeather(w http.ResponseWriter, r *http.Request) {
+	from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+	if from == "" || to == "" {
+		utils.EncodeResponseToUser(w, "Bad Request", "Missing From or To Parameter", nil, http.StatusBadRequest)
+		return
+	}
+
+	var corridorNM float64
+	if value := r.URL.Query().Get("corridor_nm"); value != "" {
+		var err error
+		corridorNM, err = strconv.ParseFloat(value, 64)
+		if err != nil || corridorNM <= 0 || corridorNM > maxCorridorNM {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Corridor", nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	route, err := h.svc.GetRouteWeather(from, to, corridorNM)
+	if err != nil {
+		log.Printf("getRouteWeather: service error for %s-%s: %v", from, to, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Route Weather is Fetched", inUnits(r, route))
+}
+
+// getLiveWeather: Reports the current weather at ?city= or at ?lat=&lon=, which need not be an
+// airport. Nothing is stored.
+func (h *Handler) getLiveWeather(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	city, lat, lon := strings.TrimSpace(query.Get("city")), query.Get("lat"), query.Get("lon")
+
+	var loc weather.Location
+	switch {
+	case city != "" && (lat != "" || lon != ""):
+		utils.EncodeResponseToUser(w, "Bad Request", "Either City or Lat and Lon", nil, http.StatusBadRequest)
+		return
+	case city != "":
+		loc.City = city
+	case lat != "" && lon != "":
+		var latErr, lonErr error
+		loc.Latitude, latErr = domain.ParseCoordinate(lat, 90)
+		loc.Longitude, lonErr = domain.ParseCoordinate(lon, 180)
+		if latErr != nil || lonErr != nil {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Coordinates", nil, http.StatusBadRequest)
+			return
+		}
+		loc.HasCoords = true
+	default:
+		utils.EncodeResponseToUser(w, "Bad Request", "Missing City or Lat and Lon", nil, http.StatusBadRequest)
+		return
+	}
+
+	obs, err := h.svc.GetLiveWeather(r.Context(), loc)
+	if err != nil {
+		log.Printf("getLiveWeather: service error for %s: %v", loc, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Weather is Fetched", inUnits(r, obs))
+}
+
+// maxAlternateRadiusNM caps the alternates search radius.
+const maxAlternateRadiusNM = 300
+
+// getAlternates: Lists nearby airports reporting ?category= (VFR, MVFR, IFR, LIFR)
+// or better within ?radius_nm=, nearest first.
+func (h *Handler) getAlternates(w http.ResponseWriter, r *http.Request) {
 	faa := chi.URLParam(r, "faa")
 
-	// airport, err := h.svc.SyncAirportByFAA(faa)
-	airport, err := h.svc.SyncAirportQueued(faa)
+	var radiusNM float64
+	if value := r.URL.Query().Get("radius_nm"); value != "" {
+		var err error
+		radiusNM, err = strconv.ParseFloat(value, 64)
+		if err != nil || radiusNM <= 0 || radiusNM > maxAlternateRadiusNM {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Radius", nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	category := strings.ToUpper(r.URL.Query().Get("category"))
+	if category != "" && !aviation.IsCategory(category) {
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Category", nil, http.StatusBadRequest)
+		return
+	}
 
-	if airport == nil {
-		utils.EncodeResponseToUser(w, "Error", "Airport Not Found", nil, http.StatusNotFound)
+	alternates, err := h.svc.GetAlternates(faa, radiusNM, category)
+	if err != nil {
+		log.Printf("getAlternates: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
 		return
 	}
 
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Alternates are Fetched", len(alternates)), alternates)
+}
+
+// syncAirportByFAA: Syncs a single airport by FAA (fetches APIs, updates DB) and reports the changed fields.
+func (h *Handler) syncAirportByFAA(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	// result, err := h.svc.SyncAirportByFAA(r.Context(), faa)
+	result, err := h.svc.SyncAirportQueued(faa)
 	if err != nil {
 		log.Printf("syncAirportByFAA: service error for %s: %v", faa, err)
-		utils.EncodeResponseToUser(w, "Error", "Service Error", nil, http.StatusInternalServerError)
+		respondServiceError(w, err)
 		return
 	}
 
-	utils.EncodeResponseToUser(w, "OK", "Airport is Synced", airport)
+	utils.EncodeResponseToUser(w, "OK", "Airport is Synced", result)
 }
 
-// syncAllAirports: Bulk updates all airports with real API data.
+// syncAllAirports: Bulk updates all airports with real API data. A state query
+// parameter or a JSON array of FAA codes in the body narrows the sync.
 func (h *Handler) syncAllAirports(w http.ResponseWriter, r *http.Request) {
-	// updated, err := h.svc.SyncAllAirports()
-	updated, err := h.svc.SyncAllAirportsQueued()
+	keepWriting(w)
+	if state := r.URL.Query().Get("state"); state != "" {
+		h.syncPartial(w, func() (int, error) { return h.svc.SyncAirportsByState(r.Context(), state) })
+		return
+	}
+
+	var faas []string
+	if err := json.NewDecoder(r.Body).Decode(&faas); err != nil && !errors.Is(err, io.EOF) {
+		log.Printf("syncAllAirports: invalid JSON: %v", err)
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid JSON", nil, http.StatusBadRequest)
+		return
+	}
+	if faas != nil {
+		if len(faas) == 0 {
+			utils.EncodeResponseToUser(w, "Bad Request", "Missing FAA Value", nil, http.StatusBadRequest)
+			return
+		}
+		h.syncPartial(w, func() (int, error) { return h.svc.SyncAirportsByFAAs(r.Context(), faas) })
+		return
+	}
+
+	// updated, err := h.svc.SyncAllAirports(r.Context())
+	updated, err := h.svc.SyncAllAirportsQueued(r.Context())
 
 	if err != nil {
 		if updated == 0 {
@@ -164,9 +770,75 @@ func (h *Handler) syncAllAirports(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		log.Printf("syncAllAirports: service error: %v", err)
-		utils.EncodeResponseToUser(w, "Error", "Service Error", nil, http.StatusInternalServerError)
+		respondServiceError(w, err)
 		return
 	}
 
 	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Airports are Synced", updated), nil)
 }
+
+// retryFailedAirports: Syncs again the airports whose last sync failed and
+// whose retry backoff has passed.
+func (h *Handler) retryFailedAirports(w http.ResponseWriter, r *http.Request) {
+	keepWriting(w)
+	updated, err := h.svc.RetryFailedAirports(r.Context())
+	if err != nil {
+		log.Printf("retryFailedAirports: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Airports are Synced", updated), nil)
+}
+
+// syncPartial runs a state or FAA list sync and reports the number of synced airports.
+func (h *Handler) syncPartial(w http.ResponseWriter, sync func() (int, error)) {
+	updated, err := sync()
+	if err != nil {
+		log.Printf("syncAllAirports: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Airports are Synced", updated), nil)
+}
+
+// airportStats: Counts airports by state, ownership and flight category, and those with stale weather.
+func (h *Handler) airportStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.svc.AirportStats(r.Context())
+	if err != nil {
+		log.Printf("airportStats: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Stats are Fetched", stats)
+}
+
+// dashboard: Counts airports by state and flight category as of the last sync.
+func (h *Handler) dashboard(w http.ResponseWriter, r *http.Request) {
+	dashboard, err := h.svc.Dashboard(r.Context())
+	if err != nil {
+		log.Printf("dashboard: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Dashboard is Fetched", dashboard)
+}
+
+// cacheStats: Reports weather cache hits, misses and size.
+func (h *Handler) cacheStats(w http.ResponseWriter, r *http.Request) {
+	utils.EncodeResponseToUser(w, "OK", "Cache Stats are Fetched", h.svc.CacheStats())
+}
+
+// effectiveConfig: Shows the settings in effect, including reloaded ones, with
+// secrets redacted.
+func (h *Handler) effectiveConfig(w http.ResponseWriter, r *http.Request) {
+	utils.EncodeResponseToUser(w, "OK", "Config is Fetched", h.effective.Load().Settings())
+}
+
+// runtimeStats: Reports goroutines, heap and sync queue depths.
+func (h *Handler) runtimeStats(w http.ResponseWriter, r *http.Request) {
+	utils.EncodeResponseToUser(w, "OK", "Runtime Stats are Fetched", h.svc.RuntimeStats())
+}
@@ -3,17 +3,24 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"aviation-weather/config"
 	"aviation-weather/internal/domain"
 	mocks "aviation-weather/internal/mock" // No conflict with testify
+	"aviation-weather/internal/weather"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+var sampleLatitude, sampleLongitude = 34.0522, -118.2437
+
 var sampleAirport = domain.Airport{
 	SiteNumber:    "12345",
 	FacilityName:  "Test Airport",
@@ -27,16 +34,23 @@ var sampleAirport = domain.Airport{
 	UseType:       "Public Use",
 	Manager:       "Test Manager",
 	ManagerPhone:  "123-456-7890",
-	Latitude:      "34.0522",
-	Longitude:     "-118.2437",
+	Latitude:      &sampleLatitude,
+	Longitude:     &sampleLongitude,
 	AirportStatus: "Open",
 	Weather:       "Clear",
 }
 
-var sampleAirportJSON = `{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":"34.0522","longitude":"-118.2437","status":"Open","weather":"Clear"}`
+var sampleAirportJSON = `{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":34.0522,"longitude":-118.2437,"status":"Open","weather":"Clear"}`
+
+var sampleProviderStatuses = []domain.ProviderStatus{
+	{Provider: "aviationapi", State: "closed"},
+	{Provider: "weatherapi", State: "open", Failures: 5, Trips: 1},
+}
 
 func TestHealthCheck(t *testing.T) {
-	h := NewHandler(&mocks.ServiceMock{})
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ProviderStatuses").Return(sampleProviderStatuses)
+	h := NewHandler(mockSvc, &config.Config{})
 	r := h.Router()
 
 	req := httptest.NewRequest("GET", "/health", nil) // Fake request
@@ -46,12 +60,136 @@ func TestHealthCheck(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rec.Code, "HTTP status code should be 200")
 	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"), "Header should be JSON")
-	assert.JSONEq(t, `{"status":"OK","message":"Aviation Weather API is Running","data":null}`, rec.Body.String(), "JSON body should match")
+	assert.JSONEq(t, `{"status":"OK","message":"Aviation Weather API is Running","data":{"providers":[{"provider":"aviationapi","state":"closed","failures":0,"trips":0},{"provider":"weatherapi","state":"open","failures":5,"trips":1}]}}`, rec.Body.String(), "JSON body should match")
+}
+
+func TestLivenessAndReadiness(t *testing.T) {
+	synced := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	ready := domain.Readiness{
+		Ready:        true,
+		Dependencies: []domain.DependencyStatus{{Name: "database", Status: domain.DependencyUp}},
+		Providers:    []domain.ProviderStatus{},
+		LastSyncAt:   &synced,
+	}
+	down := domain.Readiness{
+		Dependencies: []domain.DependencyStatus{{Name: "database", Status: domain.DependencyDown, Error: "connection refused"}},
+		Providers:    []domain.ProviderStatus{},
+	}
+
+	tests := []struct {
+		name         string
+		url          string
+		readiness    *domain.Readiness
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "live without probing anything",
+			url:          "/health/live",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":"OK","message":"Aviation Weather API is Live","data":null}`,
+		},
+		{
+			name:         "ready",
+			url:          "/v1/health/ready",
+			readiness:    &ready,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":"OK","message":"Aviation Weather API is Ready","data":{"ready":true,"dependencies":[{"name":"database","status":"up"}],"providers":[],"last_sync_at":"2025-01-02T12:00:00Z"}}`,
+		},
+		{
+			name:         "not ready",
+			url:          "/health/ready",
+			readiness:    &down,
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: `{"status":"Error","message":"Aviation Weather API is Not Ready","data":{"ready":false,"dependencies":[{"name":"database","status":"down","error":"connection refused"}],"providers":[],"last_sync_at":null}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			if tt.readiness != nil {
+				mockSvc.On("Readiness", mock.Anything).Return(*tt.readiness)
+			}
+			h := NewHandler(mockSvc, &config.Config{})
+			r := h.Router()
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String(), "JSON body should match")
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ProviderStatuses").Return(sampleProviderStatuses)
+	h := NewHandler(mockSvc, &config.Config{})
+	r := h.Router()
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "HTTP status code should be 200")
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, rec.Body.String(), `aviation_weather_provider_breaker_state{provider="aviationapi"} 0`)
+	assert.Contains(t, rec.Body.String(), `aviation_weather_provider_breaker_state{provider="weatherapi"} 2`)
+	assert.Contains(t, rec.Body.String(), `aviation_weather_provider_breaker_trips_total{provider="weatherapi"} 1`)
+}
+
+func TestVersionedRoutes(t *testing.T) {
+	tests := []struct {
+		name               string
+		path               string
+		expectedDeprecated string
+		expectedLink       string
+	}{
+		{
+			name:               "v1 route",
+			path:               "/v1/airports",
+			expectedDeprecated: "",
+			expectedLink:       "",
+		},
+		{
+			name:               "legacy alias",
+			path:               "/airports",
+			expectedDeprecated: "true",
+			expectedLink:       `</v1/airports>; rel="successor-version"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			mockSvc.On("AirportsLastModified", mock.Anything).Return(time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC), nil)
+			mockSvc.On("GetAllAirports", mock.Anything).Return([]domain.Airport{}, nil)
+			h := NewHandler(mockSvc, &config.Config{})
+			r := h.Router()
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code, "HTTP status code should be 200")
+			assert.Equal(t, tt.expectedDeprecated, rec.Header().Get("Deprecation"), "Deprecation header should match")
+			assert.Equal(t, tt.expectedLink, rec.Header().Get("Link"), "Link header should match")
+			mockSvc.AssertExpectations(t)
+		})
+	}
 }
 
 func TestGetAllAirports(t *testing.T) {
 	tests := []struct {
 		name           string
+		query          string
 		setupMock      func(*mocks.ServiceMock)
 		expectedCode   int
 		expectedJSON   string
@@ -62,21 +200,93 @@ func TestGetAllAirports(t *testing.T) {
 		{
 			name: "success",
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("GetAllAirports").Return([]domain.Airport{sampleAirport}, nil)
+				m.On("AirportsLastModified", mock.Anything).Return(time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC), nil)
+				m.On("GetAllAirports", mock.Anything).Return([]domain.Airport{sampleAirport}, nil)
 			},
 			expectedCode:   http.StatusOK,
-			expectedJSON:   `{"status":"OK","message":"Airports are Fetched","data":[{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":"34.0522","longitude":"-118.2437","status":"Open","weather":"Clear"}]}`, // Note: JSONEq for fuzzy match
+			expectedJSON:   `{"status":"OK","message":"Airports are Fetched","data":[{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":34.0522,"longitude":-118.2437,"status":"Open","weather":"Clear"}]}`, // Note: JSONEq for fuzzy match
 			expectedStatus: "OK",
 			expectedMsg:    "Airports are Fetched",
 		},
+		// Field selection
+		{
+			name:  "selected fields",
+			query: "?fields=faa_ident,city,weather,elevation_ft",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("AirportsLastModified", mock.Anything).Return(time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC), nil)
+				m.On("GetAllAirports", mock.Anything).Return([]domain.Airport{sampleAirport}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Airports are Fetched","data":[{"faa_ident":"TST","city":"Test City","weather":"Clear"}]}`,
+		},
+		{
+			name:  "unknown fields",
+			query: "?fields=faa_ident,password",
+			setupMock: func(m *mocks.ServiceMock) {
+				// No call expected
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid Fields","data":["password"]}`,
+		},
+		// Sorting
+		{
+			name:  "sorted",
+			query: "?sort=facility_name,-state",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("AirportsLastModified", mock.Anything).Return(time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC), nil)
+				m.On("GetAllAirports", []domain.SortField{{Field: "facility_name"}, {Field: "state", Desc: true}}).Return([]domain.Airport{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Airports are Fetched","data":[]}`,
+		},
+		{
+			name:  "unsortable field",
+			query: "?sort=manager_phone",
+			setupMock: func(m *mocks.ServiceMock) {
+				// No call expected
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid Sort","data":null}`,
+		},
+		// Fuel filter
+		{
+			name:  "selling fuel",
+			query: "?fuel=jet-a&fields=faa_ident,fuel_types,fbo_name&sort=state",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("AirportsLastModified", mock.Anything).Return(time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC), nil)
+				m.On("GetAirportsByFuel", domain.FuelJetA, []domain.SortField{{Field: "state"}}).
+					Return([]domain.Airport{{Faa: "DEN", FuelTypes: []string{domain.Fuel100LL, domain.FuelJetA}, FboName: "Signature"}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Airports are Fetched","data":[{"faa_ident":"DEN","fuel_types":["100LL","JetA"],"fbo_name":"Signature"}]}`,
+		},
+		{
+			name:  "unknown fuel",
+			query: "?fuel=diesel",
+			setupMock: func(m *mocks.ServiceMock) {
+				// No call expected
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid Fuel Type","data":null}`,
+		},
+		{
+			name:  "fuel with faa",
+			query: "?fuel=100LL&faa=DEN",
+			setupMock: func(m *mocks.ServiceMock) {
+				// No call expected
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Fuel Not Supported With FAA","data":null}`,
+		},
 		// Service error
 		{
 			name: "service error",
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("GetAllAirports").Return([]domain.Airport{}, assert.AnError)
+				m.On("AirportsLastModified", mock.Anything).Return(time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC), nil)
+				m.On("GetAllAirports", mock.Anything).Return([]domain.Airport{}, assert.AnError)
 			},
 			expectedCode:   http.StatusInternalServerError,
-			expectedJSON:   `{"status":"Error","message":"Service Error","data":null}`,
+			expectedJSON:   `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
 			expectedStatus: "Error",
 			expectedMsg:    "Service Error",
 		},
@@ -86,10 +296,10 @@ func TestGetAllAirports(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockSvc := &mocks.ServiceMock{} // Use the service mock to fake the return
 			tt.setupMock(mockSvc)
-			h := NewHandler(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
 			r := h.Router()
 
-			req := httptest.NewRequest("GET", "/airports", nil)
+			req := httptest.NewRequest("GET", "/airports"+tt.query, nil)
 			rec := httptest.NewRecorder()
 
 			r.ServeHTTP(rec, req)
@@ -102,6 +312,118 @@ func TestGetAllAirports(t *testing.T) {
 	}
 }
 
+func TestGetAirportsByFAAs(t *testing.T) {
+	tooMany := make([]string, maxBatchFAAs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("A%03d", i)
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:  "success",
+			query: "?faa=LAX,%20JFK,LAX,NONE&fields=faa_ident",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportsByFAAs", []string{"LAX", "JFK", "NONE"}).Return([]domain.Airport{{Faa: "LAX"}, {Faa: "JFK"}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Airports are Fetched","data":[{"faa_ident":"LAX"},{"faa_ident":"JFK"}]}`,
+		},
+		{
+			name:         "empty list",
+			query:        "?faa=,",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Missing FAA Value","data":null}`,
+		},
+		{
+			name:         "too many codes",
+			query:        "?faa=" + strings.Join(tooMany, ","),
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"At Most 100 FAA Codes","data":null}`,
+		},
+		{
+			name:         "with sort",
+			query:        "?faa=LAX&sort=city",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Sort Not Supported With FAA","data":null}`,
+		},
+		{
+			name:  "service error",
+			query: "?faa=LAX",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportsByFAAs", []string{"LAX"}).Return([]domain.Airport(nil), assert.AnError)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			r := NewHandler(mockSvc, &config.Config{}).Router()
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/airports"+tt.query, nil))
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String(), "JSON body should match")
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAirportsConditionalGet(t *testing.T) {
+	modified := time.Date(2025, 1, 2, 12, 0, 0, 500, time.UTC)
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("AirportsLastModified", mock.Anything).Return(modified, nil)
+	mockSvc.On("GetAllAirports", mock.Anything).Return([]domain.Airport{}, nil).Once()
+	r := NewHandler(mockSvc, &config.Config{HTTPCacheMaxAge: time.Minute}).Router()
+
+	tests := []struct {
+		name            string
+		ifModifiedSince string
+		expectedCode    int
+	}{
+		{name: "no validator", expectedCode: http.StatusOK},
+		{name: "copy is current", ifModifiedSince: "Thu, 02 Jan 2025 12:00:00 GMT", expectedCode: http.StatusNotModified},
+		{name: "copy is newer", ifModifiedSince: "Thu, 02 Jan 2025 13:00:00 GMT", expectedCode: http.StatusNotModified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/v1/airports", nil)
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			assert.Equal(t, "public, max-age=60", rec.Header().Get("Cache-Control"))
+			assert.Equal(t, "Thu, 02 Jan 2025 12:00:00 GMT", rec.Header().Get("Last-Modified"))
+		})
+	}
+
+	// An outdated copy is sent the list again
+	mockSvc.On("GetAllAirports", mock.Anything).Return([]domain.Airport{}, nil).Once()
+	req := httptest.NewRequest("GET", "/v1/airports", nil)
+	req.Header.Set("If-Modified-Since", "Thu, 02 Jan 2025 11:59:59 GMT")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockSvc.AssertNumberOfCalls(t, "GetAllAirports", 2)
+}
+
 func TestGetAirport(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -115,9 +437,30 @@ func TestGetAirport(t *testing.T) {
 			faa:  "TST",
 			setupMock: func(m *mocks.ServiceMock) {
 				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+				m.On("GetAirportNotes", "TST").Return([]domain.AirportNote{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Airport is Fetched","data":{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":34.0522,"longitude":-118.2437,"status":"Open","weather":"Clear"}}`,
+		},
+		{
+			name: "with notes",
+			faa:  "TST",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportByFAA", "TST").Return(&domain.Airport{Faa: "TST", Weather: "Clear"}, nil)
+				m.On("GetAirportNotes", "TST").Return([]domain.AirportNote{{ID: 5, Faa: "TST", Body: "Fuel unavailable this week"}}, nil)
 			},
 			expectedCode: http.StatusOK,
-			expectedJSON: `{"status":"OK","message":"Airport is Fetched","data":{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":"34.0522","longitude":"-118.2437","status":"Open","weather":"Clear"}}`,
+			expectedJSON: `{"status":"OK","message":"Airport is Fetched","data":{"site_number":"","facility_name":"","faa_ident":"TST","icao_ident":"","state":"","state_full":"","county":"","city":"","ownership":"","use":"","manager":"","manager_phone":"","status":"","weather":"Clear","notes":[{"id":5,"faa_ident":"TST","body":"Fuel unavailable this week"}]}}`,
+		},
+		{
+			name: "notes failing",
+			faa:  "TST",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportByFAA", "TST").Return(&domain.Airport{Faa: "TST", Weather: "Clear"}, nil)
+				m.On("GetAirportNotes", "TST").Return([]domain.AirportNote(nil), assert.AnError)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Airport is Fetched","data":{"site_number":"","facility_name":"","faa_ident":"TST","icao_ident":"","state":"","state_full":"","county":"","city":"","ownership":"","use":"","manager":"","manager_phone":"","status":"","weather":"Clear"}}`,
 		},
 		{
 			name: "missing faa",
@@ -132,10 +475,10 @@ func TestGetAirport(t *testing.T) {
 			name: "not found",
 			faa:  "NF",
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("GetAirportByFAA", "NF").Return((*domain.Airport)(nil), assert.AnError)
+				m.On("GetAirportByFAA", "NF").Return((*domain.Airport)(nil), fmt.Errorf("%w: NF", domain.ErrNotFound))
 			},
 			expectedCode: http.StatusNotFound,
-			expectedJSON: `{"status":"Error","message":"Airport Not Found","data":null}`,
+			expectedJSON: `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`,
 		},
 		{
 			name: "service error",
@@ -143,8 +486,8 @@ func TestGetAirport(t *testing.T) {
 			setupMock: func(m *mocks.ServiceMock) {
 				m.On("GetAirportByFAA", "ERR").Return((*domain.Airport)(nil), assert.AnError)
 			},
-			expectedCode: http.StatusNotFound,
-			expectedJSON: `{"status":"Error","message":"Airport Not Found","data":null}`,
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
 		},
 	}
 
@@ -152,7 +495,7 @@ func TestGetAirport(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockSvc := &mocks.ServiceMock{}
 			tt.setupMock(mockSvc)
-			h := NewHandler(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
 			r := h.Router()
 
 			urlPath := "/airport/" + tt.faa
@@ -169,6 +512,90 @@ func TestGetAirport(t *testing.T) {
 	}
 }
 
+func TestAirportDMSCoordinates(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+	mockSvc.On("GetAirportNotes", "TST").Return([]domain.AirportNote{}, nil)
+	mockSvc.On("AirportsLastModified", mock.Anything).Return(time.Time{}, assert.AnError)
+	mockSvc.On("GetAllAirports", mock.Anything).Return([]domain.Airport{sampleAirport}, nil)
+	r := NewHandler(mockSvc, &config.Config{}).Router()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/airport/TST?coordinates=dms", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"latitude":34.0522,"longitude":-118.2437,"latitude_dms":"34-03-07.9200N","longitude_dms":"118-14-37.3200W"`)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/airports?coordinates=dms&fields=faa_ident,latitude_dms", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"OK","message":"Airports are Fetched","data":[{"faa_ident":"TST","latitude_dms":"34-03-07.9200N"}]}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/airport/TST", nil))
+	assert.NotContains(t, rec.Body.String(), "latitude_dms", "Decimal degrees only by default")
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/airport/TST?coordinates=grads", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"status":"Bad Request","message":"Invalid Coordinates","data":null}`, rec.Body.String())
+}
+
+func TestXMLResponses(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetAirportByFAA", "TST").Return(&domain.Airport{Faa: "TST", FacilityName: "Test Airport"}, nil)
+	mockSvc.On("GetAirportNotes", "TST").Return([]domain.AirportNote{{ID: 5, Faa: "TST", Body: "Fuel unavailable"}}, nil)
+	mockSvc.On("GetAirportByFAA", "NF").Return((*domain.Airport)(nil), fmt.Errorf("%w: NF", domain.ErrNotFound))
+	mockSvc.On("AirportsLastModified", mock.Anything).Return(time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC), nil)
+	mockSvc.On("GetAllAirports", mock.Anything).Return([]domain.Airport{{Faa: "AAA"}, {Faa: "BBB"}}, nil)
+	r := NewHandler(mockSvc, &config.Config{}).Router()
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedCode int
+		expectedXML  string
+	}{
+		{
+			name:         "airport",
+			path:         "/v1/airport/TST",
+			expectedCode: http.StatusOK,
+			expectedXML:  `<data><airport><site_number></site_number><facility_name>Test Airport</facility_name><faa_ident>TST</faa_ident>`,
+		},
+		{
+			name:         "airport notes",
+			path:         "/v1/airport/TST",
+			expectedCode: http.StatusOK,
+			expectedXML:  `<note><id>5</id><faa_ident>TST</faa_ident><body>Fuel unavailable</body></note></airport>`,
+		},
+		{
+			name:         "airports",
+			path:         "/v1/airports",
+			expectedCode: http.StatusOK,
+			expectedXML:  `<weather></weather></airport><airport><site_number></site_number>`,
+		},
+		{
+			name:         "error",
+			path:         "/v1/airport/NF",
+			expectedCode: http.StatusNotFound,
+			expectedXML:  `<response><status>Error</status><message>Airport Not Found</message><error_code>not_found</error_code><data></data></response>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Accept", "application/xml")
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			assert.Equal(t, "application/xml", rec.Header().Get("Content-Type"), "Header should be XML")
+			assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+			assert.Contains(t, rec.Body.String(), tt.expectedXML)
+		})
+	}
+}
+
 func TestCreateAirport(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -186,7 +613,7 @@ func TestCreateAirport(t *testing.T) {
 				})).Return(nil)
 			},
 			expectedCode: http.StatusOK,
-			expectedJSON: `{"status":"OK","message":"Airport is Created","data":{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":"34.0522","longitude":"-118.2437","status":"Open","weather":"Clear"}}`,
+			expectedJSON: `{"status":"OK","message":"Airport is Created","data":{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":34.0522,"longitude":-118.2437,"status":"Open","weather":"Clear"}}`,
 		},
 		{
 			name: "invalid json",
@@ -216,15 +643,33 @@ func TestCreateAirport(t *testing.T) {
 			expectedJSON: `{"status":"Bad Request","message":"Missing FAA Value","data":null}`,
 		},
 		{
-			name: "service error",
+			name: "invalid fields",
+			body: []byte(`{"faa_ident":"TST","state":"ZZ","latitude":"95"}`),
+			setupMock: func(m *mocks.ServiceMock) {
+				// No call expected
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"state","error":"unknown state code \"ZZ\""},{"field":"latitude","error":"must be between -90 and 90"}]}`,
+		},
+		{
+			name: "duplicate",
 			body: []byte(sampleAirportJSON),
 			setupMock: func(m *mocks.ServiceMock) {
 				m.On("CreateAirport", mock.MatchedBy(func(a *domain.Airport) bool {
 					return a.Faa == "TST"
-				})).Return(assert.AnError)
+				})).Return(fmt.Errorf("%w: TST", domain.ErrDuplicate))
+			},
+			expectedCode: http.StatusConflict,
+			expectedJSON: `{"status":"Error","message":"Duplicate Airport","error_code":"duplicate","data":null}`,
+		},
+		{
+			name: "service error",
+			body: []byte(sampleAirportJSON),
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateAirport", mock.Anything).Return(assert.AnError)
 			},
 			expectedCode: http.StatusInternalServerError,
-			expectedJSON: `{"status":"Error","message":"Duplicate Airport","data":null}`,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
 		},
 	}
 
@@ -232,7 +677,7 @@ func TestCreateAirport(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockSvc := &mocks.ServiceMock{}
 			tt.setupMock(mockSvc)
-			h := NewHandler(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
 			r := h.Router()
 
 			req := httptest.NewRequest("POST", "/airport", bytes.NewReader(tt.body))
@@ -249,28 +694,27 @@ func TestCreateAirport(t *testing.T) {
 	}
 }
 
-func TestUpdateAirport(t *testing.T) {
+func TestCreateAirports(t *testing.T) {
 	tests := []struct {
 		name         string
-		body         []byte
+		body         string
 		setupMock    func(*mocks.ServiceMock)
 		expectedCode int
 		expectedJSON string
 	}{
 		{
 			name: "success",
-			body: []byte(sampleAirportJSON),
+			body: `[{"faa_ident":"TST"},{"faa_ident":"OLD"},{"city":"No Code"}]`,
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("UpdateAirport", mock.MatchedBy(func(a *domain.Airport) bool {
-					return a.Faa == "TST"
-				})).Return(nil)
+				m.On("CreateAirports", []domain.Airport{{Faa: "TST"}, {Faa: "OLD"}}).
+					Return([]string{"TST"}, []string{"OLD"}, nil)
 			},
 			expectedCode: http.StatusOK,
-			expectedJSON: `{"status":"OK","message":"Airport is Updated","data":{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":"34.0522","longitude":"-118.2437","status":"Open","weather":"Clear"}}`,
+			expectedJSON: `{"status":"OK","message":"1 Airports are Created, 1 Skipped, 1 Failed","data":{"created":["TST"],"skipped":["OLD"],"failed":[{"row":3,"faa_ident":"","error":"faa_ident: is required"}]}}`,
 		},
 		{
 			name: "invalid json",
-			body: []byte(`{invalid}`),
+			body: `{"faa_ident":"TST"}`,
 			setupMock: func(m *mocks.ServiceMock) {
 				// No call expected
 			},
@@ -279,14 +723,12 @@ func TestUpdateAirport(t *testing.T) {
 		},
 		{
 			name: "service error",
-			body: []byte(sampleAirportJSON),
+			body: `[{"faa_ident":"TST"}]`,
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("UpdateAirport", mock.MatchedBy(func(a *domain.Airport) bool {
-					return a.Faa == "TST"
-				})).Return(assert.AnError)
+				m.On("CreateAirports", mock.Anything).Return([]string(nil), []string(nil), assert.AnError)
 			},
-			expectedCode: http.StatusNotFound,
-			expectedJSON: `{"status":"Error","message":"Airport Not Found","data":null}`,
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
 		},
 	}
 
@@ -294,48 +736,127 @@ func TestUpdateAirport(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockSvc := &mocks.ServiceMock{}
 			tt.setupMock(mockSvc)
-			h := NewHandler(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
 			r := h.Router()
 
-			req := httptest.NewRequest("PUT", "/airport", bytes.NewReader(tt.body))
+			req := httptest.NewRequest("POST", "/v1/airports", bytes.NewReader([]byte(tt.body)))
 			req.Header.Set("Content-Type", "application/json")
 			rec := httptest.NewRecorder()
 
 			r.ServeHTTP(rec, req)
 
 			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
-			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"), "Header should be JSON")
 			assert.JSONEq(t, tt.expectedJSON, rec.Body.String(), "JSON body should match")
 			mockSvc.AssertExpectations(t)
 		})
 	}
 }
 
-func TestDeleteAirportByFAA(t *testing.T) {
+func TestUpdateAirport(t *testing.T) {
 	tests := []struct {
 		name         string
-		faa          string
+		body         []byte
 		setupMock    func(*mocks.ServiceMock)
 		expectedCode int
 		expectedJSON string
 	}{
 		{
 			name: "success",
-			faa:  "TST",
+			body: []byte(sampleAirportJSON),
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("DeleteAirportByFAA", "TST").Return(nil)
+				m.On("UpdateAirport", mock.MatchedBy(func(a *domain.Airport) bool {
+					return a.Faa == "TST"
+				})).Return(nil)
 			},
 			expectedCode: http.StatusOK,
-			expectedJSON: `{"status":"OK","message":"Airport is Deleted","data":"TST"}`,
+			expectedJSON: `{"status":"OK","message":"Airport is Updated","data":{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":34.0522,"longitude":-118.2437,"status":"Open","weather":"Clear"}}`,
 		},
 		{
-			name: "missing faa",
-			faa:  "",
+			name: "invalid json",
+			body: []byte(`{invalid}`),
 			setupMock: func(m *mocks.ServiceMock) {
 				// No call expected
 			},
 			expectedCode: http.StatusBadRequest,
-			expectedJSON: `{"status":"Bad Request","message":"Missing FAA Parameter","data":null}`,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid JSON","data":null}`,
+		},
+		{
+			name: "missing faa",
+			body: []byte(`{"city":"Test City"}`),
+			setupMock: func(m *mocks.ServiceMock) {
+				// No call expected
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"faa_ident","error":"is required"}]}`,
+		},
+		{
+			name: "service error",
+			body: []byte(sampleAirportJSON),
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("UpdateAirport", mock.MatchedBy(func(a *domain.Airport) bool {
+					return a.Faa == "TST"
+				})).Return(assert.AnError)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
+		},
+		{
+			name: "not found",
+			body: []byte(sampleAirportJSON),
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("UpdateAirport", mock.Anything).Return(fmt.Errorf("%w: TST", domain.ErrNotFound))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+			r := h.Router()
+
+			req := httptest.NewRequest("PUT", "/airport", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"), "Header should be JSON")
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String(), "JSON body should match")
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteAirportByFAA(t *testing.T) {
+	tests := []struct {
+		name         string
+		faa          string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name: "success",
+			faa:  "TST",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("DeleteAirportByFAA", "TST").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Airport is Deleted","data":"TST"}`,
+		},
+		{
+			name: "missing faa",
+			faa:  "",
+			setupMock: func(m *mocks.ServiceMock) {
+				// No call expected
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Missing FAA Parameter","data":null}`,
 		},
 		{
 			name: "service error",
@@ -343,8 +864,17 @@ func TestDeleteAirportByFAA(t *testing.T) {
 			setupMock: func(m *mocks.ServiceMock) {
 				m.On("DeleteAirportByFAA", "ERR").Return(assert.AnError)
 			},
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
+		},
+		{
+			name: "not found",
+			faa:  "NF",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("DeleteAirportByFAA", "NF").Return(fmt.Errorf("%w: NF", domain.ErrNotFound))
+			},
 			expectedCode: http.StatusNotFound,
-			expectedJSON: `{"status":"Error","message":"Airport Not Found","data":null}`,
+			expectedJSON: `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`,
 		},
 	}
 
@@ -352,7 +882,7 @@ func TestDeleteAirportByFAA(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockSvc := &mocks.ServiceMock{}
 			tt.setupMock(mockSvc)
-			h := NewHandler(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
 			r := h.Router()
 
 			urlPath := "/airport/" + tt.faa
@@ -381,10 +911,14 @@ func TestSyncAirportByFAA(t *testing.T) {
 			name: "success",
 			faa:  "TST",
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("SyncAirportQueued", "TST").Return(&sampleAirport, nil) // Changed from SyncAirportByFAA
+				m.On("SyncAirportQueued", "TST").Return(&domain.SyncResult{
+					Airport:       &sampleAirport,
+					ChangedFields: []string{"weather"},
+					Changes:       []domain.FieldChange{{Field: "weather", Old: "Rain", New: "Clear"}},
+				}, nil) // Changed from SyncAirportByFAA
 			},
 			expectedCode: http.StatusOK,
-			expectedJSON: `{"status":"OK","message":"Airport is Synced","data":{"site_number":"12345","facility_name":"Test Airport","faa_ident":"TST","icao_ident":"KTST","state":"CA","state_full":"California","county":"Test County","city":"Test City","ownership":"Public","use":"Public Use","manager":"Test Manager","manager_phone":"123-456-7890","latitude":"34.0522","longitude":"-118.2437","status":"Open","weather":"Clear"}}`,
+			expectedJSON: `{"status":"OK","message":"Airport is Synced","data":{"airport":` + sampleAirportJSON + `,"changed_fields":["weather"],"changes":[{"field":"weather","old":"Rain","new":"Clear"}]}}`,
 		},
 		{
 			name: "missing faa",
@@ -399,19 +933,28 @@ func TestSyncAirportByFAA(t *testing.T) {
 			name: "not found",
 			faa:  "NF",
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("SyncAirportQueued", "NF").Return((*domain.Airport)(nil), assert.AnError) // Changed from SyncAirportByFAA
+				m.On("SyncAirportQueued", "NF").Return((*domain.SyncResult)(nil), fmt.Errorf("%w: NF", domain.ErrNotFound)) // Changed from SyncAirportByFAA
 			},
 			expectedCode: http.StatusNotFound,
-			expectedJSON: `{"status":"Error","message":"Airport Not Found","data":null}`,
+			expectedJSON: `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`,
+		},
+		{
+			name: "external api error",
+			faa:  "EXT",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("SyncAirportQueued", "EXT").Return((*domain.SyncResult)(nil), fmt.Errorf("%w: weather down", domain.ErrExternalAPI))
+			},
+			expectedCode: http.StatusBadGateway,
+			expectedJSON: `{"status":"Error","message":"External API Error","error_code":"external_api_error","data":null}`,
 		},
 		{
 			name: "service error",
 			faa:  "ERR",
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("SyncAirportQueued", "ERR").Return((*domain.Airport)(nil), assert.AnError) // Changed from SyncAirportByFAA
+				m.On("SyncAirportQueued", "ERR").Return((*domain.SyncResult)(nil), assert.AnError) // Changed from SyncAirportByFAA
 			},
-			expectedCode: http.StatusNotFound,
-			expectedJSON: `{"status":"Error","message":"Airport Not Found","data":null}`,
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
 		},
 	}
 
@@ -419,7 +962,7 @@ func TestSyncAirportByFAA(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockSvc := &mocks.ServiceMock{}
 			tt.setupMock(mockSvc)
-			h := NewHandler(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
 			r := h.Router()
 
 			urlPath := "/sync/" + tt.faa
@@ -446,7 +989,7 @@ func TestSyncAllAirports(t *testing.T) {
 		{
 			name: "success",
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("SyncAllAirportsQueued").Return(1, nil) // Changed from SyncAllAirports
+				m.On("SyncAllAirportsQueued", mock.Anything).Return(1, nil) // Changed from SyncAllAirports
 			},
 			expectedCode: http.StatusOK,
 			expectedJSON: `{"status":"OK","message":"1 Airports are Synced","data":null}`,
@@ -454,7 +997,7 @@ func TestSyncAllAirports(t *testing.T) {
 		{
 			name: "no airports updated",
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("SyncAllAirportsQueued").Return(0, nil) // Changed from SyncAllAirports
+				m.On("SyncAllAirportsQueued", mock.Anything).Return(0, nil) // Changed from SyncAllAirports
 			},
 			expectedCode: http.StatusOK,
 			expectedJSON: `{"status":"OK","message":"0 Airports are Synced","data":null}`,
@@ -462,7 +1005,7 @@ func TestSyncAllAirports(t *testing.T) {
 		{
 			name: "no airports to sync with error",
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("SyncAllAirportsQueued").Return(0, assert.AnError) // Changed from SyncAllAirports
+				m.On("SyncAllAirportsQueued", mock.Anything).Return(0, assert.AnError) // Changed from SyncAllAirports
 			},
 			expectedCode: http.StatusOK,
 			expectedJSON: `{"status":"Error","message":"No Airport to Sync","data":null}`,
@@ -470,10 +1013,10 @@ func TestSyncAllAirports(t *testing.T) {
 		{
 			name: "service error with updates",
 			setupMock: func(m *mocks.ServiceMock) {
-				m.On("SyncAllAirportsQueued").Return(1, assert.AnError) // Changed from SyncAllAirports
+				m.On("SyncAllAirportsQueued", mock.Anything).Return(1, assert.AnError) // Changed from SyncAllAirports
 			},
 			expectedCode: http.StatusInternalServerError,
-			expectedJSON: `{"status":"Error","message":"Service Error","data":null}`,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
 		},
 	}
 
@@ -481,7 +1024,7 @@ func TestSyncAllAirports(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockSvc := &mocks.ServiceMock{}
 			tt.setupMock(mockSvc)
-			h := NewHandler(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
 			r := h.Router()
 
 			req := httptest.NewRequest("POST", "/sync", nil)
@@ -496,3 +1039,767 @@ func TestSyncAllAirports(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncAirportsPartial(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		body         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name: "by state",
+			url:  "/v1/sync?state=TX",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("SyncAirportsByState", mock.Anything, "TX").Return(3, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"3 Airports are Synced","data":null}`,
+		},
+		{
+			name: "by FAA list",
+			url:  "/v1/sync",
+			body: `["DFW","AUS"]`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("SyncAirportsByFAAs", mock.Anything, []string{"DFW", "AUS"}).Return(2, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"2 Airports are Synced","data":null}`,
+		},
+		{
+			name: "unknown state",
+			url:  "/v1/sync?state=XX",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("SyncAirportsByState", mock.Anything, "XX").Return(0, fmt.Errorf("%w: no airports in state XX", domain.ErrNotFound))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`,
+		},
+		{
+			name:         "empty FAA list",
+			url:          "/v1/sync",
+			body:         `[]`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Missing FAA Value","data":null}`,
+		},
+		{
+			name:         "invalid JSON",
+			url:          "/v1/sync",
+			body:         `{"faa":"DFW"}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid JSON","data":null}`,
+		},
+		{
+			name: "retry failed",
+			url:  "/v1/sync/retry-failed",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("RetryFailedAirports", mock.Anything).Return(2, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"2 Airports are Synced","data":null}`,
+		},
+		{
+			name: "retry failed error",
+			url:  "/v1/sync/retry-failed",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("RetryFailedAirports", mock.Anything).Return(0, assert.AnError)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+			r := h.Router()
+
+			req := httptest.NewRequest("POST", tt.url, bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String(), "JSON body should match")
+			mockSvc.AssertNotCalled(t, "SyncAllAirportsQueued")
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAirportStats(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name: "success",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("AirportStats", mock.Anything).Return(&domain.AirportStats{
+					TotalAirports:    3,
+					ByState:          map[string]int{"TX": 2, "CA": 1},
+					ByOwnership:      map[string]int{"PU": 3},
+					ByFlightCategory: map[string]int{"VFR": 2, "unknown": 1},
+					StaleWeather:     1,
+					StaleAfter:       "12h0m0s",
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Stats are Fetched","data":{"total_airports":3,"by_state":{"TX":2,"CA":1},"by_ownership":{"PU":3},"by_flight_category":{"VFR":2,"unknown":1},"stale_weather":1,"stale_after":"12h0m0s"}}`,
+		},
+		{
+			name: "service error",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("AirportStats", mock.Anything).Return((*domain.AirportStats)(nil), assert.AnError)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			r := NewHandler(mockSvc, &config.Config{}).Router()
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/stats", nil))
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String(), "JSON body should match")
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDashboard(t *testing.T) {
+	tests := []struct {
+		name         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name: "success",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("Dashboard", mock.Anything).Return(&domain.Dashboard{
+					TotalAirports:    3,
+					ByFlightCategory: map[string]int{"VFR": 2, "IFR": 1},
+					States: []domain.DashboardState{
+						{State: "TX", Airports: 3, ByFlightCategory: map[string]int{"VFR": 2, "IFR": 1}},
+					},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Dashboard is Fetched","data":{"total_airports":3,"by_flight_category":{"VFR":2,"IFR":1},"states":[{"state":"TX","airports":3,"by_flight_category":{"VFR":2,"IFR":1}}]}}`,
+		},
+		{
+			name: "service error",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("Dashboard", mock.Anything).Return((*domain.Dashboard)(nil), assert.AnError)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			r := NewHandler(mockSvc, &config.Config{}).Router()
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/dashboard", nil))
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String(), "JSON body should match")
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDistinctValues(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name: "states",
+			path: "/v1/airports/states",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetStates").Return([]string{"CA", "TX"}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"States are Fetched","data":["CA","TX"]}`,
+		},
+		{
+			name: "cities of a state",
+			path: "/v1/airports/cities?state=CA",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetCities", "CA").Return([]string{"Los Angeles", "San Diego"}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Cities are Fetched","data":["Los Angeles","San Diego"]}`,
+		},
+		{
+			name: "every city",
+			path: "/v1/airports/cities",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetCities", "").Return([]string{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Cities are Fetched","data":[]}`,
+		},
+		{
+			name: "service error",
+			path: "/v1/airports/states",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetStates").Return([]string(nil), assert.AnError)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			r := NewHandler(mockSvc, &config.Config{}).Router()
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String(), "JSON body should match")
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("CacheStats").Return(domain.CacheStats{Enabled: true, Hits: 3, Misses: 1, Entries: 1, HitRatio: 0.75})
+	h := NewHandler(mockSvc, &config.Config{})
+	r := h.Router()
+
+	req := httptest.NewRequest("GET", "/v1/cache/stats", nil)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "HTTP status code should be 200")
+	assert.JSONEq(t, `{"status":"OK","message":"Cache Stats are Fetched","data":{"enabled":true,"hits":3,"misses":1,"entries":1,"hit_ratio":0.75}}`, rec.Body.String(), "JSON body should match")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	cfg := &config.Config{DBHost: "localhost", DBPassword: "postgres", LogLevel: "info", AdminToken: "admin-token"}
+	h := NewHandler(&mocks.ServiceMock{}, cfg)
+	h.ApplyConfig(cfg.WithRuntime(&config.Config{LogLevel: "warn"}))
+	r := h.Router()
+
+	req := httptest.NewRequest("GET", "/v1/admin/config", nil)
+	req.Header.Set("Authorization", "Bearer admin-token")
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "HTTP status code should be 200")
+	var resp struct {
+		Data map[string]string `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "localhost", resp.Data["DBHost"])
+	assert.Equal(t, "[redacted]", resp.Data["DBPassword"])
+	assert.Equal(t, "warn", resp.Data["LogLevel"], "Reloaded settings are shown")
+}
+
+func TestAdminRoutes(t *testing.T) {
+	stats := domain.RuntimeStats{Goroutines: 42, SyncQueueDepth: 3, SyncWorkers: 4}
+
+	tests := []struct {
+		name         string
+		token        string
+		url          string
+		auth         string
+		expectedCode int
+	}{
+		{name: "hidden without a token", url: "/v1/admin/runtime", auth: "Bearer anything", expectedCode: http.StatusNotFound},
+		{name: "pprof hidden without a token", url: "/debug/pprof/", expectedCode: http.StatusNotFound},
+		{name: "missing credentials", token: "admin-token", url: "/v1/admin/runtime", expectedCode: http.StatusUnauthorized},
+		{name: "wrong token", token: "admin-token", url: "/debug/pprof/goroutine", auth: "Bearer guess", expectedCode: http.StatusUnauthorized},
+		{name: "runtime stats", token: "admin-token", url: "/v1/admin/runtime", auth: "Bearer admin-token", expectedCode: http.StatusOK},
+		{name: "pprof index", token: "admin-token", url: "/debug/pprof/", auth: "Bearer admin-token", expectedCode: http.StatusOK},
+		{name: "goroutine profile", token: "admin-token", url: "/debug/pprof/goroutine?debug=1", auth: "Bearer admin-token", expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			mockSvc.On("RuntimeStats").Return(stats).Maybe()
+			h := NewHandler(mockSvc, &config.Config{AdminToken: tt.token})
+			r := h.Router()
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedCode == http.StatusUnauthorized {
+				assert.Equal(t, `Bearer realm="admin"`, rec.Header().Get("WWW-Authenticate"))
+			}
+			if tt.url == "/v1/admin/runtime" && tt.expectedCode == http.StatusOK {
+				assert.JSONEq(t, `{"status":"OK","message":"Runtime Stats are Fetched","data":{"goroutines":42,"heap_alloc_bytes":0,"heap_objects":0,"num_gc":0,"sync_queue_depth":3,"sync_all_queue_depth":0,"syncs_in_flight":0,"sync_workers":4,"change_subscribers":0}}`, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestFrequencies(t *testing.T) {
+	frequencies := []domain.Frequency{{Type: "TWR", Description: "Tower", FrequencyMHz: 126.55}}
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "get",
+			method: http.MethodGet,
+			url:    "/v1/airport/TST/frequencies",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetFrequencies", "TST").Return(frequencies, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Frequencies are Fetched","data":[{"type":"TWR","description":"Tower","frequency_mhz":126.55}]}`,
+		},
+		{
+			name:   "get unknown airport",
+			method: http.MethodGet,
+			url:    "/v1/airport/NF/frequencies",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetFrequencies", "NF").Return([]domain.Frequency(nil), fmt.Errorf("%w: NF", domain.ErrNotFound))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`,
+		},
+		{
+			name:   "sync",
+			method: http.MethodPost,
+			url:    "/v1/sync/TST/frequencies",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("SyncFrequencies", mock.Anything, "TST").Return(frequencies, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Frequencies are Synced","data":[{"type":"TWR","description":"Tower","frequency_mhz":126.55}]}`,
+		},
+		{
+			name:   "sync upstream error",
+			method: http.MethodPost,
+			url:    "/v1/sync/TST/frequencies",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("SyncFrequencies", mock.Anything, "TST").Return([]domain.Frequency(nil), fmt.Errorf("%w: OurAirports down", domain.ErrExternalAPI))
+			},
+			expectedCode: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+			r := h.Router()
+
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			if tt.expectedJSON != "" {
+				assert.JSONEq(t, tt.expectedJSON, rec.Body.String(), "JSON body should match")
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRunwaysAndWindComponents(t *testing.T) {
+	runways := []domain.Runway{{Ident: "17", HeadingDeg: 170, LengthFt: 9000, WidthFt: 150, Surface: "ASP"}}
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "get runways",
+			method: http.MethodGet,
+			url:    "/v1/airport/TST/runways",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetRunways", "TST").Return(runways, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Runways are Fetched","data":[{"ident":"17","heading_deg":170,"length_ft":9000,"width_ft":150,"surface":"ASP"}]}`,
+		},
+		{
+			name:   "sync runways",
+			method: http.MethodPost,
+			url:    "/v1/sync/TST/runways",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("SyncRunways", mock.Anything, "TST").Return(runways, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Runways are Synced","data":[{"ident":"17","heading_deg":170,"length_ft":9000,"width_ft":150,"surface":"ASP"}]}`,
+		},
+		{
+			name:   "wind components",
+			method: http.MethodGet,
+			url:    "/v1/airport/TST/wind-components",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetWindComponents", "TST").Return(&domain.WindComponents{
+					Faa:         "TST",
+					WindDirDeg:  170,
+					WindSpeedKt: 10,
+					Recommended: "17",
+					Runways:     []domain.RunwayWind{{Runway: "17", HeadingDeg: 170, HeadwindKt: 10}},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Wind Components are Fetched","data":{"faa_ident":"TST","wind_dir_deg":170,"wind_speed_kt":10,"observed_at":"0001-01-01T00:00:00Z","recommended_runway":"17","runways":[{"runway":"17","heading_deg":170,"headwind_kt":10,"crosswind_kt":0}]}}`,
+		},
+		{
+			name:   "wind components without runways",
+			method: http.MethodGet,
+			url:    "/v1/airport/TST/wind-components",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetWindComponents", "TST").Return((*domain.WindComponents)(nil), fmt.Errorf("%w: no runways synced for TST", domain.ErrNoData))
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Data Not Available","error_code":"no_data","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+			r := h.Router()
+
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String(), "JSON body should match")
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetPerformance(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetPerformance", "DEN").Return(&domain.Performance{
+		Faa:                "DEN",
+		ElevationFt:        5434,
+		TemperatureC:       32,
+		AltimeterInHg:      30,
+		ISADeviationC:      27.6,
+		PressureAltitudeFt: 5354,
+		DensityAltitudeFt:  8633,
+	}, nil)
+	mockSvc.On("GetPerformance", "TST").Return((*domain.Performance)(nil), fmt.Errorf("%w: no elevation for TST", domain.ErrNoData))
+	h := NewHandler(mockSvc, &config.Config{})
+	r := h.Router()
+
+	req := httptest.NewRequest("GET", "/v1/airport/DEN/performance", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "HTTP status code should be 200")
+	assert.JSONEq(t, `{"status":"OK","message":"Performance is Fetched","data":{"faa_ident":"DEN","elevation_ft":5434,"temperature_c":32,"altimeter_inhg":30,"isa_deviation_c":27.6,"pressure_altitude_ft":5354,"density_altitude_ft":8633,"observed_at":"0001-01-01T00:00:00Z"}}`, rec.Body.String(), "JSON body should match")
+
+	req = httptest.NewRequest("GET", "/v1/airport/TST/performance", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code, "HTTP status code should be 404")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetAirportWeather(t *testing.T) {
+	syncedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	stored := &domain.AirportWeather{Faa: "DEN", Condition: "Clear", FlightCategory: "VFR", Observation: &domain.Observation{Condition: "Clear", VisibilitySM: 10, ObservedAt: syncedAt}, LastSyncedAt: &syncedAt}
+
+	tests := []struct {
+		name         string
+		query        string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:  "stored weather",
+			query: "",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportWeather", mock.Anything, "DEN", false).Return(stored, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":"OK","message":"Weather is Fetched","data":{"faa_ident":"DEN","weather":"Clear","flight_category":"VFR","observation":{"provider":"","condition":"Clear","temperature_c":0,"dewpoint_c":0,"humidity_pct":0,"wind_dir_deg":0,"wind_speed_kt":0,"wind_gust_kt":0,"visibility_sm":10,"pressure_hpa":0,"observed_at":"2024-06-01T12:00:00Z"},"last_synced_at":"2024-06-01T12:00:00Z"}}`,
+		},
+		{
+			name:  "refresh",
+			query: "?refresh=true",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportWeather", mock.Anything, "DEN", true).Return(&domain.AirportWeather{Faa: "DEN", Condition: "Fog"}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":"OK","message":"Weather is Fetched","data":{"faa_ident":"DEN","weather":"Fog","observation":null}}`,
+		},
+		{
+			name:         "invalid refresh",
+			query:        "?refresh=maybe",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":"Bad Request","message":"Invalid Refresh","data":null}`,
+		},
+		{
+			name:  "provider down",
+			query: "?refresh=1",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportWeather", mock.Anything, "DEN", true).Return((*domain.AirportWeather)(nil), fmt.Errorf("%w: failed to fetch weather for DEN: timeout", domain.ErrExternalAPI))
+			},
+			expectedCode: http.StatusBadGateway,
+			expectedBody: `{"status":"Error","message":"External API Error","error_code":"external_api_error","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			r := NewHandler(mockSvc, &config.Config{}).Router()
+
+			req := httptest.NewRequest("GET", "/v1/airport/DEN/weather"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetLiveWeather(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:  "city",
+			query: "?city=Denver",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetLiveWeather", mock.Anything, weather.Location{City: "Denver"}).Return(&domain.Observation{Provider: "weatherapi", Condition: "Sunny", VisibilitySM: 10}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":"OK","message":"Weather is Fetched","data":{"provider":"weatherapi","condition":"Sunny","temperature_c":0,"dewpoint_c":0,"humidity_pct":0,"wind_dir_deg":0,"wind_speed_kt":0,"wind_gust_kt":0,"visibility_sm":10,"pressure_hpa":0,"observed_at":"0001-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:  "coordinates",
+			query: "?lat=39.7392&lon=-104.9903",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetLiveWeather", mock.Anything, weather.Location{Latitude: 39.7392, Longitude: -104.9903, HasCoords: true}).Return(&domain.Observation{Condition: "Cloudy"}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":"OK","message":"Weather is Fetched","data":{"provider":"","condition":"Cloudy","temperature_c":0,"dewpoint_c":0,"humidity_pct":0,"wind_dir_deg":0,"wind_speed_kt":0,"wind_gust_kt":0,"visibility_sm":0,"pressure_hpa":0,"observed_at":"0001-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:  "imperial units",
+			query: "?city=Denver&units=imperial",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetLiveWeather", mock.Anything, weather.Location{City: "Denver"}).Return(&domain.Observation{Provider: "noaa", Condition: "Clear", TemperatureC: 20, DewpointC: -5, WindDirDeg: 270, WindSpeedKt: 10, VisibilitySM: 10, PressureHpa: 1013.2}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"status":"OK","message":"Weather is Fetched","data":{"provider":"noaa","condition":"Clear","temperature_f":68,"dewpoint_f":23,"humidity_pct":0,"wind_dir_deg":270,"wind_speed_mph":11.5,"wind_gust_mph":0,"visibility_sm":10,"pressure_inhg":29.92,"observed_at":"0001-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:         "unknown units",
+			query:        "?city=Denver&units=furlongs",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":"Bad Request","message":"Invalid Units","data":null}`,
+		},
+		{
+			name:         "missing location",
+			query:        "?lat=39.7392",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":"Bad Request","message":"Missing City or Lat and Lon","data":null}`,
+		},
+		{
+			name:         "city and coordinates",
+			query:        "?city=Denver&lat=39.7392&lon=-104.9903",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":"Bad Request","message":"Either City or Lat and Lon","data":null}`,
+		},
+		{
+			name:         "latitude out of range",
+			query:        "?lat=91&lon=0",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"status":"Bad Request","message":"Invalid Coordinates","data":null}`,
+		},
+		{
+			name:  "provider down",
+			query: "?city=Denver",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetLiveWeather", mock.Anything, mock.Anything).Return((*domain.Observation)(nil), fmt.Errorf("%w: failed to fetch weather for Denver: timeout", domain.ErrExternalAPI))
+			},
+			expectedCode: http.StatusBadGateway,
+			expectedBody: `{"status":"Error","message":"External API Error","error_code":"external_api_error","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			r := NewHandler(mockSvc, &config.Config{}).Router()
+
+			req := httptest.NewRequest("GET", "/v1/weather"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetDaylight(t *testing.T) {
+	chicago, _ := time.LoadLocation("America/Chicago")
+	sunrise := time.Date(2024, 6, 21, 6, 20, 0, 0, chicago)
+	sunset := time.Date(2024, 6, 21, 20, 39, 0, 0, chicago)
+
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetDaylight", "DFW", time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)).Return(&domain.Daylight{
+		Faa:             "DFW",
+		Timezone:        "America/Chicago",
+		Date:            "2024-06-21",
+		Sunrise:         &sunrise,
+		Sunset:          &sunset,
+		DaylightMinutes: 859,
+	}, nil)
+	h := NewHandler(mockSvc, &config.Config{})
+	r := h.Router()
+
+	req := httptest.NewRequest("GET", "/v1/airport/DFW/daylight?date=2024-06-21", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "HTTP status code should be 200")
+	assert.JSONEq(t, `{"status":"OK","message":"Daylight is Fetched","data":{"faa_ident":"DFW","timezone":"America/Chicago","date":"2024-06-21","sunrise":"2024-06-21T06:20:00-05:00","sunset":"2024-06-21T20:39:00-05:00","daylight_minutes":859}}`, rec.Body.String(), "JSON body should match")
+
+	req = httptest.NewRequest("GET", "/v1/airport/DFW/daylight?date=21-06-2024", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "HTTP status code should be 400")
+	assert.JSONEq(t, `{"status":"Bad Request","message":"Invalid Date","data":null}`, rec.Body.String(), "JSON body should match")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetRouteWeather(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetRouteWeather", "JFK", "LAX", 30.0).Return(&domain.RouteWeather{
+		From: "JFK", To: "LAX", DistanceNM: 2145.3, CourseDeg: 273.8, CorridorNM: 30,
+		Airports: []domain.RouteAirport{{Faa: "JFK", Icao: "KJFK", FacilityName: "John F Kennedy Intl", Condition: "Overcast", FlightCategory: "IFR"}},
+	}, nil)
+	mockSvc.On("GetRouteWeather", "JFK", "NOPE", 0.0).Return((*domain.RouteWeather)(nil), fmt.Errorf("%w: NOPE", domain.ErrNotFound))
+	h := NewHandler(mockSvc, &config.Config{})
+	r := h.Router()
+
+	tests := []struct {
+		url      string
+		code     int
+		expected string
+	}{
+		{"/v1/route/weather?from=JFK&to=LAX&corridor_nm=30", http.StatusOK, `{"status":"OK","message":"Route Weather is Fetched","data":{"from":"JFK","to":"LAX","distance_nm":2145.3,"course_deg":273.8,"corridor_nm":30,"airports":[{"faa_ident":"JFK","icao_ident":"KJFK","facility_name":"John F Kennedy Intl","along_track_nm":0,"offset_nm":0,"condition":"Overcast","flight_category":"IFR"}]}}`},
+		{"/v1/route/weather?from=JFK&to=NOPE", http.StatusNotFound, `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`},
+		{"/v1/route/weather?from=JFK", http.StatusBadRequest, `{"status":"Bad Request","message":"Missing From or To Parameter","data":null}`},
+		{"/v1/route/weather?from=JFK&to=LAX&corridor_nm=500", http.StatusBadRequest, `{"status":"Bad Request","message":"Invalid Corridor","data":null}`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.url, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, tt.code, rec.Code, tt.url)
+		assert.JSONEq(t, tt.expected, rec.Body.String(), tt.url)
+	}
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetAlternates(t *testing.T) {
+	observedAt := time.Date(2024, 6, 1, 12, 51, 0, 0, time.UTC)
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetAlternates", "JFK", 60.0, "MVFR").Return([]domain.Alternate{
+		{Faa: "EWR", Icao: "KEWR", FacilityName: "Newark Liberty Intl", DistanceNM: 18, BearingDeg: 285.3, Condition: "Mist", FlightCategory: "MVFR", ObservedAt: observedAt},
+	}, nil)
+	mockSvc.On("GetAlternates", "NC", 0.0, "").Return([]domain.Alternate(nil), fmt.Errorf("%w: no coordinates for NC", domain.ErrNoData))
+	h := NewHandler(mockSvc, &config.Config{})
+	r := h.Router()
+
+	tests := []struct {
+		url      string
+		code     int
+		expected string
+	}{
+		{"/v1/airport/JFK/alternates?radius_nm=60&category=mvfr", http.StatusOK, `{"status":"OK","message":"1 Alternates are Fetched","data":[{"faa_ident":"EWR","icao_ident":"KEWR","facility_name":"Newark Liberty Intl","distance_nm":18,"bearing_deg":285.3,"condition":"Mist","flight_category":"MVFR","observed_at":"2024-06-01T12:51:00Z"}]}`},
+		{"/v1/airport/NC/alternates", http.StatusNotFound, `{"status":"Error","message":"Data Not Available","error_code":"no_data","data":null}`},
+		{"/v1/airport/JFK/alternates?radius_nm=-5", http.StatusBadRequest, `{"status":"Bad Request","message":"Invalid Radius","data":null}`},
+		{"/v1/airport/JFK/alternates?category=CAVOK", http.StatusBadRequest, `{"status":"Bad Request","message":"Invalid Category","data":null}`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.url, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, tt.code, rec.Code, tt.url)
+		assert.JSONEq(t, tt.expected, rec.Body.String(), tt.url)
+	}
+	mockSvc.AssertExpectations(t)
+}
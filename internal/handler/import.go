@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+)
+
+const (
+	// Largest upload accepted, answered with 413 beyond
+	maxImportSize = 32 << 20
+	// Part of the upload held in memory, the rest going to temporary files
+	maxImportMemory = 8 << 20
+)
+
+// importRow is one parsed record before validation.
+type importRow struct {
+	line    int
+	airport AirportRequest
+	err     error
+}
+
+// importAirports: Imports airports from a multipart CSV or NDJSON file in a single transaction.
+func (h *Handler) importAirports(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(maxImportMemory); err != nil {
+		if bodyTooLarge(err) {
+			utils.EncodeErrorToUser(w, "Request Body Too Large", codeTooLarge, nil, http.StatusRequestEntityTooLarge)
+			return
+		}
+		log.Printf("importAirports: invalid multipart form: %v", err)
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Multipart Form", nil, http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.EncodeResponseToUser(w, "Bad Request", "Missing File", nil, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	var rows []importRow
+	if isNDJSON(header.Filename, header.Header.Get("Content-Type")) {
+		rows, err = parseNDJSON(file)
+	} else {
+		rows, err = parseCSV(file)
+	}
+	if err != nil {
+		log.Printf("importAirports: unreadable file: %v", err)
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid File", nil, http.StatusBadRequest)
+		return
+	}
+
+	report := domain.ImportReport{Errors: []domain.RowError{}}
+	var airports []domain.Airport
+	for _, row := range rows {
+		if row.err == nil {
+			row.err = validateAirportRow(&row.airport)
+		}
+		if row.err != nil {
+			report.Failed++
+			report.Errors = append(report.Errors, domain.RowError{Row: row.line, Faa: row.airport.Faa, Error: row.err.Error()})
+			continue
+		}
+		airports = append(airports, row.airport.toAirport())
+	}
+
+	if err := h.svc.ImportAirports(airports); err != nil {
+		log.Printf("importAirports: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+	report.Imported = len(airports)
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Airports are Imported", report.Imported), report)
+}
+
+func isNDJSON(filename, contentType string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return ext == ".ndjson" || ext == ".jsonl" || strings.HasPrefix(contentType, "application/x-ndjson")
+}
+
+// parseCSV reads a CSV whose header row uses the JSON field names of AirportRequest.
+func parseCSV(file io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1 // Short rows leave trailing fields empty
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("failed to read CSV: %w", err)
+			}
+			rows = append(rows, importRow{line: parseErr.Line, err: err})
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		// Round-trip through JSON so columns map onto the struct's JSON tags
+		fields := make(map[string]string, len(header))
+		for i, name := range header {
+			if i < len(record) {
+				fields[name] = strings.TrimSpace(record[i])
+			}
+		}
+		data, _ := json.Marshal(fields)
+
+		row := importRow{line: line}
+		if err := json.Unmarshal(data, &row.airport); err != nil {
+			row.err = fmt.Errorf("invalid row: %w", err)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseNDJSON reads one JSON airport object per line.
+func parseNDJSON(file io.Reader) ([]importRow, error) {
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+	var rows []importRow
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		row := importRow{line: line}
+		if err := json.Unmarshal(text, &row.airport); err != nil {
+			row.err = fmt.Errorf("invalid JSON: %w", err)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read NDJSON: %w", err)
+	}
+
+	return rows, nil
+}
+
+// validateAirportRow validates one entry of a bulk payload.
+func validateAirportRow(req *AirportRequest) error {
+	if errs := req.Validate(); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
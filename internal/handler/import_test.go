@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func multipartFile(t *testing.T, filename, content string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	part.Write([]byte(content))
+	assert.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestImportAirports(t *testing.T) {
+	tests := []struct {
+		name         string
+		filename     string
+		content      string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:     "csv with a bad row",
+			filename: "airports.csv",
+			content:  "faa_ident,facility_name,city\nTST,Test Airport,Test City\n,No Code,Nowhere\n",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ImportAirports", []domain.Airport{
+					{Faa: "TST", FacilityName: "Test Airport", City: "Test City"},
+				}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Airports are Imported","data":{"imported":1,"failed":1,"errors":[{"row":3,"faa_ident":"","error":"faa_ident: is required"}]}}`,
+		},
+		{
+			name:     "ndjson",
+			filename: "airports.ndjson",
+			content:  "{\"faa_ident\":\"TST\",\"city\":\"Test City\"}\n{invalid}\n",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ImportAirports", []domain.Airport{{Faa: "TST", City: "Test City"}}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Airports are Imported","data":{"imported":1,"failed":1,"errors":[{"row":2,"faa_ident":"","error":"invalid JSON: invalid character 'i' looking for beginning of object key string"}]}}`,
+		},
+		{
+			name:     "service error",
+			filename: "airports.csv",
+			content:  "faa_ident\nTST\n",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ImportAirports", mock.Anything).Return(assert.AnError)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
+		},
+		{
+			name:     "empty csv",
+			filename: "airports.csv",
+			content:  "",
+			setupMock: func(m *mocks.ServiceMock) {
+				// No call expected
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid File","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+			r := h.Router()
+
+			body, contentType := multipartFile(t, tt.filename, tt.content)
+			req := httptest.NewRequest("POST", "/v1/airports/import", body)
+			req.Header.Set("Content-Type", contentType)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String(), "JSON body should match")
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportAirportsMissingFile(t *testing.T) {
+	h := NewHandler(&mocks.ServiceMock{}, &config.Config{})
+	r := h.Router()
+
+	req := httptest.NewRequest("POST", "/v1/airports/import", nil)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "HTTP status code should be 400")
+	assert.JSONEq(t, `{"status":"Bad Request","message":"Invalid Multipart Form","data":null}`, rec.Body.String())
+}
+
+func TestImportAirportsTooLarge(t *testing.T) {
+	h := NewHandler(&mocks.ServiceMock{}, &config.Config{})
+	r := h.Router()
+
+	body, contentType := multipartFile(t, "airports.csv", "faa_ident\n"+strings.Repeat("TST\n", maxImportSize/4))
+	// Hide the length so the body is cut off while read rather than refused upfront
+	req := httptest.NewRequest("POST", "/v1/airports/import", io.MultiReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, "HTTP status code should be 413")
+	assert.JSONEq(t, `{"status":"Error","message":"Request Body Too Large","data":null,"error_code":"body_too_large"}`, rec.Body.String())
+}
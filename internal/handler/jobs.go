@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// getJobs: Lists the scheduler jobs with the definitions in effect.
+func (h *Handler) getJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.svc.GetJobs()
+	if err != nil {
+		log.Printf("getJobs: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Jobs are Fetched", len(jobs)), jobs)
+}
+
+// getQueuedJobs: Lists the jobs of the job queue, such as the syncs requested over the API.
+func (h *Handler) getQueuedJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.svc.GetQueuedJobs()
+	if err != nil {
+		log.Printf("getQueuedJobs: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Queued Jobs are Fetched", len(jobs)), jobs)
+}
+
+// runJob: Asks the scheduler to run a job at its next refresh.
+func (h *Handler) runJob(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if err := h.svc.RunJob(name); err != nil {
+		log.Printf("runJob: service error for %s: %v", name, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Job Run is Requested", name)
+}
+
+func (h *Handler) pauseJob(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if err := h.svc.PauseJob(name); err != nil {
+		log.Printf("pauseJob: service error for %s: %v", name, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Job is Paused", name)
+}
+
+func (h *Handler) resumeJob(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if err := h.svc.ResumeJob(name); err != nil {
+		log.Printf("resumeJob: service error for %s: %v", name, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Job is Resumed", name)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobAdminEndpoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		token        string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "list jobs",
+			method: "GET",
+			url:    "/v1/admin/jobs",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetJobs").Return([]domain.SchedulerJob{{Name: "sync_all", Schedule: "0 0,12 * * *", Enabled: true}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Jobs are Fetched","data":[{"name":"sync_all","schedule":"0 0,12 * * *","enabled":true}]}`,
+		},
+		{
+			name:   "list queued jobs",
+			method: "GET",
+			url:    "/v1/admin/jobs/queue",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetQueuedJobs").Return([]domain.QueuedJob{{
+					ID: 4, Type: domain.QueuedSyncAirport, Payload: json.RawMessage(`{"faa":"DEN"}`), Status: domain.JobStatusQueued,
+					Attempts: 1, MaxAttempts: 3, RunAt: time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC), LastError: "weather down",
+				}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Queued Jobs are Fetched","data":[{"id":4,"type":"sync_airport","payload":{"faa":"DEN"},"status":"queued","attempts":1,"max_attempts":3,"run_at":"2025-01-02T12:00:00Z","last_error":"weather down"}]}`,
+		},
+		{
+			name:   "run job",
+			method: "POST",
+			url:    "/v1/admin/jobs/sync_all/run",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("RunJob", "sync_all").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Job Run is Requested","data":"sync_all"}`,
+		},
+		{
+			name:   "pause job",
+			method: "POST",
+			url:    "/v1/admin/jobs/sync_all/pause",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("PauseJob", "sync_all").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Job is Paused","data":"sync_all"}`,
+		},
+		{
+			name:   "resume unknown job",
+			method: "POST",
+			url:    "/v1/admin/jobs/refresh_pireps/resume",
+			token:  "admin-token",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ResumeJob", "refresh_pireps").Return(domain.ErrJobNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Job Not Found","error_code":"not_found","data":null}`,
+		},
+		{
+			name:         "without admin token",
+			method:       "POST",
+			url:          "/v1/admin/jobs/sync_all/run",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{AdminToken: "admin-token"})
+
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedJSON != "" {
+				assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// StatusChangeRequest is the body of PATCH /airport/{faa}/status.
+type StatusChangeRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// Validate checks the status is one of the lifecycle statuses.
+func (req StatusChangeRequest) Validate() domain.ValidationErrors {
+	if domain.IsLifecycleStatus(req.Status) {
+		return nil
+	}
+	return domain.ValidationErrors{{
+		Field: "status",
+		Error: fmt.Sprintf("must be one of %s", strings.Join([]string{
+			domain.LifecycleActive, domain.LifecycleClosed, domain.LifecycleSeasonal, domain.LifecycleDecommissioned,
+		}, ", ")),
+	}}
+}
+
+// changeAirportStatus: Moves an airport to another lifecycle status, if
+// allowed from its current one.
+func (h *Handler) changeAirportStatus(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	var req StatusChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("changeAirportStatus: invalid JSON: %v", err)
+		respondInvalidJSON(w, err)
+		return
+	}
+	if errs := req.Validate(); len(errs) > 0 {
+		log.Printf("changeAirportStatus: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	change, err := h.svc.ChangeAirportStatus(faa, req.Status, req.Reason)
+	if err != nil {
+		log.Printf("changeAirportStatus: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Airport Status is Changed", change)
+}
+
+// getAirportStatusHistory: Lists the lifecycle status changes of an airport,
+// oldest first.
+func (h *Handler) getAirportStatusHistory(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	history, err := h.svc.GetAirportStatusHistory(faa)
+	if err != nil {
+		log.Printf("getAirportStatusHistory: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Status Changes are Fetched", len(history)), history)
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAirportStatusEndpoints(t *testing.T) {
+	changedAt := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	change := domain.AirportStatusChange{ID: 7, Faa: "TST", FromStatus: "active", ToStatus: "closed", Reason: "Runway works", ChangedAt: &changedAt}
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		body         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "change status",
+			method: "PATCH",
+			url:    "/v1/airport/TST/status",
+			body:   `{"status":"closed","reason":"Runway works"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ChangeAirportStatus", "TST", "closed", "Runway works").Return(&change, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Airport Status is Changed","data":{"id":7,"faa_ident":"TST","from_status":"active","to_status":"closed","reason":"Runway works","changed_at":"2025-01-02T12:00:00Z"}}`,
+		},
+		{
+			name:         "unknown status",
+			method:       "PATCH",
+			url:          "/v1/airport/TST/status",
+			body:         `{"status":"open"}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"status","error":"must be one of active, closed, seasonal, decommissioned"}]}`,
+		},
+		{
+			name:         "invalid JSON",
+			method:       "PATCH",
+			url:          "/v1/airport/TST/status",
+			body:         `{`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "transition not allowed",
+			method: "PATCH",
+			url:    "/v1/airport/TST/status",
+			body:   `{"status":"active"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ChangeAirportStatus", "TST", "active", "").Return((*domain.AirportStatusChange)(nil), domain.ErrInvalidTransition)
+			},
+			expectedCode: http.StatusConflict,
+			expectedJSON: `{"status":"Error","message":"Invalid Status Transition","error_code":"invalid_transition","data":null}`,
+		},
+		{
+			name:   "unknown airport",
+			method: "PATCH",
+			url:    "/v1/airport/NOPE/status",
+			body:   `{"status":"closed"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ChangeAirportStatus", "NOPE", "closed", "").Return((*domain.AirportStatusChange)(nil), domain.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "status history",
+			method: "GET",
+			url:    "/v1/airport/TST/status/history",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportStatusHistory", "TST").Return([]domain.AirportStatusChange{change}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Status Changes are Fetched","data":[{"id":7,"faa_ident":"TST","from_status":"active","to_status":"closed","reason":"Runway works","changed_at":"2025-01-02T12:00:00Z"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedJSON != "" {
+				assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+)
+
+// parseMETAR: Decodes a raw METAR or SPECI, taking its day of month as the latest one before now.
+func (h *Handler) parseMETAR(w http.ResponseWriter, r *http.Request) {
+	var req domain.ParseMETARRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("parseMETAR: invalid JSON: %v", err)
+		respondInvalidJSON(w, err)
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	metar, err := domain.ParseMETAR(req.Raw, time.Now())
+	if err != nil {
+		respondValidationErrors(w, domain.ValidationErrors{{Field: "raw", Error: err.Error()}})
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "METAR is Parsed", inUnits(r, metar))
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"aviation-weather/config"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMETAR(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		body         string
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:         "decodes report",
+			body:         `{"raw":"METAR KDEN 12/M04 A3001 RMK AO2"}`,
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"METAR is Parsed","data":{"raw":"METAR KDEN 12/M04 A3001 RMK AO2","type":"METAR","station":"KDEN","temperature_c":12,"dewpoint_c":-4,"altimeter_inhg":30.01,"altimeter_hpa":1016.3,"remarks":"AO2"}}`,
+		},
+		{
+			name:         "metric units",
+			url:          "/v1/parse/metar?units=metric",
+			body:         `{"raw":"METAR KDEN 27010KT 10SM 12/M04 A3001"}`,
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"METAR is Parsed","data":{"raw":"METAR KDEN 27010KT 10SM 12/M04 A3001","type":"METAR","station":"KDEN","wind":{"direction_deg":270,"speed_mps":5.1},"visibility_km":16.09,"temperature_c":12,"dewpoint_c":-4,"altimeter_hpa":1016.3}}`,
+		},
+		{
+			name:         "invalid JSON",
+			body:         `{"raw":`,
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid JSON","data":null}`,
+		},
+		{
+			name:         "missing raw",
+			body:         `{}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"raw","error":"is required"}]}`,
+		},
+		{
+			name:         "no station",
+			body:         `{"raw":"METAR 12/M04"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"raw","error":"invalid METAR: no station in \"METAR 12/M04\""}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewHandler(&mocks.ServiceMock{}, &config.Config{}).Router()
+
+			rec := httptest.NewRecorder()
+			url := tt.url
+			if url == "" {
+				url = "/v1/parse/metar"
+			}
+			r.ServeHTTP(rec, httptest.NewRequest("POST", url, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"aviation-weather/internal/utils"
+)
+
+// breakerStateValues encodes breaker states as gauge values.
+var breakerStateValues = map[string]int{
+	string(utils.BreakerClosed):   0,
+	string(utils.BreakerHalfOpen): 1,
+	string(utils.BreakerOpen):     2,
+}
+
+// metrics serves provider breaker state in the Prometheus text format.
+func (h *Handler) metrics(w http.ResponseWriter, r *http.Request) {
+	statuses := h.svc.ProviderStatuses()
+
+	var b strings.Builder
+	b.WriteString("# HELP aviation_weather_provider_breaker_state Circuit breaker state per provider (0 closed, 1 half-open, 2 open).\n")
+	b.WriteString("# TYPE aviation_weather_provider_breaker_state gauge\n")
+	for _, s := range statuses {
+		fmt.Fprintf(&b, "aviation_weather_provider_breaker_state{provider=%q} %d\n", s.Provider, breakerStateValues[s.State])
+	}
+	b.WriteString("# HELP aviation_weather_provider_consecutive_failures Consecutive failed calls per provider.\n")
+	b.WriteString("# TYPE aviation_weather_provider_consecutive_failures gauge\n")
+	for _, s := range statuses {
+		fmt.Fprintf(&b, "aviation_weather_provider_consecutive_failures{provider=%q} %d\n", s.Provider, s.Failures)
+	}
+	b.WriteString("# HELP aviation_weather_provider_breaker_trips_total Times the circuit breaker opened per provider.\n")
+	b.WriteString("# TYPE aviation_weather_provider_breaker_trips_total counter\n")
+	for _, s := range statuses {
+		fmt.Fprintf(&b, "aviation_weather_provider_breaker_trips_total{provider=%q} %d\n", s.Provider, s.Trips)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(b.String()))
+}
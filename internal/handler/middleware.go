@@ -0,0 +1,433 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"aviation-weather/internal/utils"
+
+	"github.com/andybalholm/brotli"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// clientLimiterTTL is how long an idle client's bucket is kept around.
+const clientLimiterTTL = 10 * time.Minute
+
+// keyCheckTTL is how long an API key's registration is trusted before it is
+// checked again, so a key revoked on another replica loses its bucket.
+const keyCheckTTL = time.Minute
+
+type clientLimiter struct {
+	limiter  *utils.RateLimiter
+	lastSeen time.Time
+}
+
+type keyCheck struct {
+	registered bool
+	checkedAt  time.Time
+}
+
+// clientRateLimiter keeps one token bucket per registered API key or client
+// IP. It lets every request through while rps is 0.
+type clientRateLimiter struct {
+	mu        sync.Mutex
+	rps       float64
+	burst     int
+	clients   map[string]*clientLimiter
+	keys      map[string]keyCheck
+	lastSweep time.Time
+
+	// registered reports whether an API key was issued and not revoked
+	registered func(key string) bool
+}
+
+func newClientRateLimiter(rps float64, burst int, registered func(key string) bool) *clientRateLimiter {
+	return &clientRateLimiter{
+		rps:        rps,
+		burst:      burst,
+		clients:    make(map[string]*clientLimiter),
+		keys:       make(map[string]keyCheck),
+		lastSweep:  time.Now(),
+		registered: registered,
+	}
+}
+
+// enabled reports whether requests are limited at all.
+func (c *clientRateLimiter) enabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rps > 0
+}
+
+// setLimit changes the budget of every client, dropping the current buckets.
+func (c *clientRateLimiter) setLimit(rps float64, burst int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rps, c.burst = rps, burst
+	clear(c.clients)
+}
+
+// get returns the bucket of key, or nil while limiting is disabled.
+func (c *clientRateLimiter) get(key string) *utils.RateLimiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rps <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	// Drop buckets of clients that went quiet so the map doesn't grow forever
+	if now.Sub(c.lastSweep) > clientLimiterTTL {
+		for k, cl := range c.clients {
+			if now.Sub(cl.lastSeen) > clientLimiterTTL {
+				delete(c.clients, k)
+			}
+		}
+		for k, check := range c.keys {
+			if now.Sub(check.checkedAt) > keyCheckTTL {
+				delete(c.keys, k)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	cl, ok := c.clients[key]
+	if !ok {
+		cl = &clientLimiter{limiter: utils.NewRateLimiter(c.rps, c.burst)}
+		c.clients[key] = cl
+	}
+	cl.lastSeen = now
+
+	return cl.limiter
+}
+
+// reserve takes a token from the bucket of the caller: its API key when the
+// key is registered, else its remote IP, so made-up keys share the bucket of
+// their IP. A key not known to be registered is only looked up once the IP's
+// bucket let the request through, so a flood of made-up keys is limited
+// before it reaches the database. Lookups are trusted for keyCheckTTL.
+func (c *clientRateLimiter) reserve(r *http.Request) (bool, time.Duration) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ipKey := "ip:" + host
+
+	key := r.Header.Get("X-API-Key")
+	if key == "" {
+		return c.take(ipKey)
+	}
+	c.mu.Lock()
+	check, checked := c.keys[key]
+	c.mu.Unlock()
+	fresh := checked && time.Since(check.checkedAt) <= keyCheckTTL
+
+	switch {
+	case fresh && check.registered:
+		return c.take("key:" + key)
+	case fresh:
+		return c.take(ipKey)
+	case check.registered:
+		// A key that was registered is checked again without charging its IP
+		if c.check(key) {
+			return c.take("key:" + key)
+		}
+		return c.take(ipKey)
+	}
+
+	ok, wait := c.take(ipKey)
+	if ok {
+		c.check(key)
+	}
+	return ok, wait
+}
+
+// check looks key up, dropping its bucket once it is no longer registered.
+func (c *clientRateLimiter) check(key string) bool {
+	registered := c.registered(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keys[key] = keyCheck{registered: registered, checkedAt: time.Now()}
+	if !registered {
+		delete(c.clients, "key:"+key)
+	}
+	return registered
+}
+
+// forgetKeys has every API key looked up again at its next request, as after
+// a key was revoked.
+func (c *clientRateLimiter) forgetKeys() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.keys)
+}
+
+// take reserves a token from the bucket of client, letting the request
+// through while limiting is disabled.
+func (c *clientRateLimiter) take(client string) (bool, time.Duration) {
+	limiter := c.get(client)
+	if limiter == nil {
+		return true, 0
+	}
+	return limiter.Reserve()
+}
+
+// rateLimit rejects clients exceeding their request budget with 429.
+func (c *clientRateLimiter) rateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !c.enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if ok, wait := c.reserve(r); !ok {
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+			utils.EncodeResponseToUser(w, "Error", "Too Many Requests", nil, http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// trustedRealIP takes the client address from the headers of requests coming
+// from one of proxies. Others could name any address to get a fresh rate limit
+// bucket. With header empty the address is read from X-Forwarded-For, else
+// from header, which the proxies must overwrite rather than pass on.
+func trustedRealIP(proxies []netip.Prefix, header string) func(http.Handler) http.Handler {
+	trusted := func(ip netip.Addr) bool {
+		for _, p := range proxies {
+			if p.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	return func(next http.Handler) http.Handler {
+		if len(proxies) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if addr, err := netip.ParseAddrPort(r.RemoteAddr); err == nil && trusted(addr.Addr().Unmap()) {
+				var ip netip.Addr
+				if header != "" {
+					ip, _ = netip.ParseAddr(strings.TrimSpace(r.Header.Get(header)))
+				} else {
+					ip = forwardedFor(r.Header.Values("X-Forwarded-For"), trusted)
+				}
+				if ip.IsValid() {
+					r.RemoteAddr = ip.Unmap().String()
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedFor returns the client of an X-Forwarded-For chain. Each proxy
+// appends the address it got the request from, so the chain is read from the
+// right, skipping trusted proxies: entries left of the first untrusted one may
+// be forged by the client. It returns the zero Addr for an unparsable entry.
+func forwardedFor(values []string, trusted func(netip.Addr) bool) netip.Addr {
+	var hops []string
+	for _, v := range values {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			return netip.Addr{}
+		}
+		if ip = ip.Unmap(); i == 0 || !trusted(ip) {
+			return ip
+		}
+	}
+	return netip.Addr{}
+}
+
+// cors answers preflight requests and sets CORS headers for allowed origins.
+func cors(origins, methods, headers []string) func(http.Handler) http.Handler {
+	allowAll := slices.Contains(origins, "*")
+	allowMethods := strings.Join(methods, ", ")
+	allowHeaders := strings.Join(headers, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+			if !allowAll && !slices.Contains(origins, origin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+
+			// Preflight
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+				w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+				w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+				w.Header().Set("Access-Control-Max-Age", "600")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// deprecated marks responses of legacy routes and points to their successor under prefix.
+func deprecated(prefix string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Link", fmt.Sprintf("<%s%s>; rel=\"successor-version\"", prefix, r.URL.Path))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// adminOnly lets through requests bearing token. Without a token the routes
+// are hidden behind 404, so they are never left open by accident.
+func adminOnly(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				utils.EncodeErrorToUser(w, "Not Found", codeNotFound, nil, http.StatusNotFound)
+				return
+			}
+			bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+				utils.EncodeErrorToUser(w, "Unauthorized", codeUnauthorized, nil, http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// maxBodyBytes rejects request bodies over limit with 413, either up front
+// from Content-Length or once the handler reads past it. 0 disables.
+func maxBodyBytes(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limit <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				utils.EncodeErrorToUser(w, "Request Body Too Large", codeTooLarge, nil, http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestTimeout puts a deadline of timeout on the request context. Handlers
+// passing it on stop once it runs out and answer 408 through
+// respondServiceError; one that returns past it without answering gets the
+// 408 here. 0 disables.
+func requestTimeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			tw := &trackingWriter{ResponseWriter: w}
+			next.ServeHTTP(tw, r.WithContext(ctx))
+			if !tw.wrote && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				utils.EncodeErrorToUser(w, "Request Timeout", codeTimeout, nil, http.StatusRequestTimeout)
+			}
+		})
+	}
+}
+
+// trackingWriter records whether a response was started.
+type trackingWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (t *trackingWriter) WriteHeader(status int) {
+	t.wrote = true
+	t.ResponseWriter.WriteHeader(status)
+}
+
+func (t *trackingWriter) Write(b []byte) (int, error) {
+	t.wrote = true
+	return t.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (t *trackingWriter) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
+
+// negotiateXML answers in XML when the Accept header prefers it, for the
+// dispatch systems that only read XML.
+func negotiateXML(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+		if utils.PrefersXML(r) {
+			w.Header().Set("Content-Type", utils.ContentTypeXML)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// compressedTypes are the content types worth compressing. Event streams are
+// left out so each event reaches the client as soon as it is flushed.
+var compressedTypes = []string{
+	"application/json",
+	"application/xml",
+	"text/csv",
+	"text/html",
+	"text/plain",
+}
+
+// compress encodes responses with brotli or gzip, whichever the client
+// prefers, at level. 0 disables.
+func compress(level int) func(http.Handler) http.Handler {
+	if level <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	compressor := middleware.NewCompressor(level, compressedTypes...)
+	compressor.SetEncoder("br", func(w io.Writer, level int) io.Writer {
+		return brotli.NewWriterLevel(w, level)
+	})
+	return compressor.Handler
+}
+
+// keepWriting lifts the server's write timeout for a response that streams as
+// long as the client listens or runs as long as a whole-table job.
+func keepWriting(w http.ResponseWriter) {
+	// Writers without deadlines have no timeout to lift
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+}
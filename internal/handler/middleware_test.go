@@ -0,0 +1,375 @@
+package handler
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRateLimit(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ProviderStatuses").Return([]domain.ProviderStatus{})
+	mockSvc.On("ResolveTenant", "other-client").Return(int64(2), nil).Once()
+	h := NewHandler(mockSvc, &config.Config{RateLimitRPS: 1, RateLimitBurst: 2})
+	r := h.Router()
+
+	doRequest := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/health", nil)
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// A registered API key is checked once, charged to its IP the first time,
+	// then has its own budget
+	assert.Equal(t, http.StatusOK, doRequest("other-client").Code)
+	assert.Equal(t, http.StatusOK, doRequest("other-client").Code)
+	assert.Equal(t, http.StatusOK, doRequest("other-client").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRequest("other-client").Code)
+
+	// The IP has one request of its burst of two left
+	assert.Equal(t, http.StatusOK, doRequest("").Code)
+	rec := doRequest("")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "HTTP status code should be 429")
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"), "Retry-After should be set")
+	assert.JSONEq(t, `{"status":"Error","message":"Too Many Requests","data":null}`, rec.Body.String(), "JSON body should match")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestRateLimitUnregisteredKeys(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ProviderStatuses").Return([]domain.ProviderStatus{})
+	mockSvc.On("ResolveTenant", mock.Anything).Return(int64(0), domain.ErrAPIKeyNotFound).Twice()
+	r := NewHandler(mockSvc, &config.Config{RateLimitRPS: 1, RateLimitBurst: 2}).Router()
+
+	doRequest := func(apiKey string) int {
+		req := httptest.NewRequest("GET", "/health", nil)
+		req.Header.Set("X-API-Key", apiKey)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Made-up keys share the bucket of their IP
+	assert.Equal(t, http.StatusOK, doRequest("random-1"))
+	assert.Equal(t, http.StatusOK, doRequest("random-2"))
+	assert.Equal(t, http.StatusTooManyRequests, doRequest("random-3"))
+	mockSvc.AssertExpectations(t)
+	mockSvc.AssertNotCalled(t, "ResolveTenant", "random-3")
+}
+
+func TestRateLimitForwardedFor(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ProviderStatuses").Return([]domain.ProviderStatus{})
+
+	doRequest := func(r http.Handler, remoteAddr, forwardedFor string) int {
+		req := httptest.NewRequest("GET", "/health", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Without trusted proxies the header is ignored
+	r := NewHandler(mockSvc, &config.Config{RateLimitRPS: 1, RateLimitBurst: 1}).Router()
+	assert.Equal(t, http.StatusOK, doRequest(r, "203.0.113.7:5555", "198.51.100.1"))
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(r, "203.0.113.7:5555", "198.51.100.2"), "Spoofed addresses share the peer's bucket")
+
+	// Behind a trusted proxy each forwarded client has its own bucket
+	r = NewHandler(mockSvc, &config.Config{RateLimitRPS: 1, RateLimitBurst: 1, TrustedProxies: []string{"10.0.0.0/8"}}).Router()
+	assert.Equal(t, http.StatusOK, doRequest(r, "10.1.2.3:5555", "198.51.100.1"))
+	assert.Equal(t, http.StatusOK, doRequest(r, "10.1.2.3:5555", "198.51.100.2"))
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(r, "10.1.2.3:5555", "198.51.100.2"))
+	assert.Equal(t, http.StatusOK, doRequest(r, "203.0.113.7:5555", "198.51.100.2"), "Untrusted peers are identified by their own address")
+	assert.Equal(t, http.StatusTooManyRequests, doRequest(r, "203.0.113.7:5555", "198.51.100.3"))
+}
+
+func TestTrustedRealIP(t *testing.T) {
+	proxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	tests := []struct {
+		name       string
+		header     string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{name: "forwarded client", remoteAddr: "10.1.2.3:5555", headers: map[string]string{"X-Forwarded-For": "198.51.100.1"}, expected: "198.51.100.1"},
+		{name: "forged entry left of the client", remoteAddr: "10.1.2.3:5555", headers: map[string]string{"X-Forwarded-For": "192.0.2.9, 198.51.100.1"}, expected: "198.51.100.1"},
+		{name: "trusted hops skipped", remoteAddr: "10.1.2.3:5555", headers: map[string]string{"X-Forwarded-For": "192.0.2.9, 198.51.100.1, 10.0.0.5"}, expected: "198.51.100.1"},
+		{name: "only trusted hops", remoteAddr: "10.1.2.3:5555", headers: map[string]string{"X-Forwarded-For": "10.0.0.7, 10.0.0.5"}, expected: "10.0.0.7"},
+		{name: "unparsable entry", remoteAddr: "10.1.2.3:5555", headers: map[string]string{"X-Forwarded-For": "198.51.100.1, unknown"}, expected: "10.1.2.3:5555"},
+		{name: "other headers ignored", remoteAddr: "10.1.2.3:5555", headers: map[string]string{"X-Real-IP": "198.51.100.7", "True-Client-IP": "198.51.100.8"}, expected: "10.1.2.3:5555"},
+		{name: "configured header", header: "X-Real-IP", remoteAddr: "10.1.2.3:5555", headers: map[string]string{"X-Real-IP": "198.51.100.7", "X-Forwarded-For": "198.51.100.1"}, expected: "198.51.100.7"},
+		{name: "untrusted peer", remoteAddr: "203.0.113.7:5555", headers: map[string]string{"X-Forwarded-For": "198.51.100.1"}, expected: "203.0.113.7:5555"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			h := trustedRealIP(proxies, tt.header)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			}))
+			req := httptest.NewRequest("GET", "/health", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestRateLimitReload(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ProviderStatuses").Return([]domain.ProviderStatus{})
+	h := NewHandler(mockSvc, &config.Config{})
+	r := h.Router()
+
+	doRequest := func() int {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest("GET", "/health", nil))
+		return rec.Code
+	}
+
+	// Disabled until a reload sets a budget
+	assert.Equal(t, http.StatusOK, doRequest())
+	assert.Equal(t, http.StatusOK, doRequest())
+
+	h.ApplyConfig(&config.Config{RateLimitRPS: 1, RateLimitBurst: 1})
+	assert.Equal(t, http.StatusOK, doRequest())
+	assert.Equal(t, http.StatusTooManyRequests, doRequest())
+
+	h.ApplyConfig(&config.Config{})
+	assert.Equal(t, http.StatusOK, doRequest(), "Setting the rate to 0 disables limiting again")
+}
+
+func TestReserve(t *testing.T) {
+	registered := map[string]bool{"abc": true}
+	var lookups []string
+	c := newClientRateLimiter(0.001, 1, func(key string) bool {
+		lookups = append(lookups, key)
+		return registered[key]
+	})
+	reserve := func(remoteAddr, key string) bool {
+		req := httptest.NewRequest("GET", "/health", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-API-Key", key)
+		ok, _ := c.reserve(req)
+		return ok
+	}
+
+	assert.True(t, reserve("10.0.0.1:5555", "abc"), "The first request of a key is charged to its IP")
+	assert.True(t, reserve("10.0.0.1:5555", "abc"), "A registered key has its own bucket")
+	assert.False(t, reserve("10.0.0.1:5555", "abc"))
+	assert.False(t, reserve("10.0.0.1:5555", "made-up"), "Unchecked keys wait for their IP's bucket")
+	assert.Equal(t, []string{"abc"}, lookups)
+
+	assert.True(t, reserve("10.0.0.2:5555", "made-up"))
+	assert.False(t, reserve("10.0.0.2:5555", "made-up"), "Unregistered keys share the bucket of their IP")
+	assert.Equal(t, []string{"abc", "made-up"}, lookups)
+
+	// A revoked key loses its bucket once checked again
+	delete(registered, "abc")
+	c.mu.Lock()
+	c.keys["abc"] = keyCheck{registered: true, checkedAt: time.Now().Add(-2 * keyCheckTTL)}
+	c.mu.Unlock()
+	assert.True(t, reserve("10.0.0.3:5555", "abc"))
+	assert.Equal(t, []string{"abc", "made-up", "abc"}, lookups)
+	assert.NotContains(t, c.clients, "key:abc")
+	assert.False(t, reserve("10.0.0.3:5555", "abc"), "The revoked key shares the bucket of its IP")
+
+	// Revoking a key has every key checked again
+	c.forgetKeys()
+	assert.False(t, reserve("10.0.0.1:5555", "abc"))
+	assert.True(t, reserve("10.0.0.4:5555", "abc"))
+	assert.Equal(t, []string{"abc", "made-up", "abc", "abc"}, lookups)
+}
+
+func TestCORS(t *testing.T) {
+	cfg := &config.Config{
+		CORSAllowedOrigins: []string{"https://app.example.com"},
+		CORSAllowedMethods: []string{"GET", "POST"},
+		CORSAllowedHeaders: []string{"Content-Type"},
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		origin        string
+		preflight     bool
+		expectedCode  int
+		expectedAllow string
+	}{
+		{
+			name:          "allowed origin",
+			method:        "GET",
+			origin:        "https://app.example.com",
+			expectedCode:  http.StatusOK,
+			expectedAllow: "https://app.example.com",
+		},
+		{
+			name:          "disallowed origin",
+			method:        "GET",
+			origin:        "https://evil.example.com",
+			expectedCode:  http.StatusOK,
+			expectedAllow: "",
+		},
+		{
+			name:          "preflight on any route",
+			method:        "OPTIONS",
+			origin:        "https://app.example.com",
+			preflight:     true,
+			expectedCode:  http.StatusNoContent,
+			expectedAllow: "https://app.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			mockSvc.On("ProviderStatuses").Return([]domain.ProviderStatus{})
+			h := NewHandler(mockSvc, cfg)
+			r := h.Router()
+
+			req := httptest.NewRequest(tt.method, "/health", nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", "POST")
+			}
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code, "HTTP status code should match")
+			assert.Equal(t, tt.expectedAllow, rec.Header().Get("Access-Control-Allow-Origin"), "Allowed origin should match")
+			if tt.preflight {
+				assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+				assert.Equal(t, "Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+			}
+		})
+	}
+}
+
+func TestMaxBodyBytes(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	r := NewHandler(mockSvc, &config.Config{MaxBodyBytes: 16}).Router()
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		body          string
+		contentLength int64
+	}{
+		{name: "declared length over the limit", method: "POST", path: "/v1/airport", body: `{"faa_ident":"TST","facility_name":"Test"}`, contentLength: 42},
+		{name: "chunked body over the limit", method: "PUT", path: "/v1/airport", body: `{"faa_ident":"TST","facility_name":"Test"}`, contentLength: -1},
+		{name: "chunked bulk body over the limit", method: "POST", path: "/v1/airports", body: `[{"faa_ident":"TST"},{"faa_ident":"DEN"}]`, contentLength: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.ContentLength = tt.contentLength
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, "HTTP status code should be 413")
+			assert.JSONEq(t, `{"status":"Error","message":"Request Body Too Large","data":null,"error_code":"body_too_large"}`, rec.Body.String(), "JSON body should match")
+		})
+	}
+	mockSvc.AssertNotCalled(t, "CreateAirport", mock.Anything)
+	mockSvc.AssertNotCalled(t, "UpdateAirport", mock.Anything)
+	mockSvc.AssertNotCalled(t, "CreateAirports", mock.Anything)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	t.Run("handler returning past the deadline", func(t *testing.T) {
+		h := requestTimeout(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/airports", nil))
+
+		assert.Equal(t, http.StatusRequestTimeout, rec.Code, "HTTP status code should be 408")
+		assert.JSONEq(t, `{"status":"Error","message":"Request Timeout","data":null,"error_code":"timeout"}`, rec.Body.String(), "JSON body should match")
+	})
+
+	t.Run("service giving up on the deadline", func(t *testing.T) {
+		mockSvc := &mocks.ServiceMock{}
+		mockSvc.On("SyncFrequencies", mock.Anything, "TST").Return([]domain.Frequency(nil), fmt.Errorf("failed to sync frequencies: %w", context.DeadlineExceeded))
+		r := NewHandler(mockSvc, &config.Config{RequestTimeout: time.Minute}).Router()
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest("POST", "/v1/sync/TST/frequencies", nil))
+		assert.Equal(t, http.StatusRequestTimeout, rec.Code, "HTTP status code should be 408")
+	})
+
+	t.Run("deadline reaches the handler", func(t *testing.T) {
+		var deadline bool
+		h := requestTimeout(time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, deadline = r.Context().Deadline()
+		}))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/airports", nil))
+		assert.True(t, deadline)
+	})
+}
+
+func TestCompress(t *testing.T) {
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("ProviderStatuses").Return([]domain.ProviderStatus{})
+	r := NewHandler(mockSvc, &config.Config{CompressionLevel: 5}).Router()
+
+	decoders := map[string]func(io.Reader) (io.Reader, error){
+		"gzip": func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		"br":   func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil },
+	}
+	for encoding, decode := range decoders {
+		t.Run(encoding, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/health", nil)
+			req.Header.Set("Accept-Encoding", encoding)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, encoding, rec.Header().Get("Content-Encoding"))
+			body, err := decode(rec.Body)
+			assert.NoError(t, err)
+			decoded, err := io.ReadAll(body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"status":"OK","message":"Aviation Weather API is Running","data":{"providers":[]}}`, string(decoded), "JSON body should match")
+		})
+	}
+
+	t.Run("event stream", func(t *testing.T) {
+		h := compress(5)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Write([]byte("event: ping\n\n"))
+		}))
+		req := httptest.NewRequest("GET", "/airports/changes", nil)
+		req.Header.Set("Accept-Encoding", "gzip, br")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Empty(t, rec.Header().Get("Content-Encoding"), "Events are sent as they are")
+		assert.Equal(t, "event: ping\n\n", rec.Body.String())
+	})
+}
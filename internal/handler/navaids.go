@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Caps of the navaid searches.
+const (
+	maxNavaidRadiusNM = 300
+	maxNavaidLimit    = 100
+)
+
+// getNearestNavaids: Lists the navaids nearest ?lat=&lon=, within ?radius_nm= and at most ?limit=.
+func (h *Handler) getNearestNavaids(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("lat") == "" || query.Get("lon") == "" {
+		utils.EncodeResponseToUser(w, "Bad Request", "Missing Lat and Lon", nil, http.StatusBadRequest)
+		return
+	}
+	lat, latErr := domain.ParseCoordinate(query.Get("lat"), 90)
+	lon, lonErr := domain.ParseCoordinate(query.Get("lon"), 180)
+	if latErr != nil || lonErr != nil {
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Coordinates", nil, http.StatusBadRequest)
+		return
+	}
+
+	radiusNM, ok := parseNavaidRadius(w, r)
+	if !ok {
+		return
+	}
+
+	var limit int
+	if value := query.Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 || limit > maxNavaidLimit {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Limit", nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	navaids, err := h.svc.GetNearestNavaids(lat, lon, radiusNM, limit)
+	if err != nil {
+		log.Printf("getNearestNavaids: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Navaids are Fetched", len(navaids)), navaids)
+}
+
+// getAirportNavaids: Lists the navaids within ?radius_nm= of an airport, nearest first.
+func (h *Handler) getAirportNavaids(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	radiusNM, ok := parseNavaidRadius(w, r)
+	if !ok {
+		return
+	}
+
+	navaids, err := h.svc.GetAirportNavaids(faa, radiusNM)
+	if err != nil {
+		log.Printf("getAirportNavaids: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Navaids are Fetched", len(navaids)), navaids)
+}
+
+// parseNavaidRadius reads ?radius_nm=, 0 when left out, answering 400 and
+// false when it is out of range.
+func parseNavaidRadius(w http.ResponseWriter, r *http.Request) (float64, bool) {
+	value := r.URL.Query().Get("radius_nm")
+	if value == "" {
+		return 0, true
+	}
+	radiusNM, err := strconv.ParseFloat(value, 64)
+	if err != nil || radiusNM <= 0 || radiusNM > maxNavaidRadiusNM {
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Radius", nil, http.StatusBadRequest)
+		return 0, false
+	}
+	return radiusNM, true
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNavaids(t *testing.T) {
+	freq := 115.9
+	cri := domain.NearbyNavaid{
+		Navaid:     domain.Navaid{Ident: "CRI", Type: "VOR", Name: "CANARSIE", City: "NEW YORK", StateCode: "NY", Frequency: &freq, Latitude: 40.6124, Longitude: -73.8242, Status: "OPERATIONAL IFR"},
+		DistanceNM: 2.5,
+	}
+	criJSON := `{"ident":"CRI","type":"VOR","name":"CANARSIE","city":"NEW YORK","state":"NY","frequency":115.9,"latitude":40.6124,"longitude":-73.8242,"status":"OPERATIONAL IFR","distance_nm":2.5}`
+
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetNearestNavaids", 40.64, -73.78, 0.0, 0).Return([]domain.NearbyNavaid{cri}, nil)
+	mockSvc.On("GetNearestNavaids", 40.64, -73.78, 25.0, 5).Return([]domain.NearbyNavaid{}, nil)
+	mockSvc.On("GetAirportNavaids", "JFK", 20.0).Return([]domain.NearbyNavaid{cri}, nil)
+	mockSvc.On("GetAirportNavaids", "ZZZ", 0.0).Return([]domain.NearbyNavaid(nil), fmt.Errorf("%w: ZZZ", domain.ErrNotFound))
+	h := NewHandler(mockSvc, &config.Config{})
+	r := h.Router()
+
+	tests := []struct {
+		url      string
+		code     int
+		expected string
+	}{
+		{"/v1/navaids/nearest?lat=40.64&lon=-73.78", http.StatusOK, `{"status":"OK","message":"1 Navaids are Fetched","data":[` + criJSON + `]}`},
+		{"/v1/navaids/nearest?lat=40.64&lon=-73.78&radius_nm=25&limit=5", http.StatusOK, `{"status":"OK","message":"0 Navaids are Fetched","data":[]}`},
+		{"/v1/navaids/nearest?lat=40.64", http.StatusBadRequest, `{"status":"Bad Request","message":"Missing Lat and Lon","data":null}`},
+		{"/v1/navaids/nearest?lat=95&lon=-73.78", http.StatusBadRequest, `{"status":"Bad Request","message":"Invalid Coordinates","data":null}`},
+		{"/v1/navaids/nearest?lat=40.64&lon=-73.78&radius_nm=500", http.StatusBadRequest, `{"status":"Bad Request","message":"Invalid Radius","data":null}`},
+		{"/v1/navaids/nearest?lat=40.64&lon=-73.78&limit=0", http.StatusBadRequest, `{"status":"Bad Request","message":"Invalid Limit","data":null}`},
+		{"/v1/airport/JFK/navaids?radius_nm=20", http.StatusOK, `{"status":"OK","message":"1 Navaids are Fetched","data":[` + criJSON + `]}`},
+		{"/v1/airport/ZZZ/navaids", http.StatusNotFound, `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`},
+		{"/v1/airport/JFK/navaids?radius_nm=abc", http.StatusBadRequest, `{"status":"Bad Request","message":"Invalid Radius","data":null}`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.url, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, tt.code, rec.Code, tt.url)
+		assert.JSONEq(t, tt.expected, rec.Body.String(), tt.url)
+	}
+	mockSvc.AssertExpectations(t)
+}
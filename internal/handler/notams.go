@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// getAirportNOTAMs: Lists the stored NOTAMs of an airport that have not ended.
+func (h *Handler) getAirportNOTAMs(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	notams, err := h.svc.GetAirportNOTAMs(faa)
+	if err != nil {
+		log.Printf("getAirportNOTAMs: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "NOTAMs are Fetched", notams)
+}
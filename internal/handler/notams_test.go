@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAirportNOTAMs(t *testing.T) {
+	closure := domain.NOTAM{ID: "NOTAM_1_73849637", Faa: "DEN", Number: "10/132", Type: "N", Text: "RWY 16R/34L CLSD"}
+
+	tests := []struct {
+		name         string
+		url          string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name: "airport NOTAMs",
+			url:  "/v1/airport/DEN/notams",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportNOTAMs", "DEN").Return([]domain.NOTAM{closure}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"NOTAMs are Fetched","data":[{"id":"NOTAM_1_73849637","faa_ident":"DEN","number":"10/132","type":"N","text":"RWY 16R/34L CLSD"}]}`,
+		},
+		{
+			name: "airport not found",
+			url:  "/v1/airport/ZZZ/notams",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportNOTAMs", "ZZZ").Return([]domain.NOTAM(nil), domain.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			r := NewHandler(mockSvc, &config.Config{}).Router()
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
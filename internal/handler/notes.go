@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// createAirportNote: Records an operational note on an airport, shown with it
+// until expires_at or until deleted.
+func (h *Handler) createAirportNote(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	var note domain.AirportNote
+	if err := json.NewDecoder(r.Body).Decode(&note); err != nil {
+		log.Printf("createAirportNote: invalid JSON: %v", err)
+		respondInvalidJSON(w, err)
+		return
+	}
+
+	if errs := note.Validate(); len(errs) > 0 {
+		log.Printf("createAirportNote: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	note.ID, note.Faa, note.CreatedAt = 0, faa, nil
+	if err := h.svc.CreateAirportNote(&note); err != nil {
+		log.Printf("createAirportNote: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Note is Created", note)
+}
+
+// getAirportNotes: Lists the notes of an airport that have not expired.
+func (h *Handler) getAirportNotes(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	notes, err := h.svc.GetAirportNotes(faa)
+	if err != nil {
+		log.Printf("getAirportNotes: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Notes are Fetched", len(notes)), notes)
+}
+
+func (h *Handler) deleteAirportNote(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+	id, ok := pathID(w, r, "Invalid Note ID")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteAirportNote(faa, id); err != nil {
+		log.Printf("deleteAirportNote: service error for %s %d: %v", faa, id, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Note is Deleted", id)
+}
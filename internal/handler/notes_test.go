@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAirportNoteEndpoints(t *testing.T) {
+	createdAt := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	nextWeek := time.Now().Add(7 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		body         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "create note",
+			method: "POST",
+			url:    "/v1/airport/TST/notes",
+			body:   fmt.Sprintf(`{"id":99,"faa_ident":"JFK","body":"Fuel unavailable this week","author":"Dispatch","expires_at":%q}`, nextWeek.Format(time.RFC3339)),
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateAirportNote", mock.MatchedBy(func(n *domain.AirportNote) bool {
+					return n.ID == 0 && n.Faa == "TST" && n.ExpiresAt.Equal(nextWeek)
+				})).Run(func(args mock.Arguments) {
+					n := args.Get(0).(*domain.AirportNote)
+					n.ID, n.CreatedAt = 5, &createdAt
+				}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: fmt.Sprintf(`{"status":"OK","message":"Note is Created","data":{"id":5,"faa_ident":"TST","body":"Fuel unavailable this week","author":"Dispatch","expires_at":%q,"created_at":"2025-01-02T12:00:00Z"}}`, nextWeek.Format(time.RFC3339)),
+		},
+		{
+			name:         "invalid note",
+			method:       "POST",
+			url:          "/v1/airport/TST/notes",
+			body:         `{"body":""}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"body","error":"is required"}]}`,
+		},
+		{
+			name:   "note on unknown airport",
+			method: "POST",
+			url:    "/v1/airport/NOPE/notes",
+			body:   `{"body":"Closed for snow"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateAirportNote", mock.Anything).Return(domain.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "list notes",
+			method: "GET",
+			url:    "/v1/airport/TST/notes",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAirportNotes", "TST").Return([]domain.AirportNote{{ID: 5, Faa: "TST", Body: "Fuel unavailable this week", CreatedAt: &createdAt}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Notes are Fetched","data":[{"id":5,"faa_ident":"TST","body":"Fuel unavailable this week","created_at":"2025-01-02T12:00:00Z"}]}`,
+		},
+		{
+			name:   "delete note",
+			method: "DELETE",
+			url:    "/v1/airport/TST/notes/5",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("DeleteAirportNote", "TST", int64(5)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Note is Deleted","data":5}`,
+		},
+		{
+			name:   "delete unknown note",
+			method: "DELETE",
+			url:    "/v1/airport/TST/notes/6",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("DeleteAirportNote", "TST", int64(6)).Return(domain.ErrNoteNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Note Not Found","error_code":"not_found","data":null}`,
+		},
+		{
+			name:         "delete invalid id",
+			method:       "DELETE",
+			url:          "/v1/airport/TST/notes/abc",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid Note ID","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedJSON != "" {
+				assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			}
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
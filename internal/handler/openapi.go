@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// apiOperation describes one documented endpoint. Request and Response are
+// sample values whose types are reflected into JSON schemas; Response is the
+// type of the "data" field of the response envelope.
+type apiOperation struct {
+	Method   string
+	Path     string
+	Summary  string
+	Query    []string
+	Request  any
+	Response any
+}
+
+// apiOperations lists the /v1 endpoints published in the OpenAPI document.
+var apiOperations = []apiOperation{
+	{Method: "get", Path: "/v1/health", Summary: "Health check with provider circuit breaker state", Response: domain.HealthStatus{}},
+	{Method: "get", Path: "/v1/health/live", Summary: "Liveness probe, OK while the process serves requests"},
+	{Method: "get", Path: "/v1/health/ready", Summary: "Readiness probe of the database and providers, 503 while one is down", Response: domain.Readiness{}},
+	{Method: "get", Path: "/v1/airports", Summary: "List all airports, those selling a fuel type (100LL, 100, UL94, MOGAS, JetA, JetA1, JetB), or the comma separated faa codes in one query, optionally sorted and with only the comma separated fields; coordinates=dms adds DD-MM-SS.sH coordinates", Query: []string{"faa", "fuel", "fields", "sort", "coordinates"}, Response: []AirportResponse{}},
+	{Method: "get", Path: "/v1/airports/states", Summary: "Distinct state codes of the stored airports", Response: []string{}},
+	{Method: "get", Path: "/v1/airports/cities", Summary: "Distinct cities of the stored airports, of one state when given", Query: []string{"state"}, Response: []string{}},
+	{Method: "post", Path: "/v1/airports", Summary: "Create airports in bulk", Request: []AirportRequest{}, Response: domain.BulkCreateReport{}},
+	{Method: "post", Path: "/v1/airports/import", Summary: "Import airports from a multipart CSV or NDJSON file field named \"file\"", Response: domain.ImportReport{}},
+	{Method: "get", Path: "/v1/airports/export", Summary: "Download every airport as CSV (text/csv, not the JSON envelope) in the column layout the import accepts, with ?format=kml as KML placemarks for Google Earth, or with ?format=xlsx as an Excel workbook of an Airports and a Weather sheet"},
+	{Method: "get", Path: "/v1/airports/stream", Summary: "Stream every airport as newline-delimited JSON (application/x-ndjson, not the JSON envelope), one object per line as read from the database"},
+	{Method: "get", Path: "/v1/airports/changes", Summary: "Server-sent event stream of airport inserts, updates and deletes by any writer; each event's data is one change", Response: domain.AirportChange{}},
+	{Method: "post", Path: "/v1/graphql", Summary: "GraphQL query over the airports (schema in api/graphql/schema.graphqls): airport(faa) and airports(state, city, category) with field selection, flight_category and nested weather_history(hours); answers the GraphQL {\"data\",\"errors\"} document, not the JSON envelope", Request: graphqlRequest{}},
+	{Method: "get", Path: "/v1/changes", Summary: "Airport inserts, updates and deletes after the ?since= cursor, oldest first, up to ?limit= (100, at most 1000), with the cursor to pass next time", Response: domain.ChangeFeed{}},
+	{Method: "get", Path: "/v1/airport/{faa}", Summary: "Get airport from database; coordinates=dms adds DD-MM-SS.sH coordinates", Query: []string{"coordinates"}, Response: AirportResponse{}},
+	{Method: "get", Path: "/v1/airport/{faa}/frequencies", Summary: "List stored COM frequencies (tower, ATIS, ground...) of an airport", Response: []domain.Frequency{}},
+	{Method: "get", Path: "/v1/airport/{faa}/runways", Summary: "List stored runway ends with true headings", Response: []domain.Runway{}},
+	{Method: "get", Path: "/v1/airport/{faa}/charts", Summary: "Airport diagram and approach plate PDF links, refreshed each AIRAC cycle by the sync_charts job", Response: []domain.Chart{}},
+	{Method: "get", Path: "/v1/airport/{faa}/wind-components", Summary: "Headwind and crosswind per runway for the last synced wind, with the recommended runway", Query: []string{"units"}, Response: domain.WindComponents{}},
+	{Method: "get", Path: "/v1/airport/{faa}/performance", Summary: "Pressure and density altitude for the last synced weather", Query: []string{"units"}, Response: domain.Performance{}},
+	{Method: "get", Path: "/v1/airport/{faa}/weather", Summary: "Weather of an airport, fetched live with refresh=true", Query: []string{"refresh", "units"}, Response: domain.AirportWeather{}},
+	{Method: "get", Path: "/v1/airport/{faa}/daylight", Summary: "Civil twilight, sunrise and sunset in local time for ?date=YYYY-MM-DD or today", Query: []string{"date"}, Response: domain.Daylight{}},
+	{Method: "get", Path: "/v1/airport/{faa}/alternates", Summary: "Airports within ?radius_nm= (default 100, max 300) reporting ?category= (default VFR) or better, nearest first", Query: []string{"radius_nm", "category"}, Response: []domain.Alternate{}},
+	{Method: "get", Path: "/v1/airport/{faa}/trend", Summary: "Whether pressure, visibility and ceiling improved or deteriorated over the last ?hours= (default 3, max 24), from least-squares slopes of the weather history", Query: []string{"hours"}, Response: domain.WeatherTrend{}},
+	{Method: "get", Path: "/v1/airport/{faa}/navaids", Summary: "VOR, NDB and DME stations within ?radius_nm= (default 40, max 300), nearest first", Query: []string{"radius_nm"}, Response: []domain.NearbyNavaid{}},
+	{Method: "get", Path: "/v1/airport/{faa}/advisories", Summary: "SIGMETs and AIRMETs in effect whose area contains the airport", Response: []domain.Advisory{}},
+	{Method: "get", Path: "/v1/airport/{faa}/notams", Summary: "The NOTAMs of an airport on a watchlist or subscription that have not ended, as of the last refresh_notams run", Response: []domain.NOTAM{}},
+	{Method: "get", Path: "/v1/airport/{faa}/forecast", Summary: "The prevailing and temporary TAF forecast periods covering ?at= (RFC 3339, default now)", Query: []string{"at", "units"}, Response: domain.TAFForecast{}},
+	{Method: "get", Path: "/v1/airport/{faa}/status/history", Summary: "The lifecycle status changes of an airport, oldest first", Response: []domain.AirportStatusChange{}},
+	{Method: "get", Path: "/v1/airport/{faa}/notes", Summary: "The operational notes of an airport that have not expired", Response: []domain.AirportNote{}},
+	{Method: "get", Path: "/v1/advisories", Summary: "SIGMETs and AIRMETs in effect", Response: []domain.Advisory{}},
+	{Method: "get", Path: "/v1/navaids/nearest", Summary: "VOR, NDB and DME stations nearest ?lat= and ?lon=, within ?radius_nm= (default 100, max 300), at most ?limit= (default 10, max 100)", Query: []string{"lat", "lon", "radius_nm", "limit"}, Response: []domain.NearbyNavaid{}},
+	{Method: "get", Path: "/v1/airac", Summary: "The AIRAC cycle in effect, the next one and the days until it", Response: domain.AIRACStatus{}},
+	{Method: "get", Path: "/v1/route/weather", Summary: "Airports within ?corridor_nm= (default 50, max 200) of the great-circle route ?from=&to=, with weather and flight category", Query: []string{"from", "to", "corridor_nm", "units"}, Response: domain.RouteWeather{}},
+	{Method: "get", Path: "/v1/weather", Summary: "Current weather at a city or at lat and lon, cached but not stored", Query: []string{"city", "lat", "lon", "units"}, Response: domain.Observation{}},
+	{Method: "post", Path: "/v1/parse/metar", Summary: "Decode a raw METAR or SPECI into wind, visibility, weather, clouds, temperature, altimeter and remarks", Query: []string{"units"}, Request: domain.ParseMETARRequest{}, Response: domain.METAR{}},
+	{Method: "get", Path: "/v1/alerts", Summary: "Alerts raised since ?since= (RFC 3339, default last 24h), newest first, for ?faa= or every airport, at most ?limit= (default 100)", Query: []string{"faa", "since", "limit"}, Response: []domain.Alert{}},
+	{Method: "get", Path: "/v1/alerts/rules", Summary: "List alert rules of the X-API-Key's tenant", Response: []domain.AlertRule{}},
+	{Method: "post", Path: "/v1/alerts/rules", Summary: "Create an alert rule evaluated after every sync", Request: domain.AlertRule{}, Response: domain.AlertRule{}},
+	{Method: "delete", Path: "/v1/alerts/rules/{id}", Summary: "Delete an alert rule and its alerts", Response: int64(0)},
+	{Method: "post", Path: "/v1/alerts/notifications/test", Summary: "Send a sample alert to an email address or Slack webhook (admin)", Request: domain.NotifyTarget{}, Response: domain.NotifyTarget{}},
+	{Method: "get", Path: "/v1/webhooks", Summary: "List webhooks of the X-API-Key's tenant, secrets left out", Response: []domain.Webhook{}},
+	{Method: "post", Path: "/v1/webhooks", Summary: "Subscribe a URL to weather.changed, sync.completed and alert.fired events", Request: domain.Webhook{}, Response: domain.Webhook{}},
+	{Method: "delete", Path: "/v1/webhooks/{id}", Summary: "Delete a webhook and its delivery log", Response: int64(0)},
+	{Method: "get", Path: "/v1/webhooks/{id}/deliveries", Summary: "Latest deliveries of a webhook, newest first, at most ?limit= (default 50)", Query: []string{"limit"}, Response: []domain.WebhookDelivery{}},
+	{Method: "get", Path: "/v1/watchlists", Summary: "Watchlists of the X-API-Key", Response: []domain.Watchlist{}},
+	{Method: "post", Path: "/v1/watchlists", Summary: "Save a named list of FAA codes for the X-API-Key; watched airports sync first", Request: domain.Watchlist{}, Response: domain.Watchlist{}},
+	{Method: "delete", Path: "/v1/watchlists/{id}", Summary: "Delete a watchlist of the X-API-Key", Response: int64(0)},
+	{Method: "get", Path: "/v1/watchlists/{id}/weather", Summary: "Current conditions at every airport of a watchlist, in its order", Query: []string{"units"}, Response: domain.WatchlistBoard{}},
+	{Method: "get", Path: "/v1/subscriptions", Summary: "Digest subscriptions of the X-API-Key, secrets left out", Response: []domain.Subscription{}},
+	{Method: "post", Path: "/v1/subscriptions", Summary: "Subscribe the X-API-Key to digests of some airports on DIGEST_SCHEDULE, by email or webhook", Request: domain.Subscription{}, Response: domain.Subscription{}},
+	{Method: "delete", Path: "/v1/subscriptions/{id}", Summary: "Delete a digest subscription of the X-API-Key", Response: int64(0)},
+	{Method: "post", Path: "/v1/airport", Summary: "Create airport", Request: AirportRequest{}, Response: AirportResponse{}},
+	{Method: "put", Path: "/v1/airport", Summary: "Update airport", Request: AirportRequest{}, Response: AirportResponse{}},
+	{Method: "patch", Path: "/v1/airport/{faa}/status", Summary: "Move an airport to another lifecycle status: active, closed, seasonal or decommissioned", Request: StatusChangeRequest{}, Response: domain.AirportStatusChange{}},
+	{Method: "put", Path: "/v1/airport/{faa}/fuel", Summary: "Set the fuel types and FBO contact of an airport by hand; syncs keep the fields changed", Request: FuelRequest{}, Response: AirportResponse{}},
+	{Method: "post", Path: "/v1/airport/{faa}/notes", Summary: "Record an operational note on an airport, shown with it until expires_at", Request: domain.AirportNote{}, Response: domain.AirportNote{}},
+	{Method: "delete", Path: "/v1/airport/{faa}/notes/{id}", Summary: "Delete a note of an airport", Response: ""},
+	{Method: "delete", Path: "/v1/airport/{faa}", Summary: "Delete airport", Response: ""},
+	{Method: "post", Path: "/v1/sync/retry-failed", Summary: "Sync again the airports whose last sync failed, once their retry backoff has passed"},
+	{Method: "post", Path: "/v1/sync/{faa}", Summary: "Sync single airport and report the changed fields", Response: domain.SyncResult{}},
+	{Method: "post", Path: "/v1/sync/{faa}/frequencies", Summary: "Refresh airport frequencies from OurAirports", Response: []domain.Frequency{}},
+	{Method: "post", Path: "/v1/sync/{faa}/runways", Summary: "Refresh airport runways from OurAirports", Response: []domain.Runway{}},
+	{Method: "get", Path: "/v1/stats", Summary: "Airport counts by state, ownership and flight category, and airports with stale weather", Response: domain.AirportStats{}},
+	{Method: "get", Path: "/v1/dashboard", Summary: "Airport counts by state and flight category as of the last sync", Response: domain.Dashboard{}},
+	{Method: "get", Path: "/v1/cache/stats", Summary: "Weather cache statistics", Response: domain.CacheStats{}},
+	{Method: "get", Path: "/v1/admin/config", Summary: "Effective config, including reloaded settings, with secrets redacted (admin)", Response: map[string]string{}},
+	{Method: "get", Path: "/v1/admin/runtime", Summary: "Goroutines, heap and sync queue depths (admin)", Response: domain.RuntimeStats{}},
+	{Method: "get", Path: "/v1/admin/tenants", Summary: "List tenants (admin)", Response: []domain.Tenant{}},
+	{Method: "post", Path: "/v1/admin/tenants", Summary: "Create a tenant (admin)", Request: domain.Tenant{}, Response: domain.Tenant{}},
+	{Method: "get", Path: "/v1/admin/tenants/{id}/keys", Summary: "List the API keys of a tenant, keys left out (admin)", Response: []domain.APIKey{}},
+	{Method: "post", Path: "/v1/admin/tenants/{id}/keys", Summary: "Issue an API key for a tenant, only shown in this response (admin)", Request: domain.APIKey{}, Response: domain.APIKey{}},
+	{Method: "delete", Path: "/v1/admin/keys/{id}", Summary: "Revoke an API key (admin)", Response: int64(0)},
+	{Method: "get", Path: "/v1/admin/jobs", Summary: "List the scheduler jobs and their schedules in effect (admin)", Response: []domain.SchedulerJob{}},
+	{Method: "get", Path: "/v1/admin/jobs/queue", Summary: "List the queued, running, and recently finished jobs of the job queue (admin)", Response: []domain.QueuedJob{}},
+	{Method: "post", Path: "/v1/admin/jobs/{name}/run", Summary: "Run a scheduler job at the scheduler's next refresh (admin)", Response: ""},
+	{Method: "post", Path: "/v1/admin/jobs/{name}/pause", Summary: "Pause a scheduler job (admin)", Response: ""},
+	{Method: "post", Path: "/v1/admin/jobs/{name}/resume", Summary: "Resume a paused scheduler job (admin)", Response: ""},
+	{Method: "get", Path: "/v1/admin/cache", Summary: "Hits, misses and entries of the weather, airports and provider caches (admin)", Response: []domain.CacheStats{}},
+	{Method: "delete", Path: "/v1/admin/cache/{name}", Summary: "Clear the weather, airports or provider cache (admin)", Response: ""},
+	{Method: "get", Path: "/v1/admin/conflicts", Summary: "List the provider values disagreeing with fields overridden by hand, pending unless ?status= is accepted, rejected or all (admin)", Query: []string{"status"}, Response: []domain.AirportConflict{}},
+	{Method: "post", Path: "/v1/admin/conflicts/{id}/accept", Summary: "Take the provider's value of a conflicting field and end its override (admin)", Response: domain.AirportConflict{}},
+	{Method: "post", Path: "/v1/admin/conflicts/{id}/reject", Summary: "Keep the local value of a conflicting field (admin)", Response: domain.AirportConflict{}},
+	{Method: "post", Path: "/v1/sync", Summary: "Sync all airports, or only those of ?state= or of a JSON array of FAA codes in the body", Query: []string{"state"}, Request: []string{}},
+}
+
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// openAPISpec builds the OpenAPI 3 document from apiOperations.
+func openAPISpec() map[string]any {
+	schemas := map[string]any{}
+	paths := map[string]any{}
+
+	for _, op := range apiOperations {
+		var params []any
+		for _, m := range pathParamPattern.FindAllStringSubmatch(op.Path, -1) {
+			params = append(params, map[string]any{
+				"name": m[1], "in": "path", "required": true, "schema": map[string]any{"type": "string"},
+			})
+		}
+		for _, q := range op.Query {
+			params = append(params, map[string]any{
+				"name": q, "in": "query", "required": false, "schema": map[string]any{"type": "string"},
+			})
+		}
+
+		dataSchema := map[string]any{"nullable": true}
+		if op.Response != nil {
+			dataSchema = schemaFor(reflect.TypeOf(op.Response), schemas)
+		}
+
+		operation := map[string]any{
+			"summary": op.Summary,
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content": map[string]any{
+						"application/json": map[string]any{
+							"schema": map[string]any{
+								"allOf": []any{
+									map[string]any{"$ref": "#/components/schemas/ApiResponse"},
+									map[string]any{"type": "object", "properties": map[string]any{"data": dataSchema}},
+								},
+							},
+						},
+					},
+				},
+				"default": map[string]any{
+					"description": "Error",
+					"content": map[string]any{
+						"application/json": map[string]any{
+							"schema": map[string]any{"$ref": "#/components/schemas/ApiResponse"},
+						},
+					},
+				},
+			},
+		}
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+		if op.Request != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(op.Request), schemas)},
+				},
+			}
+		}
+
+		item, ok := paths[op.Path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[op.Path] = item
+		}
+		item[op.Method] = operation
+	}
+
+	schemaFor(reflect.TypeOf(domain.ApiResponse{}), schemas)
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "Aviation Weather API",
+			"description": "Merge airport data with real-time weather in one API",
+			"version":     "1.0.0",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+// schemaFor converts a Go type into a JSON schema. Named structs are stored in
+// schemas and referenced, so they appear once under components.
+func schemaFor(t reflect.Type, schemas map[string]any) map[string]any {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	if t == reflect.TypeOf(coordinateInput{}) {
+		return map[string]any{"oneOf": []any{map[string]any{"type": "number"}, map[string]any{"type": "string"}}, "nullable": true}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := schemaFor(t.Elem(), schemas)
+		s["nullable"] = true
+		return s
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t.Name() != "" {
+			if _, ok := schemas[t.Name()]; !ok {
+				schemas[t.Name()] = map[string]any{} // placeholder guards against recursion
+				schemas[t.Name()] = structSchema(t, schemas)
+			}
+			return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+		}
+		return structSchema(t, schemas)
+	default:
+		return map[string]any{}
+	}
+}
+
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	properties := map[string]any{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			// Embedded structs are flattened into the JSON of their parent
+			for k, v := range structSchema(f.Type, schemas)["properties"].(map[string]any) {
+				properties[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = schemaFor(f.Type, schemas)
+	}
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+// openAPI serves the OpenAPI document.
+func (h *Handler) openAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(openAPISpec())
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Aviation Weather API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>`
+
+// docs serves Swagger UI pointed at /openapi.json.
+func (h *Handler) docs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(swaggerUIPage))
+}
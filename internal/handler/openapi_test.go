@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"aviation-weather/config"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPI(t *testing.T) {
+	h := NewHandler(&mocks.ServiceMock{}, &config.Config{})
+	r := h.Router()
+
+	req := httptest.NewRequest("GET", "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "HTTP status code should be 200")
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"), "Header should be JSON")
+
+	var spec struct {
+		OpenAPI    string                    `json:"openapi"`
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec), "Spec should be valid JSON")
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+	assert.Contains(t, spec.Paths["/v1/airport/{faa}"], "get")
+	assert.Contains(t, spec.Paths["/v1/airport/{faa}"], "delete")
+	assert.Contains(t, spec.Components.Schemas["AirportResponse"].Properties, "faa_ident", "Airport schema should use JSON tags")
+	assert.Contains(t, spec.Components.Schemas["AirportRequest"].Properties, "latitude")
+	assert.NotContains(t, spec.Components.Schemas["AirportRequest"].Properties, "last_synced_at", "Requests leave out what syncs maintain")
+	assert.Contains(t, spec.Components.Schemas, "ApiResponse")
+}
+
+// Every /v1 route must be documented so the spec doesn't drift from the router.
+func TestOpenAPICoversRoutes(t *testing.T) {
+	h := NewHandler(&mocks.ServiceMock{}, &config.Config{})
+
+	documented := map[string]bool{}
+	for _, op := range apiOperations {
+		documented[strings.ToUpper(op.Method)+" "+op.Path] = true
+	}
+
+	chi.Walk(h.Router(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if strings.HasPrefix(route, "/v1/") && !strings.HasSuffix(route, "/") {
+			assert.True(t, documented[method+" "+route], "%s %s should be in the OpenAPI spec", method, route)
+		}
+		return nil
+	})
+}
+
+func TestDocs(t *testing.T) {
+	h := NewHandler(&mocks.ServiceMock{}, &config.Config{})
+	r := h.Router()
+
+	req := httptest.NewRequest("GET", "/docs", nil)
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "HTTP status code should be 200")
+	assert.Contains(t, rec.Body.String(), "/openapi.json", "Swagger UI should load the spec")
+}
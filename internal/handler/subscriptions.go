@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+)
+
+// getSubscriptions: Lists the digest subscriptions of the caller's API key, secrets left out.
+func (h *Handler) getSubscriptions(w http.ResponseWriter, r *http.Request) {
+	key, ok := requireAPIKey(w, r)
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.svc.GetSubscriptions(key)
+	if err != nil {
+		log.Printf("getSubscriptions: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Subscriptions are Fetched", len(subscriptions)), subscriptions)
+}
+
+// createSubscription: Subscribes the caller's API key to scheduled digests of some airports.
+func (h *Handler) createSubscription(w http.ResponseWriter, r *http.Request) {
+	key, ok := requireAPIKey(w, r)
+	if !ok {
+		return
+	}
+
+	var sub domain.Subscription
+	if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+		log.Printf("createSubscription: invalid JSON: %v", err)
+		respondInvalidJSON(w, err)
+		return
+	}
+
+	if errs := sub.Validate(); len(errs) > 0 {
+		log.Printf("createSubscription: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	sub.TenantID = tenantID(r)
+	if err := h.svc.CreateSubscription(key, &sub); err != nil {
+		log.Printf("createSubscription: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Subscription is Created", sub)
+}
+
+func (h *Handler) deleteSubscription(w http.ResponseWriter, r *http.Request) {
+	key, ok := requireAPIKey(w, r)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r, "Invalid Subscription ID")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteSubscription(key, id); err != nil {
+		log.Printf("deleteSubscription: service error for %d: %v", id, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Subscription is Deleted", id)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSubscriptionEndpoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		apiKey       string
+		body         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "create",
+			method: "POST",
+			url:    "/v1/subscriptions",
+			apiKey: "key",
+			body:   `{"faa_codes":["DEN"],"channel":"webhook","target":"https://example.com/digest"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateSubscription", "key", mock.AnythingOfType("*domain.Subscription")).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Subscription).ID = 7
+				}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Subscription is Created","data":{"id":7,"faa_codes":["DEN"],"channel":"webhook","target":"https://example.com/digest"}}`,
+		},
+		{
+			name:   "create email without SMTP",
+			method: "POST",
+			url:    "/v1/subscriptions",
+			apiKey: "key",
+			body:   `{"faa_codes":["DEN"],"channel":"email","target":"pilot@example.com"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateSubscription", "key", mock.Anything).Return(domain.ErrChannelDisabled)
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedJSON: `{"status":"Error","message":"Notification Channel Not Configured","error_code":"channel_disabled","data":null}`,
+		},
+		{
+			name:         "create invalid",
+			method:       "POST",
+			url:          "/v1/subscriptions",
+			apiKey:       "key",
+			body:         `{"faa_codes":["DEN"],"channel":"pager","target":"555"}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"channel","error":"must be email or webhook"}]}`,
+		},
+		{
+			name:         "list without key",
+			method:       "GET",
+			url:          "/v1/subscriptions",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnauthorized,
+			expectedJSON: `{"status":"Error","message":"Missing API Key","error_code":"unauthorized","data":null}`,
+		},
+		{
+			name:   "list",
+			method: "GET",
+			url:    "/v1/subscriptions",
+			apiKey: "key",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetSubscriptions", "key").Return([]domain.Subscription{{ID: 7, Faas: []string{"DEN"}, Channel: "email", Target: "pilot@example.com"}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Subscriptions are Fetched","data":[{"id":7,"faa_codes":["DEN"],"channel":"email","target":"pilot@example.com"}]}`,
+		},
+		{
+			name:   "delete missing",
+			method: "DELETE",
+			url:    "/v1/subscriptions/8",
+			apiKey: "key",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("DeleteSubscription", "key", int64(8)).Return(domain.ErrSubscriptionNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Subscription Not Found","error_code":"not_found","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			mockSvc.On("ResolveTenant", tt.apiKey).Return(domain.DefaultTenantID, nil).Maybe()
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+
+			req := httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+)
+
+// tenantKey keys the tenant id of a request in its context.
+type tenantKey struct{}
+
+// resolveTenant resolves the tenant of the X-API-Key of each request for the
+// tenant-scoped routes. Requests without a key act for the default tenant,
+// and those with a key that isn't registered get 401.
+func (h *Handler) resolveTenant(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID := domain.DefaultTenantID
+		if key := r.Header.Get("X-API-Key"); key != "" {
+			var err error
+			if tenantID, err = h.svc.ResolveTenant(key); err != nil {
+				if errors.Is(err, domain.ErrAPIKeyNotFound) {
+					utils.EncodeErrorToUser(w, "Invalid API Key", codeUnauthorized, nil, http.StatusUnauthorized)
+					return
+				}
+				log.Printf("resolveTenant: service error: %v", err)
+				respondServiceError(w, err)
+				return
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tenantKey{}, tenantID)))
+	})
+}
+
+// tenantID returns the tenant resolved for r by resolveTenant.
+func tenantID(r *http.Request) int64 {
+	if id, ok := r.Context().Value(tenantKey{}).(int64); ok {
+		return id
+	}
+	return domain.DefaultTenantID
+}
+
+func (h *Handler) getTenants(w http.ResponseWriter, r *http.Request) {
+	tenants, err := h.svc.GetTenants()
+	if err != nil {
+		log.Printf("getTenants: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Tenants are Fetched", len(tenants)), tenants)
+}
+
+func (h *Handler) createTenant(w http.ResponseWriter, r *http.Request) {
+	var t domain.Tenant
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		log.Printf("createTenant: invalid JSON: %v", err)
+		respondInvalidJSON(w, err)
+		return
+	}
+
+	if errs := t.Validate(); len(errs) > 0 {
+		log.Printf("createTenant: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	if err := h.svc.CreateTenant(&t); err != nil {
+		log.Printf("createTenant: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Tenant is Created", t)
+}
+
+func (h *Handler) getAPIKeys(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "Invalid Tenant ID")
+	if !ok {
+		return
+	}
+
+	keys, err := h.svc.GetAPIKeys(id)
+	if err != nil {
+		log.Printf("getAPIKeys: service error for %d: %v", id, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d API Keys are Fetched", len(keys)), keys)
+}
+
+// createAPIKey: Issues an API key for a tenant. The key is only returned in
+// this response.
+func (h *Handler) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "Invalid Tenant ID")
+	if !ok {
+		return
+	}
+
+	var key domain.APIKey
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&key); err != nil {
+			log.Printf("createAPIKey: invalid JSON: %v", err)
+			respondInvalidJSON(w, err)
+			return
+		}
+	}
+	key.TenantID = id
+
+	if errs := key.Validate(); len(errs) > 0 {
+		log.Printf("createAPIKey: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	if err := h.svc.CreateAPIKey(&key); err != nil {
+		log.Printf("createAPIKey: service error for tenant %d: %v", id, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "API Key is Created", key)
+}
+
+func (h *Handler) deleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "Invalid API Key ID")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteAPIKey(id); err != nil {
+		log.Printf("deleteAPIKey: service error for %d: %v", id, err)
+		respondServiceError(w, err)
+		return
+	}
+	h.limiter.forgetKeys()
+
+	utils.EncodeResponseToUser(w, "OK", "API Key is Deleted", id)
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTenantScoping(t *testing.T) {
+	tests := []struct {
+		name         string
+		apiKey       string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "registered key",
+			apiKey: "aw_acme",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ResolveTenant", "aw_acme").Return(int64(2), nil)
+				m.On("GetWebhooks", int64(2)).Return([]domain.Webhook{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Webhooks are Fetched","data":[]}`,
+		},
+		{
+			name: "no key",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetWebhooks", domain.DefaultTenantID).Return([]domain.Webhook{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Webhooks are Fetched","data":[]}`,
+		},
+		{
+			name:   "unregistered key",
+			apiKey: "aw_revoked",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ResolveTenant", "aw_revoked").Return(int64(0), domain.ErrAPIKeyNotFound)
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedJSON: `{"status":"Error","message":"Invalid API Key","error_code":"unauthorized","data":null}`,
+		},
+		{
+			name:   "resolution fails",
+			apiKey: "aw_acme",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("ResolveTenant", "aw_acme").Return(int64(0), assert.AnError)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedJSON: `{"status":"Error","message":"Service Error","error_code":"internal_error","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+
+			req := httptest.NewRequest("GET", "/v1/webhooks", nil)
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantAdminEndpoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		body         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "create tenant",
+			method: "POST",
+			url:    "/v1/admin/tenants",
+			body:   `{"name":"Acme Aviation"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateTenant", &domain.Tenant{Name: "Acme Aviation"}).Run(func(args mock.Arguments) {
+					args.Get(0).(*domain.Tenant).ID = 2
+				}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Tenant is Created","data":{"id":2,"name":"Acme Aviation"}}`,
+		},
+		{
+			name:         "create tenant without name",
+			method:       "POST",
+			url:          "/v1/admin/tenants",
+			body:         `{"name":""}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"name","error":"is required"}]}`,
+		},
+		{
+			name:   "duplicate tenant",
+			method: "POST",
+			url:    "/v1/admin/tenants",
+			body:   `{"name":"Acme Aviation"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateTenant", mock.Anything).Return(domain.ErrTenantExists)
+			},
+			expectedCode: http.StatusConflict,
+			expectedJSON: `{"status":"Error","message":"Duplicate Tenant","error_code":"duplicate","data":null}`,
+		},
+		{
+			name:   "list tenants",
+			method: "GET",
+			url:    "/v1/admin/tenants",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetTenants").Return([]domain.Tenant{{ID: 1, Name: "default"}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Tenants are Fetched","data":[{"id":1,"name":"default"}]}`,
+		},
+		{
+			name:   "issue key",
+			method: "POST",
+			url:    "/v1/admin/tenants/2/keys",
+			body:   `{"name":"ops"}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateAPIKey", &domain.APIKey{TenantID: 2, Name: "ops"}).Run(func(args mock.Arguments) {
+					key := args.Get(0).(*domain.APIKey)
+					key.ID, key.Key, key.KeyHash = 5, "aw_secret", "hash"
+				}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"API Key is Created","data":{"id":5,"tenant_id":2,"name":"ops","key":"aw_secret"}}`,
+		},
+		{
+			name:   "issue key without body for unknown tenant",
+			method: "POST",
+			url:    "/v1/admin/tenants/9/keys",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateAPIKey", &domain.APIKey{TenantID: 9}).Return(domain.ErrTenantNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Tenant Not Found","error_code":"not_found","data":null}`,
+		},
+		{
+			name:   "list keys",
+			method: "GET",
+			url:    "/v1/admin/tenants/2/keys",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetAPIKeys", int64(2)).Return([]domain.APIKey{{ID: 5, TenantID: 2, Name: "ops"}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 API Keys are Fetched","data":[{"id":5,"tenant_id":2,"name":"ops"}]}`,
+		},
+		{
+			name:         "list keys bad id",
+			method:       "GET",
+			url:          "/v1/admin/tenants/abc/keys",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid Tenant ID","data":null}`,
+		},
+		{
+			name:   "revoke key",
+			method: "DELETE",
+			url:    "/v1/admin/keys/5",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("DeleteAPIKey", int64(5)).Return(domain.ErrAPIKeyNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"API Key Not Found","error_code":"not_found","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{AdminToken: "admin-token"})
+
+			req := httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			req.Header.Set("Authorization", "Bearer admin-token")
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxTrendHours caps the trend window; older weather says little about a go/no-go.
+const maxTrendHours = 24
+
+// getWeatherTrend: Reports whether the pressure, visibility and ceiling of an airport improved or deteriorated over the last ?hours=.
+func (h *Handler) getWeatherTrend(w http.ResponseWriter, r *http.Request) {
+	faa := chi.URLParam(r, "faa")
+
+	var hours int
+	if value := r.URL.Query().Get("hours"); value != "" {
+		var err error
+		if hours, err = strconv.Atoi(value); err != nil || hours <= 0 || hours > maxTrendHours {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Hours", nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	trend, err := h.svc.GetWeatherTrend(faa, hours)
+	if err != nil {
+		log.Printf("getWeatherTrend: service error for %s: %v", faa, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Weather Trend is Fetched", trend)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWeatherTrend(t *testing.T) {
+	first, last := 1016.0, 1013.0
+	mockSvc := &mocks.ServiceMock{}
+	mockSvc.On("GetWeatherTrend", "TST", 0).Return(&domain.WeatherTrend{
+		Faa:          "TST",
+		Hours:        3,
+		Since:        time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Observations: 4,
+		PressureHpa:  domain.TrendMetric{Samples: 4, First: &first, Last: &last, SlopePerHour: -1, Trend: domain.TrendDeteriorating},
+		VisibilitySM: domain.TrendMetric{Trend: domain.TrendUnknown},
+		CeilingFt:    domain.TrendMetric{Trend: domain.TrendUnknown},
+		Overall:      domain.TrendDeteriorating,
+	}, nil)
+	mockSvc.On("GetWeatherTrend", "NEW", 6).Return((*domain.WeatherTrend)(nil), fmt.Errorf("%w: no weather history for NEW", domain.ErrNoData))
+	h := NewHandler(mockSvc, &config.Config{})
+	r := h.Router()
+
+	tests := []struct {
+		url      string
+		code     int
+		expected string
+	}{
+		{"/v1/airport/TST/trend", http.StatusOK, `{"status":"OK","message":"Weather Trend is Fetched","data":{
+			"faa":"TST","hours":3,"since":"2024-06-01T12:00:00Z","observations":4,
+			"pressure_hpa":{"samples":4,"first":1016,"last":1013,"slope_per_hour":-1,"trend":"deteriorating"},
+			"visibility_sm":{"samples":0,"slope_per_hour":0,"trend":"unknown"},
+			"ceiling_ft":{"samples":0,"slope_per_hour":0,"trend":"unknown"},
+			"overall":"deteriorating"}}`},
+		{"/v1/airport/NEW/trend?hours=6", http.StatusNotFound, `{"status":"Error","message":"Data Not Available","error_code":"no_data","data":null}`},
+		{"/v1/airport/TST/trend?hours=0", http.StatusBadRequest, `{"status":"Bad Request","message":"Invalid Hours","data":null}`},
+		{"/v1/airport/TST/trend?hours=25", http.StatusBadRequest, `{"status":"Bad Request","message":"Invalid Hours","data":null}`},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.url, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		assert.Equal(t, tt.code, rec.Code, tt.url)
+		assert.JSONEq(t, tt.expected, rec.Body.String(), tt.url)
+	}
+	mockSvc.AssertExpectations(t)
+}
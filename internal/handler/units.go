@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"aviation-weather/internal/units"
+	"aviation-weather/internal/utils"
+)
+
+// unitsKey keys the unit system of a request in its context.
+type unitsKey struct{}
+
+// unitsParam reads ?units=metric|imperial|aviation for the weather-bearing
+// routes, rejecting unknown systems before any work is done.
+func unitsParam(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := r.URL.Query().Get("units")
+		if value == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		system, ok := units.Parse(value)
+		if !ok {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Units", nil, http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), unitsKey{}, system)))
+	})
+}
+
+// inUnits converts the weather values in data into the units asked for by
+// ?units=, renaming their fields to match. Without it data is left in the
+// stored units. Converted data only has a JSON form.
+func inUnits(r *http.Request, data any) any {
+	system, ok := r.Context().Value(unitsKey{}).(units.System)
+	if !ok {
+		return data
+	}
+	converted, err := system.Convert(data)
+	if err != nil {
+		log.Printf("WARN: Answering in stored units, conversion to %s failed: %v", system, err)
+		return data
+	}
+	return converted
+}
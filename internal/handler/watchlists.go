@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// requireAPIKey reads the API key that owns the caller's watchlists and
+// subscriptions, rejecting the request with 401 when there is none.
+func requireAPIKey(w http.ResponseWriter, r *http.Request) (string, bool) {
+	key := r.Header.Get("X-API-Key")
+	if key == "" {
+		utils.EncodeErrorToUser(w, "Missing API Key", codeUnauthorized, nil, http.StatusUnauthorized)
+		return "", false
+	}
+	return key, true
+}
+
+// pathID parses the {id} of a route, rejecting the request with message when
+// it isn't a number.
+func pathID(w http.ResponseWriter, r *http.Request, message string) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.EncodeResponseToUser(w, "Bad Request", message, nil, http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+// getWatchlists: Lists the watchlists of the caller's API key.
+func (h *Handler) getWatchlists(w http.ResponseWriter, r *http.Request) {
+	key, ok := requireAPIKey(w, r)
+	if !ok {
+		return
+	}
+
+	watchlists, err := h.svc.GetWatchlists(key)
+	if err != nil {
+		log.Printf("getWatchlists: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Watchlists are Fetched", len(watchlists)), watchlists)
+}
+
+// createWatchlist: Saves a named list of FAA codes for the caller's API key.
+func (h *Handler) createWatchlist(w http.ResponseWriter, r *http.Request) {
+	key, ok := requireAPIKey(w, r)
+	if !ok {
+		return
+	}
+
+	var wl domain.Watchlist
+	if err := json.NewDecoder(r.Body).Decode(&wl); err != nil {
+		log.Printf("createWatchlist: invalid JSON: %v", err)
+		respondInvalidJSON(w, err)
+		return
+	}
+
+	if errs := wl.Validate(); len(errs) > 0 {
+		log.Printf("createWatchlist: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	wl.TenantID = tenantID(r)
+	if err := h.svc.CreateWatchlist(key, &wl); err != nil {
+		log.Printf("createWatchlist: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Watchlist is Created", wl)
+}
+
+func (h *Handler) deleteWatchlist(w http.ResponseWriter, r *http.Request) {
+	key, ok := requireAPIKey(w, r)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r, "Invalid Watchlist ID")
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteWatchlist(key, id); err != nil {
+		log.Printf("deleteWatchlist: service error for %d: %v", id, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Watchlist is Deleted", id)
+}
+
+// getWatchlistWeather: Reports a compact board of the current conditions at
+// every airport of a watchlist.
+func (h *Handler) getWatchlistWeather(w http.ResponseWriter, r *http.Request) {
+	key, ok := requireAPIKey(w, r)
+	if !ok {
+		return
+	}
+	id, ok := pathID(w, r, "Invalid Watchlist ID")
+	if !ok {
+		return
+	}
+
+	board, err := h.svc.GetWatchlistBoard(key, id)
+	if err != nil {
+		log.Printf("getWatchlistWeather: service error for %d: %v", id, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Watchlist Weather is Fetched", inUnits(r, board))
+}
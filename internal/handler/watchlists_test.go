@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWatchlistEndpoints(t *testing.T) {
+	temperature, visibility := 21.0, 10.0
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		apiKey       string
+		body         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "create",
+			method: "POST",
+			url:    "/v1/watchlists",
+			apiKey: "key",
+			body:   `{"name":"Home base","faa_codes":["DEN","APA"]}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateWatchlist", "key", mock.AnythingOfType("*domain.Watchlist")).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.Watchlist).ID = 3
+				}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Watchlist is Created","data":{"id":3,"name":"Home base","faa_codes":["DEN","APA"]}}`,
+		},
+		{
+			name:         "create without key",
+			method:       "POST",
+			url:          "/v1/watchlists",
+			body:         `{"name":"Home base","faa_codes":["DEN"]}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnauthorized,
+			expectedJSON: `{"status":"Error","message":"Missing API Key","error_code":"unauthorized","data":null}`,
+		},
+		{
+			name:         "create invalid",
+			method:       "POST",
+			url:          "/v1/watchlists",
+			apiKey:       "key",
+			body:         `{"name":"Home base","faa_codes":[]}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"faa_codes","error":"is required"}]}`,
+		},
+		{
+			name:   "list",
+			method: "GET",
+			url:    "/v1/watchlists",
+			apiKey: "key",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetWatchlists", "key").Return([]domain.Watchlist{{ID: 3, Name: "Home base", Faas: []string{"DEN"}, Owner: "hash"}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Watchlists are Fetched","data":[{"id":3,"name":"Home base","faa_codes":["DEN"]}]}`,
+		},
+		{
+			name:   "weather",
+			method: "GET",
+			url:    "/v1/watchlists/3/weather",
+			apiKey: "key",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetWatchlistBoard", "key", int64(3)).Return(&domain.WatchlistBoard{ID: 3, Name: "Home base", Airports: []domain.BoardEntry{
+					{Faa: "DEN", FacilityName: "Denver Intl", Weather: "Sunny", FlightCategory: "VFR", TemperatureC: &temperature, VisibilitySM: &visibility},
+					{Faa: "XXX"},
+				}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Watchlist Weather is Fetched","data":{"id":3,"name":"Home base","airports":[{"faa_ident":"DEN","facility_name":"Denver Intl","weather":"Sunny","flight_category":"VFR","temperature_c":21,"visibility_sm":10},{"faa_ident":"XXX"}]}}`,
+		},
+		{
+			name:   "weather of another key",
+			method: "GET",
+			url:    "/v1/watchlists/3/weather",
+			apiKey: "other",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetWatchlistBoard", "other", int64(3)).Return((*domain.WatchlistBoard)(nil), domain.ErrWatchlistNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Watchlist Not Found","error_code":"not_found","data":null}`,
+		},
+		{
+			name:         "delete bad id",
+			method:       "DELETE",
+			url:          "/v1/watchlists/abc",
+			apiKey:       "key",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid Watchlist ID","data":null}`,
+		},
+		{
+			name:   "delete",
+			method: "DELETE",
+			url:    "/v1/watchlists/3",
+			apiKey: "key",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("DeleteWatchlist", "key", int64(3)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Watchlist is Deleted","data":3}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			mockSvc.On("ResolveTenant", tt.apiKey).Return(domain.DefaultTenantID, nil).Maybe()
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+
+			req := httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultDeliveriesLimit is how many deliveries are listed without ?limit=.
+const defaultDeliveriesLimit = 50
+
+func (h *Handler) getWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.svc.GetWebhooks(tenantID(r))
+	if err != nil {
+		log.Printf("getWebhooks: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Webhooks are Fetched", webhooks)
+}
+
+func (h *Handler) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var wh domain.Webhook
+	if err := json.NewDecoder(r.Body).Decode(&wh); err != nil {
+		log.Printf("createWebhook: invalid JSON: %v", err)
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid JSON", nil, http.StatusBadRequest)
+		return
+	}
+
+	if errs := wh.Validate(); len(errs) > 0 {
+		log.Printf("createWebhook: validation failed: %v", errs)
+		respondValidationErrors(w, errs)
+		return
+	}
+
+	wh.TenantID = tenantID(r)
+	if err := h.svc.CreateWebhook(&wh); err != nil {
+		log.Printf("createWebhook: service error: %v", err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Webhook is Created", wh)
+}
+
+func (h *Handler) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Webhook ID", nil, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.svc.DeleteWebhook(tenantID(r), id); err != nil {
+		log.Printf("deleteWebhook: service error for %d: %v", id, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", "Webhook is Deleted", id)
+}
+
+// getWebhookDeliveries: Lists the latest deliveries of one webhook, capped at ?limit=.
+func (h *Handler) getWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.EncodeResponseToUser(w, "Bad Request", "Invalid Webhook ID", nil, http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultDeliveriesLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 || limit > maxAlertsLimit {
+			utils.EncodeResponseToUser(w, "Bad Request", "Invalid Limit", nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	deliveries, err := h.svc.GetWebhookDeliveries(tenantID(r), id, limit)
+	if err != nil {
+		log.Printf("getWebhookDeliveries: service error for %d: %v", id, err)
+		respondServiceError(w, err)
+		return
+	}
+
+	utils.EncodeResponseToUser(w, "OK", fmt.Sprintf("%d Deliveries are Fetched", len(deliveries)), deliveries)
+}
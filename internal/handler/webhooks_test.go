@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookEndpoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		body         string
+		setupMock    func(*mocks.ServiceMock)
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			name:   "create",
+			method: "POST",
+			url:    "/v1/webhooks",
+			body:   `{"url":"https://example.com/hook","secret":"s3cret","events":["alert.fired"]}`,
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("CreateWebhook", mock.AnythingOfType("*domain.Webhook")).Run(func(args mock.Arguments) {
+					args.Get(0).(*domain.Webhook).ID = 4
+				}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Webhook is Created","data":{"id":4,"url":"https://example.com/hook","secret":"s3cret","events":["alert.fired"]}}`,
+		},
+		{
+			name:         "create invalid",
+			method:       "POST",
+			url:          "/v1/webhooks",
+			body:         `{"url":"example.com","events":["alert.fired"]}`,
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedJSON: `{"status":"Error","message":"Validation Failed","error_code":"validation_failed","data":[{"field":"url","error":"must be an absolute http or https URL"}]}`,
+		},
+		{
+			name:   "list",
+			method: "GET",
+			url:    "/v1/webhooks",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetWebhooks", domain.DefaultTenantID).Return([]domain.Webhook{{ID: 4, URL: "https://example.com/hook", Events: []string{"alert.fired"}}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"Webhooks are Fetched","data":[{"id":4,"url":"https://example.com/hook","events":["alert.fired"]}]}`,
+		},
+		{
+			name:   "delete missing",
+			method: "DELETE",
+			url:    "/v1/webhooks/5",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("DeleteWebhook", domain.DefaultTenantID, int64(5)).Return(domain.ErrWebhookNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedJSON: `{"status":"Error","message":"Webhook Not Found","error_code":"not_found","data":null}`,
+		},
+		{
+			name:   "deliveries",
+			method: "GET",
+			url:    "/v1/webhooks/4/deliveries?limit=5",
+			setupMock: func(m *mocks.ServiceMock) {
+				m.On("GetWebhookDeliveries", domain.DefaultTenantID, int64(4), 5).Return([]domain.WebhookDelivery{
+					{ID: 9, WebhookID: 4, Event: "alert.fired", Payload: json.RawMessage(`{"event":"alert.fired"}`), Attempts: 3, StatusCode: 500, Error: "unexpected status 500"},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedJSON: `{"status":"OK","message":"1 Deliveries are Fetched","data":[{"id":9,"webhook_id":4,"event":"alert.fired","payload":{"event":"alert.fired"},"attempts":3,"status_code":500,"error":"unexpected status 500","succeeded":false}]}`,
+		},
+		{
+			name:         "deliveries bad id",
+			method:       "GET",
+			url:          "/v1/webhooks/abc/deliveries",
+			setupMock:    func(m *mocks.ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+			expectedJSON: `{"status":"Bad Request","message":"Invalid Webhook ID","data":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := &mocks.ServiceMock{}
+			tt.setupMock(mockSvc)
+			h := NewHandler(mockSvc, &config.Config{})
+
+			req := httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			rec := httptest.NewRecorder()
+			h.Router().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedJSON, rec.Body.String())
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package migrate
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testFS = fstest.MapFS{
+	"0001_create_airport.up.sql":   {Data: []byte("CREATE TABLE airport ();")},
+	"0001_create_airport.down.sql": {Data: []byte("DROP TABLE airport;")},
+	"0002_create_weather.up.sql":   {Data: []byte("CREATE TABLE airport_weather ();")},
+	"0002_create_weather.down.sql": {Data: []byte("DROP TABLE airport_weather;")},
+	"0003_add_notes.up.sql":        {Data: []byte("ALTER TABLE airport ADD COLUMN notes TEXT;")},
+	"fill_airport.sql":             {Data: []byte("INSERT INTO airport VALUES ();")},
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFS)
+	assert.NoError(t, err)
+	if assert.Len(t, migrations, 3, "Seed files are not migrations") {
+		assert.Equal(t, Migration{Version: 1, Name: "create_airport", Up: "CREATE TABLE airport ();", Down: "DROP TABLE airport;"}, migrations[0])
+		assert.Equal(t, 3, migrations[2].Version)
+		assert.Empty(t, migrations[2].Down)
+	}
+
+	_, err = Load(fstest.MapFS{"0004_orphan.down.sql": {Data: []byte("SELECT 1;")}})
+	assert.EqualError(t, err, "migration 4_orphan has no up file")
+
+	_, err = Load(fstest.MapFS{
+		"0005_one.up.sql": {Data: []byte("SELECT 1;")},
+		"0005_two.up.sql": {Data: []byte("SELECT 2;")},
+	})
+	assert.EqualError(t, err, "migration 5 is both one and two")
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, []Migration, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	migrations, err := Load(testFS)
+	assert.NoError(t, err)
+	return NewMigrator(db, migrations), migrations, mock
+}
+
+func appliedRows(migrations ...Migration) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"version", "checksum", "applied_at"})
+	for _, m := range migrations {
+		rows.AddRow(m.Version, m.Checksum(), time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	}
+	return rows
+}
+
+func TestStatus(t *testing.T) {
+	m, migrations, mock := newTestMigrator(t)
+
+	edited := migrations[1]
+	edited.Up = "CREATE TABLE something_else ();"
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version, checksum, applied_at FROM schema_migrations`).
+		WillReturnRows(appliedRows(migrations[0], edited))
+
+	statuses, err := m.Status(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 3) {
+		assert.NotNil(t, statuses[0].AppliedAt)
+		assert.False(t, statuses[0].Modified)
+		assert.True(t, statuses[1].Modified, "Checksum differs from the file")
+		assert.Nil(t, statuses[2].AppliedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func expectLocked(mock sqlmock.Sqlmock, applied ...Migration) {
+	mock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version, checksum, applied_at FROM schema_migrations`).WillReturnRows(appliedRows(applied...))
+}
+
+func TestToUp(t *testing.T) {
+	m, migrations, mock := newTestMigrator(t)
+
+	expectLocked(mock, migrations[0])
+	for _, mig := range migrations[1:] {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(mig.Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO schema_migrations`).
+			WithArgs(mig.Version, mig.Name, mig.Checksum()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, m.To(context.Background(), m.Latest()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestToDown(t *testing.T) {
+	m, migrations, mock := newTestMigrator(t)
+
+	expectLocked(mock, migrations[0], migrations[1])
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(migrations[1].Down)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM schema_migrations WHERE version = \$1`).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, m.To(context.Background(), 1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestToRefusesModifiedMigrations(t *testing.T) {
+	m, migrations, mock := newTestMigrator(t)
+
+	edited := migrations[0]
+	edited.Up = "CREATE TABLE airport (faa TEXT);"
+	expectLocked(mock, edited)
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := m.To(context.Background(), m.Latest())
+	assert.EqualError(t, err, "migration 1_create_airport was modified after it was applied")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.EqualError(t, m.To(context.Background(), 9), "unknown target version 9, latest is 3")
+}
@@ -1,6 +1,10 @@
 package mock
 
 import (
+	"context"
+	"time"
+
+	"aviation-weather/internal/aviation"
 	"aviation-weather/internal/domain"
 
 	"github.com/stretchr/testify/mock"
@@ -9,6 +13,9 @@ import (
 // Fake repository that won't call any API or functionalities
 type RepositoryMock struct {
 	mock.Mock
+
+	// Events saved by ModifyAirport
+	ModifiedEvents []domain.OutboxEvent
 }
 
 func (m *RepositoryMock) CreateAirport(airport *domain.Airport) error {
@@ -26,12 +33,593 @@ func (m *RepositoryMock) DeleteByFAA(faa string) error {
 	return args.Error(0)
 }
 
-func (m *RepositoryMock) GetAllAirports() ([]domain.Airport, error) {
-	args := m.Called()
+func (m *RepositoryMock) GetAllAirports(sort []domain.SortField) ([]domain.Airport, error) {
+	args := m.Called(sort)
 	return args.Get(0).([]domain.Airport), args.Error(1)
 }
 
+func (m *RepositoryMock) GetStates() ([]string, error) {
+	args := m.Called()
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *RepositoryMock) GetCities(stateCode string) ([]string, error) {
+	args := m.Called(stateCode)
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *RepositoryMock) GetAirportByFAA(faaFilter string) (*domain.Airport, error) {
 	args := m.Called(faaFilter)
 	return args.Get(0).(*domain.Airport), args.Error(1)
 }
+
+func (m *RepositoryMock) UpsertAirports(airports []domain.Airport) error {
+	args := m.Called(airports)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) CreateAirports(airports []domain.Airport) ([]string, []string, error) {
+	args := m.Called(airports)
+	return args.Get(0).([]string), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *RepositoryMock) GetAirportsNeedingSync(maxAge time.Duration) ([]domain.Airport, error) {
+	args := m.Called(maxAge)
+	return args.Get(0).([]domain.Airport), args.Error(1)
+}
+
+// ForEachAirport feeds fn the airports given to Return, then returns its error.
+func (m *RepositoryMock) ForEachAirport(ctx context.Context, fn func(domain.Airport) error) error {
+	args := m.Called(ctx)
+	return feedAirports(args.Get(0).([]domain.Airport), fn, args.Error(1))
+}
+
+// ForEachAirportBySyncPriority feeds fn the airports given to Return, then returns its error.
+func (m *RepositoryMock) ForEachAirportBySyncPriority(ctx context.Context, fn func(domain.Airport) error) error {
+	args := m.Called(ctx)
+	return feedAirports(args.Get(0).([]domain.Airport), fn, args.Error(1))
+}
+
+func (m *RepositoryMock) CountAirports(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *RepositoryMock) ForEachAirportNeedingSync(ctx context.Context, maxAge time.Duration, fn func(domain.Airport) error) error {
+	args := m.Called(ctx, maxAge)
+	return feedAirports(args.Get(0).([]domain.Airport), fn, args.Error(1))
+}
+
+func feedAirports(airports []domain.Airport, fn func(domain.Airport) error, err error) error {
+	for _, a := range airports {
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (m *RepositoryMock) GetAirportsByState(stateCode string) ([]domain.Airport, error) {
+	args := m.Called(stateCode)
+	return args.Get(0).([]domain.Airport), args.Error(1)
+}
+
+func (m *RepositoryMock) GetAirportsByPlace(stateCode, city string) ([]domain.Airport, error) {
+	args := m.Called(stateCode, city)
+	return args.Get(0).([]domain.Airport), args.Error(1)
+}
+
+func (m *RepositoryMock) GetAirportsByFAAs(faas []string) ([]domain.Airport, error) {
+	args := m.Called(faas)
+	return args.Get(0).([]domain.Airport), args.Error(1)
+}
+
+func (m *RepositoryMock) GetAirportsByFuel(fuel string, sort []domain.SortField) ([]domain.Airport, error) {
+	args := m.Called(fuel, sort)
+	return args.Get(0).([]domain.Airport), args.Error(1)
+}
+
+func (m *RepositoryMock) GetFrequencies(faa string) ([]domain.Frequency, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.Frequency), args.Error(1)
+}
+
+func (m *RepositoryMock) ReplaceFrequencies(faa string, frequencies []domain.Frequency) error {
+	args := m.Called(faa, frequencies)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetRunways(faa string) ([]domain.Runway, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.Runway), args.Error(1)
+}
+
+func (m *RepositoryMock) ReplaceRunways(faa string, runways []domain.Runway) error {
+	args := m.Called(faa, runways)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetCharts(faa string) ([]domain.Chart, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.Chart), args.Error(1)
+}
+
+func (m *RepositoryMock) ReplaceCharts(faa, cycle string, charts []domain.Chart) error {
+	args := m.Called(faa, cycle, charts)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetFAAsNeedingCharts(cycle string) ([]string, error) {
+	args := m.Called(cycle)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *RepositoryMock) SaveObservation(faa string, obs domain.Observation) error {
+	args := m.Called(faa, obs)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetObservation(faa string) (*domain.Observation, error) {
+	args := m.Called(faa)
+	return args.Get(0).(*domain.Observation), args.Error(1)
+}
+
+func (m *RepositoryMock) GetObservations(faas []string) (map[string]domain.Observation, error) {
+	args := m.Called(faas)
+	return args.Get(0).(map[string]domain.Observation), args.Error(1)
+}
+
+func (m *RepositoryMock) GetWeatherHistory(faa string, since time.Time) ([]domain.Observation, error) {
+	args := m.Called(faa, since)
+	return args.Get(0).([]domain.Observation), args.Error(1)
+}
+
+func (m *RepositoryMock) GetWeatherHistories(faas []string, since time.Time) (map[string][]domain.Observation, error) {
+	args := m.Called(faas, since)
+	return args.Get(0).(map[string][]domain.Observation), args.Error(1)
+}
+
+func (m *RepositoryMock) GetAirportsInBox(box aviation.Box) ([]domain.Airport, error) {
+	args := m.Called(box)
+	return args.Get(0).([]domain.Airport), args.Error(1)
+}
+
+func (m *RepositoryMock) GetNearestAirports(center aviation.Point, radiusNM float64, limit int) ([]domain.NearbyAirport, error) {
+	args := m.Called(center, radiusNM, limit)
+	return args.Get(0).([]domain.NearbyAirport), args.Error(1)
+}
+
+func (m *RepositoryMock) ReplaceNavaids(navaids []domain.Navaid) error {
+	args := m.Called(navaids)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetNavaidsInBox(box aviation.Box) ([]domain.Navaid, error) {
+	args := m.Called(box)
+	return args.Get(0).([]domain.Navaid), args.Error(1)
+}
+
+func (m *RepositoryMock) GetNearestNavaids(center aviation.Point, radiusNM float64, limit int) ([]domain.NearbyNavaid, error) {
+	args := m.Called(center, radiusNM, limit)
+	return args.Get(0).([]domain.NearbyNavaid), args.Error(1)
+}
+
+func (m *RepositoryMock) CreateAlertRule(rule *domain.AlertRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) DeleteAlertRule(tenantID, id int64) error {
+	args := m.Called(tenantID, id)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetAlertRules(tenantID int64) ([]domain.AlertRule, error) {
+	args := m.Called(tenantID)
+	return args.Get(0).([]domain.AlertRule), args.Error(1)
+}
+
+func (m *RepositoryMock) GetAlertRulesFor(faa, stateCode string) ([]domain.AlertRule, error) {
+	args := m.Called(faa, stateCode)
+	return args.Get(0).([]domain.AlertRule), args.Error(1)
+}
+
+func (m *RepositoryMock) SaveAlerts(alerts []domain.Alert) error {
+	args := m.Called(alerts)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetAlerts(tenantID int64, faa string, since time.Time, limit int) ([]domain.Alert, error) {
+	args := m.Called(tenantID, faa, since, limit)
+	return args.Get(0).([]domain.Alert), args.Error(1)
+}
+
+func (m *RepositoryMock) CreateWebhook(wh *domain.Webhook) error {
+	args := m.Called(wh)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) DeleteWebhook(tenantID, id int64) error {
+	args := m.Called(tenantID, id)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetWebhooks(tenantID int64) ([]domain.Webhook, error) {
+	args := m.Called(tenantID)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *RepositoryMock) GetWebhooksForEvent(tenantID int64, event string) ([]domain.Webhook, error) {
+	args := m.Called(tenantID, event)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *RepositoryMock) SaveWebhookDelivery(delivery *domain.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetWebhookDeliveries(tenantID, webhookID int64, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(tenantID, webhookID, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *RepositoryMock) CreateWatchlist(wl *domain.Watchlist) error {
+	args := m.Called(wl)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) DeleteWatchlist(id int64, owner string) error {
+	args := m.Called(id, owner)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetWatchlists(owner string) ([]domain.Watchlist, error) {
+	args := m.Called(owner)
+	return args.Get(0).([]domain.Watchlist), args.Error(1)
+}
+
+func (m *RepositoryMock) GetWatchlist(id int64, owner string) (*domain.Watchlist, error) {
+	args := m.Called(id, owner)
+	return args.Get(0).(*domain.Watchlist), args.Error(1)
+}
+
+func (m *RepositoryMock) CreateSubscription(sub *domain.Subscription) error {
+	args := m.Called(sub)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) DeleteSubscription(id int64, owner string) error {
+	args := m.Called(id, owner)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetSubscriptions(owner string) ([]domain.Subscription, error) {
+	args := m.Called(owner)
+	return args.Get(0).([]domain.Subscription), args.Error(1)
+}
+
+func (m *RepositoryMock) GetAllSubscriptions() ([]domain.Subscription, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Subscription), args.Error(1)
+}
+
+func (m *RepositoryMock) MarkSubscriptionSent(id int64, sentAt time.Time) error {
+	args := m.Called(id, sentAt)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) CreateTenant(t *domain.Tenant) error {
+	args := m.Called(t)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetTenants() ([]domain.Tenant, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Tenant), args.Error(1)
+}
+
+func (m *RepositoryMock) CreateAPIKey(key *domain.APIKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) DeleteAPIKey(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetAPIKeys(tenantID int64) ([]domain.APIKey, error) {
+	args := m.Called(tenantID)
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *RepositoryMock) GetTenantIDByKeyHash(keyHash string) (int64, error) {
+	args := m.Called(keyHash)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *RepositoryMock) GetSchedulerJobs() ([]domain.SchedulerJob, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.SchedulerJob), args.Error(1)
+}
+
+func (m *RepositoryMock) SetSchedulerJobEnabled(name string, enabled bool) error {
+	args := m.Called(name, enabled)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) RequestSchedulerJobRun(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) ClaimSchedulerJobRuns() ([]string, error) {
+	args := m.Called()
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *RepositoryMock) RecordSyncOutcome(synced []string, failed []domain.SyncFailure, backoff time.Duration) error {
+	args := m.Called(synced, failed, backoff)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetDueSyncFailures() ([]domain.SyncFailure, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.SyncFailure), args.Error(1)
+}
+
+func (m *RepositoryMock) SaveConflicts(conflicts []domain.AirportConflict) error {
+	args := m.Called(conflicts)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetConflicts(status string) ([]domain.AirportConflict, error) {
+	args := m.Called(status)
+	return args.Get(0).([]domain.AirportConflict), args.Error(1)
+}
+
+func (m *RepositoryMock) GetConflict(id int64) (*domain.AirportConflict, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.AirportConflict), args.Error(1)
+}
+
+func (m *RepositoryMock) ResolveConflict(id int64, status string) (*domain.AirportConflict, error) {
+	args := m.Called(id, status)
+	return args.Get(0).(*domain.AirportConflict), args.Error(1)
+}
+
+func (m *RepositoryMock) ChangeAirportStatus(faa, status, reason string) (*domain.AirportStatusChange, error) {
+	args := m.Called(faa, status, reason)
+	return args.Get(0).(*domain.AirportStatusChange), args.Error(1)
+}
+
+func (m *RepositoryMock) GetAirportStatusHistory(faa string) ([]domain.AirportStatusChange, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.AirportStatusChange), args.Error(1)
+}
+
+func (m *RepositoryMock) CreateAirportNote(note *domain.AirportNote) error {
+	args := m.Called(note)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetAirportNotes(faa string) ([]domain.AirportNote, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.AirportNote), args.Error(1)
+}
+
+func (m *RepositoryMock) DeleteAirportNote(faa string, id int64) error {
+	args := m.Called(faa, id)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) PruneLogs(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *RepositoryMock) GetAirportChanges(after domain.ChangeCursor, limit int) ([]domain.ChangeLogEntry, error) {
+	args := m.Called(after, limit)
+	return args.Get(0).([]domain.ChangeLogEntry), args.Error(1)
+}
+
+func (m *RepositoryMock) CreateWeatherHistoryPartitions(from time.Time, months int) ([]string, error) {
+	args := m.Called(from, months)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *RepositoryMock) DropWeatherHistoryPartitions(before time.Time) ([]string, error) {
+	args := m.Called(before)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *RepositoryMock) Ping(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetLastSyncedAt(ctx context.Context) (*time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *RepositoryMock) GetLastUpdatedAt(ctx context.Context) (*time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *RepositoryMock) GetAirportStats(ctx context.Context, staleBefore time.Time) (*domain.AirportStats, error) {
+	args := m.Called(ctx, staleBefore)
+	return args.Get(0).(*domain.AirportStats), args.Error(1)
+}
+
+func (m *RepositoryMock) GetDashboard(ctx context.Context) (*domain.Dashboard, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*domain.Dashboard), args.Error(1)
+}
+
+func (m *RepositoryMock) RefreshDashboard() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) ReplaceAdvisories(advisories []domain.Advisory) error {
+	args := m.Called(advisories)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetAdvisories(at time.Time) ([]domain.Advisory, error) {
+	args := m.Called(at)
+	return args.Get(0).([]domain.Advisory), args.Error(1)
+}
+
+func (m *RepositoryMock) GetAdvisoriesAt(p aviation.Point, at time.Time) ([]domain.Advisory, error) {
+	args := m.Called(p, at)
+	return args.Get(0).([]domain.Advisory), args.Error(1)
+}
+
+func (m *RepositoryMock) ReplaceNOTAMs(faa string, notams []domain.NOTAM) error {
+	args := m.Called(faa, notams)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetNOTAMs(faa string, at time.Time) ([]domain.NOTAM, error) {
+	args := m.Called(faa, at)
+	return args.Get(0).([]domain.NOTAM), args.Error(1)
+}
+
+func (m *RepositoryMock) GetFollowedFAAs() ([]string, error) {
+	args := m.Called()
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *RepositoryMock) GetProviderResponse(url string) (*domain.ProviderResponse, error) {
+	args := m.Called(url)
+	return args.Get(0).(*domain.ProviderResponse), args.Error(1)
+}
+
+func (m *RepositoryMock) SaveProviderResponse(resp *domain.ProviderResponse) error {
+	args := m.Called(resp)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) CountProviderResponses() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *RepositoryMock) ClearProviderResponses() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *RepositoryMock) UpdateAirportWithEvents(airport *domain.Airport, events []domain.OutboxEvent) error {
+	args := m.Called(airport, events)
+	return args.Error(0)
+}
+
+// ModifyAirport runs modify on the airport given to Return, in place, so
+// tests can check what it saves, then returns the error given to Return.
+// Without an airport it returns that error straight away. The events modify
+// returns are kept in ModifiedEvents.
+func (m *RepositoryMock) ModifyAirport(faa string, modify func(a *domain.Airport) ([]domain.OutboxEvent, error)) error {
+	args := m.Called(faa)
+	airport, _ := args.Get(0).(*domain.Airport)
+	if airport == nil {
+		return args.Error(1)
+	}
+	events, err := modify(airport)
+	if err != nil {
+		return err
+	}
+	m.ModifiedEvents = append(m.ModifiedEvents, events...)
+	return args.Error(1)
+}
+
+func (m *RepositoryMock) ClaimOutboxEvents(limit int, lease time.Duration) ([]domain.OutboxEvent, error) {
+	args := m.Called(limit, lease)
+	return args.Get(0).([]domain.OutboxEvent), args.Error(1)
+}
+
+func (m *RepositoryMock) DeleteOutboxEvent(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) RetryOutboxEvent(id int64, lastError string, delay time.Duration) error {
+	args := m.Called(id, lastError, delay)
+	return args.Error(0)
+}
+
+// MergeAirports hands merge each synced airport as stored too, as if no one
+// wrote it since the sync read it. Return may instead be given the airports
+// as stored, in which case synced airports missing from them are skipped, or
+// an error.
+func (m *RepositoryMock) MergeAirports(synced []domain.Airport, merge func(stored, synced *domain.Airport) ([]domain.OutboxEvent, error)) ([]domain.Airport, error) {
+	args := m.Called(synced)
+	rows := synced
+	switch v := args.Get(0).(type) {
+	case error:
+		return nil, v
+	case []domain.Airport:
+		rows = v
+	}
+	bySynced := make(map[string]*domain.Airport, len(synced))
+	for i := range synced {
+		bySynced[synced[i].Faa] = &synced[i]
+	}
+	saved := make([]domain.Airport, 0, len(rows))
+	for _, stored := range rows {
+		if bySynced[stored.Faa] == nil {
+			continue
+		}
+		events, err := merge(&stored, bySynced[stored.Faa])
+		if err != nil {
+			return nil, err
+		}
+		m.ModifiedEvents = append(m.ModifiedEvents, events...)
+		saved = append(saved, stored)
+	}
+	return saved, nil
+}
+
+func (m *RepositoryMock) EnqueueJob(job *domain.QueuedJob) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) ClaimQueuedJob(lease time.Duration) (*domain.QueuedJob, error) {
+	args := m.Called(lease)
+	return args.Get(0).(*domain.QueuedJob), args.Error(1)
+}
+
+func (m *RepositoryMock) ExtendQueuedJob(id int64, lease time.Duration) error {
+	args := m.Called(id, lease)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) FinishQueuedJob(id int64, result []byte) error {
+	args := m.Called(id, result)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) FailQueuedJob(id int64, lastError string, retryAfter time.Duration) error {
+	args := m.Called(id, lastError, retryAfter)
+	return args.Error(0)
+}
+
+func (m *RepositoryMock) GetQueuedJob(id int64) (*domain.QueuedJob, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.QueuedJob), args.Error(1)
+}
+
+func (m *RepositoryMock) GetQueuedJobs() ([]domain.QueuedJob, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.QueuedJob), args.Error(1)
+}
+
+func (m *RepositoryMock) PruneQueuedJobs(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
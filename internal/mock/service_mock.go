@@ -1,7 +1,12 @@
 package mock
 
 import (
+	"context"
+	"time"
+
+	"aviation-weather/config"
 	"aviation-weather/internal/domain"
+	"aviation-weather/internal/weather"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -12,14 +17,14 @@ type ServiceMock struct {
 }
 
 // SyncAirportQueued implements service.ServiceInterface.
-func (m *ServiceMock) SyncAirportQueued(faa string) (*domain.Airport, error) {
+func (m *ServiceMock) SyncAirportQueued(faa string) (*domain.SyncResult, error) {
 	args := m.Called(faa)
-	return args.Get(0).(*domain.Airport), args.Error(1)
+	return args.Get(0).(*domain.SyncResult), args.Error(1)
 }
 
 // SyncAllAirportsQueued implements service.ServiceInterface.
-func (m *ServiceMock) SyncAllAirportsQueued() (int, error) {
-	args := m.Called()
+func (m *ServiceMock) SyncAllAirportsQueued(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
 	return args.Int(0), args.Error(1)
 }
 
@@ -43,17 +48,489 @@ func (m *ServiceMock) GetAirportByFAA(faa string) (*domain.Airport, error) {
 	return args.Get(0).(*domain.Airport), args.Error(1)
 }
 
-func (m *ServiceMock) GetAllAirports() ([]domain.Airport, error) {
-	args := m.Called()
+func (m *ServiceMock) GetAllAirports(sort []domain.SortField) ([]domain.Airport, error) {
+	args := m.Called(sort)
 	return args.Get(0).([]domain.Airport), args.Error(1)
 }
 
-func (m *ServiceMock) SyncAirportByFAA(faa string) (*domain.Airport, error) {
-	args := m.Called(faa)
+func (m *ServiceMock) GetAirportsByFAAs(faas []string) ([]domain.Airport, error) {
+	args := m.Called(faas)
+	return args.Get(0).([]domain.Airport), args.Error(1)
+}
+
+func (m *ServiceMock) GetAirportsByFuel(fuel string, sort []domain.SortField) ([]domain.Airport, error) {
+	args := m.Called(fuel, sort)
+	return args.Get(0).([]domain.Airport), args.Error(1)
+}
+
+func (m *ServiceMock) UpdateAirportFuel(faa string, fuel domain.AirportFuel) (*domain.Airport, error) {
+	args := m.Called(faa, fuel)
 	return args.Get(0).(*domain.Airport), args.Error(1)
 }
 
-func (m *ServiceMock) SyncAllAirports() (int, error) {
+func (m *ServiceMock) GetStates() ([]string, error) {
 	args := m.Called()
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *ServiceMock) GetCities(stateCode string) ([]string, error) {
+	args := m.Called(stateCode)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// ForEachAirport feeds fn the airports given to Return, then returns its error.
+func (m *ServiceMock) ForEachAirport(ctx context.Context, fn func(domain.Airport) error) error {
+	args := m.Called(ctx)
+	for _, a := range args.Get(0).([]domain.Airport) {
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *ServiceMock) ImportAirports(airports []domain.Airport) error {
+	args := m.Called(airports)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) CreateAirports(airports []domain.Airport) ([]string, []string, error) {
+	args := m.Called(airports)
+	return args.Get(0).([]string), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *ServiceMock) SyncAirportByFAA(ctx context.Context, faa string) (*domain.SyncResult, error) {
+	args := m.Called(ctx, faa)
+	return args.Get(0).(*domain.SyncResult), args.Error(1)
+}
+
+func (m *ServiceMock) SyncAllAirports(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
 	return args.Int(0), args.Error(1)
 }
+
+func (m *ServiceMock) SyncAirportsByState(ctx context.Context, stateCode string) (int, error) {
+	args := m.Called(ctx, stateCode)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *ServiceMock) SyncAirportsByFAAs(ctx context.Context, faas []string) (int, error) {
+	args := m.Called(ctx, faas)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *ServiceMock) ProviderStatuses() []domain.ProviderStatus {
+	args := m.Called()
+	return args.Get(0).([]domain.ProviderStatus)
+}
+
+func (m *ServiceMock) CacheStats() domain.CacheStats {
+	args := m.Called()
+	return args.Get(0).(domain.CacheStats)
+}
+
+func (m *ServiceMock) GetCaches() ([]domain.CacheStats, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.CacheStats), args.Error(1)
+}
+
+func (m *ServiceMock) ClearCache(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) Readiness(ctx context.Context) domain.Readiness {
+	args := m.Called(ctx)
+	return args.Get(0).(domain.Readiness)
+}
+
+func (m *ServiceMock) RuntimeStats() domain.RuntimeStats {
+	args := m.Called()
+	return args.Get(0).(domain.RuntimeStats)
+}
+
+func (m *ServiceMock) Dashboard(ctx context.Context) (*domain.Dashboard, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*domain.Dashboard), args.Error(1)
+}
+
+func (m *ServiceMock) AirportStats(ctx context.Context) (*domain.AirportStats, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*domain.AirportStats), args.Error(1)
+}
+
+func (m *ServiceMock) AirportsLastModified(ctx context.Context) (time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *ServiceMock) ApplyConfig(cfg *config.Config) {
+	m.Called(cfg)
+}
+
+func (m *ServiceMock) GetFrequencies(faa string) ([]domain.Frequency, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.Frequency), args.Error(1)
+}
+
+func (m *ServiceMock) SyncFrequencies(ctx context.Context, faa string) ([]domain.Frequency, error) {
+	args := m.Called(ctx, faa)
+	return args.Get(0).([]domain.Frequency), args.Error(1)
+}
+
+func (m *ServiceMock) GetRunways(faa string) ([]domain.Runway, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.Runway), args.Error(1)
+}
+
+func (m *ServiceMock) SyncRunways(ctx context.Context, faa string) ([]domain.Runway, error) {
+	args := m.Called(ctx, faa)
+	return args.Get(0).([]domain.Runway), args.Error(1)
+}
+
+func (m *ServiceMock) GetWindComponents(faa string) (*domain.WindComponents, error) {
+	args := m.Called(faa)
+	return args.Get(0).(*domain.WindComponents), args.Error(1)
+}
+
+func (m *ServiceMock) GetPerformance(faa string) (*domain.Performance, error) {
+	args := m.Called(faa)
+	return args.Get(0).(*domain.Performance), args.Error(1)
+}
+
+func (m *ServiceMock) GetAirportWeather(ctx context.Context, faa string, refresh bool) (*domain.AirportWeather, error) {
+	args := m.Called(ctx, faa, refresh)
+	return args.Get(0).(*domain.AirportWeather), args.Error(1)
+}
+
+func (m *ServiceMock) GetLiveWeather(ctx context.Context, loc weather.Location) (*domain.Observation, error) {
+	args := m.Called(ctx, loc)
+	return args.Get(0).(*domain.Observation), args.Error(1)
+}
+
+func (m *ServiceMock) GetDaylight(faa string, date time.Time) (*domain.Daylight, error) {
+	args := m.Called(faa, date)
+	return args.Get(0).(*domain.Daylight), args.Error(1)
+}
+
+func (m *ServiceMock) GetRouteWeather(from, to string, corridorNM float64) (*domain.RouteWeather, error) {
+	args := m.Called(from, to, corridorNM)
+	return args.Get(0).(*domain.RouteWeather), args.Error(1)
+}
+
+func (m *ServiceMock) GetAlternates(faa string, radiusNM float64, minCategory string) ([]domain.Alternate, error) {
+	args := m.Called(faa, radiusNM, minCategory)
+	return args.Get(0).([]domain.Alternate), args.Error(1)
+}
+
+func (m *ServiceMock) GetNearestNavaids(lat, lon, radiusNM float64, limit int) ([]domain.NearbyNavaid, error) {
+	args := m.Called(lat, lon, radiusNM, limit)
+	return args.Get(0).([]domain.NearbyNavaid), args.Error(1)
+}
+
+func (m *ServiceMock) GetAirportNavaids(faa string, radiusNM float64) ([]domain.NearbyNavaid, error) {
+	args := m.Called(faa, radiusNM)
+	return args.Get(0).([]domain.NearbyNavaid), args.Error(1)
+}
+
+func (m *ServiceMock) CreateAlertRule(rule *domain.AlertRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) DeleteAlertRule(tenantID, id int64) error {
+	args := m.Called(tenantID, id)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) GetAlertRules(tenantID int64) ([]domain.AlertRule, error) {
+	args := m.Called(tenantID)
+	return args.Get(0).([]domain.AlertRule), args.Error(1)
+}
+
+func (m *ServiceMock) GetAlerts(tenantID int64, faa string, since time.Time, limit int) ([]domain.Alert, error) {
+	args := m.Called(tenantID, faa, since, limit)
+	return args.Get(0).([]domain.Alert), args.Error(1)
+}
+
+func (m *ServiceMock) SendTestNotification(ctx context.Context, target domain.NotifyTarget) error {
+	args := m.Called(ctx, target)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) ConsumeAirportChanges(ctx context.Context, changes <-chan domain.AirportChange) {
+	m.Called(ctx, changes)
+}
+
+func (m *ServiceMock) DispatchOutbox(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func (m *ServiceMock) RunJobWorkers(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func (m *ServiceMock) GetQueuedJobs() ([]domain.QueuedJob, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.QueuedJob), args.Error(1)
+}
+
+func (m *ServiceMock) GetConflicts(status string) ([]domain.AirportConflict, error) {
+	args := m.Called(status)
+	return args.Get(0).([]domain.AirportConflict), args.Error(1)
+}
+
+func (m *ServiceMock) AcceptConflict(id int64) (*domain.AirportConflict, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.AirportConflict), args.Error(1)
+}
+
+func (m *ServiceMock) RejectConflict(id int64) (*domain.AirportConflict, error) {
+	args := m.Called(id)
+	return args.Get(0).(*domain.AirportConflict), args.Error(1)
+}
+
+func (m *ServiceMock) ChangeAirportStatus(faa, status, reason string) (*domain.AirportStatusChange, error) {
+	args := m.Called(faa, status, reason)
+	return args.Get(0).(*domain.AirportStatusChange), args.Error(1)
+}
+
+func (m *ServiceMock) GetAirportStatusHistory(faa string) ([]domain.AirportStatusChange, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.AirportStatusChange), args.Error(1)
+}
+
+func (m *ServiceMock) CreateAirportNote(note *domain.AirportNote) error {
+	args := m.Called(note)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) GetAirportNotes(faa string) ([]domain.AirportNote, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.AirportNote), args.Error(1)
+}
+
+func (m *ServiceMock) DeleteAirportNote(faa string, id int64) error {
+	args := m.Called(faa, id)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) SubscribeAirportChanges() (<-chan domain.AirportChange, func()) {
+	args := m.Called()
+	return args.Get(0).(<-chan domain.AirportChange), args.Get(1).(func())
+}
+
+func (m *ServiceMock) GetChangeFeed(after domain.ChangeCursor, limit int) (*domain.ChangeFeed, error) {
+	args := m.Called(after, limit)
+	return args.Get(0).(*domain.ChangeFeed), args.Error(1)
+}
+
+func (m *ServiceMock) CreateWebhook(wh *domain.Webhook) error {
+	args := m.Called(wh)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) DeleteWebhook(tenantID, id int64) error {
+	args := m.Called(tenantID, id)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) GetWebhooks(tenantID int64) ([]domain.Webhook, error) {
+	args := m.Called(tenantID)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *ServiceMock) GetWebhookDeliveries(tenantID, id int64, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(tenantID, id, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *ServiceMock) CreateWatchlist(apiKey string, wl *domain.Watchlist) error {
+	args := m.Called(apiKey, wl)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) DeleteWatchlist(apiKey string, id int64) error {
+	args := m.Called(apiKey, id)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) GetWatchlists(apiKey string) ([]domain.Watchlist, error) {
+	args := m.Called(apiKey)
+	return args.Get(0).([]domain.Watchlist), args.Error(1)
+}
+
+func (m *ServiceMock) GetWatchlistBoard(apiKey string, id int64) (*domain.WatchlistBoard, error) {
+	args := m.Called(apiKey, id)
+	return args.Get(0).(*domain.WatchlistBoard), args.Error(1)
+}
+
+func (m *ServiceMock) CreateSubscription(apiKey string, sub *domain.Subscription) error {
+	args := m.Called(apiKey, sub)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) DeleteSubscription(apiKey string, id int64) error {
+	args := m.Called(apiKey, id)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) GetSubscriptions(apiKey string) ([]domain.Subscription, error) {
+	args := m.Called(apiKey)
+	return args.Get(0).([]domain.Subscription), args.Error(1)
+}
+
+func (m *ServiceMock) SendDigests(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *ServiceMock) SyncStaleAirports(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *ServiceMock) RetryFailedAirports(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *ServiceMock) PartitionWeatherHistory(ctx context.Context) ([]string, []string, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]string), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *ServiceMock) SyncAdvisories(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *ServiceMock) SyncCharts(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *ServiceMock) GetCharts(faa string) ([]domain.Chart, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.Chart), args.Error(1)
+}
+
+func (m *ServiceMock) GetWeatherTrend(faa string, hours int) (*domain.WeatherTrend, error) {
+	args := m.Called(faa, hours)
+	return args.Get(0).(*domain.WeatherTrend), args.Error(1)
+}
+
+func (m *ServiceMock) GetWeatherHistories(faas []string, hours int) (map[string][]domain.Observation, error) {
+	args := m.Called(faas, hours)
+	return args.Get(0).(map[string][]domain.Observation), args.Error(1)
+}
+
+func (m *ServiceMock) GetAirportsByPlace(stateCode, city string) ([]domain.Airport, error) {
+	args := m.Called(stateCode, city)
+	return args.Get(0).([]domain.Airport), args.Error(1)
+}
+
+func (m *ServiceMock) GetFlightCategories(faas []string) (map[string]string, error) {
+	args := m.Called(faas)
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *ServiceMock) RefreshStaticData(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *ServiceMock) RefreshNOTAMs(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *ServiceMock) GetAIRAC() domain.AIRACStatus {
+	args := m.Called()
+	return args.Get(0).(domain.AIRACStatus)
+}
+
+func (m *ServiceMock) GetAdvisories() ([]domain.Advisory, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Advisory), args.Error(1)
+}
+
+func (m *ServiceMock) GetAirportAdvisories(faa string) ([]domain.Advisory, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.Advisory), args.Error(1)
+}
+
+func (m *ServiceMock) GetAirportNOTAMs(faa string) ([]domain.NOTAM, error) {
+	args := m.Called(faa)
+	return args.Get(0).([]domain.NOTAM), args.Error(1)
+}
+
+func (m *ServiceMock) GetAirportForecast(ctx context.Context, faa string, at time.Time) (*domain.TAFForecast, error) {
+	args := m.Called(ctx, faa, at)
+	return args.Get(0).(*domain.TAFForecast), args.Error(1)
+}
+
+func (m *ServiceMock) PruneLogs(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ServiceMock) GetSchedulerJobs() ([]domain.SchedulerJob, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.SchedulerJob), args.Error(1)
+}
+
+func (m *ServiceMock) GetJobs() ([]domain.SchedulerJob, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.SchedulerJob), args.Error(1)
+}
+
+func (m *ServiceMock) PauseJob(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) ResumeJob(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) RunJob(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) ClaimJobRuns() ([]string, error) {
+	args := m.Called()
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *ServiceMock) ResolveTenant(apiKey string) (int64, error) {
+	args := m.Called(apiKey)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ServiceMock) CreateTenant(t *domain.Tenant) error {
+	args := m.Called(t)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) GetTenants() ([]domain.Tenant, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Tenant), args.Error(1)
+}
+
+func (m *ServiceMock) CreateAPIKey(key *domain.APIKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) DeleteAPIKey(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *ServiceMock) GetAPIKeys(tenantID int64) ([]domain.APIKey, error) {
+	args := m.Called(tenantID)
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
@@ -0,0 +1,329 @@
+// Package nasr reads the airport tables of the FAA 28-day NASR subscription,
+// as published in its CSV extract.
+package nasr
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// Files of the CSV extract. Only APT_BASE.csv is required, the others add
+// managers, runways and frequencies when present.
+const (
+	BaseFile      = "APT_BASE.csv"
+	ContactFile   = "APT_CON.csv"
+	RunwayFile    = "APT_RWY.csv"
+	RunwayEndFile = "APT_RWY_END.csv"
+	FrequencyFile = "FRQ.csv"
+)
+
+// Dataset is every airport of a NASR cycle with its runway ends and
+// frequencies, keyed by FAA code.
+type Dataset struct {
+	Airports    []domain.Airport
+	Runways     map[string][]domain.Runway
+	Frequencies map[string][]domain.Frequency
+}
+
+// Read loads the airports (SITE_TYPE_CODE "A", so no heliports or seaplane
+// bases) from the CSV files at the root of fsys. Fields use the formats of the
+// aviation API, such as "33-38-12.1186N" coordinates and "PU" ownership, so
+// seeded and synced airports look alike.
+func Read(fsys fs.FS) (*Dataset, error) {
+	ds := &Dataset{Runways: map[string][]domain.Runway{}, Frequencies: map[string][]domain.Frequency{}}
+
+	known := map[string]bool{}
+	err := eachRecord(fsys, BaseFile, func(row record) error {
+		faa := row.get("ARPT_ID")
+		if row.get("SITE_TYPE_CODE") != "A" || faa == "" || known[faa] {
+			return nil
+		}
+		known[faa] = true
+
+		a := domain.Airport{
+			SiteNumber:    row.get("SITE_NO"),
+			FacilityName:  row.get("ARPT_NAME"),
+			Faa:           faa,
+			Icao:          row.get("ICAO_ID"),
+			StateCode:     row.get("STATE_CODE"),
+			StateFull:     row.get("STATE_NAME"),
+			County:        row.get("COUNTY_NAME"),
+			City:          row.get("CITY"),
+			OwnershipType: row.get("OWNERSHIP_TYPE_CODE"),
+			UseType:       row.get("FACILITY_USE_CODE"),
+			Latitude:      coordinate(row, "LAT", 90),
+			Longitude:     coordinate(row, "LONG", 180),
+			AirportStatus: row.get("ARPT_STATUS"),
+			FuelTypes:     domain.ParseFuelTypes(row.get("FUEL_TYPES")),
+		}
+		if elev, err := strconv.ParseFloat(row.get("ELEV"), 64); err == nil {
+			a.ElevationFt = &elev
+		}
+		if variation, err := domain.ParseMagneticVariation(row.get("MAG_VARN") + row.get("MAG_HEMIS")); err == nil {
+			a.MagneticVariation = &variation
+		}
+		ds.Airports = append(ds.Airports, a)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ds.readContacts(fsys); err != nil {
+		return nil, err
+	}
+	if err := ds.readRunways(fsys, known); err != nil {
+		return nil, err
+	}
+	if err := ds.readFrequencies(fsys, known); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// coordinate reads the position column of prefix in decimal degrees, nil
+// when it is missing or invalid.
+func coordinate(row record, prefix string, limit float64) *float64 {
+	deg, err := domain.ParseCoordinate(dms(row, prefix), limit)
+	if err != nil {
+		return nil
+	}
+	return &deg
+}
+
+// dms joins the degree, minute, second and hemisphere columns of prefix into
+// "DD-MM-SS.ssssH", or returns the decimal column when they are missing.
+func dms(row record, prefix string) string {
+	deg, minutes, sec, hemis := row.get(prefix+"_DEG"), row.get(prefix+"_MIN"), row.get(prefix+"_SEC"), row.get(prefix+"_HEMIS")
+	if deg == "" || minutes == "" || sec == "" || hemis == "" {
+		return row.get(prefix + "_DECIMAL")
+	}
+	width := 2
+	if prefix == "LONG" {
+		width = 3
+	}
+	d, _ := strconv.Atoi(deg)
+	m, _ := strconv.Atoi(minutes)
+	return fmt.Sprintf("%0*d-%02d-%s%s", width, d, m, zeroPad(sec), hemis)
+}
+
+// zeroPad gives seconds two integer digits, "5.25" becoming "05.25".
+func zeroPad(sec string) string {
+	if i := strings.IndexByte(sec, '.'); i == 1 || (i < 0 && len(sec) == 1) {
+		return "0" + sec
+	}
+	return sec
+}
+
+func (ds *Dataset) readContacts(fsys fs.FS) error {
+	managers := map[string][2]string{}
+	err := eachOptionalRecord(fsys, ContactFile, func(row record) error {
+		if strings.EqualFold(row.get("TITLE"), "MANAGER") {
+			managers[row.get("ARPT_ID")] = [2]string{row.get("NAME"), row.get("PHONE_NO")}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i := range ds.Airports {
+		if m, ok := managers[ds.Airports[i].Faa]; ok {
+			ds.Airports[i].Manager, ds.Airports[i].ManagerPhone = m[0], m[1]
+		}
+	}
+	return nil
+}
+
+// readRunways joins the runway ends to the length, width and surface of their
+// runway. Ends without a true alignment fall back to the runway number, and
+// helipads are skipped.
+func (ds *Dataset) readRunways(fsys fs.FS, known map[string]bool) error {
+	type runway struct {
+		length, width int
+		surface       string
+	}
+	runways := map[string]runway{}
+	err := eachOptionalRecord(fsys, RunwayFile, func(row record) error {
+		length, _ := strconv.Atoi(row.get("RWY_LEN"))
+		width, _ := strconv.Atoi(row.get("RWY_WIDTH"))
+		runways[row.get("ARPT_ID")+" "+row.get("RWY_ID")] = runway{length, width, row.get("SURFACE_TYPE_CODE")}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return eachOptionalRecord(fsys, RunwayEndFile, func(row record) error {
+		faa, ident := row.get("ARPT_ID"), row.get("RWY_END_ID")
+		if !known[faa] {
+			return nil
+		}
+		heading, ok := runwayHeading(ident, row.get("TRUE_ALIGNMENT"))
+		if !ok {
+			return nil
+		}
+		rwy := runways[faa+" "+row.get("RWY_ID")]
+		ds.Runways[faa] = append(ds.Runways[faa], domain.Runway{
+			Ident:      ident,
+			HeadingDeg: heading,
+			LengthFt:   rwy.length,
+			WidthFt:    rwy.width,
+			Surface:    rwy.surface,
+			Cycle:      cycle(row),
+		})
+		return nil
+	})
+}
+
+// runwayHeading prefers the true alignment, else derives it from the runway
+// number ("17R" is roughly 170°).
+func runwayHeading(ident, trueAlignment string) (float64, bool) {
+	if heading, err := strconv.ParseFloat(trueAlignment, 64); err == nil {
+		return heading, true
+	}
+	number, err := strconv.Atoi(strings.TrimRight(ident, "LCRW"))
+	if err != nil || number < 1 || number > 36 {
+		return 0, false
+	}
+	return float64(number * 10), true
+}
+
+// readFrequencies reads the frequencies serving each airport. FREQ may carry
+// remarks after the number, and emergency frequencies are skipped.
+func (ds *Dataset) readFrequencies(fsys fs.FS, known map[string]bool) error {
+	return eachOptionalRecord(fsys, FrequencyFile, func(row record) error {
+		faa := row.get("SERVICED_FACILITY")
+		if !known[faa] {
+			return nil
+		}
+		fields := strings.Fields(row.get("FREQ"))
+		if len(fields) == 0 {
+			return nil
+		}
+		mhz, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil
+		}
+		use := row.get("FREQ_USE")
+		freqType := frequencyType(use)
+		if freqType == "" {
+			return nil
+		}
+		ds.Frequencies[faa] = append(ds.Frequencies[faa], domain.Frequency{
+			Type: freqType, Description: use, FrequencyMHz: mhz, Cycle: cycle(row),
+		})
+		return nil
+	})
+}
+
+// frequencyUses maps NASR FREQ_USE prefixes to the frequency types OurAirports
+// uses, most specific first.
+var frequencyUses = []struct{ prefix, freqType string }{
+	{"EMERG", ""},
+	{"D-ATIS", "ATIS"},
+	{"ATIS", "ATIS"},
+	{"LCL", "TWR"},
+	{"GND", "GND"},
+	{"CD", "CLD"},
+	{"APCH", "APP"},
+	{"DEP", "DEP"},
+	{"CTAF", "CTAF"},
+	{"UNICOM", "UNIC"},
+}
+
+func frequencyType(use string) string {
+	use = strings.ToUpper(use)
+	for _, u := range frequencyUses {
+		if strings.HasPrefix(use, u.prefix) {
+			return u.freqType
+		}
+	}
+	return "MISC"
+}
+
+// cycle is the ident of the AIRAC cycle a row is effective from, read off its
+// EFF_DATE such as "2025/01/23", or empty when missing.
+func cycle(row record) string {
+	effective, err := time.Parse("2006/01/02", row.get("EFF_DATE"))
+	if err != nil {
+		return ""
+	}
+	return aviation.AIRACCycleAt(effective).Ident
+}
+
+// record is one CSV row addressed by header name.
+type record struct {
+	header map[string]int
+	fields []string
+}
+
+func (r record) get(name string) string {
+	i, ok := r.header[name]
+	if !ok || i >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[i])
+}
+
+// eachOptionalRecord is eachRecord for a file the extract may lack.
+func eachOptionalRecord(fsys fs.FS, file string, fn func(record) error) error {
+	err := eachRecord(fsys, file, fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// eachRecord reads the named file and calls fn for every row, stopping at the
+// first error fn returns.
+func eachRecord(fsys fs.FS, file string, fn func(record) error) error {
+	f, err := fsys.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", file, err)
+	}
+	defer f.Close()
+
+	// The extract starts with a UTF-8 byte order mark, which would otherwise
+	// keep the first header from being unquoted
+	br := bufio.NewReader(f)
+	if r, _, err := br.ReadRune(); err == nil && r != '\ufeff' {
+		_ = br.UnreadRune()
+	}
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	reader.LazyQuotes = true
+
+	names, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read %s header: %w", file, err)
+	}
+	header := make(map[string]int, len(names))
+	for i, name := range names {
+		header[strings.TrimSpace(name)] = i
+	}
+
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if err := fn(record{header: header, fields: fields}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package nasr
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const baseCSV = "\ufeff" + `"EFF_DATE","SITE_NO","SITE_TYPE_CODE","STATE_CODE","ARPT_ID","CITY","STATE_NAME","COUNTY_NAME","ARPT_NAME","OWNERSHIP_TYPE_CODE","FACILITY_USE_CODE","LAT_DEG","LAT_MIN","LAT_SEC","LAT_HEMIS","LAT_DECIMAL","LONG_DEG","LONG_MIN","LONG_SEC","LONG_HEMIS","LONG_DECIMAL","ELEV","MAG_VARN","MAG_HEMIS","ARPT_STATUS","ICAO_ID","FUEL_TYPES"
+"2025/01/23","03430.*A","A","GA","ATL","ATLANTA","GEORGIA","FULTON","HARTSFIELD/JACKSON ATLANTA INTL","PU","PU","33","38","12.1186","N","33.63670","84","25","40.3104","W","-84.42786","1026.2","5","W","O","KATL","100LL,A"
+"2025/01/23","03431.1*H","H","GA","4GA1","ATLANTA","GEORGIA","FULTON","GRADY MEMORIAL HOSPITAL","PR","PR","33","45","3","N","33.75","84","23","0","W","-84.38","1050","","","O","",""
+`
+
+const contactCSV = `"EFF_DATE","SITE_NO","ARPT_ID","TITLE","NAME","PHONE_NO"
+"2025/01/23","03430.*A","ATL","OWNER","CITY OF ATLANTA","404-530-6600"
+"2025/01/23","03430.*A","ATL","MANAGER","BALRAM BHEODARI","404-530-6600"
+`
+
+const runwayCSV = `"EFF_DATE","SITE_NO","ARPT_ID","RWY_ID","RWY_LEN","RWY_WIDTH","SURFACE_TYPE_CODE"
+"2025/01/23","03430.*A","ATL","08L/26R","9000","150","CONC"
+"2025/01/23","03430.*A","ATL","H1","60","60","CONC"
+`
+
+const runwayEndCSV = `"EFF_DATE","SITE_NO","ARPT_ID","RWY_ID","RWY_END_ID","TRUE_ALIGNMENT"
+"2025/01/23","03430.*A","ATL","08L/26R","08L","86"
+"2025/01/23","03430.*A","ATL","08L/26R","26R","266"
+"2025/01/23","03430.*A","ATL","H1","H1",""
+"2025/01/23","03430.*A","ATL","17/35","17",""
+`
+
+const frequencyCSV = `"EFF_DATE","FACILITY","SERVICED_FACILITY","FREQ","FREQ_USE"
+"2025/01/23","ATL","ATL","119.1","LCL/P"
+"2025/01/23","ATL","ATL","125.55 ;SOUTH","D-ATIS"
+"2025/01/23","ATL","ATL","121.5","EMERG"
+"2025/01/23","ZTL","4GA1","123.0","UNICOM"
+`
+
+func TestRead(t *testing.T) {
+	ds, err := Read(fstest.MapFS{
+		BaseFile:      {Data: []byte(baseCSV)},
+		ContactFile:   {Data: []byte(contactCSV)},
+		RunwayFile:    {Data: []byte(runwayCSV)},
+		RunwayEndFile: {Data: []byte(runwayEndCSV)},
+		FrequencyFile: {Data: []byte(frequencyCSV)},
+	})
+	assert.NoError(t, err)
+
+	elev, variation := 1026.2, -5.0
+	lat, _ := domain.ParseCoordinate("33-38-12.1186N", 90)
+	lon, _ := domain.ParseCoordinate("084-25-40.3104W", 180)
+	assert.InDelta(t, 33.636700, lat, 1e-6)
+	assert.InDelta(t, -84.427864, lon, 1e-6)
+	assert.Equal(t, []domain.Airport{{
+		SiteNumber:        "03430.*A",
+		FacilityName:      "HARTSFIELD/JACKSON ATLANTA INTL",
+		Faa:               "ATL",
+		Icao:              "KATL",
+		StateCode:         "GA",
+		StateFull:         "GEORGIA",
+		County:            "FULTON",
+		City:              "ATLANTA",
+		OwnershipType:     "PU",
+		UseType:           "PU",
+		Manager:           "BALRAM BHEODARI",
+		ManagerPhone:      "404-530-6600",
+		Latitude:          &lat,
+		Longitude:         &lon,
+		AirportStatus:     "O",
+		ElevationFt:       &elev,
+		MagneticVariation: &variation,
+		FuelTypes:         []string{domain.Fuel100LL, domain.FuelJetA},
+	}}, ds.Airports, "Heliports are left out")
+	assert.Empty(t, ds.Airports[0].Validate())
+
+	assert.Equal(t, map[string][]domain.Runway{"ATL": {
+		{Ident: "08L", HeadingDeg: 86, LengthFt: 9000, WidthFt: 150, Surface: "CONC", Cycle: "2501"},
+		{Ident: "26R", HeadingDeg: 266, LengthFt: 9000, WidthFt: 150, Surface: "CONC", Cycle: "2501"},
+		{Ident: "17", HeadingDeg: 170, Cycle: "2501"},
+	}}, ds.Runways)
+
+	assert.Equal(t, map[string][]domain.Frequency{"ATL": {
+		{Type: "TWR", Description: "LCL/P", FrequencyMHz: 119.1, Cycle: "2501"},
+		{Type: "ATIS", Description: "D-ATIS", FrequencyMHz: 125.55, Cycle: "2501"},
+	}}, ds.Frequencies)
+}
+
+func TestReadWithoutOptionalFiles(t *testing.T) {
+	ds, err := Read(fstest.MapFS{BaseFile: {Data: []byte(baseCSV)}})
+	assert.NoError(t, err)
+	assert.Len(t, ds.Airports, 1)
+	assert.Empty(t, ds.Runways)
+
+	_, err = Read(fstest.MapFS{})
+	assert.ErrorContains(t, err, "failed to open APT_BASE.csv")
+}
+
+func TestDMS(t *testing.T) {
+	row := record{
+		header: map[string]int{"LAT_DEG": 0, "LAT_MIN": 1, "LAT_SEC": 2, "LAT_HEMIS": 3, "LONG_DECIMAL": 4},
+		fields: []string{"9", "5", "7.5", "S", "-120.5"},
+	}
+	assert.Equal(t, "09-05-07.5S", dms(row, "LAT"))
+	assert.Equal(t, "-120.5", dms(row, "LONG"), "Falls back to the decimal column")
+}
@@ -0,0 +1,57 @@
+package nasr
+
+import (
+	"io/fs"
+	"strconv"
+
+	"aviation-weather/internal/domain"
+)
+
+// NavaidFile is the base table of the NAVAID extract, published apart from
+// the airport one as NAV_CSV.zip.
+const NavaidFile = "NAV_BASE.csv"
+
+// ReadNavaids loads the VOR, NDB and DME stations from the NAV_BASE.csv at the
+// root of fsys. Navaids without a position, and any repeat of an ident and
+// type, are skipped.
+func ReadNavaids(fsys fs.FS) ([]domain.Navaid, error) {
+	var navaids []domain.Navaid
+	seen := map[string]bool{}
+	err := eachRecord(fsys, NavaidFile, func(row record) error {
+		ident, navType := row.get("NAV_ID"), row.get("NAV_TYPE")
+		key := ident + " " + navType
+		if ident == "" || !domain.IsNavaidType(navType) || seen[key] {
+			return nil
+		}
+		lat, lon := coordinate(row, "LAT", 90), coordinate(row, "LONG", 180)
+		if lat == nil || lon == nil {
+			return nil
+		}
+		seen[key] = true
+
+		n := domain.Navaid{
+			Ident:     ident,
+			Type:      navType,
+			Name:      row.get("NAME"),
+			City:      row.get("CITY"),
+			StateCode: row.get("STATE_CODE"),
+			Channel:   row.get("CHAN"),
+			Latitude:  *lat,
+			Longitude: *lon,
+			Status:    row.get("NAV_STATUS"),
+			Cycle:     cycle(row),
+		}
+		if freq, err := strconv.ParseFloat(row.get("FREQ"), 64); err == nil && freq > 0 {
+			n.Frequency = &freq
+		}
+		if elev, err := strconv.ParseFloat(row.get("ELEV"), 64); err == nil {
+			n.ElevationFt = &elev
+		}
+		navaids = append(navaids, n)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return navaids, nil
+}
@@ -0,0 +1,54 @@
+package nasr
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const navaidCSV = "\ufeff" + `"EFF_DATE","NAV_ID","NAV_TYPE","STATE_CODE","CITY","NAME","LAT_DEG","LAT_MIN","LAT_SEC","LAT_HEMIS","LAT_DECIMAL","LONG_DEG","LONG_MIN","LONG_SEC","LONG_HEMIS","LONG_DECIMAL","ELEV","FREQ","CHAN","NAV_STATUS"
+"2025/01/23","ATL","VORTAC","GA","ATLANTA","ATLANTA","33","37","45.2","N","33.629222","84","26","06.3","W","-84.435083","1061","116.9","116X","OPERATIONAL IFR"
+"2025/01/23","ATL","VORTAC","GA","ATLANTA","ATLANTA DUPLICATE","33","37","45.2","N","33.629222","84","26","06.3","W","-84.435083","1061","116.9","116X","OPERATIONAL IFR"
+"2025/01/23","GQ","NDB","GA","ATLANTA","GLYNCO","","","","","31.25","","","","","-81.47","","400","","OPERATIONAL IFR"
+"2025/01/23","IATL","DME","GA","ATLANTA","HARTSFIELD","","","","","33.64","","","","","-84.43","1020","","44X","OPERATIONAL IFR"
+"2025/01/23","ATL","FAN MARKER","GA","ATLANTA","ATLANTA FM","","","","","33.6","","","","","-84.4","","75","","OPERATIONAL IFR"
+"2025/01/23","XYZ","VOR","GA","NOWHERE","NO POSITION","","","","","","","","","","","","110.0","","OPERATIONAL VFR ONLY"
+`
+
+func TestReadNavaids(t *testing.T) {
+	navaids, err := ReadNavaids(fstest.MapFS{NavaidFile: {Data: []byte(navaidCSV)}})
+	assert.NoError(t, err)
+	if !assert.Len(t, navaids, 3) {
+		return
+	}
+
+	atl := navaids[0]
+	assert.Equal(t, "ATL", atl.Ident)
+	assert.Equal(t, domain.NavaidVORTAC, atl.Type)
+	assert.Equal(t, "ATLANTA", atl.Name)
+	assert.Equal(t, "GA", atl.StateCode)
+	assert.Equal(t, "116X", atl.Channel)
+	assert.InDelta(t, 33.629222, atl.Latitude, 1e-5)
+	assert.InDelta(t, -84.435083, atl.Longitude, 1e-5)
+	if assert.NotNil(t, atl.Frequency) {
+		assert.Equal(t, 116.9, *atl.Frequency)
+	}
+	if assert.NotNil(t, atl.ElevationFt) {
+		assert.Equal(t, 1061.0, *atl.ElevationFt)
+	}
+	assert.Equal(t, "OPERATIONAL IFR", atl.Status)
+	assert.Equal(t, "2501", atl.Cycle)
+
+	assert.Equal(t, "GQ", navaids[1].Ident)
+	assert.Equal(t, 31.25, navaids[1].Latitude)
+	assert.Nil(t, navaids[1].ElevationFt)
+
+	assert.Equal(t, domain.NavaidDME, navaids[2].Type)
+	assert.Nil(t, navaids[2].Frequency)
+
+	_, err = ReadNavaids(fstest.MapFS{})
+	assert.ErrorContains(t, err, "failed to open NAV_BASE.csv")
+}
@@ -0,0 +1,123 @@
+// Package notam reads the NOTAMs of the FAA NOTAM API, which needs the client
+// ID and secret of a registered application.
+package notam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// pageSize is the most NOTAMs the API returns per page.
+const pageSize = 1000
+
+// Client fetches NOTAMs from BaseURL.
+type Client struct {
+	BaseURL      string
+	clientID     string
+	clientSecret string
+	client       *http.Client
+}
+
+func NewClient(client *http.Client, clientID, clientSecret string) *Client {
+	return &Client{BaseURL: "https://external-api.faa.gov", clientID: clientID, clientSecret: clientSecret, client: client}
+}
+
+type page struct {
+	TotalPages int `json:"totalPages"`
+	Items      []struct {
+		Properties struct {
+			CoreNOTAMData struct {
+				NOTAM notam `json:"notam"`
+			} `json:"coreNOTAMData"`
+		} `json:"properties"`
+	} `json:"items"`
+}
+
+type notam struct {
+	ID             string `json:"id"`
+	Number         string `json:"number"`
+	Type           string `json:"type"`
+	Classification string `json:"classification"`
+	Issued         string `json:"issued"`
+	EffectiveStart string `json:"effectiveStart"`
+	EffectiveEnd   string `json:"effectiveEnd"`
+	Text           string `json:"text"`
+}
+
+// NOTAMs returns the NOTAMs the FAA has in effect or upcoming for a location
+// by ICAO code, page after page.
+func (c *Client) NOTAMs(ctx context.Context, icao string) ([]domain.NOTAM, error) {
+	notams := []domain.NOTAM{}
+	for pageNum := 1; ; pageNum++ {
+		p, err := c.page(ctx, icao, pageNum)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range p.Items {
+			n := item.Properties.CoreNOTAMData.NOTAM
+			notams = append(notams, domain.NOTAM{
+				ID:             n.ID,
+				Number:         n.Number,
+				Type:           n.Type,
+				Classification: n.Classification,
+				IssuedAt:       parseTime(n.Issued),
+				EffectiveStart: parseTime(n.EffectiveStart),
+				EffectiveEnd:   parseTime(n.EffectiveEnd),
+				Text:           strings.TrimSpace(n.Text),
+			})
+		}
+		if pageNum >= p.TotalPages {
+			return notams, nil
+		}
+	}
+}
+
+func (c *Client) page(ctx context.Context, icao string, pageNum int) (*page, error) {
+	query := url.Values{
+		"responseFormat": {"geoJson"},
+		"icaoLocation":   {icao},
+		"pageSize":       {strconv.Itoa(pageSize)},
+		"pageNum":        {strconv.Itoa(pageNum)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/notamapi/v1/notams?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for NOTAMs of %s: %w", icao, err)
+	}
+	req.Header.Set("client_id", c.clientID)
+	req.Header.Set("client_secret", c.clientSecret)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed for NOTAMs of %s: %w", icao, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("NOTAM API returned %s for NOTAMs of %s", resp.Status, icao)
+	}
+
+	var p page
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal NOTAMs of %s: %w", icao, err)
+	}
+	return &p, nil
+}
+
+// parseTime parses a time of the API, or returns nil for one it leaves open
+// such as the end "PERM" of a permanent NOTAM.
+func parseTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
@@ -0,0 +1,62 @@
+package notam
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const closureJSON = `{"totalPages":2,"items":[{"type":"Feature","properties":{"coreNOTAMData":{"notam":{
+	"id":"NOTAM_1_73849637","number":"10/132","type":"N","classification":"DOM","issued":"2024-10-03T14:58:00.000Z",
+	"effectiveStart":"2024-10-03T15:00:00.000Z","effectiveEnd":"2024-10-05T23:59:00.000Z","text":" RWY 16R/34L CLSD ","icaoLocation":"KDEN"}}}}]}`
+
+const permanentJSON = `{"totalPages":2,"items":[{"type":"Feature","properties":{"coreNOTAMData":{"notam":{
+	"id":"NOTAM_1_70000001","number":"06/001","type":"N","classification":"DOM","issued":"2024-06-01T12:00:00.000Z",
+	"effectiveStart":"2024-06-01T12:00:00.000Z","effectiveEnd":"PERM","text":"TWY B3 CHANGED TO B4","icaoLocation":"KDEN"}}}}]}`
+
+func TestNOTAMs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/notamapi/v1/notams", r.URL.Path)
+		assert.Equal(t, "KDEN", r.URL.Query().Get("icaoLocation"))
+		assert.Equal(t, "id", r.Header.Get("client_id"))
+		assert.Equal(t, "secret", r.Header.Get("client_secret"))
+		switch r.URL.Query().Get("pageNum") {
+		case "1":
+			w.Write([]byte(closureJSON))
+		default:
+			w.Write([]byte(permanentJSON))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client(), "id", "secret")
+	c.BaseURL = srv.URL
+
+	notams, err := c.NOTAMs(context.Background(), "KDEN")
+	assert.NoError(t, err)
+	issued, start, end := time.Date(2024, 10, 3, 14, 58, 0, 0, time.UTC), time.Date(2024, 10, 3, 15, 0, 0, 0, time.UTC), time.Date(2024, 10, 5, 23, 59, 0, 0, time.UTC)
+	permIssued := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, []domain.NOTAM{
+		{ID: "NOTAM_1_73849637", Number: "10/132", Type: "N", Classification: "DOM", IssuedAt: &issued, EffectiveStart: &start, EffectiveEnd: &end, Text: "RWY 16R/34L CLSD"},
+		{ID: "NOTAM_1_70000001", Number: "06/001", Type: "N", Classification: "DOM", IssuedAt: &permIssued, EffectiveStart: &permIssued, Text: "TWY B3 CHANGED TO B4"},
+	}, notams, "Every page is read, and a permanent NOTAM has no end")
+}
+
+func TestNOTAMsErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid client", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client(), "id", "wrong")
+	c.BaseURL = srv.URL
+
+	_, err := c.NOTAMs(context.Background(), "KDEN")
+	assert.EqualError(t, err, "NOTAM API returned 401 Unauthorized for NOTAMs of KDEN")
+}
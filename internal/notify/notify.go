@@ -0,0 +1,222 @@
+// Package notify sends alert messages and weather digests over email and Slack.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// Message is a rendered notification.
+type Message struct {
+	Subject string
+	Text    string
+}
+
+// Notifier delivers a message to one target of its channel, such as an email
+// address or a Slack incoming webhook URL.
+type Notifier interface {
+	Send(ctx context.Context, target string, msg Message) error
+}
+
+// Slack posts messages to Slack incoming webhooks.
+type Slack struct {
+	client *http.Client
+}
+
+func NewSlack(client *http.Client) *Slack {
+	return &Slack{client: client}
+}
+
+// Send posts msg to the incoming webhook at webhookURL.
+func (s *Slack) Send(ctx context.Context, webhookURL string, msg Message) error {
+	body, err := json.Marshal(map[string]string{"text": "*" + msg.Subject + "*\n" + msg.Text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SMTP sends plain text email through one relay.
+type SMTP struct {
+	addr string
+	from string
+	auth smtp.Auth
+
+	// sendMail is smtp.SendMail, replaceable in tests
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTP returns an email notifier for the relay at host:port. Authentication
+// is skipped when username is empty.
+func NewSMTP(host string, port int, username, password, from string) *SMTP {
+	s := &SMTP{addr: host + ":" + strconv.Itoa(port), from: from, sendMail: smtp.SendMail}
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s
+}
+
+// headerLine keeps a rendered value on its header line, so a line break in a
+// rule name can't start headers of its own.
+var headerLine = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// Send mails msg to the address to. The relay call itself can't be cancelled,
+// so ctx is only checked before sending.
+func (s *SMTP) Send(ctx context.Context, to string, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerLine.Replace(msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Text, "\n", "\r\n"))
+
+	if err := s.sendMail(s.addr, s.auth, s.from, []string{to}, []byte(b.String())); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	return nil
+}
+
+// AlertData is what the alert templates render.
+type AlertData struct {
+	Airport     domain.Airport
+	Rule        domain.AlertRule
+	Alert       domain.Alert
+	Observation domain.Observation
+}
+
+var (
+	subjectTemplate = template.Must(template.New("subject").Parse(
+		`[{{.Rule.Name}}] {{.Airport.Faa}} {{.Alert.Metric}} {{.Alert.Value}}`))
+
+	textTemplate = template.Must(template.New("text").Parse(`{{.Alert.Message}}
+
+Airport: {{.Airport.Faa}}{{with .Airport.FacilityName}} - {{.}}{{end}}{{with .Airport.City}}, {{.}}{{end}}{{with .Airport.StateCode}} {{.}}{{end}}
+Condition: {{.Observation.Condition}}
+{{with .Observation.RawMETAR}}METAR: {{.}}
+{{end}}Observed: {{.Observation.ObservedAt.UTC.Format "2006-01-02 15:04 MST"}}
+`))
+)
+
+// AlertMessage renders the notification of one alert.
+func AlertMessage(data AlertData) (Message, error) {
+	var subject, text strings.Builder
+	if err := subjectTemplate.Execute(&subject, data); err != nil {
+		return Message{}, err
+	}
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return Message{}, err
+	}
+	return Message{Subject: subject.String(), Text: text.String()}, nil
+}
+
+// DigestMessage renders a weather digest, one line per airport, followed by
+// one line per TAF forecast.
+func DigestMessage(d domain.Digest) Message {
+	faas := make([]string, len(d.Airports))
+	var text strings.Builder
+	fmt.Fprintf(&text, "Weather digest of %s\n\n", d.GeneratedAt.UTC().Format("2006-01-02 15:04 MST"))
+	for i, a := range d.Airports {
+		faas[i] = a.Faa
+		text.WriteString(digestLine(a))
+		text.WriteByte('\n')
+	}
+	if len(d.Forecasts) > 0 {
+		text.WriteString("\nForecasts\n\n")
+		for _, f := range d.Forecasts {
+			text.WriteString(forecastLine(f))
+			text.WriteByte('\n')
+		}
+	}
+	return Message{Subject: "Weather digest: " + strings.Join(faas, ", "), Text: text.String()}
+}
+
+// forecastLine summarizes the TAF of one airport, e.g.
+// "DEN (KDEN): now VFR, P6SM; TEMPO 3SM TSRA; by 18:00Z MVFR, 5SM BR, ceiling 2500ft".
+func forecastLine(f domain.ForecastSummary) string {
+	parts := []string{"now " + f.Now}
+	parts = append(parts, f.Temporary...)
+	if f.LaterAt != nil {
+		parts = append(parts, "by "+f.LaterAt.UTC().Format("15:04Z")+" "+f.Later)
+	}
+	return fmt.Sprintf("%s (%s): %s", f.Faa, f.Station, strings.Join(parts, "; "))
+}
+
+// digestLine summarizes one airport, e.g.
+// "DEN Denver Intl: VFR, Sunny, 21C, wind 020 at 12G22KT, visibility 10SM (observed 12:00Z)".
+func digestLine(a domain.BoardEntry) string {
+	line := a.Faa
+	if a.FacilityName != "" {
+		line += " " + a.FacilityName
+	}
+	if a.Weather == "" && a.ObservedAt == nil {
+		return line + ": no weather synced"
+	}
+
+	var parts []string
+	if a.FlightCategory != "" {
+		parts = append(parts, a.FlightCategory)
+	}
+	if a.Weather != "" {
+		parts = append(parts, a.Weather)
+	}
+	if a.TemperatureC != nil {
+		parts = append(parts, fmt.Sprintf("%.0fC", *a.TemperatureC))
+	}
+	if a.WindSpeedKt != nil {
+		wind := fmt.Sprintf("wind %03d at %.0f", deref(a.WindDirDeg), *a.WindSpeedKt)
+		if a.WindGustKt != nil {
+			wind += fmt.Sprintf("G%.0f", *a.WindGustKt)
+		}
+		parts = append(parts, wind+"KT")
+	}
+	if a.VisibilitySM != nil {
+		parts = append(parts, fmt.Sprintf("visibility %gSM", *a.VisibilitySM))
+	}
+	line += ": " + strings.Join(parts, ", ")
+	if a.ObservedAt != nil {
+		line += " (observed " + a.ObservedAt.UTC().Format("15:04Z") + ")"
+	}
+	return line
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
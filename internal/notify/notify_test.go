@@ -0,0 +1,130 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleData = AlertData{
+	Airport: domain.Airport{Faa: "DFW", FacilityName: "Dallas-Fort Worth Intl", City: "Dallas", StateCode: "TX"},
+	Rule:    domain.AlertRule{Name: "Strong wind"},
+	Alert:   domain.Alert{Faa: "DFW", Metric: domain.MetricWindSpeed, Value: "30", Message: "DFW: wind_speed_kt 30 > 25 (Strong wind)"},
+	Observation: domain.Observation{
+		Condition:  "Windy",
+		RawMETAR:   "KDFW 011253Z 18030KT 10SM FEW050 30/18 A2992",
+		ObservedAt: time.Date(2024, 6, 1, 12, 53, 0, 0, time.UTC),
+	},
+}
+
+func TestAlertMessage(t *testing.T) {
+	msg, err := AlertMessage(sampleData)
+	assert.NoError(t, err)
+	assert.Equal(t, "[Strong wind] DFW wind_speed_kt 30", msg.Subject)
+	assert.Equal(t, `DFW: wind_speed_kt 30 > 25 (Strong wind)
+
+Airport: DFW - Dallas-Fort Worth Intl, Dallas TX
+Condition: Windy
+METAR: KDFW 011253Z 18030KT 10SM FEW050 30/18 A2992
+Observed: 2024-06-01 12:53 UTC
+`, msg.Text)
+
+	data := sampleData
+	data.Observation.RawMETAR = ""
+	msg, err = AlertMessage(data)
+	assert.NoError(t, err)
+	assert.NotContains(t, msg.Text, "METAR")
+}
+
+func TestSlackSend(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		if strings.HasSuffix(r.URL.Path, "/revoked") {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	s := NewSlack(srv.Client())
+	assert.NoError(t, s.Send(context.Background(), srv.URL+"/hook", Message{Subject: "Subject", Text: "Body"}))
+	assert.Equal(t, "*Subject*\nBody", got["text"])
+
+	assert.EqualError(t, s.Send(context.Background(), srv.URL+"/revoked", Message{}), "slack: unexpected status 403")
+}
+
+func TestSMTPSend(t *testing.T) {
+	s := NewSMTP("mail.example.com", 587, "user", "pass", "alerts@example.com")
+
+	var addr, from string
+	var to []string
+	var body string
+	s.sendMail = func(a string, auth smtp.Auth, f string, t []string, msg []byte) error {
+		addr, from, to, body = a, f, t, string(msg)
+		return nil
+	}
+
+	assert.NoError(t, s.Send(context.Background(), "ops@example.com", Message{Subject: "Subject", Text: "line 1\nline 2"}))
+	assert.Equal(t, "mail.example.com:587", addr)
+	assert.Equal(t, "alerts@example.com", from)
+	assert.Equal(t, []string{"ops@example.com"}, to)
+	assert.Contains(t, body, "To: ops@example.com\r\n")
+	assert.Contains(t, body, "Subject: Subject\r\n")
+	assert.True(t, strings.HasSuffix(body, "\r\n\r\nline 1\r\nline 2"))
+
+	assert.NoError(t, s.Send(context.Background(), "ops@example.com", Message{Subject: "[Wind\r\nBcc: all@example.com] DFW", Text: "Body"}))
+	assert.Contains(t, body, "Subject: [Wind Bcc: all@example.com] DFW\r\n")
+	assert.NotContains(t, body, "\r\nBcc:")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, s.Send(ctx, "ops@example.com", Message{}), context.Canceled)
+}
+
+func TestDigestMessage(t *testing.T) {
+	observedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	temperature, windDir, windSpeed, gust, visibility := 21.0, 20, 12.0, 22.0, 10.0
+	later := time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC)
+
+	msg := DigestMessage(domain.Digest{
+		GeneratedAt: time.Date(2024, 6, 1, 12, 30, 0, 0, time.UTC),
+		Airports: []domain.BoardEntry{
+			{
+				Faa: "DEN", FacilityName: "Denver Intl", Weather: "Sunny", FlightCategory: "VFR",
+				TemperatureC: &temperature, WindDirDeg: &windDir, WindSpeedKt: &windSpeed, WindGustKt: &gust,
+				VisibilitySM: &visibility, ObservedAt: &observedAt,
+			},
+			{Faa: "APA", FacilityName: "Centennial", Weather: "Clear"},
+			{Faa: "XXX"},
+		},
+		Forecasts: []domain.ForecastSummary{
+			{
+				Faa: "DEN", Station: "KDEN", Now: "VFR, P6SM", Temporary: []string{"TEMPO 3SM TSRA"},
+				LaterAt: &later, Later: "MVFR, 5SM BR, ceiling 2500ft",
+			},
+			{Faa: "APA", Station: "KAPA", Now: "VFR, wind 180 at 8KT, P6SM"},
+		},
+	})
+	assert.Equal(t, "Weather digest: DEN, APA, XXX", msg.Subject)
+	assert.Equal(t, `Weather digest of 2024-06-01 12:30 UTC
+
+DEN Denver Intl: VFR, Sunny, 21C, wind 020 at 12G22KT, visibility 10SM (observed 12:00Z)
+APA Centennial: Clear
+XXX: no weather synced
+
+Forecasts
+
+DEN (KDEN): now VFR, P6SM; TEMPO 3SM TSRA; by 18:30Z MVFR, 5SM BR, ceiling 2500ft
+APA (KAPA): now VFR, wind 180 at 8KT, P6SM
+`, msg.Text)
+}
@@ -0,0 +1,219 @@
+// Package ourairports reads the public-domain OurAirports CSV datasets.
+package ourairports
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"aviation-weather/internal/domain"
+)
+
+// Client downloads OurAirports datasets from BaseURL.
+type Client struct {
+	BaseURL string
+	client  *http.Client
+}
+
+func NewClient(client *http.Client) *Client {
+	return &Client{BaseURL: "https://davidmegginson.github.io/ourairports-data", client: client}
+}
+
+// Frequencies returns the COM frequencies of the airport listed under any of
+// idents. OurAirports keys US airports by ICAO code when they have one and by
+// FAA code otherwise, so callers usually pass both.
+func (c *Client) Frequencies(ctx context.Context, idents ...string) ([]domain.Frequency, error) {
+	frequencies := []domain.Frequency{}
+	err := c.eachRecord(ctx, "airport-frequencies.csv", func(row record) error {
+		if !matchesIdent(row.get("airport_ident"), idents) {
+			return nil
+		}
+		f, err := frequency(row)
+		if err != nil {
+			return err
+		}
+		frequencies = append(frequencies, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return frequencies, nil
+}
+
+// AllFrequencies returns the COM frequencies of every airport, keyed by
+// upper-cased airport ident, from a single download.
+func (c *Client) AllFrequencies(ctx context.Context) (map[string][]domain.Frequency, error) {
+	frequencies := map[string][]domain.Frequency{}
+	err := c.eachRecord(ctx, "airport-frequencies.csv", func(row record) error {
+		f, err := frequency(row)
+		if err != nil {
+			return err
+		}
+		ident := strings.ToUpper(row.get("airport_ident"))
+		frequencies[ident] = append(frequencies[ident], f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return frequencies, nil
+}
+
+func frequency(row record) (domain.Frequency, error) {
+	mhz, err := strconv.ParseFloat(row.get("frequency_mhz"), 64)
+	if err != nil {
+		return domain.Frequency{}, fmt.Errorf("invalid frequency %q for %s: %w", row.get("frequency_mhz"), row.get("airport_ident"), err)
+	}
+	return domain.Frequency{
+		Type:         strings.ToUpper(row.get("type")),
+		Description:  row.get("description"),
+		FrequencyMHz: mhz,
+	}, nil
+}
+
+// Runways returns both ends of every open runway of the airport listed under any
+// of idents. Ends without a published true heading fall back to the runway
+// number, and helipads are skipped.
+func (c *Client) Runways(ctx context.Context, idents ...string) ([]domain.Runway, error) {
+	runways := []domain.Runway{}
+	err := c.eachRecord(ctx, "runways.csv", func(row record) error {
+		if !matchesIdent(row.get("airport_ident"), idents) {
+			return nil
+		}
+		runways = append(runways, runwayEnds(row)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return runways, nil
+}
+
+// AllRunways returns the runway ends of every airport, keyed by upper-cased
+// airport ident, from a single download.
+func (c *Client) AllRunways(ctx context.Context) (map[string][]domain.Runway, error) {
+	runways := map[string][]domain.Runway{}
+	err := c.eachRecord(ctx, "runways.csv", func(row record) error {
+		if ends := runwayEnds(row); len(ends) > 0 {
+			ident := strings.ToUpper(row.get("airport_ident"))
+			runways[ident] = append(runways[ident], ends...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return runways, nil
+}
+
+// runwayEnds reads both ends of an open runway, none for a closed one.
+func runwayEnds(row record) []domain.Runway {
+	if row.get("closed") == "1" {
+		return nil
+	}
+	length, _ := strconv.Atoi(row.get("length_ft"))
+	width, _ := strconv.Atoi(row.get("width_ft"))
+	var ends []domain.Runway
+	for _, end := range []string{"le", "he"} {
+		ident := row.get(end + "_ident")
+		heading, ok := runwayHeading(ident, row.get(end+"_heading_degT"))
+		if !ok {
+			continue
+		}
+		ends = append(ends, domain.Runway{
+			Ident:      ident,
+			HeadingDeg: heading,
+			LengthFt:   length,
+			WidthFt:    width,
+			Surface:    row.get("surface"),
+		})
+	}
+	return ends
+}
+
+// runwayHeading prefers the published true heading, else derives it from the
+// runway number ("17R" is roughly 170°).
+func runwayHeading(ident, trueHeading string) (float64, bool) {
+	if heading, err := strconv.ParseFloat(trueHeading, 64); err == nil {
+		return heading, true
+	}
+	number, err := strconv.Atoi(strings.TrimRight(ident, "LCRW"))
+	if err != nil || number < 1 || number > 36 {
+		return 0, false
+	}
+	return float64(number * 10), true
+}
+
+func matchesIdent(ident string, idents []string) bool {
+	for _, want := range idents {
+		if want != "" && strings.EqualFold(ident, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// record is one CSV row addressed by header name.
+type record struct {
+	header map[string]int
+	fields []string
+}
+
+func (r record) get(name string) string {
+	i, ok := r.header[name]
+	if !ok || i >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[i])
+}
+
+// eachRecord streams the named dataset and calls fn for every row, stopping at
+// the first error fn returns.
+func (c *Client) eachRecord(ctx context.Context, file string, fn func(record) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/"+file, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request for %s: %w", file, err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed for %s: %w", file, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("OurAirports returned %s for %s", resp.Status, file)
+	}
+
+	reader := csv.NewReader(resp.Body)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	names, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read %s header: %w", file, err)
+	}
+	header := make(map[string]int, len(names))
+	for i, name := range names {
+		header[strings.TrimSpace(name)] = i
+	}
+
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if err := fn(record{header: header, fields: fields}); err != nil {
+			return err
+		}
+	}
+}
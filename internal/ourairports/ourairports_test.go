@@ -0,0 +1,105 @@
+package ourairports
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const frequenciesCSV = `"id","airport_ref","airport_ident","type","description","frequency_mhz"
+70518,3384,"KDFW","ATIS","ATIS ARR",123.775
+70519,3384,"KDFW","twr","TWR E",126.55
+70600,6523,"00A","CTAF","CTAF",122.9
+`
+
+func TestFrequencies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/airport-frequencies.csv", r.URL.Path)
+		w.Write([]byte(frequenciesCSV))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL = srv.URL
+
+	frequencies, err := c.Frequencies(context.Background(), "kdfw", "DFW")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Frequency{
+		{Type: "ATIS", Description: "ATIS ARR", FrequencyMHz: 123.775},
+		{Type: "TWR", Description: "TWR E", FrequencyMHz: 126.55},
+	}, frequencies)
+
+	frequencies, err = c.Frequencies(context.Background(), "", "ZZZ")
+	assert.NoError(t, err)
+	assert.Empty(t, frequencies)
+}
+
+func TestFrequenciesErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL = srv.URL
+
+	_, err := c.Frequencies(context.Background(), "KDFW")
+	assert.EqualError(t, err, "OurAirports returned 404 Not Found for airport-frequencies.csv")
+}
+
+const runwaysCSV = `"id","airport_ref","airport_ident","length_ft","width_ft","surface","lighted","closed","le_ident","le_latitude_deg","le_longitude_deg","le_elevation_ft","le_heading_degT","le_displaced_threshold_ft","he_ident","he_latitude_deg","he_longitude_deg","he_elevation_ft","he_heading_degT","he_displaced_threshold_ft"
+1,3384,"KDFW",13401,200,"CON",1,0,"17R",32.9,-97.0,607,175.5,,"35L",32.8,-97.0,590,355.5,
+2,3384,"KDFW",9000,150,"ASP",1,0,"13L",,,,,,"31R",,,,,
+3,3384,"KDFW",4000,100,"ASP",0,1,"18",,,,,,"36",,,,,
+4,3384,"KDFW",60,60,"CON",0,0,"H1",,,,,,"",,,,,
+`
+
+func TestRunways(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/runways.csv", r.URL.Path)
+		w.Write([]byte(runwaysCSV))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL = srv.URL
+
+	runways, err := c.Runways(context.Background(), "KDFW", "DFW")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Runway{
+		{Ident: "17R", HeadingDeg: 175.5, LengthFt: 13401, WidthFt: 200, Surface: "CON"},
+		{Ident: "35L", HeadingDeg: 355.5, LengthFt: 13401, WidthFt: 200, Surface: "CON"},
+		{Ident: "13L", HeadingDeg: 130, LengthFt: 9000, WidthFt: 150, Surface: "ASP"},
+		{Ident: "31R", HeadingDeg: 310, LengthFt: 9000, WidthFt: 150, Surface: "ASP"},
+	}, runways)
+}
+
+func TestAllRunwaysAndFrequencies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/runways.csv":
+			w.Write([]byte(runwaysCSV))
+		case "/airport-frequencies.csv":
+			w.Write([]byte(frequenciesCSV))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.Client())
+	c.BaseURL = srv.URL
+
+	runways, err := c.AllRunways(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, runways, 1)
+	assert.Len(t, runways["KDFW"], 4, "Closed runways and helipads are skipped")
+
+	frequencies, err := c.AllFrequencies(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, frequencies["KDFW"], 2)
+	assert.Equal(t, []domain.Frequency{{Type: "CTAF", Description: "CTAF", FrequencyMHz: 122.9}}, frequencies["00A"])
+}
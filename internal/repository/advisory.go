@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// ReplaceAdvisories swaps the stored advisories for those of a fresh sync in
+// one transaction, so readers never see a partial set, and fills in their ids.
+func (r *Repository) ReplaceAdvisories(advisories []domain.Advisory) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM advisories`); err != nil {
+		return fmt.Errorf("failed to clear advisories: %w", err)
+	}
+
+	query := `
+		INSERT INTO advisories (
+			type, hazard, severity, altitude_low_ft, altitude_high_ft, valid_from, valid_to,
+			raw_text, area, min_lat, max_lat, min_lon, max_lon
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id
+	`
+	for i := range advisories {
+		a := &advisories[i]
+		areaJSON, err := json.Marshal(a.Area)
+		if err != nil {
+			return fmt.Errorf("failed to encode advisory area: %w", err)
+		}
+		box := aviation.BoundingBox(advisoryPolygon(a.Area))
+		if err := tx.QueryRowContext(ctx,
+			query,
+			a.Type, a.Hazard, a.Severity, a.AltitudeLowFt, a.AltitudeHighFt, a.ValidFrom, a.ValidTo,
+			a.RawText, areaJSON, box.MinLat, box.MaxLat, box.MinLon, box.MaxLon,
+		).Scan(&a.ID); err != nil {
+			return fmt.Errorf("failed to insert advisory: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit advisories: %w", err)
+	}
+	return nil
+}
+
+// GetAdvisories fetches the advisories still valid at at, by id.
+func (r *Repository) GetAdvisories(at time.Time) ([]domain.Advisory, error) {
+	return r.queryAdvisories(`SELECT `+advisoryColumns+` FROM advisories
+		WHERE valid_to IS NULL OR valid_to > $1
+		ORDER BY id`, at)
+}
+
+// GetAdvisoriesAt fetches the advisories still valid at at whose area
+// contains p. Their bounding boxes narrow the candidates down in SQL.
+func (r *Repository) GetAdvisoriesAt(p aviation.Point, at time.Time) ([]domain.Advisory, error) {
+	candidates, err := r.queryAdvisories(`SELECT `+advisoryColumns+` FROM advisories
+		WHERE (valid_to IS NULL OR valid_to > $1)
+		  AND min_lat <= $2 AND max_lat >= $2 AND min_lon <= $3 AND max_lon >= $3
+		ORDER BY id`, at, p.Lat, p.Lon)
+	if err != nil {
+		return nil, err
+	}
+
+	advisories := []domain.Advisory{}
+	for _, a := range candidates {
+		if aviation.PolygonContains(advisoryPolygon(a.Area), p) {
+			advisories = append(advisories, a)
+		}
+	}
+	return advisories, nil
+}
+
+func advisoryPolygon(area []domain.AdvisoryPoint) []aviation.Point {
+	polygon := make([]aviation.Point, 0, len(area))
+	for _, v := range area {
+		polygon = append(polygon, aviation.Point{Lat: v.Lat, Lon: v.Lon})
+	}
+	return polygon
+}
+
+const advisoryColumns = `id, type, hazard, severity, altitude_low_ft, altitude_high_ft, valid_from, valid_to, raw_text, area`
+
+func (r *Repository) queryAdvisories(query string, args ...any) ([]domain.Advisory, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query advisories: %w", err)
+	}
+	defer rows.Close()
+
+	advisories := []domain.Advisory{}
+	for rows.Next() {
+		var a domain.Advisory
+		var low, high sql.NullInt64
+		var validFrom, validTo sql.NullTime
+		var areaJSON []byte
+		if err := rows.Scan(&a.ID, &a.Type, &a.Hazard, &a.Severity, &low, &high, &validFrom, &validTo, &a.RawText, &areaJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan advisory row: %w", err)
+		}
+		if err := json.Unmarshal(areaJSON, &a.Area); err != nil {
+			return nil, fmt.Errorf("failed to decode area of advisory %d: %w", a.ID, err)
+		}
+		if low.Valid {
+			ft := int(low.Int64)
+			a.AltitudeLowFt = &ft
+		}
+		if high.Valid {
+			ft := int(high.Int64)
+			a.AltitudeHighFt = &ft
+		}
+		if validFrom.Valid {
+			a.ValidFrom = &validFrom.Time
+		}
+		if validTo.Valid {
+			a.ValidTo = &validTo.Time
+		}
+		advisories = append(advisories, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return advisories, nil
+}
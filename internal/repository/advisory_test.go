@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleArea = []domain.AdvisoryPoint{{Lat: 37, Lon: -102}, {Lat: 40, Lon: -102}, {Lat: 40, Lon: -94}, {Lat: 37, Lon: -94}}
+
+const sampleAreaJSON = `[{"lat":37,"lon":-102},{"lat":40,"lon":-102},{"lat":40,"lon":-94},{"lat":37,"lon":-94}]`
+
+func TestReplaceAdvisories(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	high := 45000
+	advisories := []domain.Advisory{{
+		Type: domain.AdvisorySIGMET, Hazard: "CONVECTIVE", Severity: 1, AltitudeHighFt: &high,
+		ValidFrom: &sampleTime, ValidTo: &sampleTime, RawText: "CONVECTIVE SIGMET 12C", Area: sampleArea,
+	}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM advisories`).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectQuery(`INSERT INTO advisories .* RETURNING id`).
+		WithArgs(domain.AdvisorySIGMET, "CONVECTIVE", 1, nil, &high, &sampleTime, &sampleTime,
+			"CONVECTIVE SIGMET 12C", []byte(sampleAreaJSON), 37.0, 40.0, -102.0, -94.0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	mock.ExpectCommit()
+	assert.NoError(t, r.ReplaceAdvisories(advisories))
+	assert.Equal(t, int64(9), advisories[0].ID)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM advisories`).WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+	assert.EqualError(t, r.ReplaceAdvisories(advisories), "failed to clear advisories: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAdvisoriesAt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	columns := []string{"id", "type", "hazard", "severity", "altitude_low_ft", "altitude_high_ft", "valid_from", "valid_to", "raw_text", "area"}
+	ict := aviation.Point{Lat: 37.65, Lon: -97.43}
+
+	// The second candidate's box holds the point but its triangle doesn't
+	mock.ExpectQuery(`FROM advisories\s+WHERE \(valid_to IS NULL OR valid_to > \$1\)\s+AND min_lat <= \$2 AND max_lat >= \$2 AND min_lon <= \$3 AND max_lon >= \$3`).
+		WithArgs(sampleTime, ict.Lat, ict.Lon).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, "SIGMET", "CONVECTIVE", 1, nil, 45000, sampleTime, sampleTime, "CONVECTIVE SIGMET 12C", []byte(sampleAreaJSON)).
+			AddRow(2, "AIRMET", "ICE", 0, 8000, 16000, nil, nil, "AIRMET ZULU", []byte(`[{"lat":37,"lon":-102},{"lat":40,"lon":-102},{"lat":40,"lon":-94}]`)))
+	advisories, err := r.GetAdvisoriesAt(ict, sampleTime)
+	assert.NoError(t, err)
+	high := 45000
+	assert.Equal(t, []domain.Advisory{{
+		ID: 1, Type: "SIGMET", Hazard: "CONVECTIVE", Severity: 1, AltitudeHighFt: &high,
+		ValidFrom: &sampleTime, ValidTo: &sampleTime, RawText: "CONVECTIVE SIGMET 12C", Area: sampleArea,
+	}}, advisories)
+
+	mock.ExpectQuery(`FROM advisories`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetAdvisories(sampleTime)
+	assert.EqualError(t, err, "failed to query advisories: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// CreateAlertRule inserts a rule and fills in its id and creation time.
+func (r *Repository) CreateAlertRule(rule *domain.AlertRule) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO alert_rules (name, faa, state_code, metric, operator, threshold, category, notify, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+
+	notify := rule.Notify
+	if notify == nil {
+		notify = []domain.NotifyTarget{}
+	}
+	notifyJSON, err := json.Marshal(notify)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert rule targets: %w", err)
+	}
+
+	var createdAt time.Time
+	if err := r.db.QueryRowContext(ctx,
+		query,
+		rule.Name, rule.Faa, rule.StateCode, rule.Metric, rule.Operator, rule.Threshold, rule.Category, notifyJSON, rule.TenantID,
+	).Scan(&rule.ID, &createdAt); err != nil {
+		return fmt.Errorf("failed to create alert rule: %w", err)
+	}
+	rule.CreatedAt = &createdAt
+
+	return nil
+}
+
+// DeleteAlertRule removes a rule of a tenant and its alerts, returning
+// domain.ErrRuleNotFound if the tenant has none with that id.
+func (r *Repository) DeleteAlertRule(tenantID, id int64) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM alert_rules WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete alert rule %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected for alert rule %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %d", domain.ErrRuleNotFound, id)
+	}
+
+	return nil
+}
+
+// GetAlertRules fetches every rule of a tenant, oldest first.
+func (r *Repository) GetAlertRules(tenantID int64) ([]domain.AlertRule, error) {
+	query := `SELECT ` + alertRuleColumns + ` FROM alert_rules WHERE tenant_id = $1 ORDER BY id`
+	return r.queryAlertRules(query, tenantID)
+}
+
+// GetAlertRulesFor fetches the rules of every tenant applying to one airport:
+// its own, its state's, and those covering every airport.
+func (r *Repository) GetAlertRulesFor(faa, stateCode string) ([]domain.AlertRule, error) {
+	query := `SELECT ` + alertRuleColumns + ` FROM alert_rules
+		WHERE (faa = '' OR UPPER(faa) = UPPER($1))
+		  AND (state_code = '' OR UPPER(state_code) = UPPER($2))
+		ORDER BY id`
+	return r.queryAlertRules(query, faa, stateCode)
+}
+
+// alertRuleColumns is the column list of every alert_rules SELECT, in queryAlertRules order.
+const alertRuleColumns = `id, name, faa, state_code, metric, operator, threshold, category, notify, tenant_id, created_at`
+
+func (r *Repository) queryAlertRules(query string, args ...any) ([]domain.AlertRule, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []domain.AlertRule{}
+	for rows.Next() {
+		var rule domain.AlertRule
+		var notifyJSON []byte
+		var createdAt time.Time
+		if err := rows.Scan(
+			&rule.ID, &rule.Name, &rule.Faa, &rule.StateCode, &rule.Metric,
+			&rule.Operator, &rule.Threshold, &rule.Category, &notifyJSON, &rule.TenantID, &createdAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan alert rule row: %w", err)
+		}
+		if len(notifyJSON) > 0 {
+			if err := json.Unmarshal(notifyJSON, &rule.Notify); err != nil {
+				return nil, fmt.Errorf("failed to decode targets of alert rule %d: %w", rule.ID, err)
+			}
+		}
+		rule.CreatedAt = &createdAt
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return rules, nil
+}
+
+// SaveAlerts records triggered alerts in a single transaction, each under the
+// tenant of its rule. An alert already recorded for the same rule, airport and
+// observation is skipped.
+func (r *Repository) SaveAlerts(alerts []domain.Alert) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO alerts (rule_id, faa, metric, value, message, observed_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (rule_id, faa, observed_at) DO NOTHING
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare alert insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, a := range alerts {
+		if _, err := stmt.ExecContext(ctx, a.RuleID, a.Faa, a.Metric, a.Value, a.Message, a.ObservedAt, a.TenantID); err != nil {
+			return fmt.Errorf("failed to insert alert of rule %d for %s: %w", a.RuleID, a.Faa, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit alerts: %w", err)
+	}
+
+	return nil
+}
+
+// GetAlerts fetches up to limit alerts of a tenant raised since the given
+// time, newest first, for one airport or every airport when faa is empty.
+func (r *Repository) GetAlerts(tenantID int64, faa string, since time.Time, limit int) ([]domain.Alert, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		SELECT id, rule_id, faa, metric, value, message, observed_at, tenant_id, created_at
+		FROM alerts
+		WHERE tenant_id = $1 AND ($2 = '' OR faa = $2) AND created_at >= $3
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, faa, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []domain.Alert{}
+	for rows.Next() {
+		var a domain.Alert
+		var createdAt time.Time
+		if err := rows.Scan(&a.ID, &a.RuleID, &a.Faa, &a.Metric, &a.Value, &a.Message, &a.ObservedAt, &a.TenantID, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan alert row: %w", err)
+		}
+		a.CreatedAt = &createdAt
+		alerts = append(alerts, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return alerts, nil
+}
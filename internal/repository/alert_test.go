@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleRule = domain.AlertRule{Name: "Strong wind", StateCode: "TX", Metric: "wind_speed_kt", Operator: "gt", Threshold: 25, TenantID: 2}
+
+func TestCreateAndDeleteAlertRule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	rule := sampleRule
+	mock.ExpectQuery(`INSERT INTO alert_rules .* RETURNING id, created_at`).
+		WithArgs(rule.Name, "", "TX", rule.Metric, rule.Operator, rule.Threshold, "", []byte("[]"), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, sampleTime))
+	assert.NoError(t, r.CreateAlertRule(&rule))
+	assert.Equal(t, int64(7), rule.ID)
+	assert.Equal(t, &sampleTime, rule.CreatedAt)
+
+	mock.ExpectQuery(`INSERT INTO alert_rules`).WillReturnError(errors.New(anErrorMsg))
+	assert.EqualError(t, r.CreateAlertRule(&rule), "failed to create alert rule: "+anErrorMsg)
+
+	mock.ExpectExec(`DELETE FROM alert_rules WHERE id = \$1 AND tenant_id = \$2`).WithArgs(7, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.DeleteAlertRule(2, 7))
+
+	mock.ExpectExec(`DELETE FROM alert_rules`).WithArgs(7, 3).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, r.DeleteAlertRule(3, 7), domain.ErrRuleNotFound, "Rules of other tenants are not found")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAlertRules(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	columns := []string{"id", "name", "faa", "state_code", "metric", "operator", "threshold", "category", "notify", "tenant_id", "created_at"}
+	mock.ExpectQuery(`SELECT (.+) FROM alert_rules WHERE tenant_id = \$1 ORDER BY id`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(7, "Strong wind", "", "TX", "wind_speed_kt", "gt", 25.0, "",
+			[]byte(`[{"channel":"email","target":"ops@example.com"}]`), 2, sampleTime))
+	rules, err := r.GetAlertRules(2)
+	assert.NoError(t, err)
+	want := sampleRule
+	want.ID, want.CreatedAt = 7, &sampleTime
+	want.Notify = []domain.NotifyTarget{{Channel: domain.ChannelEmail, Target: "ops@example.com"}}
+	assert.Equal(t, []domain.AlertRule{want}, rules)
+
+	mock.ExpectQuery(`SELECT (.+) FROM alert_rules\s+WHERE \(faa = '' OR UPPER\(faa\) = UPPER\(\$1\)\)`).
+		WithArgs("DFW", "TX").
+		WillReturnRows(sqlmock.NewRows(columns))
+	rules, err = r.GetAlertRulesFor("DFW", "TX")
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	mock.ExpectQuery(`SELECT (.+) FROM alert_rules`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetAlertRules(2)
+	assert.EqualError(t, err, "failed to query alert rules: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveAndGetAlerts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	alert := domain.Alert{RuleID: 7, Faa: "DFW", Metric: "wind_speed_kt", Value: "30", Message: "DFW: wind_speed_kt 30 > 25 (Strong wind)", ObservedAt: sampleTime, TenantID: 2}
+	mock.ExpectBegin()
+	mock.ExpectPrepare(`INSERT INTO alerts .* ON CONFLICT \(rule_id, faa, observed_at\) DO NOTHING`)
+	mock.ExpectExec(`INSERT INTO alerts`).
+		WithArgs(alert.RuleID, alert.Faa, alert.Metric, alert.Value, alert.Message, alert.ObservedAt, alert.TenantID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	assert.NoError(t, r.SaveAlerts([]domain.Alert{alert}))
+
+	mock.ExpectBegin().WillReturnError(errors.New(anErrorMsg))
+	assert.EqualError(t, r.SaveAlerts([]domain.Alert{alert}), "failed to begin transaction: "+anErrorMsg)
+
+	columns := []string{"id", "rule_id", "faa", "metric", "value", "message", "observed_at", "tenant_id", "created_at"}
+	mock.ExpectQuery(`SELECT (.+) FROM alerts\s+WHERE tenant_id = \$1 AND \(\$2 = '' OR faa = \$2\) AND created_at >= \$3`).
+		WithArgs(2, "DFW", sampleTime, 50).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(3, alert.RuleID, alert.Faa, alert.Metric, alert.Value, alert.Message, sampleTime, 2, sampleTime))
+	alerts, err := r.GetAlerts(2, "DFW", sampleTime, 50)
+	assert.NoError(t, err)
+	alert.ID, alert.CreatedAt = 3, &sampleTime
+	assert.Equal(t, []domain.Alert{alert}, alerts)
+
+	mock.ExpectQuery(`SELECT (.+) FROM alerts`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetAlerts(2, "", sampleTime, 50)
+	assert.EqualError(t, err, "failed to query alerts: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
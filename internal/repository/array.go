@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// pgTypes converts text[] values, which database/sql has no type for.
+var pgTypes = pgtype.NewMap()
+
+// textArray passes a []string as a text[] parameter.
+type textArray []string
+
+func (a textArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	buf, err := pgTypes.Encode(pgtype.TextArrayOID, pgtype.TextFormatCode, []string(a), nil)
+	if err != nil {
+		return nil, err
+	}
+	return string(buf), nil
+}
+
+// scanTextArray scans a text[] column into dst.
+func scanTextArray(dst *[]string) sql.Scanner {
+	return pgTypes.SQLScanner(dst)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextArray(t *testing.T) {
+	value, err := textArray{"DFW", "a b", `q"t`}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{DFW,a b,"q\"t"}`, value)
+
+	value, err = textArray(nil).Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	var scanned []string
+	assert.NoError(t, scanTextArray(&scanned).Scan(`{DFW,"a b","q\"t"}`))
+	assert.Equal(t, []string{"DFW", "a b", `q"t`}, scanned)
+}
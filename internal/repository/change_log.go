@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"fmt"
+
+	"aviation-weather/internal/domain"
+)
+
+// GetAirportChanges returns up to limit changes of airport_change_log after
+// the cursor, in order. Only the changes of transactions older than every
+// running one are read, so a transaction committing after a reader moved on
+// can't leave changes behind its cursor.
+func (r *Repository) GetAirportChanges(after domain.ChangeCursor, limit int) ([]domain.ChangeLogEntry, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		SELECT xid, id, op, faa, created_at
+		FROM airport_change_log
+		WHERE (xid, id) > ($1, $2)
+		  AND xid < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+		ORDER BY xid, id
+		LIMIT $3
+	`
+	rows, err := r.db.QueryContext(ctx, query, after.TxID, after.ID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query airport changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []domain.ChangeLogEntry{}
+	for rows.Next() {
+		var c domain.ChangeLogEntry
+		if err := rows.Scan(&c.Cursor.TxID, &c.Cursor.ID, &c.Op, &c.Faa, &c.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan airport change row: %w", err)
+		}
+		changes = append(changes, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAirportChanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	after := domain.ChangeCursor{TxID: 100, ID: 7}
+
+	rows := sqlmock.NewRows([]string{"xid", "id", "op", "faa", "created_at"}).
+		AddRow(101, 8, "insert", "TST", sampleTime).
+		AddRow(101, 9, "update", "TST", sampleTime)
+	mock.ExpectQuery(`FROM airport_change_log\s+WHERE \(xid, id\) > \(\$1, \$2\)\s+AND xid < pg_snapshot_xmin\(pg_current_snapshot\(\)\)::text::bigint\s+ORDER BY xid, id\s+LIMIT \$3`).
+		WithArgs(int64(100), int64(7), 50).WillReturnRows(rows)
+	changes, err := r.GetAirportChanges(after, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ChangeLogEntry{
+		{Cursor: domain.ChangeCursor{TxID: 101, ID: 8}, Op: domain.ChangeInsert, Faa: "TST", ChangedAt: sampleTime},
+		{Cursor: domain.ChangeCursor{TxID: 101, ID: 9}, Op: domain.ChangeUpdate, Faa: "TST", ChangedAt: sampleTime},
+	}, changes)
+
+	mock.ExpectQuery(`FROM airport_change_log`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetAirportChanges(after, 50)
+	assert.EqualError(t, err, "failed to query airport changes: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"fmt"
+
+	"aviation-weather/internal/domain"
+)
+
+// GetCharts fetches the stored charts of one airport in d-TPP order.
+func (r *Repository) GetCharts(faa string) ([]domain.Chart, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		SELECT code, name, pdf_url, cycle
+		FROM airport_charts
+		WHERE faa = $1
+		ORDER BY seq, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query charts for %s: %w", faa, err)
+	}
+	defer rows.Close()
+
+	charts := []domain.Chart{}
+	for rows.Next() {
+		var c domain.Chart
+		if err := rows.Scan(&c.Code, &c.Name, &c.PDFURL, &c.Cycle); err != nil {
+			return nil, fmt.Errorf("failed to scan chart row: %w", err)
+		}
+		charts = append(charts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return charts, nil
+}
+
+// ReplaceCharts swaps the stored charts of one airport for the given list and
+// records them as synced for cycle, in a single transaction. An empty list
+// still records the cycle, so airports without charts aren't fetched again
+// until the next one.
+func (r *Repository) ReplaceCharts(faa, cycle string, charts []domain.Chart) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM airport_charts WHERE faa = $1`, faa); err != nil {
+		return fmt.Errorf("failed to clear charts for %s: %w", faa, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO airport_charts (faa, code, name, pdf_url, cycle, seq)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare chart insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, c := range charts {
+		if _, err := stmt.ExecContext(ctx, faa, c.Code, c.Name, c.PDFURL, c.Cycle, i); err != nil {
+			return fmt.Errorf("failed to insert chart %s for %s: %w", c.Name, faa, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO airport_chart_syncs (faa, cycle, synced_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (faa) DO UPDATE SET cycle = EXCLUDED.cycle, synced_at = EXCLUDED.synced_at
+	`, faa, cycle); err != nil {
+		return fmt.Errorf("failed to record chart cycle for %s: %w", faa, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit charts: %w", err)
+	}
+
+	return nil
+}
+
+// GetFAAsNeedingCharts lists the airports whose charts were never synced or
+// were last synced for another cycle than cycle, in FAA order.
+func (r *Repository) GetFAAsNeedingCharts(cycle string) ([]string, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		SELECT a.faa
+		FROM airport a
+		LEFT JOIN airport_chart_syncs s ON s.faa = a.faa
+		WHERE s.cycle IS DISTINCT FROM $1
+		ORDER BY a.faa
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, cycle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query airports needing charts: %w", err)
+	}
+	defer rows.Close()
+
+	var faas []string
+	for rows.Next() {
+		var faa string
+		if err := rows.Scan(&faa); err != nil {
+			return nil, fmt.Errorf("failed to scan airport row: %w", err)
+		}
+		faas = append(faas, faa)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return faas, nil
+}
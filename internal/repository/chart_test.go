@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleCharts = []domain.Chart{
+	{Code: domain.ChartAirportDiagram, Name: "AIRPORT DIAGRAM", PDFURL: "https://aeronav.faa.gov/d-tpp/2510/00026AD.PDF", Cycle: "2510"},
+	{Code: domain.ChartApproach, Name: "ILS OR LOC RWY 08L", PDFURL: "https://aeronav.faa.gov/d-tpp/2510/00026I8L.PDF", Cycle: "2510"},
+}
+
+func TestGetCharts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	rows := sqlmock.NewRows([]string{"code", "name", "pdf_url", "cycle"})
+	for _, c := range sampleCharts {
+		rows.AddRow(c.Code, c.Name, c.PDFURL, c.Cycle)
+	}
+	mock.ExpectQuery(`SELECT code, name, pdf_url, cycle\s+FROM airport_charts\s+WHERE faa = \$1\s+ORDER BY seq, id`).
+		WithArgs("ATL").
+		WillReturnRows(rows)
+	charts, err := r.GetCharts("ATL")
+	assert.NoError(t, err)
+	assert.Equal(t, sampleCharts, charts)
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport_charts`).
+		WithArgs("ERR").
+		WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetCharts("ERR")
+	assert.EqualError(t, err, "failed to query charts for ERR: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReplaceCharts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM airport_charts WHERE faa = \$1`).
+		WithArgs("ATL").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	prep := mock.ExpectPrepare(`INSERT INTO airport_charts`)
+	prep.ExpectExec().
+		WithArgs("ATL", "APD", "AIRPORT DIAGRAM", sampleCharts[0].PDFURL, "2510", 0).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	prep.ExpectExec().
+		WithArgs("ATL", "IAP", "ILS OR LOC RWY 08L", sampleCharts[1].PDFURL, "2510", 1).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(`INSERT INTO airport_chart_syncs (.+) ON CONFLICT \(faa\) DO UPDATE`).
+		WithArgs("ATL", "2510").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.NoError(t, r.ReplaceCharts("ATL", "2510", sampleCharts))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM airport_charts`).WithArgs("TST").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectPrepare(`INSERT INTO airport_charts`)
+	mock.ExpectExec(`INSERT INTO airport_chart_syncs`).
+		WithArgs("TST", "2510").
+		WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+	err = r.ReplaceCharts("TST", "2510", nil)
+	assert.EqualError(t, err, "failed to record chart cycle for TST: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFAAsNeedingCharts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`SELECT a.faa\s+FROM airport a\s+LEFT JOIN airport_chart_syncs s ON s.faa = a.faa\s+WHERE s.cycle IS DISTINCT FROM \$1`).
+		WithArgs("2510").
+		WillReturnRows(sqlmock.NewRows([]string{"faa"}).AddRow("ATL").AddRow("DEN"))
+	faas, err := r.GetFAAsNeedingCharts("2510")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ATL", "DEN"}, faas)
+
+	mock.ExpectQuery(`SELECT a.faa`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetFAAsNeedingCharts("2510")
+	assert.EqualError(t, err, "failed to query airports needing charts: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+const conflictColumns = `id, faa, field, local_value, provider_value, status, detected_at, resolved_at`
+
+// SaveConflicts records provider values that disagree with overridden fields.
+// A field keeps one pending conflict, updated to the latest values; a
+// provider value already rejected for the same local value is skipped, as
+// are conflicts of airports that are not stored.
+func (r *Repository) SaveConflicts(conflicts []domain.AirportConflict) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	query := `
+		INSERT INTO airport_conflicts (faa, field, local_value, provider_value)
+		SELECT $1, $2, $3, $4
+		WHERE EXISTS (SELECT 1 FROM airport WHERE faa = $1)
+		  AND NOT EXISTS (
+			SELECT 1 FROM airport_conflicts
+			WHERE faa = $1 AND field = $2 AND local_value = $3 AND provider_value = $4 AND status = 'rejected'
+		  )
+		ON CONFLICT (faa, field) WHERE status = 'pending' DO UPDATE SET
+			local_value = EXCLUDED.local_value,
+			provider_value = EXCLUDED.provider_value,
+			detected_at = NOW()
+	`
+	for _, c := range conflicts {
+		if _, err := tx.ExecContext(ctx, query, c.Faa, c.Field, c.LocalValue, c.ProviderValue); err != nil {
+			return fmt.Errorf("failed to save conflict of %s %s: %w", c.Faa, c.Field, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit conflicts: %w", err)
+	}
+	return nil
+}
+
+// GetConflicts fetches the conflicts with the given status, or all of them
+// for "", newest first.
+func (r *Repository) GetConflicts(status string) ([]domain.AirportConflict, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+conflictColumns+`
+		FROM airport_conflicts
+		WHERE $1 = '' OR status = $1
+		ORDER BY detected_at DESC, id DESC
+	`, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	conflicts := []domain.AirportConflict{}
+	for rows.Next() {
+		c, err := scanConflict(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan conflict row: %w", err)
+		}
+		conflicts = append(conflicts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return conflicts, nil
+}
+
+// GetConflict fetches a conflict by id, returning domain.ErrConflictNotFound
+// if it doesn't exist.
+func (r *Repository) GetConflict(id int64) (*domain.AirportConflict, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	c, err := scanConflict(r.db.QueryRowContext(ctx, `SELECT `+conflictColumns+` FROM airport_conflicts WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", domain.ErrConflictNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conflict %d: %w", id, err)
+	}
+	return &c, nil
+}
+
+// ResolveConflict marks a pending conflict accepted or rejected and returns
+// it, returning domain.ErrConflictResolved if it isn't pending, or no longer.
+func (r *Repository) ResolveConflict(id int64, status string) (*domain.AirportConflict, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	c, err := scanConflict(r.db.QueryRowContext(ctx, `
+		UPDATE airport_conflicts SET status = $2, resolved_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING `+conflictColumns, id, status))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", domain.ErrConflictResolved, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve conflict %d: %w", id, err)
+	}
+	return &c, nil
+}
+
+func scanConflict(row interface{ Scan(...any) error }) (domain.AirportConflict, error) {
+	var c domain.AirportConflict
+	var detectedAt time.Time
+	var resolvedAt sql.NullTime
+	if err := row.Scan(&c.ID, &c.Faa, &c.Field, &c.LocalValue, &c.ProviderValue, &c.Status, &detectedAt, &resolvedAt); err != nil {
+		return domain.AirportConflict{}, err
+	}
+	c.DetectedAt = &detectedAt
+	if resolvedAt.Valid {
+		c.ResolvedAt = &resolvedAt.Time
+	}
+	return c, nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var conflictRowColumns = []string{"id", "faa", "field", "local_value", "provider_value", "status", "detected_at", "resolved_at"}
+
+func TestSaveConflicts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO airport_conflicts`).WithArgs("DEN", "manager", "Local", "Provider").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	err = r.SaveConflicts([]domain.AirportConflict{{Faa: "DEN", Field: "manager", LocalValue: "Local", ProviderValue: "Provider"}})
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO airport_conflicts`).WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+	err = r.SaveConflicts([]domain.AirportConflict{{Faa: "DEN", Field: "city"}})
+	assert.EqualError(t, err, "failed to save conflict of DEN city: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetConflicts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`FROM airport_conflicts\s+WHERE \$1 = '' OR status = \$1`).WithArgs("pending").
+		WillReturnRows(sqlmock.NewRows(conflictRowColumns).
+			AddRow(3, "DEN", "manager", "Local", "Provider", "pending", sampleTime, nil))
+	conflicts, err := r.GetConflicts(domain.ConflictPending)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AirportConflict{{
+		ID: 3, Faa: "DEN", Field: "manager", LocalValue: "Local", ProviderValue: "Provider",
+		Status: domain.ConflictPending, DetectedAt: &sampleTime,
+	}}, conflicts)
+
+	mock.ExpectQuery(`FROM airport_conflicts`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetConflicts("")
+	assert.EqualError(t, err, "failed to query conflicts: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`FROM airport_conflicts WHERE id = \$1`).WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows(conflictRowColumns).
+			AddRow(3, "DEN", "city", "", "Denver", "rejected", sampleTime, sampleTime))
+	c, err := r.GetConflict(3)
+	assert.NoError(t, err)
+	assert.Equal(t, "rejected", c.Status)
+	assert.Equal(t, &sampleTime, c.ResolvedAt)
+
+	mock.ExpectQuery(`FROM airport_conflicts WHERE id = \$1`).WithArgs(int64(4)).WillReturnError(sql.ErrNoRows)
+	_, err = r.GetConflict(4)
+	assert.ErrorIs(t, err, domain.ErrConflictNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResolveConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`UPDATE airport_conflicts SET status = \$2, resolved_at = NOW\(\)\s+WHERE id = \$1 AND status = 'pending'`).
+		WithArgs(int64(3), "accepted").
+		WillReturnRows(sqlmock.NewRows(conflictRowColumns).
+			AddRow(3, "DEN", "city", "", "Denver", "accepted", sampleTime, sampleTime))
+	c, err := r.ResolveConflict(3, domain.ConflictAccepted)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ConflictAccepted, c.Status)
+
+	mock.ExpectQuery(`UPDATE airport_conflicts`).WithArgs(int64(3), "rejected").WillReturnError(sql.ErrNoRows)
+	_, err = r.ResolveConflict(3, domain.ConflictRejected)
+	assert.ErrorIs(t, err, domain.ErrConflictResolved, "Only pending conflicts are resolved")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
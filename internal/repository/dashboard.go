@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// GetDashboard summarizes the current_conditions view by state and flight
+// category, without scanning the airport table.
+func (r *Repository) GetDashboard(ctx context.Context) (*domain.Dashboard, error) {
+	var dashboard *domain.Dashboard
+	err := r.readReplica("dashboard", func(db *sql.DB) error {
+		var err error
+		dashboard, err = r.getDashboard(ctx, db)
+		return err
+	})
+	return dashboard, err
+}
+
+func (r *Repository) getDashboard(ctx context.Context, db *sql.DB) (*domain.Dashboard, error) {
+	rows, err := db.QueryContext(ctx, `SELECT state_code, visibility_sm, ceiling, has_metar, airports, last_synced_at, refreshed_at
+		FROM current_conditions`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query current conditions: %w", err)
+	}
+	defer rows.Close()
+
+	dashboard := &domain.Dashboard{ByFlightCategory: map[string]int{}, States: []domain.DashboardState{}}
+	states := map[string]*domain.DashboardState{}
+	for rows.Next() {
+		var (
+			state, ceiling string
+			visibility     float64
+			hasMETAR       bool
+			count          int
+			lastSyncedAt   sql.NullTime
+			refreshedAt    time.Time
+		)
+		if err := rows.Scan(&state, &visibility, &ceiling, &hasMETAR, &count, &lastSyncedAt, &refreshedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan current conditions row: %w", err)
+		}
+
+		category := weatherCategory(visibility, ceiling, hasMETAR)
+		st, ok := states[state]
+		if !ok {
+			st = &domain.DashboardState{State: state, ByFlightCategory: map[string]int{}}
+			states[state] = st
+		}
+		st.Airports += count
+		st.ByFlightCategory[category] += count
+		dashboard.TotalAirports += count
+		dashboard.ByFlightCategory[category] += count
+
+		if lastSyncedAt.Valid && (dashboard.LastSyncedAt == nil || lastSyncedAt.Time.After(*dashboard.LastSyncedAt)) {
+			dashboard.LastSyncedAt = &lastSyncedAt.Time
+		}
+		dashboard.RefreshedAt = &refreshedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	for _, st := range states {
+		dashboard.States = append(dashboard.States, *st)
+	}
+	sort.Slice(dashboard.States, func(i, j int) bool { return dashboard.States[i].State < dashboard.States[j].State })
+	return dashboard, nil
+}
+
+// RefreshDashboard recomputes the current_conditions view from the stored
+// airports and weather. Reads keep being served from the previous contents
+// while it runs.
+func (r *Repository) RefreshDashboard() error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY current_conditions`); err != nil {
+		return fmt.Errorf("failed to refresh current conditions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDashboard(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	earlier := sampleTime.Add(-time.Hour)
+
+	mock.ExpectQuery(`SELECT state_code, visibility_sm, ceiling, has_metar, airports, last_synced_at, refreshed_at\s+FROM current_conditions`).
+		WillReturnRows(sqlmock.NewRows([]string{"state_code", "visibility_sm", "ceiling", "has_metar", "airports", "last_synced_at", "refreshed_at"}).
+			AddRow("TX", 10.0, "", true, 2, sampleTime, sampleTime).
+			AddRow("TX", 10.0, "008", true, 1, earlier, sampleTime).
+			AddRow("CA", 0.0, "", false, 1, nil, sampleTime))
+
+	dashboard, err := r.GetDashboard(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Dashboard{
+		TotalAirports:    4,
+		ByFlightCategory: map[string]int{"VFR": 2, "IFR": 1, domain.CategoryUnknown: 1},
+		States: []domain.DashboardState{
+			{State: "CA", Airports: 1, ByFlightCategory: map[string]int{domain.CategoryUnknown: 1}},
+			{State: "TX", Airports: 3, ByFlightCategory: map[string]int{"VFR": 2, "IFR": 1}},
+		},
+		LastSyncedAt: &sampleTime,
+		RefreshedAt:  &sampleTime,
+	}, dashboard)
+
+	mock.ExpectQuery(`FROM current_conditions`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetDashboard(context.Background())
+	assert.EqualError(t, err, "failed to query current conditions: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshDashboard(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectExec(`REFRESH MATERIALIZED VIEW CONCURRENTLY current_conditions`).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.NoError(t, r.RefreshDashboard())
+
+	mock.ExpectExec(`REFRESH MATERIALIZED VIEW`).WillReturnError(errors.New(anErrorMsg))
+	assert.EqualError(t, r.RefreshDashboard(), "failed to refresh current conditions: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
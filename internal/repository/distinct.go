@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// GetStates lists the distinct state codes of the stored airports, sorted.
+func (r *Repository) GetStates() ([]string, error) {
+	return r.queryDistinct("states", `SELECT DISTINCT state_code FROM airport
+		WHERE COALESCE(state_code, '') <> '' ORDER BY 1`)
+}
+
+// GetCities lists the distinct cities of the stored airports, sorted, only
+// those of stateCode when it is not empty.
+func (r *Repository) GetCities(stateCode string) ([]string, error) {
+	return r.queryDistinct("cities", `SELECT DISTINCT city FROM airport
+		WHERE COALESCE(city, '') <> '' AND ($1 = '' OR UPPER(state_code) = UPPER($1)) ORDER BY 1`, stateCode)
+}
+
+// queryDistinct reads a single text column, from the replica when there is
+// one; what names the values in error messages.
+func (r *Repository) queryDistinct(what, query string, args ...any) ([]string, error) {
+	values := []string{}
+	err := r.readReplica(what, func(db *sql.DB) error {
+		ctx, cancel := r.queryContext()
+		defer cancel()
+
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to query %s: %w", what, err)
+		}
+		defer rows.Close()
+
+		values = values[:0]
+		for rows.Next() {
+			var value string
+			if err := rows.Scan(&value); err != nil {
+				return fmt.Errorf("failed to scan %s: %w", what, err)
+			}
+			values = append(values, value)
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to query %s: %w", what, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	mock.ExpectQuery(`SELECT DISTINCT state_code FROM airport`).
+		WillReturnRows(sqlmock.NewRows([]string{"state_code"}).AddRow("CA").AddRow("TX"))
+	states, err := r.GetStates()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CA", "TX"}, states)
+
+	mock.ExpectQuery(`SELECT DISTINCT state_code FROM airport`).
+		WillReturnError(errors.New("connection refused"))
+	_, err = r.GetStates()
+	assert.EqualError(t, err, "failed to query states: connection refused")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCities(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	mock.ExpectQuery(`SELECT DISTINCT city FROM airport`).
+		WithArgs("CA").
+		WillReturnRows(sqlmock.NewRows([]string{"city"}).AddRow("Los Angeles").AddRow("San Diego"))
+	cities, err := r.GetCities("CA")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Los Angeles", "San Diego"}, cities)
+
+	mock.ExpectQuery(`SELECT DISTINCT city FROM airport`).
+		WithArgs("").
+		WillReturnRows(sqlmock.NewRows([]string{"city"}))
+	cities, err = r.GetCities("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, cities, "No cities is an empty list")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
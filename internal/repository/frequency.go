@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"fmt"
+
+	"aviation-weather/internal/domain"
+)
+
+// GetFrequencies fetches the stored COM frequencies of one airport.
+func (r *Repository) GetFrequencies(faa string) ([]domain.Frequency, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		SELECT type, COALESCE(description, ''), frequency_mhz, cycle
+		FROM airport_frequency
+		WHERE faa = $1
+		ORDER BY type, frequency_mhz
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query frequencies for %s: %w", faa, err)
+	}
+	defer rows.Close()
+
+	frequencies := []domain.Frequency{}
+	for rows.Next() {
+		var f domain.Frequency
+		if err := rows.Scan(&f.Type, &f.Description, &f.FrequencyMHz, &f.Cycle); err != nil {
+			return nil, fmt.Errorf("failed to scan frequency row: %w", err)
+		}
+		frequencies = append(frequencies, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return frequencies, nil
+}
+
+// ReplaceFrequencies swaps the stored frequencies of one airport for the given
+// list in a single transaction.
+func (r *Repository) ReplaceFrequencies(faa string, frequencies []domain.Frequency) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM airport_frequency WHERE faa = $1`, faa); err != nil {
+		return fmt.Errorf("failed to clear frequencies for %s: %w", faa, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO airport_frequency (faa, type, description, frequency_mhz, cycle)
+		VALUES ($1, $2, $3, $4, $5)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare frequency insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, f := range frequencies {
+		if _, err := stmt.ExecContext(ctx, faa, f.Type, f.Description, f.FrequencyMHz, f.Cycle); err != nil {
+			return fmt.Errorf("failed to insert frequency %s %.3f for %s: %w", f.Type, f.FrequencyMHz, faa, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit frequencies: %w", err)
+	}
+
+	return nil
+}
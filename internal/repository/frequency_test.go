@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleFrequencies = []domain.Frequency{
+	{Type: "ATIS", Description: "ATIS", FrequencyMHz: 134.9},
+	{Type: "TWR", Description: "Tower", FrequencyMHz: 126.55},
+}
+
+func TestGetFrequencies(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupDB     func(sqlmock.Sqlmock)
+		expected    []domain.Frequency
+		expectedErr string
+	}{
+		{
+			name: "success",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"type", "description", "frequency_mhz", "cycle"}).
+					AddRow("ATIS", "ATIS", 134.9, "").
+					AddRow("TWR", "Tower", 126.55, "")
+				mock.ExpectQuery(`SELECT type, (.+) FROM airport_frequency WHERE faa = \$1`).
+					WithArgs("TST").
+					WillReturnRows(rows)
+			},
+			expected: sampleFrequencies,
+		},
+		{
+			name: "none stored",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT (.+) FROM airport_frequency`).
+					WithArgs("TST").
+					WillReturnRows(sqlmock.NewRows([]string{"type", "description", "frequency_mhz", "cycle"}))
+			},
+			expected: []domain.Frequency{},
+		},
+		{
+			name: "query error",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT (.+) FROM airport_frequency`).
+					WillReturnError(errors.New(anErrorMsg))
+			},
+			expectedErr: "failed to query frequencies for TST: " + anErrorMsg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			r := NewRepository(db, 0)
+			tt.setupDB(mock)
+
+			frequencies, err := r.GetFrequencies("TST")
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, frequencies)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReplaceFrequencies(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupDB     func(sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "success",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM airport_frequency WHERE faa = \$1`).
+					WithArgs("TST").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				prep := mock.ExpectPrepare(`INSERT INTO airport_frequency`)
+				prep.ExpectExec().
+					WithArgs("TST", "ATIS", "ATIS", 134.9, "").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				prep.ExpectExec().
+					WithArgs("TST", "TWR", "Tower", 126.55, "").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "insert error rolls back",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM airport_frequency`).WillReturnResult(sqlmock.NewResult(0, 0))
+				prep := mock.ExpectPrepare(`INSERT INTO airport_frequency`)
+				prep.ExpectExec().WillReturnError(errors.New(anErrorMsg))
+				mock.ExpectRollback()
+			},
+			expectedErr: "failed to insert frequency ATIS 134.900 for TST: " + anErrorMsg,
+		},
+		{
+			name: "delete error rolls back",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM airport_frequency`).WillReturnError(errors.New(anErrorMsg))
+				mock.ExpectRollback()
+			},
+			expectedErr: "failed to clear frequencies for TST: " + anErrorMsg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			r := NewRepository(db, 0)
+			tt.setupDB(mock)
+
+			err = r.ReplaceFrequencies("TST", sampleFrequencies)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
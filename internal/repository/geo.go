@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"sort"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// GetAirportsInBox fetches the airports positioned inside box, edges
+// included; airports without a position never match.
+func (r *Repository) GetAirportsInBox(box aviation.Box) ([]domain.Airport, error) {
+	query := `SELECT ` + airportColumns + ` FROM airport
+		WHERE latitude BETWEEN $1 AND $2 AND longitude BETWEEN $3 AND $4
+		ORDER BY faa`
+
+	return r.queryAirports("airports in box", query, box.MinLat, box.MaxLat, box.MinLon, box.MaxLon)
+}
+
+// GetNearestAirports fetches up to limit airports within radiusNM of center,
+// nearest first. A limit of 0 returns every airport in range.
+func (r *Repository) GetNearestAirports(center aviation.Point, radiusNM float64, limit int) ([]domain.NearbyAirport, error) {
+	candidates, err := r.GetAirportsInBox(aviation.BoxAround(center, radiusNM))
+	if err != nil {
+		return nil, err
+	}
+
+	var nearby []domain.NearbyAirport
+	for _, a := range candidates {
+		lat, lon, _ := a.Coordinates()
+		if d := aviation.DistanceNM(center, aviation.Point{Lat: lat, Lon: lon}); d <= radiusNM {
+			nearby = append(nearby, domain.NearbyAirport{Airport: a, DistanceNM: d})
+		}
+	}
+
+	sort.SliceStable(nearby, func(i, j int) bool { return nearby[i].DistanceNM < nearby[j].DistanceNM })
+	if limit > 0 && len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+	return nearby, nil
+}
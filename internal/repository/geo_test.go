@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/aviation"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func geoRows(faas ...string) *sqlmock.Rows {
+	cols := []string{
+		"site_number", "facility_name", "faa", "icao", "state_code", "state_full", "county",
+		"city", "ownership_type", "use_type", "manager", "manager_phone",
+		"latitude", "longitude", "airport_status", "weather",
+		"created_at", "updated_at", "last_synced_at",
+		"elevation_ft", "pressure_altitude_ft", "density_altitude_ft", "magnetic_variation",
+		"timezone", "manual_overrides", "lifecycle_status", "fuel_types", "fbo_name", "fbo_phone",
+	}
+	positions := map[string][2]float64{
+		"EWR": {40.6925, -74.1686},
+		"JFK": {40.6398, -73.7789},
+		"LAX": {33.9425, -118.4081},
+		"PHL": {39.8719, -75.2411},
+	}
+	rows := sqlmock.NewRows(cols)
+	for _, faa := range faas {
+		p := positions[faa]
+		rows.AddRow("", "", faa, "", "", "", "", "", "", "", "", "", p[0], p[1], "", "",
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	}
+	return rows
+}
+
+func TestGetAirportsInBox(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport\s+WHERE latitude BETWEEN \$1 AND \$2 AND longitude BETWEEN \$3 AND \$4`).
+		WithArgs(39.0, 41.0, -76.0, -73.0).
+		WillReturnRows(geoRows("EWR", "JFK", "PHL"))
+	airports, err := r.GetAirportsInBox(aviation.Box{MinLat: 39, MaxLat: 41, MinLon: -76, MaxLon: -73})
+	assert.NoError(t, err)
+	var faas []string
+	for _, a := range airports {
+		faas = append(faas, a.Faa)
+	}
+	assert.Equal(t, []string{"EWR", "JFK", "PHL"}, faas)
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetAirportsInBox(aviation.Box{})
+	assert.EqualError(t, err, "failed to query airports in box: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetNearestAirports(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	jfk := aviation.Point{Lat: 40.6398, Lon: -73.7789}
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport`).WillReturnRows(geoRows("EWR", "JFK", "LAX", "PHL"))
+	nearby, err := r.GetNearestAirports(jfk, 100, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, nearby, 3) {
+		assert.Equal(t, "JFK", nearby[0].Faa)
+		assert.Equal(t, "EWR", nearby[1].Faa)
+		assert.InDelta(t, 18, nearby[1].DistanceNM, 1)
+		assert.Equal(t, "PHL", nearby[2].Faa)
+		assert.InDelta(t, 82, nearby[2].DistanceNM, 1)
+	}
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport`).WillReturnRows(geoRows("EWR", "JFK", "LAX", "PHL"))
+	nearby, err = r.GetNearestAirports(jfk, 100, 2)
+	assert.NoError(t, err)
+	assert.Len(t, nearby, 2)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
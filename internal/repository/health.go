@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Ping checks the primary database answers before ctx is done.
+func (r *Repository) Ping(ctx context.Context) error {
+	if err := r.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
+// GetLastSyncedAt returns when an airport was last synced, nil before the
+// first sync.
+func (r *Repository) GetLastSyncedAt(ctx context.Context) (*time.Time, error) {
+	var last sql.NullTime
+	if err := r.db.QueryRowContext(ctx, `SELECT MAX(last_synced_at) FROM airport`).Scan(&last); err != nil {
+		return nil, fmt.Errorf("failed to query last sync: %w", err)
+	}
+	if !last.Valid {
+		return nil, nil
+	}
+	return &last.Time, nil
+}
+
+// GetLastUpdatedAt returns when an airport was last created or updated, nil
+// while there are none.
+func (r *Repository) GetLastUpdatedAt(ctx context.Context) (*time.Time, error) {
+	var last sql.NullTime
+	err := r.readReplica("last airport update", func(db *sql.DB) error {
+		return db.QueryRowContext(ctx, `SELECT GREATEST(MAX(updated_at), MAX(created_at)) FROM airport`).Scan(&last)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query last update: %w", err)
+	}
+	if !last.Valid {
+		return nil, nil
+	}
+	return &last.Time, nil
+}
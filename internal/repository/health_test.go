@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPing(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	mock.ExpectPing()
+	assert.NoError(t, r.Ping(context.Background()))
+
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	assert.EqualError(t, r.Ping(context.Background()), "failed to ping database: connection refused")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLastSyncedAt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	synced := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT MAX\(last_synced_at\) FROM airport`).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(synced))
+	last, err := r.GetLastSyncedAt(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &synced, last)
+
+	mock.ExpectQuery(`SELECT MAX\(last_synced_at\) FROM airport`).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+	last, err = r.GetLastSyncedAt(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, last, "Nothing was synced yet")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLastUpdatedAt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	updated := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT GREATEST\(MAX\(updated_at\), MAX\(created_at\)\) FROM airport`).
+		WillReturnRows(sqlmock.NewRows([]string{"greatest"}).AddRow(updated))
+	last, err := r.GetLastUpdatedAt(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &updated, last)
+
+	mock.ExpectQuery(`SELECT GREATEST`).
+		WillReturnRows(sqlmock.NewRows([]string{"greatest"}).AddRow(nil))
+	last, err = r.GetLastUpdatedAt(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, last, "No airports yet")
+
+	mock.ExpectQuery(`SELECT GREATEST`).WillReturnError(errors.New("connection refused"))
+	_, err = r.GetLastUpdatedAt(context.Background())
+	assert.EqualError(t, err, "failed to query last update: connection refused")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+const queuedJobColumns = `id, type, payload, status, attempts, max_attempts, run_at, last_error, result, created_at, updated_at`
+
+// EnqueueJob queues a job to run now and fills in its id, status, attempts
+// and timestamps. A job of the same type and payload that is still to run is
+// returned instead of queuing another.
+func (r *Repository) EnqueueJob(job *domain.QueuedJob) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO job_queue (type, payload, max_attempts)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (type, payload) WHERE status IN ('queued', 'running')
+		DO UPDATE SET max_attempts = job_queue.max_attempts
+		RETURNING ` + queuedJobColumns
+	queued, err := scanQueuedJob(r.db.QueryRowContext(ctx, query, job.Type, []byte(job.Payload), job.MaxAttempts))
+	if err != nil {
+		return fmt.Errorf("failed to queue %s job: %w", job.Type, err)
+	}
+	*job = queued
+	return nil
+}
+
+// ClaimQueuedJob marks the job due first as running, counts the attempt and
+// leases it to the caller for lease, or returns nil when no job is due. A
+// running job whose lease ran out, e.g. because its worker stopped, is due
+// again.
+func (r *Repository) ClaimQueuedJob(lease time.Duration) (*domain.QueuedJob, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		UPDATE job_queue
+		SET status = 'running', attempts = attempts + 1,
+		    run_at = NOW() + $1 * INTERVAL '1 second', updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM job_queue
+			WHERE status IN ('queued', 'running') AND run_at <= NOW()
+			ORDER BY run_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + queuedJobColumns
+	job, err := scanQueuedJob(r.db.QueryRowContext(ctx, query, lease.Seconds()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim queued job: %w", err)
+	}
+	return &job, nil
+}
+
+// ExtendQueuedJob renews the lease of a running job.
+func (r *Repository) ExtendQueuedJob(id int64, lease time.Duration) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `UPDATE job_queue SET run_at = NOW() + $2 * INTERVAL '1 second' WHERE id = $1 AND status = 'running'`
+	if _, err := r.db.ExecContext(ctx, query, id, lease.Seconds()); err != nil {
+		return fmt.Errorf("failed to extend queued job %d: %w", id, err)
+	}
+	return nil
+}
+
+// FinishQueuedJob marks a job done with its result.
+func (r *Repository) FinishQueuedJob(id int64, result []byte) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `UPDATE job_queue SET status = 'done', result = $2, last_error = '', updated_at = NOW() WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, id, result); err != nil {
+		return fmt.Errorf("failed to finish queued job %d: %w", id, err)
+	}
+	return nil
+}
+
+// FailQueuedJob records a failed attempt of a job. It runs again after
+// retryAfter while it has attempts left; a retryAfter of 0 fails it for good.
+func (r *Repository) FailQueuedJob(id int64, lastError string, retryAfter time.Duration) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		UPDATE job_queue
+		SET status = CASE WHEN $3::float8 > 0 AND attempts < max_attempts THEN 'queued' ELSE 'failed' END,
+		    run_at = NOW() + $3 * INTERVAL '1 second', last_error = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := r.db.ExecContext(ctx, query, id, lastError, retryAfter.Seconds()); err != nil {
+		return fmt.Errorf("failed to fail queued job %d: %w", id, err)
+	}
+	return nil
+}
+
+// GetQueuedJob fetches a job by id, returning domain.ErrNotFound if it doesn't exist.
+func (r *Repository) GetQueuedJob(id int64) (*domain.QueuedJob, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	job, err := scanQueuedJob(r.db.QueryRowContext(ctx, `SELECT `+queuedJobColumns+` FROM job_queue WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: queued job %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query queued job %d: %w", id, err)
+	}
+	return &job, nil
+}
+
+// GetQueuedJobs fetches every job of the queue, oldest first, results left out.
+func (r *Repository) GetQueuedJobs() ([]domain.QueuedJob, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, type, payload, status, attempts, max_attempts, run_at, last_error, NULL, created_at, updated_at
+		FROM job_queue
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query queued jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []domain.QueuedJob{}
+	for rows.Next() {
+		job, err := scanQueuedJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan queued job row: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// PruneQueuedJobs deletes the done and failed jobs last updated before
+// before, returning how many were deleted.
+func (r *Repository) PruneQueuedJobs(before time.Time) (int64, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM job_queue WHERE status IN ('done', 'failed') AND updated_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune queued jobs: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func scanQueuedJob(row interface{ Scan(...any) error }) (domain.QueuedJob, error) {
+	var job domain.QueuedJob
+	var payload, result []byte
+	var createdAt, updatedAt time.Time
+	if err := row.Scan(
+		&job.ID, &job.Type, &payload, &job.Status, &job.Attempts, &job.MaxAttempts, &job.RunAt,
+		&job.LastError, &result, &createdAt, &updatedAt,
+	); err != nil {
+		return domain.QueuedJob{}, err
+	}
+	job.Payload, job.Result = payload, result
+	job.CreatedAt, job.UpdatedAt = &createdAt, &updatedAt
+	return job, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var queuedJobRowColumns = []string{"id", "type", "payload", "status", "attempts", "max_attempts", "run_at", "last_error", "result", "created_at", "updated_at"}
+
+func TestEnqueueJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`INSERT INTO job_queue \(type, payload, max_attempts\)\s+VALUES \(\$1, \$2, \$3\)\s+ON CONFLICT \(type, payload\) WHERE status IN \('queued', 'running'\)`).
+		WithArgs(domain.QueuedSyncAirport, []byte(`{"faa":"DEN"}`), 3).
+		WillReturnRows(sqlmock.NewRows(queuedJobRowColumns).
+			AddRow(4, domain.QueuedSyncAirport, []byte(`{"faa":"DEN"}`), domain.JobStatusRunning, 1, 3, sampleTime, "", nil, sampleTime, sampleTime))
+	job := domain.QueuedJob{Type: domain.QueuedSyncAirport, Payload: json.RawMessage(`{"faa":"DEN"}`), MaxAttempts: 3}
+	assert.NoError(t, r.EnqueueJob(&job))
+	assert.Equal(t, domain.QueuedJob{
+		ID: 4, Type: domain.QueuedSyncAirport, Payload: json.RawMessage(`{"faa":"DEN"}`), Status: domain.JobStatusRunning,
+		Attempts: 1, MaxAttempts: 3, RunAt: sampleTime, CreatedAt: &sampleTime, UpdatedAt: &sampleTime,
+	}, job, "The job already running is returned")
+
+	mock.ExpectQuery(`INSERT INTO job_queue`).WillReturnError(errors.New(anErrorMsg))
+	assert.EqualError(t, r.EnqueueJob(&domain.QueuedJob{Type: domain.QueuedSyncAll}), "failed to queue sync_all job: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimQueuedJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`UPDATE job_queue\s+SET status = 'running', attempts = attempts \+ 1.*FOR UPDATE SKIP LOCKED`).
+		WithArgs(float64(60)).
+		WillReturnRows(sqlmock.NewRows(queuedJobRowColumns).
+			AddRow(5, domain.QueuedSyncAll, []byte(`{}`), domain.JobStatusRunning, 1, 3, sampleTime, "", nil, sampleTime, sampleTime))
+	job, err := r.ClaimQueuedJob(time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), job.ID)
+	assert.Equal(t, domain.QueuedSyncAll, job.Type)
+
+	mock.ExpectQuery(`UPDATE job_queue`).WillReturnError(sql.ErrNoRows)
+	job, err = r.ClaimQueuedJob(time.Minute)
+	assert.NoError(t, err)
+	assert.Nil(t, job, "Nothing is due")
+
+	mock.ExpectQuery(`UPDATE job_queue`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.ClaimQueuedJob(time.Minute)
+	assert.EqualError(t, err, "failed to claim queued job: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFinishAndFailQueuedJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectExec(`UPDATE job_queue SET run_at = NOW\(\) \+ \$2 \* INTERVAL '1 second' WHERE id = \$1 AND status = 'running'`).
+		WithArgs(int64(5), float64(60)).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.ExtendQueuedJob(5, time.Minute))
+
+	mock.ExpectExec(`UPDATE job_queue SET status = 'done', result = \$2`).
+		WithArgs(int64(5), []byte(`{"updated":2}`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.FinishQueuedJob(5, []byte(`{"updated":2}`)))
+
+	mock.ExpectExec(`UPDATE job_queue\s+SET status = CASE WHEN \$3::float8 > 0 AND attempts < max_attempts THEN 'queued' ELSE 'failed' END`).
+		WithArgs(int64(5), "weather down", float64(30)).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.FailQueuedJob(5, "weather down", 30*time.Second))
+
+	mock.ExpectExec(`UPDATE job_queue`).WillReturnError(errors.New(anErrorMsg))
+	assert.EqualError(t, r.FailQueuedJob(5, "weather down", 0), "failed to fail queued job 5: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetQueuedJobs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`SELECT .* FROM job_queue WHERE id = \$1`).WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows(queuedJobRowColumns).
+			AddRow(5, domain.QueuedSyncAll, []byte(`{}`), domain.JobStatusDone, 1, 3, sampleTime, "", []byte(`{"updated":2}`), sampleTime, sampleTime))
+	job, err := r.GetQueuedJob(5)
+	assert.NoError(t, err)
+	assert.Equal(t, json.RawMessage(`{"updated":2}`), job.Result)
+
+	mock.ExpectQuery(`FROM job_queue WHERE id = \$1`).WillReturnError(sql.ErrNoRows)
+	_, err = r.GetQueuedJob(6)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	mock.ExpectQuery(`SELECT .* FROM job_queue\s+ORDER BY id`).
+		WillReturnRows(sqlmock.NewRows(queuedJobRowColumns).
+			AddRow(5, domain.QueuedSyncAirport, []byte(`{"faa":"DEN"}`), domain.JobStatusFailed, 3, 3, sampleTime, "weather down", nil, sampleTime, sampleTime))
+	jobs, err := r.GetQueuedJobs()
+	assert.NoError(t, err)
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "weather down", jobs[0].LastError)
+		assert.Nil(t, jobs[0].Result)
+	}
+
+	mock.ExpectExec(`DELETE FROM job_queue WHERE status IN \('done', 'failed'\) AND updated_at < \$1`).
+		WithArgs(sampleTime).WillReturnResult(sqlmock.NewResult(0, 4))
+	pruned, err := r.PruneQueuedJobs(sampleTime)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), pruned)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
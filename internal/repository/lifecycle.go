@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// ChangeAirportStatus moves an airport to another lifecycle status and
+// records the change in its history, in one transaction. Returns
+// domain.ErrNotFound if the airport doesn't exist, and
+// domain.ErrInvalidTransition if it may not move from its current status.
+func (r *Repository) ChangeAirportStatus(faa, status, reason string) (*domain.AirportStatusChange, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	change := domain.AirportStatusChange{Faa: faa, ToStatus: status, Reason: reason}
+	err = tx.QueryRowContext(ctx, `SELECT lifecycle_status FROM airport WHERE faa = $1 FOR UPDATE`, faa).Scan(&change.FromStatus)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrNotFound, faa)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock airport %s: %w", faa, err)
+	}
+	if err := domain.CheckLifecycleTransition(change.FromStatus, status); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE airport SET lifecycle_status = $2, updated_at = NOW() WHERE faa = $1`, faa, status); err != nil {
+		return nil, fmt.Errorf("failed to update status of %s: %w", faa, err)
+	}
+	var changedAt time.Time
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO airport_status_history (faa, from_status, to_status, reason)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, changed_at
+	`, faa, change.FromStatus, status, reason).Scan(&change.ID, &changedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record status change of %s: %w", faa, err)
+	}
+	change.ChangedAt = &changedAt
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit status change: %w", err)
+	}
+	return &change, nil
+}
+
+// GetAirportStatusHistory fetches the lifecycle status changes of an
+// airport, oldest first.
+func (r *Repository) GetAirportStatusHistory(faa string) ([]domain.AirportStatusChange, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, faa, from_status, to_status, reason, changed_at
+		FROM airport_status_history
+		WHERE faa = $1
+		ORDER BY changed_at, id
+	`, faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []domain.AirportStatusChange{}
+	for rows.Next() {
+		var c domain.AirportStatusChange
+		var changedAt time.Time
+		if err := rows.Scan(&c.ID, &c.Faa, &c.FromStatus, &c.ToStatus, &c.Reason, &changedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status change row: %w", err)
+		}
+		c.ChangedAt = &changedAt
+		changes = append(changes, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return changes, nil
+}
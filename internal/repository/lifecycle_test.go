@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeAirportStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	// The status and its history are written together
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT lifecycle_status FROM airport WHERE faa = \$1 FOR UPDATE`).WithArgs("TST").
+		WillReturnRows(sqlmock.NewRows([]string{"lifecycle_status"}).AddRow("active"))
+	mock.ExpectExec(`UPDATE airport SET lifecycle_status = \$2, updated_at = NOW\(\) WHERE faa = \$1`).WithArgs("TST", "closed").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO airport_status_history`).WithArgs("TST", "active", "closed", "Runway works").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "changed_at"}).AddRow(7, sampleTime))
+	mock.ExpectCommit()
+	change, err := r.ChangeAirportStatus("TST", domain.LifecycleClosed, "Runway works")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.AirportStatusChange{
+		ID: 7, Faa: "TST", FromStatus: "active", ToStatus: "closed", Reason: "Runway works", ChangedAt: &sampleTime,
+	}, change)
+
+	// Transitions not allowed change nothing
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT lifecycle_status FROM airport`).WithArgs("TST").
+		WillReturnRows(sqlmock.NewRows([]string{"lifecycle_status"}).AddRow("decommissioned"))
+	mock.ExpectRollback()
+	_, err = r.ChangeAirportStatus("TST", domain.LifecycleActive, "")
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT lifecycle_status FROM airport`).WithArgs("NOPE").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+	_, err = r.ChangeAirportStatus("NOPE", domain.LifecycleClosed, "")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAirportStatusHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`FROM airport_status_history\s+WHERE faa = \$1\s+ORDER BY changed_at, id`).WithArgs("TST").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "faa", "from_status", "to_status", "reason", "changed_at"}).
+			AddRow(7, "TST", "active", "seasonal", "Winter", sampleTime))
+	changes, err := r.GetAirportStatusHistory("TST")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AirportStatusChange{{
+		ID: 7, Faa: "TST", FromStatus: "active", ToStatus: "seasonal", Reason: "Winter", ChangedAt: &sampleTime,
+	}}, changes)
+
+	mock.ExpectQuery(`FROM airport_status_history`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetAirportStatusHistory("TST")
+	assert.EqualError(t, err, "failed to query status history: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// AirportChangesChannel is the NOTIFY channel of the airport table trigger.
+const AirportChangesChannel = "airport_changes"
+
+// listenerPingInterval checks the otherwise idle LISTEN connection, so a
+// dropped one is noticed and reopened.
+const listenerPingInterval = 90 * time.Second
+
+// Reconnect delays of the LISTEN connection, doubling up to the max.
+const (
+	listenerMinBackoff = time.Second
+	listenerMaxBackoff = time.Minute
+)
+
+// ListenAirportChanges streams the airport changes announced by the database,
+// whoever wrote them, until ctx is done. LISTEN is per session, so it opens its
+// own connection from dsn instead of using the pool; dsn is asked again on each
+// reconnect so a rotated password is used. Changes made while the connection
+// is down are lost.
+func ListenAirportChanges(ctx context.Context, dsn func() string) (<-chan domain.AirportChange, error) {
+	conn, err := listen(ctx, dsn())
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan domain.AirportChange, 64)
+	go func() {
+		defer close(changes)
+		defer func() { conn.Close(context.Background()) }()
+
+		backoff := listenerMinBackoff
+		for {
+			n, err := waitForNotification(ctx, conn)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				log.Printf("WARN: Airport change listener: %v", err)
+				conn.Close(context.Background())
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(backoff):
+					}
+					backoff = min(backoff*2, listenerMaxBackoff)
+					if conn, err = listen(ctx, dsn()); err == nil {
+						break
+					}
+					log.Printf("WARN: Airport change listener: %v", err)
+				}
+				backoff = listenerMinBackoff
+				continue
+			}
+			if n == nil {
+				// Idle, the connection answered the ping
+				continue
+			}
+
+			change, err := parseAirportChange(n.Payload)
+			if err != nil {
+				log.Printf("WARN: Ignoring airport change %q: %v", n.Payload, err)
+				continue
+			}
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// listen opens a connection subscribed to AirportChangesChannel.
+func listen(ctx context.Context, dsn string) (*pgx.Conn, error) {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect listener: %w", err)
+	}
+	if _, err := conn.Exec(ctx, "LISTEN "+AirportChangesChannel); err != nil {
+		conn.Close(context.Background())
+		return nil, fmt.Errorf("failed to listen on %s: %w", AirportChangesChannel, err)
+	}
+	return conn, nil
+}
+
+// waitForNotification waits up to listenerPingInterval for a notification,
+// then pings the connection and returns nil if it is still up.
+func waitForNotification(ctx context.Context, conn *pgx.Conn) (*pgconn.Notification, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, listenerPingInterval)
+	defer cancel()
+	n, err := conn.WaitForNotification(waitCtx)
+	if err == nil || ctx.Err() != nil || !errors.Is(err, context.DeadlineExceeded) {
+		return n, err
+	}
+	return nil, conn.Ping(ctx)
+}
+
+// parseAirportChange decodes the JSON payload sent by the airport trigger.
+func parseAirportChange(payload string) (domain.AirportChange, error) {
+	var change domain.AirportChange
+	if err := json.Unmarshal([]byte(payload), &change); err != nil {
+		return change, err
+	}
+	if change.Faa == "" {
+		return change, fmt.Errorf("missing faa_ident")
+	}
+	return change, nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAirportChange(t *testing.T) {
+	change, err := parseAirportChange(`{"op":"update","faa_ident":"DFW"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AirportChange{Op: domain.ChangeUpdate, Faa: "DFW"}, change)
+
+	_, err = parseAirportChange(`{"op":"delete"}`)
+	assert.EqualError(t, err, "missing faa_ident")
+
+	_, err = parseAirportChange(`not json`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// navaidColumns is the navaids column list scanned by scanNavaids.
+const navaidColumns = `ident, type, name, city, state_code, frequency, channel,
+	latitude, longitude, elevation_ft, status, cycle`
+
+// ReplaceNavaids swaps every stored navaid for the given list in a single
+// transaction, as each NASR cycle republishes the whole file.
+func (r *Repository) ReplaceNavaids(navaids []domain.Navaid) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM navaids`); err != nil {
+		return fmt.Errorf("failed to clear navaids: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO navaids (`+navaidColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare navaid insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, n := range navaids {
+		if _, err := stmt.ExecContext(ctx, n.Ident, n.Type, n.Name, n.City, n.StateCode, n.Frequency, n.Channel,
+			n.Latitude, n.Longitude, n.ElevationFt, n.Status, n.Cycle); err != nil {
+			return fmt.Errorf("failed to insert navaid %s %s: %w", n.Ident, n.Type, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit navaids: %w", err)
+	}
+
+	return nil
+}
+
+// GetNavaidsInBox fetches the navaids positioned inside box, edges included.
+func (r *Repository) GetNavaidsInBox(box aviation.Box) ([]domain.Navaid, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `SELECT ` + navaidColumns + ` FROM navaids
+		WHERE latitude BETWEEN $1 AND $2 AND longitude BETWEEN $3 AND $4
+		ORDER BY ident, type`
+
+	rows, err := r.db.QueryContext(ctx, query, box.MinLat, box.MaxLat, box.MinLon, box.MaxLon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query navaids in box: %w", err)
+	}
+	defer rows.Close()
+
+	var navaids []domain.Navaid
+	for rows.Next() {
+		var n domain.Navaid
+		if err := rows.Scan(&n.Ident, &n.Type, &n.Name, &n.City, &n.StateCode, &n.Frequency, &n.Channel,
+			&n.Latitude, &n.Longitude, &n.ElevationFt, &n.Status, &n.Cycle); err != nil {
+			return nil, fmt.Errorf("failed to scan navaid row: %w", err)
+		}
+		navaids = append(navaids, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return navaids, nil
+}
+
+// GetNearestNavaids fetches up to limit navaids within radiusNM of center,
+// nearest first. A limit of 0 returns every navaid in range.
+func (r *Repository) GetNearestNavaids(center aviation.Point, radiusNM float64, limit int) ([]domain.NearbyNavaid, error) {
+	candidates, err := r.GetNavaidsInBox(aviation.BoxAround(center, radiusNM))
+	if err != nil {
+		return nil, err
+	}
+
+	var nearby []domain.NearbyNavaid
+	for _, n := range candidates {
+		if d := aviation.DistanceNM(center, aviation.Point{Lat: n.Latitude, Lon: n.Longitude}); d <= radiusNM {
+			nearby = append(nearby, domain.NearbyNavaid{Navaid: n, DistanceNM: d})
+		}
+	}
+
+	sort.SliceStable(nearby, func(i, j int) bool { return nearby[i].DistanceNM < nearby[j].DistanceNM })
+	if limit > 0 && len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+	return nearby, nil
+}
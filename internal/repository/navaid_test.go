@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var navaidCols = []string{
+	"ident", "type", "name", "city", "state_code", "frequency", "channel",
+	"latitude", "longitude", "elevation_ft", "status", "cycle",
+}
+
+func TestReplaceNavaids(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	freq := 115.9
+	navaids := []domain.Navaid{
+		{Ident: "CRI", Type: domain.NavaidVOR, Name: "CANARSIE", City: "NEW YORK", StateCode: "NY", Frequency: &freq, Latitude: 40.6124, Longitude: -73.8242, Status: "OPERATIONAL IFR", Cycle: "2501"},
+		{Ident: "IJFK", Type: domain.NavaidDME, Channel: "42X", Latitude: 40.64, Longitude: -73.77},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM navaids`).WillReturnResult(sqlmock.NewResult(0, 10))
+	prep := mock.ExpectPrepare(`INSERT INTO navaids`)
+	prep.ExpectExec().WithArgs("CRI", "VOR", "CANARSIE", "NEW YORK", "NY", &freq, "", 40.6124, -73.8242, nil, "OPERATIONAL IFR", "2501").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	prep.ExpectExec().WithArgs("IJFK", "DME", "", "", "", nil, "42X", 40.64, -73.77, nil, "", "").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+	assert.NoError(t, r.ReplaceNavaids(navaids))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM navaids`).WillReturnResult(sqlmock.NewResult(0, 2))
+	prep = mock.ExpectPrepare(`INSERT INTO navaids`)
+	prep.ExpectExec().WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+	assert.EqualError(t, r.ReplaceNavaids(navaids), "failed to insert navaid CRI VOR: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetNearestNavaids(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	jfk := aviation.Point{Lat: 40.6398, Lon: -73.7789}
+
+	mock.ExpectQuery(`SELECT (.+) FROM navaids\s+WHERE latitude BETWEEN \$1 AND \$2 AND longitude BETWEEN \$3 AND \$4`).
+		WillReturnRows(sqlmock.NewRows(navaidCols).
+			AddRow("CRI", "VOR", "CANARSIE", "NEW YORK", "NY", 115.9, "", 40.6124, -73.8242, 10.0, "OPERATIONAL IFR", "").
+			AddRow("DPK", "VOR/DME", "DEER PARK", "DEER PARK", "NY", 117.7, "124X", 40.7919, -73.3033, nil, "OPERATIONAL IFR", "").
+			AddRow("ETX", "VORTAC", "EAST TEXAS", "ALLENTOWN", "PA", 110.2, "39X", 40.5810, -75.6821, nil, "OPERATIONAL IFR", ""))
+	nearby, err := r.GetNearestNavaids(jfk, 50, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, nearby, 2) {
+		assert.Equal(t, "CRI", nearby[0].Ident)
+		assert.InDelta(t, 2.5, nearby[0].DistanceNM, 0.5)
+		assert.Equal(t, 10.0, *nearby[0].ElevationFt)
+		assert.Equal(t, "DPK", nearby[1].Ident)
+		assert.Nil(t, nearby[1].ElevationFt)
+	}
+
+	mock.ExpectQuery(`SELECT (.+) FROM navaids`).
+		WillReturnRows(sqlmock.NewRows(navaidCols).
+			AddRow("DPK", "VOR/DME", "DEER PARK", "DEER PARK", "NY", 117.7, "124X", 40.7919, -73.3033, nil, "", "").
+			AddRow("CRI", "VOR", "CANARSIE", "NEW YORK", "NY", 115.9, "", 40.6124, -73.8242, nil, "", ""))
+	nearby, err = r.GetNearestNavaids(jfk, 50, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, nearby, 1) {
+		assert.Equal(t, "CRI", nearby[0].Ident)
+	}
+
+	mock.ExpectQuery(`SELECT (.+) FROM navaids`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetNearestNavaids(jfk, 50, 0)
+	assert.EqualError(t, err, "failed to query navaids in box: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
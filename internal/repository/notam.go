@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// ReplaceNOTAMs swaps the stored NOTAMs of one airport for those of a fresh
+// refresh in one transaction, so readers never see a partial set.
+func (r *Repository) ReplaceNOTAMs(faa string, notams []domain.NOTAM) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM notams WHERE faa = $1`, faa); err != nil {
+		return fmt.Errorf("failed to clear NOTAMs for %s: %w", faa, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO notams (faa, id, number, type, classification, issued_at, effective_start, effective_end, text)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (faa, id) DO NOTHING
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare NOTAM insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, n := range notams {
+		if _, err := stmt.ExecContext(ctx, faa, n.ID, n.Number, n.Type, n.Classification, n.IssuedAt, n.EffectiveStart, n.EffectiveEnd, n.Text); err != nil {
+			return fmt.Errorf("failed to insert NOTAM %s for %s: %w", n.Number, faa, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit NOTAMs for %s: %w", faa, err)
+	}
+	return nil
+}
+
+// GetNOTAMs fetches the stored NOTAMs of one airport that have not ended at
+// at, those starting soonest first.
+func (r *Repository) GetNOTAMs(faa string, at time.Time) ([]domain.NOTAM, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		SELECT id, number, type, classification, issued_at, effective_start, effective_end, text
+		FROM notams
+		WHERE faa = $1 AND (effective_end IS NULL OR effective_end > $2)
+		ORDER BY effective_start NULLS FIRST, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, faa, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query NOTAMs for %s: %w", faa, err)
+	}
+	defer rows.Close()
+
+	notams := []domain.NOTAM{}
+	for rows.Next() {
+		n := domain.NOTAM{Faa: faa}
+		var issuedAt, start, end sql.NullTime
+		if err := rows.Scan(&n.ID, &n.Number, &n.Type, &n.Classification, &issuedAt, &start, &end, &n.Text); err != nil {
+			return nil, fmt.Errorf("failed to scan NOTAM row: %w", err)
+		}
+		if issuedAt.Valid {
+			n.IssuedAt = &issuedAt.Time
+		}
+		if start.Valid {
+			n.EffectiveStart = &start.Time
+		}
+		if end.Valid {
+			n.EffectiveEnd = &end.Time
+		}
+		notams = append(notams, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return notams, nil
+}
+
+// GetFollowedFAAs fetches the FAA codes on any watchlist or subscription, in
+// order.
+func (r *Repository) GetFollowedFAAs() ([]string, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		SELECT unnest(faa_codes) AS faa FROM watchlists
+		UNION
+		SELECT unnest(faa_codes) FROM subscriptions
+		ORDER BY faa
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query followed airports: %w", err)
+	}
+	defer rows.Close()
+
+	faas := []string{}
+	for rows.Next() {
+		var faa string
+		if err := rows.Scan(&faa); err != nil {
+			return nil, fmt.Errorf("failed to scan followed airport: %w", err)
+		}
+		faas = append(faas, faa)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return faas, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceNOTAMs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	notams := []domain.NOTAM{{ID: "NOTAM_1_73849637", Number: "10/132", Type: "N", Classification: "DOM", EffectiveStart: &sampleTime, Text: "RWY 16R/34L CLSD"}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM notams WHERE faa = \$1`).WithArgs("DEN").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectPrepare(`INSERT INTO notams`).
+		ExpectExec().
+		WithArgs("DEN", "NOTAM_1_73849637", "10/132", "N", "DOM", nil, &sampleTime, nil, "RWY 16R/34L CLSD").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.NoError(t, r.ReplaceNOTAMs("DEN", notams))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM notams`).WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+	assert.EqualError(t, r.ReplaceNOTAMs("DEN", notams), "failed to clear NOTAMs for DEN: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetNOTAMs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	columns := []string{"id", "number", "type", "classification", "issued_at", "effective_start", "effective_end", "text"}
+
+	mock.ExpectQuery(`FROM notams\s+WHERE faa = \$1 AND \(effective_end IS NULL OR effective_end > \$2\)`).
+		WithArgs("DEN", sampleTime).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("NOTAM_1_70000001", "06/001", "N", "DOM", sampleTime, sampleTime, nil, "TWY B3 CHANGED TO B4"))
+
+	notams, err := r.GetNOTAMs("DEN", sampleTime)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.NOTAM{{
+		ID: "NOTAM_1_70000001", Faa: "DEN", Number: "06/001", Type: "N", Classification: "DOM",
+		IssuedAt: &sampleTime, EffectiveStart: &sampleTime, Text: "TWY B3 CHANGED TO B4",
+	}}, notams, "A permanent NOTAM has no end")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFollowedFAAs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	mock.ExpectQuery(`SELECT unnest\(faa_codes\) AS faa FROM watchlists\s+UNION\s+SELECT unnest\(faa_codes\) FROM subscriptions`).
+		WillReturnRows(sqlmock.NewRows([]string{"faa"}).AddRow("APA").AddRow("DEN"))
+
+	faas, err := r.GetFollowedFAAs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"APA", "DEN"}, faas)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// CreateAirportNote saves a note on an airport, setting its id and creation
+// time.
+func (r *Repository) CreateAirportNote(note *domain.AirportNote) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO airport_notes (faa, body, author, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	var createdAt time.Time
+	if err := r.db.QueryRowContext(ctx, query, note.Faa, note.Body, note.Author, note.ExpiresAt).Scan(&note.ID, &createdAt); err != nil {
+		return fmt.Errorf("failed to create note: %w", err)
+	}
+	note.CreatedAt = &createdAt
+
+	return nil
+}
+
+// GetAirportNotes fetches the notes of an airport that have not expired,
+// oldest first.
+func (r *Repository) GetAirportNotes(faa string) ([]domain.AirportNote, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, faa, body, author, expires_at, created_at
+		FROM airport_notes
+		WHERE faa = $1 AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY created_at, id
+	`, faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notes: %w", err)
+	}
+	defer rows.Close()
+
+	notes := []domain.AirportNote{}
+	for rows.Next() {
+		var n domain.AirportNote
+		var expiresAt sql.NullTime
+		var createdAt time.Time
+		if err := rows.Scan(&n.ID, &n.Faa, &n.Body, &n.Author, &expiresAt, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan note row: %w", err)
+		}
+		n.ExpiresAt, n.CreatedAt = nullTime(expiresAt), &createdAt
+		notes = append(notes, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return notes, nil
+}
+
+// DeleteAirportNote removes a note of an airport, returning
+// domain.ErrNoteNotFound if the airport has none with that id.
+func (r *Repository) DeleteAirportNote(faa string, id int64) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM airport_notes WHERE id = $1 AND faa = $2`, id, faa)
+	if err != nil {
+		return fmt.Errorf("failed to delete note %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected for note %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %d", domain.ErrNoteNotFound, id)
+	}
+
+	return nil
+}
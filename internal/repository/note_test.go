@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAirportNote(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	note := domain.AirportNote{Faa: "TST", Body: "Fuel unavailable this week", Author: "Dispatch", ExpiresAt: &sampleTime}
+	mock.ExpectQuery(`INSERT INTO airport_notes \(faa, body, author, expires_at\)`).
+		WithArgs("TST", "Fuel unavailable this week", "Dispatch", &sampleTime).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, sampleTime))
+	assert.NoError(t, r.CreateAirportNote(&note))
+	assert.Equal(t, int64(5), note.ID)
+	assert.Equal(t, &sampleTime, note.CreatedAt)
+
+	mock.ExpectQuery(`INSERT INTO airport_notes`).WillReturnError(errors.New(anErrorMsg))
+	assert.EqualError(t, r.CreateAirportNote(&domain.AirportNote{Faa: "TST", Body: "x"}), "failed to create note: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAirportNotes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`FROM airport_notes\s+WHERE faa = \$1 AND \(expires_at IS NULL OR expires_at > NOW\(\)\)`).WithArgs("TST").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "faa", "body", "author", "expires_at", "created_at"}).
+			AddRow(5, "TST", "Fuel unavailable this week", "Dispatch", nil, sampleTime))
+	notes, err := r.GetAirportNotes("TST")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AirportNote{{ID: 5, Faa: "TST", Body: "Fuel unavailable this week", Author: "Dispatch", CreatedAt: &sampleTime}}, notes)
+
+	mock.ExpectQuery(`FROM airport_notes`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetAirportNotes("TST")
+	assert.EqualError(t, err, "failed to query notes: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAirportNote(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectExec(`DELETE FROM airport_notes WHERE id = \$1 AND faa = \$2`).WithArgs(int64(5), "TST").WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.DeleteAirportNote("TST", 5))
+
+	mock.ExpectExec(`DELETE FROM airport_notes`).WithArgs(int64(5), "JFK").WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, r.DeleteAirportNote("JFK", 5), domain.ErrNoteNotFound, "Notes of other airports are not found")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// UpdateAirportWithEvents updates an airport like UpdateAirport and saves the
+// events announcing it to event_outbox in the same transaction, so either
+// both are stored or neither is.
+func (r *Repository) UpdateAirportWithEvents(airport *domain.Airport, events []domain.OutboxEvent) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if err := updateAirport(ctx, tx, airport); err != nil {
+		return err
+	}
+	if err := saveOutboxEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit airport %s: %w", airport.Faa, err)
+	}
+	return nil
+}
+
+// ModifyAirport reads an airport, locking its row, hands it to modify and
+// saves the result with the events modify returns, in one transaction.
+// Writers of the airport wait for it meanwhile, so whatever they write in
+// between a read and an update isn't overwritten with what was read.
+// Returns domain.ErrNotFound if the airport doesn't exist, and the error of
+// modify, saving nothing, if it fails.
+func (r *Repository) ModifyAirport(faa string, modify func(a *domain.Airport) ([]domain.OutboxEvent, error)) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	rows, err := tx.QueryContext(ctx, `SELECT `+airportColumns+` FROM airport WHERE faa = $1 FOR UPDATE`, faa)
+	if err != nil {
+		return fmt.Errorf("failed to lock airport %s: %w", faa, err)
+	}
+	if !rows.Next() {
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to lock airport %s: %w", faa, err)
+		}
+		return fmt.Errorf("%w: %s", domain.ErrNotFound, faa)
+	}
+	airport, err := scanAirport(rows)
+	rows.Close()
+	if err != nil {
+		return err
+	}
+
+	events, err := modify(&airport)
+	if err != nil {
+		return err
+	}
+	if err := updateAirport(ctx, tx, &airport); err != nil {
+		return err
+	}
+	if err := saveOutboxEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit airport %s: %w", faa, err)
+	}
+	return nil
+}
+
+// MergeAirports saves the airports of a bulk sync onto their rows as stored
+// now, in one transaction. It locks the rows in FAA order, hands each stored
+// airport with its synced counterpart to merge, and updates it with the result
+// along with the events merge returns, so an edit saved since the sync read
+// the airports isn't overwritten. Airports deleted meanwhile are skipped.
+// Returns the airports as saved, in FAA order, and nothing is saved if merge
+// fails.
+func (r *Repository) MergeAirports(synced []domain.Airport, merge func(stored, synced *domain.Airport) ([]domain.OutboxEvent, error)) ([]domain.Airport, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	bySynced := make(map[string]*domain.Airport, len(synced))
+	faas := make([]string, 0, len(synced))
+	for i := range synced {
+		bySynced[synced[i].Faa] = &synced[i]
+		faas = append(faas, synced[i].Faa)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	rows, err := tx.QueryContext(ctx, `SELECT `+airportColumns+` FROM airport WHERE faa = ANY($1) ORDER BY faa FOR UPDATE`, textArray(faas))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock airports: %w", err)
+	}
+	var stored []domain.Airport
+	for rows.Next() {
+		airport, err := scanAirport(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		stored = append(stored, airport)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to lock airports: %w", err)
+	}
+
+	for i := range stored {
+		events, err := merge(&stored[i], bySynced[stored[i].Faa])
+		if err != nil {
+			return nil, err
+		}
+		if err := updateAirport(ctx, tx, &stored[i]); err != nil {
+			return nil, err
+		}
+		if err := saveOutboxEvents(ctx, tx, events); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit merged airports: %w", err)
+	}
+	return stored, nil
+}
+
+func saveOutboxEvents(ctx context.Context, tx *sql.Tx, events []domain.OutboxEvent) error {
+	query := `INSERT INTO event_outbox (tenant_id, event, payload) VALUES ($1, $2, $3)`
+	for _, e := range events {
+		if _, err := tx.ExecContext(ctx, query, e.TenantID, e.Event, []byte(e.Payload)); err != nil {
+			return fmt.Errorf("failed to save %s event: %w", e.Event, err)
+		}
+	}
+	return nil
+}
+
+// ClaimOutboxEvents fetches up to limit undelivered events, oldest first, and
+// hides them from other dispatchers for lease. An event that is neither
+// deleted nor retried by then, e.g. because its dispatcher crashed, is claimed
+// again.
+func (r *Repository) ClaimOutboxEvents(limit int, lease time.Duration) ([]domain.OutboxEvent, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		WITH claimed AS (
+			UPDATE event_outbox
+			SET available_at = NOW() + $2 * INTERVAL '1 second'
+			WHERE id IN (
+				SELECT id FROM event_outbox
+				WHERE available_at <= NOW()
+				ORDER BY id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, tenant_id, event, payload, attempts, created_at
+		)
+		SELECT id, tenant_id, event, payload, attempts, created_at FROM claimed ORDER BY id
+	`, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []domain.OutboxEvent{}
+	for rows.Next() {
+		var e domain.OutboxEvent
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.TenantID, &e.Event, &payload, &e.Attempts, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event row: %w", err)
+		}
+		e.Payload = payload
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return events, nil
+}
+
+// DeleteOutboxEvent removes a delivered event.
+func (r *Repository) DeleteOutboxEvent(id int64) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM event_outbox WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete outbox event %d: %w", id, err)
+	}
+	return nil
+}
+
+// RetryOutboxEvent records a failed delivery of an event and makes it
+// available again after delay.
+func (r *Repository) RetryOutboxEvent(id int64, lastError string, delay time.Duration) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		UPDATE event_outbox
+		SET attempts = attempts + 1, last_error = $2, available_at = NOW() + $3 * INTERVAL '1 second'
+		WHERE id = $1
+	`
+	if _, err := r.db.ExecContext(ctx, query, id, lastError, delay.Seconds()); err != nil {
+		return fmt.Errorf("failed to retry outbox event %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateAirportWithEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	airport := sampleAirport
+	events := []domain.OutboxEvent{{Event: domain.EventAirportUpdated, Payload: json.RawMessage(`{"faa_ident":"TST"}`)}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE airport`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO event_outbox \(tenant_id, event, payload\) VALUES \(\$1, \$2, \$3\)`).
+		WithArgs(domain.AllTenants, domain.EventAirportUpdated, []byte(`{"faa_ident":"TST"}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	assert.NoError(t, r.UpdateAirportWithEvents(&airport, events))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE airport`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	err = r.UpdateAirportWithEvents(&airport, events)
+	assert.ErrorIs(t, err, domain.ErrNotFound, "No event is saved for a missing airport")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE airport`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO event_outbox`).WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+	err = r.UpdateAirportWithEvents(&airport, events)
+	assert.EqualError(t, err, "failed to save airport.updated event: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestModifyAirport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	cols := []string{
+		"site_number", "facility_name", "faa", "icao", "state_code", "state_full", "county",
+		"city", "ownership_type", "use_type", "manager", "manager_phone",
+		"latitude", "longitude", "airport_status", "weather",
+		"created_at", "updated_at", "last_synced_at",
+		"elevation_ft", "pressure_altitude_ft", "density_altitude_ft", "magnetic_variation",
+		"timezone", "manual_overrides", "lifecycle_status", "fuel_types", "fbo_name", "fbo_phone",
+	}
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(cols).AddRow(
+			sampleAirport.SiteNumber, sampleAirport.FacilityName, sampleAirport.Faa, sampleAirport.Icao,
+			sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County,
+			sampleAirport.City, sampleAirport.OwnershipType, sampleAirport.UseType, sampleAirport.Manager, sampleAirport.ManagerPhone,
+			sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, "Rain",
+			nil, nil, nil,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		)
+	}
+	events := []domain.OutboxEvent{{Event: domain.EventAirportUpdated, Payload: json.RawMessage(`{"faa_ident":"TST"}`)}}
+
+	// The locked airport is modified and saved with its events
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM airport WHERE faa = \$1 FOR UPDATE`).WithArgs("TST").WillReturnRows(row())
+	mock.ExpectExec(`UPDATE airport`).WithArgs(
+		"TST", sampleAirport.SiteNumber, sampleAirport.FacilityName, sampleAirport.Icao,
+		sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County, sampleAirport.City,
+		sampleAirport.OwnershipType, sampleAirport.UseType, sampleAirport.Manager, sampleAirport.ManagerPhone,
+		sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, "Fog",
+		sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+		`["manager"]`, `["JetA"]`, "", "",
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO event_outbox`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	var read string
+	err = r.ModifyAirport("TST", func(a *domain.Airport) ([]domain.OutboxEvent, error) {
+		read = a.Weather
+		a.Weather = "Fog"
+		a.ManualOverrides = []string{"manager"}
+		a.FuelTypes = []string{domain.FuelJetA}
+		return events, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Rain", read)
+
+	// A failed modify saves nothing
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE`).WithArgs("TST").WillReturnRows(row())
+	mock.ExpectRollback()
+	err = r.ModifyAirport("TST", func(a *domain.Airport) ([]domain.OutboxEvent, error) {
+		return nil, errors.New(anErrorMsg)
+	})
+	assert.EqualError(t, err, anErrorMsg)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE`).WithArgs("NOPE").WillReturnRows(sqlmock.NewRows(cols))
+	mock.ExpectRollback()
+	err = r.ModifyAirport("NOPE", func(a *domain.Airport) ([]domain.OutboxEvent, error) {
+		t.Fatal("modify shouldn't run for a missing airport")
+		return nil, nil
+	})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE`).WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+	err = r.ModifyAirport("TST", nil)
+	assert.EqualError(t, err, "failed to lock airport TST: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMergeAirports(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	cols := []string{
+		"site_number", "facility_name", "faa", "icao", "state_code", "state_full", "county",
+		"city", "ownership_type", "use_type", "manager", "manager_phone",
+		"latitude", "longitude", "airport_status", "weather",
+		"created_at", "updated_at", "last_synced_at",
+		"elevation_ft", "pressure_altitude_ft", "density_altitude_ft", "magnetic_variation",
+		"timezone", "manual_overrides", "lifecycle_status", "fuel_types", "fbo_name", "fbo_phone",
+	}
+	// The manager was edited after the sync read the airport
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(cols).AddRow(
+			sampleAirport.SiteNumber, sampleAirport.FacilityName, sampleAirport.Faa, sampleAirport.Icao,
+			sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County,
+			sampleAirport.City, sampleAirport.OwnershipType, sampleAirport.UseType, "Edited Manager", sampleAirport.ManagerPhone,
+			sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, "Rain",
+			nil, nil, nil,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		)
+	}
+	synced := sampleAirport
+	synced.Weather = "Fog"
+	gone := sampleAirport
+	gone.Faa = "GONE"
+	events := []domain.OutboxEvent{{Event: domain.EventWeatherChanged, Payload: json.RawMessage(`{"faa_ident":"TST"}`)}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM airport WHERE faa = ANY\(\$1\) ORDER BY faa FOR UPDATE`).WillReturnRows(row())
+	mock.ExpectExec(`UPDATE airport`).WithArgs(
+		"TST", sampleAirport.SiteNumber, sampleAirport.FacilityName, sampleAirport.Icao,
+		sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County, sampleAirport.City,
+		sampleAirport.OwnershipType, sampleAirport.UseType, "Edited Manager", sampleAirport.ManagerPhone,
+		sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, "Fog",
+		sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+		sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO event_outbox`).
+		WithArgs(domain.AllTenants, domain.EventWeatherChanged, []byte(`{"faa_ident":"TST"}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	saved, err := r.MergeAirports([]domain.Airport{synced, gone}, func(stored, s *domain.Airport) ([]domain.OutboxEvent, error) {
+		assert.Equal(t, "Rain", stored.Weather)
+		stored.Weather = s.Weather
+		return events, nil
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, saved, 1, "Airports deleted meanwhile are skipped") {
+		assert.Equal(t, "Edited Manager", saved[0].Manager)
+		assert.Equal(t, "Fog", saved[0].Weather)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE`).WillReturnRows(row())
+	mock.ExpectRollback()
+	_, err = r.MergeAirports([]domain.Airport{synced}, func(stored, s *domain.Airport) ([]domain.OutboxEvent, error) {
+		return nil, errors.New(anErrorMsg)
+	})
+	assert.EqualError(t, err, anErrorMsg)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE`).WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+	_, err = r.MergeAirports([]domain.Airport{synced}, nil)
+	assert.EqualError(t, err, "failed to lock airports: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimOutboxEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`UPDATE event_outbox\s+SET available_at = NOW\(\) \+ \$2 \* INTERVAL '1 second'.*FOR UPDATE SKIP LOCKED`).
+		WithArgs(100, float64(60)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "event", "payload", "attempts", "created_at"}).
+			AddRow(7, 0, domain.EventWeatherChanged, []byte(`{"faa_ident":"TST"}`), 1, sampleTime))
+	events, err := r.ClaimOutboxEvents(100, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.OutboxEvent{{
+		ID: 7, Event: domain.EventWeatherChanged, Payload: json.RawMessage(`{"faa_ident":"TST"}`), Attempts: 1, CreatedAt: sampleTime,
+	}}, events)
+
+	mock.ExpectQuery(`UPDATE event_outbox`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.ClaimOutboxEvents(100, time.Minute)
+	assert.EqualError(t, err, "failed to claim outbox events: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAndRetryOutboxEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectExec(`DELETE FROM event_outbox WHERE id = \$1`).WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.DeleteOutboxEvent(7))
+
+	mock.ExpectExec(`UPDATE event_outbox\s+SET attempts = attempts \+ 1`).WithArgs(int64(7), "broker down", float64(30)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.RetryOutboxEvent(7, "broker down", 30*time.Second))
+
+	mock.ExpectExec(`DELETE FROM event_outbox`).WillReturnError(errors.New(anErrorMsg))
+	assert.EqualError(t, r.DeleteOutboxEvent(7), "failed to delete outbox event 7: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
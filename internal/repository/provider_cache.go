@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"aviation-weather/internal/domain"
+)
+
+// GetProviderResponse fetches the cached response of a provider URL, or nil
+// when there is none.
+func (r *Repository) GetProviderResponse(url string) (*domain.ProviderResponse, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	resp := domain.ProviderResponse{URL: url}
+	err := r.db.QueryRowContext(ctx, `SELECT etag, last_modified, body, fetched_at FROM provider_cache WHERE url = $1`, url).
+		Scan(&resp.ETag, &resp.LastModified, &resp.Body, &resp.FetchedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query provider cache for %s: %w", url, err)
+	}
+	return &resp, nil
+}
+
+// SaveProviderResponse stores the response of a provider URL, replacing the
+// one cached before.
+func (r *Repository) SaveProviderResponse(resp *domain.ProviderResponse) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO provider_cache (url, etag, last_modified, body, fetched_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (url) DO UPDATE SET
+			etag = EXCLUDED.etag,
+			last_modified = EXCLUDED.last_modified,
+			body = EXCLUDED.body,
+			fetched_at = EXCLUDED.fetched_at
+	`
+	if _, err := r.db.ExecContext(ctx, query, resp.URL, resp.ETag, resp.LastModified, resp.Body); err != nil {
+		return fmt.Errorf("failed to save provider response for %s: %w", resp.URL, err)
+	}
+	return nil
+}
+
+// CountProviderResponses counts the cached provider responses.
+func (r *Repository) CountProviderResponses() (int, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	var n int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM provider_cache`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count provider cache: %w", err)
+	}
+	return n, nil
+}
+
+// ClearProviderResponses deletes every cached provider response, so the next
+// requests fetch in full.
+func (r *Repository) ClearProviderResponses() (int64, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM provider_cache`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear provider cache: %w", err)
+	}
+	return result.RowsAffected()
+}
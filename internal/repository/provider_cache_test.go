@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProviderResponse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	fetchedAt := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT etag, last_modified, body, fetched_at FROM provider_cache WHERE url = \$1`).WithArgs("https://example.com/a").
+		WillReturnRows(sqlmock.NewRows([]string{"etag", "last_modified", "body", "fetched_at"}).AddRow(`"v1"`, "", []byte(`{}`), fetchedAt))
+	resp, err := r.GetProviderResponse("https://example.com/a")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.ProviderResponse{URL: "https://example.com/a", ETag: `"v1"`, Body: []byte(`{}`), FetchedAt: fetchedAt}, resp)
+
+	mock.ExpectQuery(`FROM provider_cache`).WithArgs("https://example.com/b").WillReturnError(sql.ErrNoRows)
+	resp, err = r.GetProviderResponse("https://example.com/b")
+	assert.NoError(t, err)
+	assert.Nil(t, resp, "Nothing cached is not an error")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveProviderResponse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectExec(`INSERT INTO provider_cache`).WithArgs("https://example.com/a", `"v1"`, "Thu, 02 Jan 2025 12:00:00 GMT", []byte(`{}`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err = r.SaveProviderResponse(&domain.ProviderResponse{URL: "https://example.com/a", ETag: `"v1"`, LastModified: "Thu, 02 Jan 2025 12:00:00 GMT", Body: []byte(`{}`)})
+	assert.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClearProviderResponses(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM provider_cache`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	n, err := r.CountProviderResponses()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	mock.ExpectExec(`DELETE FROM provider_cache`).WillReturnResult(sqlmock.NewResult(0, 2))
+	cleared, err := r.ClearProviderResponses()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), cleared)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,46 +1,194 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"log"
+	"strings"
+	"time"
 
+	"aviation-weather/internal/aviation"
 	"aviation-weather/internal/domain"
 )
 
 type Repository struct {
 	db *sql.DB
+	// Serves GetAllAirports and GetAirportByFAA when set
+	replica *sql.DB
+	// Bounds each call, 0 leaves them to the statement timeout of the server
+	queryTimeout time.Duration
 }
 
 type RepositoryInterface interface {
 	CreateAirport(airport *domain.Airport) error
 	UpdateAirport(airport *domain.Airport) error
 	DeleteByFAA(faa string) error
-	GetAllAirports() ([]domain.Airport, error)
+	GetAllAirports(sort []domain.SortField) ([]domain.Airport, error)
+	GetStates() ([]string, error)
+	GetCities(stateCode string) ([]string, error)
 	GetAirportByFAA(faaFilter string) (*domain.Airport, error)
+	GetAirportsByState(stateCode string) ([]domain.Airport, error)
+	GetAirportsByPlace(stateCode, city string) ([]domain.Airport, error)
+	GetAirportsByFAAs(faas []string) ([]domain.Airport, error)
+	GetAirportsByFuel(fuel string, sort []domain.SortField) ([]domain.Airport, error)
+	GetAirportsNeedingSync(maxAge time.Duration) ([]domain.Airport, error)
+	ForEachAirport(ctx context.Context, fn func(domain.Airport) error) error
+	ForEachAirportBySyncPriority(ctx context.Context, fn func(domain.Airport) error) error
+	ForEachAirportNeedingSync(ctx context.Context, maxAge time.Duration, fn func(domain.Airport) error) error
+	CountAirports(ctx context.Context) (int, error)
+	UpsertAirports(airports []domain.Airport) error
+	CreateAirports(airports []domain.Airport) (created []string, skipped []string, err error)
+	GetFrequencies(faa string) ([]domain.Frequency, error)
+	ReplaceFrequencies(faa string, frequencies []domain.Frequency) error
+	GetRunways(faa string) ([]domain.Runway, error)
+	ReplaceRunways(faa string, runways []domain.Runway) error
+	GetCharts(faa string) ([]domain.Chart, error)
+	ReplaceCharts(faa, cycle string, charts []domain.Chart) error
+	GetFAAsNeedingCharts(cycle string) ([]string, error)
+	SaveObservation(faa string, obs domain.Observation) error
+	GetObservation(faa string) (*domain.Observation, error)
+	GetObservations(faas []string) (map[string]domain.Observation, error)
+	GetWeatherHistory(faa string, since time.Time) ([]domain.Observation, error)
+	GetWeatherHistories(faas []string, since time.Time) (map[string][]domain.Observation, error)
+	GetAirportsInBox(box aviation.Box) ([]domain.Airport, error)
+	GetNearestAirports(center aviation.Point, radiusNM float64, limit int) ([]domain.NearbyAirport, error)
+	ReplaceNavaids(navaids []domain.Navaid) error
+	GetNavaidsInBox(box aviation.Box) ([]domain.Navaid, error)
+	GetNearestNavaids(center aviation.Point, radiusNM float64, limit int) ([]domain.NearbyNavaid, error)
+	CreateAlertRule(rule *domain.AlertRule) error
+	DeleteAlertRule(tenantID, id int64) error
+	GetAlertRules(tenantID int64) ([]domain.AlertRule, error)
+	GetAlertRulesFor(faa, stateCode string) ([]domain.AlertRule, error)
+	SaveAlerts(alerts []domain.Alert) error
+	GetAlerts(tenantID int64, faa string, since time.Time, limit int) ([]domain.Alert, error)
+	CreateWebhook(wh *domain.Webhook) error
+	DeleteWebhook(tenantID, id int64) error
+	GetWebhooks(tenantID int64) ([]domain.Webhook, error)
+	GetWebhooksForEvent(tenantID int64, event string) ([]domain.Webhook, error)
+	SaveWebhookDelivery(delivery *domain.WebhookDelivery) error
+	GetWebhookDeliveries(tenantID, webhookID int64, limit int) ([]domain.WebhookDelivery, error)
+	CreateWatchlist(wl *domain.Watchlist) error
+	DeleteWatchlist(id int64, owner string) error
+	GetWatchlists(owner string) ([]domain.Watchlist, error)
+	GetWatchlist(id int64, owner string) (*domain.Watchlist, error)
+	CreateSubscription(sub *domain.Subscription) error
+	DeleteSubscription(id int64, owner string) error
+	GetSubscriptions(owner string) ([]domain.Subscription, error)
+	GetAllSubscriptions() ([]domain.Subscription, error)
+	MarkSubscriptionSent(id int64, sentAt time.Time) error
+	CreateTenant(t *domain.Tenant) error
+	GetTenants() ([]domain.Tenant, error)
+	CreateAPIKey(key *domain.APIKey) error
+	DeleteAPIKey(id int64) error
+	GetAPIKeys(tenantID int64) ([]domain.APIKey, error)
+	GetTenantIDByKeyHash(keyHash string) (int64, error)
+	GetSchedulerJobs() ([]domain.SchedulerJob, error)
+	SetSchedulerJobEnabled(name string, enabled bool) error
+	RequestSchedulerJobRun(name string) error
+	ClaimSchedulerJobRuns() ([]string, error)
+	RecordSyncOutcome(synced []string, failed []domain.SyncFailure, backoff time.Duration) error
+	GetDueSyncFailures() ([]domain.SyncFailure, error)
+	SaveConflicts(conflicts []domain.AirportConflict) error
+	GetConflicts(status string) ([]domain.AirportConflict, error)
+	GetConflict(id int64) (*domain.AirportConflict, error)
+	ResolveConflict(id int64, status string) (*domain.AirportConflict, error)
+	ChangeAirportStatus(faa, status, reason string) (*domain.AirportStatusChange, error)
+	GetAirportStatusHistory(faa string) ([]domain.AirportStatusChange, error)
+	CreateAirportNote(note *domain.AirportNote) error
+	GetAirportNotes(faa string) ([]domain.AirportNote, error)
+	DeleteAirportNote(faa string, id int64) error
+	UpdateAirportWithEvents(airport *domain.Airport, events []domain.OutboxEvent) error
+	ModifyAirport(faa string, modify func(a *domain.Airport) ([]domain.OutboxEvent, error)) error
+	MergeAirports(synced []domain.Airport, merge func(stored, synced *domain.Airport) ([]domain.OutboxEvent, error)) ([]domain.Airport, error)
+	ClaimOutboxEvents(limit int, lease time.Duration) ([]domain.OutboxEvent, error)
+	DeleteOutboxEvent(id int64) error
+	RetryOutboxEvent(id int64, lastError string, delay time.Duration) error
+	EnqueueJob(job *domain.QueuedJob) error
+	ClaimQueuedJob(lease time.Duration) (*domain.QueuedJob, error)
+	ExtendQueuedJob(id int64, lease time.Duration) error
+	FinishQueuedJob(id int64, result []byte) error
+	FailQueuedJob(id int64, lastError string, retryAfter time.Duration) error
+	GetQueuedJob(id int64) (*domain.QueuedJob, error)
+	GetQueuedJobs() ([]domain.QueuedJob, error)
+	PruneQueuedJobs(before time.Time) (int64, error)
+	PruneLogs(before time.Time) (int64, error)
+	GetAirportChanges(after domain.ChangeCursor, limit int) ([]domain.ChangeLogEntry, error)
+	CreateWeatherHistoryPartitions(from time.Time, months int) ([]string, error)
+	DropWeatherHistoryPartitions(before time.Time) ([]string, error)
+	Ping(ctx context.Context) error
+	GetLastSyncedAt(ctx context.Context) (*time.Time, error)
+	GetLastUpdatedAt(ctx context.Context) (*time.Time, error)
+	GetAirportStats(ctx context.Context, staleBefore time.Time) (*domain.AirportStats, error)
+	GetDashboard(ctx context.Context) (*domain.Dashboard, error)
+	RefreshDashboard() error
+	ReplaceAdvisories(advisories []domain.Advisory) error
+	GetAdvisories(at time.Time) ([]domain.Advisory, error)
+	GetAdvisoriesAt(p aviation.Point, at time.Time) ([]domain.Advisory, error)
+	ReplaceNOTAMs(faa string, notams []domain.NOTAM) error
+	GetNOTAMs(faa string, at time.Time) ([]domain.NOTAM, error)
+	GetFollowedFAAs() ([]string, error)
+	GetProviderResponse(url string) (*domain.ProviderResponse, error)
+	SaveProviderResponse(resp *domain.ProviderResponse) error
+	CountProviderResponses() (int, error)
+	ClearProviderResponses() (int64, error)
 }
 
-func NewRepository(db *sql.DB) RepositoryInterface {
-	return &Repository{db: db}
+func NewRepository(db *sql.DB, queryTimeout time.Duration) RepositoryInterface {
+	return NewReplicatedRepository(db, nil, queryTimeout)
 }
 
-// Create inserts a new airport record if it does not already exist.
+// NewReplicatedRepository is NewRepository sending the airport list and lookup
+// reads to replica, and everything else to the primary db.
+func NewReplicatedRepository(db, replica *sql.DB, queryTimeout time.Duration) RepositoryInterface {
+	return &Repository{db: db, replica: replica, queryTimeout: queryTimeout}
+}
+
+// readReplica runs read on the replica when there is one, retrying on the
+// primary if it fails so a replica outage only costs the failed attempt.
+func (r *Repository) readReplica(what string, read func(db *sql.DB) error) error {
+	if r.replica != nil {
+		err := read(r.replica)
+		if err == nil {
+			return nil
+		}
+		log.Printf("WARN: Reading %s from the replica failed, using the primary: %v", what, err)
+	}
+	return read(r.db)
+}
+
+// queryContext bounds one repository call by the query timeout, so a hung
+// database fails the call instead of holding a connection forever.
+func (r *Repository) queryContext() (context.Context, context.CancelFunc) {
+	if r.queryTimeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), r.queryTimeout)
+}
+
+// CreateAirport inserts a new airport record, returning domain.ErrDuplicate if it already exists.
 func (r *Repository) CreateAirport(airport *domain.Airport) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
 	query := `
 		INSERT INTO airport (
 			site_number, facility_name, faa, icao, state_code, state_full, county,
 			city, ownership_type, use_type, manager, manager_phone,
-			latitude, longitude, airport_status, weather
+			latitude, longitude, airport_status, weather, elevation_ft, magnetic_variation
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 		ON CONFLICT (faa) DO NOTHING
 	`
 
-	result, err := r.db.Exec(
+	result, err := r.db.ExecContext(ctx,
 		query,
 		airport.SiteNumber, airport.FacilityName, airport.Faa, airport.Icao,
 		airport.StateCode, airport.StateFull, airport.County, airport.City,
 		airport.OwnershipType, airport.UseType, airport.Manager, airport.ManagerPhone,
 		airport.Latitude, airport.Longitude, airport.AirportStatus, airport.Weather,
+		airport.ElevationFt, airport.MagneticVariation,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create airport: %w", err)
@@ -51,29 +199,63 @@ func (r *Repository) CreateAirport(airport *domain.Airport) error {
 		return fmt.Errorf("failed to check rows affected for %s: %w", airport.Faa, err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("no airport found to create for %s", airport.Faa)
+		return fmt.Errorf("%w: %s", domain.ErrDuplicate, airport.Faa)
 	}
 
 	return nil
 }
 
-// UpdateAirport updates an existing airport by FAA code.
+// UpdateAirport updates an existing airport by FAA code, returning domain.ErrNotFound if it doesn't exist.
 func (r *Repository) UpdateAirport(airport *domain.Airport) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	return updateAirport(ctx, r.db, airport)
+}
+
+// execer runs a statement on a *sql.DB or inside a *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func updateAirport(ctx context.Context, db execer, airport *domain.Airport) error {
 	query := `
 		UPDATE airport
 		SET site_number = $2, facility_name = $3, icao = $4, state_code = $5, state_full = $6,
 		    county = $7, city = $8, ownership_type = $9, use_type = $10, manager = $11,
 		    manager_phone = $12, latitude = $13, longitude = $14,
-		    airport_status = $15, weather = $16
+		    airport_status = $15, weather = $16,
+		    last_synced_at = COALESCE($17, last_synced_at),
+		    elevation_ft = COALESCE($18, elevation_ft),
+		    pressure_altitude_ft = COALESCE($19, pressure_altitude_ft),
+		    density_altitude_ft = COALESCE($20, density_altitude_ft),
+		    magnetic_variation = COALESCE($21, magnetic_variation),
+		    timezone = COALESCE(NULLIF($22, ''), timezone),
+		    manual_overrides = COALESCE($23::jsonb, manual_overrides),
+		    fuel_types = COALESCE($24::jsonb, fuel_types),
+		    fbo_name = $25, fbo_phone = $26,
+		    updated_at = NOW()
 		WHERE faa = $1
 	`
+	// Nil lists are left as stored, and empty ones clear them
+	manualOverrides, err := jsonList(airport.ManualOverrides)
+	if err != nil {
+		return fmt.Errorf("failed to encode manual overrides of %s: %w", airport.Faa, err)
+	}
+	fuelTypes, err := jsonList(airport.FuelTypes)
+	if err != nil {
+		return fmt.Errorf("failed to encode fuel types of %s: %w", airport.Faa, err)
+	}
 
-	result, err := r.db.Exec(
+	result, err := db.ExecContext(ctx,
 		query,
 		airport.Faa, airport.SiteNumber, airport.FacilityName, airport.Icao,
 		airport.StateCode, airport.StateFull, airport.County, airport.City,
 		airport.OwnershipType, airport.UseType, airport.Manager, airport.ManagerPhone,
 		airport.Latitude, airport.Longitude, airport.AirportStatus, airport.Weather,
+		airport.LastSyncedAt, airport.ElevationFt, airport.PressureAltitudeFt, airport.DensityAltitudeFt,
+		airport.MagneticVariation, airport.Timezone, manualOverrides,
+		fuelTypes, airport.FboName, airport.FboPhone,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update airport %s: %w", airport.Faa, err)
@@ -84,17 +266,20 @@ func (r *Repository) UpdateAirport(airport *domain.Airport) error {
 		return fmt.Errorf("failed to check rows affected for %s: %w", airport.Faa, err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("no airport found to update for %s", airport.Faa)
+		return fmt.Errorf("%w: %s", domain.ErrNotFound, airport.Faa)
 	}
 
 	return nil
 }
 
-// DeleteByFAA deletes an airport by its FAA identifier.
+// DeleteByFAA deletes an airport by its FAA identifier, returning domain.ErrNotFound if it doesn't exist.
 func (r *Repository) DeleteByFAA(faa string) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
 	query := `DELETE FROM airport WHERE faa = $1`
 
-	result, err := r.db.Exec(query, faa)
+	result, err := r.db.ExecContext(ctx, query, faa)
 	if err != nil {
 		return fmt.Errorf("failed to delete airport %s: %w", faa, err)
 	}
@@ -104,81 +289,94 @@ func (r *Repository) DeleteByFAA(faa string) error {
 		return fmt.Errorf("failed to check rows affected for %s: %w", faa, err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("no airport found for %s", faa)
+		return fmt.Errorf("%w: %s", domain.ErrNotFound, faa)
 	}
 
 	return nil
 }
 
-// GetAllAirports fetches all airports from the DB.
-func (r *Repository) GetAllAirports() ([]domain.Airport, error) {
-	query := `
-		SELECT site_number, facility_name, faa, icao, state_code, state_full, county,
-		       city, ownership_type, use_type, manager, manager_phone,
-		       latitude, longitude, airport_status, weather
-		FROM airport
-		ORDER BY faa
-	`
-
-	rows, err := r.db.Query(query)
+// GetAllAirports fetches all airports from the DB, ordered by sort and then
+// by FAA code.
+func (r *Repository) GetAllAirports(sort []domain.SortField) ([]domain.Airport, error) {
+	orderBy, err := airportOrderBy(sort)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query all airports: %w", err)
+		return nil, err
 	}
-	defer rows.Close()
+	query := `SELECT ` + airportColumns + ` FROM airport ORDER BY ` + orderBy
 
 	var airports []domain.Airport
-	for rows.Next() {
-		var a domain.Airport
-		var siteNumber, facilityName, faa, icao, stateCode, stateFull,
-			county, city, ownershipType, useType, manager, managerPhone,
-			latitude, longitude, airportStatus, weather sql.NullString
-
-		if err := rows.Scan(
-			&siteNumber, &facilityName, &faa, &icao, &stateCode, &stateFull,
-			&county, &city, &ownershipType, &useType, &manager, &managerPhone,
-			&latitude, &longitude, &airportStatus, &weather,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan airport row: %w", err)
-		}
+	err = r.readReplica("all airports", func(db *sql.DB) error {
+		var err error
+		airports, err = r.queryAirportsOn(db, "all airports", query)
+		return err
+	})
+	return airports, err
+}
 
-		a.SiteNumber = siteNumber.String
-		a.FacilityName = facilityName.String
-		a.Faa = faa.String
-		a.Icao = icao.String
-		a.StateCode = stateCode.String
-		a.StateFull = stateFull.String
-		a.County = county.String
-		a.City = city.String
-		a.OwnershipType = ownershipType.String
-		a.UseType = useType.String
-		a.Manager = manager.String
-		a.ManagerPhone = managerPhone.String
-		a.Latitude = latitude.String
-		a.Longitude = longitude.String
-		a.AirportStatus = airportStatus.String
-		a.Weather = weather.String
+// GetAirportsByState fetches the airports of one state, matched case-insensitively.
+func (r *Repository) GetAirportsByState(stateCode string) ([]domain.Airport, error) {
+	query := `SELECT ` + airportColumns + ` FROM airport WHERE UPPER(state_code) = UPPER($1) ORDER BY faa`
+	return r.queryAirports("airports by state", query, stateCode)
+}
 
-		airports = append(airports, a)
-	}
+// GetAirportsByPlace fetches the airports of a state and city, each matched
+// case-insensitively and left out when empty, ordered by FAA code.
+func (r *Repository) GetAirportsByPlace(stateCode, city string) ([]domain.Airport, error) {
+	query := `SELECT ` + airportColumns + ` FROM airport
+		WHERE ($1 = '' OR UPPER(state_code) = UPPER($1)) AND ($2 = '' OR UPPER(city) = UPPER($2))
+		ORDER BY faa`
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration error: %w", err)
+	var airports []domain.Airport
+	err := r.readReplica("airports by place", func(db *sql.DB) error {
+		var err error
+		airports, err = r.queryAirportsOn(db, "airports by place", query, stateCode, city)
+		return err
+	})
+	return airports, err
+}
+
+// GetAirportsByFuel fetches the airports selling fuel, one of the domain
+// Fuel types, ordered like GetAllAirports.
+func (r *Repository) GetAirportsByFuel(fuel string, sort []domain.SortField) ([]domain.Airport, error) {
+	orderBy, err := airportOrderBy(sort)
+	if err != nil {
+		return nil, err
 	}
+	query := `SELECT ` + airportColumns + ` FROM airport WHERE fuel_types @> jsonb_build_array($1::text) ORDER BY ` + orderBy
 
-	return airports, nil
+	var airports []domain.Airport
+	err = r.readReplica("airports by fuel", func(db *sql.DB) error {
+		var err error
+		airports, err = r.queryAirportsOn(db, "airports by fuel", query, fuel)
+		return err
+	})
+	return airports, err
+}
+
+// GetAirportsByFAAs fetches the airports matching any of the given FAA codes.
+func (r *Repository) GetAirportsByFAAs(faas []string) ([]domain.Airport, error) {
+	query := `SELECT ` + airportColumns + ` FROM airport WHERE faa = ANY($1) ORDER BY faa`
+	return r.queryAirports("airports by FAA", query, textArray(faas))
 }
 
 // GetAirportByFAA fetches an airport by FAA code.
 func (r *Repository) GetAirportByFAA(faaFilter string) (*domain.Airport, error) {
-	query := `
-        SELECT site_number, facility_name, faa, icao, state_code, state_full, county,
-               city, ownership_type, use_type, manager, manager_phone,
-               latitude, longitude, airport_status, weather
-        FROM airport
-        WHERE faa = $1
-    `
+	var airport *domain.Airport
+	err := r.readReplica("airport "+faaFilter, func(db *sql.DB) error {
+		var err error
+		airport, err = r.getAirportByFAA(db, faaFilter)
+		return err
+	})
+	return airport, err
+}
+
+func (r *Repository) getAirportByFAA(db *sql.DB, faaFilter string) (*domain.Airport, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
 
-	rows, err := r.db.Query(query, faaFilter)
+	query := `SELECT ` + airportColumns + ` FROM airport WHERE faa = $1`
+
+	rows, err := db.QueryContext(ctx, query, faaFilter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query airport: %w", err)
 	}
@@ -189,17 +387,196 @@ func (r *Repository) GetAirportByFAA(faaFilter string) (*domain.Airport, error)
 		return nil, nil
 	}
 
+	a, err := scanAirport(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return &a, nil
+}
+
+// GetAirportsNeedingSync fetches airports never synced or last synced more than
+// maxAge ago, watched airports first.
+func (r *Repository) GetAirportsNeedingSync(maxAge time.Duration) ([]domain.Airport, error) {
+	return r.queryAirports("stale airports", staleAirportsQuery, time.Now().Add(-maxAge))
+}
+
+// staleAirportsQuery selects airports never synced or last synced before $1.
+const staleAirportsQuery = `SELECT ` + airportColumns + ` FROM airport
+		WHERE last_synced_at IS NULL OR last_synced_at < $1
+		ORDER BY ` + syncPriorityOrder
+
+// syncPriorityOrder puts the airports on any watchlist first, so a sync cut
+// short still refreshed the ones someone is watching.
+const syncPriorityOrder = `faa IN (SELECT UNNEST(faa_codes) FROM watchlists) DESC, faa`
+
+// queryAirports runs a SELECT of airportColumns and scans every row; what names
+// the selection in error messages.
+func (r *Repository) queryAirports(what, query string, args ...any) ([]domain.Airport, error) {
+	return r.queryAirportsOn(r.db, what, query, args...)
+}
+
+func (r *Repository) queryAirportsOn(db *sql.DB, what, query string, args ...any) ([]domain.Airport, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	var airports []domain.Airport
+	err := forEachAirport(ctx, db, what, query, func(a domain.Airport) error {
+		airports = append(airports, a)
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, err
+	}
+	return airports, nil
+}
+
+// ForEachAirport calls fn for every airport in FAA order, scanning rows as it
+// goes rather than loading the table, so memory stays flat however large it
+// grows. The first error from fn stops the iteration and is returned as is.
+func (r *Repository) ForEachAirport(ctx context.Context, fn func(domain.Airport) error) error {
+	query := `SELECT ` + airportColumns + ` FROM airport ORDER BY faa`
+	return r.streamAirports(ctx, "all airports", query, fn)
+}
+
+// ForEachAirportBySyncPriority is ForEachAirport with watched airports first.
+func (r *Repository) ForEachAirportBySyncPriority(ctx context.Context, fn func(domain.Airport) error) error {
+	query := `SELECT ` + airportColumns + ` FROM airport ORDER BY ` + syncPriorityOrder
+	return r.streamAirports(ctx, "all airports", query, fn)
+}
+
+// ForEachAirportNeedingSync is ForEachAirport over the airports
+// GetAirportsNeedingSync would return.
+func (r *Repository) ForEachAirportNeedingSync(ctx context.Context, maxAge time.Duration, fn func(domain.Airport) error) error {
+	return r.streamAirports(ctx, "stale airports", staleAirportsQuery, fn, time.Now().Add(-maxAge))
+}
+
+// CountAirports counts the stored airports.
+func (r *Repository) CountAirports(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM airport`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count airports: %w", err)
+	}
+	return count, nil
+}
+
+// streamAirports runs forEachAirport free of the query and statement timeouts,
+// which would cut the stream short while fn works through the rows; ctx alone
+// bounds it.
+func (r *Repository) streamAirports(ctx context.Context, what, query string, fn func(domain.Airport) error, args ...any) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // Nothing to commit
+
+	if _, err := tx.ExecContext(ctx, `SET LOCAL statement_timeout = 0`); err != nil {
+		return fmt.Errorf("failed to lift statement timeout: %w", err)
+	}
+	return forEachAirport(ctx, tx, what, query, fn, args...)
+}
+
+// querier runs a query on a *sql.DB or inside a *sql.Tx.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func forEachAirport(ctx context.Context, q querier, what, query string, fn func(domain.Airport) error, args ...any) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		a, err := scanAirport(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return nil
+}
+
+// airportColumns is the column list of every airport SELECT, in scanAirport order.
+// airportSortColumns maps domain.SortableAirportFields to their columns.
+var airportSortColumns = map[string]string{
+	"faa_ident":      "faa",
+	"icao_ident":     "icao",
+	"facility_name":  "facility_name",
+	"state":          "state_code",
+	"county":         "county",
+	"city":           "city",
+	"status":         "airport_status",
+	"elevation_ft":   "elevation_ft",
+	"created_at":     "created_at",
+	"updated_at":     "updated_at",
+	"last_synced_at": "last_synced_at",
+}
+
+// airportOrderBy builds the ORDER BY list of sort, ending with the FAA code so
+// ties keep a stable order. Only whitelisted columns reach the query.
+func airportOrderBy(sort []domain.SortField) (string, error) {
+	var terms []string
+	byFAA := false
+	for _, f := range sort {
+		column, ok := airportSortColumns[f.Field]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", domain.ErrInvalidSort, f.Field)
+		}
+		byFAA = byFAA || column == "faa"
+		if f.Desc {
+			// Unset values stay last either way
+			column += " DESC NULLS LAST"
+		}
+		terms = append(terms, column)
+	}
+	if !byFAA {
+		terms = append(terms, "faa")
+	}
+	return strings.Join(terms, ", "), nil
+}
+
+const airportColumns = `
+	site_number, facility_name, faa, icao, state_code, state_full, county,
+	city, ownership_type, use_type, manager, manager_phone,
+	latitude, longitude, airport_status, weather,
+	created_at, updated_at, last_synced_at,
+	elevation_ft, pressure_altitude_ft, density_altitude_ft, magnetic_variation,
+	timezone, manual_overrides, lifecycle_status, fuel_types, fbo_name, fbo_phone
+`
+
+// scanAirport reads one row selected with airportColumns. NULL columns map to zero values.
+func scanAirport(rows *sql.Rows) (domain.Airport, error) {
 	var a domain.Airport
 	var siteNumber, facilityName, faa, icao, stateCode, stateFull,
 		county, city, ownershipType, useType, manager, managerPhone,
-		latitude, longitude, airportStatus, weather sql.NullString
+		airportStatus, weather, timezone, lifecycleStatus, fboName, fboPhone sql.NullString
+	var createdAt, updatedAt, lastSyncedAt sql.NullTime
+	var latitude, longitude, elevationFt, magneticVariation sql.NullFloat64
+	var pressureAltitudeFt, densityAltitudeFt sql.NullInt64
+	var manualOverrides, fuelTypes []byte
 
 	if err := rows.Scan(
 		&siteNumber, &facilityName, &faa, &icao, &stateCode, &stateFull,
 		&county, &city, &ownershipType, &useType, &manager, &managerPhone,
 		&latitude, &longitude, &airportStatus, &weather,
+		&createdAt, &updatedAt, &lastSyncedAt,
+		&elevationFt, &pressureAltitudeFt, &densityAltitudeFt, &magneticVariation,
+		&timezone, &manualOverrides, &lifecycleStatus, &fuelTypes, &fboName, &fboPhone,
 	); err != nil {
-		return nil, fmt.Errorf("failed to scan airport row: %w", err)
+		return a, fmt.Errorf("failed to scan airport row: %w", err)
 	}
 
 	a.SiteNumber = siteNumber.String
@@ -214,14 +591,225 @@ func (r *Repository) GetAirportByFAA(faaFilter string) (*domain.Airport, error)
 	a.UseType = useType.String
 	a.Manager = manager.String
 	a.ManagerPhone = managerPhone.String
-	a.Latitude = latitude.String
-	a.Longitude = longitude.String
+	a.Latitude = nullFloat(latitude)
+	a.Longitude = nullFloat(longitude)
 	a.AirportStatus = airportStatus.String
 	a.Weather = weather.String
+	a.CreatedAt = nullTime(createdAt)
+	a.UpdatedAt = nullTime(updatedAt)
+	a.LastSyncedAt = nullTime(lastSyncedAt)
+	a.ElevationFt = nullFloat(elevationFt)
+	a.MagneticVariation = nullFloat(magneticVariation)
+	a.Timezone = timezone.String
+	a.PressureAltitudeFt = nullInt(pressureAltitudeFt)
+	a.DensityAltitudeFt = nullInt(densityAltitudeFt)
+	a.LifecycleStatus = lifecycleStatus.String
+	a.FboName = fboName.String
+	a.FboPhone = fboPhone.String
+	if len(manualOverrides) > 0 {
+		if err := json.Unmarshal(manualOverrides, &a.ManualOverrides); err != nil {
+			return a, fmt.Errorf("failed to decode manual overrides of %s: %w", a.Faa, err)
+		}
+	}
+	if len(fuelTypes) > 0 {
+		if err := json.Unmarshal(fuelTypes, &a.FuelTypes); err != nil {
+			return a, fmt.Errorf("failed to decode fuel types of %s: %w", a.Faa, err)
+		}
+		if len(a.FuelTypes) == 0 {
+			a.FuelTypes = nil // None known, as when never synced
+		}
+	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration error: %w", err)
+	return a, nil
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
 	}
+	return &t.Time
+}
 
-	return &a, nil
+func nullFloat(f sql.NullFloat64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
+
+func nullInt(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
+
+// jsonList encodes a list for a JSONB column, or returns nil for a nil list.
+func jsonList(list []string) (any, error) {
+	if list == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// UpsertAirports inserts or updates airports by FAA code in a single
+// transaction. As in UpdateAirport, nil sync-derived fields (elevation,
+// altitudes, variation, timezone, last sync, fuel types) keep their stored
+// values, and so do the weather and FBO when empty, so reseeding static data
+// keeps synced weather. Fields listed in manual_overrides keep their
+// stored values too.
+func (r *Repository) UpsertAirports(airports []domain.Airport) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if err := upsertAirports(ctx, tx, airports); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit upsert: %w", err)
+	}
+
+	return nil
+}
+
+// unlessOverridden is the value an upsert sets column to, keeping the stored
+// one when field, its name in domain.OverridableFields, was edited by hand.
+func unlessOverridden(field, column, value string) string {
+	return fmt.Sprintf("CASE WHEN airport.manual_overrides ? '%s' THEN airport.%s ELSE %s END", field, column, value)
+}
+
+func upsertAirports(ctx context.Context, tx *sql.Tx, airports []domain.Airport) error {
+	query := `
+		INSERT INTO airport (
+			site_number, facility_name, faa, icao, state_code, state_full, county,
+			city, ownership_type, use_type, manager, manager_phone,
+			latitude, longitude, airport_status, weather, elevation_ft, magnetic_variation,
+			last_synced_at, pressure_altitude_ft, density_altitude_ft, timezone,
+			fuel_types, fbo_name, fbo_phone
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18,
+		        $19, $20, $21, NULLIF($22, ''), COALESCE($23::jsonb, '[]'::jsonb), $24, $25)
+		ON CONFLICT (faa) DO UPDATE
+		SET site_number = ` + unlessOverridden("site_number", "site_number", "EXCLUDED.site_number") + `,
+		    facility_name = ` + unlessOverridden("facility_name", "facility_name", "EXCLUDED.facility_name") + `,
+		    icao = ` + unlessOverridden("icao_ident", "icao", "EXCLUDED.icao") + `,
+		    state_code = ` + unlessOverridden("state", "state_code", "EXCLUDED.state_code") + `,
+		    state_full = ` + unlessOverridden("state_full", "state_full", "EXCLUDED.state_full") + `,
+		    county = ` + unlessOverridden("county", "county", "EXCLUDED.county") + `,
+		    city = ` + unlessOverridden("city", "city", "EXCLUDED.city") + `,
+		    ownership_type = ` + unlessOverridden("ownership", "ownership_type", "EXCLUDED.ownership_type") + `,
+		    use_type = ` + unlessOverridden("use", "use_type", "EXCLUDED.use_type") + `,
+		    manager = ` + unlessOverridden("manager", "manager", "EXCLUDED.manager") + `,
+		    manager_phone = ` + unlessOverridden("manager_phone", "manager_phone", "EXCLUDED.manager_phone") + `,
+		    latitude = ` + unlessOverridden("latitude", "latitude", "EXCLUDED.latitude") + `,
+		    longitude = ` + unlessOverridden("longitude", "longitude", "EXCLUDED.longitude") + `,
+		    airport_status = ` + unlessOverridden("status", "airport_status", "EXCLUDED.airport_status") + `,
+		    weather = COALESCE(NULLIF(EXCLUDED.weather, ''), airport.weather),
+		    elevation_ft = ` + unlessOverridden("elevation_ft", "elevation_ft", "COALESCE(EXCLUDED.elevation_ft, airport.elevation_ft)") + `,
+		    magnetic_variation = ` + unlessOverridden("magnetic_variation", "magnetic_variation", "COALESCE(EXCLUDED.magnetic_variation, airport.magnetic_variation)") + `,
+		    last_synced_at = COALESCE(EXCLUDED.last_synced_at, airport.last_synced_at),
+		    pressure_altitude_ft = COALESCE(EXCLUDED.pressure_altitude_ft, airport.pressure_altitude_ft),
+		    density_altitude_ft = COALESCE(EXCLUDED.density_altitude_ft, airport.density_altitude_ft),
+		    timezone = COALESCE(EXCLUDED.timezone, airport.timezone),
+		    fuel_types = ` + unlessOverridden("fuel_types", "fuel_types", "COALESCE($23::jsonb, airport.fuel_types)") + `,
+		    fbo_name = ` + unlessOverridden("fbo_name", "fbo_name", "COALESCE(NULLIF(EXCLUDED.fbo_name, ''), airport.fbo_name)") + `,
+		    fbo_phone = ` + unlessOverridden("fbo_phone", "fbo_phone", "COALESCE(NULLIF(EXCLUDED.fbo_phone, ''), airport.fbo_phone)") + `,
+		    updated_at = NOW()
+	`
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare upsert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, airport := range airports {
+		fuelTypes, err := jsonList(airport.FuelTypes)
+		if err != nil {
+			return fmt.Errorf("failed to encode fuel types of %s: %w", airport.Faa, err)
+		}
+		if _, err := stmt.ExecContext(ctx,
+			airport.SiteNumber, airport.FacilityName, airport.Faa, airport.Icao,
+			airport.StateCode, airport.StateFull, airport.County, airport.City,
+			airport.OwnershipType, airport.UseType, airport.Manager, airport.ManagerPhone,
+			airport.Latitude, airport.Longitude, airport.AirportStatus, airport.Weather,
+			airport.ElevationFt, airport.MagneticVariation,
+			airport.LastSyncedAt, airport.PressureAltitudeFt, airport.DensityAltitudeFt, airport.Timezone,
+			fuelTypes, airport.FboName, airport.FboPhone,
+		); err != nil {
+			return fmt.Errorf("failed to upsert airport %s: %w", airport.Faa, err)
+		}
+	}
+	return nil
+}
+
+// CreateAirports inserts airports in a single transaction. Airports whose FAA
+// code already exists are skipped rather than failing the batch.
+func (r *Repository) CreateAirports(airports []domain.Airport) ([]string, []string, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO airport (
+			site_number, facility_name, faa, icao, state_code, state_full, county,
+			city, ownership_type, use_type, manager, manager_phone,
+			latitude, longitude, airport_status, weather, elevation_ft, magnetic_variation
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		ON CONFLICT (faa) DO NOTHING
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	created, skipped := []string{}, []string{}
+	for _, airport := range airports {
+		result, err := stmt.ExecContext(ctx,
+			airport.SiteNumber, airport.FacilityName, airport.Faa, airport.Icao,
+			airport.StateCode, airport.StateFull, airport.County, airport.City,
+			airport.OwnershipType, airport.UseType, airport.Manager, airport.ManagerPhone,
+			airport.Latitude, airport.Longitude, airport.AirportStatus, airport.Weather,
+			airport.ElevationFt, airport.MagneticVariation,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create airport %s: %w", airport.Faa, err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check rows affected for %s: %w", airport.Faa, err)
+		}
+		if rowsAffected == 0 {
+			skipped = append(skipped, airport.Faa)
+		} else {
+			created = append(created, airport.Faa)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit insert: %w", err)
+	}
+
+	return created, skipped, nil
 }
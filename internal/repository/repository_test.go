@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"context"
+	"database/sql/driver"
 	"errors"
 	"testing"
+	"time"
 
 	"aviation-weather/internal/domain"
 
@@ -23,14 +26,18 @@ var sampleAirport = domain.Airport{
 	UseType:       "Public Use",
 	Manager:       "Test Manager",
 	ManagerPhone:  "123-456-7890",
-	Latitude:      "34.0522",
-	Longitude:     "-118.2437",
+	Latitude:      &sampleLatitude,
+	Longitude:     &sampleLongitude,
 	AirportStatus: "Open",
 	Weather:       "Clear",
 }
 
+var sampleLatitude, sampleLongitude = 34.0522, -118.2437
+
 const anErrorMsg = "assert.AnError general error for testing"
 
+var sampleTime = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
 func TestCreateAirport(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -44,9 +51,9 @@ func TestCreateAirport(t *testing.T) {
 				query := `INSERT INTO airport \(
 					site_number, facility_name, faa, icao, state_code, state_full, county,
 					city, ownership_type, use_type, manager, manager_phone,
-					latitude, longitude, airport_status, weather
+					latitude, longitude, airport_status, weather, elevation_ft, magnetic_variation
 				\)
-				VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9, \$10, \$11, \$12, \$13, \$14, \$15, \$16\)
+				VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9, \$10, \$11, \$12, \$13, \$14, \$15, \$16, \$17, \$18\)
 				ON CONFLICT \(faa\) DO NOTHING`
 				mock.ExpectExec(query).
 					WithArgs(
@@ -54,6 +61,7 @@ func TestCreateAirport(t *testing.T) {
 						sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County, sampleAirport.City,
 						sampleAirport.OwnershipType, sampleAirport.UseType, sampleAirport.Manager, sampleAirport.ManagerPhone,
 						sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, sampleAirport.Weather,
+						nil, nil,
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1)) // 1 row affected
 			},
@@ -75,7 +83,7 @@ func TestCreateAirport(t *testing.T) {
 				mock.ExpectExec(query).
 					WillReturnResult(sqlmock.NewResult(1, 0)) // 0 rows affected
 			},
-			expectedErr: "no airport found to create for TST",
+			expectedErr: "airport already exists: TST",
 		},
 	}
 
@@ -86,7 +94,7 @@ func TestCreateAirport(t *testing.T) {
 			assert.NoError(t, err)
 			defer db.Close()
 
-			r := NewRepository(db)
+			r := NewRepository(db, 0)
 			tt.setupDB(mock) // Mock query
 
 			err = r.CreateAirport(&sampleAirport)
@@ -113,7 +121,17 @@ func TestUpdateAirport(t *testing.T) {
 					SET site_number = \$2, facility_name = \$3, icao = \$4, state_code = \$5, state_full = \$6,
 					    county = \$7, city = \$8, ownership_type = \$9, use_type = \$10, manager = \$11,
 					    manager_phone = \$12, latitude = \$13, longitude = \$14,
-					    airport_status = \$15, weather = \$16
+					    airport_status = \$15, weather = \$16,
+					    last_synced_at = COALESCE\(\$17, last_synced_at\),
+					    elevation_ft = COALESCE\(\$18, elevation_ft\),
+					    pressure_altitude_ft = COALESCE\(\$19, pressure_altitude_ft\),
+					    density_altitude_ft = COALESCE\(\$20, density_altitude_ft\),
+					    magnetic_variation = COALESCE\(\$21, magnetic_variation\),
+					    timezone = COALESCE\(NULLIF\(\$22, ''\), timezone\),
+					    manual_overrides = COALESCE\(\$23::jsonb, manual_overrides\),
+					    fuel_types = COALESCE\(\$24::jsonb, fuel_types\),
+					    fbo_name = \$25, fbo_phone = \$26,
+					    updated_at = NOW\(\)
 					WHERE faa = \$1`
 				mock.ExpectExec(query).
 					WithArgs(
@@ -121,6 +139,9 @@ func TestUpdateAirport(t *testing.T) {
 						sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County, sampleAirport.City,
 						sampleAirport.OwnershipType, sampleAirport.UseType, sampleAirport.Manager, sampleAirport.ManagerPhone,
 						sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, sampleAirport.Weather,
+						nil,                         // last_synced_at is kept when not syncing
+						nil, nil, nil, nil, "", nil, // as are elevation, the computed altitudes, variation, zone and overrides
+						nil, "", "", // and fuel types, while the FBO is saved as is
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1)) // 1 row affected
 			},
@@ -142,7 +163,7 @@ func TestUpdateAirport(t *testing.T) {
 				mock.ExpectExec(query).
 					WillReturnResult(sqlmock.NewResult(1, 0)) // 0 rows affected
 			},
-			expectedErr: "no airport found to update for TST",
+			expectedErr: "airport not found: TST",
 		},
 	}
 
@@ -152,7 +173,7 @@ func TestUpdateAirport(t *testing.T) {
 			assert.NoError(t, err)
 			defer db.Close()
 
-			r := NewRepository(db)
+			r := NewRepository(db, 0)
 			tt.setupDB(mock)
 
 			err = r.UpdateAirport(&sampleAirport)
@@ -202,7 +223,7 @@ func TestDeleteByFAA(t *testing.T) {
 				mock.ExpectExec(query).
 					WillReturnResult(sqlmock.NewResult(1, 0)) // 0 rows affected
 			},
-			expectedErr: "no airport found for NF",
+			expectedErr: "airport not found: NF",
 		},
 	}
 
@@ -212,7 +233,7 @@ func TestDeleteByFAA(t *testing.T) {
 			assert.NoError(t, err)
 			defer db.Close()
 
-			r := NewRepository(db)
+			r := NewRepository(db, 0)
 			tt.setupDB(mock)
 
 			err = r.DeleteByFAA(tt.faa)
@@ -233,8 +254,11 @@ func TestGetAllAirports(t *testing.T) {
 		"site_number", "facility_name", "faa", "icao", "state_code", "state_full", "county",
 		"city", "ownership_type", "use_type", "manager", "manager_phone",
 		"latitude", "longitude", "airport_status", "weather",
+		"created_at", "updated_at", "last_synced_at",
+		"elevation_ft", "pressure_altitude_ft", "density_altitude_ft", "magnetic_variation",
+		"timezone", "manual_overrides", "lifecycle_status", "fuel_types", "fbo_name", "fbo_phone",
 	}
-	mismatchCols := fullCols[:15] // Fewer columns to cause scan mismatch (15<16)
+	mismatchCols := fullCols[:18] // Fewer columns to cause scan mismatch (18<29)
 
 	tests := []struct {
 		name        string
@@ -250,12 +274,10 @@ func TestGetAllAirports(t *testing.T) {
 					sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County,
 					sampleAirport.City, sampleAirport.OwnershipType, sampleAirport.UseType, sampleAirport.Manager, sampleAirport.ManagerPhone,
 					sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, sampleAirport.Weather,
+					nil, nil, nil,
+					nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 				)
-				query := `SELECT site_number, facility_name, faa, icao, state_code, state_full, county,
-				       city, ownership_type, use_type, manager, manager_phone,
-				       latitude, longitude, airport_status, weather
-				FROM airport
-				ORDER BY faa`
+				query := `SELECT (.+) FROM airport ORDER BY faa`
 				mock.ExpectQuery(query).
 					WillReturnRows(rows)
 			},
@@ -265,11 +287,7 @@ func TestGetAllAirports(t *testing.T) {
 		{
 			name: "db query error",
 			setupDB: func(mock sqlmock.Sqlmock) {
-				query := `SELECT site_number, facility_name, faa, icao, state_code, state_full, county,
-				       city, ownership_type, use_type, manager, manager_phone,
-				       latitude, longitude, airport_status, weather
-				FROM airport
-				ORDER BY faa`
+				query := `SELECT (.+) FROM airport ORDER BY faa`
 				mock.ExpectQuery(query).
 					WillReturnError(errors.New(anErrorMsg))
 			},
@@ -280,11 +298,7 @@ func TestGetAllAirports(t *testing.T) {
 			name: "no rows",
 			setupDB: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows(fullCols)
-				query := `SELECT site_number, facility_name, faa, icao, state_code, state_full, county,
-				       city, ownership_type, use_type, manager, manager_phone,
-				       latitude, longitude, airport_status, weather
-				FROM airport
-				ORDER BY faa`
+				query := `SELECT (.+) FROM airport ORDER BY faa`
 				mock.ExpectQuery(query).
 					WillReturnRows(rows)
 			},
@@ -299,17 +313,14 @@ func TestGetAllAirports(t *testing.T) {
 					sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County,
 					sampleAirport.City, sampleAirport.OwnershipType, sampleAirport.UseType, sampleAirport.Manager, sampleAirport.ManagerPhone,
 					sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, sampleAirport.Weather,
+					nil, nil, nil,
 				)
-				query := `SELECT site_number, facility_name, faa, icao, state_code, state_full, county,
-				       city, ownership_type, use_type, manager, manager_phone,
-				       latitude, longitude, airport_status, weather
-				FROM airport
-				ORDER BY faa`
+				query := `SELECT (.+) FROM airport ORDER BY faa`
 				mock.ExpectQuery(query).
 					WillReturnRows(rows)
 			},
 			expected:    nil,
-			expectedErr: "failed to scan airport row: sql: expected 15 destination arguments in Scan, not 16",
+			expectedErr: "failed to scan airport row: sql: expected 18 destination arguments in Scan, not 29",
 		},
 	}
 
@@ -319,10 +330,10 @@ func TestGetAllAirports(t *testing.T) {
 			assert.NoError(t, err)
 			defer db.Close()
 
-			r := NewRepository(db)
+			r := NewRepository(db, 0)
 			tt.setupDB(mock)
 
-			airports, err := r.GetAllAirports()
+			airports, err := r.GetAllAirports(nil)
 			assert.Equal(t, tt.expected, airports)
 			if tt.expectedErr == "" {
 				assert.NoError(t, err)
@@ -334,6 +345,27 @@ func TestGetAllAirports(t *testing.T) {
 	}
 }
 
+func TestGetAllAirportsSorted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport ORDER BY facility_name, state_code DESC NULLS LAST, faa$`).
+		WillReturnRows(sqlmock.NewRows([]string{"faa"}))
+	_, err = r.GetAllAirports([]domain.SortField{{Field: "facility_name"}, {Field: "state", Desc: true}})
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport ORDER BY faa DESC NULLS LAST$`).
+		WillReturnRows(sqlmock.NewRows([]string{"faa"}))
+	_, err = r.GetAllAirports([]domain.SortField{{Field: "faa_ident", Desc: true}})
+	assert.NoError(t, err)
+
+	_, err = r.GetAllAirports([]domain.SortField{{Field: "faa; DROP TABLE airport"}})
+	assert.ErrorIs(t, err, domain.ErrInvalidSort, "Fields outside the whitelist never reach the query")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetAirportByFAA(t *testing.T) {
 	const anErrorMsg = "assert.AnError general error for testing"
 
@@ -341,8 +373,11 @@ func TestGetAirportByFAA(t *testing.T) {
 		"site_number", "facility_name", "faa", "icao", "state_code", "state_full", "county",
 		"city", "ownership_type", "use_type", "manager", "manager_phone",
 		"latitude", "longitude", "airport_status", "weather",
+		"created_at", "updated_at", "last_synced_at",
+		"elevation_ft", "pressure_altitude_ft", "density_altitude_ft", "magnetic_variation",
+		"timezone", "manual_overrides", "lifecycle_status", "fuel_types", "fbo_name", "fbo_phone",
 	}
-	mismatchCols := fullCols[:15]
+	mismatchCols := fullCols[:18]
 
 	tests := []struct {
 		name        string
@@ -360,12 +395,10 @@ func TestGetAirportByFAA(t *testing.T) {
 					sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County,
 					sampleAirport.City, sampleAirport.OwnershipType, sampleAirport.UseType, sampleAirport.Manager, sampleAirport.ManagerPhone,
 					sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, sampleAirport.Weather,
+					nil, nil, nil,
+					nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 				)
-				query := `SELECT site_number, facility_name, faa, icao, state_code, state_full, county,
-                       city, ownership_type, use_type, manager, manager_phone,
-                       latitude, longitude, airport_status, weather
-                FROM airport
-                WHERE faa = \$1`
+				query := `SELECT (.+) FROM airport WHERE faa = \$1`
 				mock.ExpectQuery(query).
 					WithArgs("TST").
 					WillReturnRows(rows)
@@ -373,15 +406,41 @@ func TestGetAirportByFAA(t *testing.T) {
 			expected:    &sampleAirport,
 			expectedErr: "",
 		},
+		{
+			name: "with timestamps, altitudes, overrides, lifecycle and fuel",
+			faa:  "TST",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(fullCols).AddRow(
+					sampleAirport.SiteNumber, sampleAirport.FacilityName, sampleAirport.Faa, sampleAirport.Icao,
+					sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County,
+					sampleAirport.City, sampleAirport.OwnershipType, sampleAirport.UseType, sampleAirport.Manager, sampleAirport.ManagerPhone,
+					sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, sampleAirport.Weather,
+					sampleTime, sampleTime, sampleTime,
+					607.0, 1200, 3900, 4.0, "America/Chicago", []byte(`["manager"]`), "seasonal",
+					[]byte(`["100LL","JetA"]`), "Signature Flight Support", "303-342-0100",
+				)
+				query := `SELECT (.+) FROM airport WHERE faa = \$1`
+				mock.ExpectQuery(query).
+					WithArgs("TST").
+					WillReturnRows(rows)
+			},
+			expected: func() *domain.Airport {
+				a := sampleAirport
+				a.CreatedAt, a.UpdatedAt, a.LastSyncedAt = &sampleTime, &sampleTime, &sampleTime
+				elevation, pressureAltitude, densityAltitude, variation := 607.0, 1200, 3900, 4.0
+				a.ElevationFt, a.PressureAltitudeFt, a.DensityAltitudeFt = &elevation, &pressureAltitude, &densityAltitude
+				a.MagneticVariation, a.Timezone = &variation, "America/Chicago"
+				a.ManualOverrides, a.LifecycleStatus = []string{"manager"}, domain.LifecycleSeasonal
+				a.FuelTypes, a.FboName, a.FboPhone = []string{"100LL", "JetA"}, "Signature Flight Support", "303-342-0100"
+				return &a
+			}(),
+			expectedErr: "",
+		},
 		{
 			name: "db query error",
 			faa:  "ERR",
 			setupDB: func(mock sqlmock.Sqlmock) {
-				query := `SELECT site_number, facility_name, faa, icao, state_code, state_full, county,
-                       city, ownership_type, use_type, manager, manager_phone,
-                       latitude, longitude, airport_status, weather
-                FROM airport
-                WHERE faa = \$1`
+				query := `SELECT (.+) FROM airport WHERE faa = \$1`
 				mock.ExpectQuery(query).
 					WithArgs("ERR").
 					WillReturnError(errors.New(anErrorMsg))
@@ -394,11 +453,7 @@ func TestGetAirportByFAA(t *testing.T) {
 			faa:  "NF",
 			setupDB: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows(fullCols)
-				query := `SELECT site_number, facility_name, faa, icao, state_code, state_full, county,
-                       city, ownership_type, use_type, manager, manager_phone,
-                       latitude, longitude, airport_status, weather
-                FROM airport
-                WHERE faa = \$1`
+				query := `SELECT (.+) FROM airport WHERE faa = \$1`
 				mock.ExpectQuery(query).
 					WithArgs("NF").
 					WillReturnRows(rows)
@@ -414,19 +469,16 @@ func TestGetAirportByFAA(t *testing.T) {
 					sampleAirport.SiteNumber, sampleAirport.FacilityName, sampleAirport.Faa, sampleAirport.Icao,
 					sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County,
 					sampleAirport.City, sampleAirport.OwnershipType, sampleAirport.UseType, sampleAirport.Manager, sampleAirport.ManagerPhone,
-					sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus,
+					sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, sampleAirport.Weather,
+					nil, nil,
 				)
-				query := `SELECT site_number, facility_name, faa, icao, state_code, state_full, county,
-                       city, ownership_type, use_type, manager, manager_phone,
-                       latitude, longitude, airport_status, weather
-                FROM airport
-                WHERE faa = \$1`
+				query := `SELECT (.+) FROM airport WHERE faa = \$1`
 				mock.ExpectQuery(query).
 					WithArgs("SCAN").
 					WillReturnRows(rows)
 			},
 			expected:    nil,
-			expectedErr: "failed to scan airport row: sql: expected 15 destination arguments in Scan, not 16",
+			expectedErr: "failed to scan airport row: sql: expected 18 destination arguments in Scan, not 29",
 		},
 	}
 
@@ -436,7 +488,7 @@ func TestGetAirportByFAA(t *testing.T) {
 			assert.NoError(t, err)
 			defer db.Close()
 
-			r := NewRepository(db)
+			r := NewRepository(db, 0)
 			tt.setupDB(mock)
 
 			airport, err := r.GetAirportByFAA(tt.faa)
@@ -450,3 +502,404 @@ func TestGetAirportByFAA(t *testing.T) {
 		})
 	}
 }
+
+func TestUpsertAirports(t *testing.T) {
+	other := sampleAirport
+	other.Faa = "OTH"
+
+	tests := []struct {
+		name        string
+		setupDB     func(sqlmock.Sqlmock)
+		expectedErr string
+	}{
+		{
+			name: "success",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				prep := mock.ExpectPrepare(`INSERT INTO airport .* ON CONFLICT \(faa\) DO UPDATE .*manager = CASE WHEN airport.manual_overrides \? 'manager' THEN airport.manager ELSE EXCLUDED.manager END`)
+				prep.ExpectExec().
+					WithArgs(
+						sampleAirport.SiteNumber, sampleAirport.FacilityName, sampleAirport.Faa, sampleAirport.Icao,
+						sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County, sampleAirport.City,
+						sampleAirport.OwnershipType, sampleAirport.UseType, sampleAirport.Manager, sampleAirport.ManagerPhone,
+						sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, sampleAirport.Weather,
+						nil, nil, nil, nil, nil, "",
+						nil, "", "",
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				prep.ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expectedErr: "",
+		},
+		{
+			name: "exec error rolls back",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				prep := mock.ExpectPrepare(`INSERT INTO airport`)
+				prep.ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+				prep.ExpectExec().WillReturnError(errors.New(anErrorMsg))
+				mock.ExpectRollback()
+			},
+			expectedErr: "failed to upsert airport OTH: " + anErrorMsg,
+		},
+		{
+			name: "begin error",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(errors.New(anErrorMsg))
+			},
+			expectedErr: "failed to begin transaction: " + anErrorMsg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			r := NewRepository(db, 0)
+			tt.setupDB(mock)
+
+			err = r.UpsertAirports([]domain.Airport{sampleAirport, other})
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreateAirports(t *testing.T) {
+	other := sampleAirport
+	other.Faa = "OTH"
+
+	tests := []struct {
+		name            string
+		setupDB         func(sqlmock.Sqlmock)
+		expectedCreated []string
+		expectedSkipped []string
+		expectedErr     string
+	}{
+		{
+			name: "created and skipped",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				prep := mock.ExpectPrepare(`INSERT INTO airport .* ON CONFLICT \(faa\) DO NOTHING`)
+				prep.ExpectExec().WillReturnResult(sqlmock.NewResult(1, 1))
+				prep.ExpectExec().WillReturnResult(sqlmock.NewResult(1, 0)) // Already exists
+				mock.ExpectCommit()
+			},
+			expectedCreated: []string{"TST"},
+			expectedSkipped: []string{"OTH"},
+			expectedErr:     "",
+		},
+		{
+			name: "exec error rolls back",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				prep := mock.ExpectPrepare(`INSERT INTO airport`)
+				prep.ExpectExec().WillReturnError(errors.New(anErrorMsg))
+				mock.ExpectRollback()
+			},
+			expectedErr: "failed to create airport TST: " + anErrorMsg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			r := NewRepository(db, 0)
+			tt.setupDB(mock)
+
+			created, skipped, err := r.CreateAirports([]domain.Airport{sampleAirport, other})
+			assert.Equal(t, tt.expectedCreated, created)
+			assert.Equal(t, tt.expectedSkipped, skipped)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetAirportsNeedingSync(t *testing.T) {
+	fullCols := []string{
+		"site_number", "facility_name", "faa", "icao", "state_code", "state_full", "county",
+		"city", "ownership_type", "use_type", "manager", "manager_phone",
+		"latitude", "longitude", "airport_status", "weather",
+		"created_at", "updated_at", "last_synced_at",
+		"elevation_ft", "pressure_altitude_ft", "density_altitude_ft", "magnetic_variation",
+		"timezone", "manual_overrides", "lifecycle_status", "fuel_types", "fbo_name", "fbo_phone",
+	}
+
+	tests := []struct {
+		name        string
+		setupDB     func(sqlmock.Sqlmock)
+		expected    []domain.Airport
+		expectedErr string
+	}{
+		{
+			name: "success",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(fullCols).AddRow(
+					sampleAirport.SiteNumber, sampleAirport.FacilityName, sampleAirport.Faa, sampleAirport.Icao,
+					sampleAirport.StateCode, sampleAirport.StateFull, sampleAirport.County,
+					sampleAirport.City, sampleAirport.OwnershipType, sampleAirport.UseType, sampleAirport.Manager, sampleAirport.ManagerPhone,
+					sampleAirport.Latitude, sampleAirport.Longitude, sampleAirport.AirportStatus, sampleAirport.Weather,
+					nil, nil, nil,
+					nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+				)
+				query := `SELECT (.+) FROM airport\s+WHERE last_synced_at IS NULL OR last_synced_at < \$1\s+ORDER BY faa IN \(SELECT UNNEST\(faa_codes\) FROM watchlists\) DESC, faa`
+				mock.ExpectQuery(query).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			expected:    []domain.Airport{sampleAirport},
+			expectedErr: "",
+		},
+		{
+			name: "db query error",
+			setupDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT (.+) FROM airport`).
+					WillReturnError(errors.New(anErrorMsg))
+			},
+			expected:    nil,
+			expectedErr: "failed to query stale airports: " + anErrorMsg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			r := NewRepository(db, 0)
+			tt.setupDB(mock)
+
+			airports, err := r.GetAirportsNeedingSync(6 * time.Hour)
+			assert.Equal(t, tt.expected, airports)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetAirportsByStateAndFAAs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport WHERE UPPER\(state_code\) = UPPER\(\$1\) ORDER BY faa`).
+		WithArgs("tx").
+		WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetAirportsByState("tx")
+	assert.EqualError(t, err, "failed to query airports by state: "+anErrorMsg)
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport WHERE faa = ANY\(\$1\) ORDER BY faa`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetAirportsByFAAs([]string{"DFW", "AUS"})
+	assert.EqualError(t, err, "failed to query airports by FAA: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAirportsByPlace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport\s+WHERE \(\$1 = '' OR UPPER\(state_code\) = UPPER\(\$1\)\) AND \(\$2 = '' OR UPPER\(city\) = UPPER\(\$2\)\)\s+ORDER BY faa`).
+		WithArgs("co", "").
+		WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetAirportsByPlace("co", "")
+	assert.EqualError(t, err, "failed to query airports by place: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAirportsByFuel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport WHERE fuel_types @> jsonb_build_array\(\$1::text\) ORDER BY state_code, faa`).
+		WithArgs("JetA").
+		WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetAirportsByFuel(domain.FuelJetA, []domain.SortField{{Field: "state"}})
+	assert.EqualError(t, err, "failed to query airports by fuel: "+anErrorMsg)
+
+	_, err = r.GetAirportsByFuel(domain.FuelJetA, []domain.SortField{{Field: "weather"}})
+	assert.ErrorIs(t, err, domain.ErrInvalidSort)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestForEachAirport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	cols := []string{
+		"site_number", "facility_name", "faa", "icao", "state_code", "state_full", "county",
+		"city", "ownership_type", "use_type", "manager", "manager_phone",
+		"latitude", "longitude", "airport_status", "weather",
+		"created_at", "updated_at", "last_synced_at",
+		"elevation_ft", "pressure_altitude_ft", "density_altitude_ft", "magnetic_variation",
+		"timezone", "manual_overrides", "lifecycle_status", "fuel_types", "fbo_name", "fbo_phone",
+	}
+	row := func(faa string) []driver.Value {
+		return []driver.Value{
+			"", "", faa, "", "", "", "", "", "", "", "", "", nil, nil, "", "",
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		}
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SET LOCAL statement_timeout = 0`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT (.+) FROM airport ORDER BY faa`).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(row("AAA")...).AddRow(row("BBB")...).AddRow(row("CCC")...))
+	mock.ExpectRollback()
+	var seen []string
+	stop := errors.New("stop")
+	err = r.ForEachAirport(context.Background(), func(a domain.Airport) error {
+		seen = append(seen, a.Faa)
+		if a.Faa == "BBB" {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop, "fn's error stops the iteration")
+	assert.Equal(t, []string{"AAA", "BBB"}, seen)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SET LOCAL statement_timeout = 0`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT (.+) FROM airport ORDER BY faa IN \(SELECT UNNEST\(faa_codes\) FROM watchlists\) DESC, faa$`).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(row("WCH")...).AddRow(row("AAA")...))
+	mock.ExpectRollback()
+	seen = nil
+	err = r.ForEachAirportBySyncPriority(context.Background(), func(a domain.Airport) error {
+		seen = append(seen, a.Faa)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"WCH", "AAA"}, seen)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SET LOCAL statement_timeout = 0`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT (.+) FROM airport\s+WHERE last_synced_at IS NULL OR last_synced_at < \$1`).
+		WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+	err = r.ForEachAirportNeedingSync(context.Background(), time.Hour, func(domain.Airport) error { return nil })
+	assert.EqualError(t, err, "failed to query stale airports: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountAirports(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM airport`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+	count, err := r.CountAirports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 42, count)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM airport`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.CountAirports(context.Background())
+	assert.EqualError(t, err, "failed to count airports: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueryTimeout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 10*time.Millisecond)
+
+	mock.ExpectExec(`DELETE FROM airport WHERE faa = \$1`).
+		WithArgs("TST").
+		WillDelayFor(time.Second).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err = r.DeleteByFAA("TST")
+	assert.EqualError(t, err, "failed to delete airport TST: canceling query due to user request")
+}
+
+func TestReplicaReads(t *testing.T) {
+	primary, primaryMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer primary.Close()
+	replica, replicaMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer replica.Close()
+
+	r := NewReplicatedRepository(primary, replica, 0)
+
+	cols := []string{
+		"site_number", "facility_name", "faa", "icao", "state_code", "state_full", "county",
+		"city", "ownership_type", "use_type", "manager", "manager_phone",
+		"latitude", "longitude", "airport_status", "weather",
+		"created_at", "updated_at", "last_synced_at",
+		"elevation_ft", "pressure_altitude_ft", "density_altitude_ft", "magnetic_variation",
+		"timezone", "manual_overrides", "lifecycle_status", "fuel_types", "fbo_name", "fbo_phone",
+	}
+	row := func(faa string) []driver.Value {
+		return []driver.Value{
+			"", "", faa, "", "", "", "", "", "", "", "", "", nil, nil, "", "",
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		}
+	}
+
+	// The replica answers reads
+	replicaMock.ExpectQuery(`SELECT (.+) FROM airport ORDER BY faa`).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(row("AAA")...).AddRow(row("BBB")...))
+	airports, err := r.GetAllAirports(nil)
+	assert.NoError(t, err)
+	assert.Len(t, airports, 2)
+
+	// A failing replica falls back to the primary
+	replicaMock.ExpectQuery(`SELECT (.+) FROM airport WHERE faa = \$1`).
+		WithArgs("AAA").
+		WillReturnError(errors.New("connection refused"))
+	primaryMock.ExpectQuery(`SELECT (.+) FROM airport WHERE faa = \$1`).
+		WithArgs("AAA").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(row("AAA")...))
+	airport, err := r.GetAirportByFAA("AAA")
+	assert.NoError(t, err)
+	assert.Equal(t, "AAA", airport.Faa)
+
+	// Writes always go to the primary
+	primaryMock.ExpectExec(`DELETE FROM airport WHERE faa = \$1`).
+		WithArgs("AAA").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.DeleteByFAA("AAA"))
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMock.ExpectationsWereMet())
+}
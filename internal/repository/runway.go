@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"fmt"
+
+	"aviation-weather/internal/domain"
+)
+
+// GetRunways fetches the stored runway ends of one airport.
+func (r *Repository) GetRunways(faa string) ([]domain.Runway, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		SELECT ident, heading_deg, COALESCE(length_ft, 0), COALESCE(width_ft, 0), COALESCE(surface, ''), cycle
+		FROM airport_runway
+		WHERE faa = $1
+		ORDER BY ident
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query runways for %s: %w", faa, err)
+	}
+	defer rows.Close()
+
+	runways := []domain.Runway{}
+	for rows.Next() {
+		var rwy domain.Runway
+		if err := rows.Scan(&rwy.Ident, &rwy.HeadingDeg, &rwy.LengthFt, &rwy.WidthFt, &rwy.Surface, &rwy.Cycle); err != nil {
+			return nil, fmt.Errorf("failed to scan runway row: %w", err)
+		}
+		runways = append(runways, rwy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return runways, nil
+}
+
+// ReplaceRunways swaps the stored runway ends of one airport for the given list
+// in a single transaction.
+func (r *Repository) ReplaceRunways(faa string, runways []domain.Runway) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM airport_runway WHERE faa = $1`, faa); err != nil {
+		return fmt.Errorf("failed to clear runways for %s: %w", faa, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO airport_runway (faa, ident, heading_deg, length_ft, width_ft, surface, cycle)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare runway insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, rwy := range runways {
+		if _, err := stmt.ExecContext(ctx, faa, rwy.Ident, rwy.HeadingDeg, rwy.LengthFt, rwy.WidthFt, rwy.Surface, rwy.Cycle); err != nil {
+			return fmt.Errorf("failed to insert runway %s for %s: %w", rwy.Ident, faa, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit runways: %w", err)
+	}
+
+	return nil
+}
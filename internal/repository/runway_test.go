@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleRunways = []domain.Runway{
+	{Ident: "17R", HeadingDeg: 175.5, LengthFt: 13401, WidthFt: 200, Surface: "CON"},
+	{Ident: "35L", HeadingDeg: 355.5, LengthFt: 13401, WidthFt: 200, Surface: "CON"},
+}
+
+func TestGetRunways(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	rows := sqlmock.NewRows([]string{"ident", "heading_deg", "length_ft", "width_ft", "surface", "cycle"}).
+		AddRow("17R", 175.5, 13401, 200, "CON", "").
+		AddRow("35L", 355.5, 13401, 200, "CON", "")
+	mock.ExpectQuery(`SELECT ident, heading_deg, (.+) FROM airport_runway WHERE faa = \$1`).
+		WithArgs("TST").
+		WillReturnRows(rows)
+	runways, err := r.GetRunways("TST")
+	assert.NoError(t, err)
+	assert.Equal(t, sampleRunways, runways)
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport_runway`).
+		WithArgs("ERR").
+		WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetRunways("ERR")
+	assert.EqualError(t, err, "failed to query runways for ERR: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReplaceRunways(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM airport_runway WHERE faa = \$1`).
+		WithArgs("TST").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	prep := mock.ExpectPrepare(`INSERT INTO airport_runway`)
+	prep.ExpectExec().
+		WithArgs("TST", "17R", 175.5, 13401, 200, "CON", "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	prep.ExpectExec().
+		WithArgs("TST", "35L", 355.5, 13401, 200, "CON", "").
+		WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+
+	err = r.ReplaceRunways("TST", sampleRunways)
+	assert.EqualError(t, err, "failed to insert runway 35L for TST: "+anErrorMsg)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
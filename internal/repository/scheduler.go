@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// GetSchedulerJobs fetches the job definitions stored in scheduler_jobs, by
+// name.
+func (r *Repository) GetSchedulerJobs() ([]domain.SchedulerJob, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT name, schedule, enabled, run_requested_at, updated_at FROM scheduler_jobs ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduler jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []domain.SchedulerJob{}
+	for rows.Next() {
+		var job domain.SchedulerJob
+		var runRequestedAt sql.NullTime
+		var updatedAt time.Time
+		if err := rows.Scan(&job.Name, &job.Schedule, &job.Enabled, &runRequestedAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduler job row: %w", err)
+		}
+		if runRequestedAt.Valid {
+			job.RunRequestedAt = &runRequestedAt.Time
+		}
+		job.UpdatedAt = &updatedAt
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// SetSchedulerJobEnabled pauses or resumes a job, adding its row if missing.
+func (r *Repository) SetSchedulerJobEnabled(name string, enabled bool) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO scheduler_jobs (name, enabled)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = NOW()
+	`
+	if _, err := r.db.ExecContext(ctx, query, name, enabled); err != nil {
+		return fmt.Errorf("failed to set scheduler job %s enabled: %w", name, err)
+	}
+	return nil
+}
+
+// RequestSchedulerJobRun asks the scheduler to run a job at its next refresh,
+// adding its row if missing.
+func (r *Repository) RequestSchedulerJobRun(name string) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO scheduler_jobs (name, run_requested_at)
+		VALUES ($1, NOW())
+		ON CONFLICT (name) DO UPDATE SET run_requested_at = NOW()
+	`
+	if _, err := r.db.ExecContext(ctx, query, name); err != nil {
+		return fmt.Errorf("failed to request run of scheduler job %s: %w", name, err)
+	}
+	return nil
+}
+
+// ClaimSchedulerJobRuns clears the pending run requests and returns the names
+// of their jobs, so each request is run once.
+func (r *Repository) ClaimSchedulerJobRuns() ([]string, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `UPDATE scheduler_jobs SET run_requested_at = NULL WHERE run_requested_at IS NOT NULL RETURNING name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim scheduler job runs: %w", err)
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduler job name: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return names, nil
+}
+
+// PruneLogs deletes the alerts, webhook deliveries and change feed entries
+// created before the given time in a single transaction and returns how many
+// rows went.
+func (r *Repository) PruneLogs(before time.Time) (int64, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	var pruned int64
+	for _, table := range []string{"alerts", "webhook_deliveries", "airport_change_log"} {
+		result, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE created_at < $1`, before)
+		if err != nil {
+			return 0, fmt.Errorf("failed to prune %s: %w", table, err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to check rows affected for %s: %w", table, err)
+		}
+		pruned += rowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit pruning: %w", err)
+	}
+
+	return pruned, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSchedulerJobs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`SELECT name, schedule, enabled, run_requested_at, updated_at FROM scheduler_jobs ORDER BY name`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "schedule", "enabled", "run_requested_at", "updated_at"}).
+			AddRow("prune_logs", "0 3 * * *", true, sampleTime, sampleTime).
+			AddRow("sync_all", "", false, nil, sampleTime))
+	jobs, err := r.GetSchedulerJobs()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.SchedulerJob{
+		{Name: domain.JobPruneLogs, Schedule: "0 3 * * *", Enabled: true, RunRequestedAt: &sampleTime, UpdatedAt: &sampleTime},
+		{Name: domain.JobSyncAll, UpdatedAt: &sampleTime},
+	}, jobs)
+
+	mock.ExpectQuery(`SELECT (.+) FROM scheduler_jobs`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetSchedulerJobs()
+	assert.EqualError(t, err, "failed to query scheduler jobs: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPruneLogs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM alerts WHERE created_at < \$1`).WithArgs(sampleTime).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(`DELETE FROM webhook_deliveries WHERE created_at < \$1`).WithArgs(sampleTime).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(`DELETE FROM airport_change_log WHERE created_at < \$1`).WithArgs(sampleTime).WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectCommit()
+	pruned, err := r.PruneLogs(sampleTime)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), pruned)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM alerts`).WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+	_, err = r.PruneLogs(sampleTime)
+	assert.EqualError(t, err, "failed to prune alerts: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSchedulerJobControl(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectExec(`INSERT INTO scheduler_jobs \(name, enabled\)`).WithArgs("sync_all", false).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.SetSchedulerJobEnabled("sync_all", false))
+
+	mock.ExpectExec(`INSERT INTO scheduler_jobs \(name, run_requested_at\)`).WithArgs("sync_all").WillReturnError(errors.New(anErrorMsg))
+	assert.EqualError(t, r.RequestSchedulerJobRun("sync_all"), "failed to request run of scheduler job sync_all: "+anErrorMsg)
+
+	mock.ExpectQuery(`UPDATE scheduler_jobs SET run_requested_at = NULL WHERE run_requested_at IS NOT NULL RETURNING name`).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("sync_all").AddRow("prune_logs"))
+	names, err := r.ClaimSchedulerJobRuns()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sync_all", "prune_logs"}, names)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
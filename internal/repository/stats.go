@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// weatherGroupsQuery counts airports by the visibility and ceiling of their
+// latest observation. METARs list sky layers from the lowest up, so the first
+// broken, overcast or obscured layer is the ceiling.
+const weatherGroupsQuery = `SELECT w.visibility_sm,
+		SUBSTRING(w.raw_metar FROM '(?:^|\s)(?:BKN|OVC|VV)(\d{3})'),
+		COALESCE(w.raw_metar, '') <> '',
+		COUNT(*)
+	FROM airport a LEFT JOIN airport_weather w ON w.faa = a.faa
+	GROUP BY 1, 2, 3`
+
+// GetAirportStats counts the airports in total, by state, by ownership and
+// by the flight category of their latest observation, and those last synced
+// before staleBefore or never.
+func (r *Repository) GetAirportStats(ctx context.Context, staleBefore time.Time) (*domain.AirportStats, error) {
+	var stats *domain.AirportStats
+	err := r.readReplica("airport stats", func(db *sql.DB) error {
+		var err error
+		stats, err = r.getAirportStats(ctx, db, staleBefore)
+		return err
+	})
+	return stats, err
+}
+
+func (r *Repository) getAirportStats(ctx context.Context, db *sql.DB, staleBefore time.Time) (*domain.AirportStats, error) {
+	stats := &domain.AirportStats{ByFlightCategory: map[string]int{}}
+
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE last_synced_at IS NULL OR last_synced_at < $1)
+		FROM airport`, staleBefore).Scan(&stats.TotalAirports, &stats.StaleWeather)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count airports: %w", err)
+	}
+
+	if stats.ByState, err = countBy(ctx, db, "state", "state_code"); err != nil {
+		return nil, err
+	}
+	if stats.ByOwnership, err = countBy(ctx, db, "ownership", "ownership_type"); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, weatherGroupsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count airports by flight category: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			visibility sql.NullFloat64
+			ceiling    sql.NullString
+			hasMETAR   bool
+			count      int
+		)
+		if err := rows.Scan(&visibility, &ceiling, &hasMETAR, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan flight category count: %w", err)
+		}
+		stats.ByFlightCategory[weatherCategory(visibility.Float64, ceiling.String, hasMETAR)] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count airports by flight category: %w", err)
+	}
+	return stats, nil
+}
+
+// countBy counts airports by the value of column, what naming it in errors.
+func countBy(ctx context.Context, db *sql.DB, what, column string) (map[string]int, error) {
+	rows, err := db.QueryContext(ctx, `SELECT COALESCE(`+column+`, ''), COUNT(*) FROM airport GROUP BY 1`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count airports by %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var value string
+		var count int
+		if err := rows.Scan(&value, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan %s count: %w", what, err)
+		}
+		counts[value] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count airports by %s: %w", what, err)
+	}
+	return counts, nil
+}
+
+// weatherCategory classifies a group of weatherGroupsQuery the way
+// aviation.ObservationCategory classifies one observation.
+func weatherCategory(visibilitySM float64, ceiling string, hasMETAR bool) string {
+	if visibilitySM <= 0 && !hasMETAR {
+		return domain.CategoryUnknown
+	}
+	hundreds, err := strconv.Atoi(ceiling)
+	return aviation.FlightCategory(hundreds*100, err == nil, visibilitySM)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAirportStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+	staleBefore := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\),\s+COUNT\(\*\) FILTER \(WHERE last_synced_at IS NULL OR last_synced_at < \$1\)\s+FROM airport`).
+		WithArgs(staleBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "stale"}).AddRow(5, 2))
+	mock.ExpectQuery(`SELECT COALESCE\(state_code, ''\), COUNT\(\*\) FROM airport GROUP BY 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"state_code", "count"}).AddRow("TX", 3).AddRow("CA", 2))
+	mock.ExpectQuery(`SELECT COALESCE\(ownership_type, ''\), COUNT\(\*\) FROM airport GROUP BY 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"ownership_type", "count"}).AddRow("PU", 4).AddRow("", 1))
+	mock.ExpectQuery(`FROM airport a LEFT JOIN airport_weather w ON w.faa = a.faa\s+GROUP BY 1, 2, 3`).
+		WillReturnRows(sqlmock.NewRows([]string{"visibility_sm", "ceiling", "has_metar", "count"}).
+			AddRow(10.0, nil, true, 1).
+			AddRow(10.0, "008", true, 1).
+			AddRow(2.0, nil, false, 1).
+			AddRow(nil, nil, false, 2))
+
+	stats, err := r.GetAirportStats(context.Background(), staleBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.AirportStats{
+		TotalAirports:    5,
+		ByState:          map[string]int{"TX": 3, "CA": 2},
+		ByOwnership:      map[string]int{"PU": 4, "": 1},
+		ByFlightCategory: map[string]int{"VFR": 1, "IFR": 2, domain.CategoryUnknown: 2},
+		StaleWeather:     2,
+	}, stats)
+
+	mock.ExpectQuery(`SELECT COUNT`).WillReturnError(errors.New("connection refused"))
+	_, err = r.GetAirportStats(context.Background(), staleBefore)
+	assert.EqualError(t, err, "failed to count airports: connection refused")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
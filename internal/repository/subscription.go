@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// CreateSubscription inserts a digest subscription and fills in its id and
+// creation time.
+func (r *Repository) CreateSubscription(sub *domain.Subscription) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO subscriptions (owner, faa_codes, channel, target, secret, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	var createdAt time.Time
+	if err := r.db.QueryRowContext(ctx,
+		query,
+		sub.Owner, textArray(sub.Faas), sub.Channel, sub.Target, sub.Secret, sub.TenantID,
+	).Scan(&sub.ID, &createdAt); err != nil {
+		return fmt.Errorf("failed to create subscription: %w", err)
+	}
+	sub.CreatedAt = &createdAt
+
+	return nil
+}
+
+// DeleteSubscription removes a subscription of owner, returning
+// domain.ErrSubscriptionNotFound if owner has none with that id.
+func (r *Repository) DeleteSubscription(id int64, owner string) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM subscriptions WHERE id = $1 AND owner = $2`, id, owner)
+	if err != nil {
+		return fmt.Errorf("failed to delete subscription %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected for subscription %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %d", domain.ErrSubscriptionNotFound, id)
+	}
+
+	return nil
+}
+
+// GetSubscriptions fetches the subscriptions of owner, oldest first.
+func (r *Repository) GetSubscriptions(owner string) ([]domain.Subscription, error) {
+	return r.querySubscriptions(`SELECT `+subscriptionColumns+` FROM subscriptions WHERE owner = $1 ORDER BY id`, owner)
+}
+
+// GetAllSubscriptions fetches every subscription, for sending the digests.
+func (r *Repository) GetAllSubscriptions() ([]domain.Subscription, error) {
+	return r.querySubscriptions(`SELECT ` + subscriptionColumns + ` FROM subscriptions ORDER BY id`)
+}
+
+// MarkSubscriptionSent records when the last digest of a subscription went out.
+func (r *Repository) MarkSubscriptionSent(id int64, sentAt time.Time) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctx, `UPDATE subscriptions SET last_sent_at = $2 WHERE id = $1`, id, sentAt); err != nil {
+		return fmt.Errorf("failed to mark subscription %d sent: %w", id, err)
+	}
+	return nil
+}
+
+const subscriptionColumns = `id, owner, faa_codes, channel, target, secret, tenant_id, last_sent_at, created_at`
+
+func (r *Repository) querySubscriptions(query string, args ...any) ([]domain.Subscription, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []domain.Subscription{}
+	for rows.Next() {
+		var sub domain.Subscription
+		var lastSentAt sql.NullTime
+		var createdAt time.Time
+		if err := rows.Scan(
+			&sub.ID, &sub.Owner, scanTextArray(&sub.Faas), &sub.Channel, &sub.Target, &sub.Secret, &sub.TenantID, &lastSentAt, &createdAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan subscription row: %w", err)
+		}
+		if lastSentAt.Valid {
+			sub.LastSentAt = &lastSentAt.Time
+		}
+		sub.CreatedAt = &createdAt
+		subscriptions = append(subscriptions, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return subscriptions, nil
+}
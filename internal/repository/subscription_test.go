@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	sub := domain.Subscription{Faas: []string{"DEN"}, Channel: domain.ChannelWebhook, Target: "https://example.com/digest", Owner: "owner", TenantID: 2}
+	mock.ExpectQuery(`INSERT INTO subscriptions .* RETURNING id, created_at`).
+		WithArgs("owner", sqlmock.AnyArg(), domain.ChannelWebhook, sub.Target, "", int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, sampleTime))
+	assert.NoError(t, r.CreateSubscription(&sub))
+	assert.Equal(t, int64(7), sub.ID)
+
+	columns := []string{"id", "owner", "faa_codes", "channel", "target", "secret", "tenant_id", "last_sent_at", "created_at"}
+	mock.ExpectQuery(`SELECT (.+) FROM subscriptions ORDER BY id`).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(7, "owner", "{DEN,APA}", "webhook", sub.Target, "", 2, nil, sampleTime).
+			AddRow(8, "other", "{SEA}", "email", "pilot@example.com", "", 1, sampleTime, sampleTime))
+	subscriptions, err := r.GetAllSubscriptions()
+	assert.NoError(t, err)
+	if assert.Len(t, subscriptions, 2) {
+		assert.Equal(t, []string{"DEN", "APA"}, subscriptions[0].Faas)
+		assert.Nil(t, subscriptions[0].LastSentAt, "Never sent")
+		assert.Equal(t, sampleTime, *subscriptions[1].LastSentAt)
+	}
+
+	mock.ExpectQuery(`SELECT (.+) FROM subscriptions WHERE owner = \$1 ORDER BY id`).
+		WithArgs("owner").
+		WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetSubscriptions("owner")
+	assert.EqualError(t, err, "failed to query subscriptions: "+anErrorMsg)
+
+	mock.ExpectExec(`UPDATE subscriptions SET last_sent_at = \$2 WHERE id = \$1`).WithArgs(7, sampleTime).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.MarkSubscriptionSent(7, sampleTime))
+
+	mock.ExpectExec(`DELETE FROM subscriptions WHERE id = \$1 AND owner = \$2`).WithArgs(7, "other").WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, r.DeleteSubscription(7, "other"), domain.ErrSubscriptionNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
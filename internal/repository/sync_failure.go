@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// maxRetryDoublings caps the backoff of a failing airport at 64 times the
+// base backoff.
+const maxRetryDoublings = 6
+
+// RecordSyncOutcome clears the failures of the synced airports and records
+// those of the failed ones in one transaction. A failed airport is next
+// retried after backoff, doubled for every earlier failure in a row. Failures
+// of airports that are not stored are skipped.
+func (r *Repository) RecordSyncOutcome(synced []string, failed []domain.SyncFailure, backoff time.Duration) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if len(synced) > 0 {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM sync_failures WHERE faa = ANY($1)`, textArray(synced)); err != nil {
+			return fmt.Errorf("failed to clear sync failures: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO sync_failures (faa, error, attempts, last_failed_at, next_retry_at)
+		SELECT $1, $2, 1, NOW(), NOW() + $3 * INTERVAL '1 second'
+		WHERE EXISTS (SELECT 1 FROM airport WHERE faa = $1)
+		ON CONFLICT (faa) DO UPDATE SET
+			error = EXCLUDED.error,
+			attempts = sync_failures.attempts + 1,
+			last_failed_at = NOW(),
+			next_retry_at = NOW() + $3 * POWER(2, LEAST(sync_failures.attempts, $4)) * INTERVAL '1 second'
+	`
+	for _, f := range failed {
+		if _, err := tx.ExecContext(ctx, query, f.Faa, f.Error, backoff.Seconds(), maxRetryDoublings); err != nil {
+			return fmt.Errorf("failed to record sync failure of %s: %w", f.Faa, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit sync outcome: %w", err)
+	}
+	return nil
+}
+
+// GetDueSyncFailures fetches the failed airports whose retry is due, longest
+// due first.
+func (r *Repository) GetDueSyncFailures() ([]domain.SyncFailure, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT faa, error, attempts, last_failed_at, next_retry_at
+		FROM sync_failures
+		WHERE next_retry_at <= NOW()
+		ORDER BY next_retry_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync failures: %w", err)
+	}
+	defer rows.Close()
+
+	failures := []domain.SyncFailure{}
+	for rows.Next() {
+		var f domain.SyncFailure
+		var lastFailedAt, nextRetryAt time.Time
+		if err := rows.Scan(&f.Faa, &f.Error, &f.Attempts, &lastFailedAt, &nextRetryAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sync failure row: %w", err)
+		}
+		f.LastFailedAt, f.NextRetryAt = &lastFailedAt, &nextRetryAt
+		failures = append(failures, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return failures, nil
+}
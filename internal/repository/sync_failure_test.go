@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordSyncOutcome(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM sync_failures WHERE faa = ANY\(\$1\)`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO sync_failures`).WithArgs("DEN", "timeout", float64(900), maxRetryDoublings).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	err = r.RecordSyncOutcome([]string{"AUS"}, []domain.SyncFailure{{Faa: "DEN", Error: "timeout"}}, 15*time.Minute)
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO sync_failures`).WillReturnError(errors.New(anErrorMsg))
+	mock.ExpectRollback()
+	err = r.RecordSyncOutcome(nil, []domain.SyncFailure{{Faa: "DEN"}}, time.Minute)
+	assert.EqualError(t, err, "failed to record sync failure of DEN: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDueSyncFailures(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`SELECT faa, error, attempts, last_failed_at, next_retry_at\s+FROM sync_failures\s+WHERE next_retry_at <= NOW\(\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"faa", "error", "attempts", "last_failed_at", "next_retry_at"}).
+			AddRow("DEN", "timeout", 2, sampleTime, sampleTime))
+	failures, err := r.GetDueSyncFailures()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.SyncFailure{{Faa: "DEN", Error: "timeout", Attempts: 2, LastFailedAt: &sampleTime, NextRetryAt: &sampleTime}}, failures)
+
+	mock.ExpectQuery(`FROM sync_failures`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetDueSyncFailures()
+	assert.EqualError(t, err, "failed to query sync failures: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
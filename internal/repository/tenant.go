@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// CreateTenant inserts a tenant and fills in its id and creation time,
+// returning domain.ErrTenantExists if the name is taken.
+func (r *Repository) CreateTenant(t *domain.Tenant) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO tenants (name)
+		VALUES ($1)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	var createdAt time.Time
+	if err := r.db.QueryRowContext(ctx, query, t.Name).Scan(&t.ID, &createdAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %s", domain.ErrTenantExists, t.Name)
+		}
+		return fmt.Errorf("failed to create tenant: %w", err)
+	}
+	t.CreatedAt = &createdAt
+
+	return nil
+}
+
+// GetTenants fetches every tenant, oldest first.
+func (r *Repository) GetTenants() ([]domain.Tenant, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, created_at FROM tenants ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tenants: %w", err)
+	}
+	defer rows.Close()
+
+	tenants := []domain.Tenant{}
+	for rows.Next() {
+		var t domain.Tenant
+		var createdAt time.Time
+		if err := rows.Scan(&t.ID, &t.Name, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tenant row: %w", err)
+		}
+		t.CreatedAt = &createdAt
+		tenants = append(tenants, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return tenants, nil
+}
+
+// CreateAPIKey registers the hash of a key for its tenant and fills in its id
+// and creation time, returning domain.ErrTenantNotFound if the tenant doesn't
+// exist.
+func (r *Repository) CreateAPIKey(key *domain.APIKey) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO api_keys (tenant_id, key_hash, name)
+		SELECT $1, $2, $3
+		WHERE EXISTS (SELECT 1 FROM tenants WHERE id = $1)
+		RETURNING id, created_at
+	`
+
+	var createdAt time.Time
+	if err := r.db.QueryRowContext(ctx, query, key.TenantID, key.KeyHash, key.Name).Scan(&key.ID, &createdAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %d", domain.ErrTenantNotFound, key.TenantID)
+		}
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	key.CreatedAt = &createdAt
+
+	return nil
+}
+
+// DeleteAPIKey revokes a key, returning domain.ErrAPIKeyNotFound if it doesn't
+// exist.
+func (r *Repository) DeleteAPIKey(id int64) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM api_keys WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete API key %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected for API key %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %d", domain.ErrAPIKeyNotFound, id)
+	}
+
+	return nil
+}
+
+// GetAPIKeys fetches the keys of a tenant, oldest first, without their hashes.
+func (r *Repository) GetAPIKeys(tenantID int64) ([]domain.APIKey, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id, tenant_id, name, created_at FROM api_keys WHERE tenant_id = $1 ORDER BY id`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []domain.APIKey{}
+	for rows.Next() {
+		var key domain.APIKey
+		var createdAt time.Time
+		if err := rows.Scan(&key.ID, &key.TenantID, &key.Name, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan API key row: %w", err)
+		}
+		key.CreatedAt = &createdAt
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// GetTenantIDByKeyHash resolves the tenant of the key with the given SHA-256,
+// returning domain.ErrAPIKeyNotFound if no key has that hash.
+func (r *Repository) GetTenantIDByKeyHash(keyHash string) (int64, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	var tenantID int64
+	if err := r.db.QueryRowContext(ctx, `SELECT tenant_id FROM api_keys WHERE key_hash = $1`, keyHash).Scan(&tenantID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrAPIKeyNotFound
+		}
+		return 0, fmt.Errorf("failed to resolve API key: %w", err)
+	}
+	return tenantID, nil
+}
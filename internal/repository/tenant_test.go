@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenants(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	tenant := domain.Tenant{Name: "Acme Aviation"}
+	mock.ExpectQuery(`INSERT INTO tenants .* ON CONFLICT \(name\) DO NOTHING\s+RETURNING id, created_at`).
+		WithArgs("Acme Aviation").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(2, sampleTime))
+	assert.NoError(t, r.CreateTenant(&tenant))
+	assert.Equal(t, int64(2), tenant.ID)
+	assert.Equal(t, &sampleTime, tenant.CreatedAt)
+
+	mock.ExpectQuery(`INSERT INTO tenants`).WithArgs("Acme Aviation").WillReturnError(sql.ErrNoRows)
+	assert.ErrorIs(t, r.CreateTenant(&tenant), domain.ErrTenantExists)
+
+	mock.ExpectQuery(`SELECT id, name, created_at FROM tenants ORDER BY id`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).
+			AddRow(1, "default", sampleTime).
+			AddRow(2, "Acme Aviation", sampleTime))
+	tenants, err := r.GetTenants()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Tenant{
+		{ID: 1, Name: "default", CreatedAt: &sampleTime},
+		{ID: 2, Name: "Acme Aviation", CreatedAt: &sampleTime},
+	}, tenants)
+
+	mock.ExpectQuery(`SELECT (.+) FROM tenants`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetTenants()
+	assert.EqualError(t, err, "failed to query tenants: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeys(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	key := domain.APIKey{TenantID: 2, Name: "ops", KeyHash: "hash"}
+	mock.ExpectQuery(`INSERT INTO api_keys .* WHERE EXISTS \(SELECT 1 FROM tenants WHERE id = \$1\)`).
+		WithArgs(int64(2), "hash", "ops").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(5, sampleTime))
+	assert.NoError(t, r.CreateAPIKey(&key))
+	assert.Equal(t, int64(5), key.ID)
+
+	missing := domain.APIKey{TenantID: 9, KeyHash: "hash"}
+	mock.ExpectQuery(`INSERT INTO api_keys`).WithArgs(int64(9), "hash", "").WillReturnError(sql.ErrNoRows)
+	assert.ErrorIs(t, r.CreateAPIKey(&missing), domain.ErrTenantNotFound)
+
+	mock.ExpectQuery(`SELECT id, tenant_id, name, created_at FROM api_keys WHERE tenant_id = \$1`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name", "created_at"}).AddRow(5, 2, "ops", sampleTime))
+	keys, err := r.GetAPIKeys(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.APIKey{{ID: 5, TenantID: 2, Name: "ops", CreatedAt: &sampleTime}}, keys)
+
+	mock.ExpectQuery(`SELECT tenant_id FROM api_keys WHERE key_hash = \$1`).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"tenant_id"}).AddRow(2))
+	tenantID, err := r.GetTenantIDByKeyHash("hash")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), tenantID)
+
+	mock.ExpectQuery(`SELECT tenant_id FROM api_keys`).WithArgs("unknown").WillReturnError(sql.ErrNoRows)
+	_, err = r.GetTenantIDByKeyHash("unknown")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+
+	mock.ExpectExec(`DELETE FROM api_keys WHERE id = \$1`).WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.DeleteAPIKey(5))
+	mock.ExpectExec(`DELETE FROM api_keys WHERE id = \$1`).WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, r.DeleteAPIKey(5), domain.ErrAPIKeyNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// CreateWatchlist inserts a watchlist and fills in its id and creation time.
+func (r *Repository) CreateWatchlist(wl *domain.Watchlist) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO watchlists (owner, name, faa_codes, tenant_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	var createdAt time.Time
+	if err := r.db.QueryRowContext(ctx, query, wl.Owner, wl.Name, textArray(wl.Faas), wl.TenantID).Scan(&wl.ID, &createdAt); err != nil {
+		return fmt.Errorf("failed to create watchlist: %w", err)
+	}
+	wl.CreatedAt = &createdAt
+
+	return nil
+}
+
+// DeleteWatchlist removes a watchlist of owner, returning domain.ErrWatchlistNotFound
+// if owner has none with that id.
+func (r *Repository) DeleteWatchlist(id int64, owner string) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM watchlists WHERE id = $1 AND owner = $2`, id, owner)
+	if err != nil {
+		return fmt.Errorf("failed to delete watchlist %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected for watchlist %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %d", domain.ErrWatchlistNotFound, id)
+	}
+
+	return nil
+}
+
+// GetWatchlists fetches the watchlists of owner, oldest first.
+func (r *Repository) GetWatchlists(owner string) ([]domain.Watchlist, error) {
+	return r.queryWatchlists(`SELECT id, owner, name, faa_codes, tenant_id, created_at FROM watchlists WHERE owner = $1 ORDER BY id`, owner)
+}
+
+// GetWatchlist fetches one watchlist of owner, returning domain.ErrWatchlistNotFound
+// if owner has none with that id.
+func (r *Repository) GetWatchlist(id int64, owner string) (*domain.Watchlist, error) {
+	watchlists, err := r.queryWatchlists(`SELECT id, owner, name, faa_codes, tenant_id, created_at FROM watchlists WHERE id = $1 AND owner = $2`, id, owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(watchlists) == 0 {
+		return nil, fmt.Errorf("%w: %d", domain.ErrWatchlistNotFound, id)
+	}
+	return &watchlists[0], nil
+}
+
+func (r *Repository) queryWatchlists(query string, args ...any) ([]domain.Watchlist, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watchlists: %w", err)
+	}
+	defer rows.Close()
+
+	watchlists := []domain.Watchlist{}
+	for rows.Next() {
+		var wl domain.Watchlist
+		var createdAt time.Time
+		if err := rows.Scan(&wl.ID, &wl.Owner, &wl.Name, scanTextArray(&wl.Faas), &wl.TenantID, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan watchlist row: %w", err)
+		}
+		wl.CreatedAt = &createdAt
+		watchlists = append(watchlists, wl)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return watchlists, nil
+}
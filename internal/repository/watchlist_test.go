@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchlists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	wl := domain.Watchlist{Name: "Home base", Faas: []string{"DEN", "APA"}, Owner: "owner", TenantID: 2}
+	mock.ExpectQuery(`INSERT INTO watchlists .* RETURNING id, created_at`).
+		WithArgs("owner", "Home base", sqlmock.AnyArg(), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, sampleTime))
+	assert.NoError(t, r.CreateWatchlist(&wl))
+	assert.Equal(t, int64(3), wl.ID)
+
+	columns := []string{"id", "owner", "name", "faa_codes", "tenant_id", "created_at"}
+	mock.ExpectQuery(`SELECT (.+) FROM watchlists WHERE owner = \$1 ORDER BY id`).
+		WithArgs("owner").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(3, "owner", "Home base", "{DEN,APA}", 2, sampleTime))
+	watchlists, err := r.GetWatchlists("owner")
+	assert.NoError(t, err)
+	if assert.Len(t, watchlists, 1) {
+		assert.Equal(t, []string{"DEN", "APA"}, watchlists[0].Faas, "Codes keep their order")
+	}
+
+	mock.ExpectQuery(`SELECT (.+) FROM watchlists WHERE id = \$1 AND owner = \$2`).
+		WithArgs(3, "someone else").
+		WillReturnRows(sqlmock.NewRows(columns))
+	_, err = r.GetWatchlist(3, "someone else")
+	assert.ErrorIs(t, err, domain.ErrWatchlistNotFound, "Other keys' watchlists are not found")
+
+	mock.ExpectQuery(`SELECT (.+) FROM watchlists WHERE id = \$1 AND owner = \$2`).
+		WithArgs(3, "owner").
+		WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetWatchlist(3, "owner")
+	assert.EqualError(t, err, "failed to query watchlists: "+anErrorMsg)
+
+	mock.ExpectExec(`DELETE FROM watchlists WHERE id = \$1 AND owner = \$2`).WithArgs(3, "owner").WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, r.DeleteWatchlist(3, "owner"))
+	mock.ExpectExec(`DELETE FROM watchlists WHERE id = \$1 AND owner = \$2`).WithArgs(3, "owner").WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, r.DeleteWatchlist(3, "owner"), domain.ErrWatchlistNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
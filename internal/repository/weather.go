@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// SaveObservation stores the latest structured weather of one airport,
+// replacing the previous one, and appends it to weather_history unless
+// already there.
+func (r *Repository) SaveObservation(faa string, obs domain.Observation) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		WITH history AS (
+			INSERT INTO weather_history (
+				faa, provider, condition, temperature_c, dewpoint_c, humidity_pct,
+				wind_dir_deg, wind_speed_kt, wind_gust_kt, visibility_sm, pressure_hpa,
+				raw_metar, observed_at
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			ON CONFLICT (faa, observed_at) DO NOTHING
+		)
+		INSERT INTO airport_weather (
+			faa, provider, condition, temperature_c, dewpoint_c, humidity_pct,
+			wind_dir_deg, wind_speed_kt, wind_gust_kt, visibility_sm, pressure_hpa,
+			raw_metar, observed_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (faa) DO UPDATE
+		SET provider = EXCLUDED.provider, condition = EXCLUDED.condition,
+		    temperature_c = EXCLUDED.temperature_c, dewpoint_c = EXCLUDED.dewpoint_c,
+		    humidity_pct = EXCLUDED.humidity_pct, wind_dir_deg = EXCLUDED.wind_dir_deg,
+		    wind_speed_kt = EXCLUDED.wind_speed_kt, wind_gust_kt = EXCLUDED.wind_gust_kt,
+		    visibility_sm = EXCLUDED.visibility_sm, pressure_hpa = EXCLUDED.pressure_hpa,
+		    raw_metar = EXCLUDED.raw_metar, observed_at = EXCLUDED.observed_at,
+		    updated_at = NOW()
+	`
+
+	if _, err := r.db.ExecContext(ctx,
+		query,
+		faa, obs.Provider, obs.Condition, obs.TemperatureC, obs.DewpointC, obs.HumidityPct,
+		obs.WindDirDeg, obs.WindSpeedKt, obs.WindGustKt, obs.VisibilitySM, obs.PressureHpa,
+		obs.RawMETAR, obs.ObservedAt,
+	); err != nil {
+		return fmt.Errorf("failed to save weather for %s: %w", faa, err)
+	}
+
+	return nil
+}
+
+// observationColumns is the column list of every airport_weather SELECT, in scanObservation order.
+const observationColumns = `
+	COALESCE(provider, ''), COALESCE(condition, ''),
+	COALESCE(temperature_c, 0), COALESCE(dewpoint_c, 0), COALESCE(humidity_pct, 0),
+	COALESCE(wind_dir_deg, 0), COALESCE(wind_speed_kt, 0), COALESCE(wind_gust_kt, 0),
+	COALESCE(visibility_sm, 0), COALESCE(pressure_hpa, 0), COALESCE(raw_metar, ''),
+	observed_at
+`
+
+// scanObservation reads one row selected with observationColumns, after any leading dest.
+func scanObservation(row interface{ Scan(...any) error }, dest ...any) (domain.Observation, error) {
+	var obs domain.Observation
+	var observedAt sql.NullTime
+	err := row.Scan(append(dest,
+		&obs.Provider, &obs.Condition,
+		&obs.TemperatureC, &obs.DewpointC, &obs.HumidityPct,
+		&obs.WindDirDeg, &obs.WindSpeedKt, &obs.WindGustKt,
+		&obs.VisibilitySM, &obs.PressureHpa, &obs.RawMETAR,
+		&observedAt,
+	)...)
+	obs.ObservedAt = observedAt.Time
+	return obs, err
+}
+
+// GetObservation fetches the latest structured weather of one airport, or nil
+// when none has been synced yet.
+func (r *Repository) GetObservation(faa string) (*domain.Observation, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `SELECT ` + observationColumns + ` FROM airport_weather WHERE faa = $1`
+
+	obs, err := scanObservation(r.db.QueryRowContext(ctx, query, faa))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weather for %s: %w", faa, err)
+	}
+
+	return &obs, nil
+}
+
+// GetObservations fetches the latest structured weather of several airports,
+// keyed by FAA code. Airports never synced are absent from the map.
+func (r *Repository) GetObservations(faas []string) (map[string]domain.Observation, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `SELECT faa, ` + observationColumns + ` FROM airport_weather WHERE faa = ANY($1)`
+
+	rows, err := r.db.QueryContext(ctx, query, textArray(faas))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weather: %w", err)
+	}
+	defer rows.Close()
+
+	observations := make(map[string]domain.Observation, len(faas))
+	for rows.Next() {
+		var faa string
+		obs, err := scanObservation(rows, &faa)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan weather: %w", err)
+		}
+		observations[faa] = obs
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return observations, nil
+}
+
+// GetWeatherHistory fetches the observations of one airport from
+// weather_history observed since the given time, oldest first.
+func (r *Repository) GetWeatherHistory(faa string, since time.Time) ([]domain.Observation, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `SELECT ` + observationColumns + ` FROM weather_history WHERE faa = $1 AND observed_at >= $2 ORDER BY observed_at`
+
+	rows, err := r.db.QueryContext(ctx, query, faa, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weather history for %s: %w", faa, err)
+	}
+	defer rows.Close()
+
+	observations := []domain.Observation{}
+	for rows.Next() {
+		obs, err := scanObservation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan weather history: %w", err)
+		}
+		observations = append(observations, obs)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return observations, nil
+}
+
+// GetWeatherHistories fetches the observations of several airports from
+// weather_history observed since the given time, keyed by FAA code, oldest
+// first. Airports without observations are absent from the map.
+func (r *Repository) GetWeatherHistories(faas []string, since time.Time) (map[string][]domain.Observation, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `SELECT faa, ` + observationColumns + ` FROM weather_history WHERE faa = ANY($1) AND observed_at >= $2 ORDER BY faa, observed_at`
+
+	rows, err := r.db.QueryContext(ctx, query, textArray(faas), since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weather history: %w", err)
+	}
+	defer rows.Close()
+
+	histories := make(map[string][]domain.Observation, len(faas))
+	for rows.Next() {
+		var faa string
+		obs, err := scanObservation(rows, &faa)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan weather history: %w", err)
+		}
+		histories[faa] = append(histories[faa], obs)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return histories, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+)
+
+// weatherHistoryPartition names the partition of weather_history holding the
+// month starting at month.
+func weatherHistoryPartition(month time.Time) string {
+	return fmt.Sprintf("weather_history_y%04dm%02d", month.Year(), int(month.Month()))
+}
+
+// CreateWeatherHistoryPartitions creates the monthly partitions of
+// weather_history for the months months from that of from on, skipping those
+// that exist, and returns the names of those created.
+func (r *Repository) CreateWeatherHistoryPartitions(from time.Time, months int) ([]string, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	from = from.UTC()
+	start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	created := []string{}
+	for i := range months {
+		month := start.AddDate(0, i, 0)
+		name := weatherHistoryPartition(month)
+
+		var exists bool
+		if err := r.db.QueryRowContext(ctx, `SELECT to_regclass($1) IS NOT NULL`, name).Scan(&exists); err != nil {
+			return created, fmt.Errorf("failed to check partition %s: %w", name, err)
+		}
+		if exists {
+			continue
+		}
+
+		// DDL takes no parameters; name and bounds are formatted from a time
+		query := fmt.Sprintf(`CREATE TABLE %s PARTITION OF weather_history FOR VALUES FROM ('%s') TO ('%s')`,
+			name, month.Format(time.RFC3339), month.AddDate(0, 1, 0).Format(time.RFC3339))
+		if _, err := r.db.ExecContext(ctx, query); err != nil {
+			return created, fmt.Errorf("failed to create partition %s: %w", name, err)
+		}
+		created = append(created, name)
+	}
+
+	return created, nil
+}
+
+// DropWeatherHistoryPartitions drops the monthly partitions of weather_history
+// holding only observations from before before, and returns their names. The
+// default partition is kept.
+func (r *Repository) DropWeatherHistoryPartitions(before time.Time) ([]string, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'weather_history'::regclass
+		ORDER BY c.relname
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weather history partitions: %w", err)
+	}
+	defer rows.Close()
+
+	var expired []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan partition row: %w", err)
+		}
+
+		var year, month int
+		if _, err := fmt.Sscanf(name, "weather_history_y%4dm%2d", &year, &month); err != nil {
+			continue
+		}
+		end := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+		if !end.After(before) {
+			expired = append(expired, name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	rows.Close()
+
+	dropped := []string{}
+	for _, name := range expired {
+		if _, err := r.db.ExecContext(ctx, fmt.Sprintf(`DROP TABLE IF EXISTS %s`, name)); err != nil {
+			return dropped, fmt.Errorf("failed to drop partition %s: %w", name, err)
+		}
+		dropped = append(dropped, name)
+	}
+
+	return dropped, nil
+}
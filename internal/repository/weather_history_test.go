@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateWeatherHistoryPartitions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	exists := func(name string, ok bool) {
+		mock.ExpectQuery(`SELECT to_regclass\(\$1\) IS NOT NULL`).WithArgs(name).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(ok))
+	}
+	exists("weather_history_y2024m12", true)
+	exists("weather_history_y2025m01", false)
+	mock.ExpectExec(`CREATE TABLE weather_history_y2025m01 PARTITION OF weather_history ` +
+		`FOR VALUES FROM \('2025-01-01T00:00:00Z'\) TO \('2025-02-01T00:00:00Z'\)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	created, err := r.CreateWeatherHistoryPartitions(time.Date(2024, 12, 15, 8, 0, 0, 0, time.UTC), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"weather_history_y2025m01"}, created, "Existing partitions are skipped")
+
+	exists("weather_history_y2024m12", false)
+	mock.ExpectExec(`CREATE TABLE weather_history_y2024m12`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.CreateWeatherHistoryPartitions(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), 1)
+	assert.EqualError(t, err, "failed to create partition weather_history_y2024m12: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDropWeatherHistoryPartitions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	mock.ExpectQuery(`FROM pg_inherits`).
+		WillReturnRows(sqlmock.NewRows([]string{"relname"}).
+			AddRow("weather_history_default").
+			AddRow("weather_history_y2024m01").
+			AddRow("weather_history_y2024m02").
+			AddRow("weather_history_y2024m03"))
+	mock.ExpectExec(`DROP TABLE IF EXISTS weather_history_y2024m01`).WillReturnResult(sqlmock.NewResult(0, 0))
+	dropped, err := r.DropWeatherHistoryPartitions(time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"weather_history_y2024m01"}, dropped, "Months reaching past before and the default partition are kept")
+
+	mock.ExpectQuery(`FROM pg_inherits`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.DropWeatherHistoryPartitions(sampleTime)
+	assert.EqualError(t, err, "failed to query weather history partitions: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
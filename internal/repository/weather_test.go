@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleObservation = domain.Observation{
+	Provider:     "noaa",
+	Condition:    "Clear",
+	TemperatureC: 21,
+	DewpointC:    10,
+	HumidityPct:  49.5,
+	WindDirDeg:   180,
+	WindSpeedKt:  12,
+	WindGustKt:   20,
+	VisibilitySM: 10,
+	PressureHpa:  1013.2,
+	RawMETAR:     "KTST 011200Z 18012G20KT 10SM CLR 21/10 A2992",
+	ObservedAt:   sampleTime,
+}
+
+func TestSaveObservation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	o := sampleObservation
+	mock.ExpectExec(`INSERT INTO airport_weather .* ON CONFLICT \(faa\) DO UPDATE`).
+		WithArgs("TST", o.Provider, o.Condition, o.TemperatureC, o.DewpointC, o.HumidityPct,
+			o.WindDirDeg, o.WindSpeedKt, o.WindGustKt, o.VisibilitySM, o.PressureHpa, o.RawMETAR, o.ObservedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	assert.NoError(t, r.SaveObservation("TST", o))
+
+	mock.ExpectExec(`INSERT INTO airport_weather`).WillReturnError(errors.New(anErrorMsg))
+	assert.EqualError(t, r.SaveObservation("TST", o), "failed to save weather for TST: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetObservation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	o := sampleObservation
+	columns := []string{"provider", "condition", "temperature_c", "dewpoint_c", "humidity_pct",
+		"wind_dir_deg", "wind_speed_kt", "wind_gust_kt", "visibility_sm", "pressure_hpa", "raw_metar", "observed_at"}
+	mock.ExpectQuery(`SELECT (.+) FROM airport_weather WHERE faa = \$1`).
+		WithArgs("TST").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(o.Provider, o.Condition, o.TemperatureC, o.DewpointC, o.HumidityPct,
+			o.WindDirDeg, o.WindSpeedKt, o.WindGustKt, o.VisibilitySM, o.PressureHpa, o.RawMETAR, o.ObservedAt))
+	obs, err := r.GetObservation("TST")
+	assert.NoError(t, err)
+	assert.Equal(t, &o, obs)
+
+	mock.ExpectQuery(`SELECT (.+) FROM airport_weather`).
+		WithArgs("NEW").
+		WillReturnRows(sqlmock.NewRows(columns))
+	obs, err = r.GetObservation("NEW")
+	assert.NoError(t, err)
+	assert.Nil(t, obs)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetObservations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	o := sampleObservation
+	columns := []string{"faa", "provider", "condition", "temperature_c", "dewpoint_c", "humidity_pct",
+		"wind_dir_deg", "wind_speed_kt", "wind_gust_kt", "visibility_sm", "pressure_hpa", "raw_metar", "observed_at"}
+	mock.ExpectQuery(`SELECT faa, (.+) FROM airport_weather WHERE faa = ANY\(\$1\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("TST", o.Provider, o.Condition, o.TemperatureC, o.DewpointC, o.HumidityPct,
+			o.WindDirDeg, o.WindSpeedKt, o.WindGustKt, o.VisibilitySM, o.PressureHpa, o.RawMETAR, o.ObservedAt))
+	observations, err := r.GetObservations([]string{"TST", "NEW"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]domain.Observation{"TST": o}, observations)
+
+	mock.ExpectQuery(`SELECT faa, (.+) FROM airport_weather`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetObservations([]string{"TST"})
+	assert.EqualError(t, err, "failed to query weather: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWeatherHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	o := sampleObservation
+	since := o.ObservedAt.Add(-3 * time.Hour)
+	columns := []string{"provider", "condition", "temperature_c", "dewpoint_c", "humidity_pct",
+		"wind_dir_deg", "wind_speed_kt", "wind_gust_kt", "visibility_sm", "pressure_hpa", "raw_metar", "observed_at"}
+	mock.ExpectQuery(`SELECT (.+) FROM weather_history WHERE faa = \$1 AND observed_at >= \$2 ORDER BY observed_at`).
+		WithArgs("TST", since).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(o.Provider, o.Condition, o.TemperatureC, o.DewpointC, o.HumidityPct,
+			o.WindDirDeg, o.WindSpeedKt, o.WindGustKt, o.VisibilitySM, o.PressureHpa, o.RawMETAR, o.ObservedAt))
+	observations, err := r.GetWeatherHistory("TST", since)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Observation{o}, observations)
+
+	mock.ExpectQuery(`SELECT (.+) FROM weather_history`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetWeatherHistory("TST", since)
+	assert.EqualError(t, err, "failed to query weather history for TST: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWeatherHistories(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	o := sampleObservation
+	since := o.ObservedAt.Add(-3 * time.Hour)
+	columns := []string{"faa", "provider", "condition", "temperature_c", "dewpoint_c", "humidity_pct",
+		"wind_dir_deg", "wind_speed_kt", "wind_gust_kt", "visibility_sm", "pressure_hpa", "raw_metar", "observed_at"}
+	row := func(faa string) []driver.Value {
+		return []driver.Value{faa, o.Provider, o.Condition, o.TemperatureC, o.DewpointC, o.HumidityPct,
+			o.WindDirDeg, o.WindSpeedKt, o.WindGustKt, o.VisibilitySM, o.PressureHpa, o.RawMETAR, o.ObservedAt}
+	}
+	mock.ExpectQuery(`SELECT faa, (.+) FROM weather_history WHERE faa = ANY\(\$1\) AND observed_at >= \$2 ORDER BY faa, observed_at`).
+		WithArgs(sqlmock.AnyArg(), since).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(row("DEN")...).AddRow(row("TST")...).AddRow(row("TST")...))
+	histories, err := r.GetWeatherHistories([]string{"TST", "DEN", "ASE"}, since)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]domain.Observation{"DEN": {o}, "TST": {o, o}}, histories)
+
+	mock.ExpectQuery(`SELECT faa, (.+) FROM weather_history`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetWeatherHistories([]string{"TST"}, since)
+	assert.EqualError(t, err, "failed to query weather history: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
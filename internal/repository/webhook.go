@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// CreateWebhook inserts a webhook and fills in its id and creation time.
+func (r *Repository) CreateWebhook(wh *domain.Webhook) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO webhooks (url, secret, events, tenant_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	var createdAt time.Time
+	if err := r.db.QueryRowContext(ctx, query, wh.URL, wh.Secret, textArray(wh.Events), wh.TenantID).Scan(&wh.ID, &createdAt); err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	wh.CreatedAt = &createdAt
+
+	return nil
+}
+
+// DeleteWebhook removes a webhook of a tenant and its delivery log, returning
+// domain.ErrWebhookNotFound if the tenant has none with that id.
+func (r *Repository) DeleteWebhook(tenantID, id int64) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected for webhook %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %d", domain.ErrWebhookNotFound, id)
+	}
+
+	return nil
+}
+
+// GetWebhooks fetches every webhook of a tenant, oldest first.
+func (r *Repository) GetWebhooks(tenantID int64) ([]domain.Webhook, error) {
+	return r.queryWebhooks(`SELECT `+webhookColumns+` FROM webhooks WHERE tenant_id = $1 ORDER BY id`, tenantID)
+}
+
+// GetWebhooksForEvent fetches the webhooks subscribed to one event, of one
+// tenant or of every tenant when tenantID is domain.AllTenants.
+func (r *Repository) GetWebhooksForEvent(tenantID int64, event string) ([]domain.Webhook, error) {
+	return r.queryWebhooks(
+		`SELECT `+webhookColumns+` FROM webhooks WHERE $1 = ANY(events) AND ($2 = 0 OR tenant_id = $2) ORDER BY id`,
+		event, tenantID,
+	)
+}
+
+const webhookColumns = `id, url, secret, events, tenant_id, created_at`
+
+func (r *Repository) queryWebhooks(query string, args ...any) ([]domain.Webhook, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []domain.Webhook{}
+	for rows.Next() {
+		var wh domain.Webhook
+		var createdAt time.Time
+		if err := rows.Scan(&wh.ID, &wh.URL, &wh.Secret, scanTextArray(&wh.Events), &wh.TenantID, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook row: %w", err)
+		}
+		wh.CreatedAt = &createdAt
+		webhooks = append(webhooks, wh)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// SaveWebhookDelivery logs the outcome of one delivery and fills in its id.
+func (r *Repository) SaveWebhookDelivery(d *domain.WebhookDelivery) error {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event, payload, attempts, status_code, error, succeeded)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+
+	var createdAt time.Time
+	if err := r.db.QueryRowContext(ctx,
+		query,
+		d.WebhookID, d.Event, []byte(d.Payload), d.Attempts, d.StatusCode, d.Error, d.Succeeded,
+	).Scan(&d.ID, &createdAt); err != nil {
+		return fmt.Errorf("failed to save delivery for webhook %d: %w", d.WebhookID, err)
+	}
+	d.CreatedAt = &createdAt
+
+	return nil
+}
+
+// GetWebhookDeliveries fetches the latest deliveries of one webhook of a
+// tenant, newest first.
+func (r *Repository) GetWebhookDeliveries(tenantID, webhookID int64, limit int) ([]domain.WebhookDelivery, error) {
+	ctx, cancel := r.queryContext()
+	defer cancel()
+
+	query := `
+		SELECT d.id, d.webhook_id, d.event, d.payload, d.attempts, d.status_code, d.error, d.succeeded, d.created_at
+		FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.webhook_id = $1 AND w.tenant_id = $2
+		ORDER BY d.created_at DESC, d.id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, webhookID, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deliveries for webhook %d: %w", webhookID, err)
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		var payload []byte
+		var createdAt time.Time
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &payload, &d.Attempts, &d.StatusCode, &d.Error, &d.Succeeded, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery row: %w", err)
+		}
+		d.Payload, d.CreatedAt = payload, &createdAt
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhooks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	wh := domain.Webhook{URL: "https://example.com/hook", Secret: "s3cret", Events: []string{"alert.fired"}, TenantID: 2}
+	mock.ExpectQuery(`INSERT INTO webhooks .* RETURNING id, created_at`).
+		WithArgs(wh.URL, wh.Secret, sqlmock.AnyArg(), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(4, sampleTime))
+	assert.NoError(t, r.CreateWebhook(&wh))
+	assert.Equal(t, int64(4), wh.ID)
+
+	columns := []string{"id", "url", "secret", "events", "tenant_id", "created_at"}
+	mock.ExpectQuery(`SELECT (.+) FROM webhooks WHERE \$1 = ANY\(events\) AND \(\$2 = 0 OR tenant_id = \$2\)`).
+		WithArgs("alert.fired", 2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(4, wh.URL, wh.Secret, "{alert.fired,sync.completed}", 2, sampleTime))
+	webhooks, err := r.GetWebhooksForEvent(2, "alert.fired")
+	assert.NoError(t, err)
+	if assert.Len(t, webhooks, 1) {
+		assert.Equal(t, []string{"alert.fired", "sync.completed"}, webhooks[0].Events)
+		assert.Equal(t, "s3cret", webhooks[0].Secret)
+		assert.Equal(t, int64(2), webhooks[0].TenantID)
+	}
+
+	mock.ExpectQuery(`SELECT (.+) FROM webhooks WHERE tenant_id = \$1 ORDER BY id`).WithArgs(2).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetWebhooks(2)
+	assert.EqualError(t, err, "failed to query webhooks: "+anErrorMsg)
+
+	mock.ExpectExec(`DELETE FROM webhooks WHERE id = \$1 AND tenant_id = \$2`).WithArgs(5, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, r.DeleteWebhook(2, 5), domain.ErrWebhookNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	r := NewRepository(db, 0)
+
+	payload := json.RawMessage(`{"event":"sync.completed"}`)
+	d := domain.WebhookDelivery{WebhookID: 4, Event: "sync.completed", Payload: payload, Attempts: 2, StatusCode: 200, Succeeded: true}
+	mock.ExpectQuery(`INSERT INTO webhook_deliveries .* RETURNING id, created_at`).
+		WithArgs(int64(4), "sync.completed", []byte(payload), 2, 200, "", true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(9, sampleTime))
+	assert.NoError(t, r.SaveWebhookDelivery(&d))
+	assert.Equal(t, int64(9), d.ID)
+
+	columns := []string{"id", "webhook_id", "event", "payload", "attempts", "status_code", "error", "succeeded", "created_at"}
+	mock.ExpectQuery(`SELECT (.+) FROM webhook_deliveries d\s+JOIN webhooks w ON w.id = d.webhook_id\s+WHERE d.webhook_id = \$1 AND w.tenant_id = \$2`).
+		WithArgs(4, 2, 10).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(9, 4, "sync.completed", []byte(payload), 2, 200, "", true, sampleTime))
+	deliveries, err := r.GetWebhookDeliveries(2, 4, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{d}, deliveries)
+
+	mock.ExpectQuery(`SELECT (.+) FROM webhook_deliveries`).WillReturnError(errors.New(anErrorMsg))
+	_, err = r.GetWebhookDeliveries(2, 4, 10)
+	assert.EqualError(t, err, "failed to query deliveries for webhook 4: "+anErrorMsg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// leaderLockID keys the advisory lock held by the leading scheduler. It must
+// differ from the lock of the migrations.
+const leaderLockID = 7_236_411_903
+
+// Elector elects one leader among scheduler replicas sharing a database: the
+// replica holding a session advisory lock leads, and the others keep trying
+// to take it, so one of them takes over once the leader's session ends.
+type Elector struct {
+	db       *sql.DB
+	interval time.Duration
+
+	mu   sync.Mutex
+	conn *sql.Conn // Session holding the lock while leading
+
+	leading atomic.Bool
+}
+
+// NewElector returns an Elector checking its lock every interval.
+func NewElector(db *sql.DB, interval time.Duration) *Elector {
+	return &Elector{db: db, interval: interval}
+}
+
+// Leading reports whether this replica holds the lock, as of the last check.
+func (e *Elector) Leading() bool {
+	return e.leading.Load()
+}
+
+// Campaign checks that the lock is still held while leading, or tries to take
+// it otherwise. A leader whose session broke stands down.
+func (e *Elector) Campaign(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn != nil {
+		if err := e.conn.PingContext(ctx); err == nil {
+			return nil
+		}
+		e.standDown("its database session broke")
+	}
+
+	conn, err := e.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	var locked bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, leaderLockID).Scan(&locked); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to try leader lock: %w", err)
+	}
+	if !locked {
+		conn.Close()
+		return nil
+	}
+
+	e.conn = conn
+	e.leading.Store(true)
+	log.Println("Elected scheduler leader")
+	return nil
+}
+
+// Run campaigns every interval until ctx is done. It keeps the lead on return;
+// Resign once running jobs have finished.
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.Campaign(ctx); err != nil {
+				log.Printf("WARN: Scheduler leader election: %v", err)
+			}
+		}
+	}
+}
+
+// Resign releases the lock, letting a standby replica take over at once.
+func (e *Elector) Resign() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		return
+	}
+	if _, err := e.conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, leaderLockID); err != nil {
+		log.Printf("WARN: Failed to release leader lock: %v", err)
+	}
+	e.standDown("it is shutting down")
+}
+
+// standDown ends the session of the lock, which releases it. Closing the
+// *sql.Conn would only return the session to the pool, lock and all, so it is
+// discarded instead.
+func (e *Elector) standDown(reason string) {
+	e.conn.Raw(func(any) error { return driver.ErrBadConn })
+	e.conn = nil
+	e.leading.Store(false)
+	log.Printf("Stepped down as scheduler leader: %s", reason)
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElector(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Hold a session of the pool's own, so discarding the leader's leaves the
+	// mock open
+	spare, err := db.Conn(context.Background())
+	assert.NoError(t, err)
+	defer spare.Close()
+
+	e := NewElector(db, time.Second)
+
+	// Another replica holds the lock
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).WithArgs(leaderLockID).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+	assert.NoError(t, e.Campaign(context.Background()))
+	assert.False(t, e.Leading())
+
+	// It went away
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).WithArgs(leaderLockID).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	assert.NoError(t, e.Campaign(context.Background()))
+	assert.True(t, e.Leading())
+
+	// The session of the lock answers, so the lead is kept
+	mock.ExpectPing()
+	assert.NoError(t, e.Campaign(context.Background()))
+	assert.True(t, e.Leading())
+
+	// It broke, so it is discarded rather than returned to the pool, and the
+	// lock can't be taken again right away
+	mock.ExpectPing().WillReturnError(errors.New("connection reset"))
+	mock.ExpectClose()
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).WillReturnError(errors.New("connection refused"))
+	assert.EqualError(t, e.Campaign(context.Background()), "failed to try leader lock: connection refused")
+	assert.False(t, e.Leading())
+
+	mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	assert.NoError(t, e.Campaign(context.Background()))
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(leaderLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectClose()
+	e.Resign()
+	assert.False(t, e.Leading())
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOnlyTheLeaderRunsJobs(t *testing.T) {
+	leading := false
+	runs := 0
+	s := New(context.Background())
+	s.Register(domain.JobSyncAll, func(ctx context.Context) (string, error) {
+		runs++
+		return "", nil
+	})
+	s.SetLeader(func() bool { return leading })
+
+	run := s.run(domain.JobSyncAll, s.jobs[domain.JobSyncAll])
+	run()
+	assert.Equal(t, 0, runs, "Standbys skip their runs")
+
+	leading = true
+	run()
+	assert.Equal(t, 1, runs)
+}
@@ -0,0 +1,207 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// Service is what the jobs of a Runner run, as implemented by the service
+// layer.
+type Service interface {
+	SyncAllAirports(ctx context.Context) (int, error)
+	SyncStaleAirports(ctx context.Context) (int, error)
+	SendDigests(ctx context.Context) (int, error)
+	PruneLogs(ctx context.Context) (int64, error)
+	PartitionWeatherHistory(ctx context.Context) ([]string, []string, error)
+	SyncAdvisories(ctx context.Context) (int, error)
+	SyncCharts(ctx context.Context) (int, error)
+	RefreshStaticData(ctx context.Context) (int, error)
+	RefreshNOTAMs(ctx context.Context) (int, error)
+	RetryFailedAirports(ctx context.Context) (int, error)
+	GetSchedulerJobs() ([]domain.SchedulerJob, error)
+	ClaimJobRuns() ([]string, error)
+}
+
+// Runner runs the registry of jobs against svc: on the schedules of the config
+// overridden by the scheduler_jobs table, on the elected replica only when
+// leader election is on, and with the run requests of the admin API. It
+// serves cmd/scheduler and the server started with the scheduler enabled.
+type Runner struct {
+	jobs    *Scheduler
+	svc     Service
+	elector *Elector
+	current atomic.Pointer[config.Config]
+
+	// Closed by Stop to end the refreshes
+	stopped chan struct{}
+}
+
+// NewRunner registers the jobs of svc and starts running them until ctx is
+// done. Jobs run with ctx, so it should be marked by service.Scheduled for
+// scheduled syncs to be spread.
+func NewRunner(ctx context.Context, cfg *config.Config, db *sql.DB, svc Service) *Runner {
+	r := &Runner{jobs: New(ctx), svc: svc, stopped: make(chan struct{})}
+	r.current.Store(cfg)
+	r.jobs.SetJitter(cfg.SchedulerJitter)
+	r.jobs.Register(domain.JobSyncAll, func(ctx context.Context) (string, error) {
+		updated, err := svc.SyncAllAirports(ctx)
+		return fmt.Sprintf("updated %d airports", updated), err
+	})
+	r.jobs.Register(domain.JobSyncStale, func(ctx context.Context) (string, error) {
+		updated, err := svc.SyncStaleAirports(ctx)
+		return fmt.Sprintf("updated %d stale airports", updated), err
+	})
+	r.jobs.Register(domain.JobSendDigests, func(ctx context.Context) (string, error) {
+		sent, err := svc.SendDigests(ctx)
+		return fmt.Sprintf("sent %d digests", sent), err
+	})
+	r.jobs.Register(domain.JobPruneLogs, func(ctx context.Context) (string, error) {
+		pruned, err := svc.PruneLogs(ctx)
+		return fmt.Sprintf("pruned %d rows", pruned), err
+	})
+	r.jobs.Register(domain.JobRetryFailed, func(ctx context.Context) (string, error) {
+		updated, err := svc.RetryFailedAirports(ctx)
+		return fmt.Sprintf("recovered %d airports", updated), err
+	})
+	r.jobs.Register(domain.JobPartitionHistory, func(ctx context.Context) (string, error) {
+		created, dropped, err := svc.PartitionWeatherHistory(ctx)
+		return fmt.Sprintf("created %d and dropped %d partitions", len(created), len(dropped)), err
+	})
+	r.jobs.Register(domain.JobSyncAdvisories, func(ctx context.Context) (string, error) {
+		stored, err := svc.SyncAdvisories(ctx)
+		return fmt.Sprintf("stored %d advisories", stored), err
+	})
+	r.jobs.Register(domain.JobSyncCharts, func(ctx context.Context) (string, error) {
+		synced, err := svc.SyncCharts(ctx)
+		return fmt.Sprintf("synced charts of %d airports", synced), err
+	})
+	r.jobs.Register(domain.JobRefreshStatic, func(ctx context.Context) (string, error) {
+		refreshed, err := svc.RefreshStaticData(ctx)
+		return fmt.Sprintf("refreshed static data of %d airports", refreshed), err
+	})
+	r.jobs.Register(domain.JobRefreshNOTAMs, func(ctx context.Context) (string, error) {
+		refreshed, err := svc.RefreshNOTAMs(ctx)
+		return fmt.Sprintf("refreshed NOTAMs of %d airports", refreshed), err
+	})
+
+	// Only the elected replica runs jobs; standbys keep the schedule so they
+	// can take over
+	if cfg.SchedulerLeaderElection {
+		r.elector = NewElector(db, cfg.SchedulerLeaderInterval)
+		if err := r.elector.Campaign(ctx); err != nil {
+			log.Printf("WARN: Scheduler leader election: %v", err)
+		}
+		if !r.elector.Leading() {
+			log.Println("Standing by, another scheduler replica leads")
+		}
+		r.jobs.SetLeader(r.elector.Leading)
+		go r.elector.Run(ctx)
+	}
+
+	r.applyJobs()
+
+	// Pick up edits to the scheduler_jobs table and run requests
+	if cfg.SchedulerRefreshInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.SchedulerRefreshInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-r.stopped:
+					return
+				case <-ticker.C:
+					r.applyJobs()
+					r.runRequested()
+				}
+			}
+		}()
+	}
+
+	r.jobs.Start()
+	log.Printf("Scheduler started with jobs %v", r.jobs.Scheduled())
+	go r.refreshAtAIRACBoundaries(ctx)
+
+	// Fill a fresh environment now rather than at the next scheduled sync
+	if cfg.SyncOnStart {
+		r.jobs.Trigger(domain.JobSyncAll)
+	}
+	return r
+}
+
+// ApplyConfig moves the jobs to the schedules and jitter of a reloaded config.
+func (r *Runner) ApplyConfig(cfg *config.Config) {
+	r.current.Store(cfg)
+	r.jobs.SetJitter(cfg.SchedulerJitter)
+	r.applyJobs()
+}
+
+// Stop stops scheduling jobs, waits for the running ones and gives up the
+// lead.
+func (r *Runner) Stop() {
+	close(r.stopped)
+	<-r.jobs.Stop().Done()
+	if r.elector != nil {
+		r.elector.Resign()
+	}
+}
+
+// applyJobs schedules the jobs of the config, overridden by the
+// scheduler_jobs table.
+func (r *Runner) applyJobs() {
+	defs := FromConfig(r.current.Load())
+	overrides, err := r.svc.GetSchedulerJobs()
+	if err != nil {
+		log.Printf("WARN: Scheduling jobs from config only: %v", err)
+	}
+	r.jobs.Apply(Merge(defs, overrides))
+}
+
+// runRequested runs the jobs requested over the admin API, on the leader only.
+func (r *Runner) runRequested() {
+	if !r.jobs.Leading() {
+		return
+	}
+	names, err := r.svc.ClaimJobRuns()
+	if err != nil {
+		log.Printf("WARN: %v", err)
+		return
+	}
+	for _, name := range names {
+		if !r.jobs.Trigger(name) {
+			log.Printf("WARN: Ignoring run request of unknown job %q", name)
+		}
+	}
+}
+
+// refreshAtAIRACBoundaries runs refresh_static as each AIRAC cycle becomes
+// effective, while AIRACRefresh is on. A cron spec can't express the 28-day
+// period, so it sleeps until the next boundary instead.
+func (r *Runner) refreshAtAIRACBoundaries(ctx context.Context) {
+	for {
+		next := aviation.AIRACCycleAt(time.Now()).Next()
+		timer := time.NewTimer(time.Until(next.Effective))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-r.stopped:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if r.current.Load().AIRACRefresh {
+			log.Printf("AIRAC cycle %s is effective, refreshing static data", next.Ident)
+			r.jobs.Trigger(domain.JobRefreshStatic)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeService counts the syncs and hands out one run request of sync_all.
+type fakeService struct {
+	syncs     atomic.Int32
+	requested atomic.Bool
+}
+
+func (f *fakeService) SyncAllAirports(ctx context.Context) (int, error) {
+	f.syncs.Add(1)
+	return 1, nil
+}
+
+func (f *fakeService) SyncStaleAirports(ctx context.Context) (int, error)   { return 0, nil }
+func (f *fakeService) SendDigests(ctx context.Context) (int, error)         { return 0, nil }
+func (f *fakeService) PruneLogs(ctx context.Context) (int64, error)         { return 0, nil }
+func (f *fakeService) RetryFailedAirports(ctx context.Context) (int, error) { return 0, nil }
+func (f *fakeService) SyncAdvisories(ctx context.Context) (int, error)      { return 0, nil }
+func (f *fakeService) SyncCharts(ctx context.Context) (int, error)          { return 0, nil }
+func (f *fakeService) RefreshStaticData(ctx context.Context) (int, error)   { return 0, nil }
+func (f *fakeService) RefreshNOTAMs(ctx context.Context) (int, error)       { return 0, nil }
+func (f *fakeService) PartitionWeatherHistory(ctx context.Context) ([]string, []string, error) {
+	return nil, nil, nil
+}
+
+func (f *fakeService) GetSchedulerJobs() ([]domain.SchedulerJob, error) {
+	return []domain.SchedulerJob{{Name: domain.JobPruneLogs, Schedule: "0 3 * * *", Enabled: true}}, nil
+}
+
+func (f *fakeService) ClaimJobRuns() ([]string, error) {
+	if f.requested.Swap(true) {
+		return nil, nil
+	}
+	return []string{domain.JobSyncAll}, nil
+}
+
+func TestRunner(t *testing.T) {
+	svc := &fakeService{}
+	r := NewRunner(context.Background(), &config.Config{
+		SyncSchedule:             "0 0,12 * * *",
+		SchedulerRefreshInterval: 5 * time.Millisecond,
+	}, nil, svc)
+
+	assert.Equal(t, map[string]string{
+		domain.JobSyncAll:   "0 0,12 * * *",
+		domain.JobPruneLogs: "0 3 * * *",
+	}, r.jobs.Scheduled(), "The table adds to the config")
+	assert.Eventually(t, func() bool { return svc.syncs.Load() == 1 }, time.Second, time.Millisecond, "Run requests are picked up")
+
+	r.ApplyConfig(&config.Config{SyncSchedule: "@hourly", StaleSyncSchedule: "*/30 * * * *"})
+	assert.Equal(t, map[string]string{
+		domain.JobSyncAll:   "@hourly",
+		domain.JobSyncStale: "*/30 * * * *",
+		domain.JobPruneLogs: "0 3 * * *",
+	}, r.jobs.Scheduled())
+	r.Stop()
+	assert.Equal(t, int32(1), svc.syncs.Load())
+}
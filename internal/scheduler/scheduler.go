@@ -0,0 +1,262 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Func runs a job and summarizes what it did for the log.
+type Func func(ctx context.Context) (string, error)
+
+type job struct {
+	fn Func
+
+	// Held while the job runs, so runs never overlap
+	running atomic.Bool
+}
+
+type entry struct {
+	id   cron.EntryID
+	spec string
+}
+
+// Scheduler runs the registered jobs on the cron specs of their definitions.
+// Definitions can be applied again at any time to move, pause or resume jobs
+// without a restart.
+type Scheduler struct {
+	ctx  context.Context
+	cron *cron.Cron
+
+	mu      sync.Mutex
+	jobs    map[string]*job
+	entries map[string]entry
+
+	// Runs started by Trigger
+	triggered sync.WaitGroup
+
+	// Upper bound of the random delay of scheduled runs, in nanoseconds
+	jitter atomic.Int64
+
+	// Whether this replica may run jobs, nil when it always may
+	leading func() bool
+}
+
+// New returns a Scheduler whose jobs run with ctx, so cancelling it stops a
+// running job early.
+func New(ctx context.Context) *Scheduler {
+	return &Scheduler{
+		ctx:     ctx,
+		cron:    cron.New(),
+		jobs:    make(map[string]*job),
+		entries: make(map[string]entry),
+	}
+}
+
+// Register makes a job known under name. It runs only once a definition
+// enables it.
+func (s *Scheduler) Register(name string, fn Func) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[name] = &job{fn: fn}
+}
+
+// SetJitter delays every scheduled run by a random duration up to jitter, so
+// environments on the same schedule spread their load; 0 runs jobs on time.
+// Triggered runs start at once.
+func (s *Scheduler) SetJitter(jitter time.Duration) {
+	s.jitter.Store(int64(jitter))
+}
+
+// SetLeader runs jobs only while leading reports true, so of several replicas
+// only the elected one works. Call it before Start.
+func (s *Scheduler) SetLeader(leading func() bool) {
+	s.leading = leading
+}
+
+// Leading reports whether this replica may run jobs.
+func (s *Scheduler) Leading() bool {
+	return s.leading == nil || s.leading()
+}
+
+// Trigger runs a registered job now, in the background, whether or not it is
+// scheduled. It returns false for an unknown job.
+func (s *Scheduler) Trigger(name string) bool {
+	s.mu.Lock()
+	j, ok := s.jobs[name]
+	s.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	s.triggered.Add(1)
+	go func() {
+		defer s.triggered.Done()
+		s.run(name, j)()
+	}()
+	return true
+}
+
+// Apply schedules every registered job on the spec of its definition and
+// unschedules those left without an enabled one. A definition with an invalid
+// spec or of an unknown job is logged and skipped, keeping the job on its
+// previous spec.
+func (s *Scheduler) Apply(defs []domain.SchedulerJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wanted := make(map[string]string, len(defs))
+	for _, def := range defs {
+		if _, ok := s.jobs[def.Name]; !ok {
+			log.Printf("WARN: Ignoring unknown scheduler job %q", def.Name)
+			continue
+		}
+		if def.Enabled && def.Schedule != "" {
+			wanted[def.Name] = def.Schedule
+		}
+	}
+
+	for name, j := range s.jobs {
+		current, scheduled := s.entries[name]
+		spec, ok := wanted[name]
+		switch {
+		case !ok && scheduled:
+			s.cron.Remove(current.id)
+			delete(s.entries, name)
+			log.Printf("Unscheduled job %s", name)
+		case ok && (!scheduled || current.spec != spec):
+			id, err := s.cron.AddFunc(spec, s.delayed(s.run(name, j)))
+			if err != nil {
+				log.Printf("WARN: Keeping job %s on its previous schedule: %v", name, err)
+				continue
+			}
+			if scheduled {
+				s.cron.Remove(current.id)
+			}
+			s.entries[name] = entry{id: id, spec: spec}
+			log.Printf("Scheduled job %s on %q", name, spec)
+		}
+	}
+}
+
+// Scheduled returns the spec of every scheduled job by name.
+func (s *Scheduler) Scheduled() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	specs := make(map[string]string, len(s.entries))
+	for name, e := range s.entries {
+		specs[name] = e.spec
+	}
+	return specs
+}
+
+// Start runs the scheduled jobs in the background.
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop stops scheduling jobs and returns a context done once the running ones
+// finish.
+func (s *Scheduler) Stop() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := s.cron.Stop()
+	go func() {
+		<-stopped.Done()
+		s.triggered.Wait()
+		cancel()
+	}()
+	return ctx
+}
+
+// delayed returns run delayed by a random jitter, or run itself without one.
+func (s *Scheduler) delayed(run func()) func() {
+	return func() {
+		if jitter := s.jitter.Load(); jitter > 0 {
+			select {
+			case <-time.After(time.Duration(rand.Int64N(jitter))):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+		run()
+	}
+}
+
+// run returns a func running j unless a previous run of it is still going,
+// in which case this run is skipped.
+func (s *Scheduler) run(name string, j *job) func() {
+	return func() {
+		if !s.Leading() {
+			log.Printf("DEBUG: Skipping job %s, another replica leads", name)
+			return
+		}
+		if !j.running.CompareAndSwap(false, true) {
+			log.Printf("WARN: Skipping job %s, its previous run is still going", name)
+			return
+		}
+		defer j.running.Store(false)
+
+		log.Printf("Starting job %s...", name)
+		summary, err := j.fn(s.ctx)
+		if err != nil {
+			log.Printf("Error in job %s: %v", name, err)
+			return
+		}
+		log.Printf("Job %s completed, %s", name, summary)
+	}
+}
+
+// FromConfig defines the jobs by the schedules of cfg; an empty schedule
+// leaves a job disabled.
+func FromConfig(cfg *config.Config) []domain.SchedulerJob {
+	specs := []struct{ name, spec string }{
+		{domain.JobSyncAll, cfg.SyncSchedule},
+		{domain.JobSyncStale, cfg.StaleSyncSchedule},
+		{domain.JobSendDigests, cfg.DigestSchedule},
+		{domain.JobPruneLogs, cfg.PruneSchedule},
+		{domain.JobRetryFailed, cfg.RetryFailedSchedule},
+		{domain.JobPartitionHistory, cfg.HistoryPartitionSchedule},
+		{domain.JobSyncAdvisories, cfg.AdvisorySchedule},
+		{domain.JobSyncCharts, cfg.ChartSchedule},
+		{domain.JobRefreshStatic, cfg.StaticRefreshSchedule},
+		{domain.JobRefreshNOTAMs, cfg.NOTAMSchedule},
+	}
+
+	defs := make([]domain.SchedulerJob, 0, len(specs))
+	for _, s := range specs {
+		defs = append(defs, domain.SchedulerJob{Name: s.name, Schedule: s.spec, Enabled: s.spec != ""})
+	}
+	return defs
+}
+
+// Merge replaces the definitions of base with those of overrides of the same
+// name, keeping the schedule of base when an override has none, and appends
+// the rest.
+func Merge(base, overrides []domain.SchedulerJob) []domain.SchedulerJob {
+	merged := append([]domain.SchedulerJob(nil), base...)
+	for _, o := range overrides {
+		found := false
+		for i := range merged {
+			if merged[i].Name == o.Name {
+				if o.Schedule == "" {
+					o.Schedule = merged[i].Schedule
+				}
+				merged[i], found = o, true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, o)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func noop(ctx context.Context) (string, error) { return "", nil }
+
+func TestApply(t *testing.T) {
+	s := New(context.Background())
+	s.Register(domain.JobSyncAll, noop)
+	s.Register(domain.JobPruneLogs, noop)
+
+	s.Apply([]domain.SchedulerJob{
+		{Name: domain.JobSyncAll, Schedule: "0 */12 * * *", Enabled: true},
+		{Name: domain.JobPruneLogs, Schedule: "0 3 * * *", Enabled: false},
+		{Name: "refresh_pireps", Schedule: "@hourly", Enabled: true},
+	})
+	assert.Equal(t, map[string]string{domain.JobSyncAll: "0 */12 * * *"}, s.Scheduled(), "Disabled and unknown jobs are not scheduled")
+
+	s.Apply([]domain.SchedulerJob{
+		{Name: domain.JobSyncAll, Schedule: "hourly", Enabled: true},
+		{Name: domain.JobPruneLogs, Schedule: "0 3 * * *", Enabled: true},
+	})
+	assert.Equal(t, map[string]string{
+		domain.JobSyncAll:   "0 */12 * * *",
+		domain.JobPruneLogs: "0 3 * * *",
+	}, s.Scheduled(), "An invalid spec keeps the previous one")
+	assert.Len(t, s.cron.Entries(), 2)
+
+	s.Apply([]domain.SchedulerJob{{Name: domain.JobSyncAll, Schedule: "@hourly", Enabled: true}})
+	assert.Equal(t, map[string]string{domain.JobSyncAll: "@hourly"}, s.Scheduled())
+	assert.Len(t, s.cron.Entries(), 1, "Rescheduling replaces the entry")
+}
+
+func TestFromConfigAndMerge(t *testing.T) {
+	defs := FromConfig(&config.Config{SyncSchedule: "0 */12 * * *", PruneSchedule: "0 3 * * *"})
+	assert.Equal(t, []domain.SchedulerJob{
+		{Name: domain.JobSyncAll, Schedule: "0 */12 * * *", Enabled: true},
+		{Name: domain.JobSyncStale},
+		{Name: domain.JobSendDigests},
+		{Name: domain.JobPruneLogs, Schedule: "0 3 * * *", Enabled: true},
+		{Name: domain.JobRetryFailed},
+		{Name: domain.JobPartitionHistory},
+		{Name: domain.JobSyncAdvisories},
+		{Name: domain.JobSyncCharts},
+		{Name: domain.JobRefreshStatic},
+		{Name: domain.JobRefreshNOTAMs},
+	}, defs)
+
+	merged := Merge(defs, []domain.SchedulerJob{
+		{Name: domain.JobSyncStale, Schedule: "*/30 * * * *", Enabled: true},
+		{Name: domain.JobSyncAll, Enabled: false},
+		{Name: "custom", Schedule: "@daily", Enabled: true},
+	})
+	assert.Equal(t, []domain.SchedulerJob{
+		{Name: domain.JobSyncAll, Schedule: "0 */12 * * *", Enabled: false},
+		{Name: domain.JobSyncStale, Schedule: "*/30 * * * *", Enabled: true},
+		{Name: domain.JobSendDigests},
+		{Name: domain.JobPruneLogs, Schedule: "0 3 * * *", Enabled: true},
+		{Name: domain.JobRetryFailed},
+		{Name: domain.JobPartitionHistory},
+		{Name: domain.JobSyncAdvisories},
+		{Name: domain.JobSyncCharts},
+		{Name: domain.JobRefreshStatic},
+		{Name: domain.JobRefreshNOTAMs},
+		{Name: "custom", Schedule: "@daily", Enabled: true},
+	}, merged)
+	assert.Len(t, defs, 10, "Merge leaves base alone")
+	assert.True(t, defs[0].Enabled)
+}
+
+func TestTriggerSkipsOverlappingRuns(t *testing.T) {
+	release := make(chan struct{})
+	var runs atomic.Int32
+	s := New(context.Background())
+	s.Register(domain.JobSyncAll, func(ctx context.Context) (string, error) {
+		runs.Add(1)
+		<-release
+		return "", nil
+	})
+
+	assert.False(t, s.Trigger("unknown"))
+	assert.True(t, s.Trigger(domain.JobSyncAll))
+	assert.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, time.Millisecond)
+
+	s.run(domain.JobSyncAll, s.jobs[domain.JobSyncAll])()
+	assert.Equal(t, int32(1), runs.Load(), "A run overlapping the previous one is skipped")
+	close(release)
+	<-s.Stop().Done()
+
+	assert.True(t, s.Trigger(domain.JobSyncAll))
+	<-s.Stop().Done()
+	assert.Equal(t, int32(2), runs.Load())
+}
+
+func TestJitterDelaysScheduledRuns(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := New(ctx)
+	ran := false
+	run := s.delayed(func() { ran = true })
+
+	s.SetJitter(time.Hour)
+	cancel()
+	run()
+	assert.False(t, ran, "Shutdown during the delay skips the run")
+
+	s.SetJitter(0)
+	run()
+	assert.True(t, ran)
+}
@@ -0,0 +1,168 @@
+// Package secrets reads credentials from files, such as Docker and Kubernetes
+// secrets, or from HashiCorp Vault, and re-reads them so rotated values are
+// picked up without a restart.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Source reads secret values by name.
+type Source interface {
+	Read(ctx context.Context) (map[string]string, error)
+}
+
+// Files reads each secret from its own file, by name. Surrounding whitespace,
+// such as the trailing newline of most secret files, is trimmed.
+type Files map[string]string
+
+func (f Files) Read(ctx context.Context) (map[string]string, error) {
+	values := make(map[string]string, len(f))
+	for name, path := range f {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		values[name] = strings.TrimSpace(string(data))
+	}
+	return values, nil
+}
+
+// Vault reads the secrets stored under Path of a Vault key/value engine,
+// whose keys are the secret names. Path is the API path, such as
+// "secret/data/aviation-weather" for version 2 of the engine.
+type Vault struct {
+	Addr   string
+	Token  string
+	Path   string
+	Client *http.Client
+}
+
+func (v *Vault) Read(ctx context.Context) (map[string]string, error) {
+	url := strings.TrimSuffix(v.Addr, "/") + "/v1/" + strings.TrimPrefix(v.Path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+
+	client := v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Vault returned %s for %s", resp.Status, v.Path)
+	}
+
+	var body struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode Vault response: %w", err)
+	}
+
+	// Version 2 nests the values next to their metadata
+	data := body.Data
+	if nested, ok := data["data"].(map[string]any); ok && data["metadata"] != nil {
+		data = nested
+	}
+	values := make(map[string]string, len(data))
+	for name, value := range data {
+		if s, ok := value.(string); ok {
+			values[name] = s
+		} else {
+			values[name] = fmt.Sprint(value)
+		}
+	}
+	return values, nil
+}
+
+// Store holds the latest values of its sources, later sources taking
+// precedence, and tells subscribers when a value rotates.
+type Store struct {
+	sources []Source
+
+	mu          sync.RWMutex
+	values      map[string]string
+	subscribers map[string][]func(string)
+}
+
+func NewStore(sources ...Source) *Store {
+	return &Store{sources: sources, values: map[string]string{}, subscribers: map[string][]func(string){}}
+}
+
+// Get returns the value of name, empty when no source has it.
+func (s *Store) Get(name string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.values[name]
+}
+
+// OnChange calls fn with the new value whenever name rotates.
+func (s *Store) OnChange(name string, fn func(value string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers[name] = append(s.subscribers[name], fn)
+}
+
+// Refresh re-reads every source. When one fails the previous values are kept.
+func (s *Store) Refresh(ctx context.Context) error {
+	values := map[string]string{}
+	for _, source := range s.sources {
+		read, err := source.Read(ctx)
+		if err != nil {
+			return err
+		}
+		maps.Copy(values, read)
+	}
+
+	s.mu.Lock()
+	previous := s.values
+	s.values = values
+	var notify []func()
+	for name, value := range values {
+		if old, ok := previous[name]; ok && old != value {
+			log.Printf("Secret %s rotated", name)
+			for _, fn := range s.subscribers[name] {
+				notify = append(notify, func() { fn(value) })
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	for _, fn := range notify {
+		fn()
+	}
+	return nil
+}
+
+// Watch refreshes the store every interval until ctx is done. Failed reads
+// are logged and retried at the next tick.
+func (s *Store) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				log.Printf("WARN: Keeping current secrets: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db_password")
+	assert.NoError(t, os.WriteFile(path, []byte("s3cret\n"), 0o600))
+
+	values, err := Files{"DB_PASSWORD": path}.Read(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"DB_PASSWORD": "s3cret"}, values, "Trailing newline is trimmed")
+
+	_, err = Files{"DB_PASSWORD": path + ".missing"}.Read(context.Background())
+	assert.ErrorContains(t, err, "failed to read DB_PASSWORD")
+}
+
+func TestVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/aviation-weather":
+			w.Write([]byte(`{"data":{"data":{"WEATHER_API_KEY":"key","DB_PASSWORD":"postgres"},"metadata":{"version":3}}}`))
+		case "/v1/kv/aviation-weather":
+			w.Write([]byte(`{"data":{"WEATHER_API_KEY":"key"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	values, err := (&Vault{Addr: server.URL, Token: "token", Path: "secret/data/aviation-weather"}).Read(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"WEATHER_API_KEY": "key", "DB_PASSWORD": "postgres"}, values, "Version 2 values are unwrapped")
+
+	values, err = (&Vault{Addr: server.URL, Token: "token", Path: "kv/aviation-weather"}).Read(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"WEATHER_API_KEY": "key"}, values)
+
+	_, err = (&Vault{Addr: server.URL, Token: "wrong", Path: "kv/aviation-weather"}).Read(context.Background())
+	assert.EqualError(t, err, "Vault returned 403 Forbidden for kv/aviation-weather")
+}
+
+type staticSource struct {
+	values map[string]string
+	err    error
+}
+
+func (s *staticSource) Read(ctx context.Context) (map[string]string, error) {
+	return s.values, s.err
+}
+
+func TestStore(t *testing.T) {
+	vault := &staticSource{values: map[string]string{"WEATHER_API_KEY": "from-vault", "DB_PASSWORD": "old"}}
+	files := &staticSource{values: map[string]string{"WEATHER_API_KEY": "from-file"}}
+	store := NewStore(vault, files)
+
+	var rotated []string
+	store.OnChange("DB_PASSWORD", func(value string) { rotated = append(rotated, value) })
+
+	assert.NoError(t, store.Refresh(context.Background()))
+	assert.Equal(t, "from-file", store.Get("WEATHER_API_KEY"), "Later sources take precedence")
+	assert.Equal(t, "old", store.Get("DB_PASSWORD"))
+	assert.Empty(t, rotated, "The first read is not a rotation")
+
+	vault.values = map[string]string{"WEATHER_API_KEY": "from-vault", "DB_PASSWORD": "new"}
+	assert.NoError(t, store.Refresh(context.Background()))
+	assert.Equal(t, []string{"new"}, rotated)
+
+	vault.err = assert.AnError
+	assert.Error(t, store.Refresh(context.Background()))
+	assert.Equal(t, "new", store.Get("DB_PASSWORD"), "Values are kept when a source fails")
+}
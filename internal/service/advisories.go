@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// SyncAdvisories replaces the stored SIGMETs and AIRMETs with those in effect
+// and returns how many there are.
+func (s *Service) SyncAdvisories(ctx context.Context) (int, error) {
+	advisories, err := s.FetchAdvisories(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to fetch advisories: %w", domain.ErrExternalAPI, err)
+	}
+	if err := s.repo.ReplaceAdvisories(advisories); err != nil {
+		return 0, fmt.Errorf("failed to save advisories: %w", err)
+	}
+	return len(advisories), nil
+}
+
+// GetAdvisories returns the stored advisories that have not expired.
+func (s *Service) GetAdvisories() ([]domain.Advisory, error) {
+	advisories, err := s.repo.GetAdvisories(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get advisories: %w", err)
+	}
+	return advisories, nil
+}
+
+// GetAirportAdvisories returns the stored advisories that have not expired
+// and whose area contains the airport.
+func (s *Service) GetAirportAdvisories(faa string) ([]domain.Advisory, error) {
+	airport, err := s.GetAirportByFAA(faa)
+	if err != nil {
+		return nil, err
+	}
+
+	lat, lon, ok := airport.Coordinates()
+	if !ok {
+		return nil, fmt.Errorf("%w: no coordinates for %s", domain.ErrNoData, faa)
+	}
+	advisories, err := s.repo.GetAdvisoriesAt(aviation.Point{Lat: lat, Lon: lon}, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get advisories for %s: %w", faa, err)
+	}
+	return advisories, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSyncAdvisories(t *testing.T) {
+	fetched := []domain.Advisory{{Type: domain.AdvisoryAIRMET, Hazard: "ICE"}}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ReplaceAdvisories", fetched).Return(nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchAdvisories = func(ctx context.Context) ([]domain.Advisory, error) {
+		return fetched, nil
+	}
+
+	stored, err := s.SyncAdvisories(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stored)
+
+	s.FetchAdvisories = func(ctx context.Context) ([]domain.Advisory, error) {
+		return nil, errors.New("timeout")
+	}
+	_, err = s.SyncAdvisories(context.Background())
+	assert.ErrorIs(t, err, domain.ErrExternalAPI)
+	mockRepo.AssertNumberOfCalls(t, "ReplaceAdvisories", 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAirportAdvisories(t *testing.T) {
+	ict := sampleAirport
+	ict.Faa, ict.Latitude, ict.Longitude = "ICT", deg(37.6499), deg(-97.4331)
+	noCoords := sampleAirport
+	noCoords.Faa, noCoords.Latitude, noCoords.Longitude = "NC", nil, nil
+	sigmet := domain.Advisory{ID: 7, Type: domain.AdvisorySIGMET, Hazard: "CONVECTIVE"}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "ICT").Return(&ict, nil)
+	mockRepo.On("GetAirportByFAA", "NC").Return(&noCoords, nil)
+	mockRepo.On("GetAdvisoriesAt", aviation.Point{Lat: 37.6499, Lon: -97.4331}, mock.Anything).Return([]domain.Advisory{sigmet}, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	advisories, err := s.GetAirportAdvisories("ICT")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Advisory{sigmet}, advisories)
+
+	_, err = s.GetAirportAdvisories("NC")
+	assert.EqualError(t, err, fmt.Errorf("%w: no coordinates for NC", domain.ErrNoData).Error())
+	mockRepo.AssertExpectations(t)
+}
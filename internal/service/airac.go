@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// GetAIRAC reports the AIRAC cycle in effect and the next one.
+func (s *Service) GetAIRAC() domain.AIRACStatus {
+	now := time.Now()
+	current := aviation.AIRACCycleAt(now)
+	next := current.Next()
+	return domain.AIRACStatus{
+		Current:       airacCycle(current),
+		Next:          airacCycle(next),
+		DaysUntilNext: int(math.Ceil(next.Effective.Sub(now).Hours() / 24)),
+	}
+}
+
+func airacCycle(c aviation.AIRACCycle) domain.AIRACCycle {
+	return domain.AIRACCycle{Ident: c.Ident, Effective: c.Effective, Expires: c.Next().Effective}
+}
+
+// currentCycle is the ident of the AIRAC cycle in effect, which synced static
+// data is stamped with.
+func currentCycle() string {
+	return aviation.AIRACCycleAt(time.Now()).Ident
+}
+
+// RefreshStaticData syncs again the runways and frequencies of every airport
+// from OurAirports, stamped with the current AIRAC cycle, then the charts not
+// yet synced for it. Each dataset is downloaded once for all airports, and
+// airports OurAirports doesn't list keep what they have. It returns how many
+// airports got runways or frequencies.
+func (s *Service) RefreshStaticData(ctx context.Context) (int, error) {
+	runways, err := s.FetchAllRunways(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to fetch runways: %w", domain.ErrExternalAPI, err)
+	}
+	frequencies, err := s.FetchAllFrequencies(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to fetch frequencies: %w", domain.ErrExternalAPI, err)
+	}
+
+	var airports []domain.Airport
+	if err := s.repo.ForEachAirport(ctx, func(a domain.Airport) error {
+		airports = append(airports, a)
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("failed to list airports: %w", err)
+	}
+
+	cycle := currentCycle()
+	refreshed := 0
+	for _, a := range airports {
+		if err := ctx.Err(); err != nil {
+			return refreshed, err
+		}
+		rwys := byIdent(runways, a)
+		for i := range rwys {
+			rwys[i].Cycle = cycle
+		}
+		freqs := byIdent(frequencies, a)
+		for i := range freqs {
+			freqs[i].Cycle = cycle
+		}
+		if len(rwys) > 0 {
+			if err := s.repo.ReplaceRunways(a.Faa, rwys); err != nil {
+				return refreshed, fmt.Errorf("failed to save runways for %s: %w", a.Faa, err)
+			}
+		}
+		if len(freqs) > 0 {
+			if err := s.repo.ReplaceFrequencies(a.Faa, freqs); err != nil {
+				return refreshed, fmt.Errorf("failed to save frequencies for %s: %w", a.Faa, err)
+			}
+		}
+		if len(rwys) > 0 || len(freqs) > 0 {
+			refreshed++
+		}
+	}
+
+	if _, err := s.SyncCharts(ctx); err != nil {
+		return refreshed, err
+	}
+	return refreshed, nil
+}
+
+// byIdent picks the entries of an airport from a dataset keyed by OurAirports
+// ident, the ICAO code when the airport has one and the FAA code otherwise.
+func byIdent[T any](dataset map[string][]T, a domain.Airport) []T {
+	if list, ok := dataset[strings.ToUpper(a.Icao)]; ok && a.Icao != "" {
+		return list
+	}
+	return dataset[strings.ToUpper(a.Faa)]
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAIRAC(t *testing.T) {
+	s := NewService(&mocks.RepositoryMock{}, &config.Config{}).(*Service)
+	now := time.Now()
+
+	status := s.GetAIRAC()
+	assert.Equal(t, aviation.AIRACCycleAt(now).Ident, status.Current.Ident)
+	assert.False(t, status.Current.Effective.After(now))
+	assert.True(t, status.Current.Expires.After(now))
+	assert.Equal(t, status.Current.Expires, status.Next.Effective)
+	assert.Equal(t, 28*24*time.Hour, status.Next.Expires.Sub(status.Next.Effective))
+	assert.GreaterOrEqual(t, status.DaysUntilNext, 1)
+	assert.LessOrEqual(t, status.DaysUntilNext, 28)
+}
+
+func TestRefreshStaticData(t *testing.T) {
+	cycle := aviation.AIRACCycleAt(time.Now()).Ident
+	airports := []domain.Airport{
+		{Faa: "ATL", Icao: "KATL"},
+		{Faa: "4GA1"},
+		{Faa: "ZZZ", Icao: "KZZZ"},
+	}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ForEachAirport", mock.Anything).Return(airports, nil)
+	mockRepo.On("ReplaceRunways", "ATL", []domain.Runway{{Ident: "08L", HeadingDeg: 86, Cycle: cycle}}).Return(nil)
+	mockRepo.On("ReplaceFrequencies", "ATL", []domain.Frequency{{Type: "TWR", FrequencyMHz: 119.1, Cycle: cycle}}).Return(nil)
+	mockRepo.On("ReplaceFrequencies", "4GA1", []domain.Frequency{{Type: "UNIC", FrequencyMHz: 123.0, Cycle: cycle}}).Return(nil)
+	mockRepo.On("GetFAAsNeedingCharts", cycle).Return([]string{}, nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchAllRunways = func(context.Context) (map[string][]domain.Runway, error) {
+		return map[string][]domain.Runway{"KATL": {{Ident: "08L", HeadingDeg: 86}}}, nil
+	}
+	s.FetchAllFrequencies = func(context.Context) (map[string][]domain.Frequency, error) {
+		return map[string][]domain.Frequency{
+			"KATL": {{Type: "TWR", FrequencyMHz: 119.1}},
+			"4GA1": {{Type: "UNIC", FrequencyMHz: 123.0}},
+		}, nil
+	}
+
+	refreshed, err := s.RefreshStaticData(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, refreshed, "Airports OurAirports doesn't list are left alone")
+	mockRepo.AssertExpectations(t)
+
+	s.FetchAllRunways = func(context.Context) (map[string][]domain.Runway, error) { return nil, errors.New("timeout") }
+	_, err = s.RefreshStaticData(context.Background())
+	assert.ErrorIs(t, err, domain.ErrExternalAPI)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"aviation-weather/internal/alert"
+	"aviation-weather/internal/domain"
+)
+
+// CreateAlertRule stores a new alert rule, evaluated from the next sync on.
+func (s *Service) CreateAlertRule(rule *domain.AlertRule) error {
+	if err := s.repo.CreateAlertRule(rule); err != nil {
+		return fmt.Errorf("failed to create alert rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteAlertRule removes an alert rule of a tenant and the alerts it raised.
+func (s *Service) DeleteAlertRule(tenantID, id int64) error {
+	if err := s.repo.DeleteAlertRule(tenantID, id); err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) GetAlertRules(tenantID int64) ([]domain.AlertRule, error) {
+	rules, err := s.repo.GetAlertRules(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetAlerts returns up to limit alerts of a tenant raised since the given
+// time, newest first, optionally for one airport only.
+func (s *Service) GetAlerts(tenantID int64, faa string, since time.Time, limit int) ([]domain.Alert, error) {
+	alerts, err := s.repo.GetAlerts(tenantID, faa, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// checkAlerts evaluates the rules of every tenant applying to a freshly synced
+// airport and records and announces the alerts raised, over the webhooks of
+// the rule's tenant and the channels of the rule. Failures are only logged.
+func (s *Service) checkAlerts(a *domain.Airport, obs domain.Observation) {
+	rules, err := s.repo.GetAlertRulesFor(a.Faa, a.StateCode)
+	if err != nil {
+		log.Printf("WARN: Failed to get alert rules for %s: %v", a.Faa, err)
+		return
+	}
+
+	alerts := alert.Evaluate(rules, a.Faa, obs)
+	if len(alerts) == 0 {
+		return
+	}
+	ruleByID := make(map[int64]domain.AlertRule, len(rules))
+	for _, rule := range rules {
+		ruleByID[rule.ID] = rule
+	}
+	for i := range alerts {
+		alerts[i].TenantID = ruleByID[alerts[i].RuleID].TenantID
+	}
+	if err := s.repo.SaveAlerts(alerts); err != nil {
+		log.Printf("WARN: Failed to save alerts for %s: %v", a.Faa, err)
+		return
+	}
+	for _, al := range alerts {
+		log.Printf("ALERT: %s", al.Message)
+		s.notify(al.TenantID, domain.EventAlertFired, al)
+		if rule := ruleByID[al.RuleID]; len(rule.Notify) > 0 {
+			s.sendAlertNotifications(rule, *a, al, obs)
+		}
+	}
+}
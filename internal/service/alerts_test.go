@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+	"aviation-weather/internal/notify"
+	"aviation-weather/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSyncRaisesAlerts(t *testing.T) {
+	observedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	rules := []domain.AlertRule{
+		{ID: 1, Name: "Strong wind", StateCode: "CA", Metric: domain.MetricWindSpeed, Operator: domain.OpGreaterThan, Threshold: 25, TenantID: 2},
+		{ID: 2, Name: "Low visibility", Metric: domain.MetricVisibility, Operator: domain.OpLessThan, Threshold: 3},
+	}
+
+	airport := sampleAirport
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&airport, nil)
+	mockRepo.On("SaveObservation", "TST", mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", "TST", "CA").Return(rules, nil)
+	mockRepo.On("SaveAlerts", []domain.Alert{{
+		RuleID:     1,
+		Faa:        "TST",
+		Metric:     domain.MetricWindSpeed,
+		Value:      "30",
+		Message:    "TST: wind_speed_kt 30 > 25 (Strong wind)",
+		ObservedAt: observedAt,
+		TenantID:   2,
+	}}).Return(errors.New("db down"))
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Windy", WindSpeedKt: 30, VisibilitySM: 10, ObservedAt: observedAt}, nil
+	}
+
+	_, err := s.SyncAirportByFAA(context.Background(), "TST")
+	assert.NoError(t, err, "Saving alerts is best effort")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAlertRules(t *testing.T) {
+	rule := domain.AlertRule{Name: "Fog", Metric: domain.MetricVisibility, Operator: domain.OpLessThan, Threshold: 1}
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("CreateAlertRule", &rule).Return(nil)
+	mockRepo.On("GetAlertRules", int64(2)).Return([]domain.AlertRule{rule}, nil)
+	mockRepo.On("DeleteAlertRule", int64(2), int64(9)).Return(domain.ErrRuleNotFound)
+	mockRepo.On("GetAlerts", int64(2), "DFW", since, 10).Return([]domain.Alert{}, assert.AnError)
+	s := NewService(mockRepo, &config.Config{})
+
+	assert.NoError(t, s.CreateAlertRule(&rule))
+	rules, err := s.GetAlertRules(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AlertRule{rule}, rules)
+	assert.ErrorIs(t, s.DeleteAlertRule(2, 9), domain.ErrRuleNotFound)
+	_, err = s.GetAlerts(2, "DFW", since, 10)
+	assert.EqualError(t, err, "failed to get alerts: "+assert.AnError.Error())
+	mockRepo.AssertExpectations(t)
+}
+
+// fakeNotifier records the messages it is asked to send.
+type fakeNotifier struct {
+	mu   sync.Mutex
+	sent map[string][]notify.Message
+	err  error
+}
+
+func (f *fakeNotifier) Send(ctx context.Context, target string, msg notify.Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.sent == nil {
+		f.sent = map[string][]notify.Message{}
+	}
+	f.sent[target] = append(f.sent[target], msg)
+	return f.err
+}
+
+func TestSyncSendsAlertNotifications(t *testing.T) {
+	observedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	rules := []domain.AlertRule{{
+		ID: 1, Name: "Strong wind", Metric: domain.MetricWindSpeed, Operator: domain.OpGreaterThan, Threshold: 25,
+		Notify: []domain.NotifyTarget{
+			{Channel: domain.ChannelSlack, Target: "https://hooks.slack.com/services/x"},
+			{Channel: domain.ChannelEmail, Target: "ops@example.com"},
+		},
+	}}
+
+	airport := sampleAirport
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&airport, nil)
+	mockRepo.On("SaveObservation", "TST", mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", "TST", "CA").Return(rules, nil)
+	mockRepo.On("SaveAlerts", mock.Anything).Return(nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	slack := &fakeNotifier{}
+	s.notifiers = map[string]notify.Notifier{domain.ChannelSlack: slack}
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Windy", WindSpeedKt: 30, RawMETAR: "KTST 011200Z 27030KT", ObservedAt: observedAt}, nil
+	}
+
+	_, err := s.SyncAirportByFAA(context.Background(), "TST")
+	assert.NoError(t, err)
+	s.notifications.Wait()
+
+	sent := slack.sent["https://hooks.slack.com/services/x"]
+	if assert.Len(t, sent, 1, "Email is skipped without an SMTP host") {
+		assert.Equal(t, "[Strong wind] TST wind_speed_kt 30", sent[0].Subject)
+		assert.Contains(t, sent[0].Text, "Condition: Windy")
+		assert.Contains(t, sent[0].Text, "METAR: KTST 011200Z 27030KT")
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSendTestNotification(t *testing.T) {
+	s := NewService(&mocks.RepositoryMock{}, &config.Config{}).(*Service)
+	slack := &fakeNotifier{}
+	s.notifiers = map[string]notify.Notifier{domain.ChannelSlack: slack}
+
+	target := domain.NotifyTarget{Channel: domain.ChannelSlack, Target: "https://hooks.slack.com/services/x"}
+	assert.NoError(t, s.SendTestNotification(context.Background(), target))
+	assert.Len(t, slack.sent[target.Target], 1)
+
+	slack.err = assert.AnError
+	assert.ErrorIs(t, s.SendTestNotification(context.Background(), target), domain.ErrExternalAPI)
+
+	err := s.SendTestNotification(context.Background(), domain.NotifyTarget{Channel: domain.ChannelEmail, Target: "ops@example.com"})
+	assert.ErrorIs(t, err, domain.ErrChannelDisabled)
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"fmt"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// Defaults of the alternates search when the caller leaves them out.
+const (
+	DefaultAlternateRadiusNM = 100
+	DefaultAlternateCategory = aviation.CategoryVFR
+)
+
+// GetAlternates lists the airports within radiusNM of one airport whose latest
+// stored weather is minCategory or better, nearest first. Airports without
+// classifiable weather are left out.
+func (s *Service) GetAlternates(faa string, radiusNM float64, minCategory string) ([]domain.Alternate, error) {
+	if radiusNM <= 0 {
+		radiusNM = DefaultAlternateRadiusNM
+	}
+	if minCategory == "" {
+		minCategory = DefaultAlternateCategory
+	}
+
+	airport, err := s.GetAirportByFAA(faa)
+	if err != nil {
+		return nil, err
+	}
+	lat, lon, ok := airport.Coordinates()
+	if !ok {
+		return nil, fmt.Errorf("%w: no coordinates for %s", domain.ErrNoData, faa)
+	}
+	origin := aviation.Point{Lat: lat, Lon: lon}
+
+	nearby, err := s.repo.GetNearestAirports(origin, radiusNM, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get airports near %s: %w", faa, err)
+	}
+
+	var faas []string
+	for _, n := range nearby {
+		if n.Faa != airport.Faa {
+			faas = append(faas, n.Faa)
+		}
+	}
+	alternates := []domain.Alternate{}
+	if len(faas) == 0 {
+		return alternates, nil
+	}
+
+	observations, err := s.repo.GetObservations(faas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weather near %s: %w", faa, err)
+	}
+
+	for _, n := range nearby {
+		obs, ok := observations[n.Faa]
+		if !ok || n.Faa == airport.Faa {
+			continue
+		}
+		category := aviation.ObservationCategory(obs)
+		if !aviation.CategoryAtLeast(category, minCategory) {
+			continue
+		}
+		lat, lon, _ := n.Coordinates()
+		alternates = append(alternates, domain.Alternate{
+			Faa:            n.Faa,
+			Icao:           n.Icao,
+			FacilityName:   n.FacilityName,
+			DistanceNM:     round1(n.DistanceNM),
+			BearingDeg:     round1(aviation.InitialBearing(origin, aviation.Point{Lat: lat, Lon: lon})),
+			Condition:      obs.Condition,
+			FlightCategory: category,
+			ObservedAt:     obs.ObservedAt,
+		})
+	}
+
+	return alternates, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAlternates(t *testing.T) {
+	jfk := sampleAirport
+	jfk.Faa, jfk.Latitude, jfk.Longitude = "JFK", deg(40.6398), deg(-73.7789)
+	near := func(faa string, lat, lon, distance float64) domain.NearbyAirport {
+		a := sampleAirport
+		a.Faa, a.Latitude, a.Longitude = faa, deg(lat), deg(lon)
+		return domain.NearbyAirport{Airport: a, DistanceNM: distance}
+	}
+	nearby := []domain.NearbyAirport{
+		{Airport: jfk},
+		near("LGA", 40.7769, -73.8740, 9.2),
+		near("EWR", 40.6925, -74.1687, 18.04),
+		near("ISP", 40.7952, -73.1002, 32.5),
+		near("HPN", 41.0670, -73.7076, 25.8),
+	}
+	point := aviation.Point{Lat: 40.6398, Lon: -73.7789}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "JFK").Return(&jfk, nil)
+	mockRepo.On("GetNearestAirports", point, 50.0, 0).Return(nearby, nil)
+	mockRepo.On("GetNearestAirports", point, float64(DefaultAlternateRadiusNM), 0).Return(nearby, nil)
+	mockRepo.On("GetObservations", []string{"LGA", "EWR", "ISP", "HPN"}).Return(map[string]domain.Observation{
+		"LGA": {Condition: "Fog", VisibilitySM: 0.5},
+		"EWR": {Condition: "Mist", VisibilitySM: 4},
+		"ISP": {Condition: "Clear", VisibilitySM: 10},
+	}, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	alternates, err := s.GetAlternates("JFK", 50, aviation.CategoryMVFR)
+	assert.NoError(t, err)
+	if assert.Len(t, alternates, 2) {
+		assert.Equal(t, "EWR", alternates[0].Faa)
+		assert.Equal(t, 18.0, alternates[0].DistanceNM)
+		assert.InDelta(t, 280, alternates[0].BearingDeg, 1)
+		assert.Equal(t, aviation.CategoryMVFR, alternates[0].FlightCategory)
+		assert.Equal(t, "ISP", alternates[1].Faa)
+	}
+
+	alternates, err = s.GetAlternates("JFK", 0, "")
+	assert.NoError(t, err)
+	if assert.Len(t, alternates, 1) {
+		assert.Equal(t, "ISP", alternates[0].Faa)
+	}
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/broker"
+)
+
+// newPublisher connects the configured message broker, or returns nil when
+// none is configured or its URL is unusable.
+func newPublisher(cfg *config.Config) broker.EventPublisher {
+	switch {
+	case cfg.BrokerURL == "":
+		return nil
+	case strings.HasPrefix(cfg.BrokerURL, "nats://"):
+		n, err := broker.NewNATS(cfg.BrokerURL)
+		if err != nil {
+			log.Printf("WARN: Event publishing disabled: %v", err)
+			return nil
+		}
+		return n
+	default:
+		log.Printf("WARN: Event publishing disabled: unsupported broker URL %q", cfg.BrokerURL)
+		return nil
+	}
+}
+
+// publish sends an event to the message broker, if one is configured.
+// Failures are only logged.
+func (s *Service) publish(event string, data any) {
+	if s.publisher == nil {
+		return
+	}
+	if err := s.sendToBroker(event, data); err != nil {
+		log.Printf("WARN: Failed to publish %s event: %v", event, err)
+	}
+}
+
+// sendToBroker publishes an event to the configured message broker.
+func (s *Service) sendToBroker(event string, data any) error {
+	payload, err := broker.Encode(event, data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	subject := event
+	if s.cfg.BrokerSubjectPrefix != "" {
+		subject = s.cfg.BrokerSubjectPrefix + "." + event
+	}
+	return s.publisher.Publish(subject, payload)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/broker"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+	"aviation-weather/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakePublisher records published events by subject.
+type fakePublisher struct {
+	mu     sync.Mutex
+	events map[string][]broker.Event
+}
+
+func (f *fakePublisher) Publish(subject string, payload []byte) error {
+	var event broker.Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.events == nil {
+		f.events = map[string][]broker.Event{}
+	}
+	f.events[subject] = append(f.events[subject], event)
+	return nil
+}
+
+func TestSyncPublishesEvents(t *testing.T) {
+	airport := sampleAirport
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&airport, nil)
+	mockRepo.On("SaveObservation", "TST", mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+	s := NewService(mockRepo, &config.Config{BrokerSubjectPrefix: "aw"}).(*Service)
+	publisher := &fakePublisher{}
+	s.publisher = publisher
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Clear", ObservedAt: time.Now()}, nil
+	}
+
+	_, err := s.SyncAirportByFAA(context.Background(), "TST")
+	assert.NoError(t, err)
+
+	updated := publisher.events["aw.airport.updated"]
+	if assert.Len(t, updated, 1) {
+		assert.Equal(t, broker.SchemaVersion, updated[0].SchemaVersion)
+		assert.Equal(t, domain.EventAirportUpdated, updated[0].Event)
+		assert.Equal(t, "TST", updated[0].Data.(map[string]any)["faa_ident"])
+	}
+	completed := publisher.events["aw.sync.completed"]
+	if assert.Len(t, completed, 1) {
+		assert.Equal(t, map[string]any{"faa_ident": "TST", "updated": 1.0, "failed": 0.0}, completed[0].Data)
+	}
+	assert.Empty(t, publisher.events["aw.weather.changed"], "Weather stayed Clear")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNewPublisher(t *testing.T) {
+	assert.Nil(t, newPublisher(&config.Config{}))
+	assert.Nil(t, newPublisher(&config.Config{BrokerURL: "kafka://localhost:9092"}), "Kafka is not supported")
+	assert.IsType(t, &broker.NATS{}, newPublisher(&config.Config{BrokerURL: "nats://localhost:4222"}))
+}
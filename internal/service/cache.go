@@ -0,0 +1,177 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/cache"
+	"aviation-weather/internal/domain"
+)
+
+// Caches by name, as listed by GetCaches and cleared by ClearCache. Weather
+// and airport reads share the configured backend under their own prefix.
+const (
+	weatherCacheName  = "weather"
+	airportsCacheName = "airports"
+	providerCacheName = "provider"
+)
+
+const allAirportsCacheKey = "all"
+
+func airportCacheKey(faa string) string {
+	return "faa:" + faa
+}
+
+// newCache builds the configured cache backend, or nil when nothing is cached.
+func newCache(cfg *config.Config) cache.Cache {
+	if cfg.WeatherCacheTTL <= 0 && cfg.AirportCacheTTL <= 0 {
+		return nil
+	}
+	if cfg.CacheBackend == "redis" {
+		return cache.NewRedis(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB, "aviation-weather:")
+	}
+	return cache.NewMemory()
+}
+
+// cachedAirports decodes a cached airport read into dst, reporting whether it was found.
+func (s *Service) cachedAirports(key string, dst any) bool {
+	if s.cache == nil || s.cfg.AirportCacheTTL <= 0 {
+		return false
+	}
+
+	data, ok := s.airportCache.Get(key)
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		log.Printf("cachedAirports: corrupt entry %s: %v", key, err)
+		return false
+	}
+	return true
+}
+
+func (s *Service) cacheAirports(key string, value any) {
+	if s.cache == nil || s.cfg.AirportCacheTTL <= 0 {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("cacheAirports: failed to encode %s: %v", key, err)
+		return
+	}
+	s.airportCache.Set(key, data, s.cfg.AirportCacheTTL)
+}
+
+// invalidateAirports drops cached reads of the given airports and of the full list.
+func (s *Service) invalidateAirports(faas ...string) {
+	if s.cache == nil || s.cfg.AirportCacheTTL <= 0 {
+		return
+	}
+
+	keys := []string{allAirportsCacheKey}
+	for _, faa := range faas {
+		keys = append(keys, airportCacheKey(faa))
+	}
+	s.airportCache.Delete(keys...)
+}
+
+func faaCodes(airports []domain.Airport) []string {
+	faas := make([]string, len(airports))
+	for i, a := range airports {
+		faas[i] = a.Faa
+	}
+	return faas
+}
+
+// CacheStats reports hit and miss counts of the weather and airport cache.
+func (s *Service) CacheStats() domain.CacheStats {
+	if s.cache == nil {
+		return domain.CacheStats{}
+	}
+
+	stats := s.cache.Stats()
+	result := domain.CacheStats{
+		Enabled: true,
+		Backend: s.cfg.CacheBackend,
+		Hits:    stats.Hits,
+		Misses:  stats.Misses,
+		Entries: stats.Entries,
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		result.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return result
+}
+
+// GetCaches reports each cache by name: weather and airport reads, and the
+// Aviation API responses kept in provider_cache, whose hits are the requests
+// answered 304 Not Modified.
+func (s *Service) GetCaches() ([]domain.CacheStats, error) {
+	caches := []domain.CacheStats{
+		s.namespaceStats(weatherCacheName, s.weatherCache, s.cfg.WeatherCacheTTL > 0),
+		s.namespaceStats(airportsCacheName, s.airportCache, s.cfg.AirportCacheTTL > 0),
+	}
+
+	entries, err := s.repo.CountProviderResponses()
+	if err != nil {
+		return nil, err
+	}
+	provider := domain.CacheStats{
+		Name:    providerCacheName,
+		Enabled: true,
+		Backend: "postgres",
+		Hits:    s.providerCacheHits.Load(),
+		Misses:  s.providerCacheMisses.Load(),
+		Entries: entries,
+	}
+	if total := provider.Hits + provider.Misses; total > 0 {
+		provider.HitRatio = float64(provider.Hits) / float64(total)
+	}
+	return append(caches, provider), nil
+}
+
+func (s *Service) namespaceStats(name string, ns *cache.Namespace, enabled bool) domain.CacheStats {
+	if ns == nil || !enabled {
+		return domain.CacheStats{Name: name}
+	}
+
+	stats := ns.Stats()
+	result := domain.CacheStats{
+		Name:    name,
+		Enabled: true,
+		Backend: s.cfg.CacheBackend,
+		Hits:    stats.Hits,
+		Misses:  stats.Misses,
+		Entries: stats.Entries,
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		result.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return result
+}
+
+// ClearCache drops every entry of the named cache, e.g. after a bad upstream
+// response was cached. Clearing a disabled cache does nothing.
+func (s *Service) ClearCache(name string) error {
+	switch name {
+	case weatherCacheName:
+		if s.weatherCache != nil {
+			s.weatherCache.Clear("")
+		}
+	case airportsCacheName:
+		if s.airportCache != nil {
+			s.airportCache.Clear("")
+		}
+	case providerCacheName:
+		if _, err := s.repo.ClearProviderResponses(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: %s", domain.ErrCacheNotFound, name)
+	}
+	log.Printf("Cleared the %s cache", name)
+	return nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// airportChangeBuffer is how many changes a live subscriber may lag behind.
+const airportChangeBuffer = 64
+
+// ConsumeAirportChanges relays the database's airport changes to live
+// subscribers until changes closes or ctx is done. Cached reads of each changed
+// airport are dropped too, since the write may come from another process.
+func (s *Service) ConsumeAirportChanges(ctx context.Context, changes <-chan domain.AirportChange) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case change, ok := <-changes:
+			if !ok {
+				return
+			}
+			s.invalidateAirports(change.Faa)
+			if change.Op == domain.ChangeDelete {
+				s.deletedAt.Store(time.Now().UnixNano())
+			}
+			s.airportChanges.Publish(change)
+		}
+	}
+}
+
+// SubscribeAirportChanges returns a feed of airport changes and a function
+// ending it. A subscriber that falls behind misses changes.
+func (s *Service) SubscribeAirportChanges() (<-chan domain.AirportChange, func()) {
+	return s.airportChanges.Subscribe()
+}
+
+// AirportsLastModified tells when the airport list last changed: the latest
+// write of an airport, or the latest deletion this process saw. Deletions
+// made before the start are unknown, so the start counts as one.
+func (s *Service) AirportsLastModified(ctx context.Context) (time.Time, error) {
+	updated, err := s.repo.GetLastUpdatedAt(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get last airport update: %w", err)
+	}
+	deleted := time.Unix(0, s.deletedAt.Load())
+	if updated == nil || deleted.After(*updated) {
+		return deleted, nil
+	}
+	return *updated, nil
+}
+
+// GetChangeFeed returns up to limit airport changes after the cursor, in
+// order, for replicas to catch up from where they stopped.
+func (s *Service) GetChangeFeed(after domain.ChangeCursor, limit int) (*domain.ChangeFeed, error) {
+	// One more than asked tells whether another page follows
+	changes, err := s.repo.GetAirportChanges(after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get change feed: %w", err)
+	}
+
+	feed := &domain.ChangeFeed{Changes: changes, Cursor: after}
+	if len(changes) > limit {
+		feed.Changes, feed.HasMore = changes[:limit], true
+	}
+	if len(feed.Changes) > 0 {
+		feed.Cursor = feed.Changes[len(feed.Changes)-1].Cursor
+	}
+	return feed, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestConsumeAirportChanges(t *testing.T) {
+	s := NewService(&mocks.RepositoryMock{}, &config.Config{AirportCacheTTL: time.Minute}).(*Service)
+	s.airportCache.Set(airportCacheKey("DFW"), []byte(`{}`), time.Minute)
+
+	feed, unsubscribe := s.SubscribeAirportChanges()
+	defer unsubscribe()
+
+	changes := make(chan domain.AirportChange, 1)
+	changes <- domain.AirportChange{Op: domain.ChangeUpdate, Faa: "DFW"}
+	close(changes)
+	s.ConsumeAirportChanges(context.Background(), changes)
+
+	assert.Equal(t, domain.AirportChange{Op: domain.ChangeUpdate, Faa: "DFW"}, <-feed)
+	_, cached := s.airportCache.Get(airportCacheKey("DFW"))
+	assert.False(t, cached, "External writes invalidate the cached airport")
+}
+
+func TestAirportsLastModified(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	started := time.Unix(0, s.deletedAt.Load())
+
+	updated := started.Add(time.Hour)
+	mockRepo.On("GetLastUpdatedAt", mock.Anything).Return(&updated, nil).Once()
+	modified, err := s.AirportsLastModified(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, updated, modified, "The latest write wins")
+
+	earlier := started.Add(-time.Hour)
+	mockRepo.On("GetLastUpdatedAt", mock.Anything).Return(&earlier, nil).Once()
+	modified, err = s.AirportsLastModified(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, started, modified, "Deletions before the start are unknown")
+
+	changes := make(chan domain.AirportChange, 1)
+	changes <- domain.AirportChange{Op: domain.ChangeDelete, Faa: "DFW"}
+	close(changes)
+	s.ConsumeAirportChanges(context.Background(), changes)
+
+	mockRepo.On("GetLastUpdatedAt", mock.Anything).Return((*time.Time)(nil), nil).Once()
+	modified, err = s.AirportsLastModified(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, modified.After(started), "A deletion modifies the list")
+
+	mockRepo.On("GetLastUpdatedAt", mock.Anything).Return((*time.Time)(nil), assert.AnError).Once()
+	_, err = s.AirportsLastModified(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestGetChangeFeed(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	s := NewService(mockRepo, &config.Config{})
+	after := domain.ChangeCursor{TxID: 100, ID: 7}
+	changes := []domain.ChangeLogEntry{
+		{Cursor: domain.ChangeCursor{TxID: 101, ID: 8}, Op: domain.ChangeInsert, Faa: "TST"},
+		{Cursor: domain.ChangeCursor{TxID: 101, ID: 9}, Op: domain.ChangeUpdate, Faa: "TST"},
+		{Cursor: domain.ChangeCursor{TxID: 102, ID: 10}, Op: domain.ChangeDelete, Faa: "TST"},
+	}
+	mockRepo.On("GetAirportChanges", after, 3).Return(changes, nil).Once()
+	mockRepo.On("GetAirportChanges", after, 4).Return(changes, nil).Once()
+	mockRepo.On("GetAirportChanges", domain.ChangeCursor{TxID: 102, ID: 10}, 3).Return([]domain.ChangeLogEntry{}, nil).Once()
+
+	feed, err := s.GetChangeFeed(after, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, changes[:2], feed.Changes)
+	assert.Equal(t, domain.ChangeCursor{TxID: 101, ID: 9}, feed.Cursor)
+	assert.True(t, feed.HasMore)
+
+	feed, err = s.GetChangeFeed(after, 3)
+	assert.NoError(t, err)
+	assert.Len(t, feed.Changes, 3)
+	assert.False(t, feed.HasMore)
+
+	// With nothing new the cursor stays where it was
+	feed, err = s.GetChangeFeed(domain.ChangeCursor{TxID: 102, ID: 10}, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, feed.Changes)
+	assert.Equal(t, domain.ChangeCursor{TxID: 102, ID: 10}, feed.Cursor)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"aviation-weather/internal/domain"
+)
+
+// chartsChunkSize is how many airports each chart request of SyncCharts asks
+// for; the charts of a large airport alone run to a hundred entries.
+const chartsChunkSize = 20
+
+// GetCharts returns the stored airport diagrams and approach plates of one
+// airport.
+func (s *Service) GetCharts(faa string) ([]domain.Chart, error) {
+	if _, err := s.GetAirportByFAA(faa); err != nil {
+		return nil, err
+	}
+
+	charts, err := s.repo.GetCharts(faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get charts for %s: %w", faa, err)
+	}
+	return charts, nil
+}
+
+// SyncCharts fetches the charts of every airport not yet synced for the
+// current AIRAC cycle and returns how many airports it synced. A run stopped
+// by an error leaves the rest for the next one.
+func (s *Service) SyncCharts(ctx context.Context) (int, error) {
+	cycle := currentCycle()
+	faas, err := s.repo.GetFAAsNeedingCharts(cycle)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get airports needing charts: %w", err)
+	}
+
+	synced := 0
+	for start := 0; start < len(faas); start += chartsChunkSize {
+		if err := ctx.Err(); err != nil {
+			return synced, err
+		}
+		chunk := faas[start:min(start+chartsChunkSize, len(faas))]
+		fetched, err := s.fetchCharts(chunk)
+		if err != nil {
+			return synced, fmt.Errorf("%w: failed to fetch charts: %w", domain.ErrExternalAPI, err)
+		}
+
+		for _, faa := range chunk {
+			charts := fetched[faa]
+			// Right after a boundary the provider may still serve the charts
+			// of the previous cycle; stamping them with it fetches them again
+			// at the next run
+			stamp := cycle
+			for i := range charts {
+				if charts[i].Cycle == "" {
+					charts[i].Cycle = cycle
+				}
+				if charts[i].Cycle < stamp {
+					stamp = charts[i].Cycle
+				}
+			}
+			if err := s.repo.ReplaceCharts(faa, stamp, charts); err != nil {
+				return synced, fmt.Errorf("failed to save charts for %s: %w", faa, err)
+			}
+			synced++
+		}
+	}
+	return synced, nil
+}
+
+func (s *Service) fetchCharts(faas []string) (map[string][]domain.Chart, error) {
+	var charts map[string][]domain.Chart
+	err := guarded(s.aviationBreaker, providerAviationAPI, func() (err error) {
+		charts, err = s.FetchCharts(faas)
+		return err
+	})
+	return charts, err
+}
+
+// aviationAPIChart is a chart of the d-TPP as returned by the Aviation API.
+type aviationAPIChart struct {
+	ChartCode string `json:"chart_code"`
+	ChartName string `json:"chart_name"`
+	PDFPath   string `json:"pdf_path"`
+}
+
+// tppCycle finds the cycle in d-TPP links such as
+// "https://aeronav.faa.gov/d-tpp/2510/00026AD.PDF".
+var tppCycle = regexp.MustCompile(`/d-tpp/(\d{4})/`)
+
+// Internal helper
+func (s *Service) fetchChartsFromAviationAPI(faas []string) (map[string][]domain.Chart, error) {
+	apiURL := fmt.Sprintf("%s?apt=%s", s.aviationChartsURL, url.QueryEscape(strings.Join(faas, ",")))
+	body, err := s.getAviationAPI(apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("charts %w", err)
+	}
+
+	var resultMap map[string][]aviationAPIChart
+	if err := json.Unmarshal(body, &resultMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal charts: %w", err)
+	}
+
+	charts := make(map[string][]domain.Chart, len(faas))
+	for _, faa := range faas {
+		for _, c := range resultMap[faa] {
+			if c.ChartCode != domain.ChartAirportDiagram && c.ChartCode != domain.ChartApproach {
+				continue
+			}
+			chart := domain.Chart{Code: c.ChartCode, Name: c.ChartName, PDFURL: c.PDFPath}
+			if m := tppCycle.FindStringSubmatch(c.PDFPath); m != nil {
+				chart.Cycle = m[1]
+			}
+			charts[faa] = append(charts[faa], chart)
+		}
+	}
+	return charts, nil
+}
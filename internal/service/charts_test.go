@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFetchChartsFromAviationAPI(t *testing.T) {
+	const body = `{"ATL":[
+		{"chart_seq":"10100","chart_code":"APD","chart_name":"AIRPORT DIAGRAM","pdf_path":"https://aeronav.faa.gov/d-tpp/2510/00026AD.PDF"},
+		{"chart_seq":"20100","chart_code":"MIN","chart_name":"TAKEOFF MINIMUMS","pdf_path":"https://aeronav.faa.gov/d-tpp/2510/SE4TO.PDF"},
+		{"chart_seq":"50750","chart_code":"IAP","chart_name":"ILS OR LOC RWY 08L","pdf_path":"https://example.com/00026I8L.PDF"}
+	],"4GA1":[]}`
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetProviderResponse", mock.Anything).Return((*domain.ProviderResponse)(nil), nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.aviationChartsURL = server.URL
+
+	charts, err := s.fetchChartsFromAviationAPI([]string{"ATL", "4GA1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "apt=ATL%2C4GA1", query)
+	assert.Equal(t, map[string][]domain.Chart{
+		"ATL": {
+			{Code: "APD", Name: "AIRPORT DIAGRAM", PDFURL: "https://aeronav.faa.gov/d-tpp/2510/00026AD.PDF", Cycle: "2510"},
+			{Code: "IAP", Name: "ILS OR LOC RWY 08L", PDFURL: "https://example.com/00026I8L.PDF"},
+		},
+	}, charts)
+}
+
+func TestSyncCharts(t *testing.T) {
+	cycle := aviation.AIRACCycleAt(time.Now()).Ident
+	diagram := domain.Chart{Code: domain.ChartAirportDiagram, Name: "AIRPORT DIAGRAM", PDFURL: "https://aeronav.faa.gov/d-tpp/" + cycle + "/00026AD.PDF", Cycle: cycle}
+	stale := domain.Chart{Code: domain.ChartApproach, Name: "ILS RWY 16R", PDFURL: "https://aeronav.faa.gov/d-tpp/1901/00389IL16R.PDF", Cycle: "1901"}
+	undated := domain.Chart{Code: domain.ChartApproach, Name: "VOR RWY 04", PDFURL: "https://example.com/VOR04.PDF"}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetFAAsNeedingCharts", cycle).Return([]string{"ATL", "DEN", "4GA1"}, nil)
+	mockRepo.On("ReplaceCharts", "ATL", cycle, []domain.Chart{diagram, {Code: undated.Code, Name: undated.Name, PDFURL: undated.PDFURL, Cycle: cycle}}).Return(nil)
+	mockRepo.On("ReplaceCharts", "DEN", "1901", []domain.Chart{stale}).Return(nil)
+	mockRepo.On("ReplaceCharts", "4GA1", cycle, []domain.Chart(nil)).Return(nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	var requested []string
+	s.FetchCharts = func(faas []string) (map[string][]domain.Chart, error) {
+		requested = faas
+		return map[string][]domain.Chart{"ATL": {diagram, undated}, "DEN": {stale}}, nil
+	}
+
+	synced, err := s.SyncCharts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, synced)
+	assert.Equal(t, []string{"ATL", "DEN", "4GA1"}, requested)
+	mockRepo.AssertExpectations(t)
+
+	s.FetchCharts = func([]string) (map[string][]domain.Chart, error) { return nil, errors.New("timeout") }
+	_, err = s.SyncCharts(context.Background())
+	assert.ErrorIs(t, err, domain.ErrExternalAPI)
+}
+
+func TestGetCharts(t *testing.T) {
+	charts := []domain.Chart{{Code: domain.ChartAirportDiagram, Name: "AIRPORT DIAGRAM", PDFURL: "https://aeronav.faa.gov/d-tpp/2510/00026AD.PDF", Cycle: "2510"}}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "ATL").Return(&sampleAirport, nil)
+	mockRepo.On("GetAirportByFAA", "ZZZ").Return((*domain.Airport)(nil), nil)
+	mockRepo.On("GetCharts", "ATL").Return(charts, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	got, err := s.GetCharts("ATL")
+	assert.NoError(t, err)
+	assert.Equal(t, charts, got)
+
+	_, err = s.GetCharts("ZZZ")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
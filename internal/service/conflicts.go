@@ -0,0 +1,86 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"aviation-weather/internal/domain"
+)
+
+// recordConflicts saves the provider values a sync found disagreeing with
+// overridden fields, for an admin to resolve. Failures are only logged since
+// the airports themselves are already updated.
+func (s *Service) recordConflicts(conflicts []domain.AirportConflict) {
+	if len(conflicts) == 0 {
+		return
+	}
+	if err := s.repo.SaveConflicts(conflicts); err != nil {
+		log.Printf("WARN: Failed to save %d airport conflicts: %v", len(conflicts), err)
+	}
+}
+
+// GetConflicts lists the conflicts between provider and overridden values
+// with the given status, or all of them for "".
+func (s *Service) GetConflicts(status string) ([]domain.AirportConflict, error) {
+	conflicts, err := s.repo.GetConflicts(status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conflicts: %w", err)
+	}
+	return conflicts, nil
+}
+
+// AcceptConflict resolves a pending conflict in favour of the provider: the
+// field takes the provider's value and is no longer overridden, so later
+// syncs keep it up to date.
+func (s *Service) AcceptConflict(id int64) (*domain.AirportConflict, error) {
+	conflict, err := s.pendingConflict(id)
+	if err != nil {
+		return nil, err
+	}
+	var check domain.Airport
+	if err := check.SetFieldText(conflict.Field, conflict.ProviderValue); err != nil {
+		return nil, fmt.Errorf("failed to accept conflict %d: %w", id, err)
+	}
+
+	unlock := s.airportLocks.Lock(conflict.Faa)
+	err = s.modifyAirport(conflict.Faa, func(a *domain.Airport) []airportEvent {
+		a.SetFieldText(conflict.Field, conflict.ProviderValue) // Checked above
+		a.ManualOverrides = slices.DeleteFunc(slices.Clone(a.ManualOverrides), func(field string) bool {
+			return field == conflict.Field
+		})
+		if a.ManualOverrides == nil {
+			a.ManualOverrides = []string{} // Saved as such rather than left as stored
+		}
+		return []airportEvent{{domain.AllTenants, domain.EventAirportUpdated, a}}
+	})
+	unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update airport %s: %w", conflict.Faa, err)
+	}
+	s.invalidateAirports(conflict.Faa)
+
+	return s.repo.ResolveConflict(id, domain.ConflictAccepted)
+}
+
+// RejectConflict resolves a pending conflict in favour of the local value,
+// which stays overridden. The same provider value isn't reported again.
+func (s *Service) RejectConflict(id int64) (*domain.AirportConflict, error) {
+	if _, err := s.pendingConflict(id); err != nil {
+		return nil, err
+	}
+	return s.repo.ResolveConflict(id, domain.ConflictRejected)
+}
+
+// pendingConflict fetches a conflict, returning domain.ErrConflictResolved if
+// it was already accepted or rejected.
+func (s *Service) pendingConflict(id int64) (*domain.AirportConflict, error) {
+	conflict, err := s.repo.GetConflict(id)
+	if err != nil {
+		return nil, err
+	}
+	if conflict.Status != domain.ConflictPending {
+		return nil, fmt.Errorf("%w: %d", domain.ErrConflictResolved, id)
+	}
+	return conflict, nil
+}
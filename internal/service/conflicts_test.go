@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptConflict(t *testing.T) {
+	stored := sampleAirport
+	stored.Manager = "Corrected Manager"
+	stored.ManualOverrides = []string{"manager"}
+	pending := domain.AirportConflict{ID: 3, Faa: "TST", Field: "manager", LocalValue: "Corrected Manager", ProviderValue: "Provider Manager", Status: domain.ConflictPending}
+	accepted := pending
+	accepted.Status = domain.ConflictAccepted
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetConflict", int64(3)).Return(&pending, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&stored, nil)
+	mockRepo.On("ResolveConflict", int64(3), domain.ConflictAccepted).Return(&accepted, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	conflict, err := s.AcceptConflict(3)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ConflictAccepted, conflict.Status)
+	assert.Equal(t, "Provider Manager", stored.Manager)
+	assert.Equal(t, []string{}, stored.ManualOverrides, "The field is synced again")
+}
+
+func TestAcceptConflictOfNumber(t *testing.T) {
+	stored := sampleAirport
+	stored.ManualOverrides = []string{"elevation_ft", "city"}
+	pending := domain.AirportConflict{ID: 4, Faa: "TST", Field: "elevation_ft", ProviderValue: "5431.5", Status: domain.ConflictPending}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetConflict", int64(4)).Return(&pending, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&stored, nil)
+	mockRepo.On("ResolveConflict", int64(4), domain.ConflictAccepted).Return(&pending, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	_, err := s.AcceptConflict(4)
+	assert.NoError(t, err)
+	assert.Equal(t, 5431.5, *stored.ElevationFt)
+	assert.Equal(t, []string{"city"}, stored.ManualOverrides)
+
+	bad := domain.AirportConflict{ID: 5, Faa: "TST", Field: "latitude", ProviderValue: "north", Status: domain.ConflictPending}
+	mockRepo.On("GetConflict", int64(5)).Return(&bad, nil)
+	_, err = s.AcceptConflict(5)
+	assert.Error(t, err)
+	mockRepo.AssertNumberOfCalls(t, "ModifyAirport", 1)
+}
+
+func TestRejectConflict(t *testing.T) {
+	pending := domain.AirportConflict{ID: 3, Faa: "TST", Field: "manager", Status: domain.ConflictPending}
+	rejected := pending
+	rejected.Status = domain.ConflictRejected
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetConflict", int64(3)).Return(&pending, nil)
+	mockRepo.On("ResolveConflict", int64(3), domain.ConflictRejected).Return(&rejected, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	conflict, err := s.RejectConflict(3)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ConflictRejected, conflict.Status)
+	mockRepo.AssertNotCalled(t, "ModifyAirport", "TST")
+
+	mockRepo.On("GetConflict", int64(6)).Return(&rejected, nil)
+	_, err = s.RejectConflict(6)
+	assert.ErrorIs(t, err, domain.ErrConflictResolved, "Resolved conflicts stay so")
+	_, err = s.AcceptConflict(6)
+	assert.ErrorIs(t, err, domain.ErrConflictResolved)
+}
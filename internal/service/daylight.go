@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// applyTimezone fills in the IANA zone of an airport with a known position.
+func applyTimezone(a *domain.Airport) {
+	if a.Timezone != "" {
+		return
+	}
+	if lat, lon, ok := a.Coordinates(); ok {
+		a.Timezone = aviation.Timezone(a.StateCode, lat, lon)
+	}
+}
+
+// GetDaylight reports civil twilight, sunrise and sunset of one airport on
+// date, or on today's local date when date is zero.
+func (s *Service) GetDaylight(faa string, date time.Time) (*domain.Daylight, error) {
+	airport, err := s.GetAirportByFAA(faa)
+	if err != nil {
+		return nil, err
+	}
+
+	lat, lon, ok := airport.Coordinates()
+	if !ok {
+		return nil, fmt.Errorf("%w: no coordinates for %s", domain.ErrNoData, faa)
+	}
+	zone := airport.Timezone
+	if zone == "" {
+		zone = aviation.Timezone(airport.StateCode, lat, lon)
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone %s for %s: %w", zone, faa, err)
+	}
+
+	if date.IsZero() {
+		date = time.Now().In(loc)
+	}
+
+	daylight := &domain.Daylight{Faa: faa, Timezone: zone, Date: date.Format(time.DateOnly)}
+	localTime := func(t time.Time) *time.Time {
+		t = t.In(loc)
+		return &t
+	}
+
+	rise, set, pos := aviation.SunEvent(date, lat, lon, aviation.SunriseAltitude)
+	switch pos {
+	case aviation.SunCrosses:
+		daylight.Sunrise, daylight.Sunset = localTime(rise), localTime(set)
+		daylight.DaylightMinutes = int(set.Sub(rise).Minutes())
+	case aviation.SunAlwaysAbove:
+		daylight.DaylightMinutes = 24 * 60
+	}
+
+	if dawn, dusk, pos := aviation.SunEvent(date, lat, lon, aviation.CivilAltitude); pos == aviation.SunCrosses {
+		daylight.CivilDawn, daylight.CivilDusk = localTime(dawn), localTime(dusk)
+	}
+
+	return daylight, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDaylight(t *testing.T) {
+	dfw := sampleAirport
+	dfw.StateCode, dfw.Latitude, dfw.Longitude = "TX", deg(32.8966), deg(-97.0376)
+	noCoords := sampleAirport
+	noCoords.Faa, noCoords.Latitude, noCoords.Longitude = "NC", nil, nil
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "DFW").Return(&dfw, nil)
+	mockRepo.On("GetAirportByFAA", "NC").Return(&noCoords, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	daylight, err := s.GetDaylight("DFW", time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "America/Chicago", daylight.Timezone)
+	assert.Equal(t, "2024-06-21", daylight.Date)
+	assert.Equal(t, "06:20 CDT", daylight.Sunrise.Format("15:04 MST"))
+	assert.Equal(t, "20:39 CDT", daylight.Sunset.Format("15:04 MST"))
+	assert.True(t, daylight.CivilDawn.Before(*daylight.Sunrise))
+	assert.InDelta(t, 859, daylight.DaylightMinutes, 2)
+
+	_, err = s.GetDaylight("NC", time.Time{})
+	assert.EqualError(t, err, fmt.Errorf("%w: no coordinates for NC", domain.ErrNoData).Error())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApplyTimezone(t *testing.T) {
+	a := domain.Airport{StateCode: "FL", Latitude: deg(30.4733), Longitude: deg(-87.1866)}
+	applyTimezone(&a)
+	assert.Equal(t, "America/Chicago", a.Timezone)
+
+	a.Timezone = "America/New_York" // Stored zones are kept
+	applyTimezone(&a)
+	assert.Equal(t, "America/New_York", a.Timezone)
+}
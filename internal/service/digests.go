@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/notify"
+	"aviation-weather/internal/webhook"
+)
+
+// CreateSubscription subscribes apiKey to digests of the listed airports. Email
+// digests need an SMTP host.
+func (s *Service) CreateSubscription(apiKey string, sub *domain.Subscription) error {
+	if sub.Channel == domain.ChannelEmail && s.notifiers[domain.ChannelEmail] == nil {
+		return fmt.Errorf("%w: %s", domain.ErrChannelDisabled, sub.Channel)
+	}
+	sub.Faas = normalizeFAAs(sub.Faas)
+	sub.Owner = keyOwner(apiKey)
+
+	if err := s.repo.CreateSubscription(sub); err != nil {
+		return fmt.Errorf("failed to create subscription: %w", err)
+	}
+	return nil
+}
+
+// DeleteSubscription unsubscribes a subscription of apiKey.
+func (s *Service) DeleteSubscription(apiKey string, id int64) error {
+	if err := s.repo.DeleteSubscription(id, keyOwner(apiKey)); err != nil {
+		return fmt.Errorf("failed to delete subscription: %w", err)
+	}
+	return nil
+}
+
+// GetSubscriptions returns the subscriptions of apiKey with their secrets left
+// out.
+func (s *Service) GetSubscriptions(apiKey string) ([]domain.Subscription, error) {
+	subscriptions, err := s.repo.GetSubscriptions(keyOwner(apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+	return subscriptions, nil
+}
+
+// digestForecastHours is how far past its generation a digest's forecast
+// summaries look.
+const digestForecastHours = 6
+
+// SendDigests sends every subscription its digest of the stored conditions and
+// TAF forecasts and returns how many went out. A failed digest is logged and
+// skipped until the next run.
+func (s *Service) SendDigests(ctx context.Context) (int, error) {
+	subscriptions, err := s.repo.GetAllSubscriptions()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	// Subscriptions often share airports, so each TAF is fetched once a run.
+	tafs := map[string]*domain.TAF{}
+	sent := 0
+	for _, sub := range subscriptions {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		entries, err := s.boardEntries(sub.Faas)
+		if err != nil {
+			log.Printf("WARN: Skipping digest of subscription %d: %v", sub.ID, err)
+			continue
+		}
+		digest := domain.Digest{
+			Event:          domain.EventWeatherDigest,
+			SubscriptionID: sub.ID,
+			GeneratedAt:    time.Now().UTC(),
+			Airports:       entries,
+		}
+		digest.Forecasts = s.forecastSummaries(ctx, sub.Faas, digest.GeneratedAt, tafs)
+		if err := s.sendDigest(ctx, sub, digest); err != nil {
+			log.Printf("WARN: Failed to send digest of subscription %d over %s: %v", sub.ID, sub.Channel, err)
+			continue
+		}
+
+		sent++
+		if err := s.repo.MarkSubscriptionSent(sub.ID, digest.GeneratedAt); err != nil {
+			log.Printf("WARN: %v", err)
+		}
+	}
+	return sent, nil
+}
+
+// sendDigest mails digest or posts it as JSON, per the channel of sub.
+func (s *Service) sendDigest(ctx context.Context, sub domain.Subscription, digest domain.Digest) error {
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+
+	switch sub.Channel {
+	case domain.ChannelWebhook:
+		body, err := json.Marshal(digest)
+		if err != nil {
+			return err
+		}
+		_, err = webhook.Post(ctx, s.httpClient, sub.Target, sub.Secret, domain.EventWeatherDigest, body)
+		return err
+	default:
+		notifier, ok := s.notifiers[sub.Channel]
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrChannelDisabled, sub.Channel)
+		}
+		return notifier.Send(ctx, sub.Target, notify.DigestMessage(digest))
+	}
+}
+
+// forecastSummaries sums up the TAF of each airport of faas at a time and
+// digestForecastHours later, in order. TAFs are looked up in tafs by ICAO code
+// and fetched into it when missing. An airport without an ICAO code or a TAF
+// covering the time is left out, and a failure to get one is logged.
+func (s *Service) forecastSummaries(ctx context.Context, faas []string, at time.Time, tafs map[string]*domain.TAF) []domain.ForecastSummary {
+	airports, err := s.repo.GetAirportsByFAAs(faas)
+	if err != nil {
+		log.Printf("WARN: Leaving forecasts out of digest: failed to get airports: %v", err)
+		return nil
+	}
+	icaos := make(map[string]string, len(airports))
+	for _, a := range airports {
+		icaos[a.Faa] = a.Icao
+	}
+
+	var summaries []domain.ForecastSummary
+	for _, faa := range faas {
+		icao := icaos[faa]
+		if icao == "" {
+			continue
+		}
+		taf, ok := tafs[icao]
+		if !ok {
+			taf = s.fetchDigestTAF(ctx, icao, at)
+			tafs[icao] = taf
+		}
+		if taf == nil {
+			continue
+		}
+		forecast, ok := taf.ForecastAt(at)
+		if !ok {
+			continue
+		}
+
+		summary := domain.ForecastSummary{Faa: faa, Station: taf.Station, Now: prevailingSummary(forecast.Prevailing)}
+		for _, p := range forecast.Temporary {
+			summary.Temporary = append(summary.Temporary, temporarySummary(p))
+		}
+		later := at.Add(digestForecastHours * time.Hour)
+		if next, ok := taf.ForecastAt(later); ok {
+			summary.LaterAt, summary.Later = &later, prevailingSummary(next.Prevailing)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// fetchDigestTAF fetches and parses the TAF of a station, or returns nil when
+// it has none or the fetch fails.
+func (s *Service) fetchDigestTAF(ctx context.Context, icao string, at time.Time) *domain.TAF {
+	raw, err := s.FetchTAF(ctx, icao)
+	if err != nil {
+		log.Printf("WARN: Leaving %s out of digest forecasts: failed to fetch TAF: %v", icao, err)
+		return nil
+	}
+	if raw == "" {
+		return nil
+	}
+	taf, err := domain.ParseTAF(raw, at)
+	if err != nil {
+		log.Printf("WARN: Leaving %s out of digest forecasts: failed to parse TAF: %v", icao, err)
+		return nil
+	}
+	return taf
+}
+
+// prevailingSummary describes prevailing TAF conditions led by their flight
+// category, e.g. "IFR, wind 300 at 10KT, 3SM BR, ceiling 800ft".
+func prevailingSummary(p domain.TAFPeriod) string {
+	parts := conditionsSummary(p.Conditions)
+	if p.VisibilitySM != nil {
+		ceiling := 0
+		if p.CeilingFt != nil {
+			ceiling = *p.CeilingFt
+		}
+		category := aviation.FlightCategory(ceiling, p.CeilingFt != nil, *p.VisibilitySM)
+		parts = append([]string{category}, parts...)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// temporarySummary describes a TEMPO, PROB or BECMG group by its change and
+// what it forecasts, e.g. "PROB30 TEMPO 2SM TSRA, ceiling 1200ft". A group
+// lists only what changes, so it has no flight category of its own.
+func temporarySummary(p domain.TAFPeriod) string {
+	change := p.Change
+	if p.Probability > 0 {
+		change = "PROB" + strconv.Itoa(p.Probability)
+		if p.Change == domain.TAFTemporary {
+			change += " " + domain.TAFTemporary
+		}
+	}
+	if parts := conditionsSummary(p.Conditions); len(parts) > 0 {
+		return change + " " + strings.Join(parts, ", ")
+	}
+	return change
+}
+
+// conditionsSummary lists the wind, visibility with weather, and ceiling of
+// forecast conditions, leaving out what they don't give.
+func conditionsSummary(c domain.Conditions) []string {
+	var parts []string
+	if c.Wind != nil {
+		direction := "VRB"
+		if c.Wind.DirectionDeg != nil {
+			direction = fmt.Sprintf("%03d", *c.Wind.DirectionDeg)
+		}
+		speed := strconv.Itoa(c.Wind.SpeedKt)
+		if c.Wind.GustKt > 0 {
+			speed += "G" + strconv.Itoa(c.Wind.GustKt)
+		}
+		parts = append(parts, fmt.Sprintf("wind %s at %sKT", direction, speed))
+	}
+
+	var visibility []string
+	if c.VisibilitySM != nil {
+		sm := strconv.FormatFloat(*c.VisibilitySM, 'f', -1, 64) + "SM"
+		if c.VisibilityAbove {
+			sm = "P" + sm
+		}
+		visibility = append(visibility, sm)
+	}
+	visibility = append(visibility, c.Weather...)
+	if len(visibility) > 0 {
+		parts = append(parts, strings.Join(visibility, " "))
+	}
+
+	if c.CeilingFt != nil {
+		parts = append(parts, fmt.Sprintf("ceiling %dft", *c.CeilingFt))
+	}
+	return parts
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+	"aviation-weather/internal/notify"
+	"aviation-weather/internal/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateSubscription(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("CreateSubscription", mock.MatchedBy(func(sub *domain.Subscription) bool {
+		return sub.Owner == keyOwner("key") && assert.ObjectsAreEqual([]string{"DEN"}, sub.Faas)
+	})).Return(nil)
+	mockRepo.On("GetSubscriptions", keyOwner("key")).Return([]domain.Subscription{{ID: 1, Secret: "s3cret"}}, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	assert.NoError(t, s.CreateSubscription("key", &domain.Subscription{Faas: []string{"den", "DEN"}, Channel: domain.ChannelWebhook, Target: "https://example.com/digest"}))
+	err := s.CreateSubscription("key", &domain.Subscription{Faas: []string{"DEN"}, Channel: domain.ChannelEmail, Target: "pilot@example.com"})
+	assert.ErrorIs(t, err, domain.ErrChannelDisabled, "Email needs an SMTP host")
+
+	subscriptions, err := s.GetSubscriptions("key")
+	assert.NoError(t, err)
+	assert.Empty(t, subscriptions[0].Secret, "Secrets are left out")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSendDigests(t *testing.T) {
+	var posted domain.Digest
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(webhook.HeaderSignature)
+		json.NewDecoder(r.Body).Decode(&posted)
+	}))
+	defer server.Close()
+
+	den := domain.Airport{Faa: "DEN", Icao: "KDEN", FacilityName: "Denver Intl", Weather: "Sunny"}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAllSubscriptions").Return([]domain.Subscription{
+		{ID: 1, Faas: []string{"DEN"}, Channel: domain.ChannelWebhook, Target: server.URL, Secret: "s3cret"},
+		{ID: 2, Faas: []string{"DEN"}, Channel: domain.ChannelEmail, Target: "pilot@example.com"},
+		{ID: 3, Faas: []string{"DEN"}, Channel: domain.ChannelWebhook, Target: "http://127.0.0.1:0"},
+	}, nil)
+	mockRepo.On("GetAirportsByFAAs", []string{"DEN"}).Return([]domain.Airport{den}, nil)
+	mockRepo.On("GetObservations", []string{"DEN"}).Return(map[string]domain.Observation{}, nil)
+	mockRepo.On("MarkSubscriptionSent", int64(1), mock.Anything).Return(nil)
+	mockRepo.On("MarkSubscriptionSent", int64(2), mock.Anything).Return(nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	email := &fakeNotifier{}
+	s.notifiers = map[string]notify.Notifier{domain.ChannelEmail: email}
+	fetches := 0
+	s.FetchTAF = func(ctx context.Context, icao string) (string, error) {
+		fetches++
+		return "", nil
+	}
+
+	sent, err := s.SendDigests(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sent, "The unreachable webhook is skipped")
+
+	assert.Equal(t, int64(1), posted.SubscriptionID)
+	assert.Equal(t, domain.EventWeatherDigest, posted.Event)
+	assert.Equal(t, []domain.BoardEntry{{Faa: "DEN", FacilityName: "Denver Intl", Weather: "Sunny"}}, posted.Airports)
+	assert.NotEmpty(t, signature, "Digests to a webhook with a secret are signed")
+	assert.Empty(t, posted.Forecasts, "Airports without a TAF are left out")
+	assert.Equal(t, 1, fetches, "Subscriptions share the TAFs of a run")
+	if assert.Len(t, email.sent["pilot@example.com"], 1) {
+		assert.Equal(t, "Weather digest: DEN", email.sent["pilot@example.com"][0].Subject)
+	}
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "MarkSubscriptionSent", int64(3), mock.Anything)
+}
+
+func TestForecastSummaries(t *testing.T) {
+	now := time.Now().UTC()
+	from := now.Truncate(time.Hour)
+	change := from.Add(3 * time.Hour)
+	raw := "TAF KDEN " + from.Add(-time.Hour).Format("021504") + "Z " + from.Format("0215") + "/" + from.Add(24*time.Hour).Format("0215") +
+		" 27015KT P6SM SKC TEMPO " + from.Format("0215") + "/" + from.Add(2*time.Hour).Format("0215") + " 3SM TSRA" +
+		" FM" + change.Format("021504") + " 30010KT 3SM BR OVC008"
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportsByFAAs", []string{"DEN", "APA", "ASE"}).Return([]domain.Airport{
+		{Faa: "DEN", Icao: "KDEN"}, {Faa: "APA", Icao: "KAPA"}, {Faa: "ASE"},
+	}, nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchTAF = func(ctx context.Context, icao string) (string, error) {
+		if icao == "KAPA" {
+			return "", assert.AnError
+		}
+		return raw, nil
+	}
+
+	tafs := map[string]*domain.TAF{}
+	summaries := s.forecastSummaries(context.Background(), []string{"DEN", "APA", "ASE"}, now, tafs)
+	later := now.Add(digestForecastHours * time.Hour)
+	assert.Equal(t, []domain.ForecastSummary{{
+		Faa:       "DEN",
+		Station:   "KDEN",
+		Now:       "VFR, wind 270 at 15KT, P6SM",
+		Temporary: []string{"TEMPO 3SM TSRA"},
+		LaterAt:   &later,
+		Later:     "IFR, wind 300 at 10KT, 3SM BR, ceiling 800ft",
+	}}, summaries, "Failed fetches and airports without an ICAO code are left out")
+	assert.Contains(t, tafs, "KAPA", "A failed fetch is not retried within a run")
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// GetAirportForecast returns what the latest TAF of an airport forecasts at a
+// time, or now for a zero time.
+func (s *Service) GetAirportForecast(ctx context.Context, faa string, at time.Time) (*domain.TAFForecast, error) {
+	airport, err := s.GetAirportByFAA(faa)
+	if err != nil {
+		return nil, err
+	}
+	if airport.Icao == "" {
+		return nil, fmt.Errorf("%w: no ICAO code for %s", domain.ErrNoData, faa)
+	}
+
+	now := time.Now()
+	if at.IsZero() {
+		at = now
+	}
+
+	raw, err := s.FetchTAF(ctx, airport.Icao)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch TAF for %s: %w", domain.ErrExternalAPI, faa, err)
+	}
+	if raw == "" {
+		return nil, fmt.Errorf("%w: no TAF for %s", domain.ErrNoData, faa)
+	}
+
+	taf, err := domain.ParseTAF(raw, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse TAF for %s: %w", domain.ErrExternalAPI, faa, err)
+	}
+	forecast, ok := taf.ForecastAt(at)
+	if !ok {
+		return nil, fmt.Errorf("%w: TAF for %s does not cover %s", domain.ErrNoData, faa, at.UTC().Format(time.RFC3339))
+	}
+	return forecast, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAirportForecast(t *testing.T) {
+	issued := time.Now().UTC().Truncate(time.Hour).Add(-time.Hour)
+	from, to := issued.Add(time.Hour), issued.Add(25*time.Hour)
+	change := from.Add(6 * time.Hour)
+	raw := "TAF KTST " + issued.Format("021504") + "Z " + from.Format("0215") + "/" + to.Format("0215") +
+		" 27015KT P6SM SKC FM" + change.Format("021504") + " 30010KT 3SM BR OVC008"
+
+	noICAO := sampleAirport
+	noICAO.Faa, noICAO.Icao = "NI", ""
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+	mockRepo.On("GetAirportByFAA", "NI").Return(&noICAO, nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchTAF = func(ctx context.Context, icao string) (string, error) {
+		assert.Equal(t, "KTST", icao)
+		return raw, nil
+	}
+
+	forecast, err := s.GetAirportForecast(context.Background(), "TST", change.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TAFFrom, forecast.Prevailing.Change)
+	assert.Equal(t, 800, *forecast.Prevailing.CeilingFt)
+
+	forecast, err = s.GetAirportForecast(context.Background(), "TST", time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TAFBase, forecast.Prevailing.Change, "A zero time is now, before the TAF's validity ends")
+
+	_, err = s.GetAirportForecast(context.Background(), "TST", to.Add(time.Hour))
+	assert.ErrorIs(t, err, domain.ErrNoData)
+
+	_, err = s.GetAirportForecast(context.Background(), "NI", time.Time{})
+	assert.ErrorIs(t, err, domain.ErrNoData)
+
+	s.FetchTAF = func(ctx context.Context, icao string) (string, error) { return "", nil }
+	_, err = s.GetAirportForecast(context.Background(), "TST", time.Time{})
+	assert.ErrorIs(t, err, domain.ErrNoData)
+
+	s.FetchTAF = func(ctx context.Context, icao string) (string, error) { return "", errors.New("timeout") }
+	_, err = s.GetAirportForecast(context.Background(), "TST", time.Time{})
+	assert.ErrorIs(t, err, domain.ErrExternalAPI)
+	mockRepo.AssertExpectations(t)
+}
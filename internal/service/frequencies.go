@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"aviation-weather/internal/domain"
+)
+
+// GetFrequencies returns the stored COM frequencies of one airport.
+func (s *Service) GetFrequencies(faa string) ([]domain.Frequency, error) {
+	if _, err := s.GetAirportByFAA(faa); err != nil {
+		return nil, err
+	}
+
+	frequencies, err := s.repo.GetFrequencies(faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get frequencies for %s: %w", faa, err)
+	}
+
+	return frequencies, nil
+}
+
+// SyncFrequencies refreshes the stored frequencies of one airport from OurAirports,
+// stamped with the current AIRAC cycle.
+func (s *Service) SyncFrequencies(ctx context.Context, faa string) ([]domain.Frequency, error) {
+	airport, err := s.repo.GetAirportByFAA(faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get airport for %s: %w", faa, err)
+	}
+	if airport == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrNotFound, faa)
+	}
+
+	frequencies, err := s.FetchFrequencies(ctx, airport)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch frequencies for %s: %w", domain.ErrExternalAPI, faa, err)
+	}
+
+	cycle := currentCycle()
+	for i := range frequencies {
+		frequencies[i].Cycle = cycle
+	}
+
+	if err := s.repo.ReplaceFrequencies(faa, frequencies); err != nil {
+		return nil, fmt.Errorf("failed to save frequencies for %s: %w", faa, err)
+	}
+
+	return frequencies, nil
+}
+
+// Internal helper
+func (s *Service) fetchFrequencies(ctx context.Context, a *domain.Airport) ([]domain.Frequency, error) {
+	return s.ourAirports.Frequencies(ctx, a.Icao, a.Faa)
+}
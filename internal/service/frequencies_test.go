@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleFrequencies = []domain.Frequency{
+	{Type: "ATIS", Description: "ATIS", FrequencyMHz: 134.9},
+	{Type: "TWR", Description: "Tower", FrequencyMHz: 126.55},
+}
+
+func TestGetFrequencies(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+	mockRepo.On("GetAirportByFAA", "NF").Return((*domain.Airport)(nil), nil)
+	mockRepo.On("GetFrequencies", "TST").Return(sampleFrequencies, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	frequencies, err := s.GetFrequencies("TST")
+	assert.NoError(t, err)
+	assert.Equal(t, sampleFrequencies, frequencies)
+
+	_, err = s.GetFrequencies("NF")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncFrequencies(t *testing.T) {
+	cycle := aviation.AIRACCycleAt(time.Now()).Ident
+	synced := []domain.Frequency{
+		{Type: "ATIS", Description: "ATIS", FrequencyMHz: 134.9, Cycle: cycle},
+		{Type: "TWR", Description: "Tower", FrequencyMHz: 126.55, Cycle: cycle},
+	}
+
+	tests := []struct {
+		name      string
+		faa       string
+		setupMock func(*mocks.RepositoryMock)
+		fetch     func(ctx context.Context, a *domain.Airport) ([]domain.Frequency, error)
+		expected  []domain.Frequency
+		err       error
+	}{
+		{
+			name: "success",
+			faa:  "TST",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+				m.On("ReplaceFrequencies", "TST", synced).Return(nil)
+			},
+			fetch: func(ctx context.Context, a *domain.Airport) ([]domain.Frequency, error) {
+				return slices.Clone(sampleFrequencies), nil
+			},
+			expected: synced,
+		},
+		{
+			name: "not found",
+			faa:  "NF",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "NF").Return((*domain.Airport)(nil), nil)
+			},
+			err: fmt.Errorf("%w: NF", domain.ErrNotFound),
+		},
+		{
+			name: "fetch error",
+			faa:  "TST",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+			},
+			fetch: func(ctx context.Context, a *domain.Airport) ([]domain.Frequency, error) {
+				return nil, assert.AnError
+			},
+			err: fmt.Errorf("%w: failed to fetch frequencies for TST: %w", domain.ErrExternalAPI, assert.AnError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.RepositoryMock{}
+			tt.setupMock(mockRepo)
+			s := NewService(mockRepo, &config.Config{}).(*Service)
+			s.FetchFrequencies = tt.fetch
+
+			frequencies, err := s.SyncFrequencies(context.Background(), tt.faa)
+			assert.Equal(t, tt.expected, frequencies)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
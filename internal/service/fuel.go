@@ -0,0 +1,48 @@
+package service
+
+import (
+	"fmt"
+
+	"aviation-weather/internal/domain"
+)
+
+// GetAirportsByFuel lists the airports selling fuel, one of the domain Fuel
+// types, ordered by sort, by FAA code when empty.
+func (s *Service) GetAirportsByFuel(fuel string, sort []domain.SortField) ([]domain.Airport, error) {
+	airports, err := s.repo.GetAirportsByFuel(fuel, sort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get airports selling %s: %w", fuel, err)
+	}
+	if len(airports) == 0 {
+		return []domain.Airport{}, nil
+	}
+	return airports, nil
+}
+
+// UpdateAirportFuel saves a hand edit of the fuel types and FBO of an
+// airport. The fields it changes are added to the manual overrides, so later
+// syncs keep them.
+func (s *Service) UpdateAirportFuel(faa string, fuel domain.AirportFuel) (*domain.Airport, error) {
+	defer s.airportLocks.Lock(faa)()
+	defer s.invalidateAirports(faa)
+
+	var updated domain.Airport
+	err := s.modifyAirport(faa, func(a *domain.Airport) []airportEvent {
+		before := *a
+		a.FuelTypes, a.FboName, a.FboPhone = fuel.FuelTypes, fuel.FboName, fuel.FboPhone
+		for _, field := range a.EditedFields(&before) {
+			if !a.IsOverridden(field) {
+				a.ManualOverrides = append(a.ManualOverrides, field)
+			}
+		}
+		if a.FuelTypes == nil {
+			a.FuelTypes = []string{} // Saved as such rather than left as stored
+		}
+		updated = *a
+		return []airportEvent{{domain.AllTenants, domain.EventAirportUpdated, a}}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update fuel of %s: %w", faa, err)
+	}
+	return &updated, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAirportsByFuel(t *testing.T) {
+	sort := []domain.SortField{{Field: "state"}}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportsByFuel", domain.FuelJetA, sort).Return([]domain.Airport{sampleAirport}, nil)
+	mockRepo.On("GetAirportsByFuel", domain.FuelMogas, []domain.SortField(nil)).Return([]domain.Airport(nil), nil)
+	mockRepo.On("GetAirportsByFuel", domain.FuelJetB, []domain.SortField(nil)).Return([]domain.Airport(nil), assert.AnError)
+	s := NewService(mockRepo, &config.Config{})
+
+	airports, err := s.GetAirportsByFuel(domain.FuelJetA, sort)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Airport{sampleAirport}, airports)
+
+	airports, err = s.GetAirportsByFuel(domain.FuelMogas, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Airport{}, airports)
+
+	_, err = s.GetAirportsByFuel(domain.FuelJetB, nil)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestUpdateAirportFuel(t *testing.T) {
+	stored := sampleAirport
+	stored.FuelTypes = []string{domain.Fuel100LL}
+	stored.FboName = "Old FBO"
+	stored.ManualOverrides = []string{"city"}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ModifyAirport", "TST").Return(&stored, nil)
+	mockRepo.On("ModifyAirport", "NOPE").Return((*domain.Airport)(nil), domain.ErrNotFound)
+	s := NewService(mockRepo, &config.Config{})
+
+	updated, err := s.UpdateAirportFuel("TST", domain.AirportFuel{
+		FuelTypes: []string{domain.Fuel100LL, domain.FuelJetA}, FboName: "Old FBO", FboPhone: "555-0100",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.Fuel100LL, domain.FuelJetA}, updated.FuelTypes)
+	assert.Equal(t, "555-0100", updated.FboPhone)
+	assert.Equal(t, []string{"city", "fuel_types", "fbo_phone"}, stored.ManualOverrides, "Only the fields changed are overridden")
+
+	_, err = s.UpdateAirportFuel("TST", domain.AirportFuel{FboName: "Old FBO", FboPhone: "555-0100"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, stored.FuelTypes, "No fuel clears the stored list")
+
+	_, err = s.UpdateAirportFuel("NOPE", domain.AirportFuel{})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"log"
+	"runtime"
+	"time"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+)
+
+// readinessTimeout bounds the database probes of a readiness check, so a hung
+// database fails the probe instead of the prober timing out.
+const readinessTimeout = 2 * time.Second
+
+// Readiness probes the database and reads the circuit breakers of the external
+// providers. The aviation API has no fallback, so its open breaker takes the
+// API down, while weather is only down once every provider is open.
+func (s *Service) Readiness(ctx context.Context) domain.Readiness {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	readiness := domain.Readiness{Providers: s.ProviderStatuses()}
+
+	database := domain.DependencyStatus{Name: "database", Status: domain.DependencyUp}
+	if err := s.repo.Ping(ctx); err != nil {
+		database.Status, database.Error = domain.DependencyDown, err.Error()
+	} else if last, err := s.repo.GetLastSyncedAt(ctx); err != nil {
+		log.Printf("WARN: Readiness: %v", err)
+	} else {
+		readiness.LastSyncAt = last
+	}
+
+	aviation := domain.DependencyStatus{Name: providerAviationAPI, Status: domain.DependencyUp}
+	switch breakerState(s.aviationBreaker) {
+	case utils.BreakerOpen:
+		aviation.Status, aviation.Error = domain.DependencyDown, "circuit breaker is open"
+	case utils.BreakerHalfOpen:
+		aviation.Status = domain.DependencyDegraded
+	}
+
+	weather := domain.DependencyStatus{Name: "weather", Status: domain.DependencyUp}
+	open, tripped := 0, 0
+	for _, p := range s.weatherProviders {
+		switch breakerState(p.breaker) {
+		case utils.BreakerOpen:
+			open++
+			tripped++
+		case utils.BreakerHalfOpen:
+			tripped++
+		}
+	}
+	switch {
+	case len(s.weatherProviders) > 0 && open == len(s.weatherProviders):
+		weather.Status, weather.Error = domain.DependencyDown, "every provider's circuit breaker is open"
+	case tripped > 0:
+		weather.Status = domain.DependencyDegraded
+	}
+
+	readiness.Dependencies = []domain.DependencyStatus{database, aviation, weather}
+	readiness.Ready = true
+	for _, d := range readiness.Dependencies {
+		if d.Status == domain.DependencyDown {
+			readiness.Ready = false
+		}
+	}
+	return readiness
+}
+
+// breakerState reads a breaker that may be disabled, which counts as closed.
+func breakerState(breaker *utils.CircuitBreaker) utils.BreakerState {
+	if breaker == nil {
+		return utils.BreakerClosed
+	}
+	return breaker.State()
+}
+
+// RuntimeStats reports goroutines, heap and the depth of the sync queues.
+func (s *Service) RuntimeStats() domain.RuntimeStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return domain.RuntimeStats{
+		Goroutines:        runtime.NumGoroutine(),
+		HeapAllocBytes:    mem.HeapAlloc,
+		HeapObjects:       mem.HeapObjects,
+		NumGC:             mem.NumGC,
+		SyncQueueDepth:    s.queueDepth(domain.QueuedSyncAirport),
+		SyncAllQueueDepth: s.queueDepth(domain.QueuedSyncAll),
+		SyncsInFlight:     s.syncFlight.InFlight(),
+		SyncWorkers:       s.syncWorkers.Load(),
+		ChangeSubscribers: s.airportChanges.Subscribers(),
+	}
+}
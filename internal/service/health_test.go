@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReadiness(t *testing.T) {
+	synced := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	cfg := &config.Config{WeatherProviders: []string{"weatherapi", "noaa"}, BreakerFailureThreshold: 1, BreakerCooldown: time.Minute}
+
+	t.Run("ready", func(t *testing.T) {
+		mockRepo := &mocks.RepositoryMock{}
+		mockRepo.On("Ping", mock.Anything).Return(nil)
+		mockRepo.On("GetLastSyncedAt", mock.Anything).Return(&synced, nil)
+		s := NewService(mockRepo, cfg).(*Service)
+
+		// One weather provider failing leaves the other to answer
+		s.weatherProviders[0].breaker.Record(errors.New("timeout"))
+
+		readiness := s.Readiness(context.Background())
+		assert.True(t, readiness.Ready)
+		assert.Equal(t, &synced, readiness.LastSyncAt)
+		assert.Equal(t, []domain.DependencyStatus{
+			{Name: "database", Status: domain.DependencyUp},
+			{Name: "aviationapi", Status: domain.DependencyUp},
+			{Name: "weather", Status: domain.DependencyDegraded},
+		}, readiness.Dependencies)
+		assert.Len(t, readiness.Providers, 3)
+	})
+
+	t.Run("database and aviation API down", func(t *testing.T) {
+		mockRepo := &mocks.RepositoryMock{}
+		mockRepo.On("Ping", mock.Anything).Return(errors.New("failed to ping database: connection refused"))
+		s := NewService(mockRepo, cfg).(*Service)
+		s.aviationBreaker.Record(errors.New("timeout"))
+
+		readiness := s.Readiness(context.Background())
+		assert.False(t, readiness.Ready)
+		assert.Nil(t, readiness.LastSyncAt)
+		assert.Equal(t, []domain.DependencyStatus{
+			{Name: "database", Status: domain.DependencyDown, Error: "failed to ping database: connection refused"},
+			{Name: "aviationapi", Status: domain.DependencyDown, Error: "circuit breaker is open"},
+			{Name: "weather", Status: domain.DependencyUp},
+		}, readiness.Dependencies)
+		mockRepo.AssertNotCalled(t, "GetLastSyncedAt", mock.Anything)
+	})
+}
+
+func TestRuntimeStats(t *testing.T) {
+	s := NewService(&mocks.RepositoryMock{}, &config.Config{}).(*Service)
+	_, unsubscribe := s.SubscribeAirportChanges()
+	defer unsubscribe()
+
+	stats := s.RuntimeStats()
+	assert.Positive(t, stats.Goroutines)
+	assert.Positive(t, stats.HeapAllocBytes)
+	assert.Zero(t, stats.SyncQueueDepth)
+	assert.Zero(t, stats.SyncWorkers)
+	assert.Equal(t, 1, stats.ChangeSubscribers)
+}
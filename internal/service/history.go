@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// DefaultHistoryHours is the weather history window when the caller leaves it
+// out.
+const DefaultHistoryHours = 24
+
+// MaxHistoryHours caps the weather history window, as each hour adds an
+// observation per airport and sync.
+const MaxHistoryHours = 72
+
+// GetWeatherHistories returns the observations stored for several airports
+// over the last hours, at most MaxHistoryHours, keyed by FAA code and oldest
+// first. Airports without observations are left out.
+func (s *Service) GetWeatherHistories(faas []string, hours int) (map[string][]domain.Observation, error) {
+	if hours <= 0 {
+		hours = DefaultHistoryHours
+	}
+	hours = min(hours, MaxHistoryHours)
+	if len(faas) == 0 {
+		return map[string][]domain.Observation{}, nil
+	}
+
+	histories, err := s.repo.GetWeatherHistories(faas, time.Now().Add(-time.Duration(hours)*time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weather history: %w", err)
+	}
+	return histories, nil
+}
+
+// GetFlightCategories classifies the latest stored observation of each
+// airport. Airports without one, or whose observation can't be classified,
+// are left out.
+func (s *Service) GetFlightCategories(faas []string) (map[string]string, error) {
+	categories := make(map[string]string, len(faas))
+	if len(faas) == 0 {
+		return categories, nil
+	}
+
+	observations, err := s.repo.GetObservations(faas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weather: %w", err)
+	}
+	for faa, obs := range observations {
+		if category := aviation.ObservationCategory(obs); category != "" {
+			categories[faa] = category
+		}
+	}
+	return categories, nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetWeatherHistories(t *testing.T) {
+	histories := map[string][]domain.Observation{"TST": {{Condition: "Mist", VisibilitySM: 4}, {Condition: "Clear", VisibilitySM: 10}}}
+	var since time.Time
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetWeatherHistories", []string{"TST", "DEN"}, mock.Anything).Run(func(args mock.Arguments) {
+		since = args.Get(1).(time.Time)
+	}).Return(histories, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	got, err := s.GetWeatherHistories([]string{"TST", "DEN"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, histories, got)
+	assert.WithinDuration(t, time.Now().Add(-DefaultHistoryHours*time.Hour), since, time.Minute)
+
+	_, err = s.GetWeatherHistories([]string{"TST", "DEN"}, 1000000)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-MaxHistoryHours*time.Hour), since, time.Minute, "The window is capped")
+
+	got, err = s.GetWeatherHistories(nil, 6)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	mockRepo.AssertNumberOfCalls(t, "GetWeatherHistories", 2)
+}
+
+func TestGetAirportsByPlace(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportsByPlace", "co", "").Return([]domain.Airport(nil), nil)
+	mockRepo.On("GetAirportsByPlace", "", "Denver").Return([]domain.Airport(nil), assert.AnError)
+	s := NewService(mockRepo, &config.Config{})
+
+	airports, err := s.GetAirportsByPlace("co", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Airport{}, airports)
+
+	_, err = s.GetAirportsByPlace("", "Denver")
+	assert.ErrorIs(t, err, assert.AnError)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetFlightCategories(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetObservations", []string{"VFR", "IFR", "UNK", "NOOBS"}).Return(map[string]domain.Observation{
+		"VFR": {VisibilitySM: 10},
+		"IFR": {VisibilitySM: 2},
+		"UNK": {Condition: "Cloudy"},
+	}, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	categories, err := s.GetFlightCategories([]string{"VFR", "IFR", "UNK", "NOOBS"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"VFR": "VFR", "IFR": "IFR"}, categories, "Airports without a classifiable observation are left out")
+
+	categories, err = s.GetFlightCategories(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, categories)
+	mockRepo.AssertExpectations(t)
+}
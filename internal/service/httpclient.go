@@ -0,0 +1,94 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/fixtures"
+)
+
+// Timeout of outbound requests when HTTP_TIMEOUT is 0
+const defaultHTTPTimeout = 10 * time.Second
+
+// outboundClients are the HTTP clients of the providers. They share one
+// transport, so its proxy, CA certificates and idle connections, and only
+// differ in their timeout. Depending on the provider mode the transport
+// answers the providers from fixtures or recordings, or records them.
+type outboundClients struct {
+	standard *http.Client
+	aviation *http.Client
+	weather  *http.Client
+}
+
+func newOutboundClients(cfg *config.Config) outboundClients {
+	transport, err := newTransport(cfg)
+	if err != nil {
+		log.Printf("WARN: Outbound requests use the default transport: %v", err)
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	var roundTripper http.RoundTripper = transport
+	switch cfg.ProviderMode {
+	case "mock":
+		roundTripper = fixtures.NewTransport(transport)
+	case "record":
+		roundTripper = fixtures.NewRecorder(cfg.ProviderRecordingsDir, transport)
+	case "replay":
+		roundTripper = fixtures.NewReplayer(cfg.ProviderRecordingsDir, transport)
+	}
+	client := func(timeout time.Duration) *http.Client {
+		if timeout <= 0 {
+			timeout = cfg.HTTPTimeout
+		}
+		if timeout <= 0 {
+			timeout = defaultHTTPTimeout
+		}
+		return &http.Client{Transport: roundTripper, Timeout: timeout}
+	}
+	return outboundClients{
+		standard: client(0),
+		aviation: client(cfg.AviationAPITimeout),
+		weather:  client(cfg.WeatherAPITimeout),
+	}
+}
+
+// newTransport builds the transport of outbound requests from the defaults of
+// net/http. Without HTTPProxyURL the proxy is still taken from the
+// environment.
+func newTransport(cfg *config.Config) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.HTTPProxyURL != "" {
+		proxy, err := url.Parse(cfg.HTTPProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if cfg.HTTPCAFile != "" {
+		pem, err := os.ReadFile(cfg.HTTPCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificate in %s", cfg.HTTPCAFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	if cfg.HTTPMaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.HTTPMaxIdleConns
+	}
+	if cfg.HTTPMaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.HTTPMaxIdleConnsPerHost
+	}
+	return transport, nil
+}
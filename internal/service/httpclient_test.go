@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOutboundClients(t *testing.T) {
+	clients := newOutboundClients(&config.Config{HTTPTimeout: 5 * time.Second, AviationAPITimeout: 20 * time.Second, HTTPMaxIdleConnsPerHost: 32})
+	assert.Equal(t, 5*time.Second, clients.standard.Timeout)
+	assert.Equal(t, 20*time.Second, clients.aviation.Timeout)
+	assert.Equal(t, 5*time.Second, clients.weather.Timeout, "Takes HTTP_TIMEOUT")
+	assert.Same(t, clients.standard.Transport, clients.weather.Transport, "Connections are shared")
+	assert.Equal(t, 32, clients.standard.Transport.(*http.Transport).MaxIdleConnsPerHost)
+
+	clients = newOutboundClients(&config.Config{})
+	assert.Equal(t, defaultHTTPTimeout, clients.aviation.Timeout)
+}
+
+func TestTransportProxy(t *testing.T) {
+	transport, err := newTransport(&config.Config{HTTPProxyURL: "http://proxy.corp:3128"})
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "https://api.aviationapi.com/v1/airports", nil)
+	proxy, err := transport.Proxy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "proxy.corp:3128", proxy.Host)
+}
+
+func TestTransportTrustsCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+	_, err := newOutboundClients(&config.Config{}).standard.Get(server.URL)
+	assert.Error(t, err, "The server certificate is unknown to the system")
+
+	resp, err := newOutboundClients(&config.Config{HTTPCAFile: caFile}).standard.Get(server.URL)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	_, err = newTransport(&config.Config{HTTPCAFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.ErrorContains(t, err, "failed to read CA file")
+}
+
+// TestSyncAllAirportsReplaysRecordings syncs against the provider responses
+// recorded in testdata/recordings with PROVIDER_MODE=record.
+func TestSyncAllAirportsReplaysRecordings(t *testing.T) {
+	var saved []domain.Airport
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ForEachAirportBySyncPriority", mock.Anything).Return([]domain.Airport{{Faa: "DEN"}, {Faa: "JFK"}}, nil)
+	mockRepo.On("GetProviderResponse", mock.Anything).Return((*domain.ProviderResponse)(nil), nil)
+	mockRepo.On("MergeAirports", mock.Anything).Run(func(args mock.Arguments) { saved = args.Get(0).([]domain.Airport) }).Return(nil)
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+	mockRepo.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRepo.On("RefreshDashboard").Return(nil).Maybe()
+	s := NewService(mockRepo, &config.Config{
+		ProviderMode: "replay", ProviderRecordingsDir: "testdata/recordings",
+		WeatherProviders: []string{"weatherapi"}, SyncChunkSize: 2, SyncMaxConcurrency: 1,
+	})
+
+	updated, err := s.SyncAllAirports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated)
+	if assert.Len(t, saved, 2) {
+		assert.Equal(t, "DENVER INTL", saved[0].FacilityName)
+		assert.Equal(t, "Partly cloudy", saved[0].Weather)
+		assert.Equal(t, "JOHN F KENNEDY INTL", saved[1].FacilityName)
+		assert.Equal(t, "Light rain", saved[1].Weather)
+	}
+	mockRepo.AssertExpectations(t)
+}
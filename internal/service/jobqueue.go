@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+const (
+	// How long a claimed job is hidden from other workers. Its worker renews
+	// the lease while running it, so only a stopped worker's job is taken over
+	jobLease = time.Minute
+	// Wait before the first retry of a job failing on a provider, doubling
+	// with each failure
+	jobRetryBackoff = 30 * time.Second
+	// How long done and failed jobs stay inspectable
+	jobRetention = 24 * time.Hour
+)
+
+// jobOutcome is how a job ended, as handed to the callers waiting on it.
+type jobOutcome struct {
+	result json.RawMessage
+	err    error
+}
+
+type syncAirportPayload struct {
+	Faa string `json:"faa"`
+}
+
+type syncAllResult struct {
+	Updated int `json:"updated"`
+}
+
+// SyncAirportQueued queues a sync of one airport and waits for it. Callers
+// asking for an FAA that is already queued or running wait for that sync
+// instead of adding another.
+func (s *Service) SyncAirportQueued(faa string) (*domain.SyncResult, error) {
+	result, err, _ := s.syncFlight.Do(faa, func() (*domain.SyncResult, error) {
+		// Not tied to one caller's request since the result is shared
+		body, err := s.runQueued(context.Background(), domain.QueuedSyncAirport, syncAirportPayload{Faa: faa})
+		if err != nil {
+			return nil, err
+		}
+		var result domain.SyncResult
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode sync result of %s: %w", faa, err)
+		}
+		return &result, nil
+	})
+	return result, err
+}
+
+// SyncAllAirportsQueued queues a sync of every airport and waits for it until
+// ctx is done; the sync itself goes on.
+func (s *Service) SyncAllAirportsQueued(ctx context.Context) (int, error) {
+	body, err := s.runQueued(ctx, domain.QueuedSyncAll, struct{}{})
+	var result syncAllResult
+	if body != nil {
+		if err := json.Unmarshal(body, &result); err != nil {
+			return 0, fmt.Errorf("failed to decode sync result: %w", err)
+		}
+	}
+	return result.Updated, err
+}
+
+// GetQueuedJobs lists the jobs of the queue.
+func (s *Service) GetQueuedJobs() ([]domain.QueuedJob, error) {
+	return s.repo.GetQueuedJobs()
+}
+
+// runQueued queues a job and waits for the outcome of its next attempt. A job
+// run by this process hands over its outcome, errors included; one run by
+// another process is polled for, and its error is only the message stored.
+func (s *Service) runQueued(ctx context.Context, jobType string, payload any) (json.RawMessage, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s job: %w", jobType, err)
+	}
+	job := &domain.QueuedJob{Type: jobType, Payload: body, MaxAttempts: max(s.cfg.JobMaxAttempts, 1)}
+	if err := s.repo.EnqueueJob(job); err != nil {
+		return nil, err
+	}
+
+	outcome := s.watchJob(job.ID, jobType)
+	defer s.unwatchJob(job.ID, jobType, outcome)
+	select {
+	case s.jobWake <- struct{}{}:
+	default: // A wake-up is already pending
+	}
+
+	poll := time.NewTicker(s.jobPollInterval())
+	defer poll.Stop()
+	for {
+		select {
+		case out := <-outcome:
+			return out.result, out.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-poll.C:
+			current, err := s.repo.GetQueuedJob(job.ID)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case current.Status == domain.JobStatusDone:
+				return current.Result, nil
+			case current.Status == domain.JobStatusFailed,
+				current.Status == domain.JobStatusQueued && current.Attempts > job.Attempts:
+				return nil, errors.New(current.LastError)
+			}
+		}
+	}
+}
+
+func (s *Service) watchJob(id int64, jobType string) chan jobOutcome {
+	outcome := make(chan jobOutcome, 1)
+	s.jobMu.Lock()
+	defer s.jobMu.Unlock()
+	s.jobWaiters[id] = append(s.jobWaiters[id], outcome)
+	s.jobsWaiting[jobType]++
+	return outcome
+}
+
+func (s *Service) unwatchJob(id int64, jobType string, outcome chan jobOutcome) {
+	s.jobMu.Lock()
+	defer s.jobMu.Unlock()
+	waiters := s.jobWaiters[id]
+	for i, w := range waiters {
+		if w == outcome {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(waiters) == 0 {
+		delete(s.jobWaiters, id)
+	} else {
+		s.jobWaiters[id] = waiters
+	}
+	s.jobsWaiting[jobType]--
+}
+
+// jobAttempted hands the outcome of an attempt to the callers of this process
+// waiting on the job.
+func (s *Service) jobAttempted(id int64, out jobOutcome) {
+	s.jobMu.Lock()
+	defer s.jobMu.Unlock()
+	for _, w := range s.jobWaiters[id] {
+		select {
+		case w <- out:
+		default: // Already has an outcome
+		}
+	}
+}
+
+// queueDepth is how many callers of this process wait on jobs of jobType.
+func (s *Service) queueDepth(jobType string) int {
+	s.jobMu.Lock()
+	defer s.jobMu.Unlock()
+	return s.jobsWaiting[jobType]
+}
+
+func (s *Service) jobPollInterval() time.Duration {
+	if s.cfg.JobPollInterval <= 0 {
+		return time.Second
+	}
+	return s.cfg.JobPollInterval
+}
+
+// RunJobWorkers runs JobWorkers workers on the job queue until ctx is done,
+// and prunes the jobs finished more than a day ago every hour. Workers of
+// every process share the queue; each job is run by one of them at a time.
+func (s *Service) RunJobWorkers(ctx context.Context) {
+	if s.cfg.JobWorkers <= 0 {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.cfg.JobWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runJobWorker(ctx)
+		}()
+	}
+
+	prune := time.NewTicker(time.Hour)
+	defer prune.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-prune.C:
+			if pruned, err := s.repo.PruneQueuedJobs(time.Now().Add(-jobRetention)); err != nil {
+				log.Printf("WARN: %v", err)
+			} else if pruned > 0 {
+				log.Printf("INFO: Pruned %d finished jobs", pruned)
+			}
+		}
+	}
+}
+
+func (s *Service) runJobWorker(ctx context.Context) {
+	poll := time.NewTicker(s.jobPollInterval())
+	defer poll.Stop()
+	for {
+		// Keep claiming while jobs are due
+		for ctx.Err() == nil && s.runNextJob(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		case <-s.jobWake:
+		}
+	}
+}
+
+// runNextJob claims and runs the job due first, reporting whether there was
+// one. A job failing on a provider is retried with backoff; other failures
+// are final. A job cut short by ctx is left for its lease to run out, so
+// another worker takes it over.
+func (s *Service) runNextJob(ctx context.Context) bool {
+	job, err := s.repo.ClaimQueuedJob(jobLease)
+	if err != nil {
+		log.Printf("WARN: %v", err)
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	stop := s.keepJobLease(job.ID)
+	result, err := s.runJob(ctx, job)
+	stop()
+
+	switch {
+	case err == nil:
+		if err := s.repo.FinishQueuedJob(job.ID, result); err != nil {
+			log.Printf("WARN: %v", err)
+		}
+	case ctx.Err() != nil:
+		log.Printf("INFO: %s job %d interrupted, left for another worker", job.Type, job.ID)
+	default:
+		var retryAfter time.Duration
+		if errors.Is(err, domain.ErrExternalAPI) {
+			retryAfter = retryBackoff(jobRetryBackoff, job.Attempts-1)
+		}
+		log.Printf("ERROR: %s job %d failed (attempt %d of %d): %v", job.Type, job.ID, job.Attempts, job.MaxAttempts, err)
+		if err := s.repo.FailQueuedJob(job.ID, err.Error(), retryAfter); err != nil {
+			log.Printf("WARN: %v", err)
+		}
+	}
+	s.jobAttempted(job.ID, jobOutcome{result: result, err: err})
+	return true
+}
+
+// keepJobLease renews the lease of a running job until the returned func is
+// called.
+func (s *Service) keepJobLease(id int64) func() {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(jobLease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.repo.ExtendQueuedJob(id, jobLease); err != nil {
+					log.Printf("WARN: %v", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// runJob runs one attempt of a job and encodes its result.
+func (s *Service) runJob(ctx context.Context, job *domain.QueuedJob) (json.RawMessage, error) {
+	switch job.Type {
+	case domain.QueuedSyncAirport:
+		var payload syncAirportPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("invalid %s payload: %w", job.Type, err)
+		}
+		result, err := s.SyncAirportByFAA(ctx, payload.Faa)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(result)
+	case domain.QueuedSyncAll:
+		updated, err := s.SyncAllAirports(ctx)
+		// The count is kept on failure too, telling an empty sync apart
+		body, encodeErr := json.Marshal(syncAllResult{Updated: updated})
+		if encodeErr != nil {
+			return nil, encodeErr
+		}
+		return body, err
+	}
+	return nil, fmt.Errorf("unknown job type %q", job.Type)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+	"aviation-weather/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunNextJobFailures(t *testing.T) {
+	airport := sampleAirport
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("GetAirportByFAA", "NONE").Return((*domain.Airport)(nil), nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{}, errors.New("weather down")
+	}
+
+	// A provider failure is retried with backoff
+	job := domain.QueuedJob{ID: 1, Type: domain.QueuedSyncAirport, Payload: json.RawMessage(`{"faa":"TST"}`), Attempts: 2, MaxAttempts: 3}
+	mockRepo.On("ClaimQueuedJob", jobLease).Return(&job, nil).Once()
+	mockRepo.On("FailQueuedJob", int64(1), mock.Anything, 2*jobRetryBackoff).Return(nil).Once()
+	outcome := s.watchJob(1, domain.QueuedSyncAirport)
+	assert.True(t, s.runNextJob(context.Background()))
+	assert.ErrorIs(t, (<-outcome).err, domain.ErrExternalAPI, "Waiters of this process get the error itself")
+	s.unwatchJob(1, domain.QueuedSyncAirport, outcome)
+
+	// Any other failure is final
+	missing := domain.QueuedJob{ID: 2, Type: domain.QueuedSyncAirport, Payload: json.RawMessage(`{"faa":"NONE"}`), Attempts: 1, MaxAttempts: 3}
+	mockRepo.On("ClaimQueuedJob", jobLease).Return(&missing, nil).Once()
+	mockRepo.On("FailQueuedJob", int64(2), "airport not found: NONE", time.Duration(0)).Return(nil).Once()
+	assert.True(t, s.runNextJob(context.Background()))
+
+	unknown := domain.QueuedJob{ID: 3, Type: "reindex", Attempts: 1}
+	mockRepo.On("ClaimQueuedJob", jobLease).Return(&unknown, nil).Once()
+	mockRepo.On("FailQueuedJob", int64(3), `unknown job type "reindex"`, time.Duration(0)).Return(nil).Once()
+	assert.True(t, s.runNextJob(context.Background()))
+
+	mockRepo.On("ClaimQueuedJob", jobLease).Return((*domain.QueuedJob)(nil), nil).Once()
+	assert.False(t, s.runNextJob(context.Background()), "Nothing is due")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncAllAirportsQueuedPollsOtherWorkers(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("EnqueueJob", &domain.QueuedJob{Type: domain.QueuedSyncAll, Payload: json.RawMessage(`{}`), MaxAttempts: 3}).
+		Run(func(args mock.Arguments) { args.Get(0).(*domain.QueuedJob).ID = 9 }).Return(nil)
+	mockRepo.On("GetQueuedJob", int64(9)).Return(&domain.QueuedJob{ID: 9, Status: domain.JobStatusRunning, Attempts: 1}, nil).Once()
+	mockRepo.On("GetQueuedJob", int64(9)).Return(&domain.QueuedJob{ID: 9, Status: domain.JobStatusDone, Attempts: 1, Result: json.RawMessage(`{"updated":3}`)}, nil).Once()
+	// No worker in this process, another one runs the job
+	s := NewService(mockRepo, &config.Config{JobPollInterval: time.Millisecond, JobMaxAttempts: 3}).(*Service)
+
+	updated, err := s.SyncAllAirportsQueued(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, updated)
+
+	mockRepo.On("GetQueuedJob", int64(9)).Return(&domain.QueuedJob{ID: 9, Status: domain.JobStatusQueued, Attempts: 1, LastError: "weather down"}, nil).Once()
+	_, err = s.SyncAllAirportsQueued(context.Background())
+	assert.EqualError(t, err, "weather down", "A failed attempt ends the wait, its retry goes on")
+	assert.Zero(t, s.RuntimeStats().SyncAllQueueDepth)
+	mockRepo.AssertExpectations(t)
+}
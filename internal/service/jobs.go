@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/scheduler"
+)
+
+// SyncStaleAirports refreshes only the airports whose weather is older than
+// SyncStaleAfter, or 12 hours when unset. Having none stale is no error.
+func (s *Service) SyncStaleAirports(ctx context.Context) (int, error) {
+	staleAfter := time.Duration(s.syncStaleAfter.Load())
+	if staleAfter <= 0 {
+		staleAfter = defaultStaleAfter
+	}
+
+	updated, _, err := s.syncAirportStream(ctx, s.spreadInterval(ctx), func(fn func(domain.Airport) error) error {
+		return s.repo.ForEachAirportNeedingSync(ctx, staleAfter, fn)
+	})
+	return updated, err
+}
+
+// PruneLogs deletes the alerts, webhook deliveries and change feed entries
+// older than LogRetention and returns how many went.
+func (s *Service) PruneLogs(ctx context.Context) (int64, error) {
+	if s.cfg.LogRetention <= 0 {
+		return 0, fmt.Errorf("log retention is not configured")
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	pruned, err := s.repo.PruneLogs(time.Now().Add(-s.cfg.LogRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune logs: %w", err)
+	}
+	return pruned, nil
+}
+
+// historyPartitionsAhead is how many monthly weather_history partitions,
+// counting the current month, PartitionWeatherHistory keeps created.
+const historyPartitionsAhead = 3
+
+// PartitionWeatherHistory creates the weather_history partitions of the
+// current and upcoming months, and drops those of the months older than
+// WeatherHistoryRetention unless it is 0. It returns the partitions created
+// and dropped.
+func (s *Service) PartitionWeatherHistory(ctx context.Context) ([]string, []string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	created, err := s.repo.CreateWeatherHistoryPartitions(now, historyPartitionsAhead)
+	if err != nil {
+		return created, nil, fmt.Errorf("failed to create weather history partitions: %w", err)
+	}
+	if s.cfg.WeatherHistoryRetention <= 0 {
+		return created, []string{}, nil
+	}
+
+	dropped, err := s.repo.DropWeatherHistoryPartitions(now.Add(-s.cfg.WeatherHistoryRetention))
+	if err != nil {
+		return created, dropped, fmt.Errorf("failed to drop weather history partitions: %w", err)
+	}
+	return created, dropped, nil
+}
+
+// RetryFailedAirports syncs again the airports whose last sync failed and
+// whose backoff has passed. Those failing again back off further.
+func (s *Service) RetryFailedAirports(ctx context.Context) (int, error) {
+	failures, err := s.repo.GetDueSyncFailures()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sync failures: %w", err)
+	}
+	if len(failures) == 0 {
+		return 0, nil
+	}
+
+	faas := make([]string, 0, len(failures))
+	for _, f := range failures {
+		faas = append(faas, f.Faa)
+	}
+	airports, err := s.repo.GetAirportsByFAAs(faas)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get airports: %w", err)
+	}
+	return s.syncAirports(ctx, airports)
+}
+
+// GetSchedulerJobs returns the job definitions stored in the database, which
+// override those of the config. See GetJobs for the definitions in effect.
+func (s *Service) GetSchedulerJobs() ([]domain.SchedulerJob, error) {
+	jobs, err := s.repo.GetSchedulerJobs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// GetJobs returns the definition in effect of every job: that of the config,
+// overridden by the database.
+func (s *Service) GetJobs() ([]domain.SchedulerJob, error) {
+	overrides, err := s.GetSchedulerJobs()
+	if err != nil {
+		return nil, err
+	}
+	return scheduler.Merge(scheduler.FromConfig(s.cfg), overrides), nil
+}
+
+// PauseJob stops the scheduler from running a job until ResumeJob.
+func (s *Service) PauseJob(name string) error {
+	return s.setJobEnabled(name, false)
+}
+
+// ResumeJob lets the scheduler run a paused job again on its schedule.
+func (s *Service) ResumeJob(name string) error {
+	return s.setJobEnabled(name, true)
+}
+
+func (s *Service) setJobEnabled(name string, enabled bool) error {
+	if err := knownJob(name); err != nil {
+		return err
+	}
+	if err := s.repo.SetSchedulerJobEnabled(name, enabled); err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+	return nil
+}
+
+// RunJob asks the scheduler to run a job, paused or not, at its next refresh.
+func (s *Service) RunJob(name string) error {
+	if err := knownJob(name); err != nil {
+		return err
+	}
+	if err := s.repo.RequestSchedulerJobRun(name); err != nil {
+		return fmt.Errorf("failed to request job run: %w", err)
+	}
+	return nil
+}
+
+// ClaimJobRuns takes the pending run requests of RunJob, returning the names
+// of the jobs to run.
+func (s *Service) ClaimJobRuns() ([]string, error) {
+	names, err := s.repo.ClaimSchedulerJobRuns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim job runs: %w", err)
+	}
+	return names, nil
+}
+
+// knownJob returns domain.ErrJobNotFound unless name is a job of the registry.
+func knownJob(name string) error {
+	if slices.Contains(domain.SchedulerJobNames, name) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", domain.ErrJobNotFound, name)
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+	"aviation-weather/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSyncStaleAirports(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ForEachAirportNeedingSync", mock.Anything, defaultStaleAfter).Return([]domain.Airport{sampleAirport}, nil).Once()
+	mockRepo.On("MergeAirports", mock.Anything).Return(nil)
+	mockRepo.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRepo.On("RefreshDashboard").Return(nil).Maybe()
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Clear skies"}, nil
+	}
+
+	updated, err := s.SyncStaleAirports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+
+	mockRepo.On("ForEachAirportNeedingSync", mock.Anything, defaultStaleAfter).Return([]domain.Airport{}, nil).Once()
+	updated, err = s.SyncStaleAirports(context.Background())
+	assert.NoError(t, err, "Nothing stale is no error")
+	assert.Equal(t, 0, updated)
+	mockRepo.AssertNotCalled(t, "ForEachAirportBySyncPriority", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPruneLogs(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	_, err := s.PruneLogs(context.Background())
+	assert.EqualError(t, err, "log retention is not configured")
+
+	mockRepo.On("PruneLogs", mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) > 47*time.Hour && time.Since(before) < 49*time.Hour
+	})).Return(int64(5), nil).Once()
+	s = NewService(mockRepo, &config.Config{LogRetention: 48 * time.Hour}).(*Service)
+	pruned, err := s.PruneLogs(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), pruned)
+
+	mockRepo.On("PruneLogs", mock.Anything).Return(int64(0), errors.New("db down")).Once()
+	_, err = s.PruneLogs(context.Background())
+	assert.EqualError(t, err, "failed to prune logs: db down")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestScheduledSyncIsSpread(t *testing.T) {
+	second := sampleAirport
+	second.Faa = "TS2"
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("CountAirports", mock.Anything).Return(2, nil).Once()
+	mockRepo.On("ForEachAirportBySyncPriority", mock.Anything).Return([]domain.Airport{sampleAirport, second}, nil)
+	mockRepo.On("MergeAirports", mock.Anything).Return(nil)
+	mockRepo.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRepo.On("RefreshDashboard").Return(nil).Maybe()
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+
+	s := NewService(mockRepo, &config.Config{SyncChunkSize: 1, SyncSpread: 200 * time.Millisecond}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Clear skies"}, nil
+	}
+
+	start := time.Now()
+	updated, err := s.SyncAllAirports(Scheduled(context.Background()))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "The second chunk waits half the window")
+
+	start = time.Now()
+	_, err = s.SyncAllAirports(context.Background())
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 100*time.Millisecond, "Unscheduled syncs run at full speed")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestJobControl(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetSchedulerJobs").Return([]domain.SchedulerJob{{Name: domain.JobSyncAll, Enabled: false}}, nil)
+	mockRepo.On("SetSchedulerJobEnabled", domain.JobSyncAll, false).Return(nil)
+	mockRepo.On("SetSchedulerJobEnabled", domain.JobPruneLogs, true).Return(nil)
+	mockRepo.On("RequestSchedulerJobRun", domain.JobSyncStale).Return(nil)
+	s := NewService(mockRepo, &config.Config{SyncSchedule: "0 0,12 * * *"}).(*Service)
+
+	jobs, err := s.GetJobs()
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SchedulerJob{Name: domain.JobSyncAll, Schedule: "0 0,12 * * *"}, jobs[0], "A paused job keeps its configured schedule")
+	assert.Len(t, jobs, len(domain.SchedulerJobNames))
+
+	assert.NoError(t, s.PauseJob(domain.JobSyncAll))
+	assert.NoError(t, s.ResumeJob(domain.JobPruneLogs))
+	assert.NoError(t, s.RunJob(domain.JobSyncStale))
+	assert.ErrorIs(t, s.RunJob("refresh_pireps"), domain.ErrJobNotFound)
+	assert.ErrorIs(t, s.PauseJob("refresh_pireps"), domain.ErrJobNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRetryFailedAirports(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetDueSyncFailures").Return([]domain.SyncFailure{}, nil).Once()
+	s := NewService(mockRepo, &config.Config{SyncRetryBackoff: 15 * time.Minute}).(*Service)
+
+	updated, err := s.RetryFailedAirports(context.Background())
+	assert.NoError(t, err, "Nothing to retry is no error")
+	assert.Equal(t, 0, updated)
+
+	mockRepo.On("GetDueSyncFailures").Return([]domain.SyncFailure{{Faa: "TST", Attempts: 1}}, nil).Once()
+	mockRepo.On("GetAirportsByFAAs", []string{"TST"}).Return([]domain.Airport{sampleAirport}, nil)
+	mockRepo.On("MergeAirports", mock.Anything).Return(nil)
+	mockRepo.On("RecordSyncOutcome", []string{"TST"}, []domain.SyncFailure(nil), 15*time.Minute).Return(nil)
+	mockRepo.On("RefreshDashboard").Return(nil).Maybe()
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Clear skies"}, nil
+	}
+
+	updated, err = s.RetryFailedAirports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPartitionWeatherHistory(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("CreateWeatherHistoryPartitions", mock.Anything, historyPartitionsAhead).Return([]string{"weather_history_y2025m03"}, nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+
+	created, dropped, err := s.PartitionWeatherHistory(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"weather_history_y2025m03"}, created)
+	assert.Empty(t, dropped, "No retention keeps every month")
+	mockRepo.AssertNotCalled(t, "DropWeatherHistoryPartitions", mock.Anything)
+
+	s.cfg.WeatherHistoryRetention = 365 * 24 * time.Hour
+	mockRepo.On("DropWeatherHistoryPartitions", mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) > 364*24*time.Hour
+	})).Return([]string{"weather_history_y2024m01"}, nil)
+	_, dropped, err = s.PartitionWeatherHistory(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"weather_history_y2024m01"}, dropped)
+	mockRepo.AssertExpectations(t)
+}
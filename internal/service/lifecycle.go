@@ -0,0 +1,39 @@
+package service
+
+import (
+	"fmt"
+
+	"aviation-weather/internal/domain"
+)
+
+// ChangeAirportStatus moves an airport to another lifecycle status and
+// records the change, with its reason, in the airport's status history.
+func (s *Service) ChangeAirportStatus(faa, status, reason string) (*domain.AirportStatusChange, error) {
+	defer s.airportLocks.Lock(faa)()
+
+	change, err := s.repo.ChangeAirportStatus(faa, status, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to change status of %s: %w", faa, err)
+	}
+	s.invalidateAirports(faa)
+	return change, nil
+}
+
+// GetAirportStatusHistory lists the lifecycle status changes of an airport,
+// oldest first.
+func (s *Service) GetAirportStatusHistory(faa string) ([]domain.AirportStatusChange, error) {
+	if _, err := s.GetAirportByFAA(faa); err != nil {
+		return nil, err
+	}
+	history, err := s.repo.GetAirportStatusHistory(faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history of %s: %w", faa, err)
+	}
+	return history, nil
+}
+
+// skipsSync reports whether batch syncs leave an airport out: closed and
+// decommissioned airports are, unless SyncClosedAirports is set.
+func (s *Service) skipsSync(a *domain.Airport) bool {
+	return a.IsOutOfService() && !s.cfg.SyncClosedAirports
+}
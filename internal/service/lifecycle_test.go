@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+	"aviation-weather/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChangeAirportStatus(t *testing.T) {
+	change := domain.AirportStatusChange{ID: 1, Faa: "TST", FromStatus: "active", ToStatus: "closed", Reason: "Runway works"}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ChangeAirportStatus", "TST", "closed", "Runway works").Return(&change, nil)
+	mockRepo.On("ChangeAirportStatus", "TST", "active", "").Return((*domain.AirportStatusChange)(nil), domain.ErrInvalidTransition)
+	s := NewService(mockRepo, &config.Config{})
+
+	got, err := s.ChangeAirportStatus("TST", domain.LifecycleClosed, "Runway works")
+	assert.NoError(t, err)
+	assert.Equal(t, &change, got)
+
+	_, err = s.ChangeAirportStatus("TST", domain.LifecycleActive, "")
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+}
+
+func TestGetAirportStatusHistory(t *testing.T) {
+	airport := sampleAirport
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("GetAirportByFAA", "NOPE").Return((*domain.Airport)(nil), nil)
+	mockRepo.On("GetAirportStatusHistory", "TST").Return([]domain.AirportStatusChange{{ID: 1, Faa: "TST"}}, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	history, err := s.GetAirportStatusHistory("TST")
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	_, err = s.GetAirportStatusHistory("NOPE")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestSyncAllAirportsSkipsClosed(t *testing.T) {
+	open, seasonal, closed, decommissioned := sampleAirport, sampleAirport, sampleAirport, sampleAirport
+	open.Faa, seasonal.Faa, closed.Faa, decommissioned.Faa = "OPN", "SSN", "CLS", "DCM"
+	seasonal.LifecycleStatus = domain.LifecycleSeasonal
+	closed.LifecycleStatus = domain.LifecycleClosed
+	decommissioned.LifecycleStatus = domain.LifecycleDecommissioned
+	airports := []domain.Airport{open, seasonal, closed, decommissioned}
+
+	for _, tt := range []struct {
+		name          string
+		syncClosed    bool
+		expectedSaved []string
+	}{
+		{"skipped by default", false, []string{"OPN", "SSN"}},
+		{"included when configured", true, []string{"OPN", "SSN", "CLS", "DCM"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved []string
+			mockRepo := &mocks.RepositoryMock{}
+			mockRepo.On("ForEachAirportBySyncPriority", mock.Anything).Return(airports, nil)
+			mockRepo.On("MergeAirports", mock.Anything).Run(func(args mock.Arguments) {
+				saved = faaCodes(args.Get(0).([]domain.Airport))
+			}).Return(nil)
+			mockRepo.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockRepo.On("RefreshDashboard").Return(nil)
+			mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+			mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+			s := NewService(mockRepo, &config.Config{SyncChunkSize: 10, SyncClosedAirports: tt.syncClosed}).(*Service)
+			s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+				return domain.Observation{Condition: "Clear skies"}, nil
+			}
+
+			updated, err := s.SyncAllAirports(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.expectedSaved), updated)
+			assert.Equal(t, tt.expectedSaved, saved)
+		})
+	}
+}
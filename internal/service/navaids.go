@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// Defaults of the navaid searches when the caller leaves them out.
+const (
+	DefaultNavaidRadiusNM        = 100
+	DefaultNavaidLimit           = 10
+	DefaultAirportNavaidRadiusNM = 40
+)
+
+// GetNearestNavaids lists up to limit navaids within radiusNM of a position,
+// nearest first.
+func (s *Service) GetNearestNavaids(lat, lon, radiusNM float64, limit int) ([]domain.NearbyNavaid, error) {
+	if radiusNM <= 0 {
+		radiusNM = DefaultNavaidRadiusNM
+	}
+	if limit <= 0 {
+		limit = DefaultNavaidLimit
+	}
+
+	nearby, err := s.repo.GetNearestNavaids(aviation.Point{Lat: lat, Lon: lon}, radiusNM, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get navaids near %.4f,%.4f: %w", lat, lon, err)
+	}
+	return roundNavaids(nearby), nil
+}
+
+// GetAirportNavaids lists every navaid within radiusNM of one airport,
+// nearest first.
+func (s *Service) GetAirportNavaids(faa string, radiusNM float64) ([]domain.NearbyNavaid, error) {
+	if radiusNM <= 0 {
+		radiusNM = DefaultAirportNavaidRadiusNM
+	}
+
+	airport, err := s.GetAirportByFAA(faa)
+	if err != nil {
+		return nil, err
+	}
+	lat, lon, ok := airport.Coordinates()
+	if !ok {
+		return nil, fmt.Errorf("%w: no coordinates for %s", domain.ErrNoData, faa)
+	}
+
+	nearby, err := s.repo.GetNearestNavaids(aviation.Point{Lat: lat, Lon: lon}, radiusNM, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get navaids near %s: %w", faa, err)
+	}
+	return roundNavaids(nearby), nil
+}
+
+// roundNavaids rounds distances to a tenth of a mile, never returning nil so
+// an empty search encodes as [].
+func roundNavaids(nearby []domain.NearbyNavaid) []domain.NearbyNavaid {
+	rounded := make([]domain.NearbyNavaid, 0, len(nearby))
+	for _, n := range nearby {
+		n.DistanceNM = round1(n.DistanceNM)
+		rounded = append(rounded, n)
+	}
+	return rounded
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNearestNavaids(t *testing.T) {
+	point := aviation.Point{Lat: 40.64, Lon: -73.78}
+	cri := domain.Navaid{Ident: "CRI", Type: domain.NavaidVOR, Latitude: 40.6124, Longitude: -73.8242}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetNearestNavaids", point, 25.0, 3).Return([]domain.NearbyNavaid{{Navaid: cri, DistanceNM: 2.4567}}, nil)
+	mockRepo.On("GetNearestNavaids", point, float64(DefaultNavaidRadiusNM), DefaultNavaidLimit).Return([]domain.NearbyNavaid(nil), nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	nearby, err := s.GetNearestNavaids(40.64, -73.78, 25, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.NearbyNavaid{{Navaid: cri, DistanceNM: 2.5}}, nearby)
+
+	nearby, err = s.GetNearestNavaids(40.64, -73.78, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.NearbyNavaid{}, nearby)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAirportNavaids(t *testing.T) {
+	jfk := sampleAirport
+	jfk.Faa, jfk.Latitude, jfk.Longitude = "JFK", deg(40.6398), deg(-73.7789)
+	noPosition := sampleAirport
+	noPosition.Faa, noPosition.Latitude, noPosition.Longitude = "NC", nil, nil
+	point := aviation.Point{Lat: 40.6398, Lon: -73.7789}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "JFK").Return(&jfk, nil)
+	mockRepo.On("GetAirportByFAA", "NC").Return(&noPosition, nil)
+	mockRepo.On("GetAirportByFAA", "ZZZ").Return((*domain.Airport)(nil), nil)
+	mockRepo.On("GetNearestNavaids", point, float64(DefaultAirportNavaidRadiusNM), 0).Return([]domain.NearbyNavaid{
+		{Navaid: domain.Navaid{Ident: "CRI"}, DistanceNM: 1.94},
+		{Navaid: domain.Navaid{Ident: "DPK"}, DistanceNM: 24.06},
+	}, nil)
+	mockRepo.On("GetNearestNavaids", point, 10.0, 0).Return([]domain.NearbyNavaid(nil), errors.New("boom"))
+	s := NewService(mockRepo, &config.Config{})
+
+	nearby, err := s.GetAirportNavaids("JFK", 0)
+	assert.NoError(t, err)
+	if assert.Len(t, nearby, 2) {
+		assert.Equal(t, 1.9, nearby[0].DistanceNM)
+		assert.Equal(t, "DPK", nearby[1].Ident)
+		assert.Equal(t, 24.1, nearby[1].DistanceNM)
+	}
+
+	_, err = s.GetAirportNavaids("JFK", 10)
+	assert.EqualError(t, err, "failed to get navaids near JFK: boom")
+
+	_, err = s.GetAirportNavaids("NC", 0)
+	assert.ErrorIs(t, err, domain.ErrNoData)
+
+	_, err = s.GetAirportNavaids("ZZZ", 0)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// RefreshNOTAMs replaces the stored NOTAMs of every airport on a watchlist or
+// subscription with those the FAA has for it and returns how many airports it
+// refreshed. Airports without an ICAO code have none. An airport that fails is
+// logged and keeps its stored NOTAMs while the others are refreshed, and the
+// failures are returned together at the end.
+func (s *Service) RefreshNOTAMs(ctx context.Context) (int, error) {
+	if s.cfg.NOTAMClientID == "" {
+		return 0, errors.New("NOTAM API credentials are not configured")
+	}
+
+	faas, err := s.repo.GetFollowedFAAs()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get followed airports: %w", err)
+	}
+	airports, err := s.repo.GetAirportsByFAAs(faas)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get airports: %w", err)
+	}
+
+	refreshed := 0
+	var failures []error
+	for _, a := range airports {
+		if a.Icao == "" {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if err := s.refreshNOTAMs(ctx, &a); err != nil {
+			log.Printf("ERROR: %v", err)
+			failures = append(failures, err)
+			continue
+		}
+		refreshed++
+	}
+	err = ctx.Err()
+	if len(failures) > 0 {
+		err = errors.Join(err, fmt.Errorf("failed to refresh NOTAMs of %d airports: %w", len(failures), errors.Join(failures...)))
+	}
+	return refreshed, err
+}
+
+func (s *Service) refreshNOTAMs(ctx context.Context, a *domain.Airport) error {
+	notams, err := s.FetchNOTAMs(ctx, a.Icao)
+	if err != nil {
+		return fmt.Errorf("%w: failed to fetch NOTAMs for %s: %w", domain.ErrExternalAPI, a.Faa, err)
+	}
+	if err := s.repo.ReplaceNOTAMs(a.Faa, notams); err != nil {
+		return fmt.Errorf("failed to save NOTAMs for %s: %w", a.Faa, err)
+	}
+	return nil
+}
+
+// GetAirportNOTAMs returns the stored NOTAMs of an airport that have not
+// ended.
+func (s *Service) GetAirportNOTAMs(faa string) ([]domain.NOTAM, error) {
+	if _, err := s.GetAirportByFAA(faa); err != nil {
+		return nil, err
+	}
+
+	notams, err := s.repo.GetNOTAMs(faa, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NOTAMs for %s: %w", faa, err)
+	}
+	return notams, nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRefreshNOTAMs(t *testing.T) {
+	closure := []domain.NOTAM{{ID: "NOTAM_1_73849637", Number: "10/132", Type: "N", Text: "RWY 16R/34L CLSD"}}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetFollowedFAAs").Return([]string{"ASE", "DEN", "NI"}, nil)
+	mockRepo.On("GetAirportsByFAAs", []string{"ASE", "DEN", "NI"}).Return([]domain.Airport{
+		{Faa: "ASE", Icao: "KASE"}, {Faa: "DEN", Icao: "KDEN"}, {Faa: "NI"},
+	}, nil)
+	mockRepo.On("ReplaceNOTAMs", "ASE", []domain.NOTAM{}).Return(nil)
+	mockRepo.On("ReplaceNOTAMs", "DEN", closure).Return(nil)
+
+	_, err := NewService(mockRepo, &config.Config{}).RefreshNOTAMs(context.Background())
+	assert.EqualError(t, err, "NOTAM API credentials are not configured")
+
+	s := NewService(mockRepo, &config.Config{NOTAMClientID: "app", NOTAMClientSecret: "s3cret"}).(*Service)
+	var fetched []string
+	s.FetchNOTAMs = func(ctx context.Context, icao string) ([]domain.NOTAM, error) {
+		fetched = append(fetched, icao)
+		if icao == "KDEN" {
+			return closure, nil
+		}
+		return []domain.NOTAM{}, nil
+	}
+
+	refreshed, err := s.RefreshNOTAMs(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, refreshed)
+	assert.Equal(t, []string{"KASE", "KDEN"}, fetched, "Airports without an ICAO code are skipped")
+
+	// A failing airport doesn't keep the others from being refreshed
+	fetched = nil
+	s.FetchNOTAMs = func(ctx context.Context, icao string) ([]domain.NOTAM, error) {
+		fetched = append(fetched, icao)
+		if icao == "KASE" {
+			return nil, errors.New("timeout")
+		}
+		return closure, nil
+	}
+	refreshed, err = s.RefreshNOTAMs(context.Background())
+	assert.ErrorIs(t, err, domain.ErrExternalAPI)
+	assert.EqualError(t, err, "failed to refresh NOTAMs of 1 airports: external API error: failed to fetch NOTAMs for ASE: timeout")
+	assert.Equal(t, 1, refreshed)
+	assert.Equal(t, []string{"KASE", "KDEN"}, fetched)
+	mockRepo.AssertNumberOfCalls(t, "ReplaceNOTAMs", 3)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAirportNOTAMs(t *testing.T) {
+	closure := domain.NOTAM{ID: "NOTAM_1_73849637", Faa: "TST", Number: "10/132", Text: "RWY 16R/34L CLSD"}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+	mockRepo.On("GetNOTAMs", "TST", mock.Anything).Return([]domain.NOTAM{closure}, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	notams, err := s.GetAirportNOTAMs("TST")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.NOTAM{closure}, notams)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"fmt"
+
+	"aviation-weather/internal/domain"
+)
+
+// CreateAirportNote records an operational note on an airport.
+func (s *Service) CreateAirportNote(note *domain.AirportNote) error {
+	if _, err := s.GetAirportByFAA(note.Faa); err != nil {
+		return err
+	}
+	if err := s.repo.CreateAirportNote(note); err != nil {
+		return fmt.Errorf("failed to create note on %s: %w", note.Faa, err)
+	}
+	return nil
+}
+
+// GetAirportNotes lists the notes of an airport that have not expired,
+// oldest first.
+func (s *Service) GetAirportNotes(faa string) ([]domain.AirportNote, error) {
+	if _, err := s.GetAirportByFAA(faa); err != nil {
+		return nil, err
+	}
+	notes, err := s.repo.GetAirportNotes(faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes of %s: %w", faa, err)
+	}
+	return notes, nil
+}
+
+// DeleteAirportNote removes a note of an airport.
+func (s *Service) DeleteAirportNote(faa string, id int64) error {
+	return s.repo.DeleteAirportNote(faa, id)
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAirportNote(t *testing.T) {
+	airport := sampleAirport
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("GetAirportByFAA", "NOPE").Return((*domain.Airport)(nil), nil)
+	mockRepo.On("CreateAirportNote", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*domain.AirportNote).ID = 5
+	}).Return(nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	note := domain.AirportNote{Faa: "TST", Body: "Fuel unavailable this week"}
+	assert.NoError(t, s.CreateAirportNote(&note))
+	assert.Equal(t, int64(5), note.ID)
+
+	assert.ErrorIs(t, s.CreateAirportNote(&domain.AirportNote{Faa: "NOPE", Body: "x"}), domain.ErrNotFound)
+	mockRepo.AssertNumberOfCalls(t, "CreateAirportNote", 1)
+}
+
+func TestGetAirportNotes(t *testing.T) {
+	airport := sampleAirport
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("GetAirportNotes", "TST").Return([]domain.AirportNote{{ID: 5, Faa: "TST", Body: "Fuel unavailable this week"}}, nil)
+	mockRepo.On("DeleteAirportNote", "TST", int64(6)).Return(domain.ErrNoteNotFound)
+	s := NewService(mockRepo, &config.Config{})
+
+	notes, err := s.GetAirportNotes("TST")
+	assert.NoError(t, err)
+	assert.Len(t, notes, 1)
+
+	assert.ErrorIs(t, s.DeleteAirportNote("TST", 6), domain.ErrNoteNotFound)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/notify"
+)
+
+// notificationTimeout bounds one send, so a stuck relay doesn't pile up goroutines.
+const notificationTimeout = 30 * time.Second
+
+// newNotifiers builds the notifier of each available channel.
+func newNotifiers(cfg *config.Config, client *http.Client) map[string]notify.Notifier {
+	notifiers := map[string]notify.Notifier{
+		domain.ChannelSlack: notify.NewSlack(client),
+	}
+	if cfg.SMTPHost != "" {
+		notifiers[domain.ChannelEmail] = notify.NewSMTP(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+	}
+	return notifiers
+}
+
+// sendAlertNotifications sends one alert to the targets of the rule that raised
+// it, in the background. Failures are only logged.
+func (s *Service) sendAlertNotifications(rule domain.AlertRule, a domain.Airport, al domain.Alert, obs domain.Observation) {
+	msg, err := notify.AlertMessage(notify.AlertData{Airport: a, Rule: rule, Alert: al, Observation: obs})
+	if err != nil {
+		log.Printf("WARN: Failed to render notification of rule %d: %v", rule.ID, err)
+		return
+	}
+
+	for _, target := range rule.Notify {
+		notifier, ok := s.notifiers[target.Channel]
+		if !ok {
+			log.Printf("WARN: Rule %d notifies over %s, which is not configured", rule.ID, target.Channel)
+			continue
+		}
+
+		s.notifications.Add(1)
+		go func(target domain.NotifyTarget) {
+			defer s.notifications.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+			defer cancel()
+			if err := notifier.Send(ctx, target.Target, msg); err != nil {
+				log.Printf("WARN: Failed to notify %s %s of rule %d: %v", target.Channel, target.Target, rule.ID, err)
+			}
+		}(target)
+	}
+}
+
+// SendTestNotification sends a sample alert to target, so a channel can be
+// checked before rules rely on it.
+func (s *Service) SendTestNotification(ctx context.Context, target domain.NotifyTarget) error {
+	notifier, ok := s.notifiers[target.Channel]
+	if !ok {
+		return fmt.Errorf("%w: %s", domain.ErrChannelDisabled, target.Channel)
+	}
+
+	now := time.Now().UTC()
+	msg, err := notify.AlertMessage(notify.AlertData{
+		Airport: domain.Airport{Faa: "TEST", FacilityName: "Test Airport"},
+		Rule:    domain.AlertRule{Name: "Test notification"},
+		Alert:   domain.Alert{Faa: "TEST", Metric: domain.MetricWindSpeed, Value: "30", Message: "TEST: this is a test notification"},
+		Observation: domain.Observation{
+			Condition:  "Clear",
+			RawMETAR:   "TEST " + now.Format("021504") + "Z 27030KT 10SM CLR 20/10 A2992",
+			ObservedAt: now,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render test notification: %w", err)
+	}
+
+	if err := notifier.Send(ctx, target.Target, msg); err != nil {
+		return fmt.Errorf("%w: failed to send test notification: %w", domain.ErrExternalAPI, err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+const (
+	// Events claimed per poll of the outbox
+	outboxBatchSize = 100
+	// How long a claimed event is hidden from other dispatchers, after which
+	// a crashed dispatcher's events are delivered again
+	outboxLease = time.Minute
+)
+
+// maxRetryBackoff caps the backoff of failing outbox events and queued jobs.
+const maxRetryBackoff = time.Hour
+
+// airportEvent is an event announcing an airport update, sent once the
+// update is saved.
+type airportEvent struct {
+	tenantID int64
+	event    string
+	data     any
+}
+
+// syncEvents are the events of a synced airport: its update, and its change
+// of weather if any.
+func syncEvents(a *domain.Airport, previousWeather string, obs domain.Observation) []airportEvent {
+	events := []airportEvent{{domain.AllTenants, domain.EventAirportUpdated, a}}
+	if a.Weather != previousWeather {
+		events = append(events, airportEvent{domain.AllTenants, domain.EventWeatherChanged, domain.WeatherChange{
+			Faa:        a.Faa,
+			Previous:   previousWeather,
+			Current:    a.Weather,
+			ObservedAt: obs.ObservedAt,
+		}})
+	}
+	return events
+}
+
+// outboxEnabled reports whether airport events go through event_outbox,
+// which they do when there is somewhere to deliver them and a dispatcher
+// polling for them.
+func (s *Service) outboxEnabled() bool {
+	return (s.webhooks != nil || s.publisher != nil) && s.cfg.OutboxPollInterval > 0
+}
+
+// modifyAirport updates an airport as stored, locked against other writers
+// until saved. modify changes it in place and returns the events announcing
+// the change. With the outbox enabled the events are saved in the same
+// transaction and left to DispatchOutbox, so a crash right after the update
+// loses none of them; otherwise they are sent once the update succeeded.
+func (s *Service) modifyAirport(faa string, modify func(a *domain.Airport) []airportEvent) error {
+	var events []airportEvent
+	err := s.repo.ModifyAirport(faa, func(a *domain.Airport) ([]domain.OutboxEvent, error) {
+		events = modify(a)
+		if !s.outboxEnabled() {
+			return nil, nil
+		}
+		return outboxEvents(events)
+	})
+	if err != nil {
+		return err
+	}
+	if !s.outboxEnabled() {
+		s.announce(events)
+	}
+	return nil
+}
+
+// mergeAirports saves the airports of a bulk sync onto the airports as stored
+// now, locked against other writers until saved, like modifyAirport. apply
+// changes a stored airport in place after its synced counterpart and returns
+// the events announcing the change. Returns the airports as saved.
+func (s *Service) mergeAirports(synced []domain.Airport, apply func(stored, synced *domain.Airport) []airportEvent) ([]domain.Airport, error) {
+	defer s.airportLocks.LockAll(faaCodes(synced)...)()
+
+	var events []airportEvent
+	saved, err := s.repo.MergeAirports(synced, func(stored, synced *domain.Airport) ([]domain.OutboxEvent, error) {
+		merged := apply(stored, synced)
+		events = append(events, merged...)
+		if !s.outboxEnabled() {
+			return nil, nil
+		}
+		return outboxEvents(merged)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !s.outboxEnabled() {
+		s.announce(events)
+	}
+	return saved, nil
+}
+
+func outboxEvents(events []airportEvent) ([]domain.OutboxEvent, error) {
+	outbox := make([]domain.OutboxEvent, 0, len(events))
+	for _, e := range events {
+		payload, err := json.Marshal(e.data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s event: %w", e.event, err)
+		}
+		outbox = append(outbox, domain.OutboxEvent{TenantID: e.tenantID, Event: e.event, Payload: payload})
+	}
+	return outbox, nil
+}
+
+// announce sends events straight away, to the webhooks only those they may
+// subscribe to.
+func (s *Service) announce(events []airportEvent) {
+	for _, e := range events {
+		if domain.IsWebhookEvent(e.event) {
+			s.notify(e.tenantID, e.event, e.data)
+		} else {
+			s.publish(e.event, e.data)
+		}
+	}
+}
+
+// DispatchOutbox delivers the events saved in event_outbox until ctx is
+// done, polling every OutboxPollInterval. It returns at once when the outbox
+// is disabled. Several dispatchers may run against the same database; each
+// event is claimed by one of them at a time.
+func (s *Service) DispatchOutbox(ctx context.Context) {
+	if !s.outboxEnabled() {
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.OutboxPollInterval)
+	defer ticker.Stop()
+	for {
+		// A full batch hints at a backlog, so don't wait for the next tick
+		if s.dispatchOutbox() == outboxBatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchOutbox delivers one batch of due events and returns how many were
+// claimed. Delivered events are deleted; the others are retried after a
+// backoff doubling with each failure.
+func (s *Service) dispatchOutbox() int {
+	events, err := s.repo.ClaimOutboxEvents(outboxBatchSize, outboxLease)
+	if err != nil {
+		log.Printf("WARN: Failed to claim outbox events: %v", err)
+		return 0
+	}
+
+	for _, e := range events {
+		if err := s.deliverOutboxEvent(e); err != nil {
+			log.Printf("WARN: Failed to deliver %s event %d: %v", e.Event, e.ID, err)
+			if err := s.repo.RetryOutboxEvent(e.ID, err.Error(), retryBackoff(s.cfg.OutboxPollInterval, e.Attempts)); err != nil {
+				log.Printf("WARN: %v", err)
+			}
+			continue
+		}
+		if err := s.repo.DeleteOutboxEvent(e.ID); err != nil {
+			log.Printf("WARN: %v", err)
+		}
+	}
+	return len(events)
+}
+
+// deliverOutboxEvent hands an event to the webhook dispatcher, which retries
+// and logs each delivery itself, and publishes it to the broker. Only the
+// broker can fail the event, so its retries skip the webhooks already sent.
+func (s *Service) deliverOutboxEvent(e domain.OutboxEvent) error {
+	if s.webhooks != nil && e.Attempts == 0 && domain.IsWebhookEvent(e.Event) {
+		s.webhooks.Dispatch(e.TenantID, e.Event, e.Payload)
+	}
+	if s.publisher == nil {
+		return nil
+	}
+	return s.sendToBroker(e.Event, e.Payload)
+}
+
+// retryBackoff is the wait before the next try of something that failed
+// attempts times before: base, doubled for each earlier failure, capped at
+// maxRetryBackoff.
+func retryBackoff(base time.Duration, attempts int) time.Duration {
+	wait := base
+	for i := 0; i < attempts && wait < maxRetryBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxRetryBackoff)
+}
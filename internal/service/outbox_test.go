@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+	"aviation-weather/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSyncSavesEventsToOutbox(t *testing.T) {
+	airport := sampleAirport
+	airport.Weather = "Rain"
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&airport, nil)
+	mockRepo.On("SaveObservation", "TST", mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+	s := NewService(mockRepo, &config.Config{OutboxPollInterval: time.Second}).(*Service)
+	publisher := &fakePublisher{}
+	s.publisher = publisher
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Clear", ObservedAt: time.Now()}, nil
+	}
+
+	_, err := s.SyncAirportByFAA(context.Background(), "TST")
+	assert.NoError(t, err)
+
+	saved := mockRepo.ModifiedEvents
+	if assert.Len(t, saved, 2) {
+		assert.Equal(t, domain.EventAirportUpdated, saved[0].Event)
+		assert.Equal(t, domain.EventWeatherChanged, saved[1].Event)
+		var change domain.WeatherChange
+		assert.NoError(t, json.Unmarshal(saved[1].Payload, &change))
+		assert.Equal(t, "Rain", change.Previous)
+		assert.Equal(t, "Clear", change.Current)
+	}
+	assert.Empty(t, publisher.events["airport.updated"], "Left to the dispatcher")
+	assert.Empty(t, publisher.events["weather.changed"], "Left to the dispatcher")
+	mockRepo.AssertExpectations(t)
+}
+
+// flakyPublisher fails the first failures publishes, then records subjects.
+type flakyPublisher struct {
+	failures int
+	subjects []string
+}
+
+func (f *flakyPublisher) Publish(subject string, payload []byte) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("broker down")
+	}
+	f.subjects = append(f.subjects, subject)
+	return nil
+}
+
+func TestDispatchOutbox(t *testing.T) {
+	events := []domain.OutboxEvent{
+		{ID: 1, Event: domain.EventAirportUpdated, Payload: json.RawMessage(`{"faa_ident":"TST"}`)},
+		{ID: 2, Event: domain.EventWeatherChanged, Payload: json.RawMessage(`{"faa_ident":"TST"}`), Attempts: 2},
+	}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ClaimOutboxEvents", outboxBatchSize, outboxLease).Return(events, nil).Once()
+	mockRepo.On("RetryOutboxEvent", int64(1), "broker down", time.Second).Return(nil).Once()
+	mockRepo.On("DeleteOutboxEvent", int64(2)).Return(nil).Once()
+	s := NewService(mockRepo, &config.Config{OutboxPollInterval: time.Second, BrokerSubjectPrefix: "aw"}).(*Service)
+	publisher := &flakyPublisher{failures: 1}
+	s.publisher = publisher
+
+	assert.Equal(t, 2, s.dispatchOutbox())
+	assert.Equal(t, []string{"aw.weather.changed"}, publisher.subjects)
+
+	mockRepo.On("ClaimOutboxEvents", outboxBatchSize, outboxLease).Return([]domain.OutboxEvent(nil), errors.New("db down")).Once()
+	assert.Equal(t, 0, s.dispatchOutbox())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDispatchOutboxDisabled(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	s := NewService(mockRepo, &config.Config{OutboxPollInterval: time.Second}).(*Service)
+
+	// Returns at once without touching the repository: nothing to deliver to
+	s.DispatchOutbox(context.Background())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, retryBackoff(time.Second, 0))
+	assert.Equal(t, 8*time.Second, retryBackoff(time.Second, 3))
+	assert.Equal(t, maxRetryBackoff, retryBackoff(time.Second, 40))
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"fmt"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// applyAltitudes stores the pressure and density altitude of an airport under
+// obs. Airports without a known elevation or pressure are left unchanged.
+func applyAltitudes(a *domain.Airport, obs domain.Observation) {
+	if a.ElevationFt == nil || obs.PressureHpa <= 0 {
+		return
+	}
+	pa := aviation.PressureAltitude(*a.ElevationFt, obs.PressureHpa)
+	da := aviation.DensityAltitude(pa, obs.TemperatureC)
+	a.PressureAltitudeFt, a.DensityAltitudeFt = &pa, &da
+}
+
+// GetPerformance reports pressure and density altitude of one airport under
+// the last synced weather.
+func (s *Service) GetPerformance(faa string) (*domain.Performance, error) {
+	airport, err := s.GetAirportByFAA(faa)
+	if err != nil {
+		return nil, err
+	}
+	if airport.ElevationFt == nil {
+		return nil, fmt.Errorf("%w: no elevation for %s", domain.ErrNoData, faa)
+	}
+
+	obs, err := s.repo.GetObservation(faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weather for %s: %w", faa, err)
+	}
+	if obs == nil || obs.PressureHpa <= 0 {
+		return nil, fmt.Errorf("%w: no weather synced for %s", domain.ErrNoData, faa)
+	}
+
+	pa := aviation.PressureAltitude(*airport.ElevationFt, obs.PressureHpa)
+	return &domain.Performance{
+		Faa:                faa,
+		ElevationFt:        *airport.ElevationFt,
+		TemperatureC:       obs.TemperatureC,
+		AltimeterInHg:      aviation.AltimeterInHg(obs.PressureHpa),
+		ISADeviationC:      aviation.ISADeviation(pa, obs.TemperatureC),
+		PressureAltitudeFt: pa,
+		DensityAltitudeFt:  aviation.DensityAltitude(pa, obs.TemperatureC),
+		ObservedAt:         obs.ObservedAt,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+	"aviation-weather/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPerformance(t *testing.T) {
+	elevation := 5434.0
+	denver := sampleAirport
+	denver.ElevationFt = &elevation
+	observedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.RepositoryMock)
+		expected  *domain.Performance
+		err       error
+	}{
+		{
+			name: "success",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&denver, nil)
+				m.On("GetObservation", "TST").Return(&domain.Observation{TemperatureC: 32, PressureHpa: 1016, ObservedAt: observedAt}, nil)
+			},
+			expected: &domain.Performance{
+				Faa:                "TST",
+				ElevationFt:        5434,
+				TemperatureC:       32,
+				AltimeterInHg:      30,
+				ISADeviationC:      27.6,
+				PressureAltitudeFt: 5354,
+				DensityAltitudeFt:  8633,
+				ObservedAt:         observedAt,
+			},
+		},
+		{
+			name: "no elevation",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+			},
+			err: fmt.Errorf("%w: no elevation for TST", domain.ErrNoData),
+		},
+		{
+			name: "no weather synced",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&denver, nil)
+				m.On("GetObservation", "TST").Return((*domain.Observation)(nil), nil)
+			},
+			err: fmt.Errorf("%w: no weather synced for TST", domain.ErrNoData),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.RepositoryMock{}
+			tt.setupMock(mockRepo)
+			s := NewService(mockRepo, &config.Config{})
+
+			performance, err := s.GetPerformance("TST")
+			assert.Equal(t, tt.expected, performance)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSyncComputesDensityAltitude(t *testing.T) {
+	elevation := 5434.0
+	denver := sampleAirport
+	denver.ElevationFt = &elevation
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&denver, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&denver, nil)
+	mockRepo.On("SaveObservation", "TST", mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Sunny", TemperatureC: 32, PressureHpa: 1016}, nil
+	}
+
+	_, err := s.SyncAirportByFAA(context.Background(), "TST")
+	assert.NoError(t, err)
+	if assert.NotNil(t, denver.PressureAltitudeFt) && assert.NotNil(t, denver.DensityAltitudeFt) {
+		assert.Equal(t, 5354, *denver.PressureAltitudeFt)
+		assert.Equal(t, 8633, *denver.DensityAltitudeFt)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAviationAPIAirportToDomain(t *testing.T) {
+	var resp map[string][]aviationAPIAirport
+	err := json.Unmarshal([]byte(`{"DEN":[{"faa_ident":"DEN","icao_ident":"KDEN","state":"CO","latitude":"39-51-42.0000N","longitude":"104-40-23.0000W","elevation":"5434","magnetic_variation":"08E","fuel_types":"100LL,A","fbo_name":"Signature Flight Support","fbo_phone":"303-342-0100"}],"SEA":[{"faa_ident":"SEA","elevation":"","magnetic_variation":"15W"}]}`), &resp)
+	assert.NoError(t, err)
+
+	den := resp["DEN"][0].toDomain()
+	assert.Equal(t, "KDEN", den.Icao)
+	assert.Equal(t, "CO", den.StateCode)
+	assert.InDelta(t, 39.861667, *den.Latitude, 1e-6)
+	assert.InDelta(t, -104.673056, *den.Longitude, 1e-6)
+	assert.Equal(t, 5434.0, *den.ElevationFt)
+	assert.Equal(t, 8.0, *den.MagneticVariation)
+	assert.Equal(t, []string{domain.Fuel100LL, domain.FuelJetA}, den.FuelTypes)
+	assert.Equal(t, "Signature Flight Support", den.FboName)
+
+	sea := resp["SEA"][0].toDomain()
+	assert.Nil(t, sea.ElevationFt, "Missing elevation should stay unknown")
+	assert.Nil(t, sea.Latitude, "Missing coordinates should stay unknown")
+	assert.Equal(t, -15.0, *sea.MagneticVariation)
+	assert.Nil(t, sea.FuelTypes, "Fuel not sent should stay unknown")
+}
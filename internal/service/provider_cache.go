@@ -0,0 +1,62 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"aviation-weather/internal/domain"
+)
+
+// getAviationAPI fetches an Aviation API URL. The last response it sent with
+// an ETag or Last-Modified is kept in provider_cache and revalidated with
+// If-None-Match and If-Modified-Since, so airport data that hasn't changed
+// since the last sync isn't sent again. The request goes out uncached when
+// the cache can't be read.
+func (s *Service) getAviationAPI(apiURL string) ([]byte, error) {
+	cached, err := s.repo.GetProviderResponse(apiURL)
+	if err != nil {
+		log.Printf("WARN: Requesting %s without the provider cache: %v", apiURL, err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	if cached != nil && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached != nil && cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+
+	waitFor(s.aviationLimiter.Load())
+	resp, err := s.aviationClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		s.providerCacheHits.Add(1)
+		return cached.Body, nil
+	}
+	s.providerCacheMisses.Add(1)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	fresh := &domain.ProviderResponse{URL: apiURL, ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified"), Body: body}
+	if fresh.ETag != "" || fresh.LastModified != "" {
+		if err := s.repo.SaveProviderResponse(fresh); err != nil {
+			log.Printf("WARN: Not caching %s: %v", apiURL, err)
+		}
+	}
+	return body, nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAviationAPIConditionalRequests(t *testing.T) {
+	const body = `{"DEN":[{"faa_ident":"DEN","icao_ident":"KDEN"}]}`
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	apiURL := server.URL + "?apt=DEN"
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetProviderResponse", apiURL).Return((*domain.ProviderResponse)(nil), nil).Once()
+	mockRepo.On("SaveProviderResponse", &domain.ProviderResponse{URL: apiURL, ETag: `"v1"`, Body: []byte(body)}).Return(nil).Once()
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.aviationAPIURL = server.URL
+
+	airport, err := s.fetchAirportFromAviationAPI("DEN")
+	assert.NoError(t, err)
+	assert.Equal(t, "KDEN", airport.Icao)
+
+	mockRepo.On("GetProviderResponse", apiURL).Return(&domain.ProviderResponse{URL: apiURL, ETag: `"v1"`, Body: []byte(body)}, nil).Once()
+	airport, err = s.fetchAirportFromAviationAPI("DEN")
+	assert.NoError(t, err)
+	assert.Equal(t, "KDEN", airport.Icao, "A 304 answers from the cached body")
+
+	mockRepo.On("GetProviderResponse", apiURL).Return((*domain.ProviderResponse)(nil), errors.New("db down")).Once()
+	mockRepo.On("SaveProviderResponse", mock.Anything).Return(errors.New("db down")).Once()
+	airport, err = s.fetchAirportFromAviationAPI("DEN")
+	assert.NoError(t, err, "The cache is only an optimisation")
+	assert.Equal(t, "KDEN", airport.Icao)
+
+	assert.Equal(t, 3, requests)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCaches(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("CountProviderResponses").Return(12, nil)
+	mockRepo.On("ClearProviderResponses").Return(int64(12), nil)
+	s := NewService(mockRepo, &config.Config{WeatherCacheTTL: time.Minute, CacheBackend: "memory"}).(*Service)
+
+	s.weatherCache.Set("dallas", []byte(`{}`), time.Minute)
+	s.weatherCache.Get("dallas")
+	s.weatherCache.Get("austin")
+	s.providerCacheHits.Add(3)
+	s.providerCacheMisses.Add(1)
+
+	caches, err := s.GetCaches()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CacheStats{
+		{Name: "weather", Enabled: true, Backend: "memory", Hits: 1, Misses: 1, Entries: 1, HitRatio: 0.5},
+		{Name: "airports"},
+		{Name: "provider", Enabled: true, Backend: "postgres", Hits: 3, Misses: 1, Entries: 12, HitRatio: 0.75},
+	}, caches)
+
+	assert.NoError(t, s.ClearCache("weather"))
+	_, ok := s.weatherCache.Get("dallas")
+	assert.False(t, ok, "Cleared entries are gone")
+	assert.NoError(t, s.ClearCache("airports"), "Clearing a disabled cache does nothing")
+	assert.NoError(t, s.ClearCache("provider"))
+	assert.ErrorIs(t, s.ClearCache("tiles"), domain.ErrCacheNotFound)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/utils"
+	"aviation-weather/internal/weather"
+)
+
+// Provider names used in breaker status and metrics.
+const (
+	providerAviationAPI    = "aviationapi"
+	providerWeatherAPI     = "weatherapi"
+	providerOpenWeatherMap = "openweathermap"
+	providerNOAA           = "noaa"
+)
+
+// newProviderLimiter returns a token bucket for one provider, or nil when rps is 0.
+func newProviderLimiter(rps float64, burst int) *utils.RateLimiter {
+	if rps <= 0 {
+		return nil
+	}
+	return utils.NewRateLimiter(rps, burst)
+}
+
+// waitFor blocks until limiter grants a request. A nil limiter never blocks.
+func waitFor(limiter *utils.RateLimiter) {
+	if limiter != nil {
+		limiter.Wait()
+	}
+}
+
+// newProviderBreaker returns a circuit breaker for one provider, or nil when threshold is 0.
+func newProviderBreaker(threshold int, cooldown time.Duration) *utils.CircuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return utils.NewCircuitBreaker(threshold, cooldown)
+}
+
+// guarded runs call through breaker, failing fast while the breaker is open.
+func guarded(breaker *utils.CircuitBreaker, provider string, call func() error) error {
+	if breaker == nil {
+		return call()
+	}
+	if err := breaker.Allow(); err != nil {
+		return fmt.Errorf("%s: %w", provider, err)
+	}
+	err := call()
+	breaker.Record(err)
+	return err
+}
+
+func (s *Service) fetchAirport(faa string) (*domain.Airport, error) {
+	var airport *domain.Airport
+	err := guarded(s.aviationBreaker, providerAviationAPI, func() (err error) {
+		airport, err = s.FetchAirportFromAviationAPI(faa)
+		return err
+	})
+	return airport, err
+}
+
+func (s *Service) fetchAirports(faaList []string) ([]domain.Airport, error) {
+	var airports []domain.Airport
+	err := guarded(s.aviationBreaker, providerAviationAPI, func() (err error) {
+		airports, err = s.FetchAirportsFromAviationAPI(faaList)
+		return err
+	})
+	return airports, err
+}
+
+// guardedProvider applies a provider's rate limit and circuit breaker around its calls.
+type guardedProvider struct {
+	weather.Provider
+	limiter atomic.Pointer[utils.RateLimiter]
+	breaker *utils.CircuitBreaker
+}
+
+func (g *guardedProvider) Fetch(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+	var obs domain.Observation
+	err := guarded(g.breaker, g.Name(), func() (err error) {
+		waitFor(g.limiter.Load())
+		obs, err = g.Provider.Fetch(ctx, loc)
+		return err
+	})
+	return obs, err
+}
+
+// newWeatherProviders builds the providers listed in WEATHER_PROVIDERS, each
+// with its own rate limit and circuit breaker.
+func newWeatherProviders(cfg *config.Config, client *http.Client) []*guardedProvider {
+	names := cfg.WeatherProviders
+	if len(names) == 0 {
+		names = []string{providerWeatherAPI}
+	}
+
+	weatherAPIKey, openWeatherMapAPIKey := cfg.WeatherAPIKey, cfg.OpenWeatherMapAPIKey
+	if cfg.ProvidersOffline() {
+		// Fixtures and recordings accept any key but the providers refuse to
+		// go without one
+		weatherAPIKey, openWeatherMapAPIKey = cmp.Or(weatherAPIKey, "mock"), cmp.Or(openWeatherMapAPIKey, "mock")
+	}
+
+	var providers []*guardedProvider
+	for _, name := range names {
+		var p weather.Provider
+		switch strings.ToLower(name) {
+		case providerWeatherAPI:
+			weatherAPI := weather.NewWeatherAPI(client, weatherAPIKey)
+			if store := cfg.Secrets(); store != nil {
+				store.OnChange(config.SecretWeatherAPIKey, weatherAPI.SetAPIKey)
+			}
+			p = weatherAPI
+		case providerOpenWeatherMap:
+			p = weather.NewOpenWeatherMap(client, openWeatherMapAPIKey)
+		case providerNOAA:
+			p = weather.NewNOAA(client)
+		default:
+			log.Printf("WARN: unknown weather provider %q ignored", name)
+			continue
+		}
+		g := &guardedProvider{
+			Provider: p,
+			breaker:  newProviderBreaker(cfg.BreakerFailureThreshold, cfg.BreakerCooldown),
+		}
+		g.limiter.Store(newProviderLimiter(cfg.WeatherAPIRPS, cfg.WeatherAPIBurst))
+		providers = append(providers, g)
+	}
+	return providers
+}
+
+// fetchWeatherChain asks each weather provider in order until one answers.
+func (s *Service) fetchWeatherChain(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+	providers := make([]weather.Provider, len(s.weatherProviders))
+	for i, p := range s.weatherProviders {
+		providers[i] = p
+	}
+	return weather.NewChain(providers...).Fetch(ctx, loc)
+}
+
+// weatherLocation describes an airport to the weather providers.
+func weatherLocation(a *domain.Airport) weather.Location {
+	loc := weather.Location{City: a.City, Icao: a.Icao}
+	loc.Latitude, loc.Longitude, loc.HasCoords = a.Coordinates()
+	return loc
+}
+
+// fetchWeather serves weather from the cache when fresh, so airports sharing a
+// city cost a single provider call.
+func (s *Service) fetchWeather(ctx context.Context, a *domain.Airport) (domain.Observation, error) {
+	return s.cachedWeather(ctx, weatherLocation(a))
+}
+
+// cachedWeather fetches the weather at loc through the cache, keyed by city
+// when loc has one, else by its ICAO code or coordinates.
+func (s *Service) cachedWeather(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+	key := strings.ToLower(strings.TrimSpace(loc.String()))
+	if s.weatherCache != nil && s.cfg.WeatherCacheTTL > 0 {
+		if cached, ok := s.weatherCache.Get(key); ok {
+			var obs domain.Observation
+			if err := json.Unmarshal(cached, &obs); err == nil {
+				return obs, nil
+			}
+		}
+	}
+
+	obs, err := s.FetchWeather(ctx, loc)
+	if err != nil {
+		return obs, err
+	}
+
+	if s.weatherCache != nil && s.cfg.WeatherCacheTTL > 0 {
+		if data, err := json.Marshal(obs); err == nil {
+			s.weatherCache.Set(key, data, s.cfg.WeatherCacheTTL)
+		}
+	}
+	return obs, nil
+}
+
+// ProviderStatuses reports the circuit breaker of every external provider.
+func (s *Service) ProviderStatuses() []domain.ProviderStatus {
+	statuses := []domain.ProviderStatus{providerStatus(providerAviationAPI, s.aviationBreaker)}
+	for _, p := range s.weatherProviders {
+		statuses = append(statuses, providerStatus(p.Name(), p.breaker))
+	}
+	return statuses
+}
+
+func providerStatus(provider string, breaker *utils.CircuitBreaker) domain.ProviderStatus {
+	if breaker == nil {
+		return domain.ProviderStatus{Provider: provider, State: string(utils.BreakerClosed)}
+	}
+	return domain.ProviderStatus{
+		Provider: provider,
+		State:    string(breaker.State()),
+		Failures: breaker.Failures(),
+		Trips:    breaker.Trips(),
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// DefaultCorridorNM is the route corridor half-width used when none is given.
+const DefaultCorridorNM = 50
+
+// GetRouteWeather lists the airports within corridorNM of the great-circle
+// route between two airports, ordered along the route, with their latest
+// stored weather and flight category.
+func (s *Service) GetRouteWeather(from, to string, corridorNM float64) (*domain.RouteWeather, error) {
+	if corridorNM <= 0 {
+		corridorNM = DefaultCorridorNM
+	}
+
+	origin, err := s.routeEndpoint(from)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := s.routeEndpoint(to)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.repo.GetAirportsInBox(aviation.RouteBox(origin, destination, corridorNM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get airports along %s-%s: %w", from, to, err)
+	}
+
+	distance := aviation.DistanceNM(origin, destination)
+	route := &domain.RouteWeather{
+		From:       from,
+		To:         to,
+		DistanceNM: round1(distance),
+		CourseDeg:  round1(aviation.InitialBearing(origin, destination)),
+		CorridorNM: corridorNM,
+		Airports:   []domain.RouteAirport{},
+	}
+
+	var faas []string
+	for _, a := range candidates {
+		lat, lon, _ := a.Coordinates()
+		p := aviation.Point{Lat: lat, Lon: lon}
+		cross, along := aviation.CrossTrack(origin, destination, p)
+		onRoute := along >= 0 && along <= distance && math.Abs(cross) <= corridorNM
+		nearEnd := aviation.DistanceNM(origin, p) <= corridorNM || aviation.DistanceNM(destination, p) <= corridorNM
+		if !onRoute && !nearEnd {
+			continue
+		}
+		route.Airports = append(route.Airports, domain.RouteAirport{
+			Faa:          a.Faa,
+			Icao:         a.Icao,
+			FacilityName: a.FacilityName,
+			AlongTrackNM: round1(along),
+			OffsetNM:     round1(cross),
+			Condition:    a.Weather,
+		})
+		faas = append(faas, a.Faa)
+	}
+	if len(faas) == 0 {
+		return route, nil
+	}
+
+	observations, err := s.repo.GetObservations(faas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weather along %s-%s: %w", from, to, err)
+	}
+	for i := range route.Airports {
+		ra := &route.Airports[i]
+		if obs, ok := observations[ra.Faa]; ok {
+			observedAt := obs.ObservedAt
+			ra.Condition, ra.ObservedAt = obs.Condition, &observedAt
+			ra.FlightCategory = aviation.ObservationCategory(obs)
+		}
+	}
+
+	sort.SliceStable(route.Airports, func(i, j int) bool {
+		return route.Airports[i].AlongTrackNM < route.Airports[j].AlongTrackNM
+	})
+	return route, nil
+}
+
+// routeEndpoint looks up one end of a route and its position.
+func (s *Service) routeEndpoint(faa string) (aviation.Point, error) {
+	airport, err := s.GetAirportByFAA(faa)
+	if err != nil {
+		return aviation.Point{}, err
+	}
+	lat, lon, ok := airport.Coordinates()
+	if !ok {
+		return aviation.Point{}, fmt.Errorf("%w: no coordinates for %s", domain.ErrNoData, faa)
+	}
+	return aviation.Point{Lat: lat, Lon: lon}, nil
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
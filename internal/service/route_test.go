@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRouteWeather(t *testing.T) {
+	at := func(faa string, p aviation.Point) domain.Airport {
+		a := sampleAirport
+		a.Faa, a.Icao = faa, "K"+faa
+		a.Latitude, a.Longitude = deg(p.Lat), deg(p.Lon)
+		return a
+	}
+	jfkPoint, laxPoint := aviation.Point{Lat: 40.6398, Lon: -73.7789}, aviation.Point{Lat: 33.9425, Lon: -118.4081}
+	jfk, lax := at("JFK", jfkPoint), at("LAX", laxPoint)
+	mid := at("MID", aviation.Intermediate(jfkPoint, laxPoint, 0.5))
+	bos := at("BOS", aviation.Point{Lat: 42.3656, Lon: -71.0096})
+	den := at("DEN", aviation.Point{Lat: 39.8561, Lon: -104.6737})
+	noCoords := sampleAirport
+	noCoords.Faa, noCoords.Latitude = "NC", nil
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "JFK").Return(&jfk, nil)
+	mockRepo.On("GetAirportByFAA", "LAX").Return(&lax, nil)
+	mockRepo.On("GetAirportByFAA", "NC").Return(&noCoords, nil)
+	mockRepo.On("GetAirportByFAA", "XXX").Return((*domain.Airport)(nil), nil)
+	mockRepo.On("GetAirportsInBox", mock.Anything).Return([]domain.Airport{bos, den, jfk, lax, mid}, nil)
+	mockRepo.On("GetObservations", []string{"JFK", "LAX", "MID"}).Return(map[string]domain.Observation{
+		"JFK": {Condition: "Overcast", VisibilitySM: 10, RawMETAR: "KJFK 011251Z 18012KT 10SM OVC008 21/10 A2992", ObservedAt: time.Date(2024, 6, 1, 12, 51, 0, 0, time.UTC)},
+		"MID": {Condition: "Sunny", VisibilitySM: 10},
+	}, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	route, err := s.GetRouteWeather("JFK", "LAX", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(DefaultCorridorNM), route.CorridorNM)
+	assert.InDelta(t, 2145, route.DistanceNM, 5)
+	if assert.Len(t, route.Airports, 3) {
+		assert.Equal(t, "JFK", route.Airports[0].Faa)
+		assert.Equal(t, aviation.CategoryIFR, route.Airports[0].FlightCategory)
+		assert.NotNil(t, route.Airports[0].ObservedAt)
+
+		assert.Equal(t, "MID", route.Airports[1].Faa)
+		assert.Equal(t, aviation.CategoryVFR, route.Airports[1].FlightCategory)
+		assert.InDelta(t, route.DistanceNM/2, route.Airports[1].AlongTrackNM, 1)
+
+		assert.Equal(t, "LAX", route.Airports[2].Faa)
+		assert.Equal(t, "Clear", route.Airports[2].Condition, "falls back to the airport's weather")
+		assert.Empty(t, route.Airports[2].FlightCategory)
+	}
+
+	_, err = s.GetRouteWeather("JFK", "NC", 50)
+	assert.ErrorIs(t, err, domain.ErrNoData)
+	_, err = s.GetRouteWeather("XXX", "LAX", 50)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
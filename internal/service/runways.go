@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// GetRunways returns the stored runway ends of one airport.
+func (s *Service) GetRunways(faa string) ([]domain.Runway, error) {
+	if _, err := s.GetAirportByFAA(faa); err != nil {
+		return nil, err
+	}
+
+	runways, err := s.repo.GetRunways(faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get runways for %s: %w", faa, err)
+	}
+
+	return runways, nil
+}
+
+// SyncRunways refreshes the stored runway ends of one airport from OurAirports,
+// stamped with the current AIRAC cycle.
+func (s *Service) SyncRunways(ctx context.Context, faa string) ([]domain.Runway, error) {
+	airport, err := s.repo.GetAirportByFAA(faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get airport for %s: %w", faa, err)
+	}
+	if airport == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrNotFound, faa)
+	}
+
+	runways, err := s.FetchRunways(ctx, airport)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch runways for %s: %w", domain.ErrExternalAPI, faa, err)
+	}
+
+	cycle := currentCycle()
+	for i := range runways {
+		runways[i].Cycle = cycle
+	}
+
+	if err := s.repo.ReplaceRunways(faa, runways); err != nil {
+		return nil, fmt.Errorf("failed to save runways for %s: %w", faa, err)
+	}
+
+	return runways, nil
+}
+
+// GetWindComponents reports the headwind and crosswind of every runway end for
+// the last synced wind, and the runway end best aligned with it.
+func (s *Service) GetWindComponents(faa string) (*domain.WindComponents, error) {
+	if _, err := s.GetAirportByFAA(faa); err != nil {
+		return nil, err
+	}
+
+	obs, err := s.repo.GetObservation(faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weather for %s: %w", faa, err)
+	}
+	if obs == nil {
+		return nil, fmt.Errorf("%w: no weather synced for %s", domain.ErrNoData, faa)
+	}
+
+	runways, err := s.repo.GetRunways(faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get runways for %s: %w", faa, err)
+	}
+	if len(runways) == 0 {
+		return nil, fmt.Errorf("%w: no runways synced for %s", domain.ErrNoData, faa)
+	}
+
+	winds := aviation.RunwayWinds(runways, *obs)
+	return &domain.WindComponents{
+		Faa:         faa,
+		WindDirDeg:  obs.WindDirDeg,
+		WindSpeedKt: obs.WindSpeedKt,
+		WindGustKt:  obs.WindGustKt,
+		ObservedAt:  obs.ObservedAt,
+		Recommended: aviation.RecommendRunway(runways, winds),
+		Runways:     winds,
+	}, nil
+}
+
+// Internal helper
+func (s *Service) fetchRunways(ctx context.Context, a *domain.Airport) ([]domain.Runway, error) {
+	return s.ourAirports.Runways(ctx, a.Icao, a.Faa)
+}
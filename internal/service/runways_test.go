@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleRunways = []domain.Runway{
+	{Ident: "17", HeadingDeg: 170, LengthFt: 9000},
+	{Ident: "35", HeadingDeg: 350, LengthFt: 9000},
+}
+
+func TestSyncRunways(t *testing.T) {
+	cycle := aviation.AIRACCycleAt(time.Now()).Ident
+	synced := []domain.Runway{
+		{Ident: "17", HeadingDeg: 170, LengthFt: 9000, Cycle: cycle},
+		{Ident: "35", HeadingDeg: 350, LengthFt: 9000, Cycle: cycle},
+	}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+	mockRepo.On("ReplaceRunways", "TST", synced).Return(nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchRunways = func(ctx context.Context, a *domain.Airport) ([]domain.Runway, error) {
+		assert.Equal(t, "KTST", a.Icao)
+		return slices.Clone(sampleRunways), nil
+	}
+
+	runways, err := s.SyncRunways(context.Background(), "TST")
+	assert.NoError(t, err)
+	assert.Equal(t, synced, runways)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetWindComponents(t *testing.T) {
+	observedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.RepositoryMock)
+		expected  *domain.WindComponents
+		err       error
+	}{
+		{
+			name: "success",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+				m.On("GetObservation", "TST").Return(&domain.Observation{WindDirDeg: 340, WindSpeedKt: 10, ObservedAt: observedAt}, nil)
+				m.On("GetRunways", "TST").Return(sampleRunways, nil)
+			},
+			expected: &domain.WindComponents{
+				Faa:         "TST",
+				WindDirDeg:  340,
+				WindSpeedKt: 10,
+				ObservedAt:  observedAt,
+				Recommended: "35",
+				Runways: []domain.RunwayWind{
+					{Runway: "17", HeadingDeg: 170, HeadwindKt: -9.8, CrosswindKt: 1.7},
+					{Runway: "35", HeadingDeg: 350, HeadwindKt: 9.8, CrosswindKt: -1.7},
+				},
+			},
+		},
+		{
+			name: "no weather synced",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+				m.On("GetObservation", "TST").Return((*domain.Observation)(nil), nil)
+			},
+			err: fmt.Errorf("%w: no weather synced for TST", domain.ErrNoData),
+		},
+		{
+			name: "no runways synced",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+				m.On("GetObservation", "TST").Return(&domain.Observation{}, nil)
+				m.On("GetRunways", "TST").Return([]domain.Runway{}, nil)
+			},
+			err: fmt.Errorf("%w: no runways synced for TST", domain.ErrNoData),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.RepositoryMock{}
+			tt.setupMock(mockRepo)
+			s := NewService(mockRepo, &config.Config{})
+
+			wind, err := s.GetWindComponents("TST")
+			assert.Equal(t, tt.expected, wind)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -1,32 +1,117 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"aviation-weather/config"
+	"aviation-weather/internal/awc"
+	"aviation-weather/internal/broker"
+	"aviation-weather/internal/cache"
 	"aviation-weather/internal/domain"
+	"aviation-weather/internal/notam"
+	"aviation-weather/internal/notify"
+	"aviation-weather/internal/ourairports"
 	"aviation-weather/internal/repository"
+	"aviation-weather/internal/utils"
+	"aviation-weather/internal/weather"
+	"aviation-weather/internal/webhook"
 )
 
 type Service struct {
 	repo       repository.RepositoryInterface
 	cfg        *config.Config
 	httpClient *http.Client
+	// Client of the Aviation API, with its own timeout
+	aviationClient *http.Client
 
 	// Internal helper so that it can be overriden
 	FetchAirportFromAviationAPI  func(faa string) (*domain.Airport, error)
 	FetchAirportsFromAviationAPI func(faa []string) ([]domain.Airport, error)
-	FetchWeatherFromWeatherAPI   func(city string) (string, error)
-
-	syncQueue    chan syncJob
-	syncAllQueue chan syncAllJob
+	FetchWeather                 func(ctx context.Context, loc weather.Location) (domain.Observation, error)
+	FetchFrequencies             func(ctx context.Context, a *domain.Airport) ([]domain.Frequency, error)
+	FetchRunways                 func(ctx context.Context, a *domain.Airport) ([]domain.Runway, error)
+	FetchAllFrequencies          func(ctx context.Context) (map[string][]domain.Frequency, error)
+	FetchAllRunways              func(ctx context.Context) (map[string][]domain.Runway, error)
+	FetchAdvisories              func(ctx context.Context) ([]domain.Advisory, error)
+	FetchTAF                     func(ctx context.Context, icao string) (string, error)
+	FetchCharts                  func(faas []string) (map[string][]domain.Chart, error)
+	FetchNOTAMs                  func(ctx context.Context, icao string) ([]domain.NOTAM, error)
+
+	// Source of frequencies and other reference data
+	ourAirports *ourairports.Client
+
+	// Airport and chart lookups of the Aviation API, overridden by tests
+	aviationAPIURL    string
+	aviationChartsURL string
+
+	// Shared outbound budgets, nil when unlimited. Swapped by ApplyConfig.
+	aviationLimiter atomic.Pointer[utils.RateLimiter]
+
+	// SyncStaleAfter as of the last ApplyConfig
+	syncStaleAfter atomic.Int64
+
+	// Per-provider circuit breakers, nil when disabled
+	aviationBreaker *utils.CircuitBreaker
+
+	// Weather sources in fallback order
+	weatherProviders []*guardedProvider
+
+	// Weather by city and airport reads, nil when caching is disabled. Each
+	// has its own namespace of the backend
+	cache        cache.Cache
+	weatherCache *cache.Namespace
+	airportCache *cache.Namespace
+
+	// Aviation API requests answered from provider_cache, and those that
+	// were not
+	providerCacheHits   atomic.Int64
+	providerCacheMisses atomic.Int64
+
+	// Event notifications, nil when webhooks are disabled
+	webhooks *webhook.Dispatcher
+
+	// Alert notifiers by channel; email is missing without an SMTP host
+	notifiers     map[string]notify.Notifier
+	notifications sync.WaitGroup
+
+	// Event stream for downstream consumers, nil when no broker is configured
+	publisher broker.EventPublisher
+
+	// Live airport changes, fed by ConsumeAirportChanges
+	airportChanges *utils.Hub[domain.AirportChange]
+
+	// Unix nanoseconds of the last deletion seen, or of the start; deleted
+	// rows leave no updated_at behind for AirportsLastModified
+	deletedAt atomic.Int64
+
+	// Callers waiting on the jobs this process queued, by job id, and how
+	// many wait per job type
+	jobMu       sync.Mutex
+	jobWaiters  map[int64][]chan jobOutcome
+	jobsWaiting map[string]int
+	// Wakes an idle job worker of this process when a job is queued
+	jobWake chan struct{}
+
+	// Concurrent syncs of the same FAA share one run
+	syncFlight utils.SingleFlight[*domain.SyncResult]
+
+	// Writers of an airport in this process take turns on these, by FAA;
+	// those of other processes on the row lock of ModifyAirport
+	airportLocks utils.KeyedMutex
+
+	// Chunk workers of the bulk syncs running now
+	syncWorkers atomic.Int64
 }
 
 type ServiceInterface interface {
@@ -34,230 +119,615 @@ type ServiceInterface interface {
 	UpdateAirport(a *domain.Airport) error
 	DeleteAirportByFAA(faa string) error
 	GetAirportByFAA(faa string) (*domain.Airport, error)
-	GetAllAirports() ([]domain.Airport, error)
-	SyncAirportByFAA(faa string) (*domain.Airport, error)
-	SyncAllAirports() (int, error)
-
-	SyncAirportQueued(faa string) (*domain.Airport, error)
-	SyncAllAirportsQueued() (int, error)
+	GetAllAirports(sort []domain.SortField) ([]domain.Airport, error)
+	GetAirportsByFAAs(faas []string) ([]domain.Airport, error)
+	GetAirportsByPlace(stateCode, city string) ([]domain.Airport, error)
+	GetAirportsByFuel(fuel string, sort []domain.SortField) ([]domain.Airport, error)
+	UpdateAirportFuel(faa string, fuel domain.AirportFuel) (*domain.Airport, error)
+	GetStates() ([]string, error)
+	GetCities(stateCode string) ([]string, error)
+	ForEachAirport(ctx context.Context, fn func(domain.Airport) error) error
+	ImportAirports(airports []domain.Airport) error
+	CreateAirports(airports []domain.Airport) (created []string, skipped []string, err error)
+	SyncAirportByFAA(ctx context.Context, faa string) (*domain.SyncResult, error)
+	SyncAllAirports(ctx context.Context) (int, error)
+	SyncAirportsByState(ctx context.Context, stateCode string) (int, error)
+	SyncAirportsByFAAs(ctx context.Context, faas []string) (int, error)
+
+	SyncAirportQueued(faa string) (*domain.SyncResult, error)
+	SyncAllAirportsQueued(ctx context.Context) (int, error)
+
+	GetFrequencies(faa string) ([]domain.Frequency, error)
+	SyncFrequencies(ctx context.Context, faa string) ([]domain.Frequency, error)
+	GetRunways(faa string) ([]domain.Runway, error)
+	SyncRunways(ctx context.Context, faa string) ([]domain.Runway, error)
+	GetCharts(faa string) ([]domain.Chart, error)
+	GetWindComponents(faa string) (*domain.WindComponents, error)
+	GetPerformance(faa string) (*domain.Performance, error)
+	GetAirportWeather(ctx context.Context, faa string, refresh bool) (*domain.AirportWeather, error)
+	GetLiveWeather(ctx context.Context, loc weather.Location) (*domain.Observation, error)
+	GetDaylight(faa string, date time.Time) (*domain.Daylight, error)
+	GetRouteWeather(from, to string, corridorNM float64) (*domain.RouteWeather, error)
+	GetAlternates(faa string, radiusNM float64, minCategory string) ([]domain.Alternate, error)
+	GetWeatherTrend(faa string, hours int) (*domain.WeatherTrend, error)
+	GetWeatherHistories(faas []string, hours int) (map[string][]domain.Observation, error)
+	GetFlightCategories(faas []string) (map[string]string, error)
+	GetNearestNavaids(lat, lon, radiusNM float64, limit int) ([]domain.NearbyNavaid, error)
+	GetAirportNavaids(faa string, radiusNM float64) ([]domain.NearbyNavaid, error)
+
+	CreateAlertRule(rule *domain.AlertRule) error
+	DeleteAlertRule(tenantID, id int64) error
+	GetAlertRules(tenantID int64) ([]domain.AlertRule, error)
+	GetAlerts(tenantID int64, faa string, since time.Time, limit int) ([]domain.Alert, error)
+	SendTestNotification(ctx context.Context, target domain.NotifyTarget) error
+
+	CreateWebhook(wh *domain.Webhook) error
+	DeleteWebhook(tenantID, id int64) error
+	GetWebhooks(tenantID int64) ([]domain.Webhook, error)
+	GetWebhookDeliveries(tenantID, id int64, limit int) ([]domain.WebhookDelivery, error)
+	CreateWatchlist(apiKey string, wl *domain.Watchlist) error
+	DeleteWatchlist(apiKey string, id int64) error
+	GetWatchlists(apiKey string) ([]domain.Watchlist, error)
+	GetWatchlistBoard(apiKey string, id int64) (*domain.WatchlistBoard, error)
+	CreateSubscription(apiKey string, sub *domain.Subscription) error
+	DeleteSubscription(apiKey string, id int64) error
+	GetSubscriptions(apiKey string) ([]domain.Subscription, error)
+	SendDigests(ctx context.Context) (int, error)
+
+	SyncStaleAirports(ctx context.Context) (int, error)
+	RetryFailedAirports(ctx context.Context) (int, error)
+	PruneLogs(ctx context.Context) (int64, error)
+	PartitionWeatherHistory(ctx context.Context) ([]string, []string, error)
+	SyncAdvisories(ctx context.Context) (int, error)
+	SyncCharts(ctx context.Context) (int, error)
+	RefreshStaticData(ctx context.Context) (int, error)
+	RefreshNOTAMs(ctx context.Context) (int, error)
+	GetAIRAC() domain.AIRACStatus
+	GetAdvisories() ([]domain.Advisory, error)
+	GetAirportAdvisories(faa string) ([]domain.Advisory, error)
+	GetAirportNOTAMs(faa string) ([]domain.NOTAM, error)
+	GetAirportForecast(ctx context.Context, faa string, at time.Time) (*domain.TAFForecast, error)
+	GetSchedulerJobs() ([]domain.SchedulerJob, error)
+	GetJobs() ([]domain.SchedulerJob, error)
+	PauseJob(name string) error
+	ResumeJob(name string) error
+	RunJob(name string) error
+	ClaimJobRuns() ([]string, error)
+
+	ResolveTenant(apiKey string) (int64, error)
+	CreateTenant(t *domain.Tenant) error
+	GetTenants() ([]domain.Tenant, error)
+	CreateAPIKey(key *domain.APIKey) error
+	DeleteAPIKey(id int64) error
+	GetAPIKeys(tenantID int64) ([]domain.APIKey, error)
+
+	ConsumeAirportChanges(ctx context.Context, changes <-chan domain.AirportChange)
+	SubscribeAirportChanges() (<-chan domain.AirportChange, func())
+	GetChangeFeed(after domain.ChangeCursor, limit int) (*domain.ChangeFeed, error)
+	DispatchOutbox(ctx context.Context)
+	RunJobWorkers(ctx context.Context)
+	GetQueuedJobs() ([]domain.QueuedJob, error)
+	GetConflicts(status string) ([]domain.AirportConflict, error)
+	AcceptConflict(id int64) (*domain.AirportConflict, error)
+	RejectConflict(id int64) (*domain.AirportConflict, error)
+	ChangeAirportStatus(faa, status, reason string) (*domain.AirportStatusChange, error)
+	GetAirportStatusHistory(faa string) ([]domain.AirportStatusChange, error)
+	CreateAirportNote(note *domain.AirportNote) error
+	GetAirportNotes(faa string) ([]domain.AirportNote, error)
+	DeleteAirportNote(faa string, id int64) error
+
+	ProviderStatuses() []domain.ProviderStatus
+	Readiness(ctx context.Context) domain.Readiness
+	RuntimeStats() domain.RuntimeStats
+	AirportStats(ctx context.Context) (*domain.AirportStats, error)
+	Dashboard(ctx context.Context) (*domain.Dashboard, error)
+	AirportsLastModified(ctx context.Context) (time.Time, error)
+	CacheStats() domain.CacheStats
+	GetCaches() ([]domain.CacheStats, error)
+	ClearCache(name string) error
+
+	ApplyConfig(cfg *config.Config)
 }
 
 func NewService(repo repository.RepositoryInterface, cfg *config.Config) ServiceInterface {
+	clients := newOutboundClients(cfg)
 	s := &Service{
-		repo: repo,
-		cfg:  cfg,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		syncQueue:    make(chan syncJob, 100),
-		syncAllQueue: make(chan syncAllJob, 100),
+		repo:              repo,
+		cfg:               cfg,
+		httpClient:        clients.standard,
+		aviationClient:    clients.aviation,
+		aviationAPIURL:    "https://api.aviationapi.com/v1/airports",
+		aviationChartsURL: "https://api.aviationapi.com/v1/charts",
+		aviationBreaker:   newProviderBreaker(cfg.BreakerFailureThreshold, cfg.BreakerCooldown),
+		jobWaiters:        map[int64][]chan jobOutcome{},
+		jobsWaiting:       map[string]int{},
+		jobWake:           make(chan struct{}, 1),
+		airportChanges:    utils.NewHub[domain.AirportChange](airportChangeBuffer),
+	}
+	s.aviationLimiter.Store(newProviderLimiter(cfg.AviationAPIRPS, cfg.AviationAPIBurst))
+	s.syncStaleAfter.Store(int64(cfg.SyncStaleAfter))
+	s.deletedAt.Store(time.Now().UnixNano())
+	s.cache = newCache(cfg)
+	if s.cache != nil {
+		s.weatherCache = cache.NewNamespace(s.cache, "weather:")
+		s.airportCache = cache.NewNamespace(s.cache, "airports:")
 	}
 	s.FetchAirportFromAviationAPI = s.fetchAirportFromAviationAPI
 	s.FetchAirportsFromAviationAPI = s.fetchAirportsFromAviationAPI
-	s.FetchWeatherFromWeatherAPI = s.fetchWeatherFromWeatherAPI
-
-	go s.runSyncWorker()
-	go s.runSyncAllWorker()
+	s.FetchCharts = s.fetchChartsFromAviationAPI
+	s.weatherProviders = newWeatherProviders(cfg, clients.weather)
+	s.FetchWeather = s.fetchWeatherChain
+	s.ourAirports = ourairports.NewClient(s.httpClient)
+	s.FetchFrequencies = s.fetchFrequencies
+	s.FetchRunways = s.fetchRunways
+	s.FetchAllFrequencies = s.ourAirports.AllFrequencies
+	s.FetchAllRunways = s.ourAirports.AllRunways
+	awcClient := awc.NewClient(s.httpClient)
+	s.FetchAdvisories = awcClient.Advisories
+	s.FetchTAF = awcClient.TAF
+	s.FetchNOTAMs = notam.NewClient(s.httpClient, cfg.NOTAMClientID, cfg.NOTAMClientSecret).NOTAMs
+	if cfg.WebhookMaxAttempts > 0 {
+		s.webhooks = webhook.NewDispatcher(repo, s.httpClient, cfg.WebhookMaxAttempts, cfg.WebhookBackoff)
+	}
+	s.notifiers = newNotifiers(cfg, s.httpClient)
+	s.publisher = newPublisher(cfg)
 
 	return s
 }
 
-type syncJob struct {
-	faa      string
-	resultCh chan *domain.Airport
-	errCh    chan error
+// ApplyConfig takes on the runtime settings of a reloaded config: the stale
+// threshold of SyncAllAirports and the outbound rate limits. Limiters start
+// over with a full bucket.
+func (s *Service) ApplyConfig(cfg *config.Config) {
+	s.syncStaleAfter.Store(int64(cfg.SyncStaleAfter))
+	s.aviationLimiter.Store(newProviderLimiter(cfg.AviationAPIRPS, cfg.AviationAPIBurst))
+	for _, p := range s.weatherProviders {
+		p.limiter.Store(newProviderLimiter(cfg.WeatherAPIRPS, cfg.WeatherAPIBurst))
+	}
 }
 
-func (s *Service) runSyncWorker() {
-	for job := range s.syncQueue {
-		airport, err := s.SyncAirportByFAA(job.faa)
-		if err != nil {
-			job.errCh <- err
-		} else {
-			job.resultCh <- airport
+func (s *Service) CreateAirport(a *domain.Airport) error {
+	defer s.invalidateAirports(a.Faa)
+	return s.repo.CreateAirport(a)
+}
+
+// UpdateAirport saves an edit of an airport. The fields syncs fill in that
+// the edit changes are added to its manual overrides, unless a lists its
+// overrides itself, so later syncs keep them.
+func (s *Service) UpdateAirport(a *domain.Airport) error {
+	defer s.airportLocks.Lock(a.Faa)()
+	defer s.invalidateAirports(a.Faa)
+	return s.modifyAirport(a.Faa, func(stored *domain.Airport) []airportEvent {
+		// Fuel and FBO are edited through UpdateAirportFuel
+		a.FuelTypes, a.FboName, a.FboPhone = stored.FuelTypes, stored.FboName, stored.FboPhone
+		if a.ManualOverrides == nil {
+			a.ManualOverrides = stored.ManualOverrides
+			for _, field := range a.EditedFields(stored) {
+				if !stored.IsOverridden(field) {
+					a.ManualOverrides = append(a.ManualOverrides, field)
+				}
+			}
 		}
+		*stored = *a
+		return []airportEvent{{domain.AllTenants, domain.EventAirportUpdated, a}}
+	})
+}
+
+func (s *Service) DeleteAirportByFAA(faa string) error {
+	defer s.airportLocks.Lock(faa)()
+	defer s.invalidateAirports(faa)
+	if err := s.repo.DeleteByFAA(faa); err != nil {
+		return err
 	}
+	s.deletedAt.Store(time.Now().UnixNano())
+	return nil
 }
 
-func (s *Service) SyncAirportQueued(faa string) (*domain.Airport, error) {
-	job := syncJob{
-		faa:      faa,
-		resultCh: make(chan *domain.Airport, 1),
-		errCh:    make(chan error, 1),
+// ForEachAirport streams every airport to fn in FAA order, straight from the
+// database without loading or caching the whole list.
+func (s *Service) ForEachAirport(ctx context.Context, fn func(domain.Airport) error) error {
+	if err := s.repo.ForEachAirport(ctx, fn); err != nil {
+		return fmt.Errorf("failed to read airports: %w", err)
 	}
-	s.syncQueue <- job
-	select {
-	case airport := <-job.resultCh:
-		return airport, nil
-	case err := <-job.errCh:
-		return nil, err
+	return nil
+}
+
+func (s *Service) GetAirportByFAA(faa string) (*domain.Airport, error) {
+	var cached domain.Airport
+	if s.cachedAirports(airportCacheKey(faa), &cached) {
+		return &cached, nil
 	}
+
+	airport, err := s.repo.GetAirportByFAA(faa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get airport for %s: %w", faa, err)
+	}
+
+	if airport == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrNotFound, faa)
+	}
+
+	s.cacheAirports(airportCacheKey(faa), airport)
+	return airport, nil
 }
 
-type syncAllJob struct {
-	resultCh chan int
-	errCh    chan error
+// GetAllAirports lists every airport ordered by sort, by FAA code when empty.
+// Only the default order is cached.
+func (s *Service) GetAllAirports(sort []domain.SortField) ([]domain.Airport, error) {
+	var cached []domain.Airport
+	if len(sort) == 0 && s.cachedAirports(allAirportsCacheKey, &cached) {
+		return cached, nil
+	}
+
+	airports, err := s.repo.GetAllAirports(sort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get airports: %w", err)
+	}
+
+	if len(airports) == 0 {
+		return []domain.Airport{}, nil
+	}
+
+	if len(sort) == 0 {
+		s.cacheAirports(allAirportsCacheKey, airports)
+	}
+	return airports, nil
 }
 
-func (s *Service) runSyncAllWorker() {
-	for job := range s.syncAllQueue {
-		updated, err := s.SyncAllAirports()
-		if err != nil {
-			job.errCh <- err
-		} else {
-			job.resultCh <- updated
+// GetAirportsByFAAs fetches the listed airports in one query, in the order
+// listed. Codes matching no airport are left out.
+func (s *Service) GetAirportsByFAAs(faas []string) ([]domain.Airport, error) {
+	airports, err := s.repo.GetAirportsByFAAs(faas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get airports: %w", err)
+	}
+
+	byFAA := make(map[string]domain.Airport, len(airports))
+	for _, a := range airports {
+		byFAA[a.Faa] = a
+	}
+	ordered := make([]domain.Airport, 0, len(airports))
+	for _, faa := range faas {
+		if a, ok := byFAA[faa]; ok {
+			ordered = append(ordered, a)
+			delete(byFAA, faa)
 		}
 	}
+	return ordered, nil
 }
 
-func (s *Service) SyncAllAirportsQueued() (int, error) {
-	job := syncAllJob{
-		resultCh: make(chan int, 1),
-		errCh:    make(chan error, 1),
+// GetAirportsByPlace lists the airports of a state and city, matched
+// case-insensitively, ordered by FAA code. An empty state or city matches
+// every airport.
+func (s *Service) GetAirportsByPlace(stateCode, city string) ([]domain.Airport, error) {
+	airports, err := s.repo.GetAirportsByPlace(stateCode, city)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get airports: %w", err)
 	}
-	s.syncAllQueue <- job
-	select {
-	case updated := <-job.resultCh:
-		return updated, nil
-	case err := <-job.errCh:
-		return 0, err
+	if airports == nil {
+		return []domain.Airport{}, nil
 	}
+	return airports, nil
 }
 
-func (s *Service) CreateAirport(a *domain.Airport) error {
-	return s.repo.CreateAirport(a)
+// GetStates lists the state codes that have airports, for filter dropdowns.
+func (s *Service) GetStates() ([]string, error) {
+	states, err := s.repo.GetStates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get states: %w", err)
+	}
+	return states, nil
 }
 
-func (s *Service) UpdateAirport(a *domain.Airport) error {
-	return s.repo.UpdateAirport(a)
+// GetCities lists the cities that have airports, in one state when stateCode
+// is set.
+func (s *Service) GetCities(stateCode string) ([]string, error) {
+	cities, err := s.repo.GetCities(stateCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cities: %w", err)
+	}
+	return cities, nil
 }
 
-func (s *Service) DeleteAirportByFAA(faa string) error {
-	return s.repo.DeleteByFAA(faa)
-}
+// ImportAirports saves imported airports. Those already stored are merged
+// like synced ones: fields edited by hand keep their values, and imported
+// values disagreeing with them are recorded as conflicts. The rest are
+// inserted.
+func (s *Service) ImportAirports(airports []domain.Airport) error {
+	if len(airports) == 0 {
+		return nil
+	}
 
-func (s *Service) GetAirportByFAA(faa string) (*domain.Airport, error) {
-	airport, err := s.repo.GetAirportByFAA(faa)
+	defer s.invalidateAirports(faaCodes(airports)...)
+	var conflicts []domain.AirportConflict
+	merged, err := s.mergeAirports(airports, func(stored, imported *domain.Airport) []airportEvent {
+		conflicts = append(conflicts, stored.MergeFetched(imported)...)
+		if imported.Weather != "" {
+			stored.Weather = imported.Weather
+		}
+		return []airportEvent{{domain.AllTenants, domain.EventAirportUpdated, stored}}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get airport for %s: %w", faa, err)
+		return fmt.Errorf("failed to import airports: %w", err)
 	}
+	s.recordConflicts(conflicts)
 
-	if airport == nil {
-		return nil, fmt.Errorf("no airport found for %s", faa)
+	saved := make(map[string]bool, len(merged))
+	for _, a := range merged {
+		saved[a.Faa] = true
+	}
+	added := slices.DeleteFunc(slices.Clone(airports), func(a domain.Airport) bool { return saved[a.Faa] })
+	if len(added) == 0 {
+		return nil
+	}
+	// Still guarded against overrides should one of them be created meanwhile
+	unlock := s.airportLocks.LockAll(faaCodes(added)...)
+	defer unlock()
+	if err := s.repo.UpsertAirports(added); err != nil {
+		return fmt.Errorf("failed to import airports: %w", err)
 	}
 
-	return airport, nil
+	return nil
 }
 
-func (s *Service) GetAllAirports() ([]domain.Airport, error) {
-	airports, err := s.repo.GetAllAirports()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get airports: %w", err)
+func (s *Service) CreateAirports(airports []domain.Airport) ([]string, []string, error) {
+	if len(airports) == 0 {
+		return []string{}, []string{}, nil
 	}
 
-	if len(airports) == 0 {
-		return []domain.Airport{}, nil
+	defer s.invalidateAirports(faaCodes(airports)...)
+	created, skipped, err := s.repo.CreateAirports(airports)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create airports: %w", err)
 	}
 
-	return airports, nil
+	return created, skipped, nil
 }
 
-func (s *Service) SyncAirportByFAA(faa string) (*domain.Airport, error) {
+// SyncAirportByFAA refreshes one airport and reports which fields changed.
+func (s *Service) SyncAirportByFAA(ctx context.Context, faa string) (*domain.SyncResult, error) {
+	result, err := s.syncAirport(ctx, faa)
+	if err != nil {
+		return nil, err
+	}
+	s.notify(domain.AllTenants, domain.EventSyncCompleted, domain.SyncCompleted{Faa: faa, Updated: 1})
+	return result, nil
+}
+
+// syncAirport does the work of SyncAirportByFAA without announcing the sync,
+// so batch syncs can fall back to it and announce once. The airport stays
+// locked from before the providers are asked until it's saved, so an edit made
+// through this service waits for the sync; what the providers report is applied
+// to the row as locked for update, so an edit saved elsewhere meanwhile is kept.
+func (s *Service) syncAirport(ctx context.Context, faa string) (*domain.SyncResult, error) {
+	defer s.airportLocks.Lock(faa)()
+
 	// First check DB
 	airport, err := s.repo.GetAirportByFAA(faa)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get airport for %s: %w", faa, err)
 	}
 	if airport == nil {
-		return nil, fmt.Errorf("no airport found for %s", faa)
-	}
-
-	// Determine if static fields are missing
-	needsAirportFetch := airport.SiteNumber == "" ||
-		airport.FacilityName == "" ||
-		airport.Icao == "" ||
-		airport.StateCode == "" ||
-		airport.StateFull == "" ||
-		airport.County == "" ||
-		airport.City == "" ||
-		airport.OwnershipType == "" ||
-		airport.UseType == "" ||
-		airport.Manager == "" ||
-		airport.ManagerPhone == "" ||
-		airport.Latitude == "" ||
-		airport.Longitude == "" ||
-		airport.AirportStatus == ""
-
-	if needsAirportFetch {
+		return nil, fmt.Errorf("%w: %s", domain.ErrNotFound, faa)
+	}
+
+	var airportData *domain.Airport
+	if needsAirportFetch(airport) {
 		// Fetch airport details from Aviation API
-		airportData, err := s.FetchAirportFromAviationAPI(faa)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch airport for %s: %w", faa, err)
+		if airportData, err = s.fetchAirport(faa); err != nil {
+			return nil, fmt.Errorf("%w: failed to fetch airport for %s: %w", domain.ErrExternalAPI, faa, err)
 		}
 		if airportData == nil {
-			return nil, fmt.Errorf("no airport found for %s", faa)
+			return nil, fmt.Errorf("%w: %s", domain.ErrNotFound, faa)
 		}
 		airport = airportData
 	}
 
 	// Always refresh weather
-	weatherText, err := s.FetchWeatherFromWeatherAPI(airport.City)
+	obs, err := s.fetchWeather(ctx, airport)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch weather for %s: %w", airport.City, err)
+		return nil, fmt.Errorf("%w: failed to fetch weather for %s: %w", domain.ErrExternalAPI, airport.City, err)
 	}
-	airport.Weather = weatherText
 
 	// Save back to DB
-	if err := s.repo.UpdateAirport(airport); err != nil {
+	var previous, synced domain.Airport
+	var conflicts []domain.AirportConflict
+	err = s.modifyAirport(faa, func(stored *domain.Airport) []airportEvent {
+		previous = *stored
+		conflicts = nil
+		if airportData != nil && needsAirportFetch(stored) {
+			conflicts = stored.MergeFetched(airportData)
+		}
+		stored.Weather = obs.Condition
+		applyAltitudes(stored, obs)
+		applyTimezone(stored)
+		now := time.Now().UTC()
+		stored.LastSyncedAt = &now
+		synced = *stored
+		return syncEvents(stored, previous.Weather, obs)
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to update airport %s: %w", faa, err)
 	}
+	s.invalidateAirports(faa)
+	s.recordObservation(&synced, obs)
+	s.recordConflicts(conflicts)
 
-	return airport, nil
+	return domain.NewSyncResult(&previous, &synced), nil
 }
 
-func (s *Service) SyncAllAirports() (int, error) {
-	airports, err := s.repo.GetAllAirports()
+// airportFetchFields are the static fields of an airport whose absence has
+// a sync fetch it from the Aviation API.
+var airportFetchFields = []string{
+	"site_number", "facility_name", "icao_ident", "state", "state_full", "county", "city",
+	"ownership", "use", "manager", "manager_phone", "latitude", "longitude", "status",
+}
+
+// needsAirportFetch reports whether static fields of an airport are missing,
+// to be fetched from the Aviation API. A field emptied by hand is not missing.
+func needsAirportFetch(a *domain.Airport) bool {
+	for _, field := range airportFetchFields {
+		if !a.IsSet(field) && !a.IsOverridden(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordObservation runs the follow-ups of a synced airport: storing the
+// structured weather and evaluating alert rules. Failures are only logged
+// since the airport itself is already updated.
+func (s *Service) recordObservation(a *domain.Airport, obs domain.Observation) {
+	s.saveObservation(a.Faa, obs)
+	s.checkAlerts(a, obs)
+}
+
+// saveObservation keeps the structured weather behind the condition text.
+func (s *Service) saveObservation(faa string, obs domain.Observation) {
+	if err := s.repo.SaveObservation(faa, obs); err != nil {
+		log.Printf("WARN: Failed to save weather for %s: %v", faa, err)
+	}
+}
+
+// notify sends an event to the subscribed webhooks of a tenant, or of every
+// tenant for domain.AllTenants, and to the message broker, where enabled.
+func (s *Service) notify(tenantID int64, event string, data any) {
+	if s.webhooks != nil {
+		s.webhooks.Dispatch(tenantID, event, data)
+	}
+	s.publish(event, data)
+}
+
+// SyncAllAirports refreshes every airport, or only the stale ones when
+// SyncStaleAfter is configured. Airports on a watchlist go first, and scheduled
+// runs are spread over SyncSpread.
+func (s *Service) SyncAllAirports(ctx context.Context) (int, error) {
+	source := func(fn func(domain.Airport) error) error {
+		return s.repo.ForEachAirportBySyncPriority(ctx, fn)
+	}
+	if staleAfter := time.Duration(s.syncStaleAfter.Load()); staleAfter > 0 {
+		source = func(fn func(domain.Airport) error) error {
+			return s.repo.ForEachAirportNeedingSync(ctx, staleAfter, fn)
+		}
+	}
+
+	updated, seen, err := s.syncAirportStream(ctx, s.spreadInterval(ctx), source)
+	if err == nil && seen == 0 {
+		return 0, fmt.Errorf("no airports to sync")
+	}
+	return updated, err
+}
+
+// SyncAirportsByState refreshes only the airports of one state.
+func (s *Service) SyncAirportsByState(ctx context.Context, stateCode string) (int, error) {
+	airports, err := s.repo.GetAirportsByState(stateCode)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get airports for state %s: %w", stateCode, err)
+	}
+	if len(airports) == 0 {
+		return 0, fmt.Errorf("%w: no airports in state %s", domain.ErrNotFound, stateCode)
+	}
+
+	return s.syncAirports(ctx, airports)
+}
+
+// SyncAirportsByFAAs refreshes only the listed airports. Unknown codes are ignored.
+func (s *Service) SyncAirportsByFAAs(ctx context.Context, faas []string) (int, error) {
+	airports, err := s.repo.GetAirportsByFAAs(faas)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get airports: %w", err)
 	}
 	if len(airports) == 0 {
-		return 0, fmt.Errorf("no airports to sync")
+		return 0, fmt.Errorf("%w: %s", domain.ErrNotFound, strings.Join(faas, ", "))
+	}
+
+	return s.syncAirports(ctx, airports)
+}
+
+// defaultSyncWorkers is the pool size when SyncMaxConcurrency is not set.
+const defaultSyncWorkers = 4
+
+func (s *Service) chunkSize() int {
+	if s.cfg.SyncChunkSize <= 0 {
+		return 20
+	}
+	return s.cfg.SyncChunkSize
+}
+
+type scheduledKey struct{}
+
+// Scheduled marks ctx as that of a scheduled run, whose bulk syncs are spread
+// over SyncSpread. Syncs requested over the API still run at full speed.
+func Scheduled(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scheduledKey{}, true)
+}
+
+// spreadInterval paces the chunks of a scheduled sync so that syncing every
+// airport takes about SyncSpread rather than bursting at the provider. It
+// returns 0, syncing at full speed, when unset, not scheduled or the airports
+// can't be counted.
+func (s *Service) spreadInterval(ctx context.Context) time.Duration {
+	if scheduled, _ := ctx.Value(scheduledKey{}).(bool); !scheduled || s.cfg.SyncSpread <= 0 {
+		return 0
 	}
+	count, err := s.repo.CountAirports(ctx)
+	if err != nil {
+		log.Printf("WARN: Syncing without spread: %v", err)
+		return 0
+	}
+	chunks := (count + s.chunkSize() - 1) / s.chunkSize()
+	if chunks <= 1 {
+		return 0
+	}
+	return s.cfg.SyncSpread / time.Duration(chunks)
+}
 
-	type result struct {
-		updated int
-		errors  int
+// syncAirports syncs the given airports; see syncAirportStream.
+func (s *Service) syncAirports(ctx context.Context, airports []domain.Airport) (int, error) {
+	updated, _, err := s.syncAirportStream(ctx, 0, func(fn func(domain.Airport) error) error {
+		for _, a := range airports {
+			if err := fn(a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return updated, err
+}
+
+// syncAirportStream fetches missing airport data and fresh weather for the
+// airports yielded by source, in chunks handed to a fixed pool of workers as
+// they fill, so only the chunks in flight are held in memory. Closed and
+// decommissioned airports are skipped unless SyncClosedAirports is set. Failed airports
+// are recorded for RetryFailedAirports, and synced ones cleared. seen counts the
+// airports yielded and not skipped; with none, nothing is synced or announced. A positive pace
+// waits that long between chunks. Cancelling ctx stops the workers after their
+// current airport.
+func (s *Service) syncAirportStream(ctx context.Context, pace time.Duration, source func(fn func(domain.Airport) error) error) (int, int, error) {
+	chunkSize := s.chunkSize()
+
+	workers := s.cfg.SyncMaxConcurrency
+	if workers <= 0 {
+		workers = defaultSyncWorkers
 	}
 
-	chunkSize := 20
-	numChunks := (len(airports) + chunkSize - 1) / chunkSize
-	resultCh := make(chan result, numChunks)
+	var mu sync.Mutex
+	totalUpdated, totalErrors := 0, 0
 
 	processChunk := func(chunk []domain.Airport) {
 		updated, errors := 0, 0
+		var syncedFAAs []string
+		var failed []domain.SyncFailure
 
 		// Split into two groups: incomplete (need Aviation API) vs complete (only weather)
 		var incompleteFAA []string
 		var completeAirports []domain.Airport
+		stored := make(map[string]*domain.Airport, len(chunk)) // Incomplete airports as read from the DB
 
 		for _, a := range chunk {
-			needsAirportFetch := a.SiteNumber == "" ||
-				a.FacilityName == "" ||
-				a.Icao == "" ||
-				a.StateCode == "" ||
-				a.StateFull == "" ||
-				a.County == "" ||
-				a.City == "" ||
-				a.OwnershipType == "" ||
-				a.UseType == "" ||
-				a.Manager == "" ||
-				a.ManagerPhone == "" ||
-				a.Latitude == "" ||
-				a.Longitude == "" ||
-				a.AirportStatus == ""
-
-			if needsAirportFetch {
+			if needsAirportFetch(&a) {
 				incompleteFAA = append(incompleteFAA, a.Faa)
+				stored[a.Faa] = &a
 			} else {
 				completeAirports = append(completeAirports, a)
 			}
@@ -268,104 +738,276 @@ func (s *Service) SyncAllAirports() (int, error) {
 		var batchErr error
 		if len(incompleteFAA) > 0 {
 			for attempt := 0; attempt < 2; attempt++ {
-				fetchedAirports, batchErr = s.FetchAirportsFromAviationAPI(incompleteFAA)
+				fetchedAirports, batchErr = s.fetchAirports(incompleteFAA)
 				if batchErr == nil {
 					break
 				}
 				if attempt == 0 {
 					log.Printf("WARN: Batch fetch failed, retrying...")
-					time.Sleep(1 * time.Second)
+					select {
+					case <-ctx.Done():
+					case <-time.After(1 * time.Second):
+					}
 				}
 			}
 			if batchErr != nil {
 				log.Printf("ERROR: Batch fetch failed, falling back to individual fetches: %v", batchErr)
 				for _, faa := range incompleteFAA {
-					airport, err := s.SyncAirportByFAA(faa)
+					if ctx.Err() != nil {
+						break
+					}
+					result, err := s.syncAirport(ctx, faa)
 					if err != nil {
 						errors++
+						failed = append(failed, domain.SyncFailure{Faa: faa, Error: err.Error()})
 						log.Printf("ERROR: Failed to sync %s: %v", faa, err)
 					} else {
 						updated++
+						syncedFAAs = append(syncedFAAs, faa)
+						airport := result.Airport
 						log.Printf("INFO: Synced %s (%s) in %s: %s", airport.Faa, airport.FacilityName, airport.City, airport.Weather)
 					}
-					time.Sleep(200 * time.Millisecond)
 				}
 			}
 		}
 
-		// Merge fetched airports with complete ones
+		// Merge fetched airports into the stored ones, minus their manual
+		// overrides, then join the complete ones. Saving merges them again into
+		// the airports as stored by then, which is where conflicts are found.
+		fetched := make(map[string]*domain.Airport, len(fetchedAirports))
+		for i := range fetchedAirports {
+			if a, ok := stored[fetchedAirports[i].Faa]; ok {
+				fetched[a.Faa] = &fetchedAirports[i]
+				merged := *a
+				merged.MergeFetched(&fetchedAirports[i])
+				fetchedAirports[i] = merged
+			}
+		}
 		allAirports := append(fetchedAirports, completeAirports...)
 
-		// Refresh weather for all
+		// Refresh weather for all, then save the chunk in one transaction
+		var synced []domain.Airport
+		observations := make(map[string]domain.Observation, len(allAirports))
 		for i := range allAirports {
-			weatherText, err := s.FetchWeatherFromWeatherAPI(allAirports[i].City)
+			if ctx.Err() != nil {
+				break
+			}
+			obs, err := s.fetchWeather(ctx, &allAirports[i])
 			if err != nil {
 				errors++
+				failed = append(failed, domain.SyncFailure{Faa: allAirports[i].Faa, Error: err.Error()})
 				log.Printf("ERROR: Failed to fetch weather for %s: %v", allAirports[i].City, err)
 				continue
 			}
-			allAirports[i].Weather = weatherText
+			allAirports[i].Weather = obs.Condition
+			applyAltitudes(&allAirports[i], obs)
+			applyTimezone(&allAirports[i])
+			now := time.Now().UTC()
+			allAirports[i].LastSyncedAt = &now
+			synced = append(synced, allAirports[i])
+			observations[allAirports[i].Faa] = obs
+		}
 
-			if err := s.repo.UpdateAirport(&allAirports[i]); err != nil {
-				errors++
-				log.Printf("ERROR: Failed to update %s: %v", allAirports[i].Faa, err)
-				continue
+		if len(synced) > 0 {
+			var conflicts []domain.AirportConflict
+			saved, err := s.mergeAirports(synced, func(stored, synced *domain.Airport) []airportEvent {
+				previousWeather := stored.Weather
+				if f := fetched[stored.Faa]; f != nil && needsAirportFetch(stored) {
+					conflicts = append(conflicts, stored.MergeFetched(f)...)
+				}
+				obs := observations[stored.Faa]
+				stored.Weather = obs.Condition
+				applyAltitudes(stored, obs)
+				applyTimezone(stored)
+				stored.LastSyncedAt = synced.LastSyncedAt
+				return syncEvents(stored, previousWeather, obs)
+			})
+			if err != nil {
+				errors += len(synced)
+				for _, a := range synced {
+					failed = append(failed, domain.SyncFailure{Faa: a.Faa, Error: err.Error()})
+				}
+				log.Printf("ERROR: Failed to save %d synced airports: %v", len(synced), err)
+			} else {
+				syncedFAAs = append(syncedFAAs, faaCodes(saved)...)
+				s.invalidateAirports(faaCodes(saved)...)
+				s.recordConflicts(conflicts)
+				for i := range saved {
+					s.recordObservation(&saved[i], observations[saved[i].Faa])
+					updated++
+					log.Printf("INFO: Synced %s (%s) in %s: %s", saved[i].Faa, saved[i].FacilityName, saved[i].City, saved[i].Weather)
+				}
 			}
+		}
 
-			updated++
-			log.Printf("INFO: Synced %s (%s) in %s: %s", allAirports[i].Faa, allAirports[i].FacilityName, allAirports[i].City, allAirports[i].Weather)
-			time.Sleep(200 * time.Millisecond)
+		if len(syncedFAAs) > 0 || len(failed) > 0 {
+			if err := s.repo.RecordSyncOutcome(syncedFAAs, failed, s.cfg.SyncRetryBackoff); err != nil {
+				log.Printf("WARN: %v", err)
+			}
 		}
 
-		resultCh <- result{updated, errors}
+		mu.Lock()
+		totalUpdated += updated
+		totalErrors += errors
+		mu.Unlock()
 	}
 
-	// Launch goroutines for each chunk
-	for i := 0; i < len(airports); i += chunkSize {
-		end := min(i+chunkSize, len(airports))
-		go processChunk(airports[i:end])
+	// Start the worker pool
+	chunks := make(chan []domain.Airport)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		s.syncWorkers.Add(1)
+		go func() {
+			defer wg.Done()
+			defer s.syncWorkers.Add(-1)
+			for chunk := range chunks {
+				processChunk(chunk)
+			}
+		}()
 	}
 
-	// Collect results
-	totalUpdated, totalErrors := 0, 0
-	for i := 0; i < numChunks; i++ {
-		res := <-resultCh
-		totalUpdated += res.updated
-		totalErrors += res.errors
+	// Hand out chunks as the source fills them, until done or cancelled
+	seen, handedOut := 0, 0
+	chunk := make([]domain.Airport, 0, chunkSize)
+	handOut := func() error {
+		if pace > 0 && handedOut > 0 {
+			select {
+			case <-time.After(pace):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		handedOut++
+		select {
+		case chunks <- chunk:
+			chunk = make([]domain.Airport, 0, chunkSize)
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
+	sourceErr := source(func(a domain.Airport) error {
+		if s.skipsSync(&a) {
+			return nil
+		}
+		seen++
+		chunk = append(chunk, a)
+		if len(chunk) < chunkSize {
+			return nil
+		}
+		return handOut()
+	})
+	if sourceErr == nil && len(chunk) > 0 {
+		sourceErr = handOut()
+	}
+	close(chunks)
+	wg.Wait()
 
+	if seen == 0 {
+		if sourceErr != nil {
+			return 0, 0, fmt.Errorf("failed to get airports: %w", sourceErr)
+		}
+		return 0, 0, nil
+	}
+	s.notify(domain.AllTenants, domain.EventSyncCompleted, domain.SyncCompleted{Updated: totalUpdated, Failed: totalErrors})
+	if totalUpdated > 0 {
+		if err := s.repo.RefreshDashboard(); err != nil {
+			log.Printf("WARN: %v", err)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return totalUpdated, seen, fmt.Errorf("sync cancelled after %d airports: %w", totalUpdated, err)
+	}
+	if sourceErr != nil {
+		return totalUpdated, seen, fmt.Errorf("failed to get airports: %w", sourceErr)
+	}
 	if totalErrors > 0 && totalUpdated == 0 {
-		return 0, fmt.Errorf("failed to sync all airports")
+		return 0, seen, fmt.Errorf("failed to sync all airports")
 	}
-	return totalUpdated, nil
+	return totalUpdated, seen, nil
 }
 
-// Internal helper
-func (s *Service) fetchAirportFromAviationAPI(faa string) (*domain.Airport, error) {
-	apiURL := fmt.Sprintf("https://api.aviationapi.com/v1/airports?apt=%s", url.QueryEscape(faa))
-	resp, err := s.httpClient.Get(apiURL)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed for %s: %w", faa, err)
-	}
-	defer resp.Body.Close()
+// aviationAPIAirport is an airport as returned by the Aviation API, which sends
+// every value as text: coordinates in DD-MM-SS.sH ("33-38-12.1186N"), and
+// elevation and magnetic variation as "607" and "04E".
+type aviationAPIAirport struct {
+	SiteNumber        string `json:"site_number"`
+	FacilityName      string `json:"facility_name"`
+	Faa               string `json:"faa_ident"`
+	Icao              string `json:"icao_ident"`
+	State             string `json:"state"`
+	StateFull         string `json:"state_full"`
+	County            string `json:"county"`
+	City              string `json:"city"`
+	Ownership         string `json:"ownership"`
+	Use               string `json:"use"`
+	Manager           string `json:"manager"`
+	ManagerPhone      string `json:"manager_phone"`
+	Latitude          string `json:"latitude"`
+	Longitude         string `json:"longitude"`
+	Elevation         string `json:"elevation"`
+	MagneticVariation string `json:"magnetic_variation"`
+	Status            string `json:"status"`
+	// Sent for some airports only, the fuel types as in NASR ("100LL,A")
+	FuelTypes string `json:"fuel_types"`
+	FboName   string `json:"fbo_name"`
+	FboPhone  string `json:"fbo_phone"`
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned %s for %s", resp.Status, faa)
+// toDomain maps the airport onto the stored model, leaving values that don't
+// parse unknown.
+func (a aviationAPIAirport) toDomain() domain.Airport {
+	airport := domain.Airport{
+		SiteNumber:    a.SiteNumber,
+		FacilityName:  a.FacilityName,
+		Faa:           a.Faa,
+		Icao:          a.Icao,
+		StateCode:     a.State,
+		StateFull:     a.StateFull,
+		County:        a.County,
+		City:          a.City,
+		OwnershipType: a.Ownership,
+		UseType:       a.Use,
+		Manager:       a.Manager,
+		ManagerPhone:  a.ManagerPhone,
+		AirportStatus: a.Status,
+		FuelTypes:     domain.ParseFuelTypes(a.FuelTypes),
+		FboName:       strings.TrimSpace(a.FboName),
+		FboPhone:      strings.TrimSpace(a.FboPhone),
+	}
+	if lat, err := domain.ParseCoordinate(a.Latitude, 90); err == nil {
+		airport.Latitude = &lat
+	}
+	if lon, err := domain.ParseCoordinate(a.Longitude, 180); err == nil {
+		airport.Longitude = &lon
+	}
+	if elevation, err := strconv.ParseFloat(strings.TrimSpace(a.Elevation), 64); err == nil {
+		airport.ElevationFt = &elevation
 	}
+	if variation, err := domain.ParseMagneticVariation(a.MagneticVariation); err == nil {
+		airport.MagneticVariation = &variation
+	}
+	return airport
+}
 
-	body, err := io.ReadAll(resp.Body)
+// Internal helper
+func (s *Service) fetchAirportFromAviationAPI(faa string) (*domain.Airport, error) {
+	apiURL := fmt.Sprintf("%s?apt=%s", s.aviationAPIURL, url.QueryEscape(faa))
+	body, err := s.getAviationAPI(apiURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response for %s: %w", faa, err)
+		return nil, fmt.Errorf("%w for %s", err, faa)
 	}
 
-	var airports map[string][]domain.Airport
+	var airports map[string][]aviationAPIAirport
 	if err := json.Unmarshal(body, &airports); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response for %s: %w", faa, err)
 	}
 
 	var airport domain.Airport
 	if len(airports[faa]) > 0 {
-		airport = airports[faa][0]
+		airport = airports[faa][0].toDomain()
 	}
 
 	return &airport, nil
@@ -378,70 +1020,25 @@ func (s *Service) fetchAirportsFromAviationAPI(faaList []string) ([]domain.Airpo
 	}
 
 	aptParam := strings.Join(faaList, ",")
-	apiURL := fmt.Sprintf("https://api.aviationapi.com/v1/airports?apt=%s", url.QueryEscape(aptParam))
+	apiURL := fmt.Sprintf("%s?apt=%s", s.aviationAPIURL, url.QueryEscape(aptParam))
 
-	resp, err := s.httpClient.Get(apiURL)
+	body, err := s.getAviationAPI(apiURL)
 	if err != nil {
-		return nil, fmt.Errorf("batch request failed: %w", err)
+		return nil, fmt.Errorf("batch %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("batch API returned %s", resp.Status)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read batch response: %w", err)
-	}
-
-	var resultMap map[string][]domain.Airport
+	var resultMap map[string][]aviationAPIAirport
 	if err := json.Unmarshal(body, &resultMap); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal batch: %w", err)
 	}
 
-	// Flatten the map into a single array
+	// Flatten the map into a single array, in the order requested
 	airports := []domain.Airport{}
-	for _, airportList := range resultMap {
-		if len(airportList) > 0 {
-			airports = append(airports, airportList[0]) // Take first airport from each list
+	for _, faa := range faaList {
+		if airportList := resultMap[faa]; len(airportList) > 0 {
+			airports = append(airports, airportList[0].toDomain()) // Take first airport from each list
 		}
 	}
 
 	return airports, nil
 }
-
-// Internal helper
-func (s *Service) fetchWeatherFromWeatherAPI(city string) (string, error) {
-	if s.cfg.WeatherAPIKey == "" {
-		return "Weather API key not configured", fmt.Errorf("missing WEATHER_API_KEY")
-	}
-
-	apiURL := fmt.Sprintf(
-		"https://api.weatherapi.com/v1/current.json?key=%s&q=%s",
-		url.QueryEscape(s.cfg.WeatherAPIKey),
-		url.QueryEscape(city),
-	)
-
-	resp, err := s.httpClient.Get(apiURL)
-	if err != nil {
-		return "", fmt.Errorf("HTTP request failed for %s: %w", city, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API returned %s for %s", resp.Status, city)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response for %s: %w", city, err)
-	}
-
-	var weather domain.WeatherResponse
-	if err := json.Unmarshal(body, &weather); err != nil {
-		return "", fmt.Errorf("failed to unmarshal response for %s: %w", city, err)
-	}
-
-	return weather.Current.Condition.Text, nil
-}
@@ -2,17 +2,26 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"aviation-weather/config"
 	"aviation-weather/internal/domain"
 	mocks "aviation-weather/internal/mock" // No conflict with testify
+	"aviation-weather/internal/weather"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// deg points at a coordinate in decimal degrees.
+func deg(v float64) *float64 { return &v }
+
 var sampleAirport = domain.Airport{
 	SiteNumber:    "12345",
 	FacilityName:  "Test Airport",
@@ -26,8 +35,8 @@ var sampleAirport = domain.Airport{
 	UseType:       "Public Use",
 	Manager:       "Test Manager",
 	ManagerPhone:  "123-456-7890",
-	Latitude:      "34.0522",
-	Longitude:     "-118.2437",
+	Latitude:      deg(34.0522),
+	Longitude:     deg(-118.2437),
 	AirportStatus: "Open",
 	Weather:       "Clear",
 }
@@ -68,35 +77,59 @@ func TestCreateAirport(t *testing.T) {
 }
 
 func TestUpdateAirport(t *testing.T) {
+	overridden := sampleAirport
+	overridden.ManualOverrides = []string{"city"}
+	overridden.FuelTypes = []string{domain.FuelJetA}
+
 	tests := []struct {
 		name      string
-		setupMock func(*mocks.RepositoryMock)
+		stored    domain.Airport
+		overrides []string
+		repoErr   error
+		expected  []string
 		err       error
 	}{
 		{
-			name: "success",
-			setupMock: func(m *mocks.RepositoryMock) {
-				m.On("UpdateAirport", &sampleAirport).Return(nil)
-			},
-			err: nil,
+			name:     "edited fields become overrides",
+			stored:   sampleAirport,
+			expected: []string{"manager", "manager_phone"},
 		},
 		{
-			name: "repo error",
-			setupMock: func(m *mocks.RepositoryMock) {
-				m.On("UpdateAirport", &sampleAirport).Return(assert.AnError)
-			},
-			err: assert.AnError,
+			name:     "overrides add up",
+			stored:   overridden,
+			expected: []string{"city", "manager", "manager_phone"},
+		},
+		{
+			name:      "overrides sent replace them",
+			stored:    overridden,
+			overrides: []string{},
+			expected:  []string{},
+		},
+		{
+			name:    "repo error",
+			stored:  sampleAirport,
+			repoErr: assert.AnError,
+			err:     assert.AnError,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			stored := tt.stored
 			mockRepo := &mocks.RepositoryMock{}
-			tt.setupMock(mockRepo)
+			mockRepo.On("ModifyAirport", "TST").Return(&stored, tt.repoErr)
 			s := NewService(mockRepo, &config.Config{})
 
-			err := s.UpdateAirport(&sampleAirport)
+			edit := sampleAirport
+			edit.Manager, edit.ManagerPhone = "New Manager", "555-0100-99"
+			edit.ManualOverrides = tt.overrides
+			err := s.UpdateAirport(&edit)
 			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Equal(t, "New Manager", stored.Manager)
+				assert.Equal(t, tt.expected, stored.ManualOverrides)
+				assert.Equal(t, tt.stored.FuelTypes, stored.FuelTypes, "Fuel is left as stored")
+			}
 			mockRepo.AssertExpectations(t)
 		})
 	}
@@ -131,10 +164,16 @@ func TestDeleteAirportByFAA(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := &mocks.RepositoryMock{}
 			tt.setupMock(mockRepo)
-			s := NewService(mockRepo, &config.Config{})
+			s := NewService(mockRepo, &config.Config{}).(*Service)
+			started := s.deletedAt.Load()
 
 			err := s.DeleteAirportByFAA(tt.faa)
 			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Greater(t, s.deletedAt.Load(), started, "A deletion moves the last modification")
+			} else {
+				assert.Equal(t, started, s.deletedAt.Load(), "A failed deletion leaves it")
+			}
 			mockRepo.AssertExpectations(t)
 		})
 	}
@@ -173,7 +212,7 @@ func TestGetAirportByFAA(t *testing.T) {
 				m.On("GetAirportByFAA", "NF").Return((*domain.Airport)(nil), nil)
 			},
 			expected: nil,
-			err:      fmt.Errorf("no airport found for NF"),
+			err:      fmt.Errorf("%w: NF", domain.ErrNotFound),
 		},
 	}
 
@@ -206,7 +245,7 @@ func TestGetAllAirports(t *testing.T) {
 		{
 			name: "success",
 			setupMock: func(m *mocks.RepositoryMock) {
-				m.On("GetAllAirports").Return([]domain.Airport{sampleAirport}, nil)
+				m.On("GetAllAirports", mock.Anything).Return([]domain.Airport{sampleAirport}, nil)
 			},
 			expected: []domain.Airport{sampleAirport},
 			err:      nil,
@@ -214,7 +253,7 @@ func TestGetAllAirports(t *testing.T) {
 		{
 			name: "repo error",
 			setupMock: func(m *mocks.RepositoryMock) {
-				m.On("GetAllAirports").Return([]domain.Airport{}, assert.AnError)
+				m.On("GetAllAirports", mock.Anything).Return([]domain.Airport{}, assert.AnError)
 			},
 			expected: nil,
 			err:      fmt.Errorf("failed to get airports: %w", assert.AnError),
@@ -222,7 +261,7 @@ func TestGetAllAirports(t *testing.T) {
 		{
 			name: "no airports",
 			setupMock: func(m *mocks.RepositoryMock) {
-				m.On("GetAllAirports").Return([]domain.Airport{}, nil)
+				m.On("GetAllAirports", mock.Anything).Return([]domain.Airport{}, nil)
 			},
 			expected: []domain.Airport{},
 			err:      nil,
@@ -235,7 +274,7 @@ func TestGetAllAirports(t *testing.T) {
 			tt.setupMock(mockRepo)
 			s := NewService(mockRepo, &config.Config{})
 
-			airports, err := s.GetAllAirports()
+			airports, err := s.GetAllAirports(nil)
 			assert.Equal(t, tt.expected, airports)
 			if tt.err != nil {
 				assert.Error(t, err)
@@ -248,14 +287,169 @@ func TestGetAllAirports(t *testing.T) {
 	}
 }
 
+func TestImportAirports(t *testing.T) {
+	overridden := sampleAirport
+	overridden.Manager = "Corrected Manager"
+	overridden.ManualOverrides = []string{"manager"}
+
+	tests := []struct {
+		name      string
+		airports  []domain.Airport
+		setupMock func(*mocks.RepositoryMock)
+		err       error
+	}{
+		{
+			name:     "new airports are inserted",
+			airports: []domain.Airport{sampleAirport},
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("MergeAirports", []domain.Airport{sampleAirport}).Return([]domain.Airport{})
+				m.On("UpsertAirports", []domain.Airport{sampleAirport}).Return(nil)
+				m.On("RefreshDashboard").Return(nil).Maybe()
+			},
+			err: nil,
+		},
+		{
+			name:     "stored airports keep their overrides",
+			airports: []domain.Airport{sampleAirport},
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("MergeAirports", []domain.Airport{sampleAirport}).Return([]domain.Airport{overridden})
+				m.On("SaveConflicts", []domain.AirportConflict{{
+					Faa: "TST", Field: "manager", LocalValue: "Corrected Manager", ProviderValue: "Test Manager", Status: domain.ConflictPending,
+				}}).Return(nil)
+				m.On("RefreshDashboard").Return(nil).Maybe()
+			},
+			err: nil,
+		},
+		{
+			name:     "nothing to import",
+			airports: []domain.Airport{},
+			setupMock: func(m *mocks.RepositoryMock) {
+				// No call expected
+			},
+			err: nil,
+		},
+		{
+			name:     "merge error",
+			airports: []domain.Airport{sampleAirport},
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("MergeAirports", []domain.Airport{sampleAirport}).Return(assert.AnError)
+				m.On("RefreshDashboard").Return(nil).Maybe()
+			},
+			err: fmt.Errorf("failed to import airports: %w", assert.AnError),
+		},
+		{
+			name:     "insert error",
+			airports: []domain.Airport{sampleAirport},
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("MergeAirports", []domain.Airport{sampleAirport}).Return([]domain.Airport{})
+				m.On("UpsertAirports", []domain.Airport{sampleAirport}).Return(assert.AnError)
+				m.On("RefreshDashboard").Return(nil).Maybe()
+			},
+			err: fmt.Errorf("failed to import airports: %w", assert.AnError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.RepositoryMock{}
+			tt.setupMock(mockRepo)
+			s := NewService(mockRepo, &config.Config{})
+
+			err := s.ImportAirports(tt.airports)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateAirports(t *testing.T) {
+	tests := []struct {
+		name            string
+		airports        []domain.Airport
+		setupMock       func(*mocks.RepositoryMock)
+		expectedCreated []string
+		expectedSkipped []string
+		err             error
+	}{
+		{
+			name:     "success",
+			airports: []domain.Airport{sampleAirport},
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("CreateAirports", []domain.Airport{sampleAirport}).Return([]string{"TST"}, []string{}, nil)
+			},
+			expectedCreated: []string{"TST"},
+			expectedSkipped: []string{},
+			err:             nil,
+		},
+		{
+			name:     "nothing to create",
+			airports: nil,
+			setupMock: func(m *mocks.RepositoryMock) {
+				// No call expected
+			},
+			expectedCreated: []string{},
+			expectedSkipped: []string{},
+			err:             nil,
+		},
+		{
+			name:     "repo error",
+			airports: []domain.Airport{sampleAirport},
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("CreateAirports", []domain.Airport{sampleAirport}).Return([]string(nil), []string(nil), assert.AnError)
+			},
+			err: fmt.Errorf("failed to create airports: %w", assert.AnError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.RepositoryMock{}
+			tt.setupMock(mockRepo)
+			s := NewService(mockRepo, &config.Config{})
+
+			created, skipped, err := s.CreateAirports(tt.airports)
+			assert.Equal(t, tt.expectedCreated, created)
+			assert.Equal(t, tt.expectedSkipped, skipped)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestSyncAirportByFAA(t *testing.T) {
 	tests := []struct {
 		name      string
 		faa       string
 		setupMock func(*mocks.RepositoryMock)
-		expected  *domain.Airport
+		expected  []string
 		err       error
 	}{
+		{
+			name: "success reports changed fields",
+			faa:  "TST",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&domain.Airport{
+					Faa:  "TST",
+					City: "Old City",
+				}, nil)
+				m.On("ModifyAirport", "TST").Return(&domain.Airport{
+					Faa:  "TST",
+					City: "Old City",
+				}, nil)
+				m.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+				m.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+			},
+			expected: []string{"city", "weather"},
+			err:      nil,
+		},
 		{
 			name: "repo update error",
 			faa:  "TST",
@@ -264,7 +458,10 @@ func TestSyncAirportByFAA(t *testing.T) {
 					Faa:  "TST",
 					City: "Old City",
 				}, nil)
-				m.On("UpdateAirport", mock.Anything).Return(assert.AnError)
+				m.On("ModifyAirport", "TST").Return(&domain.Airport{
+					Faa:  "TST",
+					City: "Old City",
+				}, assert.AnError)
 			},
 			expected: nil,
 			err:      fmt.Errorf("failed to update airport TST: %w", assert.AnError),
@@ -281,12 +478,17 @@ func TestSyncAirportByFAA(t *testing.T) {
 			s.FetchAirportFromAviationAPI = func(faa string) (*domain.Airport, error) {
 				return &domain.Airport{Faa: faa, City: "Jakarta"}, nil
 			}
-			s.FetchWeatherFromWeatherAPI = func(city string) (string, error) {
-				return "Sunny", nil
+			s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+				return domain.Observation{Condition: "Sunny"}, nil
 			}
 
-			airport, err := s.SyncAirportByFAA(tt.faa)
-			assert.Equal(t, tt.expected, airport)
+			result, err := s.SyncAirportByFAA(context.Background(), tt.faa)
+			if tt.expected != nil {
+				assert.Equal(t, tt.expected, result.ChangedFields)
+				assert.Equal(t, "Sunny", result.Airport.Weather)
+			} else {
+				assert.Nil(t, result)
+			}
 			if tt.err != nil {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tt.err.Error())
@@ -296,6 +498,104 @@ func TestSyncAirportByFAA(t *testing.T) {
 	}
 }
 
+func TestSyncAirportKeepsConcurrentEdit(t *testing.T) {
+	// The airport lacked static fields when read, then was filled in by an
+	// edit before the sync saved
+	edited := sampleAirport
+	edited.Manager = "Edited Manager"
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&domain.Airport{Faa: "TST", City: "Old City"}, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&edited, nil)
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchAirportFromAviationAPI = func(faa string) (*domain.Airport, error) {
+		return &domain.Airport{Faa: faa, City: "Jakarta"}, nil
+	}
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Sunny"}, nil
+	}
+
+	result, err := s.SyncAirportByFAA(context.Background(), "TST")
+	assert.NoError(t, err)
+	assert.Equal(t, "Edited Manager", edited.Manager)
+	assert.Equal(t, sampleAirport.City, edited.City, "Fetched fields shouldn't replace the edit")
+	assert.Equal(t, "Sunny", edited.Weather)
+	assert.Contains(t, result.ChangedFields, "weather")
+	assert.NotContains(t, result.ChangedFields, "city")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncAirportKeepsManualOverrides(t *testing.T) {
+	stored := sampleAirport
+	stored.County = "" // Has the sync fetch the airport
+	stored.Manager = "Corrected Manager"
+	stored.ManualOverrides = []string{"manager"}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&stored, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&stored, nil)
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+	mockRepo.On("SaveConflicts", []domain.AirportConflict{{
+		Faa: "TST", Field: "manager", LocalValue: "Corrected Manager", ProviderValue: "Provider Manager", Status: domain.ConflictPending,
+	}}).Return(nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchAirportFromAviationAPI = func(faa string) (*domain.Airport, error) {
+		fetched := sampleAirport
+		fetched.County, fetched.Manager, fetched.ManagerPhone = "Fetched County", "Provider Manager", "555-0199"
+		return &fetched, nil
+	}
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Sunny"}, nil
+	}
+
+	result, err := s.SyncAirportByFAA(context.Background(), "TST")
+	assert.NoError(t, err)
+	assert.Equal(t, "Corrected Manager", stored.Manager)
+	assert.Equal(t, "555-0199", stored.ManagerPhone, "Fields not overridden take the provider's value")
+	assert.Equal(t, "Fetched County", stored.County)
+	assert.Equal(t, []string{"manager"}, stored.ManualOverrides)
+	assert.NotContains(t, result.ChangedFields, "manager")
+	mockRepo.AssertCalled(t, "SaveConflicts", mock.Anything)
+}
+
+func TestUpdateAirportWaitsForSync(t *testing.T) {
+	stored := sampleAirport
+	var order []string
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&stored, nil)
+	mockRepo.On("ModifyAirport", "TST").Run(func(mock.Arguments) { order = append(order, "save") }).Return(&stored, nil)
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		close(fetching)
+		<-release
+		order = append(order, "sync")
+		return domain.Observation{Condition: "Sunny"}, nil
+	}
+
+	synced := make(chan error)
+	go func() {
+		_, err := s.SyncAirportByFAA(context.Background(), "TST")
+		synced <- err
+	}()
+	<-fetching
+	updated := make(chan error)
+	go func() {
+		edit := sampleAirport
+		updated <- s.UpdateAirport(&edit)
+	}()
+	time.Sleep(20 * time.Millisecond) // Let the update wait for the sync
+	close(release)
+
+	assert.NoError(t, <-synced)
+	assert.NoError(t, <-updated)
+	assert.Equal(t, []string{"sync", "save", "save"}, order)
+}
+
 func TestSyncAllAirports(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -306,7 +606,7 @@ func TestSyncAllAirports(t *testing.T) {
 		{
 			name: "no airports",
 			setupMock: func(m *mocks.RepositoryMock) {
-				m.On("GetAllAirports").Return([]domain.Airport{}, nil)
+				m.On("ForEachAirportBySyncPriority", mock.Anything).Return([]domain.Airport{}, nil)
 			},
 			expected: 0,
 			err:      fmt.Errorf("no airports to sync"),
@@ -314,7 +614,7 @@ func TestSyncAllAirports(t *testing.T) {
 		{
 			name: "repo get error",
 			setupMock: func(m *mocks.RepositoryMock) {
-				m.On("GetAllAirports").Return([]domain.Airport{}, assert.AnError)
+				m.On("ForEachAirportBySyncPriority", mock.Anything).Return([]domain.Airport{}, assert.AnError)
 			},
 			expected: 0,
 			err:      fmt.Errorf("failed to get airports: %w", assert.AnError),
@@ -322,10 +622,16 @@ func TestSyncAllAirports(t *testing.T) {
 		{
 			name: "successful sync with mocked APIs",
 			setupMock: func(m *mocks.RepositoryMock) {
-				m.On("GetAllAirports").Return([]domain.Airport{
+				m.On("ForEachAirportBySyncPriority", mock.Anything).Return([]domain.Airport{
 					{Faa: "TST", FacilityName: "Test Airport", City: "Jakarta"},
 				}, nil)
-				m.On("UpdateAirport", mock.Anything).Return(nil)
+				m.On("MergeAirports", mock.MatchedBy(func(airports []domain.Airport) bool {
+					return len(airports) == 1 && airports[0].LastSyncedAt != nil // Sync stamps weather freshness
+				})).Return(nil)
+				m.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+				m.On("RefreshDashboard").Return(nil).Maybe()
+				m.On("SaveObservation", "TST", mock.Anything).Return(nil)
+				m.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
 			},
 			expected: 1,
 			err:      nil,
@@ -353,11 +659,11 @@ func TestSyncAllAirports(t *testing.T) {
 			}
 
 			// mock weather API call
-			s.FetchWeatherFromWeatherAPI = func(city string) (string, error) {
-				return "Clear skies", nil
+			s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+				return domain.Observation{Condition: "Clear skies"}, nil
 			}
 
-			updated, err := s.SyncAllAirports()
+			updated, err := s.SyncAllAirports(context.Background())
 			assert.Equal(t, tt.expected, updated)
 
 			if tt.err != nil {
@@ -371,3 +677,424 @@ func TestSyncAllAirports(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncAllAirportsStaleOnly(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ForEachAirportNeedingSync", mock.Anything, 6*time.Hour).Return([]domain.Airport{sampleAirport}, nil)
+	mockRepo.On("MergeAirports", mock.Anything).Return(nil)
+	mockRepo.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRepo.On("RefreshDashboard").Return(nil).Once()
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+
+	s := NewService(mockRepo, &config.Config{SyncStaleAfter: 6 * time.Hour}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Clear skies"}, nil
+	}
+
+	updated, err := s.SyncAllAirports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	mockRepo.AssertNotCalled(t, "ForEachAirportBySyncPriority", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestApplyConfig(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ForEachAirportNeedingSync", mock.Anything, time.Hour).Return([]domain.Airport{sampleAirport}, nil)
+	mockRepo.On("MergeAirports", mock.Anything).Return(nil)
+	mockRepo.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRepo.On("RefreshDashboard").Return(nil).Maybe()
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Clear skies"}, nil
+	}
+	assert.Nil(t, s.aviationLimiter.Load(), "Unlimited until configured")
+
+	s.ApplyConfig(&config.Config{SyncStaleAfter: time.Hour, AviationAPIRPS: 5, AviationAPIBurst: 5, WeatherAPIRPS: 2, WeatherAPIBurst: 2})
+	assert.NotNil(t, s.aviationLimiter.Load())
+	for _, p := range s.weatherProviders {
+		assert.NotNil(t, p.limiter.Load())
+	}
+
+	_, err := s.SyncAllAirports(context.Background())
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "ForEachAirportBySyncPriority", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncAirportsPartial(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(*mocks.RepositoryMock)
+		sync      func(*Service) (int, error)
+		expected  int
+		err       error
+	}{
+		{
+			name: "by state",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportsByState", "CA").Return([]domain.Airport{sampleAirport}, nil)
+				m.On("MergeAirports", mock.Anything).Return(nil)
+				m.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+				m.On("RefreshDashboard").Return(nil).Maybe()
+				m.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+				m.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+			},
+			sync:     func(s *Service) (int, error) { return s.SyncAirportsByState(context.Background(), "CA") },
+			expected: 1,
+		},
+		{
+			name: "state without airports",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportsByState", "XX").Return([]domain.Airport{}, nil)
+			},
+			sync:     func(s *Service) (int, error) { return s.SyncAirportsByState(context.Background(), "XX") },
+			expected: 0,
+			err:      fmt.Errorf("%w: no airports in state XX", domain.ErrNotFound),
+		},
+		{
+			name: "by FAA list",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportsByFAAs", []string{"TST", "ABC"}).Return([]domain.Airport{sampleAirport}, nil)
+				m.On("MergeAirports", mock.Anything).Return(nil)
+				m.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+				m.On("RefreshDashboard").Return(nil).Maybe()
+				m.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+				m.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+			},
+			sync: func(s *Service) (int, error) {
+				return s.SyncAirportsByFAAs(context.Background(), []string{"TST", "ABC"})
+			},
+			expected: 1,
+		},
+		{
+			name: "FAA list repo error",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportsByFAAs", []string{"TST"}).Return([]domain.Airport{}, assert.AnError)
+			},
+			sync:     func(s *Service) (int, error) { return s.SyncAirportsByFAAs(context.Background(), []string{"TST"}) },
+			expected: 0,
+			err:      fmt.Errorf("failed to get airports: %w", assert.AnError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.RepositoryMock{}
+			tt.setupMock(mockRepo)
+
+			s := NewService(mockRepo, &config.Config{}).(*Service)
+			s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+				return domain.Observation{Condition: "Clear skies"}, nil
+			}
+
+			updated, err := tt.sync(s)
+			assert.Equal(t, tt.expected, updated)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertNotCalled(t, "GetAllAirports")
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSyncAllAirportsSavesChunksInOneUpsert(t *testing.T) {
+	airports := []domain.Airport{sampleAirport, sampleAirport, sampleAirport}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ForEachAirportBySyncPriority", mock.Anything).Return(airports, nil)
+	mockRepo.On("MergeAirports", mock.MatchedBy(func(a []domain.Airport) bool { return len(a) == 2 })).Return(nil).Once()
+	mockRepo.On("MergeAirports", mock.MatchedBy(func(a []domain.Airport) bool { return len(a) == 1 })).Return(assert.AnError).Once()
+	mockRepo.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRepo.On("RefreshDashboard").Return(nil).Maybe()
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+
+	s := NewService(mockRepo, &config.Config{SyncChunkSize: 2, SyncMaxConcurrency: 1}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Clear skies"}, nil
+	}
+
+	updated, err := s.SyncAllAirports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated, "A failed upsert fails its whole chunk")
+	mockRepo.AssertNotCalled(t, "ModifyAirport", mock.Anything)
+	mockRepo.AssertNumberOfCalls(t, "SaveObservation", 2)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncAllAirportsStreamError(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ForEachAirportBySyncPriority", mock.Anything).Return([]domain.Airport{sampleAirport}, assert.AnError)
+	mockRepo.On("MergeAirports", mock.Anything).Return(nil)
+	mockRepo.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRepo.On("RefreshDashboard").Return(nil).Maybe()
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+
+	s := NewService(mockRepo, &config.Config{SyncChunkSize: 1}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Clear skies"}, nil
+	}
+
+	updated, err := s.SyncAllAirports(context.Background())
+	assert.EqualError(t, err, fmt.Sprintf("failed to get airports: %v", assert.AnError))
+	assert.Equal(t, 1, updated, "Airports read before the failure are still synced")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncAllAirportsConcurrencyLimit(t *testing.T) {
+	airports := []domain.Airport{sampleAirport, sampleAirport, sampleAirport, sampleAirport}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ForEachAirportBySyncPriority", mock.Anything).Return(airports, nil)
+	mockRepo.On("MergeAirports", mock.Anything).Return(nil)
+	mockRepo.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRepo.On("RefreshDashboard").Return(nil).Maybe()
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+
+	s := NewService(mockRepo, &config.Config{SyncChunkSize: 1, SyncMaxConcurrency: 2}).(*Service)
+
+	var inFlight, peak atomic.Int32
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return domain.Observation{Condition: "Clear skies"}, nil
+	}
+
+	updated, err := s.SyncAllAirports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 4, updated)
+	assert.LessOrEqual(t, peak.Load(), int32(2), "At most two chunks should run at once")
+}
+
+func TestWeatherCircuitBreaker(t *testing.T) {
+	airports := []domain.Airport{sampleAirport, sampleAirport, sampleAirport, sampleAirport, sampleAirport}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ForEachAirportBySyncPriority", mock.Anything).Return(airports, nil)
+	mockRepo.On("RecordSyncOutcome", []string(nil), mock.MatchedBy(func(failed []domain.SyncFailure) bool {
+		return len(failed) == 5 // Every airport is recorded for a retry
+	}), time.Duration(0)).Return(nil)
+
+	s := NewService(mockRepo, &config.Config{BreakerFailureThreshold: 2, BreakerCooldown: time.Minute}).(*Service)
+
+	failing := &fakeProvider{name: "weatherapi", err: assert.AnError}
+	s.weatherProviders[0].Provider = failing
+
+	updated, err := s.SyncAllAirports(context.Background())
+	assert.EqualError(t, err, "failed to sync all airports")
+	assert.Equal(t, 0, updated)
+	assert.Equal(t, 2, failing.calls, "Open breaker should stop calls to the provider")
+
+	statuses := s.ProviderStatuses()
+	assert.Equal(t, domain.ProviderStatus{Provider: "weatherapi", State: "open", Failures: 2, Trips: 1}, statuses[1])
+	mockRepo.AssertNotCalled(t, "MergeAirports", mock.Anything)
+}
+
+func TestWeatherCache(t *testing.T) {
+	dallas := sampleAirport
+	dallas.City = "Dallas"
+	airports := []domain.Airport{dallas, dallas, dallas}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ForEachAirportBySyncPriority", mock.Anything).Return(airports, nil)
+	mockRepo.On("MergeAirports", mock.Anything).Return(nil)
+	mockRepo.On("RecordSyncOutcome", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRepo.On("RefreshDashboard").Return(nil).Maybe()
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+
+	s := NewService(mockRepo, &config.Config{WeatherCacheTTL: time.Minute}).(*Service)
+
+	calls := 0
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		calls++
+		return domain.Observation{Condition: "Sunny"}, nil
+	}
+
+	updated, err := s.SyncAllAirports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, updated)
+	assert.Equal(t, 1, calls, "Airports in the same city should share one weather call")
+	assert.Equal(t, domain.CacheStats{Enabled: true, Hits: 2, Misses: 1, Entries: 1, HitRatio: 2.0 / 3.0}, s.CacheStats())
+}
+
+func TestGetAirportsByFAAs(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportsByFAAs", []string{"LAX", "NONE", "JFK"}).Return([]domain.Airport{{Faa: "JFK"}, {Faa: "LAX"}}, nil)
+	mockRepo.On("GetAirportsByFAAs", []string{"ERR"}).Return([]domain.Airport(nil), assert.AnError)
+	s := NewService(mockRepo, &config.Config{})
+
+	airports, err := s.GetAirportsByFAAs([]string{"LAX", "NONE", "JFK"})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Airport{{Faa: "LAX"}, {Faa: "JFK"}}, airports, "Airports keep the order listed")
+
+	_, err = s.GetAirportsByFAAs([]string{"ERR"})
+	assert.EqualError(t, err, "failed to get airports: "+assert.AnError.Error())
+}
+
+func TestGetStatesAndCities(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetStates").Return([]string{"CA", "TX"}, nil)
+	mockRepo.On("GetCities", "CA").Return([]string{"Los Angeles"}, nil)
+	mockRepo.On("GetCities", "XX").Return([]string(nil), assert.AnError)
+	s := NewService(mockRepo, &config.Config{})
+
+	states, err := s.GetStates()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CA", "TX"}, states)
+
+	cities, err := s.GetCities("CA")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Los Angeles"}, cities)
+
+	_, err = s.GetCities("XX")
+	assert.EqualError(t, err, "failed to get cities: "+assert.AnError.Error())
+}
+
+func TestAirportCache(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	stored := sampleAirport
+	mockRepo.On("GetAllAirports", mock.Anything).Return([]domain.Airport{sampleAirport}, nil).Twice()
+	mockRepo.On("ModifyAirport", "TST").Return(&stored, nil)
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+
+	s := NewService(mockRepo, &config.Config{AirportCacheTTL: time.Minute, CacheBackend: "memory"})
+
+	// Second read is served from the cache
+	for i := 0; i < 2; i++ {
+		airports, err := s.GetAllAirports(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Airport{sampleAirport}, airports)
+	}
+	mockRepo.AssertNumberOfCalls(t, "GetAllAirports", 1)
+
+	// A write invalidates the cached list
+	edit := sampleAirport
+	assert.NoError(t, s.UpdateAirport(&edit))
+	_, err := s.GetAllAirports(nil)
+	assert.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "GetAllAirports", 2)
+	assert.Equal(t, "memory", s.CacheStats().Backend)
+
+	// Sorted lists are read from the repository every time
+	byCity := []domain.SortField{{Field: "city"}}
+	mockRepo.On("GetAllAirports", byCity).Return([]domain.Airport{sampleAirport}, nil).Twice()
+	for i := 0; i < 2; i++ {
+		_, err = s.GetAllAirports(byCity)
+		assert.NoError(t, err)
+	}
+	mockRepo.AssertNumberOfCalls(t, "GetAllAirports", 4)
+}
+
+// fakeProvider is a weather.Provider returning a fixed result.
+type fakeProvider struct {
+	name  string
+	obs   domain.Observation
+	err   error
+	calls int
+}
+
+func (f *fakeProvider) Name() string { return f.name }
+
+func (f *fakeProvider) Fetch(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+	f.calls++
+	return f.obs, f.err
+}
+
+func TestWeatherProviderFallback(t *testing.T) {
+	airport := sampleAirport
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&airport, nil)
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+
+	s := NewService(mockRepo, &config.Config{WeatherProviders: []string{"weatherapi", "noaa"}}).(*Service)
+	primary := &fakeProvider{name: "weatherapi", err: weather.ErrRateLimited}
+	secondary := &fakeProvider{name: "noaa", obs: domain.Observation{Provider: "noaa", Condition: "Mist"}}
+	s.weatherProviders[0].Provider = primary
+	s.weatherProviders[1].Provider = secondary
+
+	synced, err := s.SyncAirportByFAA(context.Background(), "TST")
+	assert.NoError(t, err)
+	assert.Equal(t, "Mist", synced.Airport.Weather)
+	assert.Equal(t, 1, primary.calls)
+	assert.Equal(t, 1, secondary.calls)
+}
+
+func TestSyncAirportQueuedDeduplicates(t *testing.T) {
+	airport := sampleAirport
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&airport, nil)
+	mockRepo.On("SaveObservation", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+	job := domain.QueuedJob{ID: 1, Type: domain.QueuedSyncAirport, Payload: json.RawMessage(`{"faa":"TST"}`), Status: domain.JobStatusRunning, Attempts: 1}
+	mockRepo.On("EnqueueJob", mock.Anything).Run(func(args mock.Arguments) { args.Get(0).(*domain.QueuedJob).ID = 1 }).Return(nil).Once()
+	mockRepo.On("ClaimQueuedJob", jobLease).Return(&job, nil).Once()
+	mockRepo.On("ClaimQueuedJob", jobLease).Return((*domain.QueuedJob)(nil), nil)
+	mockRepo.On("FinishQueuedJob", int64(1), mock.Anything).Return(nil).Once()
+
+	s := NewService(mockRepo, &config.Config{JobWorkers: 1}).(*Service)
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go s.RunJobWorkers(ctx)
+	release := make(chan struct{})
+	var calls atomic.Int32
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		calls.Add(1)
+		<-release
+		return domain.Observation{Condition: "Clear skies"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			synced, err := s.SyncAirportQueued("TST")
+			assert.NoError(t, err)
+			assert.Equal(t, "Clear skies", synced.Airport.Weather)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond) // Let every request join the in-flight sync
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load(), "Concurrent syncs of one FAA should run once")
+	mockRepo.AssertNumberOfCalls(t, "ModifyAirport", 1)
+	mockRepo.AssertNumberOfCalls(t, "EnqueueJob", 1)
+}
+
+func TestSyncAllAirportsCancelled(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("ForEachAirportBySyncPriority", mock.Anything).Return([]domain.Airport{sampleAirport, sampleAirport}, nil)
+
+	s := NewService(mockRepo, &config.Config{SyncChunkSize: 1, SyncMaxConcurrency: 1}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Clear skies"}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	updated, err := s.SyncAllAirports(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, updated)
+	mockRepo.AssertNotCalled(t, "MergeAirports", mock.Anything)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// defaultStaleAfter judges weather stale when SyncStaleAfter is unset: the
+// default schedule syncs every 12 hours, so older weather missed a sync.
+const defaultStaleAfter = 12 * time.Hour
+
+// AirportStats counts the stored airports by state, ownership and flight
+// category, and those whose weather is stale.
+func (s *Service) AirportStats(ctx context.Context) (*domain.AirportStats, error) {
+	staleAfter := time.Duration(s.syncStaleAfter.Load())
+	if staleAfter <= 0 {
+		staleAfter = defaultStaleAfter
+	}
+
+	stats, err := s.repo.GetAirportStats(ctx, time.Now().Add(-staleAfter))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get airport stats: %w", err)
+	}
+	stats.StaleAfter = staleAfter.String()
+	return stats, nil
+}
+
+// Dashboard reports the current conditions by state and flight category, as
+// of the last sync.
+func (s *Service) Dashboard(ctx context.Context) (*domain.Dashboard, error) {
+	dashboard, err := s.repo.GetDashboard(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dashboard: %w", err)
+	}
+	return dashboard, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAirportStats(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        *config.Config
+		staleAfter time.Duration
+	}{
+		{name: "default staleness", cfg: &config.Config{}, staleAfter: 12 * time.Hour},
+		{name: "configured staleness", cfg: &config.Config{SyncStaleAfter: 6 * time.Hour}, staleAfter: 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.RepositoryMock{}
+			before := time.Now()
+			mockRepo.On("GetAirportStats", mock.Anything, mock.MatchedBy(func(staleBefore time.Time) bool {
+				return !staleBefore.Before(before.Add(-tt.staleAfter)) && !staleBefore.After(time.Now().Add(-tt.staleAfter))
+			})).Return(&domain.AirportStats{TotalAirports: 2, StaleWeather: 1}, nil)
+			s := NewService(mockRepo, tt.cfg)
+
+			stats, err := s.AirportStats(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, &domain.AirportStats{TotalAirports: 2, StaleWeather: 1, StaleAfter: tt.staleAfter.String()}, stats)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportStats", mock.Anything, mock.Anything).Return((*domain.AirportStats)(nil), assert.AnError)
+	_, err := NewService(mockRepo, &config.Config{}).AirportStats(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+}
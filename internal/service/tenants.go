@@ -0,0 +1,79 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"aviation-weather/internal/domain"
+)
+
+// apiKeyBytes is the entropy of issued API keys.
+const apiKeyBytes = 24
+
+// ResolveTenant returns the tenant of apiKey. Requests without a key act for
+// domain.DefaultTenantID, as they did before tenants, while a key that was
+// never issued or was revoked returns domain.ErrAPIKeyNotFound.
+func (s *Service) ResolveTenant(apiKey string) (int64, error) {
+	if apiKey == "" {
+		return domain.DefaultTenantID, nil
+	}
+	tenantID, err := s.repo.GetTenantIDByKeyHash(keyOwner(apiKey))
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return 0, err
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve tenant: %w", err)
+	}
+	return tenantID, nil
+}
+
+// CreateTenant adds a tenant, to be issued API keys.
+func (s *Service) CreateTenant(t *domain.Tenant) error {
+	if err := s.repo.CreateTenant(t); err != nil {
+		return fmt.Errorf("failed to create tenant: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) GetTenants() ([]domain.Tenant, error) {
+	tenants, err := s.repo.GetTenants()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenants: %w", err)
+	}
+	return tenants, nil
+}
+
+// CreateAPIKey issues a random key for the tenant of key and fills in
+// key.Key, which is never stored and can't be shown again.
+func (s *Service) CreateAPIKey(key *domain.APIKey) error {
+	secret := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key.Key = "aw_" + hex.EncodeToString(secret)
+	key.KeyHash = keyOwner(key.Key)
+
+	if err := s.repo.CreateAPIKey(key); err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	return nil
+}
+
+// DeleteAPIKey revokes a key; its requests fall back to the default tenant.
+func (s *Service) DeleteAPIKey(id int64) error {
+	if err := s.repo.DeleteAPIKey(id); err != nil {
+		return fmt.Errorf("failed to delete API key: %w", err)
+	}
+	return nil
+}
+
+// GetAPIKeys returns the keys of a tenant, without the keys themselves.
+func (s *Service) GetAPIKeys(tenantID int64) ([]domain.APIKey, error) {
+	keys, err := s.repo.GetAPIKeys(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	return keys, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResolveTenant(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetTenantIDByKeyHash", keyOwner("aw_acme")).Return(int64(2), nil)
+	mockRepo.On("GetTenantIDByKeyHash", keyOwner("legacy")).Return(int64(0), domain.ErrAPIKeyNotFound)
+	mockRepo.On("GetTenantIDByKeyHash", keyOwner("broken")).Return(int64(0), assert.AnError)
+	s := NewService(mockRepo, &config.Config{})
+
+	tenantID, err := s.ResolveTenant("aw_acme")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), tenantID)
+
+	_, err = s.ResolveTenant("legacy")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound, "Unregistered keys are rejected")
+
+	tenantID, err = s.ResolveTenant("")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DefaultTenantID, tenantID)
+
+	_, err = s.ResolveTenant("broken")
+	assert.EqualError(t, err, "failed to resolve tenant: "+assert.AnError.Error())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("CreateAPIKey", mock.MatchedBy(func(key *domain.APIKey) bool {
+		return key.TenantID == 2 && key.KeyHash == keyOwner(key.Key)
+	})).Return(nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	key := domain.APIKey{TenantID: 2, Name: "ops"}
+	assert.NoError(t, s.CreateAPIKey(&key))
+	assert.True(t, strings.HasPrefix(key.Key, "aw_"))
+	assert.Len(t, key.Key, len("aw_")+2*apiKeyBytes)
+
+	other := domain.APIKey{TenantID: 2}
+	assert.NoError(t, s.CreateAPIKey(&other))
+	assert.NotEqual(t, key.Key, other.Key)
+	mockRepo.AssertExpectations(t)
+}
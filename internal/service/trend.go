@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// DefaultTrendHours is the trend window when the caller leaves it out, that of
+// the three-hour pressure tendency.
+const DefaultTrendHours = 3
+
+// Slopes per hour within which an element counts as steady: the 1 hPa in
+// three hours of a steady pressure tendency, a quarter mile of visibility and
+// 100 ft of ceiling.
+const (
+	steadyPressureHpa  = 1.0 / 3
+	steadyVisibilitySM = 0.25
+	steadyCeilingFt    = 100
+)
+
+// Caps keeping unlimited values from swamping the slopes. Observations
+// without a ceiling count as a 12,000 ft one, so a layer breaking up reads as
+// improving, and visibility is rarely reported beyond 10 miles.
+const (
+	unlimitedCeilingFt    = 12000
+	unlimitedVisibilitySM = 10
+)
+
+// GetWeatherTrend reports whether the pressure, visibility and ceiling of an
+// airport improved or deteriorated over the last hours, from the slopes of
+// its weather_history. Ceilings come from the raw METARs, so observations of
+// providers without one only count toward pressure and visibility.
+func (s *Service) GetWeatherTrend(faa string, hours int) (*domain.WeatherTrend, error) {
+	if hours <= 0 {
+		hours = DefaultTrendHours
+	}
+
+	airport, err := s.GetAirportByFAA(faa)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+	history, err := s.repo.GetWeatherHistory(airport.Faa, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weather history for %s: %w", faa, err)
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf("%w: no weather history for %s in the last %d hours", domain.ErrNoData, faa, hours)
+	}
+
+	var pressure, visibility, ceiling []aviation.Sample
+	for _, obs := range history {
+		if obs.PressureHpa > 0 {
+			pressure = append(pressure, aviation.Sample{At: obs.ObservedAt, Value: obs.PressureHpa})
+		}
+		if obs.VisibilitySM > 0 {
+			visibility = append(visibility, aviation.Sample{At: obs.ObservedAt, Value: min(obs.VisibilitySM, unlimitedVisibilitySM)})
+		}
+		if obs.RawMETAR == "" {
+			continue
+		}
+		metar, err := domain.ParseMETAR(obs.RawMETAR, obs.ObservedAt)
+		if err != nil {
+			continue
+		}
+		ceilingFt := unlimitedCeilingFt
+		if metar.CeilingFt != nil {
+			ceilingFt = min(*metar.CeilingFt, unlimitedCeilingFt)
+		}
+		ceiling = append(ceiling, aviation.Sample{At: obs.ObservedAt, Value: float64(ceilingFt)})
+	}
+
+	trend := &domain.WeatherTrend{
+		Faa:          airport.Faa,
+		Hours:        hours,
+		Since:        since.UTC().Truncate(time.Second),
+		Observations: len(history),
+		PressureHpa:  aviation.Trend(pressure, steadyPressureHpa),
+		VisibilitySM: aviation.Trend(visibility, steadyVisibilitySM),
+		CeilingFt:    aviation.Trend(ceiling, steadyCeilingFt),
+	}
+	trend.Overall = aviation.OverallTrend(trend.PressureHpa, trend.VisibilitySM, trend.CeilingFt)
+	return trend, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetWeatherTrend(t *testing.T) {
+	start := time.Now().Add(-3 * time.Hour).Truncate(time.Minute)
+	at := func(hours int) time.Time { return start.Add(time.Duration(hours) * time.Hour) }
+	closingIn := []domain.Observation{
+		{PressureHpa: 1016, VisibilitySM: 10, ObservedAt: at(0), RawMETAR: "KTST 011200Z 18008KT 10SM FEW050 20/10 A3000"},
+		{PressureHpa: 1015, VisibilitySM: 7, ObservedAt: at(1), RawMETAR: "KTST 011300Z 18010KT 7SM BKN040 19/12 A2997"},
+		{PressureHpa: 1014, VisibilitySM: 5, ObservedAt: at(2), RawMETAR: "KTST 011400Z 18012KT 5SM BR BKN025 18/14 A2994"},
+		{PressureHpa: 1013, VisibilitySM: 3, ObservedAt: at(3), RawMETAR: "KTST 011500Z 18014KT 3SM BR OVC012 17/15 A2991"},
+	}
+	clearing := []domain.Observation{
+		{PressureHpa: 1010, VisibilitySM: 4, ObservedAt: at(0)},
+		{PressureHpa: 1010.2, VisibilitySM: 6, ObservedAt: at(1)},
+		{PressureHpa: 1010.1, VisibilitySM: 9, ObservedAt: at(2)},
+	}
+
+	tests := []struct {
+		name      string
+		hours     int
+		setupMock func(*mocks.RepositoryMock)
+		check     func(*testing.T, *domain.WeatherTrend)
+		err       error
+	}{
+		{
+			name:  "closing in",
+			hours: 6,
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+				m.On("GetWeatherHistory", "TST", mock.Anything).Return(closingIn, nil)
+			},
+			check: func(t *testing.T, trend *domain.WeatherTrend) {
+				assert.Equal(t, 6, trend.Hours)
+				assert.Equal(t, 4, trend.Observations)
+				assert.Equal(t, -1.0, trend.PressureHpa.SlopePerHour)
+				assert.Equal(t, domain.TrendDeteriorating, trend.PressureHpa.Trend)
+				assert.Equal(t, -2.3, trend.VisibilitySM.SlopePerHour)
+				assert.Equal(t, domain.TrendDeteriorating, trend.VisibilitySM.Trend)
+				assert.Equal(t, 4, trend.CeilingFt.Samples)
+				assert.Equal(t, 12000.0, *trend.CeilingFt.First, "No ceiling counts as unlimited")
+				assert.Equal(t, 1200.0, *trend.CeilingFt.Last)
+				assert.Equal(t, domain.TrendDeteriorating, trend.CeilingFt.Trend)
+				assert.Equal(t, domain.TrendDeteriorating, trend.Overall)
+			},
+		},
+		{
+			name: "clearing without METARs",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+				m.On("GetWeatherHistory", "TST", mock.Anything).Return(clearing, nil)
+			},
+			check: func(t *testing.T, trend *domain.WeatherTrend) {
+				assert.Equal(t, DefaultTrendHours, trend.Hours)
+				assert.Equal(t, domain.TrendSteady, trend.PressureHpa.Trend)
+				assert.Equal(t, domain.TrendImproving, trend.VisibilitySM.Trend)
+				assert.Equal(t, domain.TrendUnknown, trend.CeilingFt.Trend)
+				assert.Equal(t, domain.TrendImproving, trend.Overall)
+			},
+		},
+		{
+			name: "no history",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+				m.On("GetWeatherHistory", "TST", mock.Anything).Return([]domain.Observation{}, nil)
+			},
+			err: fmt.Errorf("%w: no weather history for TST in the last 3 hours", domain.ErrNoData),
+		},
+		{
+			name: "not found",
+			setupMock: func(m *mocks.RepositoryMock) {
+				m.On("GetAirportByFAA", "TST").Return((*domain.Airport)(nil), nil)
+			},
+			err: fmt.Errorf("%w: TST", domain.ErrNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.RepositoryMock{}
+			tt.setupMock(mockRepo)
+			s := NewService(mockRepo, &config.Config{}).(*Service)
+
+			trend, err := s.GetWeatherTrend("TST", tt.hours)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+				tt.check(t, trend)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+)
+
+// watchlistOwner identifies the owner of watchlists by a hash of their API
+// key, so a leaked table doesn't leak keys.
+func keyOwner(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeFAAs upper-cases codes and drops repeats, keeping their order.
+func normalizeFAAs(codes []string) []string {
+	faas := make([]string, 0, len(codes))
+	for _, faa := range codes {
+		faa = strings.ToUpper(strings.TrimSpace(faa))
+		if !slices.Contains(faas, faa) {
+			faas = append(faas, faa)
+		}
+	}
+	return faas
+}
+
+// CreateWatchlist saves a watchlist for apiKey, with its codes upper-cased
+// and repeats dropped.
+func (s *Service) CreateWatchlist(apiKey string, wl *domain.Watchlist) error {
+	wl.Faas = normalizeFAAs(wl.Faas)
+	wl.Owner = keyOwner(apiKey)
+
+	if err := s.repo.CreateWatchlist(wl); err != nil {
+		return fmt.Errorf("failed to create watchlist: %w", err)
+	}
+	return nil
+}
+
+// DeleteWatchlist removes a watchlist of apiKey.
+func (s *Service) DeleteWatchlist(apiKey string, id int64) error {
+	if err := s.repo.DeleteWatchlist(id, keyOwner(apiKey)); err != nil {
+		return fmt.Errorf("failed to delete watchlist: %w", err)
+	}
+	return nil
+}
+
+// GetWatchlists returns the watchlists of apiKey, oldest first.
+func (s *Service) GetWatchlists(apiKey string) ([]domain.Watchlist, error) {
+	watchlists, err := s.repo.GetWatchlists(keyOwner(apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchlists: %w", err)
+	}
+	return watchlists, nil
+}
+
+// GetWatchlistBoard reports the stored conditions of every airport of a
+// watchlist of apiKey, in watchlist order.
+func (s *Service) GetWatchlistBoard(apiKey string, id int64) (*domain.WatchlistBoard, error) {
+	wl, err := s.repo.GetWatchlist(id, keyOwner(apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchlist: %w", err)
+	}
+
+	entries, err := s.boardEntries(wl.Faas)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.WatchlistBoard{ID: wl.ID, Name: wl.Name, Airports: entries}, nil
+}
+
+// boardEntries reports the stored conditions of each airport of faas, in
+// order.
+func (s *Service) boardEntries(faas []string) ([]domain.BoardEntry, error) {
+	airports, err := s.repo.GetAirportsByFAAs(faas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get airports: %w", err)
+	}
+	byFAA := make(map[string]domain.Airport, len(airports))
+	for _, a := range airports {
+		byFAA[a.Faa] = a
+	}
+
+	observations, err := s.repo.GetObservations(faas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weather: %w", err)
+	}
+
+	entries := make([]domain.BoardEntry, 0, len(faas))
+	for _, faa := range faas {
+		entry := domain.BoardEntry{Faa: faa}
+		if a, ok := byFAA[faa]; ok {
+			entry.FacilityName, entry.Weather, entry.LastSyncedAt = a.FacilityName, a.Weather, a.LastSyncedAt
+		}
+		if obs, ok := observations[faa]; ok {
+			entry.FlightCategory = aviation.ObservationCategory(obs)
+			entry.TemperatureC = &obs.TemperatureC
+			entry.WindDirDeg = &obs.WindDirDeg
+			entry.WindSpeedKt = &obs.WindSpeedKt
+			if obs.WindGustKt > 0 {
+				entry.WindGustKt = &obs.WindGustKt
+			}
+			entry.VisibilitySM = &obs.VisibilitySM
+			entry.ObservedAt = &obs.ObservedAt
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
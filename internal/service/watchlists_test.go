@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateWatchlist(t *testing.T) {
+	owner := keyOwner("key")
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("CreateWatchlist", mock.MatchedBy(func(wl *domain.Watchlist) bool {
+		return wl.Owner == owner && assert.ObjectsAreEqual([]string{"DEN", "APA"}, wl.Faas)
+	})).Return(nil)
+	mockRepo.On("GetWatchlists", owner).Return([]domain.Watchlist{}, nil)
+	mockRepo.On("DeleteWatchlist", int64(3), owner).Return(domain.ErrWatchlistNotFound)
+	s := NewService(mockRepo, &config.Config{})
+
+	assert.NoError(t, s.CreateWatchlist("key", &domain.Watchlist{Name: "Home base", Faas: []string{"den", " APA", "DEN"}}))
+	assert.Len(t, owner, 64)
+	assert.NotContains(t, owner, "key", "The API key is not stored")
+
+	watchlists, err := s.GetWatchlists("key")
+	assert.NoError(t, err)
+	assert.Empty(t, watchlists)
+	assert.ErrorIs(t, s.DeleteWatchlist("key", 3), domain.ErrWatchlistNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetWatchlistBoard(t *testing.T) {
+	syncedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	owner := keyOwner("key")
+	den := domain.Airport{Faa: "DEN", FacilityName: "Denver Intl", Weather: "Snow", LastSyncedAt: &syncedAt}
+	apa := domain.Airport{Faa: "APA", FacilityName: "Centennial", Weather: "Clear"}
+	obs := domain.Observation{Condition: "Snow", TemperatureC: -3, WindDirDeg: 20, WindSpeedKt: 12, WindGustKt: 22, VisibilitySM: 2, ObservedAt: syncedAt}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetWatchlist", int64(3), owner).Return(&domain.Watchlist{ID: 3, Name: "Front range", Faas: []string{"DEN", "APA", "XXX"}}, nil)
+	mockRepo.On("GetAirportsByFAAs", []string{"DEN", "APA", "XXX"}).Return([]domain.Airport{apa, den}, nil)
+	mockRepo.On("GetObservations", []string{"DEN", "APA", "XXX"}).Return(map[string]domain.Observation{"DEN": obs}, nil)
+	mockRepo.On("GetWatchlist", int64(4), owner).Return((*domain.Watchlist)(nil), domain.ErrWatchlistNotFound)
+	s := NewService(mockRepo, &config.Config{})
+
+	board, err := s.GetWatchlistBoard("key", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.WatchlistBoard{ID: 3, Name: "Front range", Airports: []domain.BoardEntry{
+		{
+			Faa:            "DEN",
+			FacilityName:   "Denver Intl",
+			Weather:        "Snow",
+			FlightCategory: "IFR",
+			TemperatureC:   &obs.TemperatureC,
+			WindDirDeg:     &obs.WindDirDeg,
+			WindSpeedKt:    &obs.WindSpeedKt,
+			WindGustKt:     &obs.WindGustKt,
+			VisibilitySM:   &obs.VisibilitySM,
+			ObservedAt:     &syncedAt,
+			LastSyncedAt:   &syncedAt,
+		},
+		{Faa: "APA", FacilityName: "Centennial", Weather: "Clear"},
+		{Faa: "XXX"},
+	}}, board, "Airports keep the watchlist order")
+
+	_, err = s.GetWatchlistBoard("key", 4)
+	assert.ErrorIs(t, err, domain.ErrWatchlistNotFound)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"aviation-weather/internal/aviation"
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/weather"
+)
+
+// GetAirportWeather reports the stored weather of an airport. With refresh it
+// first asks the providers for the current weather, bypassing the weather
+// cache, and stores it like a sync would, without refreshing the rest of the
+// airport.
+func (s *Service) GetAirportWeather(ctx context.Context, faa string, refresh bool) (*domain.AirportWeather, error) {
+	airport, err := s.GetAirportByFAA(faa)
+	if err != nil {
+		return nil, err
+	}
+
+	var obs *domain.Observation
+	if refresh {
+		if obs, err = s.refreshWeather(ctx, airport); err != nil {
+			return nil, err
+		}
+	} else if obs, err = s.repo.GetObservation(faa); err != nil {
+		return nil, fmt.Errorf("failed to get weather for %s: %w", faa, err)
+	}
+
+	weather := &domain.AirportWeather{
+		Faa:          airport.Faa,
+		Condition:    airport.Weather,
+		Observation:  obs,
+		LastSyncedAt: airport.LastSyncedAt,
+	}
+	if obs != nil {
+		weather.FlightCategory = aviation.ObservationCategory(*obs)
+	}
+	return weather, nil
+}
+
+// refreshWeather fetches and stores the current weather of airport, updating
+// it in place. The weather is applied to the airport as stored, like a sync.
+func (s *Service) refreshWeather(ctx context.Context, airport *domain.Airport) (*domain.Observation, error) {
+	defer s.airportLocks.Lock(airport.Faa)()
+
+	obs, err := s.FetchWeather(ctx, weatherLocation(airport))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch weather for %s: %w", domain.ErrExternalAPI, airport.Faa, err)
+	}
+
+	err = s.modifyAirport(airport.Faa, func(stored *domain.Airport) []airportEvent {
+		previous := stored.Weather
+		stored.Weather = obs.Condition
+		applyAltitudes(stored, obs)
+		now := time.Now().UTC()
+		stored.LastSyncedAt = &now
+		*airport = *stored
+		return syncEvents(stored, previous, obs)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update airport %s: %w", airport.Faa, err)
+	}
+	s.invalidateAirports(airport.Faa)
+	s.recordObservation(airport, obs)
+	return &obs, nil
+}
+
+// GetLiveWeather reports the current weather at a city or point that need not
+// be an airport. It goes through the weather cache but stores nothing.
+func (s *Service) GetLiveWeather(ctx context.Context, loc weather.Location) (*domain.Observation, error) {
+	obs, err := s.cachedWeather(ctx, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch weather for %s: %w", domain.ErrExternalAPI, loc, err)
+	}
+	return &obs, nil
+}
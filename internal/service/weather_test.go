@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+	"aviation-weather/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAirportWeather(t *testing.T) {
+	syncedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	airport := sampleAirport
+	airport.LastSyncedAt = &syncedAt
+	stored := domain.Observation{Condition: "Clear", VisibilitySM: 10, ObservedAt: syncedAt}
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("GetObservation", "TST").Return(&stored, nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		t.Fatal("Stored weather should not be fetched")
+		return domain.Observation{}, nil
+	}
+
+	got, err := s.GetAirportWeather(context.Background(), "TST", false)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.AirportWeather{
+		Faa:            "TST",
+		Condition:      "Clear",
+		FlightCategory: "VFR",
+		Observation:    &stored,
+		LastSyncedAt:   &syncedAt,
+	}, got)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAirportWeatherRefresh(t *testing.T) {
+	observedAt := time.Date(2024, 6, 1, 13, 0, 0, 0, time.UTC)
+	airport := sampleAirport
+	stored := sampleAirport
+
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&stored, nil)
+	mockRepo.On("SaveObservation", "TST", mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Fog", VisibilitySM: 0.5, ObservedAt: observedAt}, nil
+	}
+
+	got, err := s.GetAirportWeather(context.Background(), "TST", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "Fog", got.Condition)
+	assert.Equal(t, "LIFR", got.FlightCategory)
+	assert.Equal(t, observedAt, got.Observation.ObservedAt)
+	assert.NotNil(t, got.LastSyncedAt)
+	assert.Equal(t, "Fog", stored.Weather)
+	assert.Equal(t, got.LastSyncedAt, stored.LastSyncedAt)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "GetObservation", "TST")
+}
+
+func TestGetAirportWeatherRefreshFails(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&sampleAirport, nil)
+	s := NewService(mockRepo, &config.Config{}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{}, errors.New("timeout")
+	}
+
+	_, err := s.GetAirportWeather(context.Background(), "TST", true)
+	assert.ErrorIs(t, err, domain.ErrExternalAPI)
+	mockRepo.AssertNotCalled(t, "ModifyAirport", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetLiveWeather(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	s := NewService(mockRepo, &config.Config{WeatherCacheTTL: time.Minute}).(*Service)
+
+	var fetched []weather.Location
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		fetched = append(fetched, loc)
+		if loc.City == "Nowhere" {
+			return domain.Observation{}, errors.New("no matching location")
+		}
+		return domain.Observation{Condition: "Sunny"}, nil
+	}
+
+	point := weather.Location{Latitude: 39.7392, Longitude: -104.9903, HasCoords: true}
+	for range 2 {
+		obs, err := s.GetLiveWeather(context.Background(), point)
+		assert.NoError(t, err)
+		assert.Equal(t, "Sunny", obs.Condition)
+	}
+	_, err := s.GetLiveWeather(context.Background(), weather.Location{City: "Denver"})
+	assert.NoError(t, err)
+	assert.Equal(t, []weather.Location{point, {City: "Denver"}}, fetched, "Repeated points are served from the cache")
+
+	_, err = s.GetLiveWeather(context.Background(), weather.Location{City: "Nowhere"})
+	assert.ErrorIs(t, err, domain.ErrExternalAPI)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"fmt"
+
+	"aviation-weather/internal/domain"
+)
+
+// CreateWebhook subscribes a URL to the events it lists.
+func (s *Service) CreateWebhook(wh *domain.Webhook) error {
+	if err := s.repo.CreateWebhook(wh); err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// DeleteWebhook unsubscribes a webhook of a tenant and drops its delivery log.
+func (s *Service) DeleteWebhook(tenantID, id int64) error {
+	if err := s.repo.DeleteWebhook(tenantID, id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// GetWebhooks returns every webhook of a tenant with its secret left out.
+func (s *Service) GetWebhooks(tenantID int64) ([]domain.Webhook, error) {
+	webhooks, err := s.repo.GetWebhooks(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+// GetWebhookDeliveries returns the latest deliveries of one webhook of a
+// tenant, newest first.
+func (s *Service) GetWebhookDeliveries(tenantID, id int64, limit int) ([]domain.WebhookDelivery, error) {
+	deliveries, err := s.repo.GetWebhookDeliveries(tenantID, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
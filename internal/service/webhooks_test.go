@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	mocks "aviation-weather/internal/mock"
+	"aviation-weather/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSyncNotifiesWebhooks(t *testing.T) {
+	airport := sampleAirport // Stored weather is "Clear"
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&airport, nil)
+	mockRepo.On("SaveObservation", "TST", mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", mock.Anything, mock.Anything).Return([]domain.AlertRule{}, nil)
+	mockRepo.On("GetWebhooksForEvent", domain.AllTenants, domain.EventWeatherChanged).Return([]domain.Webhook{}, nil).Once()
+	mockRepo.On("GetWebhooksForEvent", domain.AllTenants, domain.EventSyncCompleted).Return([]domain.Webhook{}, nil).Once()
+	s := NewService(mockRepo, &config.Config{WebhookMaxAttempts: 1}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Rain", ObservedAt: time.Now()}, nil
+	}
+
+	_, err := s.SyncAirportByFAA(context.Background(), "TST")
+	assert.NoError(t, err)
+	s.webhooks.Wait()
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAlertsNotifyTheirTenantsWebhooks(t *testing.T) {
+	airport := sampleAirport
+	airport.Weather = "Rain"
+	rules := []domain.AlertRule{{ID: 1, Name: "Rain", Metric: domain.MetricVisibility, Operator: domain.OpLessThan, Threshold: 3, TenantID: 2}}
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetAirportByFAA", "TST").Return(&airport, nil)
+	mockRepo.On("ModifyAirport", "TST").Return(&airport, nil)
+	mockRepo.On("SaveObservation", "TST", mock.Anything).Return(nil)
+	mockRepo.On("GetAlertRulesFor", "TST", "CA").Return(rules, nil)
+	mockRepo.On("SaveAlerts", mock.MatchedBy(func(alerts []domain.Alert) bool {
+		return len(alerts) == 1 && alerts[0].TenantID == 2
+	})).Return(nil)
+	mockRepo.On("GetWebhooksForEvent", int64(2), domain.EventAlertFired).Return([]domain.Webhook{}, nil).Once()
+	mockRepo.On("GetWebhooksForEvent", domain.AllTenants, domain.EventSyncCompleted).Return([]domain.Webhook{}, nil).Once()
+	s := NewService(mockRepo, &config.Config{WebhookMaxAttempts: 1}).(*Service)
+	s.FetchWeather = func(ctx context.Context, loc weather.Location) (domain.Observation, error) {
+		return domain.Observation{Condition: "Rain", VisibilitySM: 2, ObservedAt: time.Now()}, nil
+	}
+
+	_, err := s.SyncAirportByFAA(context.Background(), "TST")
+	assert.NoError(t, err)
+	s.webhooks.Wait()
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetWebhooksHidesSecrets(t *testing.T) {
+	mockRepo := &mocks.RepositoryMock{}
+	mockRepo.On("GetWebhooks", int64(2)).Return([]domain.Webhook{{ID: 1, URL: "https://example.com/hook", Secret: "s3cret", Events: []string{domain.EventAlertFired}}}, nil)
+	s := NewService(mockRepo, &config.Config{})
+
+	webhooks, err := s.GetWebhooks(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Webhook{{ID: 1, URL: "https://example.com/hook", Events: []string{domain.EventAlertFired}}}, webhooks)
+	mockRepo.AssertExpectations(t)
+}
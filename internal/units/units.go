@@ -0,0 +1,172 @@
+// Package units converts weather values from the units the API stores them in
+// (°C, knots, statute miles and hPa) into those of a unit system.
+package units
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+)
+
+// System is a set of units to report weather in.
+type System string
+
+const (
+	// Metric reports °C, m/s, km and hPa.
+	Metric System = "metric"
+	// Imperial reports °F, mph, statute miles and inHg.
+	Imperial System = "imperial"
+	// Aviation reports °C, knots, statute miles and inHg, as US METARs do.
+	Aviation System = "aviation"
+)
+
+// Parse returns the system named s, or false when there is none.
+func Parse(s string) (System, bool) {
+	switch system := System(strings.ToLower(strings.TrimSpace(s))); system {
+	case Metric, Imperial, Aviation:
+		return system, true
+	}
+	return "", false
+}
+
+const (
+	hpaPerInHg = 33.8639
+	kmPerSM    = 1.609344
+	mpsPerKt   = 0.514444
+	mphPerKt   = 1.150779
+)
+
+// Conversions between single units, unrounded.
+func CelsiusToFahrenheit(c float64) float64 { return c*9/5 + 32 }
+func KnotsToMPS(kt float64) float64         { return kt * mpsPerKt }
+func KnotsToMPH(kt float64) float64         { return kt * mphPerKt }
+func MilesToKilometres(sm float64) float64  { return sm * kmPerSM }
+func HpaToInHg(hpa float64) float64         { return hpa / hpaPerInHg }
+func InHgToHpa(inHg float64) float64        { return inHg * hpaPerInHg }
+
+// Temperature converts a temperature in °C, returning the suffix of its unit.
+// A difference, such as a deviation from ISA, takes no offset.
+func (s System) Temperature(c float64, difference bool) (float64, string) {
+	if s != Imperial {
+		return c, "c"
+	}
+	if difference {
+		return round(c*9/5, 1), "f"
+	}
+	return round(CelsiusToFahrenheit(c), 1), "f"
+}
+
+// Speed converts a speed in knots, returning the suffix of its unit.
+func (s System) Speed(kt float64) (float64, string) {
+	switch s {
+	case Metric:
+		return round(KnotsToMPS(kt), 1), "mps"
+	case Imperial:
+		return round(KnotsToMPH(kt), 1), "mph"
+	}
+	return kt, "kt"
+}
+
+// Distance converts a visibility in statute miles, returning the suffix of its unit.
+func (s System) Distance(sm float64) (float64, string) {
+	if s == Metric {
+		return round(MilesToKilometres(sm), 2), "km"
+	}
+	return sm, "sm"
+}
+
+// Pressure converts a pressure in hPa, returning the suffix of its unit.
+func (s System) Pressure(hpa float64) (float64, string) {
+	if s == Metric {
+		return round(hpa, 1), "hpa"
+	}
+	return round(HpaToInHg(hpa), 2), "inhg"
+}
+
+// temperatureDifferences are the temperature fields that hold a difference.
+var temperatureDifferences = map[string]bool{"isa_deviation": true}
+
+// Convert returns the JSON form of v with every field named for a stored unit,
+// such as temperature_c, wind_speed_kt, visibility_sm, pressure_hpa or
+// altimeter_inhg, converted into s and renamed for its new unit. Where two
+// fields end up with the same name, as altimeter_hpa and altimeter_inhg do,
+// they hold the same value.
+func (s System) Convert(v any) (any, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var tree any
+	if err := json.Unmarshal(encoded, &tree); err != nil {
+		return nil, err
+	}
+	return s.convert(tree), nil
+}
+
+func (s System) convert(v any) any {
+	switch v := v.(type) {
+	case []any:
+		for i := range v {
+			v[i] = s.convert(v[i])
+		}
+		return v
+	case map[string]any:
+		converted := make(map[string]any, len(v))
+		for key, value := range v {
+			name, convertedValue := s.field(key, s.convert(value))
+			if _, ok := converted[name]; ok && name != key {
+				continue // The field already in that unit wins
+			}
+			converted[name] = convertedValue
+		}
+		return converted
+	default:
+		return v
+	}
+}
+
+// field converts one field by the unit its name ends in. Nulls are renamed
+// but stay null.
+func (s System) field(key string, value any) (string, any) {
+	base, unit, ok := cutUnit(key)
+	if !ok {
+		return key, value
+	}
+	number, isNumber := value.(float64)
+
+	var suffix string
+	switch unit {
+	case "c":
+		number, suffix = s.Temperature(number, temperatureDifferences[base])
+	case "kt":
+		number, suffix = s.Speed(number)
+	case "sm":
+		number, suffix = s.Distance(number)
+	case "hpa":
+		number, suffix = s.Pressure(number)
+	case "inhg":
+		number, suffix = s.Pressure(InHgToHpa(number))
+	}
+	if !isNumber {
+		return base + "_" + suffix, value
+	}
+	return base + "_" + suffix, number
+}
+
+// cutUnit splits a field name such as wind_speed_kt into its base and stored unit.
+func cutUnit(key string) (base, unit string, ok bool) {
+	i := strings.LastIndexByte(key, '_')
+	if i <= 0 {
+		return "", "", false
+	}
+	switch unit = key[i+1:]; unit {
+	case "c", "kt", "sm", "hpa", "inhg":
+		return key[:i], unit, true
+	}
+	return "", "", false
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,68 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	system, ok := Parse(" Metric ")
+	assert.True(t, ok)
+	assert.Equal(t, Metric, system)
+
+	_, ok = Parse("nautical")
+	assert.False(t, ok)
+}
+
+func TestConvert(t *testing.T) {
+	type observation struct {
+		TemperatureC  float64  `json:"temperature_c"`
+		ISADeviationC float64  `json:"isa_deviation_c"`
+		WindSpeedKt   float64  `json:"wind_speed_kt"`
+		VisibilitySM  float64  `json:"visibility_sm"`
+		PressureHpa   float64  `json:"pressure_hpa"`
+		AltimeterInHg *float64 `json:"altimeter_inhg"`
+		AltimeterHpa  *float64 `json:"altimeter_hpa"`
+		ElevationFt   float64  `json:"elevation_ft"`
+	}
+	inHg, hpa := 29.92, 1013.2
+	obs := []observation{{
+		TemperatureC:  20,
+		ISADeviationC: 10,
+		WindSpeedKt:   10,
+		VisibilitySM:  10,
+		PressureHpa:   1013.25,
+		AltimeterInHg: &inHg,
+		AltimeterHpa:  &hpa,
+		ElevationFt:   5434,
+	}}
+
+	converted, err := Imperial.Convert(obs)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{
+		"temperature_f":   68.0,
+		"isa_deviation_f": 18.0,
+		"wind_speed_mph":  11.5,
+		"visibility_sm":   10.0,
+		"pressure_inhg":   29.92,
+		"altimeter_inhg":  29.92,
+		"elevation_ft":    5434.0,
+	}}, converted)
+
+	converted, err = Metric.Convert(obs)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{
+		"temperature_c":   20.0,
+		"isa_deviation_c": 10.0,
+		"wind_speed_mps":  5.1,
+		"visibility_km":   16.09,
+		"pressure_hpa":    1013.3,
+		"altimeter_hpa":   1013.2,
+		"elevation_ft":    5434.0,
+	}}, converted, "The field already in hPa wins over the converted one")
+
+	converted, err = Aviation.Convert(map[string]any{"temperature_c": nil, "speed_kt": 12.0})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"temperature_c": nil, "speed_kt": 12.0}, converted)
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := NewCircuitBreaker(2, 20*time.Millisecond)
+	failure := errors.New("provider down")
+
+	// Opens after two consecutive failures
+	assert.NoError(t, b.Allow())
+	b.Record(failure)
+	assert.Equal(t, BreakerClosed, b.State())
+	assert.NoError(t, b.Allow())
+	b.Record(failure)
+	assert.Equal(t, BreakerOpen, b.State())
+	assert.Equal(t, int64(1), b.Trips())
+
+	// Rejects calls during cooldown
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	// After cooldown a single trial call is let through
+	time.Sleep(25 * time.Millisecond)
+	assert.NoError(t, b.Allow())
+	assert.Equal(t, BreakerHalfOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen, "Only one trial call should be allowed")
+
+	// A failed trial reopens it
+	b.Record(failure)
+	assert.Equal(t, BreakerOpen, b.State())
+	assert.Equal(t, int64(2), b.Trips())
+
+	// A successful trial closes it
+	time.Sleep(25 * time.Millisecond)
+	assert.NoError(t, b.Allow())
+	b.Record(nil)
+	assert.Equal(t, BreakerClosed, b.State())
+	assert.Equal(t, 0, b.Failures())
+}
@@ -0,0 +1,54 @@
+package utils
+
+import "sync"
+
+// Hub fans values out to every current subscriber. A subscriber that falls
+// behind by more than its buffer misses values rather than blocking Publish.
+type Hub[T any] struct {
+	mu     sync.Mutex
+	subs   map[chan T]struct{}
+	buffer int
+}
+
+// NewHub returns a hub whose subscriptions buffer up to buffer values.
+func NewHub[T any](buffer int) *Hub[T] {
+	return &Hub[T]{subs: make(map[chan T]struct{}), buffer: buffer}
+}
+
+// Subscribe returns a channel receiving every value published from now on,
+// and a function ending the subscription and closing the channel.
+func (h *Hub[T]) Subscribe() (<-chan T, func()) {
+	ch := make(chan T, h.buffer)
+	h.mu.Lock()
+	h.subs[ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subs, ch)
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish hands v to every subscriber with room for it.
+func (h *Hub[T]) Publish(v T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		select {
+		case ch <- v:
+		default:
+		}
+	}
+}
+
+// Subscribers returns the number of current subscriptions.
+func (h *Hub[T]) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
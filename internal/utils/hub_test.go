@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub(t *testing.T) {
+	h := NewHub[int](1)
+	a, unsubscribeA := h.Subscribe()
+	b, unsubscribeB := h.Subscribe()
+	assert.Equal(t, 2, h.Subscribers())
+
+	h.Publish(1)
+	h.Publish(2) // Buffers are full, dropped
+	assert.Equal(t, 1, <-a)
+	assert.Equal(t, 1, <-b)
+
+	unsubscribeA()
+	unsubscribeA()
+	_, open := <-a
+	assert.False(t, open, "Unsubscribing closes the channel")
+	assert.Equal(t, 1, h.Subscribers())
+
+	h.Publish(3)
+	assert.Equal(t, 3, <-b)
+	unsubscribeB()
+	assert.Equal(t, 0, h.Subscribers())
+}
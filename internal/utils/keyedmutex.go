@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"slices"
+	"sync"
+)
+
+type keyedLock struct {
+	sync.Mutex
+	// Holders and waiters of the lock; it is dropped at 0
+	refs int
+}
+
+// KeyedMutex is a set of mutexes by key, such as one per airport, kept only
+// while held or waited on. The zero value is ready to use.
+type KeyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+// Lock locks key, waiting until no one else holds it, and returns the func
+// unlocking it.
+func (k *KeyedMutex) Lock(key string) (unlock func()) {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*keyedLock)
+	}
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		k.locks[key] = l
+	}
+	l.refs++
+	k.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		k.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
+// LockAll locks every key, in sorted order so that two callers locking
+// overlapping keys can't deadlock, and returns the func unlocking them all.
+func (k *KeyedMutex) LockAll(keys ...string) (unlock func()) {
+	keys = slices.Compact(slices.Sorted(slices.Values(keys)))
+	unlocks := make([]func(), 0, len(keys))
+	for _, key := range keys {
+		unlocks = append(unlocks, k.Lock(key))
+	}
+	return func() {
+		for _, unlock := range unlocks {
+			unlock()
+		}
+	}
+}
+
+// Held counts the keys locked or waited on.
+func (k *KeyedMutex) Held() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.locks)
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	var k KeyedMutex
+	unlock := k.Lock("JFK")
+
+	// Another key isn't held up
+	done := make(chan struct{})
+	go func() {
+		k.Lock("LAX")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Lock of another key should not wait")
+	}
+
+	// The same key waits for the unlock
+	var mu sync.Mutex
+	var order []string
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		defer k.Lock("JFK")()
+		mu.Lock()
+		order = append(order, "second")
+		mu.Unlock()
+	}()
+	time.Sleep(20 * time.Millisecond) // Let the second holder wait
+	mu.Lock()
+	order = append(order, "first")
+	mu.Unlock()
+	unlock()
+	<-finished
+
+	assert.Equal(t, []string{"first", "second"}, order)
+	assert.Zero(t, k.Held(), "Unused keys should be dropped")
+}
+
+func TestKeyedMutexLockAll(t *testing.T) {
+	var k KeyedMutex
+	unlock := k.LockAll("LAX", "JFK", "LAX")
+	assert.Equal(t, 2, k.Held(), "Repeated keys are locked once")
+
+	// Overlapping sets locked in either order don't deadlock
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		k.LockAll("SFO", "JFK")()
+	}()
+	time.Sleep(20 * time.Millisecond)
+	unlock()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("LockAll should get the keys once unlocked")
+	}
+	assert.Zero(t, k.Held())
+}
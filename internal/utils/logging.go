@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// Log levels, going by the "WARN:" style prefix of log lines.
+const (
+	LogDebug int32 = iota
+	LogInfo
+	LogWarn
+	LogError
+)
+
+var logLevels = map[string]int32{"debug": LogDebug, "info": LogInfo, "warn": LogWarn, "error": LogError}
+
+// LevelWriter drops log lines below its level before writing to out. Lines
+// without a level prefix count as info.
+type LevelWriter struct {
+	out   io.Writer
+	level atomic.Int32
+}
+
+func NewLevelWriter(out io.Writer, level string) *LevelWriter {
+	w := &LevelWriter{out: out}
+	w.SetLevel(level)
+	return w
+}
+
+// SetLevel changes the lowest level written; unknown names mean info.
+func (w *LevelWriter) SetLevel(level string) {
+	l, ok := logLevels[level]
+	if !ok {
+		l = LogInfo
+	}
+	w.level.Store(l)
+}
+
+func (w *LevelWriter) Write(p []byte) (int, error) {
+	if lineLevel(p) < w.level.Load() {
+		return len(p), nil
+	}
+	return w.out.Write(p)
+}
+
+// lineLevel finds the level prefix of a line, which comes after the date and
+// time of the standard log flags.
+func lineLevel(line []byte) int32 {
+	fields := bytes.SplitN(line, []byte(" "), 4)
+	for _, field := range fields[:min(len(fields), 3)] {
+		if prefix, ok := bytes.CutSuffix(field, []byte(":")); ok {
+			if l, ok := logLevels[string(bytes.ToLower(prefix))]; ok {
+				return l
+			}
+		}
+	}
+	return LogInfo
+}
+
+var (
+	stdLogs     *LevelWriter
+	stdLogsOnce sync.Once
+)
+
+// SetLogLevel filters the standard logger at level, and can be called again
+// to change it.
+func SetLogLevel(level string) {
+	stdLogsOnce.Do(func() {
+		stdLogs = NewLevelWriter(os.Stderr, level)
+		log.SetOutput(stdLogs)
+	})
+	stdLogs.SetLevel(level)
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := NewLevelWriter(&out, "warn")
+	logger := log.New(w, "", log.LstdFlags)
+
+	logger.Printf("Scheduler started")
+	logger.Printf("INFO: Weather cached")
+	logger.Printf("WARN: Provider slow")
+	logger.Printf("ERROR: Sync failed")
+	assert.NotContains(t, out.String(), "Scheduler started", "Unprefixed lines are info")
+	assert.NotContains(t, out.String(), "Weather cached")
+	assert.Contains(t, out.String(), "WARN: Provider slow")
+	assert.Contains(t, out.String(), "ERROR: Sync failed")
+
+	out.Reset()
+	w.SetLevel("debug")
+	logger.Printf("DEBUG: Cache miss")
+	logger.Printf("Scheduler started")
+	assert.Contains(t, out.String(), "DEBUG: Cache miss")
+	assert.Contains(t, out.String(), "Scheduler started")
+}
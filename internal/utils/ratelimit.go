@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket refilled at a fixed rate up to its burst size.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Reserve takes a token if one is available. Otherwise it reports how long
+// the caller has to wait before the next token is refilled.
+func (l *RateLimiter) Reserve() (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return true, 0
+	}
+
+	if l.rate <= 0 {
+		return false, time.Duration(math.MaxInt64)
+	}
+	wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// Allow reports whether a token was available and consumes it.
+func (l *RateLimiter) Allow() bool {
+	ok, _ := l.Reserve()
+	return ok
+}
+
+// Wait blocks until a token is available and consumes it.
+func (l *RateLimiter) Wait() {
+	for {
+		ok, wait := l.Reserve()
+		if ok {
+			return
+		}
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter(1, 2)
+
+	// Burst is available immediately
+	assert.True(t, l.Allow(), "First request should be allowed")
+	assert.True(t, l.Allow(), "Second request should be allowed within burst")
+
+	// Bucket is empty, caller must wait roughly one second
+	ok, wait := l.Reserve()
+	assert.False(t, ok, "Third request should be limited")
+	assert.InDelta(t, time.Second, wait, float64(50*time.Millisecond), "Wait should be about one token interval")
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	l := NewRateLimiter(100, 1)
+
+	assert.True(t, l.Allow())
+	assert.False(t, l.Allow())
+
+	time.Sleep(20 * time.Millisecond) // 100 tokens/s refills one token in 10ms
+	assert.True(t, l.Allow(), "Token should be refilled after waiting")
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(50, 1)
+	l.Wait()
+
+	start := time.Now()
+	l.Wait() // 50 tokens/s refills one token in 20ms
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond, "Wait should block until refill")
+}
@@ -0,0 +1,52 @@
+package utils
+
+import "sync"
+
+type call[T any] struct {
+	wg  sync.WaitGroup
+	val T
+	err error
+}
+
+// SingleFlight collapses concurrent calls with the same key into one
+// execution whose result is shared by every caller.
+type SingleFlight[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[T]
+}
+
+// InFlight counts the keys with a call running.
+func (g *SingleFlight[T]) InFlight() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.calls)
+}
+
+// Do runs fn unless a call for key is already in flight, in which case it
+// waits for that call instead. shared reports whether the result was reused.
+func (g *SingleFlight[T]) Do(key string, fn func() (T, error)) (val T, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call[T])
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err, true
+	}
+
+	c := &call[T]{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.val, c.err = fn()
+	return c.val, c.err, false
+}
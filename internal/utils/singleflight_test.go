@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingleFlight(t *testing.T) {
+	var g SingleFlight[string]
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]string, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _, _ = g.Do("JFK", func() (string, error) {
+				calls.Add(1)
+				<-release
+				return "synced", nil
+			})
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond) // Let every caller join the flight
+	assert.Equal(t, 1, g.InFlight(), "Shared calls count once")
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load(), "Concurrent calls should share one execution")
+	assert.Zero(t, g.InFlight())
+	for _, r := range results {
+		assert.Equal(t, "synced", r)
+	}
+
+	// A later call runs again
+	g.Do("JFK", func() (string, error) { calls.Add(1); return "", nil })
+	assert.Equal(t, int32(2), calls.Load())
+}
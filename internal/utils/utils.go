@@ -2,11 +2,20 @@ package utils
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"log"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"aviation-weather/internal/domain"
 )
 
+// ContentTypeXML, once set on the response by content negotiation, makes the
+// encoders answer in XML instead of JSON.
+const ContentTypeXML = "application/xml"
+
 func EncodeResponseToUser(w http.ResponseWriter, status string, message string, data any, code ...int) {
 	// Default = 200
 	httpCode := http.StatusOK
@@ -14,13 +23,97 @@ func EncodeResponseToUser(w http.ResponseWriter, status string, message string,
 		httpCode = code[0]
 	}
 
-	w.WriteHeader(httpCode)
-
-	w.Header().Set("Content-Type", "application/json")
 	resp := domain.ApiResponse{
 		Status:  status,
 		Message: message,
 		Data:    data,
 	}
+	writeResponse(w, httpCode, resp)
+}
+
+// EncodeErrorToUser writes an error response carrying a machine-readable error code.
+func EncodeErrorToUser(w http.ResponseWriter, message string, errorCode string, data any, code int) {
+	resp := domain.ApiResponse{
+		Status:    "Error",
+		Message:   message,
+		ErrorCode: errorCode,
+		Data:      data,
+	}
+	writeResponse(w, code, resp)
+}
+
+// writeResponse encodes resp as XML when negotiated, else as JSON. Data that
+// has no XML form falls back to JSON.
+func writeResponse(w http.ResponseWriter, code int, resp domain.ApiResponse) {
+	if w.Header().Get("Content-Type") == ContentTypeXML {
+		body, err := xml.Marshal(xmlResponse{
+			Status:    resp.Status,
+			Message:   resp.Message,
+			ErrorCode: resp.ErrorCode,
+			Data:      xmlData{resp.Data},
+		})
+		if err == nil {
+			w.WriteHeader(code)
+			w.Write([]byte(xml.Header))
+			w.Write(body)
+			return
+		}
+		log.Printf("WARN: Answering in JSON, no XML form: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(resp)
 }
+
+// xmlResponse is domain.ApiResponse in XML.
+type xmlResponse struct {
+	XMLName   xml.Name `xml:"response"`
+	Status    string   `xml:"status"`
+	Message   string   `xml:"message"`
+	ErrorCode string   `xml:"error_code,omitempty"`
+	Data      xmlData  `xml:"data"`
+}
+
+// xmlData wraps the payload in a data element, so a list comes out as one
+// element per entry, such as <data><airport>...</airport></data>.
+type xmlData struct {
+	value any
+}
+
+func (d xmlData) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if d.value != nil {
+		if err := e.Encode(d.value); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// PrefersXML tells whether the Accept header of r ranks XML above JSON. Ties,
+// and requests without an Accept header, go to JSON.
+func PrefersXML(r *http.Request) bool {
+	var jsonQ, xmlQ float64
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case "application/xml", "text/xml":
+			xmlQ = max(xmlQ, q)
+		case "application/json", "application/*", "*/*":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return xmlQ > jsonQ
+}
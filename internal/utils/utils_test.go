@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/xml"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -76,3 +77,86 @@ func TestEncodeResponseToUser(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeErrorToUser(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	EncodeErrorToUser(rec, "Airport Not Found", "not_found", nil, http.StatusNotFound)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code, "HTTP status code should match")
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"), "Header should be JSON")
+	assert.JSONEq(t, `{"status":"Error","message":"Airport Not Found","error_code":"not_found","data":null}`, rec.Body.String(), "JSON body should match")
+}
+
+func TestEncodeResponseToUserXML(t *testing.T) {
+	type entry struct {
+		XMLName xml.Name `xml:"entry"`
+		Name    string   `xml:"name"`
+	}
+
+	tests := []struct {
+		name        string
+		data        any
+		expectedXML string
+	}{
+		{
+			name:        "single value",
+			data:        entry{Name: "a"},
+			expectedXML: `<response><status>OK</status><message>Fetched</message><data><entry><name>a</name></entry></data></response>`,
+		},
+		{
+			name:        "list",
+			data:        []entry{{Name: "a"}, {Name: "b"}},
+			expectedXML: `<response><status>OK</status><message>Fetched</message><data><entry><name>a</name></entry><entry><name>b</name></entry></data></response>`,
+		},
+		{
+			name:        "no data",
+			data:        nil,
+			expectedXML: `<response><status>OK</status><message>Fetched</message><data></data></response>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			rec.Header().Set("Content-Type", ContentTypeXML)
+
+			EncodeResponseToUser(rec, "OK", "Fetched", tt.data)
+
+			assert.Equal(t, http.StatusOK, rec.Code, "HTTP status code should match")
+			assert.Equal(t, ContentTypeXML, rec.Header().Get("Content-Type"), "Header should be XML")
+			assert.Equal(t, xml.Header+tt.expectedXML, rec.Body.String(), "XML body should match")
+		})
+	}
+
+	// Maps have no XML form
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", ContentTypeXML)
+	EncodeResponseToUser(rec, "OK", "Fetched", map[string]string{"a": "b"})
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"), "Header should fall back to JSON")
+	assert.JSONEq(t, `{"status":"OK","message":"Fetched","data":{"a":"b"}}`, rec.Body.String(), "JSON body should match")
+}
+
+func TestPrefersXML(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected bool
+	}{
+		{accept: "", expected: false},
+		{accept: "application/json", expected: false},
+		{accept: "application/xml", expected: true},
+		{accept: "text/xml", expected: true},
+		{accept: "application/xml, application/json", expected: false},
+		{accept: "application/json;q=0.5, application/xml", expected: true},
+		{accept: "application/xml;q=0.9, */*;q=0.8", expected: true},
+		{accept: "*/*", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/airports", nil)
+			req.Header.Set("Accept", tt.accept)
+			assert.Equal(t, tt.expected, PrefersXML(req))
+		})
+	}
+}
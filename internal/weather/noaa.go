@@ -0,0 +1,142 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// NOAA fetches the latest METAR from the Aviation Weather Center. It needs no
+// key but only knows airports by ICAO code.
+type NOAA struct {
+	BaseURL string
+	client  *http.Client
+}
+
+func NewNOAA(client *http.Client) *NOAA {
+	return &NOAA{BaseURL: "https://aviationweather.gov", client: client}
+}
+
+func (p *NOAA) Name() string { return "noaa" }
+
+type noaaMETAR struct {
+	ObsTime  int64           `json:"obsTime"`
+	Temp     float64         `json:"temp"`
+	Dewp     float64         `json:"dewp"`
+	Wdir     json.RawMessage `json:"wdir"` // Degrees, or "VRB"
+	Wspd     float64         `json:"wspd"`
+	Wgst     float64         `json:"wgst"`
+	Visib    json.RawMessage `json:"visib"` // Miles, or "10+"
+	Altim    float64         `json:"altim"`
+	WxString string          `json:"wxString"`
+	RawOb    string          `json:"rawOb"`
+	Clouds   []struct {
+		Cover string `json:"cover"`
+	} `json:"clouds"`
+}
+
+// coverConditions describes the sky when no weather phenomena are reported.
+var coverConditions = map[string]string{
+	"SKC":   "Clear",
+	"CLR":   "Clear",
+	"CAVOK": "Clear",
+	"FEW":   "Partly cloudy",
+	"SCT":   "Partly cloudy",
+	"BKN":   "Cloudy",
+	"OVC":   "Overcast",
+	"OVX":   "Overcast",
+}
+
+func (p *NOAA) Fetch(ctx context.Context, loc Location) (domain.Observation, error) {
+	if loc.Icao == "" {
+		return domain.Observation{}, ErrUnsupportedLocation
+	}
+
+	apiURL := p.BaseURL + "/api/data/metar?format=json&ids=" + url.QueryEscape(loc.Icao)
+
+	var metars []noaaMETAR
+	if err := getJSON(ctx, p.client, apiURL, loc, &metars); err != nil {
+		return domain.Observation{}, err
+	}
+	if len(metars) == 0 {
+		return domain.Observation{}, ErrUnsupportedLocation
+	}
+	m := metars[0]
+
+	condition := m.WxString
+	if condition == "" && len(m.Clouds) > 0 {
+		condition = coverConditions[m.Clouds[0].Cover]
+	}
+	if condition == "" {
+		condition = "Clear"
+	}
+
+	obs := domain.Observation{
+		Provider:     p.Name(),
+		Condition:    condition,
+		TemperatureC: m.Temp,
+		DewpointC:    m.Dewp,
+		HumidityPct:  round1(relativeHumidity(m.Temp, m.Dewp)),
+		WindDirDeg:   int(looseNumber(m.Wdir)),
+		WindSpeedKt:  m.Wspd,
+		WindGustKt:   m.Wgst,
+		VisibilitySM: looseNumber(m.Visib),
+		PressureHpa:  m.Altim,
+		RawMETAR:     m.RawOb,
+		ObservedAt:   time.Unix(m.ObsTime, 0).UTC(),
+	}
+	fillFromMETAR(&obs, m)
+	return obs, nil
+}
+
+// fillFromMETAR decodes the raw report for the fields the JSON of m left out,
+// which it does for groups the API couldn't decode.
+func fillFromMETAR(obs *domain.Observation, m noaaMETAR) {
+	metar, err := domain.ParseMETAR(m.RawOb, time.Now())
+	if err != nil {
+		return
+	}
+
+	if m.ObsTime == 0 && metar.ObservedAt != nil {
+		obs.ObservedAt = *metar.ObservedAt
+	}
+	if isMissing(m.Visib) && metar.VisibilitySM != nil {
+		obs.VisibilitySM = *metar.VisibilitySM
+	}
+	if isMissing(m.Wdir) && metar.Wind != nil {
+		if metar.Wind.DirectionDeg != nil {
+			obs.WindDirDeg = *metar.Wind.DirectionDeg
+		}
+		obs.WindSpeedKt, obs.WindGustKt = float64(metar.Wind.SpeedKt), float64(metar.Wind.GustKt)
+	}
+	if m.Altim == 0 && metar.AltimeterHpa != nil {
+		obs.PressureHpa = *metar.AltimeterHpa
+	}
+	if m.WxString == "" && len(metar.Weather) > 0 {
+		obs.Condition = strings.Join(metar.Weather, " ")
+	}
+}
+
+func isMissing(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+// looseNumber reads a JSON number or a numeric string such as "10+"; anything
+// else, like "VRB", is 0.
+func looseNumber(raw json.RawMessage) float64 {
+	var n float64
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		n, _ = strconv.ParseFloat(strings.TrimSuffix(s, "+"), 64)
+	}
+	return n
+}
@@ -0,0 +1,91 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// metersPerStatuteMile converts OpenWeatherMap visibility.
+const metersPerStatuteMile = 1609.344
+
+// OpenWeatherMap fetches current conditions from openweathermap.org.
+type OpenWeatherMap struct {
+	BaseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewOpenWeatherMap(client *http.Client, apiKey string) *OpenWeatherMap {
+	return &OpenWeatherMap{BaseURL: "https://api.openweathermap.org", apiKey: apiKey, client: client}
+}
+
+func (p *OpenWeatherMap) Name() string { return "openweathermap" }
+
+type openWeatherMapResponse struct {
+	Dt      int64 `json:"dt"`
+	Weather []struct {
+		Description string `json:"description"`
+	} `json:"weather"`
+	Main struct {
+		Temp     float64 `json:"temp"`
+		Pressure float64 `json:"pressure"`
+		Humidity float64 `json:"humidity"`
+	} `json:"main"`
+	Visibility float64 `json:"visibility"`
+	Wind       struct {
+		Speed float64 `json:"speed"`
+		Deg   int     `json:"deg"`
+		Gust  float64 `json:"gust"`
+	} `json:"wind"`
+}
+
+func (p *OpenWeatherMap) Fetch(ctx context.Context, loc Location) (domain.Observation, error) {
+	if p.apiKey == "" {
+		return domain.Observation{}, errors.New("missing OPENWEATHERMAP_API_KEY")
+	}
+
+	params := url.Values{"appid": {p.apiKey}, "units": {"metric"}}
+	switch {
+	case loc.HasCoords:
+		params.Set("lat", fmt.Sprintf("%f", loc.Latitude))
+		params.Set("lon", fmt.Sprintf("%f", loc.Longitude))
+	case loc.City != "":
+		params.Set("q", loc.City)
+	default:
+		return domain.Observation{}, ErrUnsupportedLocation
+	}
+
+	var body openWeatherMapResponse
+	if err := getJSON(ctx, p.client, p.BaseURL+"/data/2.5/weather?"+params.Encode(), loc, &body); err != nil {
+		return domain.Observation{}, err
+	}
+
+	var condition string
+	if len(body.Weather) > 0 {
+		condition = body.Weather[0].Description
+		if condition != "" {
+			condition = strings.ToUpper(condition[:1]) + condition[1:]
+		}
+	}
+
+	return domain.Observation{
+		Provider:     p.Name(),
+		Condition:    condition,
+		TemperatureC: body.Main.Temp,
+		DewpointC:    dewpoint(body.Main.Temp, body.Main.Humidity),
+		HumidityPct:  body.Main.Humidity,
+		WindDirDeg:   body.Wind.Deg,
+		WindSpeedKt:  round1(body.Wind.Speed / mpsPerKnot),
+		WindGustKt:   round1(body.Wind.Gust / mpsPerKnot),
+		VisibilitySM: round1(body.Visibility / metersPerStatuteMile),
+		PressureHpa:  body.Main.Pressure,
+		ObservedAt:   time.Unix(body.Dt, 0).UTC(),
+	}, nil
+}
@@ -0,0 +1,113 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"aviation-weather/internal/domain"
+)
+
+var (
+	// ErrRateLimited means the provider rejected the call for exceeding its quota.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrUnsupportedLocation means the provider can't look up this location,
+	// e.g. NOAA without an ICAO code.
+	ErrUnsupportedLocation = errors.New("location not supported")
+)
+
+// Location identifies where to fetch weather for. Providers use whichever
+// fields they support.
+type Location struct {
+	City      string
+	Icao      string
+	Latitude  float64
+	Longitude float64
+	HasCoords bool
+}
+
+func (l Location) String() string {
+	if l.City != "" {
+		return l.City
+	}
+	if l.Icao != "" {
+		return l.Icao
+	}
+	return fmt.Sprintf("%.4f,%.4f", l.Latitude, l.Longitude)
+}
+
+// Provider fetches the current observation from one weather source.
+type Provider interface {
+	Name() string
+	Fetch(ctx context.Context, loc Location) (domain.Observation, error)
+}
+
+// Chain tries providers in priority order and returns the first observation.
+type Chain struct {
+	providers []Provider
+}
+
+func NewChain(providers ...Provider) *Chain {
+	return &Chain{providers: providers}
+}
+
+func (c *Chain) Name() string { return "chain" }
+
+// Fetch falls back to the next provider whenever one fails, and returns every
+// provider's error when none succeeds.
+func (c *Chain) Fetch(ctx context.Context, loc Location) (domain.Observation, error) {
+	if len(c.providers) == 0 {
+		return domain.Observation{}, errors.New("no weather providers configured")
+	}
+
+	var errs []error
+	for _, p := range c.providers {
+		obs, err := p.Fetch(ctx, loc)
+		if err == nil {
+			return obs, nil
+		}
+		if ctx.Err() != nil {
+			return domain.Observation{}, ctx.Err()
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+	}
+
+	return domain.Observation{}, errors.Join(errs...)
+}
+
+// statusError turns a non-200 response into an error, flagging quota rejections.
+func statusError(resp *http.Response, loc Location) error {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("API returned %s for %s: %w", resp.Status, loc, ErrRateLimited)
+	}
+	return fmt.Errorf("API returned %s for %s", resp.Status, loc)
+}
+
+const (
+	kphPerKnot = 1.852
+	mpsPerKnot = 0.514444
+)
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// Magnus formula constants.
+const (
+	magnusB = 17.625
+	magnusC = 243.04
+)
+
+func relativeHumidity(tempC, dewpointC float64) float64 {
+	return 100 * math.Exp(magnusB*dewpointC/(magnusC+dewpointC)) / math.Exp(magnusB*tempC/(magnusC+tempC))
+}
+
+func dewpoint(tempC, humidityPct float64) float64 {
+	if humidityPct <= 0 {
+		return 0
+	}
+	gamma := math.Log(humidityPct/100) + magnusB*tempC/(magnusC+tempC)
+	return round1(magnusC * gamma / (magnusB - gamma))
+}
@@ -0,0 +1,115 @@
+package weather
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(t *testing.T, status int, body string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestWeatherAPI(t *testing.T) {
+	srv := serve(t, http.StatusOK, `{"current":{"last_updated_epoch":1700000000,"temp_c":21.5,"dewpoint_c":10.2,"humidity":48,"wind_kph":18.52,"wind_degree":270,"gust_kph":37.04,"vis_miles":10,"pressure_mb":1013,"condition":{"text":"Sunny"}}}`)
+	p := NewWeatherAPI(srv.Client(), "key")
+	p.BaseURL = srv.URL
+
+	obs, err := p.Fetch(context.Background(), Location{City: "Dallas"})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Observation{
+		Provider:     "weatherapi",
+		Condition:    "Sunny",
+		TemperatureC: 21.5,
+		DewpointC:    10.2,
+		HumidityPct:  48,
+		WindDirDeg:   270,
+		WindSpeedKt:  10,
+		WindGustKt:   20,
+		VisibilitySM: 10,
+		PressureHpa:  1013,
+		ObservedAt:   time.Unix(1700000000, 0).UTC(),
+	}, obs)
+}
+
+func TestWeatherAPIRateLimited(t *testing.T) {
+	srv := serve(t, http.StatusTooManyRequests, `{}`)
+	p := NewWeatherAPI(srv.Client(), "key")
+	p.BaseURL = srv.URL
+
+	_, err := p.Fetch(context.Background(), Location{City: "Dallas"})
+	assert.ErrorIs(t, err, ErrRateLimited)
+}
+
+func TestOpenWeatherMap(t *testing.T) {
+	srv := serve(t, http.StatusOK, `{"dt":1700000000,"weather":[{"description":"light rain"}],"main":{"temp":15,"pressure":1008,"humidity":80},"visibility":16093,"wind":{"speed":5.14444,"deg":180,"gust":0}}`)
+	p := NewOpenWeatherMap(srv.Client(), "key")
+	p.BaseURL = srv.URL
+
+	obs, err := p.Fetch(context.Background(), Location{Latitude: 32.9, Longitude: -97.0, HasCoords: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "Light rain", obs.Condition)
+	assert.Equal(t, 10.0, obs.WindSpeedKt)
+	assert.Equal(t, 10.0, obs.VisibilitySM)
+	assert.Equal(t, 11.6, obs.DewpointC)
+}
+
+func TestNOAA(t *testing.T) {
+	srv := serve(t, http.StatusOK, `[{"obsTime":1700000000,"temp":20,"dewp":10,"wdir":"VRB","wspd":3,"wgst":0,"visib":"10+","altim":1016.2,"wxString":"","rawOb":"KDFW 141453Z VRB03KT 10SM FEW250 20/10 A3001","clouds":[{"cover":"FEW"}]}]`)
+	p := NewNOAA(srv.Client())
+	p.BaseURL = srv.URL
+
+	obs, err := p.Fetch(context.Background(), Location{Icao: "KDFW"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Partly cloudy", obs.Condition)
+	assert.Equal(t, 0, obs.WindDirDeg, "Variable wind has no direction")
+	assert.Equal(t, 10.0, obs.VisibilitySM)
+	assert.Equal(t, 52.5, obs.HumidityPct)
+	assert.Equal(t, "KDFW 141453Z VRB03KT 10SM FEW250 20/10 A3001", obs.RawMETAR)
+
+	_, err = p.Fetch(context.Background(), Location{City: "Dallas"})
+	assert.ErrorIs(t, err, ErrUnsupportedLocation, "NOAA needs an ICAO code")
+}
+
+func TestNOAAFillsFromRawMETAR(t *testing.T) {
+	srv := serve(t, http.StatusOK, `[{"obsTime":1700000000,"temp":12,"dewp":12,"rawOb":"KSFO 141456Z 28008KT 1 1/2SM -RA BR OVC004 12/12 A3001"}]`)
+	p := NewNOAA(srv.Client())
+	p.BaseURL = srv.URL
+
+	obs, err := p.Fetch(context.Background(), Location{Icao: "KSFO"})
+	assert.NoError(t, err)
+	assert.Equal(t, "-RA BR", obs.Condition)
+	assert.Equal(t, 280, obs.WindDirDeg)
+	assert.Equal(t, 8.0, obs.WindSpeedKt)
+	assert.Equal(t, 1.5, obs.VisibilitySM)
+	assert.Equal(t, 1016.3, obs.PressureHpa)
+}
+
+func TestChain(t *testing.T) {
+	down := serve(t, http.StatusServiceUnavailable, ``)
+	primary := NewWeatherAPI(down.Client(), "key")
+	primary.BaseURL = down.URL
+
+	up := serve(t, http.StatusOK, `[{"obsTime":1700000000,"temp":20,"dewp":10,"wdir":90,"wspd":5,"visib":6,"altim":1016,"wxString":"BR"}]`)
+	fallback := NewNOAA(up.Client())
+	fallback.BaseURL = up.URL
+
+	obs, err := NewChain(primary, fallback).Fetch(context.Background(), Location{City: "Dallas", Icao: "KDFW"})
+	assert.NoError(t, err)
+	assert.Equal(t, "noaa", obs.Provider)
+	assert.Equal(t, "BR", obs.Condition)
+
+	_, err = NewChain(primary).Fetch(context.Background(), Location{City: "Dallas"})
+	assert.ErrorContains(t, err, "weatherapi: API returned 503 Service Unavailable for Dallas")
+}
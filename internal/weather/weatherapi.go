@@ -0,0 +1,111 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// WeatherAPI fetches current conditions from weatherapi.com.
+type WeatherAPI struct {
+	BaseURL string
+	apiKey  atomic.Pointer[string]
+	client  *http.Client
+}
+
+func NewWeatherAPI(client *http.Client, apiKey string) *WeatherAPI {
+	p := &WeatherAPI{BaseURL: "https://api.weatherapi.com", client: client}
+	p.SetAPIKey(apiKey)
+	return p
+}
+
+// SetAPIKey switches to a rotated key for the following requests.
+func (p *WeatherAPI) SetAPIKey(apiKey string) {
+	p.apiKey.Store(&apiKey)
+}
+
+func (p *WeatherAPI) Name() string { return "weatherapi" }
+
+type weatherAPIResponse struct {
+	Current struct {
+		LastUpdatedEpoch int64   `json:"last_updated_epoch"`
+		TempC            float64 `json:"temp_c"`
+		DewpointC        float64 `json:"dewpoint_c"`
+		Humidity         float64 `json:"humidity"`
+		WindKph          float64 `json:"wind_kph"`
+		WindDegree       int     `json:"wind_degree"`
+		GustKph          float64 `json:"gust_kph"`
+		VisMiles         float64 `json:"vis_miles"`
+		PressureMb       float64 `json:"pressure_mb"`
+		Condition        struct {
+			Text string `json:"text"`
+		} `json:"condition"`
+	} `json:"current"`
+}
+
+func (p *WeatherAPI) Fetch(ctx context.Context, loc Location) (domain.Observation, error) {
+	apiKey := *p.apiKey.Load()
+	if apiKey == "" {
+		return domain.Observation{}, errors.New("missing WEATHER_API_KEY")
+	}
+
+	q := loc.City
+	if q == "" && loc.HasCoords {
+		q = fmt.Sprintf("%f,%f", loc.Latitude, loc.Longitude)
+	}
+	if q == "" {
+		return domain.Observation{}, ErrUnsupportedLocation
+	}
+
+	apiURL := fmt.Sprintf("%s/v1/current.json?key=%s&q=%s", p.BaseURL, url.QueryEscape(apiKey), url.QueryEscape(q))
+
+	var body weatherAPIResponse
+	if err := getJSON(ctx, p.client, apiURL, loc, &body); err != nil {
+		return domain.Observation{}, err
+	}
+
+	c := body.Current
+	return domain.Observation{
+		Provider:     p.Name(),
+		Condition:    c.Condition.Text,
+		TemperatureC: c.TempC,
+		DewpointC:    c.DewpointC,
+		HumidityPct:  c.Humidity,
+		WindDirDeg:   c.WindDegree,
+		WindSpeedKt:  round1(c.WindKph / kphPerKnot),
+		WindGustKt:   round1(c.GustKph / kphPerKnot),
+		VisibilitySM: c.VisMiles,
+		PressureHpa:  c.PressureMb,
+		ObservedAt:   time.Unix(c.LastUpdatedEpoch, 0).UTC(),
+	}, nil
+}
+
+// getJSON performs a GET and decodes a 200 response into dst.
+func getJSON(ctx context.Context, client *http.Client, apiURL string, loc Location, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request for %s: %w", loc, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed for %s: %w", loc, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusError(resp, loc)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("failed to unmarshal response for %s: %w", loc, err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Package webhook delivers signed event notifications to subscribed URLs.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"aviation-weather/internal/domain"
+)
+
+// Headers set on every delivery.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Store looks up subscribers and keeps the delivery log.
+type Store interface {
+	GetWebhooksForEvent(tenantID int64, event string) ([]domain.Webhook, error)
+	SaveWebhookDelivery(delivery *domain.WebhookDelivery) error
+}
+
+// Dispatcher posts events to their subscribers in the background, retrying
+// failed deliveries with exponential backoff.
+type Dispatcher struct {
+	store       Store
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	wg          sync.WaitGroup
+}
+
+// NewDispatcher returns a dispatcher trying each delivery up to maxAttempts
+// times, waiting backoff before the first retry and doubling it after each.
+func NewDispatcher(store Store, client *http.Client, maxAttempts int, backoff time.Duration) *Dispatcher {
+	return &Dispatcher{store: store, client: client, maxAttempts: max(maxAttempts, 1), backoff: backoff}
+}
+
+// Dispatch sends event to every webhook of a tenant subscribed to it, or of
+// every tenant for domain.AllTenants, without blocking the caller.
+func (d *Dispatcher) Dispatch(tenantID int64, event string, data any) {
+	body, err := json.Marshal(domain.WebhookEvent{Event: event, OccurredAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		log.Printf("WARN: Failed to encode %s webhook event: %v", event, err)
+		return
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		webhooks, err := d.store.GetWebhooksForEvent(tenantID, event)
+		if err != nil {
+			log.Printf("WARN: Failed to get webhooks for %s: %v", event, err)
+			return
+		}
+		for _, wh := range webhooks {
+			d.wg.Add(1)
+			go func() {
+				defer d.wg.Done()
+				d.deliver(wh, event, body)
+			}()
+		}
+	}()
+}
+
+// Wait blocks until every pending delivery has succeeded or given up.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// deliver posts body to one webhook until it answers 2xx or attempts run out,
+// then logs the outcome.
+func (d *Dispatcher) deliver(wh domain.Webhook, event string, body []byte) {
+	delivery := domain.WebhookDelivery{WebhookID: wh.ID, Event: event, Payload: body}
+
+	wait := d.backoff
+	for delivery.Attempts < d.maxAttempts {
+		if delivery.Attempts > 0 {
+			time.Sleep(wait)
+			wait *= 2
+		}
+		delivery.Attempts++
+
+		statusCode, err := d.post(wh, event, body)
+		delivery.StatusCode, delivery.Error = statusCode, ""
+		if err == nil {
+			delivery.Succeeded = true
+			break
+		}
+		delivery.Error = err.Error()
+	}
+
+	if !delivery.Succeeded {
+		log.Printf("WARN: Webhook %d gave up on %s after %d attempts: %s", wh.ID, event, delivery.Attempts, delivery.Error)
+	}
+	if err := d.store.SaveWebhookDelivery(&delivery); err != nil {
+		log.Printf("WARN: Failed to log delivery of %s to webhook %d: %v", event, wh.ID, err)
+	}
+}
+
+// post sends one attempt. Any status outside 2xx is an error.
+func (d *Dispatcher) post(wh domain.Webhook, event string, body []byte) (int, error) {
+	return Post(context.Background(), d.client, wh.URL, wh.Secret, event, body)
+}
+
+// Post sends one event body to url with the delivery headers, signed when
+// secret is set, and returns the status code. Any status outside 2xx is an
+// error.
+func Post(ctx context.Context, client *http.Client, url, secret, event string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, event)
+	if secret != "" {
+		req.Header.Set(HeaderSignature, Sign(secret, body))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) // Drain so the connection is reused
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value of body: "sha256=" followed by the
+// hex HMAC-SHA256 of body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
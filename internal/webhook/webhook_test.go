@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"aviation-weather/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStore serves fixed webhooks, filtered by tenant, and records deliveries.
+type fakeStore struct {
+	webhooks   []domain.Webhook
+	mu         sync.Mutex
+	deliveries []domain.WebhookDelivery
+}
+
+func (f *fakeStore) GetWebhooksForEvent(tenantID int64, event string) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	for _, wh := range f.webhooks {
+		if tenantID == domain.AllTenants || wh.TenantID == tenantID {
+			webhooks = append(webhooks, wh)
+		}
+	}
+	return webhooks, nil
+}
+
+func (f *fakeStore) SaveWebhookDelivery(d *domain.WebhookDelivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deliveries = append(f.deliveries, *d)
+	return nil
+}
+
+func TestDispatchSignsAndRetries(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, Sign("s3cret", body), r.Header.Get(HeaderSignature))
+		assert.Equal(t, domain.EventAlertFired, r.Header.Get(HeaderEvent))
+
+		var event domain.WebhookEvent
+		assert.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, domain.EventAlertFired, event.Event)
+
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	store := &fakeStore{webhooks: []domain.Webhook{
+		{ID: 1, URL: srv.URL, Secret: "s3cret", TenantID: 2},
+		{ID: 3, URL: srv.URL, Secret: "other", TenantID: 3},
+	}}
+	d := NewDispatcher(store, srv.Client(), 3, 0)
+	d.Dispatch(2, domain.EventAlertFired, domain.Alert{RuleID: 7, Faa: "DFW"})
+	d.Wait()
+
+	if assert.Len(t, store.deliveries, 1, "Only the tenant's webhooks are sent the event") {
+		delivery := store.deliveries[0]
+		assert.Equal(t, int64(1), delivery.WebhookID)
+		assert.True(t, delivery.Succeeded)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Equal(t, http.StatusOK, delivery.StatusCode)
+		assert.Empty(t, delivery.Error)
+		assert.Contains(t, string(delivery.Payload), `"faa_ident":"DFW"`)
+	}
+}
+
+func TestDispatchGivesUp(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get(HeaderSignature), "unsigned without a secret")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	store := &fakeStore{webhooks: []domain.Webhook{{ID: 2, URL: srv.URL}}}
+	d := NewDispatcher(store, srv.Client(), 2, 0)
+	d.Dispatch(domain.AllTenants, domain.EventSyncCompleted, domain.SyncCompleted{Updated: 3})
+	d.Wait()
+
+	if assert.Len(t, store.deliveries, 1) {
+		delivery := store.deliveries[0]
+		assert.False(t, delivery.Succeeded)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Equal(t, http.StatusInternalServerError, delivery.StatusCode)
+		assert.Equal(t, "unexpected status 500", delivery.Error)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '{}' | openssl dgst -sha256 -hmac key
+	assert.Equal(t, "sha256=a777724d943eb48dc69bca8a4a6d57a04db3f9ec7e1de4e581e860265bdf3032", Sign("key", []byte("{}")))
+}
@@ -0,0 +1,314 @@
+// Package xlsx writes Excel workbooks (Office Open XML spreadsheets) as a
+// stream, without holding the first sheet in memory. Cells are typed: strings,
+// numbers and times, the latter shown as dates by Excel.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType is the media type of an .xlsx file.
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// Style indexes of the cellXfs in styles.xml.
+const (
+	styleDefault = 0
+	styleHeader  = 1
+	styleTime    = 2
+)
+
+// excelEpoch is day 0 of the serial dates of Excel, leap year bug included.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Column is a column of a sheet: its header and width in characters, 0 for
+// the default.
+type Column struct {
+	Name  string
+	Width float64
+}
+
+// Writer writes a workbook to an io.Writer. The rows of its first sheet go
+// straight out; the other sheets are held in memory until Close, as a zip
+// archive can only write one file at a time.
+type Writer struct {
+	zip    *zip.Writer
+	sheets []*Sheet
+	closed bool
+}
+
+// NewWriter starts a workbook on w. Nothing is complete until Close.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{zip: zip.NewWriter(w)}
+}
+
+// Sheet is a sheet of a workbook, written row by row after its header.
+type Sheet struct {
+	name    string
+	columns []Column
+	out     *bufio.Writer
+	buf     *bytes.Buffer // the rows of a sheet other than the first
+	rows    int
+}
+
+// NewSheet adds a sheet with a header row of columns. Sheet names are at most
+// 31 characters, without any of []:*?/\.
+func (w *Writer) NewSheet(name string, columns []Column) (*Sheet, error) {
+	if w.closed {
+		return nil, errors.New("workbook is closed")
+	}
+	if name == "" || len([]rune(name)) > 31 || strings.ContainsAny(name, `[]:*?/\`) {
+		return nil, fmt.Errorf("invalid sheet name %q", name)
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("sheet has no column")
+	}
+
+	s := &Sheet{name: name, columns: columns}
+	if len(w.sheets) == 0 {
+		f, err := w.zip.Create(sheetPath(1))
+		if err != nil {
+			return nil, err
+		}
+		s.out = bufio.NewWriter(f)
+	} else {
+		s.buf = &bytes.Buffer{}
+		s.out = bufio.NewWriter(s.buf)
+	}
+	w.sheets = append(w.sheets, s)
+
+	s.out.WriteString(xml.Header)
+	s.out.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	// The header row stays in view while scrolling
+	s.out.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	s.out.WriteString(`<cols>`)
+	for i, col := range columns {
+		width := col.Width
+		if width == 0 {
+			width = max(10, float64(len(col.Name))+2)
+		}
+		fmt.Fprintf(s.out, `<col min="%d" max="%d" width="%s" customWidth="1"/>`, i+1, i+1, strconv.FormatFloat(width, 'f', -1, 64))
+	}
+	s.out.WriteString(`</cols><sheetData>`)
+
+	header := make([]any, len(columns))
+	for i, col := range columns {
+		header[i] = col.Name
+	}
+	if err := s.writeRow(header, styleHeader); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// WriteRow writes a row of values, one per column: a string, a number, a
+// time.Time, shown in UTC, or nil for an empty cell. Pointers are followed,
+// nil ones left empty.
+func (s *Sheet) WriteRow(values ...any) error {
+	if len(values) > len(s.columns) {
+		return fmt.Errorf("sheet %s: row of %d values for %d columns", s.name, len(values), len(s.columns))
+	}
+	return s.writeRow(values, styleDefault)
+}
+
+func (s *Sheet) writeRow(values []any, style int) error {
+	s.rows++
+	fmt.Fprintf(s.out, `<row r="%d">`, s.rows)
+	for i, value := range values {
+		ref := columnName(i) + strconv.Itoa(s.rows)
+		if err := s.writeCell(ref, value, style); err != nil {
+			return fmt.Errorf("sheet %s, cell %s: %w", s.name, ref, err)
+		}
+	}
+	_, err := s.out.WriteString(`</row>`)
+	return err
+}
+
+func (s *Sheet) writeCell(ref string, value any, style int) error {
+	var number string
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *string:
+		if v == nil {
+			return nil
+		}
+		value = *v
+	case *float64:
+		if v == nil {
+			return nil
+		}
+		value = *v
+	case *int:
+		if v == nil {
+			return nil
+		}
+		value = *v
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		value = *v
+	}
+
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		fmt.Fprintf(s.out, `<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">`, ref, styleAttr(style))
+		if err := xml.EscapeText(s.out, []byte(v)); err != nil {
+			return err
+		}
+		s.out.WriteString(`</t></is></c>`)
+		return nil
+	case float64:
+		number = strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		number = strconv.Itoa(v)
+	case int64:
+		number = strconv.FormatInt(v, 10)
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		days := float64(v.UTC().Sub(excelEpoch)) / float64(24*time.Hour)
+		number = strconv.FormatFloat(days, 'f', -1, 64)
+		style = styleTime
+	default:
+		return fmt.Errorf("unsupported cell value %T", value)
+	}
+	_, err := fmt.Fprintf(s.out, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr(style), number)
+	return err
+}
+
+func styleAttr(style int) string {
+	if style == styleDefault {
+		return ""
+	}
+	return ` s="` + strconv.Itoa(style) + `"`
+}
+
+// columnName names the 0-based column i as Excel does: A to Z, then AA.
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func sheetPath(n int) string {
+	return "xl/worksheets/sheet" + strconv.Itoa(n) + ".xml"
+}
+
+// end finishes the sheet, with a filter on the header row.
+func (s *Sheet) end() error {
+	s.out.WriteString(`</sheetData>`)
+	fmt.Fprintf(s.out, `<autoFilter ref="A1:%s%d"/>`, columnName(len(s.columns)-1), s.rows)
+	s.out.WriteString(`</worksheet>`)
+	return s.out.Flush()
+}
+
+// Flush writes out the rows of the first sheet buffered so far.
+func (w *Writer) Flush() error {
+	if len(w.sheets) > 0 {
+		if err := w.sheets[0].out.Flush(); err != nil {
+			return err
+		}
+	}
+	return w.zip.Flush()
+}
+
+// Close finishes every sheet and the workbook. It doesn't close the
+// underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if len(w.sheets) == 0 {
+		return errors.New("workbook has no sheet")
+	}
+	for i, s := range w.sheets {
+		if err := s.end(); err != nil {
+			return err
+		}
+		if i == 0 {
+			continue
+		}
+		f, err := w.zip.Create(sheetPath(i + 1))
+		if err != nil {
+			return err
+		}
+		if _, err := s.buf.WriteTo(f); err != nil {
+			return err
+		}
+	}
+
+	var types, sheets, rels strings.Builder
+	for i, s := range w.sheets {
+		n := i + 1
+		fmt.Fprintf(&types, `<Override PartName="/%s" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, sheetPath(n))
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeAttr(s.name), n, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+	fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(w.sheets)+1)
+
+	parts := []struct{ path, body string }{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			types.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + sheets.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			rels.String() + `</Relationships>`},
+		{"xl/styles.xml", stylesXML},
+	}
+	for _, part := range parts {
+		f, err := w.zip.Create(part.path)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, xml.Header+part.body); err != nil {
+			return err
+		}
+	}
+	return w.zip.Close()
+}
+
+func escapeAttr(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// stylesXML defines the cell styles: default, bold for headers, and a date
+// and time format for times.
+const stylesXML = `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
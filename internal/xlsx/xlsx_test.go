@@ -0,0 +1,83 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out)
+	airports, err := w.NewSheet("Airports", []Column{{Name: "faa_ident"}, {Name: "latitude"}, {Name: "synced", Width: 18}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	weather, err := w.NewSheet("Weather", []Column{{Name: "faa_ident"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latitude := 34.05
+	synced := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	assert.NoError(t, airports.WriteRow("JFK", &latitude, synced))
+	assert.NoError(t, airports.WriteRow(`A & <B>`, (*float64)(nil), nil))
+	assert.NoError(t, weather.WriteRow("JFK"))
+	assert.Error(t, weather.WriteRow("JFK", "extra"))
+	assert.Error(t, weather.WriteRow(struct{}{}))
+	assert.NoError(t, w.Close())
+
+	files := unzip(t, out.Bytes())
+
+	sheet1 := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet1, `<c r="A1" t="inlineStr" s="1"><is><t xml:space="preserve">faa_ident</t></is></c>`)
+	assert.Contains(t, sheet1, `<c r="B2"><v>34.05</v></c>`)
+	assert.Contains(t, sheet1, `<c r="C2" s="2"><v>45444.75</v></c>`, "2024-06-01 18:00 as an Excel serial date")
+	assert.Contains(t, sheet1, `<row r="3"><c r="A3" t="inlineStr"><is><t xml:space="preserve">A &amp; &lt;B&gt;</t></is></c></row>`)
+	assert.Contains(t, sheet1, `<autoFilter ref="A1:C3"/>`)
+	assert.Contains(t, files["xl/worksheets/sheet2.xml"], `<row r="2"><c r="A2" t="inlineStr">`)
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Airports" sheetId="1" r:id="rId1"/><sheet name="Weather" sheetId="2" r:id="rId2"/>`)
+	assert.Contains(t, files["[Content_Types].xml"], `PartName="/xl/worksheets/sheet2.xml"`)
+	assert.Contains(t, files["xl/_rels/workbook.xml.rels"], `Id="rId3"`)
+	assert.Contains(t, files, "xl/styles.xml")
+}
+
+func TestNewSheetRejects(t *testing.T) {
+	w := NewWriter(io.Discard)
+	_, err := w.NewSheet("Weather/METAR", []Column{{Name: "faa_ident"}})
+	assert.Error(t, err)
+	_, err = w.NewSheet("Empty", nil)
+	assert.Error(t, err)
+	assert.Error(t, w.Close(), "A workbook needs a sheet")
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AZ", columnName(51))
+	assert.Equal(t, "BA", columnName(52))
+}
+
+// unzip reads every file of an archive by name.
+func unzip(t *testing.T, data []byte) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(body)
+	}
+	return files
+}
@@ -0,0 +1,32 @@
+-- Migration: Create Airport table
+CREATE TABLE IF NOT EXISTS airport (
+    site_number VARCHAR(10),
+    facility_name VARCHAR(255),
+    faa VARCHAR(10) PRIMARY KEY,
+    icao VARCHAR(10),
+    state_code VARCHAR(2),
+    state_full VARCHAR(100),
+    county VARCHAR(100),
+    city VARCHAR(100),
+    ownership_type VARCHAR(100),
+    use_type VARCHAR(50),
+    manager VARCHAR(255),
+    manager_phone VARCHAR(20),
+    latitude VARCHAR(50),
+    longitude VARCHAR(50),
+    airport_status VARCHAR(50),
+    weather VARCHAR(50),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_synced_at TIMESTAMPTZ
+);
+
+-- Columns added after the initial release, for databases created before them
+ALTER TABLE airport ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE airport ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE airport ADD COLUMN IF NOT EXISTS last_synced_at TIMESTAMPTZ;
+ALTER TABLE airport ADD COLUMN IF NOT EXISTS elevation_ft NUMERIC(7, 1);
+ALTER TABLE airport ADD COLUMN IF NOT EXISTS pressure_altitude_ft INTEGER;
+ALTER TABLE airport ADD COLUMN IF NOT EXISTS density_altitude_ft INTEGER;
+ALTER TABLE airport ADD COLUMN IF NOT EXISTS magnetic_variation NUMERIC(4, 1);
+ALTER TABLE airport ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
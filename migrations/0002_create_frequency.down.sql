@@ -0,0 +1,2 @@
+-- Migration: Drop Airport Frequency table
+DROP TABLE IF EXISTS airport_frequency;
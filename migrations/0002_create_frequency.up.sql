@@ -0,0 +1,10 @@
+-- Migration: Create Airport Frequency table
+CREATE TABLE IF NOT EXISTS airport_frequency (
+    id SERIAL PRIMARY KEY,
+    faa VARCHAR(10) NOT NULL REFERENCES airport(faa) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    description VARCHAR(255),
+    frequency_mhz NUMERIC(7, 3) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS airport_frequency_faa_idx ON airport_frequency (faa);
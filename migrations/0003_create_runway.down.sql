@@ -0,0 +1,2 @@
+-- Migration: Drop Airport Runway table
+DROP TABLE IF EXISTS airport_runway;
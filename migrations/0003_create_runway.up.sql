@@ -0,0 +1,12 @@
+-- Migration: Create Airport Runway table, one row per runway end
+CREATE TABLE IF NOT EXISTS airport_runway (
+    id SERIAL PRIMARY KEY,
+    faa VARCHAR(10) NOT NULL REFERENCES airport(faa) ON DELETE CASCADE,
+    ident VARCHAR(10) NOT NULL,
+    heading_deg NUMERIC(5, 1) NOT NULL,
+    length_ft INTEGER,
+    width_ft INTEGER,
+    surface VARCHAR(50)
+);
+
+CREATE INDEX IF NOT EXISTS airport_runway_faa_idx ON airport_runway (faa);
@@ -0,0 +1,2 @@
+-- Migration: Drop Airport Weather table
+DROP TABLE IF EXISTS airport_weather;
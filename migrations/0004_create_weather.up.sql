@@ -0,0 +1,17 @@
+-- Migration: Create Airport Weather table holding the latest structured observation
+CREATE TABLE IF NOT EXISTS airport_weather (
+    faa VARCHAR(10) PRIMARY KEY REFERENCES airport(faa) ON DELETE CASCADE,
+    provider VARCHAR(50),
+    condition VARCHAR(100),
+    temperature_c NUMERIC(5, 1),
+    dewpoint_c NUMERIC(5, 1),
+    humidity_pct NUMERIC(5, 1),
+    wind_dir_deg INTEGER,
+    wind_speed_kt NUMERIC(5, 1),
+    wind_gust_kt NUMERIC(5, 1),
+    visibility_sm NUMERIC(6, 2),
+    pressure_hpa NUMERIC(6, 1),
+    raw_metar TEXT,
+    observed_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+-- Migration: Drop Alerts and Alert Rules tables
+DROP TABLE IF EXISTS alerts;
+DROP TABLE IF EXISTS alert_rules;
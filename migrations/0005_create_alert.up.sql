@@ -0,0 +1,29 @@
+-- Migration: Create Alert Rules and Alerts tables
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    faa VARCHAR(10) NOT NULL DEFAULT '',
+    state_code VARCHAR(2) NOT NULL DEFAULT '',
+    metric VARCHAR(30) NOT NULL,
+    operator VARCHAR(20) NOT NULL,
+    threshold NUMERIC(8, 2) NOT NULL DEFAULT 0,
+    category VARCHAR(4) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE alert_rules ADD COLUMN IF NOT EXISTS notify JSONB NOT NULL DEFAULT '[]';
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id BIGSERIAL PRIMARY KEY,
+    rule_id BIGINT NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    faa VARCHAR(10) NOT NULL REFERENCES airport(faa) ON DELETE CASCADE,
+    metric VARCHAR(30) NOT NULL,
+    value VARCHAR(20) NOT NULL,
+    message TEXT NOT NULL,
+    observed_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- One alert per rule and observation, however often the same weather is synced
+    UNIQUE (rule_id, faa, observed_at)
+);
+
+CREATE INDEX IF NOT EXISTS alerts_created_at_idx ON alerts (created_at DESC);
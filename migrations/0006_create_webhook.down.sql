@@ -0,0 +1,3 @@
+-- Migration: Drop Webhook Deliveries and Webhooks tables
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,3 @@
+-- Migration: Drop the airport change trigger
+DROP TRIGGER IF EXISTS airport_change_notify ON airport;
+DROP FUNCTION IF EXISTS notify_airport_change();
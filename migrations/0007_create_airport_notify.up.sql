@@ -0,0 +1,16 @@
+-- Migration: Announce airport changes on the airport_changes channel
+CREATE OR REPLACE FUNCTION notify_airport_change() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('airport_changes', json_build_object(
+        'op', LOWER(TG_OP),
+        'faa_ident', CASE WHEN TG_OP = 'DELETE' THEN OLD.faa ELSE NEW.faa END
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Fires for every writer, not just this service
+DROP TRIGGER IF EXISTS airport_change_notify ON airport;
+CREATE TRIGGER airport_change_notify
+    AFTER INSERT OR UPDATE OR DELETE ON airport
+    FOR EACH ROW EXECUTE FUNCTION notify_airport_change();
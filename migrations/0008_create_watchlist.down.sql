@@ -0,0 +1,2 @@
+-- Migration: Drop Watchlists table
+DROP TABLE IF EXISTS watchlists;
@@ -0,0 +1,11 @@
+-- Migration: Create Watchlists table
+CREATE TABLE IF NOT EXISTS watchlists (
+    id BIGSERIAL PRIMARY KEY,
+    -- SHA-256 of the owner's API key
+    owner CHAR(64) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    faa_codes TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS watchlists_owner_idx ON watchlists (owner, id);
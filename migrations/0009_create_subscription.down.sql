@@ -0,0 +1,2 @@
+-- Migration: Drop Subscriptions table
+DROP TABLE IF EXISTS subscriptions;
@@ -0,0 +1,14 @@
+-- Migration: Create Subscriptions table
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    -- SHA-256 of the owner's API key
+    owner CHAR(64) NOT NULL,
+    faa_codes TEXT[] NOT NULL,
+    channel VARCHAR(10) NOT NULL,
+    target TEXT NOT NULL,
+    secret TEXT NOT NULL DEFAULT '',
+    last_sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS subscriptions_owner_idx ON subscriptions (owner, id);
@@ -0,0 +1,8 @@
+-- Migration: Drop Tenants and API Keys tables and the tenant columns
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE watchlists DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE alerts DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE alert_rules DROP COLUMN IF EXISTS tenant_id;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS tenants;
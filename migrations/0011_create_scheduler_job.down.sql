@@ -0,0 +1,2 @@
+-- Migration: Drop Scheduler Jobs table
+DROP TABLE IF EXISTS scheduler_jobs;
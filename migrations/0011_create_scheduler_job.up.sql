@@ -0,0 +1,7 @@
+-- Migration: Create Scheduler Jobs table overriding the job schedules of the config
+CREATE TABLE IF NOT EXISTS scheduler_jobs (
+    name VARCHAR(50) PRIMARY KEY,
+    schedule VARCHAR(100) NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
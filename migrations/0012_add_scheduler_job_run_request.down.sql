@@ -0,0 +1,2 @@
+-- Migration: Drop run requests from Scheduler Jobs table
+ALTER TABLE scheduler_jobs DROP COLUMN IF EXISTS run_requested_at;
@@ -0,0 +1,2 @@
+-- Migration: Add run requests to Scheduler Jobs table
+ALTER TABLE scheduler_jobs ADD COLUMN IF NOT EXISTS run_requested_at TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+-- Migration: Drop Sync Failures table
+DROP TABLE IF EXISTS sync_failures;
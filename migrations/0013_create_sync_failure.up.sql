@@ -0,0 +1,10 @@
+-- Migration: Create Sync Failures table holding the airports whose last sync failed
+CREATE TABLE IF NOT EXISTS sync_failures (
+    faa VARCHAR(10) PRIMARY KEY REFERENCES airport(faa) ON DELETE CASCADE,
+    error TEXT NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 1,
+    last_failed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    next_retry_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_failures_next_retry_at ON sync_failures (next_retry_at);
@@ -0,0 +1,2 @@
+-- Migration: Drop Weather History table and its partitions
+DROP TABLE IF EXISTS weather_history;
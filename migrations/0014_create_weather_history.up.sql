@@ -0,0 +1,37 @@
+-- Migration: Create Weather History table, one row per observation, partitioned by month
+CREATE TABLE IF NOT EXISTS weather_history (
+    faa VARCHAR(10) NOT NULL REFERENCES airport(faa) ON DELETE CASCADE,
+    provider VARCHAR(50),
+    condition VARCHAR(100),
+    temperature_c NUMERIC(5, 1),
+    dewpoint_c NUMERIC(5, 1),
+    humidity_pct NUMERIC(5, 1),
+    wind_dir_deg INTEGER,
+    wind_speed_kt NUMERIC(5, 1),
+    wind_gust_kt NUMERIC(5, 1),
+    visibility_sm NUMERIC(6, 2),
+    pressure_hpa NUMERIC(6, 1),
+    raw_metar TEXT,
+    observed_at TIMESTAMPTZ NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (faa, observed_at)
+) PARTITION BY RANGE (observed_at);
+
+-- Observations outside every monthly partition land here until one is created
+CREATE TABLE IF NOT EXISTS weather_history_default PARTITION OF weather_history DEFAULT;
+
+-- The partition_history job keeps the upcoming months created from here on
+DO $$
+DECLARE
+    month DATE := date_trunc('month', NOW() AT TIME ZONE 'UTC');
+BEGIN
+    FOR i IN 0..2 LOOP
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF weather_history FOR VALUES FROM (%L) TO (%L)',
+            'weather_history_y' || to_char(month, 'YYYY') || 'm' || to_char(month, 'MM'),
+            month::timestamp AT TIME ZONE 'UTC',
+            (month + INTERVAL '1 month')::timestamp AT TIME ZONE 'UTC'
+        );
+        month := month + INTERVAL '1 month';
+    END LOOP;
+END $$;
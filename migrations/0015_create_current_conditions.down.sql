@@ -0,0 +1,2 @@
+-- Migration: Drop Current Conditions materialized view
+DROP MATERIALIZED VIEW IF EXISTS current_conditions;
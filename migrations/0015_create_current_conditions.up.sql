@@ -0,0 +1,18 @@
+-- Migration: Create Current Conditions materialized view counting airports by
+-- state and the visibility and ceiling of their latest observation, refreshed
+-- after each sync for the dashboard
+CREATE MATERIALIZED VIEW IF NOT EXISTS current_conditions AS
+SELECT COALESCE(a.state_code, '') AS state_code,
+       COALESCE(w.visibility_sm, 0) AS visibility_sm,
+       COALESCE(SUBSTRING(w.raw_metar FROM '(?:^|\s)(?:BKN|OVC|VV)(\d{3})'), '') AS ceiling,
+       COALESCE(w.raw_metar, '') <> '' AS has_metar,
+       COUNT(*) AS airports,
+       MAX(a.last_synced_at) AS last_synced_at,
+       NOW() AS refreshed_at
+FROM airport a
+LEFT JOIN airport_weather w ON w.faa = a.faa
+GROUP BY 1, 2, 3, 4;
+
+-- REFRESH ... CONCURRENTLY needs a unique index
+CREATE UNIQUE INDEX IF NOT EXISTS current_conditions_group_idx
+    ON current_conditions (state_code, visibility_sm, ceiling, has_metar);
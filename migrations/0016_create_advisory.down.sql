@@ -0,0 +1,2 @@
+-- Migration: Drop Advisories table
+DROP TABLE IF EXISTS advisories;
@@ -0,0 +1,21 @@
+-- Migration: Create Advisories table holding the SIGMETs and AIRMETs of the last advisory sync
+CREATE TABLE IF NOT EXISTS advisories (
+    id SERIAL PRIMARY KEY,
+    type VARCHAR(10) NOT NULL,
+    hazard VARCHAR(50) NOT NULL,
+    severity INTEGER NOT NULL DEFAULT 0,
+    altitude_low_ft INTEGER,
+    altitude_high_ft INTEGER,
+    valid_from TIMESTAMPTZ,
+    valid_to TIMESTAMPTZ,
+    raw_text TEXT NOT NULL DEFAULT '',
+    area JSONB NOT NULL,
+    -- Bounding box of area, narrowing the advisories to test an airport against
+    min_lat DOUBLE PRECISION NOT NULL,
+    max_lat DOUBLE PRECISION NOT NULL,
+    min_lon DOUBLE PRECISION NOT NULL,
+    max_lon DOUBLE PRECISION NOT NULL,
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_advisories_valid_to ON advisories (valid_to);
@@ -0,0 +1,6 @@
+-- Migration: Store airport latitude and longitude as text again, in decimal degrees
+DROP INDEX IF EXISTS airport_position_idx;
+
+ALTER TABLE airport
+    ALTER COLUMN latitude TYPE VARCHAR(50) USING latitude::TEXT,
+    ALTER COLUMN longitude TYPE VARCHAR(50) USING longitude::TEXT;
@@ -0,0 +1,29 @@
+-- Migration: Store airport latitude and longitude as decimal degrees instead of text
+-- Text in decimal degrees or DD-MM-SS.sH is converted; anything else becomes NULL.
+CREATE OR REPLACE FUNCTION coordinate_degrees(value TEXT) RETURNS DOUBLE PRECISION AS $$
+DECLARE
+    parts TEXT[];
+BEGIN
+    value := btrim(value);
+    IF value ~ '^[-+]?[0-9]+(\.[0-9]+)?$' THEN
+        RETURN value::DOUBLE PRECISION;
+    END IF;
+    parts := regexp_match(value, '^([0-9]{1,3})-([0-9]{1,2})-([0-9]{1,2}(\.[0-9]+)?)([NSEW])$');
+    IF parts IS NULL OR parts[2]::INTEGER >= 60 OR parts[3]::DOUBLE PRECISION >= 60 THEN
+        RETURN NULL;
+    END IF;
+    RETURN (CASE WHEN parts[5] IN ('S', 'W') THEN -1 ELSE 1 END)
+        * (parts[1]::DOUBLE PRECISION + parts[2]::DOUBLE PRECISION / 60 + parts[3]::DOUBLE PRECISION / 3600);
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+ALTER TABLE airport
+    ALTER COLUMN latitude TYPE DOUBLE PRECISION USING coordinate_degrees(latitude),
+    ALTER COLUMN longitude TYPE DOUBLE PRECISION USING coordinate_degrees(longitude);
+
+UPDATE airport SET latitude = NULL WHERE ABS(latitude) > 90;
+UPDATE airport SET longitude = NULL WHERE ABS(longitude) > 180;
+
+DROP FUNCTION coordinate_degrees(TEXT);
+
+CREATE INDEX IF NOT EXISTS airport_position_idx ON airport (latitude, longitude);
@@ -0,0 +1,2 @@
+-- Migration: Drop Provider Cache table
+DROP TABLE IF EXISTS provider_cache;
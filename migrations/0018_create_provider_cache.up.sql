@@ -0,0 +1,8 @@
+-- Migration: Create Provider Cache table keeping provider responses for conditional requests
+CREATE TABLE IF NOT EXISTS provider_cache (
+    url TEXT PRIMARY KEY,
+    etag TEXT NOT NULL DEFAULT '',
+    last_modified TEXT NOT NULL DEFAULT '',
+    body BYTEA NOT NULL,
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
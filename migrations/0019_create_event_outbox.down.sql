@@ -0,0 +1,2 @@
+-- Migration: Drop Event Outbox table
+DROP TABLE IF EXISTS event_outbox;
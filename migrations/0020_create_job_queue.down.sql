@@ -0,0 +1,2 @@
+-- Migration: Drop Job Queue table
+DROP TABLE IF EXISTS job_queue;
@@ -0,0 +1,20 @@
+-- Migration: Create Job Queue table holding the syncs requested over the API until a worker has run them
+CREATE TABLE IF NOT EXISTS job_queue (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL DEFAULT 1,
+    run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT NOT NULL DEFAULT '',
+    result JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Claimed by run_at among the jobs still to run
+CREATE INDEX IF NOT EXISTS job_queue_run_at_idx ON job_queue (run_at) WHERE status IN ('queued', 'running');
+
+-- A job asked for again while it is still to run is not queued twice
+CREATE UNIQUE INDEX IF NOT EXISTS job_queue_pending_idx ON job_queue (type, payload) WHERE status IN ('queued', 'running');
@@ -0,0 +1,5 @@
+-- Migration: Drop Airport Change Log table and its triggers
+DROP TRIGGER IF EXISTS airport_change_log_update ON airport;
+DROP TRIGGER IF EXISTS airport_change_log_write ON airport;
+DROP FUNCTION IF EXISTS log_airport_change();
+DROP TABLE IF EXISTS airport_change_log;
@@ -0,0 +1,42 @@
+-- Migration: Create Airport Change Log table recording every airport insert, update and delete for the change feed
+CREATE TABLE IF NOT EXISTS airport_change_log (
+    id BIGSERIAL PRIMARY KEY,
+    -- Transaction of the change; the feed is read in (xid, id) order up to the
+    -- oldest transaction still running, so a change committed late is never
+    -- skipped by a reader that already went past its id
+    xid BIGINT NOT NULL DEFAULT pg_current_xact_id()::text::bigint,
+    op VARCHAR(10) NOT NULL,
+    faa VARCHAR(10) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS airport_change_log_cursor_idx ON airport_change_log (xid, id);
+CREATE INDEX IF NOT EXISTS airport_change_log_created_at_idx ON airport_change_log (created_at);
+
+CREATE OR REPLACE FUNCTION log_airport_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO airport_change_log (op, faa) VALUES ('insert', NEW.faa);
+    ELSIF TG_OP = 'DELETE' THEN
+        INSERT INTO airport_change_log (op, faa) VALUES ('delete', OLD.faa);
+    ELSIF OLD.faa IS DISTINCT FROM NEW.faa THEN
+        -- A renamed airport is gone under its old code
+        INSERT INTO airport_change_log (op, faa) VALUES ('delete', OLD.faa), ('insert', NEW.faa);
+    ELSE
+        INSERT INTO airport_change_log (op, faa) VALUES ('update', NEW.faa);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Fires for every writer, not just this service; updates that change nothing
+-- aren't logged
+DROP TRIGGER IF EXISTS airport_change_log_write ON airport;
+CREATE TRIGGER airport_change_log_write
+    AFTER INSERT OR DELETE ON airport
+    FOR EACH ROW EXECUTE FUNCTION log_airport_change();
+
+DROP TRIGGER IF EXISTS airport_change_log_update ON airport;
+CREATE TRIGGER airport_change_log_update
+    AFTER UPDATE ON airport
+    FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION log_airport_change();
@@ -0,0 +1,2 @@
+-- Migration: Drop manual overrides from Airport table
+ALTER TABLE airport DROP COLUMN IF EXISTS manual_overrides;
@@ -0,0 +1,2 @@
+-- Migration: Add manual overrides to Airport table, the fields edited by hand that syncs leave alone
+ALTER TABLE airport ADD COLUMN IF NOT EXISTS manual_overrides JSONB NOT NULL DEFAULT '[]'::jsonb;
@@ -0,0 +1,2 @@
+-- Migration: Drop Airport Conflicts table
+DROP TABLE IF EXISTS airport_conflicts;
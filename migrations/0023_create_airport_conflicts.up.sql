@@ -0,0 +1,15 @@
+-- Migration: Create Airport Conflicts table holding provider values that disagree with manually overridden fields
+CREATE TABLE IF NOT EXISTS airport_conflicts (
+    id BIGSERIAL PRIMARY KEY,
+    faa VARCHAR(10) NOT NULL REFERENCES airport(faa) ON DELETE CASCADE,
+    field VARCHAR(50) NOT NULL,
+    local_value TEXT NOT NULL DEFAULT '',
+    provider_value TEXT NOT NULL DEFAULT '',
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+-- One pending conflict per field, brought up to date by later syncs
+CREATE UNIQUE INDEX IF NOT EXISTS idx_airport_conflicts_pending ON airport_conflicts (faa, field) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_airport_conflicts_status ON airport_conflicts (status, detected_at);
@@ -0,0 +1,3 @@
+-- Migration: Drop lifecycle status from Airport, and its history
+DROP TABLE IF EXISTS airport_status_history;
+ALTER TABLE airport DROP COLUMN IF EXISTS lifecycle_status;
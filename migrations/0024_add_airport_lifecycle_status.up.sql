@@ -0,0 +1,13 @@
+-- Migration: Add lifecycle status to Airport, and the history of its changes
+ALTER TABLE airport ADD COLUMN IF NOT EXISTS lifecycle_status VARCHAR(20) NOT NULL DEFAULT 'active';
+
+CREATE TABLE IF NOT EXISTS airport_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    faa VARCHAR(10) NOT NULL REFERENCES airport(faa) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_airport_status_history_faa ON airport_status_history (faa, changed_at);
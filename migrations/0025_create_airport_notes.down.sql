@@ -0,0 +1,2 @@
+-- Migration: Drop Airport Notes table
+DROP TABLE IF EXISTS airport_notes;
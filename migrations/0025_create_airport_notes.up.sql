@@ -0,0 +1,11 @@
+-- Migration: Create Airport Notes table holding operational remarks recorded by dispatchers
+CREATE TABLE IF NOT EXISTS airport_notes (
+    id BIGSERIAL PRIMARY KEY,
+    faa VARCHAR(10) NOT NULL REFERENCES airport(faa) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    author VARCHAR(100) NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_airport_notes_faa ON airport_notes (faa, created_at);
@@ -0,0 +1,6 @@
+-- Migration: Drop fuel types and FBO contact from Airport table
+DROP INDEX IF EXISTS idx_airport_fuel_types;
+ALTER TABLE airport
+    DROP COLUMN IF EXISTS fuel_types,
+    DROP COLUMN IF EXISTS fbo_name,
+    DROP COLUMN IF EXISTS fbo_phone;
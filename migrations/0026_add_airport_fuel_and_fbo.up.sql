@@ -0,0 +1,7 @@
+-- Migration: Add fuel types and FBO contact to Airport table, for flight planning
+ALTER TABLE airport
+    ADD COLUMN IF NOT EXISTS fuel_types JSONB NOT NULL DEFAULT '[]'::jsonb,
+    ADD COLUMN IF NOT EXISTS fbo_name VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS fbo_phone VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_airport_fuel_types ON airport USING GIN (fuel_types);
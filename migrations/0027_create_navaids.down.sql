@@ -0,0 +1,2 @@
+-- Migration: Drop Navaids table
+DROP TABLE IF EXISTS navaids;
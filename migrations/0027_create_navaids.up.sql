@@ -0,0 +1,18 @@
+-- Migration: Create Navaids table holding the VOR, NDB and DME stations of the FAA NAVAID file
+CREATE TABLE IF NOT EXISTS navaids (
+    id SERIAL PRIMARY KEY,
+    ident VARCHAR(10) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL DEFAULT '',
+    state_code VARCHAR(10) NOT NULL DEFAULT '',
+    frequency NUMERIC(7, 2),
+    channel VARCHAR(10) NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    elevation_ft NUMERIC(7, 1),
+    status VARCHAR(50) NOT NULL DEFAULT '',
+    UNIQUE (ident, type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_navaids_position ON navaids (latitude, longitude);
@@ -0,0 +1,3 @@
+-- Migration: Drop Airport Charts tables
+DROP TABLE IF EXISTS airport_chart_syncs;
+DROP TABLE IF EXISTS airport_charts;
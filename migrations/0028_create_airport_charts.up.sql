@@ -0,0 +1,19 @@
+-- Migration: Create Airport Charts table linking the airport diagrams and approach plates of each
+-- airport, and the AIRAC cycle each airport's charts were last synced for
+CREATE TABLE IF NOT EXISTS airport_charts (
+    id SERIAL PRIMARY KEY,
+    faa VARCHAR(10) NOT NULL REFERENCES airport(faa) ON DELETE CASCADE,
+    code VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    pdf_url TEXT NOT NULL,
+    cycle VARCHAR(4) NOT NULL,
+    seq INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_airport_charts_faa ON airport_charts (faa, seq);
+
+CREATE TABLE IF NOT EXISTS airport_chart_syncs (
+    faa VARCHAR(10) PRIMARY KEY REFERENCES airport(faa) ON DELETE CASCADE,
+    cycle VARCHAR(4) NOT NULL,
+    synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
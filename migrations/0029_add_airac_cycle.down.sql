@@ -0,0 +1,4 @@
+-- Migration: Drop the AIRAC cycle of runways, frequencies and navaids
+ALTER TABLE navaids DROP COLUMN IF EXISTS cycle;
+ALTER TABLE airport_frequency DROP COLUMN IF EXISTS cycle;
+ALTER TABLE airport_runway DROP COLUMN IF EXISTS cycle;
@@ -0,0 +1,4 @@
+-- Migration: Stamp runways, frequencies and navaids with the AIRAC cycle they were synced for
+ALTER TABLE airport_runway ADD COLUMN IF NOT EXISTS cycle VARCHAR(4) NOT NULL DEFAULT '';
+ALTER TABLE airport_frequency ADD COLUMN IF NOT EXISTS cycle VARCHAR(4) NOT NULL DEFAULT '';
+ALTER TABLE navaids ADD COLUMN IF NOT EXISTS cycle VARCHAR(4) NOT NULL DEFAULT '';
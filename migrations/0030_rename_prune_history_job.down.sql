@@ -0,0 +1,2 @@
+-- Migration: Rename the prune_logs job back to prune_history
+UPDATE scheduler_jobs SET name = 'prune_history' WHERE name = 'prune_logs';
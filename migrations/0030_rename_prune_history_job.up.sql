@@ -0,0 +1,2 @@
+-- Migration: Rename the prune_history job to prune_logs, as it prunes alerts, webhook deliveries and the change feed rather than weather history
+UPDATE scheduler_jobs SET name = 'prune_logs' WHERE name = 'prune_history';
@@ -0,0 +1,2 @@
+-- Migration: Drop NOTAMs table
+DROP TABLE IF EXISTS notams;
@@ -0,0 +1,15 @@
+-- Migration: Create NOTAMs table holding the NOTAMs of the last refresh of each followed airport
+CREATE TABLE IF NOT EXISTS notams (
+    faa VARCHAR(10) NOT NULL REFERENCES airport(faa) ON DELETE CASCADE,
+    id VARCHAR(50) NOT NULL,
+    number VARCHAR(20) NOT NULL DEFAULT '',
+    type VARCHAR(1) NOT NULL DEFAULT '',
+    classification VARCHAR(10) NOT NULL DEFAULT '',
+    issued_at TIMESTAMPTZ,
+    effective_start TIMESTAMPTZ,
+    -- NULL for a permanent NOTAM
+    effective_end TIMESTAMPTZ,
+    text TEXT NOT NULL DEFAULT '',
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (faa, id)
+);
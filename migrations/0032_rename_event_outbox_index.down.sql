@@ -0,0 +1,2 @@
+-- Migration: Rename the event outbox index back
+ALTER INDEX IF EXISTS event_outbox_available_at_idx RENAME TO idx_event_outbox_available_at;
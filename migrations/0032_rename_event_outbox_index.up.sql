@@ -0,0 +1,2 @@
+-- Migration: Rename the event outbox index after the <table>_<columns>_idx pattern of the other indexes
+ALTER INDEX IF EXISTS idx_event_outbox_available_at RENAME TO event_outbox_available_at_idx;
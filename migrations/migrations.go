@@ -0,0 +1,8 @@
+// Package migrations embeds the numbered schema migrations and the seed SQL,
+// so the binaries don't depend on the working directory.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
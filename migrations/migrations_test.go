@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"io/fs"
+	"testing"
+
+	"aviation-weather/internal/migrate"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	loaded, err := migrate.Load(FS)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, loaded)
+	for i, m := range loaded {
+		assert.Equal(t, i+1, m.Version, "Versions have no gaps")
+		assert.NotEmpty(t, m.Down, "Migration %d_%s can be reverted", m.Version, m.Name)
+	}
+
+	_, err = fs.ReadFile(FS, "fill_airport.sql")
+	assert.NoError(t, err, "Seed SQL is embedded")
+}
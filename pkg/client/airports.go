@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MaxBatchFAAs is the most FAA codes the API takes in one ?faa= request;
+// GetAirports pages through longer lists.
+const MaxBatchFAAs = 100
+
+// ListOptions narrows ListAirports.
+type ListOptions struct {
+	// Sort order such as "state,-facility_name", the server's default when
+	// empty
+	Sort string
+	// Fuel type the airports must sell, such as "100LL" or "JetA"
+	Fuel string
+}
+
+// ListAirports lists every airport, or those selling opts.Fuel.
+func (c *Client) ListAirports(ctx context.Context, opts ListOptions) ([]Airport, error) {
+	query := url.Values{}
+	if opts.Sort != "" {
+		query.Set("sort", opts.Sort)
+	}
+	if opts.Fuel != "" {
+		query.Set("fuel", opts.Fuel)
+	}
+	var airports []Airport
+	_, err := c.do(ctx, http.MethodGet, "/airports", query, nil, &airports)
+	return airports, err
+}
+
+// Export formats of ExportAirports.
+const (
+	// FormatCSV has a header row, then a row per airport in the layout the
+	// import accepts
+	FormatCSV = "csv"
+	// FormatKML is a placemark per airport with a position, for Google Earth
+	FormatKML = "kml"
+	// FormatXLSX is an Excel workbook of an Airports and a Weather sheet
+	FormatXLSX = "xlsx"
+)
+
+// ExportAirports writes every airport to w in format, FormatCSV when empty,
+// as streamed by the API.
+func (c *Client) ExportAirports(ctx context.Context, format string, w io.Writer) error {
+	query := url.Values{}
+	if format != "" {
+		query.Set("format", format)
+	}
+	return c.stream(ctx, "/airports/export", query, w)
+}
+
+// StreamAirports calls fn with every airport, as the API streams them one
+// per line, so the whole table is never held in memory. It stops at the first
+// error of fn, which it returns.
+func (c *Client) StreamAirports(ctx context.Context, fn func(Airport) error) error {
+	body, err := c.open(ctx, "/airports/stream", nil, "application/x-ndjson")
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	for {
+		var airport Airport
+		if err := dec.Decode(&airport); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to decode airport: %w", err)
+		}
+		if err := fn(airport); err != nil {
+			return err
+		}
+	}
+}
+
+// GetAirports fetches the airports of faas in the order listed, a page of
+// MaxBatchFAAs codes per request. Unknown codes are left out.
+func (c *Client) GetAirports(ctx context.Context, faas []string) ([]Airport, error) {
+	airports := make([]Airport, 0, len(faas))
+	for start := 0; start < len(faas); start += MaxBatchFAAs {
+		page := faas[start:min(start+MaxBatchFAAs, len(faas))]
+		var found []Airport
+		query := url.Values{"faa": {strings.Join(page, ",")}}
+		if _, err := c.do(ctx, http.MethodGet, "/airports", query, nil, &found); err != nil {
+			return nil, err
+		}
+		airports = append(airports, found...)
+	}
+	return airports, nil
+}
+
+// GetAirport fetches one airport. An unknown FAA code fails with an error
+// IsNotFound recognizes.
+func (c *Client) GetAirport(ctx context.Context, faa string) (*Airport, error) {
+	var airport Airport
+	if _, err := c.do(ctx, http.MethodGet, "/airport/"+url.PathEscape(faa), nil, nil, &airport); err != nil {
+		return nil, err
+	}
+	return &airport, nil
+}
+
+// CreateAirport creates an airport and returns it as stored.
+func (c *Client) CreateAirport(ctx context.Context, airport AirportInput) (*Airport, error) {
+	var created Airport
+	if _, err := c.do(ctx, http.MethodPost, "/airport", nil, airport, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// CreateAirports creates airports in one transaction, skipping the FAA codes
+// that already exist.
+func (c *Client) CreateAirports(ctx context.Context, airports []AirportInput) (*BulkCreateReport, error) {
+	var report BulkCreateReport
+	if _, err := c.do(ctx, http.MethodPost, "/airports", nil, airports, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// UpdateAirport replaces the airport of airport.Faa.
+func (c *Client) UpdateAirport(ctx context.Context, airport AirportInput) (*Airport, error) {
+	var updated Airport
+	if _, err := c.do(ctx, http.MethodPut, "/airport", nil, airport, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// FuelInput is the fuel and FBO of an airport as UpdateAirportFuel sets them.
+type FuelInput struct {
+	FuelTypes []string `json:"fuel_types"`
+	FboName   string   `json:"fbo_name"`
+	FboPhone  string   `json:"fbo_phone"`
+}
+
+// UpdateAirportFuel sets the fuel types and FBO of an airport by hand; later
+// syncs keep the values changed.
+func (c *Client) UpdateAirportFuel(ctx context.Context, faa string, fuel FuelInput) (*Airport, error) {
+	var updated Airport
+	if _, err := c.do(ctx, http.MethodPut, "/airport/"+url.PathEscape(faa)+"/fuel", nil, fuel, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteAirport deletes an airport.
+func (c *Client) DeleteAirport(ctx context.Context, faa string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/airport/"+url.PathEscape(faa), nil, nil, nil)
+	return err
+}
+
+// AirportWeather fetches the weather of an airport as of its last sync, or
+// live from the providers with refresh.
+func (c *Client) AirportWeather(ctx context.Context, faa string, refresh bool) (*AirportWeather, error) {
+	query := url.Values{}
+	if refresh {
+		query.Set("refresh", strconv.FormatBool(refresh))
+	}
+	var weather AirportWeather
+	if _, err := c.do(ctx, http.MethodGet, "/airport/"+url.PathEscape(faa)+"/weather", query, nil, &weather); err != nil {
+		return nil, err
+	}
+	return &weather, nil
+}
+
+// Weather fetches the current weather of a city, which isn't stored.
+func (c *Client) Weather(ctx context.Context, city string) (*Observation, error) {
+	var obs Observation
+	if _, err := c.do(ctx, http.MethodGet, "/weather", url.Values{"city": {city}}, nil, &obs); err != nil {
+		return nil, err
+	}
+	return &obs, nil
+}
+
+// Stats counts the airports by state, ownership and flight category.
+func (c *Client) Stats(ctx context.Context) (*AirportStats, error) {
+	var stats AirportStats
+	if _, err := c.do(ctx, http.MethodGet, "/stats", nil, nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Operations of a Change.
+const (
+	ChangeInsert = "insert"
+	ChangeUpdate = "update"
+	ChangeDelete = "delete"
+)
+
+// Change is one write to an airport, by a sync, an edit or any other writer of
+// the database. It only names the airport; fetch it for the new values.
+type Change struct {
+	Op  string `json:"op"`
+	Faa string `json:"faa_ident"`
+}
+
+// WatchChanges streams airport changes to fn as they happen, until ctx is
+// done, the server ends the stream or fn returns an error, which it returns.
+// The stream is long-lived, so the timeout of the HTTP client doesn't apply
+// to it. Changes made while no stream is open are not replayed.
+func (c *Client) WatchChanges(ctx context.Context, fn func(Change) error) error {
+	body, err := c.open(ctx, "/airports/changes", nil, "text/event-stream")
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// Events are "field: value" lines ended by a blank line; comments, such
+	// as the keepalives, start with a colon
+	var data strings.Builder
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" {
+			if value, ok := strings.CutPrefix(line, "data:"); ok {
+				data.WriteString(strings.TrimPrefix(value, " "))
+			}
+			continue
+		}
+		if data.Len() == 0 {
+			continue
+		}
+		var change Change
+		if err := json.Unmarshal([]byte(data.String()), &change); err != nil {
+			return fmt.Errorf("failed to decode change: %w", err)
+		}
+		data.Reset()
+		if err := fn(change); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read changes: %w", err)
+	}
+	return ctx.Err()
+}
+
+// FeedChange is a change of the change feed. Cursor resumes the feed right
+// after it.
+type FeedChange struct {
+	Cursor    string    `json:"cursor"`
+	Op        string    `json:"op"`
+	Faa       string    `json:"faa_ident"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// ChangeFeed is a page of the change feed. Pass Cursor to the next call;
+// HasMore tells a next page is ready.
+type ChangeFeed struct {
+	Changes []FeedChange `json:"changes"`
+	Cursor  string       `json:"cursor"`
+	HasMore bool         `json:"has_more"`
+}
+
+// Changes returns up to limit airport changes after the cursor since, in
+// order, from the oldest kept when since is empty. A limit of 0 takes the
+// server's default. Unlike WatchChanges, no change is missed between calls.
+func (c *Client) Changes(ctx context.Context, since string, limit int) (*ChangeFeed, error) {
+	query := url.Values{}
+	if since != "" {
+		query.Set("since", since)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	var feed ChangeFeed
+	if _, err := c.do(ctx, http.MethodGet, "/changes", query, nil, &feed); err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
@@ -0,0 +1,270 @@
+// Package client is a typed Go client of the Aviation Weather API, for other
+// services to call it without building requests by hand. It speaks the /v1
+// API, unwraps the status/message/data envelope of every answer and retries
+// the requests that failed before the server ran them.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 500 * time.Millisecond
+	maxRetryBackoff     = 30 * time.Second
+)
+
+// Client calls the API at BaseURL. Its zero value is not usable; create one
+// with New.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	adminToken string
+	httpClient *http.Client
+
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithAPIKey sends key as X-API-Key, acting for its tenant.
+func WithAPIKey(key string) Option {
+	return func(c *Client) { c.apiKey = key }
+}
+
+// WithAdminToken sends token as the bearer token the admin endpoints require.
+func WithAdminToken(token string) Option {
+	return func(c *Client) { c.adminToken = token }
+}
+
+// WithHTTPClient replaces the default client, which times out after 30s.
+// Full syncs take longer, so their caller usually passes a context deadline
+// and a client without a timeout instead.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithRetries retries a failed request up to maxRetries times, waiting backoff
+// before the first retry and doubling it after each. 0 disables retries.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) { c.maxRetries, c.retryBackoff = maxRetries, backoff }
+}
+
+// New creates a client of the API at baseURL, such as
+// "http://aviation-weather:8080".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		httpClient:   &http.Client{Timeout: defaultTimeout},
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Error is an answer of the API outside 2xx.
+type Error struct {
+	StatusCode int
+	Message    string
+	// Machine-readable code such as "not_found" or "validation_failed", empty
+	// for the errors that have none
+	Code string
+	// Detail of the error, such as the field errors of a failed validation
+	Data json.RawMessage
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("aviation-weather: %d %s (%s)", e.StatusCode, e.Message, e.Code)
+	}
+	return fmt.Sprintf("aviation-weather: %d %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is an answer of 404, such as that of an
+// unknown airport.
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// envelope is the body of every answer.
+type envelope struct {
+	Status    string          `json:"status"`
+	Message   string          `json:"message"`
+	ErrorCode string          `json:"error_code"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// do sends a request and decodes the data of its answer into out, unless out
+// is nil, returning the message of the answer.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) (string, error) {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return "", fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+	apiURL := c.baseURL + "/v1" + path
+	if len(query) > 0 {
+		apiURL += "?" + query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		env, retryAfter, err := c.send(ctx, method, apiURL, body)
+		if err == nil {
+			if out != nil && len(env.Data) > 0 && string(env.Data) != "null" {
+				if err := json.Unmarshal(env.Data, out); err != nil {
+					return "", fmt.Errorf("failed to decode %s %s: %w", method, path, err)
+				}
+			}
+			return env.Message, nil
+		}
+		if attempt >= c.maxRetries || !retryable(method, err) {
+			return "", err
+		}
+
+		wait := min(c.retryBackoff<<attempt, maxRetryBackoff)
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// stream sends a GET once and copies the body of a 2xx answer to w, as is.
+// Since part of it may already be written, a failed stream isn't retried.
+func (c *Client) stream(ctx context.Context, path string, query url.Values, w io.Writer) error {
+	body, err := c.open(ctx, path, query, "")
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if _, err := io.Copy(w, body); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
+// open sends a GET once and returns the body of a 2xx answer, for the caller
+// to read and close. Streams last as long as they need, so the timeout of the
+// HTTP client doesn't apply; ctx ends them.
+func (c *Client) open(ctx context.Context, path string, query url.Values, accept string) (io.ReadCloser, error) {
+	apiURL := c.baseURL + "/v1" + path
+	if len(query) > 0 {
+		apiURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	c.authorize(req)
+
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		var env envelope
+		_ = json.NewDecoder(resp.Body).Decode(&env)
+		return nil, newError(resp.StatusCode, env)
+	}
+	return resp.Body, nil
+}
+
+func (c *Client) authorize(req *http.Request) {
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	if c.adminToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.adminToken)
+	}
+}
+
+func newError(statusCode int, env envelope) *Error {
+	apiErr := &Error{StatusCode: statusCode, Message: env.Message, Code: env.ErrorCode, Data: env.Data}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(statusCode)
+	}
+	return apiErr
+}
+
+// send makes one attempt, returning how long the server asked to wait before
+// the next one when it did.
+func (c *Client) send(ctx context.Context, method, apiURL string, body []byte) (*envelope, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.authorize(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read answer: %w", err)
+	}
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil && resp.StatusCode < 300 {
+		return nil, 0, fmt.Errorf("failed to decode answer: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return nil, time.Duration(retryAfter) * time.Second, newError(resp.StatusCode, env)
+	}
+	return &env, 0, nil
+}
+
+// retryable tells whether a failed request may be sent again. Any request
+// rejected by the rate limit never ran; the other failures are only retried
+// for the methods that can run twice.
+func retryable(method string, err error) bool {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode == http.StatusTooManyRequests {
+			return true
+		}
+		if apiErr.StatusCode != http.StatusBadGateway && apiErr.StatusCode != http.StatusServiceUnavailable &&
+			apiErr.StatusCode != http.StatusGatewayTimeout {
+			return false
+		}
+	} else if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete
+}
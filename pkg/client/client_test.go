@@ -0,0 +1,222 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aviation-weather/config"
+	"aviation-weather/internal/domain"
+	"aviation-weather/internal/handler"
+	mocks "aviation-weather/internal/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newServer serves the real router over a mocked service, so the client is
+// checked against the API as it is.
+func newServer(t *testing.T, svc *mocks.ServiceMock) *Client {
+	server := httptest.NewServer(handler.NewHandler(svc, &config.Config{}).Router())
+	t.Cleanup(server.Close)
+	return New(server.URL, WithRetries(0, 0))
+}
+
+func TestAirports(t *testing.T) {
+	ctx := context.Background()
+	svc := &mocks.ServiceMock{}
+	svc.On("GetAirportByFAA", "JFK").Return(&domain.Airport{Faa: "JFK", FacilityName: "JOHN F KENNEDY INTL"}, nil)
+	svc.On("GetAirportNotes", "JFK").Return([]domain.AirportNote{{ID: 1, Faa: "JFK", Body: "Runway 4L/22R closed"}}, nil)
+	svc.On("GetAirportByFAA", "XYZ").Return((*domain.Airport)(nil), domain.ErrNotFound)
+	svc.On("CreateAirport", mock.MatchedBy(func(a *domain.Airport) bool { return a.Faa == "TST" && *a.Latitude == 40.5 })).Return(nil)
+	svc.On("DeleteAirportByFAA", "TST").Return(nil)
+	c := newServer(t, svc)
+
+	airport, err := c.GetAirport(ctx, "JFK")
+	assert.NoError(t, err)
+	assert.Equal(t, "JOHN F KENNEDY INTL", airport.FacilityName)
+	assert.Equal(t, []Note{{ID: 1, Faa: "JFK", Body: "Runway 4L/22R closed"}}, airport.Notes)
+
+	_, err = c.GetAirport(ctx, "XYZ")
+	assert.True(t, IsNotFound(err))
+	assert.EqualError(t, err, "aviation-weather: 404 Airport Not Found (not_found)")
+
+	latitude := 40.5
+	created, err := c.CreateAirport(ctx, AirportInput{Faa: "TST", FacilityName: "Test", Latitude: &latitude})
+	assert.NoError(t, err)
+	assert.Equal(t, "TST", created.Faa)
+
+	_, err = c.CreateAirport(ctx, AirportInput{FacilityName: "No code"})
+	assert.EqualError(t, err, "aviation-weather: 400 Missing FAA Value")
+
+	assert.NoError(t, c.DeleteAirport(ctx, "TST"))
+	svc.AssertExpectations(t)
+}
+
+func TestExportAirports(t *testing.T) {
+	svc := &mocks.ServiceMock{}
+	svc.On("ForEachAirport", mock.Anything).Return([]domain.Airport{{Faa: "JFK", FacilityName: "JOHN F KENNEDY INTL"}}, nil).Once()
+	svc.On("ForEachAirport", mock.Anything).Return([]domain.Airport{}, errors.New("db down")).Once()
+	c := newServer(t, svc)
+
+	var csv strings.Builder
+	assert.NoError(t, c.ExportAirports(context.Background(), "", &csv))
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.True(t, strings.HasPrefix(lines[0], "site_number,facility_name,faa_ident,"), lines[0])
+		assert.Contains(t, lines[1], "JOHN F KENNEDY INTL")
+	}
+
+	assert.EqualError(t, c.ExportAirports(context.Background(), FormatKML, io.Discard), "aviation-weather: 500 Service Error (internal_error)")
+}
+
+func TestGetAirportsPages(t *testing.T) {
+	faas := make([]string, MaxBatchFAAs+1)
+	for i := range faas {
+		faas[i] = fmt.Sprintf("A%02d", i)
+	}
+	svc := &mocks.ServiceMock{}
+	svc.On("GetAirportsByFAAs", faas[:MaxBatchFAAs]).Return([]domain.Airport{{Faa: "A00"}}, nil).Once()
+	svc.On("GetAirportsByFAAs", faas[MaxBatchFAAs:]).Return([]domain.Airport{{Faa: faas[MaxBatchFAAs]}}, nil).Once()
+	c := newServer(t, svc)
+
+	airports, err := c.GetAirports(context.Background(), faas)
+	assert.NoError(t, err)
+	assert.Len(t, airports, 2)
+	svc.AssertExpectations(t)
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	svc := &mocks.ServiceMock{}
+	svc.On("SyncAirportQueued", "JFK").Return(&domain.SyncResult{Airport: &domain.Airport{Faa: "JFK"}, ChangedFields: []string{"weather"}}, nil)
+	svc.On("SyncAirportsByFAAs", mock.Anything, []string{"JFK", "LAX"}).Return(2, nil)
+	svc.On("SyncAllAirportsQueued", mock.Anything).Return(0, domain.ErrNotFound)
+	c := newServer(t, svc)
+
+	result, err := c.SyncAirport(ctx, "JFK")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"weather"}, result.ChangedFields)
+
+	updated, err := c.SyncAirports(ctx, []string{"JFK", "LAX"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated)
+
+	_, err = c.SyncAll(ctx)
+	assert.EqualError(t, err, "aviation-weather: No Airport to Sync")
+	svc.AssertExpectations(t)
+}
+
+func TestRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-API-Key"))
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"status":"OK","message":"Stats are Fetched","data":{"total_airports":3}}`)
+	}))
+	defer server.Close()
+	c := New(server.URL, WithAPIKey("secret"), WithRetries(3, time.Millisecond))
+
+	stats, err := c.Stats(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.TotalAirports)
+	assert.Equal(t, int32(3), calls.Load())
+
+	// A sync that failed on the server may have run, so it isn't sent again
+	calls.Store(0)
+	_, err = c.SyncAll(context.Background())
+	assert.EqualError(t, err, "aviation-weather: 503 Service Unavailable")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetriesRateLimited(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"status":"OK","message":"Airport is Synced","data":{"airport":{"faa_ident":"JFK"}}}`)
+	}))
+	defer server.Close()
+	c := New(strings.TrimSuffix(server.URL, "/")+"/", WithRetries(1, time.Millisecond))
+
+	start := time.Now()
+	result, err := c.SyncAirport(context.Background(), "JFK")
+	assert.NoError(t, err)
+	assert.Equal(t, "JFK", result.Airport.Faa)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second, "Retry-After is honored")
+}
+
+func TestWatchChanges(t *testing.T) {
+	changes := make(chan domain.AirportChange, 2)
+	changes <- domain.AirportChange{Op: domain.ChangeUpdate, Faa: "DEN"}
+	changes <- domain.AirportChange{Op: domain.ChangeDelete, Faa: "JFK"}
+	close(changes)
+	svc := &mocks.ServiceMock{}
+	svc.On("SubscribeAirportChanges").Return((<-chan domain.AirportChange)(changes), func() {})
+	c := newServer(t, svc)
+
+	var got []Change
+	err := c.WatchChanges(context.Background(), func(change Change) error {
+		got = append(got, change)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{{Op: ChangeUpdate, Faa: "DEN"}, {Op: ChangeDelete, Faa: "JFK"}}, got)
+
+	// An error of fn ends a stream the server keeps open
+	open := make(chan domain.AirportChange, 1)
+	open <- domain.AirportChange{Op: domain.ChangeInsert, Faa: "TST"}
+	svc = &mocks.ServiceMock{}
+	svc.On("SubscribeAirportChanges").Return((<-chan domain.AirportChange)(open), func() {})
+	stop := errors.New("stop")
+	err = newServer(t, svc).WatchChanges(context.Background(), func(Change) error { return stop })
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestStreamAirports(t *testing.T) {
+	svc := &mocks.ServiceMock{}
+	svc.On("ForEachAirport", mock.Anything).Return([]domain.Airport{{Faa: "DEN"}, {Faa: "JFK"}, {Faa: "LAX"}}, nil)
+	c := newServer(t, svc)
+
+	var faas []string
+	err := c.StreamAirports(context.Background(), func(airport Airport) error {
+		faas = append(faas, airport.Faa)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"DEN", "JFK", "LAX"}, faas)
+
+	stop := errors.New("stop")
+	err = c.StreamAirports(context.Background(), func(Airport) error { return stop })
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestChanges(t *testing.T) {
+	svc := &mocks.ServiceMock{}
+	svc.On("GetChangeFeed", domain.ChangeCursor{TxID: 7500, ID: 40}, 2).Return(&domain.ChangeFeed{
+		Changes: []domain.ChangeLogEntry{{Cursor: domain.ChangeCursor{TxID: 7521, ID: 42}, Op: domain.ChangeDelete, Faa: "TST"}},
+		Cursor:  domain.ChangeCursor{TxID: 7521, ID: 42},
+	}, nil)
+	c := newServer(t, svc)
+
+	feed, err := c.Changes(context.Background(), "7500-40", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "7521-42", feed.Cursor)
+	assert.False(t, feed.HasMore)
+	if assert.Len(t, feed.Changes, 1) {
+		assert.Equal(t, FeedChange{Cursor: "7521-42", Op: ChangeDelete, Faa: "TST", ChangedAt: feed.Changes[0].ChangedAt}, feed.Changes[0])
+	}
+}
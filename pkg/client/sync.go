@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// SyncAirport refreshes one airport from the providers and reports what
+// changed.
+func (c *Client) SyncAirport(ctx context.Context, faa string) (*SyncResult, error) {
+	var result SyncResult
+	if _, err := c.do(ctx, http.MethodPost, "/sync/"+url.PathEscape(faa), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SyncAll refreshes every airport and returns how many were updated. It runs
+// as long as the sync, so ctx should allow for it.
+func (c *Client) SyncAll(ctx context.Context) (int, error) {
+	return c.syncMany(ctx, "/sync", nil, nil)
+}
+
+// SyncState refreshes the airports of a state.
+func (c *Client) SyncState(ctx context.Context, state string) (int, error) {
+	return c.syncMany(ctx, "/sync", url.Values{"state": {state}}, nil)
+}
+
+// SyncAirports refreshes the airports of faas. Unknown codes are ignored.
+func (c *Client) SyncAirports(ctx context.Context, faas []string) (int, error) {
+	if len(faas) == 0 {
+		return 0, fmt.Errorf("no FAA code to sync")
+	}
+	return c.syncMany(ctx, "/sync", nil, faas)
+}
+
+// RetryFailed syncs again the airports whose last sync failed, once their
+// retry backoff has passed.
+func (c *Client) RetryFailed(ctx context.Context) (int, error) {
+	return c.syncMany(ctx, "/sync/retry-failed", nil, nil)
+}
+
+// syncMany runs a sync of several airports. The API only reports their count
+// in its message, "N Airports are Synced".
+func (c *Client) syncMany(ctx context.Context, path string, query url.Values, faas []string) (int, error) {
+	var body any
+	if faas != nil {
+		body = faas
+	}
+	message, err := c.do(ctx, http.MethodPost, path, query, body, nil)
+	if err != nil {
+		return 0, err
+	}
+	var updated int
+	if _, err := fmt.Sscanf(message, "%d Airports are Synced", &updated); err != nil {
+		// Answered with 200 when there was nothing to sync
+		return 0, fmt.Errorf("aviation-weather: %s", message)
+	}
+	return updated, nil
+}
@@ -0,0 +1,150 @@
+package client
+
+import "time"
+
+// Airport is an airport as the API returns it.
+type Airport struct {
+	SiteNumber    string `json:"site_number"`
+	FacilityName  string `json:"facility_name"`
+	Faa           string `json:"faa_ident"`
+	Icao          string `json:"icao_ident"`
+	StateCode     string `json:"state"`
+	StateFull     string `json:"state_full"`
+	County        string `json:"county"`
+	City          string `json:"city"`
+	OwnershipType string `json:"ownership"`
+	UseType       string `json:"use"`
+	Manager       string `json:"manager"`
+	ManagerPhone  string `json:"manager_phone"`
+	AirportStatus string `json:"status"`
+	Weather       string `json:"weather"`
+
+	// Position in decimal degrees, nil when unknown
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+
+	ElevationFt        *float64 `json:"elevation_ft,omitempty"`
+	PressureAltitudeFt *int     `json:"pressure_altitude_ft,omitempty"`
+	DensityAltitudeFt  *int     `json:"density_altitude_ft,omitempty"`
+	MagneticVariation  *float64 `json:"magnetic_variation,omitempty"`
+	Timezone           string   `json:"timezone,omitempty"`
+
+	// Fuel types sold, such as "100LL" and "JetA", and the FBO to call
+	FuelTypes []string `json:"fuel_types,omitempty"`
+	FboName   string   `json:"fbo_name,omitempty"`
+	FboPhone  string   `json:"fbo_phone,omitempty"`
+
+	// Fields edited by hand, which syncs leave alone
+	ManualOverrides []string `json:"manual_overrides,omitempty"`
+
+	// active, closed, seasonal or decommissioned
+	LifecycleStatus string `json:"lifecycle_status,omitempty"`
+
+	// Operational notes, only filled in by GetAirport
+	Notes []Note `json:"notes,omitempty"`
+
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
+}
+
+// Note is an operational remark recorded on an airport.
+type Note struct {
+	ID        int64      `json:"id"`
+	Faa       string     `json:"faa_ident"`
+	Body      string     `json:"body"`
+	Author    string     `json:"author,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// AirportInput is an airport to create or update. Faa is required.
+type AirportInput struct {
+	SiteNumber        string   `json:"site_number"`
+	FacilityName      string   `json:"facility_name"`
+	Faa               string   `json:"faa_ident"`
+	Icao              string   `json:"icao_ident"`
+	StateCode         string   `json:"state"`
+	StateFull         string   `json:"state_full"`
+	County            string   `json:"county"`
+	City              string   `json:"city"`
+	OwnershipType     string   `json:"ownership"`
+	UseType           string   `json:"use"`
+	Manager           string   `json:"manager"`
+	ManagerPhone      string   `json:"manager_phone"`
+	AirportStatus     string   `json:"status"`
+	Weather           string   `json:"weather"`
+	Latitude          *float64 `json:"latitude"`
+	Longitude         *float64 `json:"longitude"`
+	ElevationFt       *float64 `json:"elevation_ft"`
+	MagneticVariation *float64 `json:"magnetic_variation"`
+	// On update, the fields syncs must leave alone; nil adds those the update
+	// changes to the stored ones, and an empty list clears them
+	ManualOverrides []string `json:"manual_overrides"`
+}
+
+// BulkCreateReport tells which airports of a bulk create were created, which
+// already existed and which were invalid.
+type BulkCreateReport struct {
+	Created []string   `json:"created"`
+	Skipped []string   `json:"skipped"`
+	Failed  []RowError `json:"failed"`
+}
+
+// RowError is an airport of a bulk request that was rejected, by its 1-based
+// position.
+type RowError struct {
+	Row   int    `json:"row"`
+	Faa   string `json:"faa_ident"`
+	Error string `json:"error"`
+}
+
+// FieldChange is one airport field a sync changed.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// SyncResult is a synced airport together with what the sync changed.
+type SyncResult struct {
+	Airport       *Airport      `json:"airport"`
+	ChangedFields []string      `json:"changed_fields"`
+	Changes       []FieldChange `json:"changes"`
+}
+
+// Observation is a weather report. Units follow the ?units= of the request,
+// metric knots and statute miles by default.
+type Observation struct {
+	Provider     string    `json:"provider"`
+	Condition    string    `json:"condition"`
+	TemperatureC float64   `json:"temperature_c"`
+	DewpointC    float64   `json:"dewpoint_c"`
+	HumidityPct  float64   `json:"humidity_pct"`
+	WindDirDeg   int       `json:"wind_dir_deg"`
+	WindSpeedKt  float64   `json:"wind_speed_kt"`
+	WindGustKt   float64   `json:"wind_gust_kt"`
+	VisibilitySM float64   `json:"visibility_sm"`
+	PressureHpa  float64   `json:"pressure_hpa"`
+	RawMETAR     string    `json:"raw_metar,omitempty"`
+	ObservedAt   time.Time `json:"observed_at"`
+}
+
+// AirportWeather is the weather of an airport as of its last sync.
+type AirportWeather struct {
+	Faa            string       `json:"faa_ident"`
+	Condition      string       `json:"weather"`
+	FlightCategory string       `json:"flight_category,omitempty"`
+	Observation    *Observation `json:"observation"`
+	LastSyncedAt   *time.Time   `json:"last_synced_at,omitempty"`
+}
+
+// AirportStats counts the stored airports.
+type AirportStats struct {
+	TotalAirports    int            `json:"total_airports"`
+	ByState          map[string]int `json:"by_state"`
+	ByOwnership      map[string]int `json:"by_ownership"`
+	ByFlightCategory map[string]int `json:"by_flight_category"`
+	StaleWeather     int            `json:"stale_weather"`
+	StaleAfter       string         `json:"stale_after"`
+}